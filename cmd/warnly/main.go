@@ -20,25 +20,30 @@ import (
 	"github.com/coreos/go-oidc/v3/oidc"
 	capoidc "github.com/hashicorp/cap/oidc"
 	"github.com/ilyakaznacheev/cleanenv"
-	"github.com/microcosm-cc/bluemonday"
 	"github.com/patrickmn/go-cache"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/vk-rv/warnly/internal/ch"
 	"github.com/vk-rv/warnly/internal/chprometheus"
+	"github.com/vk-rv/warnly/internal/filestore"
+	"github.com/vk-rv/warnly/internal/httpclient"
 	"github.com/vk-rv/warnly/internal/kafka"
 	"github.com/vk-rv/warnly/internal/migrator"
 	"github.com/vk-rv/warnly/internal/mysql"
 	"github.com/vk-rv/warnly/internal/notifier"
+	"github.com/vk-rv/warnly/internal/s3"
 	"github.com/vk-rv/warnly/internal/server"
 	sessionstore "github.com/vk-rv/warnly/internal/session"
 	"github.com/vk-rv/warnly/internal/stdlog"
 	"github.com/vk-rv/warnly/internal/svc/alert"
+	"github.com/vk-rv/warnly/internal/svc/assignment"
+	"github.com/vk-rv/warnly/internal/svc/attachment"
 	"github.com/vk-rv/warnly/internal/svc/event"
 	"github.com/vk-rv/warnly/internal/svc/initializer"
 	"github.com/vk-rv/warnly/internal/svc/notification"
 	"github.com/vk-rv/warnly/internal/svc/project"
+	"github.com/vk-rv/warnly/internal/svc/regroup"
 	"github.com/vk-rv/warnly/internal/svc/session"
 	"github.com/vk-rv/warnly/internal/svc/system"
 	"github.com/vk-rv/warnly/internal/svcotel"
@@ -199,11 +204,16 @@ func run(cfg *config, logger *slog.Logger) error {
 	issueStore := mysql.NewIssueStore(db)
 	messageStore := mysql.NewMessageStore(db)
 	mentionStore := mysql.NewMentionStore(db)
+	activityStore := mysql.NewActivityStore(db)
+	subscriptionStore := mysql.NewSubscriptionStore(db)
+	labelStore := mysql.NewIssueLabelStore(db)
+	seenStore := mysql.NewSeenStore(db)
 	assingmentStore := mysql.NewAssingmentStore(db)
 	alertStore := mysql.NewAlertStore(db)
 	notificationStore := mysql.NewNotificationStore(db)
 
 	olap := ch.NewClickhouseStore(clickConn, tracingProvider)
+	olap.RegisterMetrics(reg)
 
 	regCollectors := []prometheus.Collector{
 		collectors.NewGoCollector(),
@@ -218,7 +228,10 @@ func run(cfg *config, logger *slog.Logger) error {
 
 	startUOW := mysql.NewUOW(db, logger.With(slog.String("service", "uow")))
 
-	sanitizerPolicy := bluemonday.UGCPolicy()
+	sanitizerPolicy, err := project.NewSanitizerPolicy(cfg.SanitizerPolicy)
+	if err != nil {
+		return err
+	}
 
 	now := time.Now
 
@@ -235,27 +248,78 @@ func run(cfg *config, logger *slog.Logger) error {
 		publicBaseURL = u.Host
 	}
 
-	sessionService := session.NewSessionService(sessionStore, userStore, teamStore, startUOW, now)
-	projectService := project.NewProjectService(
-		projectStore,
-		assingmentStore,
+	var invitationSender warnly.InvitationSender
+	if cfg.SMTP.Addr != "" {
+		invitationSender = notifier.NewSMTPMailer(notifier.SMTPConfig{
+			Addr:     cfg.SMTP.Addr,
+			From:     cfg.SMTP.From,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+		})
+	}
+
+	sessionService := session.NewSessionService(
+		sessionStore,
+		userStore,
 		teamStore,
-		issueStore,
-		messageStore,
-		mentionStore,
-		olap,
+		mysql.NewInvitationStore(db),
 		startUOW,
-		sanitizerPolicy,
-		net.JoinHostPort(cfg.Server.Host, cfg.Server.Port),
-		cfg.Server.Scheme,
-		publicBaseURL,
-		publicScheme,
+		session.InvitationOptions{
+			Sender:  invitationSender,
+			BaseURL: publicScheme + "://" + publicBaseURL,
+			Key:     cfg.SessionKey,
+			TTL:     cfg.InvitationTTL,
+		},
 		now,
-		logger.With(slog.String("service", "project")))
+	)
 	systemService := system.NewSystemService(olap, now, logger.With(slog.String("service", "system")))
 
 	memoryCache := cache.New(5*time.Minute, 10*time.Minute)
 
+	var autoAssigner warnly.IssueAutoAssigner
+	switch cfg.AutoAssignStrategy {
+	case "":
+	case assignment.StrategyRoundRobin:
+		autoAssigner = assignment.NewAutoAssigner(
+			assingmentStore,
+			now,
+			logger.With(slog.String("service", "auto_assign")),
+			assignment.NewRoundRobin(teamStore, assingmentStore),
+		)
+	default:
+		return fmt.Errorf("unknown auto assign strategy %q", cfg.AutoAssignStrategy)
+	}
+
+	outboundClient, err := httpclient.New(&cfg.OutboundHTTP)
+	if err != nil {
+		return fmt.Errorf("create outbound http client: %w", err)
+	}
+
+	var attachmentStore warnly.AttachmentStore
+	switch cfg.AttachmentStorage {
+	case "local":
+		if cfg.AttachmentDir != "" {
+			objectStore, err := filestore.NewObjectStore(cfg.AttachmentDir)
+			if err != nil {
+				return fmt.Errorf("create attachment store: %w", err)
+			}
+			defer func() {
+				if err := objectStore.Close(); err != nil {
+					logger.Error("close attachment store", slog.Any("error", err))
+				}
+			}()
+			attachmentStore = filestore.NewAttachmentStore(objectStore)
+		}
+	case "s3":
+		objectStore, err := s3.NewObjectStore(cfg.S3, outboundClient)
+		if err != nil {
+			return fmt.Errorf("create attachment store: %w", err)
+		}
+		attachmentStore = filestore.NewAttachmentStore(objectStore)
+	default:
+		return fmt.Errorf("unknown attachment storage %q", cfg.AttachmentStorage)
+	}
+
 	eventService := event.NewEventService(
 		projectStore,
 		issueStore,
@@ -265,19 +329,47 @@ func run(cfg *config, logger *slog.Logger) error {
 			Enabled:  len(cfg.Kafka.Brokers) > 0,
 			Producer: kafkaProducer,
 		},
-		now)
+		autoAssigner,
+		attachmentStore,
+		now,
+		logger.With(slog.String("service", "event")))
+	eventService.ConfirmStores(event.Confirmation{
+		Rate:      cfg.IngestConfirmRate,
+		NewIssues: cfg.IngestConfirmNewIssues,
+	})
+	eventService.DedupEventIDs(event.Idempotency{
+		Window:       cfg.IngestIdempotencyWindow,
+		VerifyStored: cfg.IngestIdempotencyVerifyStored,
+	})
+	eventService.RejectUnknownProjects(event.UnknownProjects{
+		CacheTTL: cfg.IngestUnknownProjectTTL,
+		Grace:    cfg.IngestNewProjectGrace,
+	})
+
+	attachmentService := attachment.NewAttachmentService(attachmentStore, projectStore, teamStore)
+
+	regroupService := regroup.NewRegroupService(
+		projectStore,
+		teamStore,
+		issueStore,
+		olap,
+		cfg.RegroupBatchSize,
+		now,
+		logger.With(slog.String("service", "regroup")),
+	)
+	defer regroupService.Stop()
 
 	alertService := alert.NewAlertService(alertStore, projectStore, teamStore, now, logger.With(slog.String("service", "alert")))
 
 	webhookNotifier := notifier.NewWebhookNotifier(
 		notificationStore,
 		cfg.NotificationEncryptionKey,
-		&http.Client{
-			Timeout: 10 * time.Second,
-		},
+		outboundClient,
+		webhookRetryPolicy(cfg.WebhookMaxAttempts),
 		now,
 		logger.With(slog.String("service", "webhook_notifier")),
 	)
+	webhookNotifier.LimitConcurrency(cfg.MaxConcurrentNotificationsPerDestination)
 
 	notificationService := notification.NewNotificationService(
 		notificationStore,
@@ -286,22 +378,126 @@ func run(cfg *config, logger *slog.Logger) error {
 		now,
 		logger.With(slog.String("service", "notification")),
 	)
+	eventService.NotifyRegressions(notificationService)
+	eventService.NotifySubscribers(event.Subscribers{
+		Subscriptions: subscriptionStore,
+		Teams:         teamStore,
+	})
+
+	if _, err := warnly.ParseDuration(cfg.IssuesDefaultPeriod); err != nil {
+		return fmt.Errorf("parse issues default period: %w", err)
+	}
+
+	projectService := project.NewProjectService(
+		projectStore,
+		assingmentStore,
+		teamStore,
+		issueStore,
+		messageStore,
+		mentionStore,
+		activityStore,
+		subscriptionStore,
+		labelStore,
+		seenStore,
+		olap,
+		notificationService,
+		startUOW,
+		sanitizerPolicy,
+		net.JoinHostPort(cfg.Server.Host, cfg.Server.Port),
+		cfg.Server.Scheme,
+		publicBaseURL,
+		publicScheme,
+		project.Options{
+			IssuesPeriod: cfg.IssuesDefaultPeriod,
+			PageSize:     cfg.ProjectIssuesPageSize,
+			MaxIssues:    cfg.IssuesMaxPerPage,
+
+			DeletionGracePeriod: cfg.ProjectDeletionGracePeriod,
+			NewIssueWindow:      cfg.NewIssueWindow,
+			VisibleFrames:       cfg.StackVisibleFrames,
+			HotnessHalfLife:     cfg.HotnessHalfLife,
+			ReadOnly:            cfg.ReadOnly,
+		},
+		now,
+		logger.With(slog.String("service", "project")))
+	projectService.SetIngestCache(eventService)
 
 	alertWorker := worker.NewAlertWorker(
 		alertStore,
 		olap,
 		issueStore,
 		notificationStore,
-		webhookNotifier,
+		notificationService,
 		now,
 		cfg.AlertWorkerInterval,
+		cfg.AlertWorkerWarmUp,
 		warnly.NewUUID().String(),
 		logger.With(slog.String("service", "alert_worker")),
 	)
+	alertWorker.RegisterMetrics(reg)
 	defer alertWorker.Stop()
 
 	go alertWorker.Start(termCtx)
 
+	if retention := (warnly.MessageRetention{
+		MaxAge:         cfg.MessageRetention,
+		KeepOpenIssues: cfg.MessageRetentionKeepOpen,
+	}); retention.Enabled() {
+		messageReaper := worker.NewMessageReaper(
+			messageStore,
+			mentionStore,
+			startUOW,
+			retention,
+			now,
+			time.Hour,
+			logger.With(slog.String("service", "message_reaper")),
+		)
+		defer messageReaper.Stop()
+
+		go messageReaper.Start(termCtx)
+	}
+
+	if thresholds := (warnly.PriorityThresholds{
+		Window:       cfg.PriorityWindow,
+		Users:        cfg.PriorityHighUsers,
+		MinEvents:    cfg.PriorityAccelerationMinEvents,
+		Acceleration: cfg.PriorityAcceleration,
+	}); thresholds.Enabled() {
+		priorityClassifier := worker.NewPriorityClassifier(
+			projectStore,
+			issueStore,
+			olap,
+			thresholds,
+			now,
+			cfg.PriorityClassifierInterval,
+			logger.With(slog.String("service", "priority_classifier")),
+		)
+		defer priorityClassifier.Stop()
+
+		go priorityClassifier.Start(termCtx)
+	}
+
+	ignoreWatcher := worker.NewIgnoreWatcher(
+		eventService,
+		cfg.IgnoreWatcherInterval,
+		logger.With(slog.String("service", "ignore_watcher")),
+	)
+	defer ignoreWatcher.Stop()
+
+	go ignoreWatcher.Start(termCtx)
+
+	projectReaper := worker.NewProjectReaper(
+		projectStore,
+		olap,
+		cfg.ProjectDeletionGracePeriod,
+		now,
+		time.Hour,
+		logger.With(slog.String("service", "project_reaper")),
+	)
+	defer projectReaper.Stop()
+
+	go projectReaper.Start(termCtx)
+
 	isHTTPS := cfg.Server.Scheme == "https"
 
 	cookieStore := sessionstore.NewCookieStore(now, cfg.SessionKey)
@@ -343,20 +539,28 @@ func run(cfg *config, logger *slog.Logger) error {
 
 	var handler http.Handler
 	handler, err = server.NewHandler(&server.Backend{
-		SessionStore:        sessionStore,
-		UserStore:           userStore,
-		SessionService:      sessionService,
-		EventService:        eventService,
-		ProjectService:      projectService,
-		SystemService:       systemService,
-		AlertService:        alertService,
-		NotificationService: notificationService,
-		IsHTTPS:             isHTTPS,
-		RememberSessionDays: cfg.RemeberSessionDays,
-		CookieStore:         cookieStore,
-		Reg:                 reg,
-		Now:                 now,
-		Logger:              logger,
+		SessionStore:               sessionStore,
+		UserStore:                  userStore,
+		SessionService:             sessionService,
+		EventService:               eventService,
+		ProjectService:             projectService,
+		SystemService:              systemService,
+		AlertService:               alertService,
+		NotificationService:        notificationService,
+		AttachmentService:          attachmentService,
+		RegroupService:             regroupService,
+		AdminEmails:                append([]string{cfg.Admin.Email}, cfg.Admin.Emails...),
+		IsHTTPS:                    isHTTPS,
+		RememberSessionDays:        cfg.RemeberSessionDays,
+		IngestReadTimeout:          cfg.Server.IngestReadTimeout,
+		IngestMaxBodySize:          cfg.Server.IngestMaxBodySize,
+		IngestAuthLockoutThreshold: cfg.Server.IngestAuthLockoutThreshold,
+		IngestAuthLockoutWindow:    cfg.Server.IngestAuthLockoutWindow,
+		IngestAllowedOrigins:       cfg.Server.IngestAllowedOrigins,
+		CookieStore:                cookieStore,
+		Reg:                        reg,
+		Now:                        now,
+		Logger:                     logger,
 		OIDC: &server.OIDC{
 			ProviderName: cfg.OIDCProvider.ProviderName,
 			Provider:     oidcProvider,
@@ -516,17 +720,36 @@ type config struct {
 	Admin struct {
 		Email    string `env:"ADMIN_EMAIL"    env-required:"true"`
 		Password string `env:"ADMIN_PASSWORD" env-required:"true"`
+		// Emails are the users besides the initial admin allowed to use the admin API.
+		Emails []string `env:"ADMIN_EMAILS"`
 	}
 	ClickHouse struct {
 		DSN string `env:"CLICKHOUSE_DSN" env-required:"true"`
 	}
+	// SMTP is the mail server invitations are sent through, invitation links are only shown when Addr is empty.
+	SMTP struct {
+		Addr     string `env:"SMTP_ADDR"`
+		From     string `env:"SMTP_FROM"`
+		Username string `env:"SMTP_USERNAME"`
+		Password string `env:"SMTP_PASSWORD"`
+	}
 	Server struct {
-		Host         string        `env:"SERVER_HOST"   env-default:"localhost"`
-		Port         string        `env:"SERVER_PORT"   env-default:"8080"`
-		Scheme       string        `env:"SCHEME"        env-default:"http"`
-		CertFile     string        `env:"CERT_FILE"`
-		CertKey      string        `env:"CERT_KEY"`
-		CloseTimeout time.Duration `env:"CLOSE_TIMEOUT" env-default:"5s"`
+		Host              string        `env:"SERVER_HOST"   env-default:"localhost"`
+		Port              string        `env:"SERVER_PORT"   env-default:"8080"`
+		Scheme            string        `env:"SCHEME"        env-default:"http"`
+		CertFile          string        `env:"CERT_FILE"`
+		CertKey           string        `env:"CERT_KEY"`
+		CloseTimeout      time.Duration `env:"CLOSE_TIMEOUT" env-default:"5s"`
+		IngestReadTimeout time.Duration `env:"INGEST_READ_TIMEOUT" env-default:"30s"`
+		// IngestMaxBodySize is the largest envelope in bytes that can be ingested, after decompression.
+		IngestMaxBodySize int64 `env:"INGEST_MAX_BODY_SIZE" env-default:"4194304"`
+		// IngestAuthLockoutThreshold is the number of ingest authentication failures within the window
+		// a source is locked out after, zero disables the lockout.
+		IngestAuthLockoutThreshold int `env:"INGEST_AUTH_LOCKOUT_THRESHOLD" env-default:"0"`
+		// IngestAuthLockoutWindow is how long ingest authentication failures of a source are counted.
+		IngestAuthLockoutWindow time.Duration `env:"INGEST_AUTH_LOCKOUT_WINDOW" env-default:"1m"`
+		// IngestAllowedOrigins are the origins browser SDKs may send events from, "*" allows any origin.
+		IngestAllowedOrigins []string `env:"INGEST_ALLOWED_ORIGINS" env-default:"*"`
 	}
 	Metrics struct {
 		Port         string `env:"METRICS_PORT"         env-default:"8081"`
@@ -543,7 +766,10 @@ type config struct {
 		ServiceName string  `env:"TRACING_SERVICE_NAME" env-default:"warnly"`
 		Probability float64 `env:"TRACING_PROBABILITY"  env-default:"1.0"`
 	}
-	Kafka                     kafka.KafkaConfig
+	Kafka        kafka.KafkaConfig
+	OutboundHTTP httpclient.Config
+	// PublicIngestURL is the address the server is reachable at from outside, e.g. behind a proxy.
+	// DSNs and invitation links point to it, to the server host and port when it is empty.
 	PublicIngestURL           string `env:"PUBLIC_INGEST_URL"`
 	SessionKey                []byte `env:"SESSION_KEY" env-required:"true"`
 	NotificationEncryptionKey []byte `env:"NOTIFICATION_ENCRYPTION_KEY" env-required:"true"`
@@ -552,4 +778,81 @@ type config struct {
 	RemeberSessionDays        int           `env:"REMEMBER_SESSION_DAYS" env-default:"30"`
 	ForceMigrate              bool          `env:"FORCE_MIGRATE"         env-default:"false"`
 	IsDemo                    bool          `env:"IS_DEMO"               env-default:"false"`
+	// AlertWorkerWarmUp is the period after startup during which alerts keep their states and nothing is notified.
+	AlertWorkerWarmUp time.Duration `env:"ALERT_WORKER_WARM_UP" env-default:"0s"`
+	// AutoAssignStrategy enables automatic assignment of new issues, e.g. "round_robin".
+	AutoAssignStrategy string `env:"AUTO_ASSIGN_STRATEGY"`
+	// WebhookMaxAttempts is the number of delivery attempts before a webhook is dead-lettered.
+	WebhookMaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"3"`
+	// MaxConcurrentNotificationsPerDestination caps simultaneous deliveries to one webhook URL, 0 disables the cap.
+	MaxConcurrentNotificationsPerDestination int `env:"NOTIFICATION_MAX_CONCURRENT_PER_DESTINATION" env-default:"2"`
+	// MessageRetention is the age after which issue discussion messages are deleted, 0 keeps them forever.
+	MessageRetention time.Duration `env:"MESSAGE_RETENTION" env-default:"0s"`
+	// MessageRetentionKeepOpen keeps discussions of unresolved issues regardless of their age.
+	MessageRetentionKeepOpen bool `env:"MESSAGE_RETENTION_KEEP_OPEN" env-default:"true"`
+	// SanitizerPolicy is the HTML allowed in issue discussion messages, "ugc", "basic" (no images) or "strict" (text only).
+	SanitizerPolicy string `env:"SANITIZER_POLICY" env-default:"ugc"`
+	// AttachmentStorage is where event attachments are stored, "local" keeps them in AttachmentDir, "s3" in the S3 bucket.
+	AttachmentStorage string `env:"ATTACHMENT_STORAGE" env-default:"local"`
+	// AttachmentDir is the directory event attachments are stored in, attachments are discarded when empty.
+	AttachmentDir string `env:"ATTACHMENT_DIR"`
+	// S3 is the bucket event attachments are stored in when AttachmentStorage is "s3".
+	S3 s3.Config
+	// IssuesDefaultPeriod is the time range of the issues list when no period is selected, e.g. 7d.
+	IssuesDefaultPeriod string `env:"ISSUES_DEFAULT_PERIOD" env-default:"14d"`
+	// ProjectIssuesPageSize is the number of issues on a page of the project details.
+	ProjectIssuesPageSize int `env:"PROJECT_ISSUES_PAGE_SIZE" env-default:"5"`
+	// IssuesMaxPerPage caps the number of issues returned by a single issues list request.
+	IssuesMaxPerPage int `env:"ISSUES_MAX_PER_PAGE" env-default:"100"`
+	// RegroupBatchSize is the number of stored events regrouped at once after grouping options change.
+	RegroupBatchSize int `env:"REGROUP_BATCH_SIZE" env-default:"1000"`
+	// IngestConfirmRate is the fraction of events stored with an insert that waits until the event is persisted.
+	IngestConfirmRate float64 `env:"INGEST_CONFIRM_RATE" env-default:"0"`
+	// IngestConfirmNewIssues waits until the first event of every new issue is persisted.
+	IngestConfirmNewIssues bool `env:"INGEST_CONFIRM_NEW_ISSUES" env-default:"false"`
+	// IngestIdempotencyWindow is how long the IDs of ingested events are remembered so that retried
+	// events are stored once, zero disables the check.
+	IngestIdempotencyWindow time.Duration `env:"INGEST_IDEMPOTENCY_WINDOW" env-default:"5m"`
+	// IngestIdempotencyVerifyStored also looks up event IDs that aren't remembered in ClickHouse.
+	IngestIdempotencyVerifyStored bool `env:"INGEST_IDEMPOTENCY_VERIFY_STORED" env-default:"false"`
+	// IngestUnknownProjectTTL is how long a failed lookup of an ingest project and key is cached, zero disables it.
+	IngestUnknownProjectTTL time.Duration `env:"INGEST_UNKNOWN_PROJECT_TTL" env-default:"5s"`
+	// IngestNewProjectGrace is how long after a project was created events with a key it doesn't match
+	// are rejected with 429 and Retry-After rather than 404, zero disables it.
+	IngestNewProjectGrace time.Duration `env:"INGEST_NEW_PROJECT_GRACE" env-default:"1m"`
+	// PriorityHighUsers promotes issues that affected at least this many users within the priority window, 0 disables it.
+	PriorityHighUsers uint64 `env:"PRIORITY_HIGH_USERS" env-default:"0"`
+	// PriorityAcceleration promotes issues whose events within the window are at least this many times
+	// the events of the preceding window, 0 disables it.
+	PriorityAcceleration float64 `env:"PRIORITY_ACCELERATION" env-default:"0"`
+	// PriorityAccelerationMinEvents is the number of events within the window an issue needs for its acceleration to count.
+	PriorityAccelerationMinEvents uint64 `env:"PRIORITY_ACCELERATION_MIN_EVENTS" env-default:"20"`
+	// PriorityWindow is the period issue metrics are counted over to promote issues.
+	PriorityWindow time.Duration `env:"PRIORITY_WINDOW" env-default:"1h"`
+	// PriorityClassifierInterval is how often issue priorities are reevaluated.
+	PriorityClassifierInterval time.Duration `env:"PRIORITY_CLASSIFIER_INTERVAL" env-default:"5m"`
+	// IgnoreWatcherInterval is how often issues ignored for a time or until they affect more users are checked.
+	IgnoreWatcherInterval time.Duration `env:"IGNORE_WATCHER_INTERVAL" env-default:"1m"`
+	// ProjectDeletionGracePeriod is how long a deleted project can be restored before it is purged with its events.
+	ProjectDeletionGracePeriod time.Duration `env:"PROJECT_DELETION_GRACE_PERIOD" env-default:"168h"`
+	// StackVisibleFrames is the number of stack frames shown before the rest are collapsed,
+	// zero keeps the default of the project platform.
+	StackVisibleFrames int `env:"STACK_VISIBLE_FRAMES" env-default:"0"`
+	// InvitationTTL is how long an invitation to join a team can be accepted.
+	InvitationTTL time.Duration `env:"INVITATION_TTL" env-default:"168h"`
+	// NewIssueWindow is how long an issue is listed as new after it was first seen.
+	NewIssueWindow time.Duration `env:"NEW_ISSUE_WINDOW" env-default:"168h"`
+	// HotnessHalfLife is how long it takes for an event to count half as much to the hotness of an issue.
+	HotnessHalfLife time.Duration `env:"HOTNESS_HALF_LIFE" env-default:"6h"`
+	// ReadOnly starts in read-only maintenance mode, changes in the app are rejected while events are still ingested.
+	// Admins toggle it at runtime with PUT /api/v1/system/read-only, the toggle is held in memory
+	// of the process that served it and is reset to this value on restart.
+	ReadOnly bool `env:"READ_ONLY" env-default:"false"`
+}
+
+// webhookRetryPolicy returns the default webhook retry policy with the configured number of attempts.
+func webhookRetryPolicy(maxAttempts int) notifier.RetryPolicy {
+	policy := notifier.DefaultRetryPolicy()
+	policy.MaxAttempts = maxAttempts
+	return policy
 }
@@ -5,24 +5,104 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/vk-rv/warnly/internal/svcotel"
 	"github.com/vk-rv/warnly/internal/warnly"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	hasTagSQL    = " AND has(_tags_hash_map, cityHash64(?))"
-	notHasTagSQL = " AND not has(_tags_hash_map, cityHash64(?))"
+	hasTagSQL        = " AND has(_tags_hash_map, cityHash64(?))"
+	notHasTagSQL     = " AND not has(_tags_hash_map, cityHash64(?))"
+	hasContextSQL    = " AND arrayExists((k, v) -> k = ? AND v = ?, contexts.key, contexts.value)"
+	notHasContextSQL = " AND not arrayExists((k, v) -> k = ? AND v = ?, contexts.key, contexts.value)"
 )
 
+// writeFilter appends the condition matching a tag or, for context keys,
+// a context or extra value to the query and returns the extended args.
+func writeFilter(query *strings.Builder, args []any, key string, value warnly.QueryValue) []any {
+	if value.IsContext {
+		if value.IsNot {
+			query.WriteString(notHasContextSQL)
+		} else {
+			query.WriteString(hasContextSQL)
+		}
+		return append(args, key, value.Value)
+	}
+
+	if value.IsNot {
+		query.WriteString(notHasTagSQL)
+	} else {
+		query.WriteString(hasTagSQL)
+	}
+	return append(args, fmt.Sprintf("%s=%s", key, value.Value))
+}
+
+// writeUserFilter appends the conditions matching events of the user of the criteria
+// to the query and returns the extended args. Nothing is appended when no user is given.
+func writeUserFilter(query *strings.Builder, args []any, criteria *warnly.EventCriteria) []any {
+	if criteria.UserEmail != "" {
+		query.WriteString(" AND user_email = ?")
+		args = append(args, criteria.UserEmail)
+	}
+	if criteria.UserID != "" {
+		query.WriteString(" AND user = ?")
+		args = append(args, storedUserID(criteria.UserID))
+	}
+	return args
+}
+
+// writeUserToken appends the condition matching events of the user of a user:<email or ID> query token,
+// or the events of other users when the token is negated, to the query and returns the extended args.
+func writeUserToken(query *strings.Builder, args []any, token *warnly.QueryToken) []any {
+	op := "="
+	if token.Operator == "is not" {
+		op = "!="
+	}
+	email, id := warnly.UserFilter(token.Value)
+	if email != "" {
+		query.WriteString(" AND user_email " + op + " ?")
+		return append(args, email)
+	}
+	query.WriteString(" AND user " + op + " ?")
+	return append(args, storedUserID(id))
+}
+
+// storedUserID returns the user ID the way it is stored in the user column, events store
+// the ID of their user prefixed with "id:".
+func storedUserID(id string) string {
+	if strings.HasPrefix(id, "id:") {
+		return id
+	}
+	return "id:" + id
+}
+
+// writeLevelFilter appends the condition matching events of the given level names
+// to the query and returns the extended args. Nothing is appended when levels are empty.
+func writeLevelFilter(query *strings.Builder, args []any, levels []string) []any {
+	if len(levels) == 0 {
+		return args
+	}
+	codes := make([]warnly.Level, len(levels))
+	for i := range levels {
+		codes[i] = warnly.GetLevel(levels[i])
+	}
+	placeholders, levelArgs := createPlaceholdersAndArgs(codes)
+	query.WriteString(" AND level IN (" + strings.Join(placeholders, ",") + ")")
+	return append(args, levelArgs...)
+}
+
 // ClickhouseStore encapsulates clickhouse connection.
 type ClickhouseStore struct {
 	conn            clickhouse.Conn
 	tracer          trace.Tracer // https://github.com/ClickHouse/clickhouse-go/issues/1444
+	queryDuration   *prometheus.HistogramVec
 	asyncInsertWait bool
 }
 
@@ -42,34 +122,52 @@ func (s *ClickhouseStore) Close() error {
 
 // ListIssueMetrics lists issue metrics for the given project IDs and issue IDs within the specified time range.
 // It displays how many times each issue was seen, when it was first and last seen,
-// and the number of unique users affected.
+// and the number of unique users affected. Without issue IDs the metrics of all issues of the projects are listed.
+// With a limit the metrics are ordered the way issues are listed and the keyset of the cursor
+// is pushed down, so that deep pages don't aggregate and sort the issues before them in the service.
 func (s *ClickhouseStore) ListIssueMetrics(
 	ctx context.Context,
 	c *warnly.ListIssueMetricsCriteria,
 ) ([]warnly.IssueMetrics, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListIssueMetrics")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListIssueMetrics")
+	defer done()
 
-	gidQuestionMarks, args := createPlaceholdersAndArgs(c.GroupIDs)
-	pidQuestionMarks, pidArgs := createPlaceholdersAndArgs(c.ProjectIDs)
-
-	args = append(args, c.From, c.To)
-	args = append(args, pidArgs...)
+	pidQuestionMarks, args := createPlaceholdersAndArgs(c.ProjectIDs)
 
-	query := `SELECT gid, 
+	var query strings.Builder
+	query.WriteString(`SELECT gid, 
 					 count() AS times_seen, 
 					 min(created_at) AS first_seen, 
 					 max(created_at) AS last_seen,
-					 ifNull(uniq(nullIf(user, '')), 0) AS user_count 
+					 ifNull(uniq(nullIf(user, '')), 0) AS user_count,
+					 topK(1)(level)[1] AS level
 			   FROM event 
 			   WHERE deleted = 0
-			   AND gid IN (` + strings.Join(gidQuestionMarks, ",") + `)
-			   AND created_at >= toDateTime(?, 'UTC')
-			   AND created_at <= toDateTime(?, 'UTC')
 			   AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
-			   GROUP BY gid`
+			   AND created_at >= toDateTime(?, 'UTC')
+			   AND created_at <= toDateTime(?, 'UTC')`)
+	args = append(args, c.From, c.To)
 
-	rows, err := s.conn.Query(ctx, query, args...)
+	if len(c.GroupIDs) > 0 {
+		gidQuestionMarks, gidArgs := createPlaceholdersAndArgs(c.GroupIDs)
+		query.WriteString(" AND gid IN (" + strings.Join(gidQuestionMarks, ",") + ")")
+		args = append(args, gidArgs...)
+	}
+
+	args = writeLevelFilter(&query, args, c.Levels)
+
+	query.WriteString(" GROUP BY gid")
+
+	if c.Limit > 0 {
+		if c.After != nil {
+			query.WriteString(" HAVING (times_seen, last_seen, gid) < (?, toDateTime(?, 'UTC'), ?)")
+			args = append(args, c.After.TimesSeen, c.After.LastSeen, uint64(c.After.ID))
+		}
+		query.WriteString(" ORDER BY times_seen DESC, last_seen DESC, gid DESC LIMIT ?")
+		args = append(args, c.Limit)
+	}
+
+	rows, err := s.conn.Query(ctx, query.String(), args...)
 	if err != nil {
 		return nil, fmt.Errorf("clickhouse: list issue metrics: %w", err)
 	}
@@ -82,7 +180,7 @@ func (s *ClickhouseStore) ListIssueMetrics(
 	var res []warnly.IssueMetrics
 	for rows.Next() {
 		m := warnly.IssueMetrics{}
-		if err := rows.Scan(&m.GID, &m.TimesSeen, &m.FirstSeen, &m.LastSeen, &m.UserCount); err != nil {
+		if err := rows.Scan(&m.GID, &m.TimesSeen, &m.FirstSeen, &m.LastSeen, &m.UserCount, &m.Level); err != nil {
 			return nil, fmt.Errorf("clickhouse: list issue metrics, scan result: %w", err)
 		}
 		res = append(res, m)
@@ -97,8 +195,8 @@ func (s *ClickhouseStore) ListIssueMetrics(
 
 // CountFields counts additional fields for a given issue and project within a specified time range.
 func (s *ClickhouseStore) CountFields(ctx context.Context, c *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.CountFields")
-	defer span.End()
+	ctx, done := s.observe(ctx, "CountFields")
+	defer done()
 
 	const query = `SELECT 
 				   	tag,
@@ -147,25 +245,41 @@ func (s *ClickhouseStore) CountFields(ctx context.Context, c *warnly.EventDefCri
 }
 
 // StoreEvent stores an event in the analytics database.
-//
-//nolint:staticcheck // dont forget to replace in future
 func (s *ClickhouseStore) StoreEvent(ctx context.Context, ev *warnly.EventClickhouse) error {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.StoreEvent")
-	defer span.End()
+	ctx, done := s.observe(ctx, "StoreEvent")
+	defer done()
+
+	// No need to wait for acknowledgment for async insert depends on testing
+	return s.storeEvent(ctx, ev, s.asyncInsertWait)
+}
+
+// StoreEventSync stores an event in the analytics database and waits until the insert is
+// flushed, so an error is returned if the event is not persisted.
+func (s *ClickhouseStore) StoreEventSync(ctx context.Context, ev *warnly.EventClickhouse) error {
+	ctx, done := s.observe(ctx, "StoreEventSync")
+	defer done()
 
+	return s.storeEvent(ctx, ev, true)
+}
+
+// storeEvent inserts an event asynchronously, wait selects whether to wait for acknowledgment of the insert.
+//
+//nolint:staticcheck // dont forget to replace in future
+func (s *ClickhouseStore) storeEvent(ctx context.Context, ev *warnly.EventClickhouse, wait bool) error {
 	const query = `INSERT INTO event (
 		created_at, sdk_version, user, primary_hash, env, event_id,
-		message, ipv6, release, title, ipv4,
+		message, ipv6, release, dist, logger, transaction, trace_id, title, ipv4,
 		exception_frames.in_app, contexts.key, exception_frames.colno, exception_frames.abs_path,
 		exception_frames.lineno, exception_stacks.type, exception_stacks.value, tags.key,
 		exception_frames.function, tags.value, exception_frames.filename, contexts.value,
-		gid, user_name, user_username, user_email, pid, level, type, sdk_id, platform, retention_days, deleted
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		gid, user_name, user_username, user_email, pid, level, type, sdk_id, platform, retention_days, deleted,
+		unhandled, raw
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	if err := s.conn.AsyncInsert(
 		ctx,
 		query,
-		s.asyncInsertWait, // No need to wait for acknowledgment for async insert depends on testing
+		wait,
 		ev.CreatedAt,
 		ev.SDKVersion,
 		ev.User,
@@ -175,6 +289,10 @@ func (s *ClickhouseStore) StoreEvent(ctx context.Context, ev *warnly.EventClickh
 		ev.Message,
 		ev.IPv6,
 		ev.Release,
+		ev.Dist,
+		ev.Logger,
+		ev.Transaction,
+		ev.TraceID,
 		ev.Title,
 		ev.IPv4,
 		ev.ExceptionFramesInApp,
@@ -200,6 +318,8 @@ func (s *ClickhouseStore) StoreEvent(ctx context.Context, ev *warnly.EventClickh
 		ev.Platform,
 		ev.RetentionDays,
 		ev.Deleted,
+		ev.Unhandled,
+		ev.Raw,
 	); err != nil {
 		return fmt.Errorf("clickhouse: async insert event: %w", err)
 	}
@@ -209,8 +329,8 @@ func (s *ClickhouseStore) StoreEvent(ctx context.Context, ev *warnly.EventClickh
 
 // GetIssueEvent retrieves a single event associated with a specific issue and project within a given time range.
 func (s *ClickhouseStore) GetIssueEvent(ctx context.Context, c *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.GetIssueEvent")
-	defer span.End()
+	ctx, done := s.observe(ctx, "GetIssueEvent")
+	defer done()
 
 	var (
 		query string
@@ -220,7 +340,7 @@ func (s *ClickhouseStore) GetIssueEvent(ctx context.Context, c *warnly.EventDefC
 	if c.EventID != "" {
 		query = `SELECT replaceAll(toString(event_id), '-', '') AS event_id,
 			created_at,
-			env, release,
+			env, release, dist, logger, transaction, trace_id,
 			user, user_username, user_name, user_email,
 			tags.key, tags.value, contexts.key, contexts.value, message,
 			exception_frames.abs_path, exception_frames.colno,
@@ -236,7 +356,7 @@ func (s *ClickhouseStore) GetIssueEvent(ctx context.Context, c *warnly.EventDefC
 	} else {
 		query = `SELECT replaceAll(toString(event_id), '-', '') AS event_id,
 			created_at,
-			env, release,
+			env, release, dist, logger, transaction, trace_id,
 			user, user_username, user_name, user_email,
 			tags.key, tags.value, contexts.key, contexts.value, message,
 			exception_frames.abs_path, exception_frames.colno,
@@ -269,6 +389,10 @@ func (s *ClickhouseStore) GetIssueEvent(ctx context.Context, c *warnly.EventDefC
 			&i.CreatedAt,
 			&i.Env,
 			&i.Release,
+			&i.Dist,
+			&i.Logger,
+			&i.Transaction,
+			&i.TraceID,
 			&i.UserID,
 			&i.UserUsername,
 			&i.UserName,
@@ -294,13 +418,34 @@ func (s *ClickhouseStore) GetIssueEvent(ctx context.Context, c *warnly.EventDefC
 	return &i, nil
 }
 
+// GetRawEvent retrieves the original JSON payload of the event.
+func (s *ClickhouseStore) GetRawEvent(ctx context.Context, c *warnly.EventDefCriteria) ([]byte, error) {
+	ctx, done := s.observe(ctx, "GetRawEvent")
+	defer done()
+
+	const query = `SELECT raw FROM event WHERE deleted = 0 AND event_id = ? AND pid = ? AND gid = ? LIMIT 1`
+
+	var raw string
+	if err := s.conn.QueryRow(ctx, query, c.EventID, c.ProjectID, c.GroupID).Scan(&raw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("clickhouse: get raw event: %w", warnly.ErrNotFound)
+		}
+		return nil, fmt.Errorf("clickhouse: get raw event: %w", err)
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("clickhouse: get raw event stored without payload: %w", warnly.ErrNotFound)
+	}
+
+	return []byte(raw), nil
+}
+
 // ListFieldFilters lists field filters for a given set of project IDs.
 func (s *ClickhouseStore) ListFieldFilters(
 	ctx context.Context,
 	criteria *warnly.FieldFilterCriteria,
 ) ([]warnly.Filter, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListFieldFilters")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListFieldFilters")
+	defer done()
 
 	pidPlaceholders, pidArgs := createPlaceholdersAndArgs(criteria.ProjectIDs)
 
@@ -355,8 +500,8 @@ func (s *ClickhouseStore) ListFieldFilters(
 // CalculateFields calculates the number of occurrences of each field for a given issue identifier and
 // project within a specified time range.
 func (s *ClickhouseStore) CalculateFields(ctx context.Context, c warnly.FieldsCriteria) ([]warnly.TagCount, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.CalculateFields")
-	defer span.End()
+	ctx, done := s.observe(ctx, "CalculateFields")
+	defer done()
 
 	const query = `
 		SELECT 
@@ -404,8 +549,8 @@ func (s *ClickhouseStore) CalculateFields(ctx context.Context, c warnly.FieldsCr
 // CalculateEventsPerDay calculates the number of events per day for a given group and project
 // within a specified time range.
 func (s *ClickhouseStore) CalculateEventsPerDay(ctx context.Context, c *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.CalculateEventsPerDay")
-	defer span.End()
+	ctx, done := s.observe(ctx, "CalculateEventsPerDay")
+	defer done()
 
 	const query = `SELECT 
 				   	gid,
@@ -448,9 +593,43 @@ func (s *ClickhouseStore) CalculateEventsPerDay(ctx context.Context, c *warnly.E
 
 // ListEvents lists error events per issue based on the given criteria.
 func (s *ClickhouseStore) ListEvents(ctx context.Context, criteria *warnly.EventCriteria) ([]warnly.EventEntry, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListEvents")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListEvents")
+	defer done()
+
+	var events []warnly.EventEntry
+	if err := s.streamEvents(ctx, criteria, func(event *warnly.EventEntry) error {
+		events = append(events, *event)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("clickhouse: list events: %w", err)
+	}
 
+	return events, nil
+}
+
+// StreamEvents calls fn for every error event matching the given criteria
+// without buffering the result set. The entry passed to fn is reused between calls.
+// Iteration stops at the first error returned by fn.
+func (s *ClickhouseStore) StreamEvents(
+	ctx context.Context,
+	criteria *warnly.EventCriteria,
+	fn func(event *warnly.EventEntry) error,
+) error {
+	ctx, done := s.observe(ctx, "StreamEvents")
+	defer done()
+
+	if err := s.streamEvents(ctx, criteria, fn); err != nil {
+		return fmt.Errorf("clickhouse: stream events: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ClickhouseStore) streamEvents(
+	ctx context.Context,
+	criteria *warnly.EventCriteria,
+	fn func(event *warnly.EventEntry) error,
+) (err error) {
 	var query strings.Builder
 	query.WriteString(`SELECT 
 			  	replaceAll(toString(event_id), '-', '') AS event_id,
@@ -458,6 +637,7 @@ func (s *ClickhouseStore) ListEvents(ctx context.Context, criteria *warnly.Event
 				title,
 				message,
 				release,
+				dist,
 				env,
 				user,
 				user_email,
@@ -478,14 +658,12 @@ func (s *ClickhouseStore) ListEvents(ctx context.Context, criteria *warnly.Event
 	}
 
 	for key, value := range criteria.Tags {
-		if value.IsNot {
-			query.WriteString(notHasTagSQL)
-		} else {
-			query.WriteString(hasTagSQL)
-		}
-		args = append(args, fmt.Sprintf("%s=%s", key, value.Value))
+		args = writeFilter(&query, args, key, value)
 	}
 
+	args = writeUserFilter(&query, args, criteria)
+	args = writeLevelFilter(&query, args, criteria.Levels)
+
 	query.WriteString(" AND in(pid, ?)")
 	args = append(args, criteria.ProjectID)
 
@@ -494,7 +672,7 @@ func (s *ClickhouseStore) ListEvents(ctx context.Context, criteria *warnly.Event
 
 	rows, err := s.conn.Query(ctx, query.String(), args...)
 	if err != nil {
-		return nil, fmt.Errorf("clickhouse: list events: %w", err)
+		return fmt.Errorf("query: %w", err)
 	}
 
 	defer func() {
@@ -503,37 +681,256 @@ func (s *ClickhouseStore) ListEvents(ctx context.Context, criteria *warnly.Event
 		}
 	}()
 
-	var events []warnly.EventEntry
+	var event warnly.EventEntry
 	for rows.Next() {
-		var event warnly.EventEntry
 		if err := rows.Scan(
 			&event.EventID,
 			&event.CreatedAt,
 			&event.Title,
 			&event.Message,
 			&event.Release,
+			&event.Dist,
 			&event.Env,
 			&event.User,
 			&event.UserEmail,
 			&event.UserUsername,
 			&event.UserName,
 			&event.OS); err != nil {
-			return nil, fmt.Errorf("clickhouse: list events, scan result: %w", err)
+			return fmt.Errorf("scan result: %w", err)
+		}
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return nil
+}
+
+// ListRawEvents lists a batch of stored events of a project with their payloads, the oldest first.
+func (s *ClickhouseStore) ListRawEvents(ctx context.Context, criteria *warnly.RawEventsCriteria) (_ []warnly.RawEvent, err error) {
+	ctx, done := s.observe(ctx, "ListRawEvents")
+	defer done()
+
+	var query strings.Builder
+	query.WriteString(`SELECT toString(event_id), created_at, gid, raw
+			FROM event
+			WHERE deleted = 0
+			AND pid = ?`)
+
+	args := []any{criteria.ProjectID}
+
+	if criteria.AfterEventID != "" {
+		query.WriteString(" AND (created_at, event_id) > (toDateTime(?, 'UTC'), toUUID(?))")
+		args = append(args, criteria.AfterCreatedAt, criteria.AfterEventID)
+	}
+
+	query.WriteString(" ORDER BY created_at, event_id LIMIT ?")
+	args = append(args, criteria.Limit)
+
+	rows, err := s.conn.Query(ctx, query.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: list raw events: %w", err)
+	}
+
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	events := make([]warnly.RawEvent, 0, criteria.Limit)
+	for rows.Next() {
+		var (
+			event warnly.RawEvent
+			raw   string
+		)
+		if err := rows.Scan(&event.EventID, &event.CreatedAt, &event.GroupID, &raw); err != nil {
+			return nil, fmt.Errorf("clickhouse: list raw events, scan result: %w", err)
 		}
+		event.Raw = []byte(raw)
 		events = append(events, event)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("clickhouse: list events, rows.Err: %w", err)
+		return nil, fmt.Errorf("clickhouse: list raw events, rows.Err: %w", err)
 	}
 
 	return events, nil
 }
 
+// RegroupEvents reassigns events of a project to other groups with a single mutation.
+// The mutation is applied in the background, like the deletion of events.
+func (s *ClickhouseStore) RegroupEvents(ctx context.Context, criteria *warnly.RegroupEventsCriteria) error {
+	ctx, done := s.observe(ctx, "RegroupEvents")
+	defer done()
+
+	if len(criteria.Moves) == 0 {
+		return nil
+	}
+
+	gids := make([]uint64, 0, len(criteria.Moves))
+	for gid := range criteria.Moves {
+		gids = append(gids, gid)
+	}
+	slices.Sort(gids)
+
+	var (
+		cases      strings.Builder
+		caseArgs   []any
+		allEventID []string
+	)
+	for _, gid := range gids {
+		placeholders, eventArgs := createPlaceholdersAndArgs(criteria.Moves[gid])
+		cases.WriteString("event_id IN (" + strings.Join(placeholders, ",") + "), ?, ")
+		caseArgs = append(caseArgs, eventArgs...)
+		caseArgs = append(caseArgs, gid)
+		allEventID = append(allEventID, criteria.Moves[gid]...)
+	}
+	placeholders, eventArgs := createPlaceholdersAndArgs(allEventID)
+
+	query := `ALTER TABLE event
+			  UPDATE gid = multiIf(` + cases.String() + `gid)
+			  WHERE pid = ?
+			  AND event_id IN (` + strings.Join(placeholders, ",") + `)`
+
+	args := make([]any, 0, len(caseArgs)+len(eventArgs)+1)
+	args = append(args, caseArgs...)
+	args = append(args, criteria.ProjectID)
+	args = append(args, eventArgs...)
+
+	if err := s.conn.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("clickhouse: regroup events: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProjectEvents deletes all events of a project.
+// The deletion is a mutation ClickHouse applies in the background.
+func (s *ClickhouseStore) DeleteProjectEvents(ctx context.Context, projectID int) error {
+	ctx, done := s.observe(ctx, "DeleteProjectEvents")
+	defer done()
+
+	if err := s.conn.Exec(ctx, `ALTER TABLE event DELETE WHERE pid = ?`, projectID); err != nil {
+		return fmt.Errorf("clickhouse: delete project events: %w", err)
+	}
+
+	return nil
+}
+
+// HasEvent reports whether an event with the ID was stored for the project since the time.
+func (s *ClickhouseStore) HasEvent(ctx context.Context, projectID int, eventID string, since time.Time) (bool, error) {
+	ctx, done := s.observe(ctx, "HasEvent")
+	defer done()
+
+	const query = `SELECT count() FROM event
+			  WHERE deleted = 0
+			  AND pid = ?
+			  AND event_id = ?
+			  AND created_at >= toDateTime(?, 'UTC')`
+
+	var count uint64
+	if err := s.conn.QueryRow(ctx, query, projectID, eventID, since).Scan(&count); err != nil {
+		return false, fmt.Errorf("clickhouse: has event: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ListEventsByTrace lists up to warnly.MaxTraceEvents events of the projects that happened in the trace,
+// the most recent first.
+func (s *ClickhouseStore) ListEventsByTrace(
+	ctx context.Context,
+	traceID string,
+	projectIDs []int,
+) (res []warnly.TraceEvent, err error) {
+	if traceID == "" || len(projectIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, done := s.observe(ctx, "ListEventsByTrace")
+	defer done()
+
+	pidQuestionMarks, args := createPlaceholdersAndArgs(projectIDs)
+	args = append([]any{traceID}, args...)
+	args = append(args, warnly.MaxTraceEvents)
+
+	query := `SELECT
+				replaceAll(toString(event_id), '-', '') AS event_id,
+				created_at, title, message, gid, pid
+			  FROM event
+			  WHERE deleted = 0
+			  AND trace_id = ?
+			  AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
+			  ORDER BY created_at DESC
+			  LIMIT ?`
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: list events by trace: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			ev  warnly.TraceEvent
+			gid uint64
+			pid uint16
+		)
+		if err := rows.Scan(&ev.EventID, &ev.CreatedAt, &ev.Title, &ev.Message, &gid, &pid); err != nil {
+			return nil, fmt.Errorf("clickhouse: list events by trace, scan result: %w", err)
+		}
+		ev.GroupID = int64(gid)
+		ev.ProjectID = int(pid)
+		res = append(res, ev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: list events by trace, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// countIssueEvents counts events of an issue without message and tag filters by reusing
+// times_seen of the issue metrics instead of building a filtered count query.
+func (s *ClickhouseStore) countIssueEvents(ctx context.Context, criteria *warnly.EventCriteria) (uint64, error) {
+	metrics, err := s.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: []int{criteria.ProjectID},
+		GroupIDs:   []int64{int64(criteria.GroupID)},
+		Levels:     criteria.Levels,
+		From:       criteria.From,
+		// created_at has a second precision and metrics include the upper bound.
+		To: criteria.To.Add(-time.Second),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("clickhouse: count events: %w", err)
+	}
+
+	metric, ok := warnly.GetMetrics(metrics, int64(criteria.GroupID))
+	if !ok {
+		return 0, nil
+	}
+
+	return metric.TimesSeen, nil
+}
+
 // CountEvents returns the number of events for a given project and issue.
 func (s *ClickhouseStore) CountEvents(ctx context.Context, criteria *warnly.EventCriteria) (uint64, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.CountEvents")
-	defer span.End()
+	ctx, done := s.observe(ctx, "CountEvents")
+	defer done()
+
+	if criteria.Message == "" && len(criteria.Tags) == 0 && criteria.UserEmail == "" && criteria.UserID == "" {
+		return s.countIssueEvents(ctx, criteria)
+	}
 
 	var query strings.Builder
 	query.WriteString(`SELECT count() AS count
@@ -551,14 +948,12 @@ func (s *ClickhouseStore) CountEvents(ctx context.Context, criteria *warnly.Even
 	}
 
 	for key, value := range criteria.Tags {
-		if value.IsNot {
-			query.WriteString(" AND not has(_tags_hash_map, cityHash64(?))")
-		} else {
-			query.WriteString(" AND has(_tags_hash_map, cityHash64(?))")
-		}
-		args = append(args, fmt.Sprintf("%s=%s", key, value.Value))
+		args = writeFilter(&query, args, key, value)
 	}
 
+	args = writeUserFilter(&query, args, criteria)
+	args = writeLevelFilter(&query, args, criteria.Levels)
+
 	query.WriteString(" AND in(pid, ?)")
 	args = append(args, criteria.ProjectID)
 
@@ -592,8 +987,8 @@ func (s *ClickhouseStore) GetEventPagination(
 	ctx context.Context,
 	c *warnly.EventPaginationCriteria,
 ) (*warnly.EventPagination, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.GetEventPagination")
-	defer span.End()
+	ctx, done := s.observe(ctx, "GetEventPagination")
+	defer done()
 
 	p := &warnly.EventPagination{}
 
@@ -675,8 +1070,8 @@ func (s *ClickhouseStore) GetEventPagination(
 
 // ListSchemas lists olap database schemas from largest to smallest.
 func (s *ClickhouseStore) ListSchemas(ctx context.Context) ([]warnly.Schema, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListSchemas")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListSchemas")
+	defer done()
 
 	const query = `SELECT name, 
 						  formatReadableSize(total_bytes) AS readable_bytes,
@@ -721,8 +1116,8 @@ func (s *ClickhouseStore) ListSchemas(ctx context.Context) ([]warnly.Schema, err
 
 // ListErrors lists recent errors from the olap system for the last 24 hours.
 func (s *ClickhouseStore) ListErrors(ctx context.Context, c warnly.ListErrorsCriteria) ([]warnly.AnalyticsStoreErr, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListErrors")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListErrors")
+	defer done()
 
 	const query = `SELECT name, count() AS count, max(last_error_time) AS max_last_error_time
 				   FROM system.errors
@@ -758,8 +1153,8 @@ func (s *ClickhouseStore) ListErrors(ctx context.Context, c warnly.ListErrorsCri
 
 // ListSlowQueries lists slow SQL queries from the olap system and their statistics.
 func (s *ClickhouseStore) ListSlowQueries(ctx context.Context) ([]warnly.SQLQuery, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListSlowQueries")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListSlowQueries")
+	defer done()
 
 	const query = `SELECT 
     				normalizeQuery(query) AS normalized_query,
@@ -825,42 +1220,53 @@ func (s *ClickhouseStore) ListSlowQueries(ctx context.Context) ([]warnly.SQLQuer
 	return queries, nil
 }
 
-// CalculateEvents calculates the number of events per day split by hour.
-//
-//nolint:staticcheck // false positive
-func (s *ClickhouseStore) CalculateEvents(
-	ctx context.Context,
-	c *warnly.ListIssueMetricsCriteria,
-) ([]warnly.EventsPerHour, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.CalculateEvents")
-	defer span.End()
-
-	pidQuestionMarks := make([]string, 0, len(c.ProjectIDs))
-	for range c.ProjectIDs {
-		pidQuestionMarks = append(pidQuestionMarks, "?")
-	}
+// maxEventsPerHourRows caps the number of hourly event counts returned by CalculateEvents
+// and CalculateEventsByEnv.
+const maxEventsPerHourRows = 5000
 
-	pidArgs := make([]any, 0, len(c.ProjectIDs))
-	for _, pid := range c.ProjectIDs {
-		pidArgs = append(pidArgs, pid)
-	}
+// hourlyEventsQuery returns the query counting events of the projects within the range per hour and project,
+// the extra columns split the counts further. The most recent hours come first, so that the oldest hours
+// are the ones left out when the counts are capped.
+func hourlyEventsQuery(c *warnly.ListIssueMetricsCriteria, columns ...string) (string, []any) {
+	pidQuestionMarks, args := createPlaceholdersAndArgs(c.ProjectIDs)
+	args = append(args, c.From, c.To, maxEventsPerHourRows)
 
-	args := make([]any, 0, len(pidArgs)+2)
-	args = append(args, pidArgs...)
-	args = append(args, c.From, c.To)
+	groupBy := strings.Join(append([]string{"pid"}, columns...), ", ")
 
 	query := `SELECT 
     		  	toStartOfHour(created_at, 'UTC') AS ts,
-				pid,
+				` + groupBy + `,
 				count() AS event_count
 			  FROM event
 			  WHERE deleted = 0
 			  AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
 			  AND created_at >= toDateTime(?, 'UTC')
 			  AND created_at < toDateTime(?, 'UTC')
-			  GROUP BY ts, pid
-			  ORDER BY ts ASC
-			  LIMIT 5000`
+			  GROUP BY ts, ` + groupBy + `
+			  ORDER BY ts DESC
+			  LIMIT ?`
+
+	return query, args
+}
+
+// hourlyEventsCapacity is the number of rows to allocate for the hourly counts of the criteria,
+// at most a row per hour of the range for every project.
+func hourlyEventsCapacity(c *warnly.ListIssueMetricsCriteria) int {
+	hours := max(int(c.To.Sub(c.From).Hours())+1, 1)
+	return min(hours*len(c.ProjectIDs), maxEventsPerHourRows)
+}
+
+// CalculateEvents calculates the number of events per day split by hour, oldest hour first.
+//
+//nolint:staticcheck // false positive
+func (s *ClickhouseStore) CalculateEvents(
+	ctx context.Context,
+	c *warnly.ListIssueMetricsCriteria,
+) ([]warnly.EventsPerHour, error) {
+	ctx, done := s.observe(ctx, "CalculateEvents")
+	defer done()
+
+	query, args := hourlyEventsQuery(c)
 
 	rows, err := s.conn.Query(ctx, query, args...)
 	if err != nil {
@@ -872,7 +1278,7 @@ func (s *ClickhouseStore) CalculateEvents(
 		}
 	}()
 
-	res := make([]warnly.EventsPerHour, 0, 24)
+	res := make([]warnly.EventsPerHour, 0, hourlyEventsCapacity(c))
 	for rows.Next() {
 		var (
 			ts    time.Time
@@ -889,69 +1295,287 @@ func (s *ClickhouseStore) CalculateEvents(
 		return nil, fmt.Errorf("clickhouse: calculate events, rows.Err: %w", err)
 	}
 
+	slices.Reverse(res)
+
 	return res, nil
 }
 
-// ListPopularTags lists popular tag keys across all events in the given time range and projects.
-func (s *ClickhouseStore) ListPopularTags(
+// CalculateEventsByEnv calculates the number of events per hour split by environment, oldest hour first.
+//
+//nolint:staticcheck // false positive
+func (s *ClickhouseStore) CalculateEventsByEnv(
 	ctx context.Context,
-	c *warnly.ListPopularTagsCriteria,
-) ([]warnly.TagCount, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListPopularTags")
-	defer span.End()
-
-	pidQuestionMarks, pidArgs := createPlaceholdersAndArgs(c.ProjectIDs)
-
-	args := make([]any, 0, 2+len(pidArgs)+1)
-	args = append(args, c.From, c.To)
-	args = append(args, pidArgs...)
-
-	query := `SELECT tag, count() AS count
-			   FROM event
-			   ARRAY JOIN tags.key AS tag
-			   WHERE deleted = 0
-			   AND created_at >= toDateTime(?, 'UTC')
-			   AND created_at <= toDateTime(?, 'UTC')
-			   AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
-			   GROUP BY tag
-			   ORDER BY count DESC
-			   LIMIT ?`
+	c *warnly.ListIssueMetricsCriteria,
+) ([]warnly.EventsPerEnvHour, error) {
+	ctx, done := s.observe(ctx, "CalculateEventsByEnv")
+	defer done()
 
-	args = append(args, c.Limit)
+	query, args := hourlyEventsQuery(c, "env")
 
 	rows, err := s.conn.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("clickhouse: list popular tags: %w", err)
+		return nil, fmt.Errorf("clickhouse: calculate events by env: %w", err)
 	}
 	defer func() {
-		if cerr := rows.Close(); cerr != nil && err == nil {
+		if cerr := rows.Close(); err == nil && cerr != nil {
 			err = cerr
 		}
 	}()
 
-	res := make([]warnly.TagCount, 0, c.Limit)
+	res := make([]warnly.EventsPerEnvHour, 0, hourlyEventsCapacity(c))
 	for rows.Next() {
-		tc := warnly.TagCount{}
-		if err := rows.Scan(&tc.Tag, &tc.Count); err != nil {
-			return nil, fmt.Errorf("clickhouse: list popular tags, scan result: %w", err)
+		var (
+			ts    time.Time
+			pid   uint16
+			env   string
+			count uint64
+		)
+		if err := rows.Scan(&ts, &pid, &env, &count); err != nil {
+			return nil, fmt.Errorf("clickhouse: calculate events by env, scan result: %w", err)
 		}
-		res = append(res, tc)
+		res = append(res, warnly.EventsPerEnvHour{TS: ts, Env: env, ProjectID: int(pid), Count: int(count)})
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("clickhouse: list popular tags, rows.Err: %w", err)
+		return nil, fmt.Errorf("clickhouse: calculate events by env, rows.Err: %w", err)
 	}
 
+	slices.Reverse(res)
+
 	return res, nil
 }
 
-// ListTagValues lists popular values for a given tag in the specified time range and projects.
-func (s *ClickhouseStore) ListTagValues(
+// CountUniqueUsers counts the distinct users the events of each project came from within the period,
+// events without a user aren't counted, as in the user count of ListIssueMetrics.
+func (s *ClickhouseStore) CountUniqueUsers(
+	ctx context.Context,
+	c *warnly.ListIssueMetricsCriteria,
+) ([]warnly.ProjectUsers, error) {
+	ctx, done := s.observe(ctx, "CountUniqueUsers")
+	defer done()
+
+	pidQuestionMarks, args := createPlaceholdersAndArgs(c.ProjectIDs)
+	args = append(args, c.From, c.To)
+
+	query := `SELECT pid, uniq(user) AS user_count
+			  FROM event
+			  WHERE deleted = 0
+			  AND user != ''
+			  AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
+			  AND created_at >= toDateTime(?, 'UTC')
+			  AND created_at <= toDateTime(?, 'UTC')
+			  GROUP BY pid`
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: count unique users: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	res := make([]warnly.ProjectUsers, 0, len(c.ProjectIDs))
+	for rows.Next() {
+		var (
+			pid   uint16
+			count uint64
+		)
+		if err := rows.Scan(&pid, &count); err != nil {
+			return nil, fmt.Errorf("clickhouse: count unique users, scan result: %w", err)
+		}
+		res = append(res, warnly.ProjectUsers{ProjectID: int(pid), Count: count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: count unique users, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// CalculateIssueEvents calculates the number of events of the issues per hour, most recent hours first,
+// so that the oldest hours are the ones left out when the counts are capped.
+func (s *ClickhouseStore) CalculateIssueEvents(
+	ctx context.Context,
+	c *warnly.ListIssueMetricsCriteria,
+) ([]warnly.IssueEventsPerHour, error) {
+	ctx, done := s.observe(ctx, "CalculateIssueEvents")
+	defer done()
+
+	gidQuestionMarks, args := createPlaceholdersAndArgs(c.GroupIDs)
+	pidQuestionMarks, pidArgs := createPlaceholdersAndArgs(c.ProjectIDs)
+
+	args = append(args, pidArgs...)
+	args = append(args, c.From, c.To, maxEventsPerHourRows)
+
+	query := `SELECT
+				toStartOfHour(created_at, 'UTC') AS ts,
+				gid,
+				count() AS event_count
+			  FROM event
+			  WHERE deleted = 0
+			  AND gid IN (` + strings.Join(gidQuestionMarks, ",") + `)
+			  AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
+			  AND created_at >= toDateTime(?, 'UTC')
+			  AND created_at <= toDateTime(?, 'UTC')
+			  GROUP BY ts, gid
+			  ORDER BY ts DESC
+			  LIMIT ?`
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: calculate issue events: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	var res []warnly.IssueEventsPerHour
+	for rows.Next() {
+		var (
+			ts    time.Time
+			gid   uint64
+			count uint64
+		)
+		if err := rows.Scan(&ts, &gid, &count); err != nil {
+			return nil, fmt.Errorf("clickhouse: calculate issue events, scan result: %w", err)
+		}
+		res = append(res, warnly.IssueEventsPerHour{TS: ts, GroupID: int64(gid), Count: int(count)})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: calculate issue events, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// EventSeriesForIssues calculates the number of events of each of the issues of the projects per hour
+// within the time range in a single query, ordered by issue and hour.
+func (s *ClickhouseStore) EventSeriesForIssues(
+	ctx context.Context,
+	gids []int64,
+	from, to time.Time,
+	projectIDs []int,
+) (res []warnly.IssueEventsPerHour, err error) {
+	if len(gids) == 0 || len(projectIDs) == 0 {
+		return nil, nil
+	}
+
+	ctx, done := s.observe(ctx, "EventSeriesForIssues")
+	defer done()
+
+	pidQuestionMarks, args := createPlaceholdersAndArgs(projectIDs)
+	gidQuestionMarks, gidArgs := createPlaceholdersAndArgs(gids)
+	args = append(args, gidArgs...)
+	args = append(args, from, to)
+
+	query := `SELECT
+				gid,
+				toStartOfHour(created_at, 'UTC') AS ts,
+				count() AS event_count
+			  FROM event
+			  WHERE deleted = 0
+			  AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
+			  AND gid IN (` + strings.Join(gidQuestionMarks, ",") + `)
+			  AND created_at >= toDateTime(?, 'UTC')
+			  AND created_at <= toDateTime(?, 'UTC')
+			  GROUP BY gid, ts
+			  ORDER BY gid, ts`
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: event series for issues: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			gid   uint64
+			ts    time.Time
+			count uint64
+		)
+		if err := rows.Scan(&gid, &ts, &count); err != nil {
+			return nil, fmt.Errorf("clickhouse: event series for issues, scan result: %w", err)
+		}
+		res = append(res, warnly.IssueEventsPerHour{TS: ts, GroupID: int64(gid), Count: int(count)})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: event series for issues, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListPopularTags lists popular tag keys across all events in the given time range and projects.
+func (s *ClickhouseStore) ListPopularTags(
+	ctx context.Context,
+	c *warnly.ListPopularTagsCriteria,
+) ([]warnly.TagCount, error) {
+	ctx, done := s.observe(ctx, "ListPopularTags")
+	defer done()
+
+	pidQuestionMarks, pidArgs := createPlaceholdersAndArgs(c.ProjectIDs)
+
+	args := make([]any, 0, 2+len(pidArgs)+1)
+	args = append(args, c.From, c.To)
+	args = append(args, pidArgs...)
+
+	query := `SELECT tag, count() AS count
+			   FROM event
+			   ARRAY JOIN tags.key AS tag
+			   WHERE deleted = 0
+			   AND created_at >= toDateTime(?, 'UTC')
+			   AND created_at <= toDateTime(?, 'UTC')
+			   AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
+			   GROUP BY tag
+			   ORDER BY count DESC
+			   LIMIT ?`
+
+	args = append(args, c.Limit)
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: list popular tags: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	res := make([]warnly.TagCount, 0, c.Limit)
+	for rows.Next() {
+		tc := warnly.TagCount{}
+		if err := rows.Scan(&tc.Tag, &tc.Count); err != nil {
+			return nil, fmt.Errorf("clickhouse: list popular tags, scan result: %w", err)
+		}
+		res = append(res, tc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: list popular tags, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListTagValues lists popular values for a given tag in the specified time range and projects.
+func (s *ClickhouseStore) ListTagValues(
 	ctx context.Context,
 	c *warnly.ListTagValuesCriteria,
 ) ([]warnly.TagValueCount, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.ListTagValues")
-	defer span.End()
+	ctx, done := s.observe(ctx, "ListTagValues")
+	defer done()
 
 	pidQuestionMarks, pidArgs := createPlaceholdersAndArgs(c.ProjectIDs)
 
@@ -999,6 +1623,516 @@ func (s *ClickhouseStore) ListTagValues(
 	return res, nil
 }
 
+// SuggestTagValues lists the most popular values of a project tag starting with a prefix, ignoring case,
+// in the specified time range.
+func (s *ClickhouseStore) SuggestTagValues(
+	ctx context.Context,
+	c *warnly.SuggestTagValuesCriteria,
+) ([]warnly.TagValueCount, error) {
+	ctx, done := s.observe(ctx, "SuggestTagValues")
+	defer done()
+
+	query := `SELECT value, count() AS count
+			   FROM event
+			   ARRAY JOIN tags.key AS tag, tags.value AS value
+			   WHERE tag = ?
+			   AND deleted = 0
+			   AND created_at >= toDateTime(?, 'UTC')
+			   AND created_at <= toDateTime(?, 'UTC')
+			   AND pid = ?
+			   AND startsWith(lowerUTF8(value), lowerUTF8(?))
+			   GROUP BY value
+			   ORDER BY count DESC, value
+			   LIMIT ?`
+
+	rows, err := s.conn.Query(ctx, query, c.Tag, c.From, c.To, c.ProjectID, c.Prefix, c.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: suggest tag values: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	res := make([]warnly.TagValueCount, 0, c.Limit)
+	for rows.Next() {
+		tvc := warnly.TagValueCount{}
+		if err := rows.Scan(&tvc.Value, &tvc.Count); err != nil {
+			return nil, fmt.Errorf("clickhouse: suggest tag values, scan result: %w", err)
+		}
+		res = append(res, tvc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: suggest tag values, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListUnhandledGroupIDs returns group IDs that have at least one unhandled event.
+func (s *ClickhouseStore) ListUnhandledGroupIDs(
+	ctx context.Context,
+	from, to time.Time,
+	projectIDs []int,
+) ([]int64, error) {
+	ctx, done := s.observe(ctx, "ListUnhandledGroupIDs")
+	defer done()
+
+	query := `SELECT DISTINCT gid FROM event WHERE deleted = 0 AND unhandled = 1 AND pid IN (?` +
+		strings.Repeat(",?", len(projectIDs)-1) +
+		`) AND created_at >= toDateTime(?, 'UTC') AND created_at <= toDateTime(?, 'UTC')`
+
+	args := make([]any, 0, len(projectIDs)+2)
+	for _, pid := range projectIDs {
+		args = append(args, pid)
+	}
+	args = append(args, from, to)
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: list unhandled group ids: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	var gids []int64
+	for rows.Next() {
+		var gid uint64
+		if err := rows.Scan(&gid); err != nil {
+			return nil, fmt.Errorf("clickhouse: list unhandled group ids, scan result: %w", err)
+		}
+		gids = append(gids, int64(gid))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: list unhandled group ids, rows.Err: %w", err)
+	}
+
+	return gids, nil
+}
+
+// SearchIssues returns group IDs whose titles or messages match the query, best matches first.
+// Title matches score higher than message matches. Queries made of plain words are matched
+// by tokens which the token bloom filter indexes serve, phrases fall back to substring matching.
+func (s *ClickhouseStore) SearchIssues(
+	ctx context.Context,
+	query string,
+	criteria *warnly.SearchIssuesCriteria,
+) ([]warnly.IssueMatch, error) {
+	ctx, done := s.observe(ctx, "SearchIssues")
+	defer done()
+
+	q, args := buildSearchIssuesQuery(query, criteria)
+
+	rows, err := s.conn.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: search issues: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	var matches []warnly.IssueMatch
+	for rows.Next() {
+		var (
+			gid   uint64
+			score float64
+		)
+		if err := rows.Scan(&gid, &score); err != nil {
+			return nil, fmt.Errorf("clickhouse: search issues, scan result: %w", err)
+		}
+		matches = append(matches, warnly.IssueMatch{GID: int64(gid), Score: score})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: search issues, rows.Err: %w", err)
+	}
+
+	return matches, nil
+}
+
+const (
+	// titleMatchWeight and messageMatchWeight are the scores of a search term found in the title and the message.
+	titleMatchWeight   = 2
+	messageMatchWeight = 1
+)
+
+// buildSearchIssuesQuery builds the query scoring every event by the search terms
+// found in its title and message and keeping the best score of each issue.
+func buildSearchIssuesQuery(query string, criteria *warnly.SearchIssuesCriteria) (string, []any) {
+	terms, byToken := searchTerms(query, criteria.Phrase)
+
+	titleMatch := "notEquals(positionCaseInsensitive(title, ?), 0)"
+	messageMatch := "notEquals(positionCaseInsensitive(message, ?), 0)"
+	if byToken {
+		titleMatch = "hasToken(lower(title), ?)"
+		messageMatch = "hasToken(lower(message), ?)"
+	}
+
+	scores := make([]string, 0, len(terms))
+	matches := make([]string, 0, len(terms))
+	scoreArgs := make([]any, 0, 2*len(terms))
+	matchArgs := make([]any, 0, 2*len(terms))
+	for _, term := range terms {
+		scores = append(scores, fmt.Sprintf("%d * %s + %d * %s", titleMatchWeight, titleMatch, messageMatchWeight, messageMatch))
+		matches = append(matches, titleMatch, messageMatch)
+		scoreArgs = append(scoreArgs, term, term)
+		matchArgs = append(matchArgs, term, term)
+	}
+
+	var q strings.Builder
+	q.WriteString(`SELECT gid, max(score) AS score FROM (SELECT gid, ` + strings.Join(scores, " + ") +
+		` AS score FROM event WHERE deleted = 0 AND pid IN (?` + strings.Repeat(",?", len(criteria.ProjectIDs)-1) +
+		`) AND created_at >= toDateTime(?, 'UTC') AND created_at <= toDateTime(?, 'UTC') AND (` +
+		strings.Join(matches, " OR ") + `)) GROUP BY gid ORDER BY score DESC, gid DESC`)
+
+	args := make([]any, 0, len(scoreArgs)+len(criteria.ProjectIDs)+len(matchArgs)+3)
+	args = append(args, scoreArgs...)
+	for _, pid := range criteria.ProjectIDs {
+		args = append(args, pid)
+	}
+	args = append(args, criteria.From, criteria.To)
+	args = append(args, matchArgs...)
+
+	if criteria.Limit > 0 {
+		q.WriteString(" LIMIT ?")
+		args = append(args, criteria.Limit)
+	}
+
+	return q.String(), args
+}
+
+// searchTerms splits the query into the search terms and reports whether they can be matched by tokens.
+// A phrase, or a query with separators inside a word, is kept whole and matched as a substring.
+func searchTerms(query string, phrase bool) ([]string, bool) {
+	query = strings.TrimSpace(query)
+	if phrase {
+		return []string{query}, false
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	for _, term := range terms {
+		if strings.ContainsFunc(term, isTokenSeparator) {
+			return []string{query}, false
+		}
+	}
+
+	return terms, true
+}
+
+// isTokenSeparator reports whether ClickHouse splits tokens at r: any ASCII character other than a letter or a digit.
+func isTokenSeparator(r rune) bool {
+	return r < utf8.RuneSelf && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+}
+
+// RollingBaseline counts the project events in the current window
+// and averages the counts of the preceding windows.
+func (s *ClickhouseStore) RollingBaseline(
+	ctx context.Context,
+	criteria *warnly.RollingBaselineCriteria,
+) (*warnly.RollingBaseline, error) {
+	ctx, done := s.observe(ctx, "RollingBaseline")
+	defer done()
+
+	const query = `SELECT
+		countIf(created_at > toDateTime(?, 'UTC')) AS current,
+		countIf(created_at <= toDateTime(?, 'UTC')) AS baseline
+	FROM event
+	WHERE deleted = 0
+	AND pid = ?
+	AND created_at > toDateTime(?, 'UTC')
+	AND created_at <= toDateTime(?, 'UTC')`
+
+	currentFrom := criteria.CurrentFrom()
+
+	var current, baseline uint64
+	if err := s.conn.QueryRow(ctx, query,
+		currentFrom,
+		currentFrom,
+		criteria.ProjectID,
+		criteria.BaselineFrom(),
+		criteria.To,
+	).Scan(&current, &baseline); err != nil {
+		return nil, fmt.Errorf("clickhouse: rolling baseline: %w", err)
+	}
+
+	res := &warnly.RollingBaseline{Current: current}
+	if criteria.Windows > 0 {
+		res.Baseline = float64(baseline) / float64(criteria.Windows)
+	}
+
+	return res, nil
+}
+
+// CalculateEventGaps builds the histogram of time gaps between consecutive events of an issue.
+// Each gap is put into the first bucket whose bound it is shorter than, index 0 is the unbounded bucket.
+// Gaps are taken between neighbouring rows of a window, so the events of an issue aren't collected into an array.
+func (s *ClickhouseStore) CalculateEventGaps(
+	ctx context.Context,
+	c *warnly.EventDefCriteria,
+) ([]warnly.EventGapBucket, error) {
+	ctx, done := s.observe(ctx, "CalculateEventGaps")
+	defer done()
+
+	const query = `SELECT
+		toUInt32(arrayFirstIndex(bound -> gap < bound, ?)) AS bucket,
+		count() AS gaps
+	FROM (
+		SELECT
+			toUInt32(created_at) - lagInFrame(toUInt32(created_at)) OVER w AS gap,
+			row_number() OVER w AS n
+		FROM event
+		WHERE deleted = 0
+		AND gid = ?
+		AND pid = ?
+		AND created_at >= toDateTime(?, 'UTC')
+		AND created_at < toDateTime(?, 'UTC')
+		WINDOW w AS (ORDER BY created_at ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)
+	)
+	WHERE n > 1
+	GROUP BY bucket`
+
+	bounds := make([]uint64, len(warnly.EventGapBounds))
+	for i, bound := range warnly.EventGapBounds {
+		bounds[i] = uint64(bound / time.Second)
+	}
+
+	rows, err := s.conn.Query(ctx, query, bounds, c.GroupID, c.ProjectID, c.From, c.To)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: calculate event gaps: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	res := warnly.NewEventGapHistogram()
+	for rows.Next() {
+		var (
+			bucket uint32
+			gaps   uint64
+		)
+		if err := rows.Scan(&bucket, &gaps); err != nil {
+			return nil, fmt.Errorf("clickhouse: calculate event gaps, scan result: %w", err)
+		}
+		if bucket == 0 {
+			res[len(res)-1].Count = gaps
+		} else {
+			res[bucket-1].Count = gaps
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: calculate event gaps, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// ReleaseAdoption aggregates users and errors of the most recent releases of a project.
+// A user is new to the release their earliest event in the time range was sent from.
+func (s *ClickhouseStore) ReleaseAdoption(
+	ctx context.Context,
+	c *warnly.ReleaseAdoptionCriteria,
+) ([]warnly.ReleaseAdoption, error) {
+	ctx, done := s.observe(ctx, "ReleaseAdoption")
+	defer done()
+
+	const releasesQuery = `SELECT
+		release,
+		min(created_at) AS first_seen,
+		uniqExactIf(user, user != '') AS users,
+		count() AS errors
+	FROM event
+	WHERE deleted = 0
+	AND pid = ?
+	AND release != ''
+	AND created_at >= toDateTime(?, 'UTC')
+	AND created_at < toDateTime(?, 'UTC')
+	GROUP BY release
+	ORDER BY first_seen DESC, release
+	LIMIT ?`
+
+	rows, err := s.conn.Query(ctx, releasesQuery, c.ProjectID, c.From, c.To, c.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: release adoption: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	res := []warnly.ReleaseAdoption{}
+	byRelease := map[string]*warnly.ReleaseAdoption{}
+	for rows.Next() {
+		var r warnly.ReleaseAdoption
+		if err := rows.Scan(&r.Release, &r.FirstSeen, &r.Users, &r.Errors); err != nil {
+			return nil, fmt.Errorf("clickhouse: release adoption, scan release: %w", err)
+		}
+		res = append(res, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: release adoption, rows.Err: %w", err)
+	}
+	if len(res) == 0 {
+		return res, nil
+	}
+	for i := range res {
+		byRelease[res[i].Release] = &res[i]
+	}
+
+	const newUsersQuery = `SELECT release, count() AS new_users
+	FROM (
+		SELECT user, argMin(release, created_at) AS release
+		FROM event
+		WHERE deleted = 0
+		AND pid = ?
+		AND release != ''
+		AND user != ''
+		AND created_at >= toDateTime(?, 'UTC')
+		AND created_at < toDateTime(?, 'UTC')
+		GROUP BY user
+	)
+	GROUP BY release`
+
+	newUsers, err := s.conn.Query(ctx, newUsersQuery, c.ProjectID, c.From, c.To)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: release adoption, new users: %w", err)
+	}
+	defer func() {
+		if cerr := newUsers.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for newUsers.Next() {
+		var (
+			release string
+			count   uint64
+		)
+		if err := newUsers.Scan(&release, &count); err != nil {
+			return nil, fmt.Errorf("clickhouse: release adoption, scan new users: %w", err)
+		}
+		if r, ok := byRelease[release]; ok {
+			r.NewUsers = count
+		}
+	}
+	if err := newUsers.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: release adoption, new users rows.Err: %w", err)
+	}
+
+	const daysQuery = `SELECT
+		release,
+		toStartOfDay(created_at) AS day,
+		uniqExactIf(user, user != '') AS users,
+		count() AS errors
+	FROM event
+	WHERE deleted = 0
+	AND pid = ?
+	AND release != ''
+	AND created_at >= toDateTime(?, 'UTC')
+	AND created_at < toDateTime(?, 'UTC')
+	GROUP BY release, day
+	ORDER BY day`
+
+	days, err := s.conn.Query(ctx, daysQuery, c.ProjectID, c.From, c.To)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: release adoption, days: %w", err)
+	}
+	defer func() {
+		if cerr := days.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for days.Next() {
+		var (
+			release string
+			day     warnly.ReleaseDay
+		)
+		if err := days.Scan(&release, &day.Day, &day.Users, &day.Errors); err != nil {
+			return nil, fmt.Errorf("clickhouse: release adoption, scan day: %w", err)
+		}
+		if r, ok := byRelease[release]; ok {
+			r.Days = append(r.Days, day)
+		}
+	}
+	if err := days.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: release adoption, days rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
+// CompareWindows counts the events of each issue in the baseline and the current windows
+// with a single scan over both, the issues that changed the most first.
+func (s *ClickhouseStore) CompareWindows(
+	ctx context.Context,
+	c *warnly.CompareWindowsCriteria,
+) ([]warnly.IssueWindowCounts, error) {
+	ctx, done := s.observe(ctx, "CompareWindows")
+	defer done()
+
+	pidQuestionMarks, pidArgs := createPlaceholdersAndArgs(c.ProjectIDs)
+
+	query := `SELECT gid, baseline_count, current_count
+	FROM (
+		SELECT gid,
+			countIf(created_at >= toDateTime(?, 'UTC') AND created_at < toDateTime(?, 'UTC')) AS baseline_count,
+			countIf(created_at >= toDateTime(?, 'UTC') AND created_at < toDateTime(?, 'UTC')) AS current_count
+		FROM event
+		WHERE deleted = 0
+		AND pid IN (` + strings.Join(pidQuestionMarks, ",") + `)
+		AND ((created_at >= toDateTime(?, 'UTC') AND created_at < toDateTime(?, 'UTC'))
+			OR (created_at >= toDateTime(?, 'UTC') AND created_at < toDateTime(?, 'UTC')))
+		GROUP BY gid
+	)
+	ORDER BY abs(toInt64(current_count) - toInt64(baseline_count)) DESC, gid
+	LIMIT ?`
+
+	args := make([]any, 0, len(pidArgs)+9)
+	args = append(args, c.Baseline.From, c.Baseline.To, c.Current.From, c.Current.To)
+	args = append(args, pidArgs...)
+	args = append(args, c.Baseline.From, c.Baseline.To, c.Current.From, c.Current.To, c.Limit)
+
+	rows, err := s.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: compare windows: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	res := []warnly.IssueWindowCounts{}
+	for rows.Next() {
+		var (
+			gid    uint64
+			counts warnly.IssueWindowCounts
+		)
+		if err := rows.Scan(&gid, &counts.Baseline, &counts.Current); err != nil {
+			return nil, fmt.Errorf("clickhouse: compare windows, scan: %w", err)
+		}
+		counts.GroupID = int64(gid)
+		res = append(res, counts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("clickhouse: compare windows, rows.Err: %w", err)
+	}
+
+	return res, nil
+}
+
 // GetFilteredGroupIDs returns group IDs that match the query filters.
 func (s *ClickhouseStore) GetFilteredGroupIDs(
 	ctx context.Context,
@@ -1006,8 +2140,8 @@ func (s *ClickhouseStore) GetFilteredGroupIDs(
 	from, to time.Time,
 	projectIDs []int,
 ) ([]int64, error) {
-	ctx, span := s.tracer.Start(ctx, "ClickhouseStore.GetFilteredGroupIDs")
-	defer span.End()
+	ctx, done := s.observe(ctx, "GetFilteredGroupIDs")
+	defer done()
 
 	var query strings.Builder
 	query.WriteString(`SELECT DISTINCT gid FROM event WHERE deleted = 0 AND pid IN (?` +
@@ -1020,20 +2154,22 @@ func (s *ClickhouseStore) GetFilteredGroupIDs(
 	}
 	args = append(args, from, to)
 
-	for _, token := range tokens {
-		if token.IsRawText {
+	for i := range tokens {
+		token := &tokens[i]
+		switch {
+		case token.IsRawText:
 			query.WriteString(`
 			 AND (notEquals(positionCaseInsensitive(message, ?), 0) 
 			 OR notEquals(positionCaseInsensitive(title, ?), 0))`)
 			args = append(args, token.Value, token.Value)
-		} else {
-			hash := fmt.Sprintf("%s=%s", token.Key, token.Value)
-			if token.Operator == "is not" {
-				query.WriteString(" AND not has(_tags_hash_map, cityHash64(?))")
-			} else {
-				query.WriteString(" AND has(_tags_hash_map, cityHash64(?))")
-			}
-			args = append(args, hash)
+		case token.IsUser:
+			args = writeUserToken(&query, args, token)
+		default:
+			args = writeFilter(&query, args, token.Key, warnly.QueryValue{
+				Value:     token.Value,
+				IsNot:     token.Operator == "is not",
+				IsContext: token.IsContext,
+			})
 		}
 	}
 
@@ -0,0 +1,178 @@
+package ch
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestWriteFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		key       string
+		value     warnly.QueryValue
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "tag",
+			key:       "os",
+			value:     warnly.QueryValue{Value: "darwin"},
+			wantQuery: hasTagSQL,
+			wantArgs:  []any{"os=darwin"},
+		},
+		{
+			name:      "negated tag",
+			key:       "os",
+			value:     warnly.QueryValue{Value: "darwin", IsNot: true},
+			wantQuery: notHasTagSQL,
+			wantArgs:  []any{"os=darwin"},
+		},
+		{
+			name:      "context",
+			key:       "os.name",
+			value:     warnly.QueryValue{Value: "darwin", IsContext: true},
+			wantQuery: hasContextSQL,
+			wantArgs:  []any{"os.name", "darwin"},
+		},
+		{
+			name:      "negated extra",
+			key:       "extra.order_id",
+			value:     warnly.QueryValue{Value: "42", IsNot: true, IsContext: true},
+			wantQuery: notHasContextSQL,
+			wantArgs:  []any{"extra.order_id", "42"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var query strings.Builder
+			args := writeFilter(&query, nil, tt.key, tt.value)
+
+			assert.Equal(t, tt.wantQuery, query.String())
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestWriteUserFilter(t *testing.T) {
+	t.Parallel()
+
+	var query strings.Builder
+	args := writeUserFilter(&query, nil, &warnly.EventCriteria{UserEmail: "jane@example.com", UserID: "42"})
+	assert.Equal(t, " AND user_email = ? AND user = ?", query.String())
+	assert.Equal(t, []any{"jane@example.com", "id:42"}, args, "user IDs are stored prefixed")
+
+	query.Reset()
+	args = writeUserToken(&query, nil, &warnly.QueryToken{Key: "user", Operator: "is not", Value: "id:42", IsUser: true})
+	assert.Equal(t, " AND user != ?", query.String())
+	assert.Equal(t, []any{"id:42"}, args, "prefixed IDs aren't prefixed again")
+
+	query.Reset()
+	args = writeUserToken(&query, nil, &warnly.QueryToken{Key: "user", Operator: "is", Value: "jane@example.com", IsUser: true})
+	assert.Equal(t, " AND user_email = ?", query.String())
+	assert.Equal(t, []any{"jane@example.com"}, args)
+}
+
+func TestWriteLevelFilter(t *testing.T) {
+	t.Parallel()
+
+	var query strings.Builder
+	args := writeLevelFilter(&query, []any{1}, []string{"warning", "fatal"})
+	assert.Equal(t, " AND level IN (?,?)", query.String())
+	assert.Equal(t, []any{1, warnly.LevelWarning, warnly.LevelFatal}, args)
+
+	query.Reset()
+	args = writeLevelFilter(&query, []any{1}, nil)
+	assert.Empty(t, query.String())
+	assert.Equal(t, []any{1}, args)
+}
+
+func TestSearchTerms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		query       string
+		phrase      bool
+		wantTerms   []string
+		wantByToken bool
+	}{
+		{
+			name:        "words",
+			query:       "Connection Timeout",
+			wantTerms:   []string{"connection", "timeout"},
+			wantByToken: true,
+		},
+		{
+			name:        "quoted phrase",
+			query:       "connection timeout",
+			phrase:      true,
+			wantTerms:   []string{"connection timeout"},
+			wantByToken: false,
+		},
+		{
+			name:        "separator inside a word",
+			query:       "i/o timeout",
+			wantTerms:   []string{"i/o timeout"},
+			wantByToken: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			terms, byToken := searchTerms(tt.query, tt.phrase)
+
+			assert.Equal(t, tt.wantTerms, terms)
+			assert.Equal(t, tt.wantByToken, byToken)
+		})
+	}
+}
+
+func TestBuildSearchIssuesQuery(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("tokens", func(t *testing.T) {
+		t.Parallel()
+
+		query, args := buildSearchIssuesQuery("Timeout", &warnly.SearchIssuesCriteria{
+			From:       from,
+			To:         to,
+			ProjectIDs: []int{1, 2},
+			Limit:      100,
+		})
+
+		assert.Contains(t, query, "2 * hasToken(lower(title), ?) + 1 * hasToken(lower(message), ?) AS score")
+		assert.Contains(t, query, "AND (hasToken(lower(title), ?) OR hasToken(lower(message), ?))")
+		assert.Contains(t, query, "ORDER BY score DESC")
+		assert.NotContains(t, query, "positionCaseInsensitive")
+		assert.Equal(t, []any{"timeout", "timeout", 1, 2, from, to, "timeout", "timeout", 100}, args)
+	})
+
+	t.Run("phrase falls back to substring matching", func(t *testing.T) {
+		t.Parallel()
+
+		query, args := buildSearchIssuesQuery("i/o timeout", &warnly.SearchIssuesCriteria{
+			From:       from,
+			To:         to,
+			ProjectIDs: []int{1},
+		})
+
+		assert.Contains(t, query, "2 * notEquals(positionCaseInsensitive(title, ?), 0)")
+		assert.NotContains(t, query, "hasToken")
+		assert.NotContains(t, query, "LIMIT")
+		assert.Equal(t, []any{"i/o timeout", "i/o timeout", 1, from, to, "i/o timeout", "i/o timeout"}, args)
+	})
+}
@@ -0,0 +1,62 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestCompareWindows(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const projectID = 1
+	lastWeek := time.Now().UTC().Add(-14 * 24 * time.Hour).Truncate(24 * time.Hour)
+	thisWeek := lastWeek.Add(7 * 24 * time.Hour)
+
+	events := []struct {
+		at        time.Time
+		gid       uint64
+		projectID uint16
+		deleted   uint8
+	}{
+		// issue 1 is seen in both windows, issue 2 only in the current one.
+		{at: lastWeek.Add(time.Hour), gid: 1, projectID: projectID},
+		{at: lastWeek.Add(2 * time.Hour), gid: 1, projectID: projectID},
+		{at: lastWeek.Add(3 * time.Hour), gid: 1, projectID: projectID},
+		{at: thisWeek.Add(time.Hour), gid: 1, projectID: projectID},
+		{at: thisWeek.Add(time.Hour), gid: 2, projectID: projectID},
+		{at: thisWeek.Add(2 * time.Hour), gid: 2, projectID: projectID},
+		{at: thisWeek.Add(3 * time.Hour), gid: 2, projectID: projectID},
+		{at: thisWeek.Add(4 * time.Hour), gid: 2, projectID: projectID},
+		// events outside of the windows, of other projects and deleted events don't count.
+		{at: lastWeek.Add(-time.Hour), gid: 3, projectID: projectID},
+		{at: thisWeek.Add(time.Hour), gid: 4, projectID: projectID + 1},
+		{at: thisWeek.Add(time.Hour), gid: 1, projectID: projectID, deleted: 1},
+	}
+	for _, e := range events {
+		ev := testEvent(e.at, e.gid, e.projectID)
+		ev.Deleted = e.deleted
+		require.NoError(t, store.StoreEvent(ctx, ev))
+	}
+
+	counts, err := store.CompareWindows(ctx, &warnly.CompareWindowsCriteria{
+		Baseline:   warnly.TimeWindow{From: lastWeek, To: thisWeek},
+		Current:    warnly.TimeWindow{From: thisWeek, To: thisWeek.Add(7 * 24 * time.Hour)},
+		ProjectIDs: []int{projectID},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []warnly.IssueWindowCounts{
+		{GroupID: 2, Baseline: 0, Current: 4},
+		{GroupID: 1, Baseline: 3, Current: 1},
+	}, counts)
+}
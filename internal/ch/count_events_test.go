@@ -0,0 +1,66 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestCountEventsMatchesIssueMetrics(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		groupID   = 3
+	)
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-time.Hour)
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, store.StoreEvent(ctx, testEvent(to.Add(-time.Duration(i)*time.Minute), groupID, projectID)))
+	}
+	withMessage := testEvent(to.Add(-time.Second), groupID, projectID)
+	withMessage.Message = "connection refused"
+	require.NoError(t, store.StoreEvent(ctx, withMessage))
+	// the upper bound is exclusive, older events, deleted events and other issues are not counted.
+	require.NoError(t, store.StoreEvent(ctx, testEvent(to, groupID, projectID)))
+	require.NoError(t, store.StoreEvent(ctx, testEvent(from.Add(-time.Second), groupID, projectID)))
+	require.NoError(t, store.StoreEvent(ctx, testEvent(from.Add(-24*time.Hour), groupID, projectID)))
+	deleted := testEvent(to.Add(-time.Minute), groupID, projectID)
+	deleted.Deleted = 1
+	require.NoError(t, store.StoreEvent(ctx, deleted))
+	require.NoError(t, store.StoreEvent(ctx, testEvent(to.Add(-time.Minute), groupID+1, projectID)))
+
+	metrics, err := store.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		From:       from,
+		To:         to.Add(-time.Second),
+		ProjectIDs: []int{projectID},
+		GroupIDs:   []int64{groupID},
+	})
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+
+	criteria := &warnly.EventCriteria{From: from, To: to, ProjectID: projectID, GroupID: groupID}
+	count, err := store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), count)
+	assert.Equal(t, metrics[0].TimesSeen, count)
+
+	criteria.Message = "refused"
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	count, err = store.CountEvents(ctx, &warnly.EventCriteria{From: from, To: to, ProjectID: projectID, GroupID: groupID + 2})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
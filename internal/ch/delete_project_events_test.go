@@ -0,0 +1,45 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestDeleteProjectEvents(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const projectID = 1
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	for i := range 3 {
+		require.NoError(t, store.StoreEvent(ctx, testEvent(start.Add(time.Duration(i)*time.Minute), 1, projectID)))
+		require.NoError(t, store.StoreEvent(ctx, testEvent(start.Add(time.Duration(i)*time.Minute), 1, projectID+1)))
+	}
+
+	countEvents := func(pid int) uint64 {
+		count, err := store.CountEvents(ctx, &warnly.EventCriteria{
+			From:      start,
+			To:        start.Add(time.Hour),
+			ProjectID: pid,
+			GroupID:   1,
+		})
+		require.NoError(t, err)
+		return count
+	}
+
+	require.NoError(t, store.DeleteProjectEvents(ctx, projectID))
+
+	// the deletion is applied by ClickHouse in the background.
+	require.Eventually(t, func() bool { return countEvents(projectID) == 0 }, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, uint64(3), countEvents(projectID+1), "events of other projects are kept")
+}
@@ -0,0 +1,90 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestDistFilter(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		oldBuild  = 3
+		newBuild  = 4
+	)
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-time.Hour)
+
+	storeEvents := func(groupID uint64, dist string, n int) *warnly.EventClickhouse {
+		var ev *warnly.EventClickhouse
+		for i := 1; i <= n; i++ {
+			ev = testEvent(to.Add(-time.Duration(i)*time.Minute), groupID, projectID)
+			ev.Release = "app@1.4.0"
+			ev.Dist = dist
+			ev.TagsKey = []string{"release", "dist"}
+			ev.TagsValue = []string{ev.Release, dist}
+			require.NoError(t, store.StoreEvent(ctx, ev))
+		}
+		return ev
+	}
+	last := storeEvents(oldBuild, "411", 2)
+	storeEvents(newBuild, "412", 3)
+
+	criteria := &warnly.EventCriteria{
+		From:      from,
+		To:        to,
+		ProjectID: projectID,
+		GroupID:   newBuild,
+		Tags:      map[string]warnly.QueryValue{"dist": {Value: "412"}},
+		Limit:     100,
+	}
+	count, err := store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	events, err := store.ListEvents(ctx, criteria)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	for _, ev := range events {
+		assert.Equal(t, "412", ev.Dist)
+		assert.Equal(t, "app@1.4.0 (412)", ev.DisplayRelease())
+	}
+
+	criteria.Tags = map[string]warnly.QueryValue{"dist": {Value: "411"}}
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	groupIDs, err := store.GetFilteredGroupIDs(ctx, []warnly.QueryToken{
+		{Key: "dist", Operator: "is", Value: "411"},
+	}, from, to, []int{projectID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{oldBuild}, groupIDs)
+
+	groupIDs, err = store.GetFilteredGroupIDs(ctx, []warnly.QueryToken{
+		{Key: "dist", Operator: "is not", Value: "411"},
+	}, from, to, []int{projectID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{newBuild}, groupIDs)
+
+	event, err := store.GetIssueEvent(ctx, &warnly.EventDefCriteria{
+		From:      from,
+		To:        to,
+		EventID:   last.EventID,
+		GroupID:   oldBuild,
+		ProjectID: projectID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "411", event.Dist)
+}
@@ -0,0 +1,97 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+var testInstance *ClickHouseTestInstance
+
+func TestMain(m *testing.M) {
+	testInstance = MustTestInstance()
+	defer testInstance.MustClose()
+
+	m.Run()
+}
+
+func TestCalculateEventGaps(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		groupID   = 42
+	)
+	start := time.Now().UTC().Add(-3 * 24 * time.Hour).Truncate(time.Second)
+
+	// gaps between consecutive events: 0s, 5s, 30s, 5m, 2h, 30h.
+	offsets := []time.Duration{
+		0,
+		0,
+		5 * time.Second,
+		35 * time.Second,
+		5*time.Minute + 35*time.Second,
+		2*time.Hour + 5*time.Minute + 35*time.Second,
+		32*time.Hour + 5*time.Minute + 35*time.Second,
+	}
+	// stored out of order to check that the gaps are measured between events sorted by time.
+	for _, i := range []int{3, 0, 6, 1, 5, 2, 4} {
+		require.NoError(t, store.StoreEvent(ctx, testEvent(start.Add(offsets[i]), groupID, projectID)))
+	}
+
+	// events of other issues and deleted events don't count.
+	require.NoError(t, store.StoreEvent(ctx, testEvent(start.Add(time.Second), groupID+1, projectID)))
+	require.NoError(t, store.StoreEvent(ctx, testEvent(start.Add(time.Second), groupID, projectID+1)))
+	deleted := testEvent(start.Add(time.Second), groupID, projectID)
+	deleted.Deleted = 1
+	require.NoError(t, store.StoreEvent(ctx, deleted))
+
+	buckets, err := store.CalculateEventGaps(ctx, &warnly.EventDefCriteria{
+		From:      start.Add(-time.Hour),
+		To:        start.Add(2 * 24 * time.Hour),
+		GroupID:   groupID,
+		ProjectID: projectID,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []warnly.EventGapBucket{
+		{Max: time.Second, Count: 1},
+		{Max: 10 * time.Second, Count: 1},
+		{Max: time.Minute, Count: 1},
+		{Max: 10 * time.Minute, Count: 1},
+		{Max: time.Hour, Count: 0},
+		{Max: 6 * time.Hour, Count: 1},
+		{Max: 24 * time.Hour, Count: 0},
+		{Max: 0, Count: 1},
+	}, buckets)
+
+	buckets, err = store.CalculateEventGaps(ctx, &warnly.EventDefCriteria{
+		From:      start.Add(-time.Hour),
+		To:        start.Add(time.Second),
+		GroupID:   groupID,
+		ProjectID: projectID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, warnly.NewEventGapHistogram()[1:], buckets[1:], "only the gap between the two first events is in range")
+	assert.Equal(t, uint64(1), buckets[0].Count)
+}
+
+func testEvent(createdAt time.Time, groupID uint64, projectID uint16) *warnly.EventClickhouse {
+	return &warnly.EventClickhouse{
+		CreatedAt:     createdAt,
+		EventID:       uuid.NewString(),
+		GroupID:       groupID,
+		ProjectID:     projectID,
+		RetentionDays: 30,
+	}
+}
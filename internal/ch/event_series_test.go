@@ -0,0 +1,79 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestEventSeriesForIssues(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID    = 1
+		otherProject = 2
+	)
+	to := time.Now().UTC().Truncate(time.Hour).Add(30 * time.Minute)
+	from := to.Truncate(time.Hour).Add(-(warnly.SparklineHours - 1) * time.Hour)
+
+	events := []struct {
+		at      time.Time
+		gid     uint64
+		pid     uint16
+		deleted uint8
+	}{
+		{at: to.Add(-time.Minute), gid: 1},
+		{at: to.Add(-2 * time.Minute), gid: 1},
+		{at: to.Add(-3 * time.Hour), gid: 1},
+		{at: to.Add(-5 * time.Hour), gid: 2},
+		// other issues, other projects, deleted and out of range events aren't counted.
+		{at: to.Add(-time.Minute), gid: 3},
+		{at: to.Add(-time.Minute), gid: 1, pid: otherProject},
+		{at: to.Add(-time.Minute), gid: 2, deleted: 1},
+		{at: from.Add(-time.Hour), gid: 2},
+	}
+	for _, e := range events {
+		pid := uint16(projectID)
+		if e.pid != 0 {
+			pid = e.pid
+		}
+		ev := testEvent(e.at, e.gid, pid)
+		ev.Deleted = e.deleted
+		require.NoError(t, store.StoreEvent(ctx, ev))
+	}
+
+	series, err := store.EventSeriesForIssues(ctx, []int64{1, 2}, from, to, []int{projectID})
+	require.NoError(t, err)
+
+	hour := to.Truncate(time.Hour)
+	assert.Equal(t, []warnly.IssueEventsPerHour{
+		{TS: hour.Add(-3 * time.Hour), GroupID: 1, Count: 1},
+		{TS: hour, GroupID: 1, Count: 2},
+		{TS: hour.Add(-5 * time.Hour), GroupID: 2, Count: 1},
+	}, series)
+
+	byIssue := map[int64][]warnly.IssueEventsPerHour{}
+	for _, s := range series {
+		byIssue[s.GroupID] = append(byIssue[s.GroupID], s)
+	}
+	first := warnly.NewSparkline(byIssue[1], to)
+	second := warnly.NewSparkline(byIssue[2], to)
+	require.Len(t, first, warnly.SparklineHours)
+	require.Len(t, second, warnly.SparklineHours)
+	assert.Equal(t, 2, first[warnly.SparklineHours-1])
+	assert.Equal(t, 1, second[warnly.SparklineHours-6])
+	assert.Zero(t, second[warnly.SparklineHours-1])
+
+	series, err = store.EventSeriesForIssues(ctx, nil, from, to, []int{projectID})
+	require.NoError(t, err)
+	assert.Empty(t, series)
+}
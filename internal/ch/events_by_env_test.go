@@ -0,0 +1,75 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestCalculateEventsByEnv(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		groupID   = 3
+	)
+	to := time.Now().UTC().Truncate(time.Hour)
+	from := to.Add(-3 * time.Hour)
+
+	storeEvents := func(env string, at time.Time, n int) {
+		for range n {
+			ev := testEvent(at, groupID, projectID)
+			ev.Env = env
+			require.NoError(t, store.StoreEvent(ctx, ev))
+		}
+	}
+	storeEvents("production", to.Add(-90*time.Minute), 2)
+	storeEvents("production", to.Add(-30*time.Minute), 1)
+	storeEvents("staging", to.Add(-30*time.Minute), 4)
+	// events out of the range and of other projects are not counted.
+	storeEvents("staging", from.Add(-time.Minute), 1)
+	other := testEvent(to.Add(-30*time.Minute), groupID, 2)
+	other.Env = "production"
+	require.NoError(t, store.StoreEvent(ctx, other))
+
+	criteria := &warnly.ListIssueMetricsCriteria{ProjectIDs: []int{projectID}, From: from, To: to}
+	byEnv, err := store.CalculateEventsByEnv(ctx, criteria)
+	require.NoError(t, err)
+
+	type hourEnv struct {
+		ts  time.Time
+		env string
+	}
+	counts := map[hourEnv]int{}
+	for _, e := range byEnv {
+		assert.Equal(t, projectID, e.ProjectID)
+		counts[hourEnv{e.TS.UTC(), e.Env}] += e.Count
+	}
+	assert.Equal(t, map[hourEnv]int{
+		{to.Add(-2 * time.Hour), "production"}: 2,
+		{to.Add(-time.Hour), "production"}:     1,
+		{to.Add(-time.Hour), "staging"}:        4,
+	}, counts)
+
+	assert.Equal(t, []warnly.EnvTotal{
+		{Env: "staging", Count: 4},
+		{Env: "production", Count: 3},
+	}, warnly.EnvEventsList(byEnv).Totals())
+
+	events, err := store.CalculateEvents(ctx, criteria)
+	require.NoError(t, err)
+	total := 0
+	for _, e := range events {
+		total += e.Count
+	}
+	assert.Equal(t, 7, total, "the environments add up to the overall count")
+}
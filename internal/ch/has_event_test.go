@@ -0,0 +1,36 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+)
+
+func TestHasEvent(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	ev := testEvent(now.Add(-time.Minute), 7, 1)
+	require.NoError(t, store.StoreEvent(ctx, ev))
+
+	found, err := store.HasEvent(ctx, 1, ev.EventID, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = store.HasEvent(ctx, 2, ev.EventID, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.False(t, found, "events of other projects are not matched")
+
+	found, err = store.HasEvent(ctx, 1, ev.EventID, now)
+	require.NoError(t, err)
+	assert.False(t, found, "events stored before the time are not matched")
+}
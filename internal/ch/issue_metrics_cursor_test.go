@@ -0,0 +1,72 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestListIssueMetricsAfterCursor(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		pageSize  = 2
+	)
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-time.Hour)
+
+	// groups 1-3 tie on times seen, 2 and 3 also on last seen, so the group ID breaks the tie.
+	groups := []struct {
+		lastSeen time.Time
+		gid      uint64
+		events   int
+	}{
+		{gid: 1, events: 2, lastSeen: to.Add(-time.Minute)},
+		{gid: 2, events: 2, lastSeen: to.Add(-5 * time.Minute)},
+		{gid: 3, events: 2, lastSeen: to.Add(-5 * time.Minute)},
+		{gid: 4, events: 3, lastSeen: to.Add(-10 * time.Minute)},
+		{gid: 5, events: 1, lastSeen: to.Add(-2 * time.Minute)},
+	}
+	for _, g := range groups {
+		for i := range g.events {
+			require.NoError(t, store.StoreEvent(ctx, testEvent(g.lastSeen.Add(-time.Duration(i)*time.Minute), g.gid, projectID)))
+		}
+	}
+	require.NoError(t, store.StoreEvent(ctx, testEvent(to.Add(-time.Minute), 6, projectID+1)))
+
+	var (
+		got    []uint64
+		cursor *warnly.IssueCursor
+	)
+	for range len(groups) {
+		metrics, err := store.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+			From:       from,
+			To:         to,
+			ProjectIDs: []int{projectID},
+			After:      cursor,
+			Limit:      pageSize,
+		})
+		require.NoError(t, err)
+		if len(metrics) == 0 {
+			break
+		}
+		require.LessOrEqual(t, len(metrics), pageSize)
+		for _, m := range metrics {
+			got = append(got, m.GID)
+		}
+		last := metrics[len(metrics)-1]
+		cursor = warnly.CursorAt(&warnly.IssueEntry{ID: int64(last.GID), TimesSeen: last.TimesSeen, LastSeen: last.LastSeen})
+	}
+
+	assert.Equal(t, []uint64{4, 1, 3, 2, 5}, got, "every issue once, ordered by times seen, last seen and ID")
+}
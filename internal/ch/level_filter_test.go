@@ -0,0 +1,108 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestLevelFilter(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID   = 1
+		errorGroup  = 3
+		warnGroup   = 4
+		mixedGroup  = 5
+		warnMessage = "slow query"
+	)
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-time.Hour)
+
+	storeEvents := func(groupID uint64, level warnly.Level, n int) {
+		for i := 1; i <= n; i++ {
+			ev := testEvent(to.Add(-time.Duration(i)*time.Minute), groupID, projectID)
+			ev.Level = level
+			if level == warnly.LevelWarning {
+				ev.Message = warnMessage
+			}
+			require.NoError(t, store.StoreEvent(ctx, ev))
+		}
+	}
+	storeEvents(errorGroup, warnly.LevelError, 3)
+	storeEvents(errorGroup, warnly.LevelFatal, 1)
+	storeEvents(warnGroup, warnly.LevelWarning, 2)
+	storeEvents(mixedGroup, warnly.LevelWarning, 3)
+	storeEvents(mixedGroup, warnly.LevelError, 1)
+
+	metrics, err := store.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		From:       from,
+		To:         to,
+		ProjectIDs: []int{projectID},
+		GroupIDs:   []int64{errorGroup, warnGroup, mixedGroup},
+	})
+	require.NoError(t, err)
+	require.Len(t, metrics, 3)
+	levels := map[uint64]warnly.Level{}
+	for _, m := range metrics {
+		levels[m.GID] = m.Level
+	}
+	assert.Equal(t, map[uint64]warnly.Level{
+		errorGroup: warnly.LevelError,
+		warnGroup:  warnly.LevelWarning,
+		mixedGroup: warnly.LevelWarning,
+	}, levels, "the dominant level of every issue")
+
+	metrics, err = store.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		From:       from,
+		To:         to,
+		ProjectIDs: []int{projectID},
+		GroupIDs:   []int64{errorGroup, warnGroup, mixedGroup},
+		Levels:     []string{"warning"},
+	})
+	require.NoError(t, err)
+	times := map[uint64]uint64{}
+	for _, m := range metrics {
+		times[m.GID] = m.TimesSeen
+	}
+	assert.Equal(t, map[uint64]uint64{warnGroup: 2, mixedGroup: 3}, times, "only warnings are counted")
+
+	criteria := &warnly.EventCriteria{
+		From:      from,
+		To:        to,
+		ProjectID: projectID,
+		GroupID:   mixedGroup,
+		Levels:    []string{"warning"},
+		Limit:     100,
+	}
+	count, err := store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	events, err := store.ListEvents(ctx, criteria)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	for _, ev := range events {
+		assert.Equal(t, warnMessage, ev.Message)
+	}
+
+	// the filtered count query is used along with a message filter.
+	criteria.Message = "slow"
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	criteria.Levels = warnly.DefaultIssueLevels
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
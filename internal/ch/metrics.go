@@ -0,0 +1,33 @@
+package ch
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RegisterMetrics records the duration of the store queries labeled by the query name
+// in the warnly_olap_query_duration_seconds histogram.
+func (s *ClickhouseStore) RegisterMetrics(r prometheus.Registerer) {
+	s.queryDuration = promauto.With(r).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "warnly_olap_query_duration_seconds",
+		Help:    "Duration of the analytics store queries in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query"})
+}
+
+// observe starts the span of the named query and returns the function ending it,
+// which also records the query duration once metrics are registered.
+func (s *ClickhouseStore) observe(ctx context.Context, query string) (context.Context, func()) {
+	ctx, span := s.tracer.Start(ctx, "ClickhouseStore."+query)
+	start := time.Now()
+
+	return ctx, func() {
+		if s.queryDuration != nil {
+			s.queryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,39 @@
+package ch
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+)
+
+func TestObserveRecordsQueryDuration(t *testing.T) {
+	t.Parallel()
+
+	store := NewClickhouseStore(nil, svcotel.NewNoopProvider())
+
+	// without registered metrics queries are only traced.
+	_, done := store.observe(t.Context(), "CountEvents")
+	done()
+
+	registry := prometheus.NewRegistry()
+	store.RegisterMetrics(registry)
+
+	_, done = store.observe(t.Context(), "CountEvents")
+	done()
+	_, done = store.observe(t.Context(), "StoreEvent")
+	done()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, "warnly_olap_query_duration_seconds", families[0].GetName())
+	require.Len(t, families[0].GetMetric(), 2)
+	for _, m := range families[0].GetMetric() {
+		require.Equal(t, uint64(1), m.GetHistogram().GetSampleCount())
+	}
+
+	require.Equal(t, 2, testutil.CollectAndCount(registry, "warnly_olap_query_duration_seconds"))
+}
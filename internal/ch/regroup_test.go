@@ -0,0 +1,69 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestListRawEventsAndRegroup(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const projectID = 1
+	start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	var eventIDs []string
+	for i := range 5 {
+		ev := testEvent(start.Add(time.Duration(i)*time.Minute), uint64(i%2+1), projectID)
+		ev.Raw = `{"message":"connection refused"}`
+		require.NoError(t, store.StoreEvent(ctx, ev))
+		eventIDs = append(eventIDs, ev.EventID)
+	}
+	require.NoError(t, store.StoreEvent(ctx, testEvent(start, 1, projectID+1)))
+
+	var listed []warnly.RawEvent
+	criteria := &warnly.RawEventsCriteria{ProjectID: projectID, Limit: 2}
+	for {
+		events, err := store.ListRawEvents(ctx, criteria)
+		require.NoError(t, err)
+		listed = append(listed, events...)
+		if len(events) < criteria.Limit {
+			break
+		}
+		last := events[len(events)-1]
+		criteria.AfterCreatedAt, criteria.AfterEventID = last.CreatedAt, last.EventID
+	}
+
+	require.Len(t, listed, 5)
+	for i := range listed {
+		assert.Equal(t, eventIDs[i], listed[i].EventID)
+		assert.Equal(t, uint64(i%2+1), listed[i].GroupID)
+		assert.JSONEq(t, `{"message":"connection refused"}`, string(listed[i].Raw))
+	}
+
+	// The mutation is applied in the background unless the caller waits for it.
+	syncCtx := clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{"mutations_sync": 1}))
+	require.NoError(t, store.RegroupEvents(syncCtx, &warnly.RegroupEventsCriteria{
+		Moves:     map[uint64][]string{1: {eventIDs[1]}, 2: {eventIDs[0]}},
+		ProjectID: projectID,
+	}))
+
+	count, err := store.CountEvents(ctx, &warnly.EventCriteria{
+		From:      start,
+		To:        start.Add(time.Hour),
+		ProjectID: projectID,
+		GroupID:   1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count, "one event is moved into the first group and one out of it")
+}
@@ -0,0 +1,97 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestReleaseAdoption(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const projectID = 1
+	day0 := time.Now().UTC().Add(-3 * 24 * time.Hour).Truncate(24 * time.Hour)
+	day1 := day0.Add(24 * time.Hour)
+	day2 := day1.Add(24 * time.Hour)
+
+	events := []struct {
+		at        time.Time
+		release   string
+		user      string
+		projectID uint16
+		deleted   uint8
+	}{
+		// 1.0 is used by alice and bob, then alice upgrades to 1.1 where carol shows up.
+		{at: day0.Add(time.Hour), release: "1.0.0", user: "alice", projectID: projectID},
+		{at: day0.Add(2 * time.Hour), release: "1.0.0", user: "alice", projectID: projectID},
+		{at: day0.Add(3 * time.Hour), release: "1.0.0", user: "bob", projectID: projectID},
+		{at: day1.Add(time.Hour), release: "1.0.0", user: "bob", projectID: projectID},
+		{at: day1.Add(2 * time.Hour), release: "1.1.0", user: "alice", projectID: projectID},
+		{at: day1.Add(3 * time.Hour), release: "1.1.0", user: "carol", projectID: projectID},
+		{at: day2.Add(time.Hour), release: "1.1.0", user: "alice", projectID: projectID},
+		{at: day2.Add(2 * time.Hour), release: "1.1.0", user: "", projectID: projectID},
+		// events without a release, of other projects and deleted events don't count.
+		{at: day0.Add(time.Hour), release: "", user: "dave", projectID: projectID},
+		{at: day1.Add(time.Hour), release: "1.1.0", user: "erin", projectID: projectID + 1},
+		{at: day1.Add(time.Hour), release: "1.0.0", user: "frank", projectID: projectID, deleted: 1},
+	}
+	for _, e := range events {
+		ev := testEvent(e.at, 1, e.projectID)
+		ev.Release = e.release
+		ev.User = e.user
+		ev.Deleted = e.deleted
+		require.NoError(t, store.StoreEvent(ctx, ev))
+	}
+
+	releases, err := store.ReleaseAdoption(ctx, &warnly.ReleaseAdoptionCriteria{
+		From:      day0,
+		To:        day2.Add(24 * time.Hour),
+		ProjectID: projectID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []warnly.ReleaseAdoption{
+		{
+			FirstSeen: day1.Add(2 * time.Hour),
+			Release:   "1.1.0",
+			Days: []warnly.ReleaseDay{
+				{Day: day1, Users: 2, Errors: 2},
+				{Day: day2, Users: 1, Errors: 2},
+			},
+			Users:    2,
+			NewUsers: 1,
+			Errors:   4,
+		},
+		{
+			FirstSeen: day0.Add(time.Hour),
+			Release:   "1.0.0",
+			Days: []warnly.ReleaseDay{
+				{Day: day0, Users: 2, Errors: 3},
+				{Day: day1, Users: 1, Errors: 1},
+			},
+			Users:    2,
+			NewUsers: 2,
+			Errors:   4,
+		},
+	}, releases)
+
+	releases, err = store.ReleaseAdoption(ctx, &warnly.ReleaseAdoptionCriteria{
+		From:      day0,
+		To:        day2.Add(24 * time.Hour),
+		ProjectID: projectID,
+		Limit:     1,
+	})
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "1.1.0", releases[0].Release)
+}
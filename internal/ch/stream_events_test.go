@@ -0,0 +1,63 @@
+package ch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		groupID   = 7
+		total     = 25
+	)
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := range total {
+		require.NoError(t, store.StoreEvent(ctx, testEvent(now.Add(-time.Duration(i)*time.Minute), groupID, projectID)))
+	}
+	// events of another issue are not streamed.
+	require.NoError(t, store.StoreEvent(ctx, testEvent(now, groupID+1, projectID)))
+
+	criteria := &warnly.EventCriteria{
+		From:      now.Add(-time.Hour),
+		To:        now.Add(time.Minute),
+		ProjectID: projectID,
+		GroupID:   groupID,
+		Limit:     1000,
+	}
+
+	listed, err := store.ListEvents(ctx, criteria)
+	require.NoError(t, err)
+	require.Len(t, listed, total)
+
+	calls := 0
+	err = store.StreamEvents(ctx, criteria, func(event *warnly.EventEntry) error {
+		assert.Equal(t, listed[calls].EventID, event.EventID)
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, total, calls)
+
+	errStop := errors.New("stop")
+	calls = 0
+	err = store.StreamEvents(ctx, criteria, func(_ *warnly.EventEntry) error {
+		calls++
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,95 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestSuggestTagValues(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const projectID = 1
+	at := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	events := []struct {
+		browser   string
+		times     int
+		projectID uint16
+		deleted   uint8
+	}{
+		{browser: "Chrome", times: 4, projectID: projectID},
+		{browser: "Chromium", times: 2, projectID: projectID},
+		{browser: "chrome mobile", times: 1, projectID: projectID},
+		{browser: "Firefox", times: 3, projectID: projectID},
+		// values of other projects and of deleted events aren't suggested.
+		{browser: "Chrome OS", times: 5, projectID: projectID + 1},
+		{browser: "Chromebook", times: 5, projectID: projectID, deleted: 1},
+	}
+	for _, e := range events {
+		for range e.times {
+			ev := testEvent(at, 1, e.projectID)
+			ev.TagsKey = []string{"browser", "os"}
+			ev.TagsValue = []string{e.browser, "Linux"}
+			ev.Deleted = e.deleted
+			require.NoError(t, store.StoreEvent(ctx, ev))
+		}
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		want   []warnly.TagValueCount
+	}{
+		{
+			name:   "prefix matches values ignoring case",
+			prefix: "chr",
+			limit:  10,
+			want: []warnly.TagValueCount{
+				{Value: "Chrome", Count: 4},
+				{Value: "Chromium", Count: 2},
+				{Value: "chrome mobile", Count: 1},
+			},
+		},
+		{
+			name:   "empty prefix returns the top values",
+			prefix: "",
+			limit:  2,
+			want: []warnly.TagValueCount{
+				{Value: "Chrome", Count: 4},
+				{Value: "Firefox", Count: 3},
+			},
+		},
+		{
+			name:   "no matches",
+			prefix: "safari",
+			limit:  10,
+			want:   []warnly.TagValueCount{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := store.SuggestTagValues(ctx, &warnly.SuggestTagValuesCriteria{
+				From:      at.Add(-time.Hour),
+				To:        at.Add(time.Hour),
+				Tag:       "browser",
+				Prefix:    tt.prefix,
+				ProjectID: projectID,
+				Limit:     tt.limit,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
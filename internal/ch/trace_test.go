@@ -0,0 +1,66 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestListEventsByTrace(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// a failure of the api and the failure of the database client it caused share the trace.
+	api := testEvent(now.Add(-time.Minute), 7, 1)
+	api.TraceID = traceID
+	api.Title = "upstream failed"
+	db := testEvent(now.Add(-2*time.Minute), 8, 2)
+	db.TraceID = traceID
+	db.Title = "connection refused"
+	other := testEvent(now, 7, 1)
+	other.TraceID = "0af7651916cd43dd8448eb211c80319c"
+	untraced := testEvent(now, 9, 1)
+
+	for _, ev := range []*warnly.EventClickhouse{api, db, other, untraced} {
+		require.NoError(t, store.StoreEvent(ctx, ev))
+	}
+
+	events, err := store.ListEventsByTrace(ctx, traceID, []int{1, 2})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(7), events[0].GroupID)
+	assert.Equal(t, 1, events[0].ProjectID)
+	assert.Equal(t, "upstream failed", events[0].Title)
+	assert.Equal(t, int64(8), events[1].GroupID)
+	assert.Equal(t, 2, events[1].ProjectID)
+	assert.Equal(t, "connection refused", events[1].Title)
+
+	events, err = store.ListEventsByTrace(ctx, traceID, []int{1})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(7), events[0].GroupID)
+
+	events, err = store.ListEventsByTrace(ctx, "", []int{1, 2})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	ev, err := store.GetIssueEvent(ctx, &warnly.EventDefCriteria{
+		GroupID:   8,
+		ProjectID: 2,
+		From:      now.Add(-time.Hour),
+		To:        now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, traceID, ev.TraceID)
+}
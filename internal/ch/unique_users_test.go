@@ -0,0 +1,64 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestCountUniqueUsers(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID      = 1
+		otherProjectID = 2
+	)
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-24 * time.Hour)
+
+	events := []struct {
+		at        time.Time
+		user      string
+		gid       uint64
+		projectID uint16
+		deleted   uint8
+	}{
+		// alice is counted once across both issues of the project.
+		{at: to.Add(-time.Hour), user: "alice", gid: 1, projectID: projectID},
+		{at: to.Add(-2 * time.Hour), user: "alice", gid: 2, projectID: projectID},
+		{at: to.Add(-3 * time.Hour), user: "bob", gid: 1, projectID: projectID},
+		{at: to.Add(-4 * time.Hour), user: "carol", gid: 2, projectID: projectID},
+		{at: to.Add(-5 * time.Hour), user: "dave", gid: 3, projectID: otherProjectID},
+		// anonymous, deleted and out of period events don't count.
+		{at: to.Add(-time.Hour), user: "", gid: 1, projectID: projectID},
+		{at: to.Add(-time.Hour), user: "erin", gid: 1, projectID: projectID, deleted: 1},
+		{at: from.Add(-time.Hour), user: "frank", gid: 1, projectID: projectID},
+	}
+	for _, e := range events {
+		ev := testEvent(e.at, e.gid, e.projectID)
+		ev.User = e.user
+		ev.Deleted = e.deleted
+		require.NoError(t, store.StoreEvent(ctx, ev))
+	}
+
+	users, err := store.CountUniqueUsers(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: []int{projectID, otherProjectID, 3},
+		From:       from,
+		To:         to,
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []warnly.ProjectUsers{
+		{ProjectID: projectID, Count: 3},
+		{ProjectID: otherProjectID, Count: 1},
+	}, users)
+}
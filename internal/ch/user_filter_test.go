@@ -0,0 +1,101 @@
+package ch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/svcotel"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestUserFilter(t *testing.T) {
+	t.Parallel()
+
+	conn, _ := testInstance.NewDatabase(t)
+	store := NewClickhouseStore(conn, svcotel.NewNoopProvider())
+	store.EnableAsyncInsertWait()
+
+	ctx := t.Context()
+	const (
+		projectID = 1
+		groupID   = 7
+	)
+	to := time.Now().UTC().Truncate(time.Second)
+	from := to.Add(-time.Hour)
+
+	users := []struct {
+		id    string
+		email string
+		n     int
+	}{
+		// the users are stored the way the ingestion stores them, the IDs prefixed with "id:".
+		{id: "id:42", email: "jane@example.com", n: 2},
+		{id: "id:43", email: "john@example.com", n: 3},
+	}
+	minute := 0
+	for _, u := range users {
+		for range u.n {
+			minute++
+			ev := testEvent(to.Add(-time.Duration(minute)*time.Minute), groupID, projectID)
+			ev.User = u.id
+			ev.UserEmail = u.email
+			require.NoError(t, store.StoreEvent(ctx, ev))
+		}
+	}
+
+	criteria := &warnly.EventCriteria{
+		From:      from,
+		To:        to,
+		ProjectID: projectID,
+		GroupID:   groupID,
+		Limit:     100,
+	}
+	count, err := store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+
+	criteria.UserEmail = "jane@example.com"
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	events, err := store.ListEvents(ctx, criteria)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	for _, ev := range events {
+		assert.Equal(t, "jane@example.com", ev.UserEmail)
+		assert.Equal(t, "id:42", ev.User)
+	}
+
+	criteria.UserEmail = ""
+	criteria.UserID = "43"
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), count)
+
+	events, err = store.ListEvents(ctx, criteria)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	for _, ev := range events {
+		assert.Equal(t, "john@example.com", ev.UserEmail)
+	}
+
+	criteria.UserEmail = "jane@example.com"
+	count, err = store.CountEvents(ctx, criteria)
+	require.NoError(t, err)
+	assert.Zero(t, count, "the email and the ID of different users match no events")
+
+	other := testEvent(to.Add(-time.Minute), groupID+1, projectID)
+	other.User = "id:44"
+	require.NoError(t, store.StoreEvent(ctx, other))
+
+	gids, err := store.GetFilteredGroupIDs(ctx, warnly.ParseQuery("user:42"), from, to, []int{projectID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{groupID}, gids, "the issues are filtered by the user of their events")
+
+	gids, err = store.GetFilteredGroupIDs(ctx, warnly.ParseQuery("user:john@example.com"), from, to, []int{projectID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{groupID}, gids)
+}
@@ -0,0 +1,144 @@
+// Package filestore provides stores keeping data as files, on the local filesystem or in an object store.
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+const (
+	// filesDir holds attachment contents, metaDir holds their metadata as JSON.
+	filesDir = "files"
+	metaDir  = "meta"
+)
+
+// AttachmentStore implements warnly.AttachmentStore keeping attachments in an object store.
+// Attachments of an event are stored under <project id>/<event id>/.
+type AttachmentStore struct {
+	objects warnly.ObjectStore
+}
+
+// NewAttachmentStore returns a store keeping attachments in the object store.
+func NewAttachmentStore(objects warnly.ObjectStore) *AttachmentStore {
+	return &AttachmentStore{objects: objects}
+}
+
+// StoreAttachment writes the attachment content and then its metadata,
+// so an attachment is visible only when it is completely written.
+func (s *AttachmentStore) StoreAttachment(ctx context.Context, a *warnly.Attachment) error {
+	dir, err := eventDir(a.ProjectID, a.EventID)
+	if err != nil {
+		return err
+	}
+	if err := validateFilename(a.Filename); err != nil {
+		return err
+	}
+
+	if err := s.objects.PutObject(ctx, path.Join(dir, filesDir, a.Filename), a.Data, a.ContentType); err != nil {
+		return fmt.Errorf("filestore attachment store: write attachment: %w", err)
+	}
+
+	meta, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("filestore attachment store: marshal metadata: %w", err)
+	}
+	if err := s.objects.PutObject(ctx, path.Join(dir, metaDir, a.Filename+".json"), meta, "application/json"); err != nil {
+		return fmt.Errorf("filestore attachment store: write metadata: %w", err)
+	}
+
+	return nil
+}
+
+// ListAttachments lists attachments of the event without their data.
+func (s *AttachmentStore) ListAttachments(ctx context.Context, projectID int, eventID string) ([]warnly.Attachment, error) {
+	dir, err := eventDir(projectID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.objects.ListObjects(ctx, path.Join(dir, metaDir)+"/")
+	if err != nil {
+		return nil, fmt.Errorf("filestore attachment store: list attachments: %w", err)
+	}
+	slices.Sort(keys)
+
+	attachments := make([]warnly.Attachment, 0, len(keys))
+	for _, key := range keys {
+		name, ok := strings.CutSuffix(path.Base(key), ".json")
+		if !ok {
+			continue
+		}
+		a, err := s.readMeta(ctx, dir, name)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *a)
+	}
+
+	return attachments, nil
+}
+
+// GetAttachment returns the attachment with its data.
+func (s *AttachmentStore) GetAttachment(
+	ctx context.Context,
+	projectID int,
+	eventID, filename string,
+) (*warnly.Attachment, error) {
+	dir, err := eventDir(projectID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateFilename(filename); err != nil {
+		return nil, err
+	}
+
+	a, err := s.readMeta(ctx, dir, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Data, err = s.objects.GetObject(ctx, path.Join(dir, filesDir, filename))
+	if err != nil {
+		return nil, fmt.Errorf("filestore attachment store: read attachment: %w", err)
+	}
+
+	return a, nil
+}
+
+// readMeta reads the metadata of the attachment stored in the event directory.
+func (s *AttachmentStore) readMeta(ctx context.Context, dir, filename string) (*warnly.Attachment, error) {
+	b, err := s.objects.GetObject(ctx, path.Join(dir, metaDir, filename+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("filestore attachment store: read metadata: %w", err)
+	}
+
+	a := &warnly.Attachment{}
+	if err := json.Unmarshal(b, a); err != nil {
+		return nil, fmt.Errorf("filestore attachment store: unmarshal metadata: %w", err)
+	}
+
+	return a, nil
+}
+
+// eventDir returns the directory of the event attachments.
+func eventDir(projectID int, eventID string) (string, error) {
+	if eventID == "" || strings.ContainsAny(eventID, `/\.`) {
+		return "", fmt.Errorf("%w: event id %q", warnly.ErrInvalidAttachment, eventID)
+	}
+	return path.Join(strconv.Itoa(projectID), eventID), nil
+}
+
+// validateFilename rejects names that can't be stored as a single file.
+func validateFilename(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("%w: filename %q", warnly.ErrInvalidAttachment, name)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package filestore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/filestore"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestAttachmentStore(t *testing.T) {
+	t.Parallel()
+
+	objects, err := filestore.NewObjectStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = objects.Close() })
+	store := filestore.NewAttachmentStore(objects)
+
+	ctx := t.Context()
+	const eventID = "3708a788c39c44508a3c9442214b2f9f"
+	createdAt := time.Date(2025, 10, 4, 2, 33, 58, 0, time.UTC)
+
+	screenshot := &warnly.Attachment{
+		CreatedAt:   createdAt,
+		EventID:     eventID,
+		Filename:    "screenshot.png",
+		ContentType: "image/png",
+		Data:        []byte{0x89, 'P', 'N', 'G', '\n', 0x00},
+		Size:        6,
+		ProjectID:   1,
+	}
+	require.NoError(t, store.StoreAttachment(ctx, screenshot))
+
+	got, err := store.GetAttachment(ctx, 1, eventID, "screenshot.png")
+	require.NoError(t, err)
+	assert.Equal(t, screenshot, got)
+
+	list, err := store.ListAttachments(ctx, 1, eventID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "screenshot.png", list[0].Filename)
+	assert.Equal(t, 6, list[0].Size)
+	assert.Nil(t, list[0].Data)
+
+	_, err = store.GetAttachment(ctx, 2, eventID, "screenshot.png")
+	require.ErrorIs(t, err, warnly.ErrNotFound)
+
+	list, err = store.ListAttachments(ctx, 2, eventID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	err = store.StoreAttachment(ctx, &warnly.Attachment{EventID: eventID, Filename: "../escape", ProjectID: 1})
+	require.ErrorIs(t, err, warnly.ErrInvalidAttachment)
+
+	_, err = store.GetAttachment(ctx, 1, "..", "screenshot.png")
+	require.ErrorIs(t, err, warnly.ErrInvalidAttachment)
+}
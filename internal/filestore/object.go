@@ -0,0 +1,114 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// ObjectStore implements warnly.ObjectStore keeping every object as a file in a directory,
+// slashes of the object key separate nested directories.
+type ObjectStore struct {
+	root *os.Root
+}
+
+// NewObjectStore creates the directory if needed and returns a store rooted in it.
+func NewObjectStore(dir string) (*ObjectStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("filestore object store: create dir: %w", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("filestore object store: open dir: %w", err)
+	}
+	return &ObjectStore{root: root}, nil
+}
+
+// Close releases the store directory.
+func (s *ObjectStore) Close() error {
+	return s.root.Close()
+}
+
+// PutObject writes the object file, the content type is not kept.
+func (s *ObjectStore) PutObject(_ context.Context, key string, data []byte, _ string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := s.root.MkdirAll(path.Dir(key), 0o750); err != nil {
+		return fmt.Errorf("filestore object store: create dir: %w", err)
+	}
+	if err := s.root.WriteFile(key, data, 0o640); err != nil {
+		return fmt.Errorf("filestore object store: write object: %w", err)
+	}
+	return nil
+}
+
+// GetObject reads the object file.
+func (s *ObjectStore) GetObject(_ context.Context, key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	data, err := s.root.ReadFile(key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("filestore object store: read object: %w", warnly.ErrNotFound)
+		}
+		return nil, fmt.Errorf("filestore object store: read object: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteObject removes the object file.
+func (s *ObjectStore) DeleteObject(_ context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := s.root.Remove(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("filestore object store: remove object: %w", err)
+	}
+	return nil
+}
+
+// ListObjects walks the directory of the prefix and returns keys of the files in it.
+func (s *ObjectStore) ListObjects(_ context.Context, prefix string) ([]string, error) {
+	dir, ok := strings.CutSuffix(prefix, "/")
+	if !ok {
+		return nil, fmt.Errorf("filestore object store: prefix %q doesn't end with a slash", prefix)
+	}
+	if err := validateKey(dir); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	err := fs.WalkDir(s.root.FS(), dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			keys = append(keys, name)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("filestore object store: list objects: %w", err)
+	}
+
+	return keys, nil
+}
+
+// validateKey rejects keys that aren't clean relative paths.
+func validateKey(key string) error {
+	if key == "" || path.Clean(key) != key || path.IsAbs(key) || key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("filestore object store: invalid key %q", key)
+	}
+	return nil
+}
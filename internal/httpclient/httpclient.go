@@ -0,0 +1,106 @@
+// Package httpclient builds the HTTP client shared by outbound integrations
+// such as webhook notifications.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Config is the configuration of the outbound HTTP client.
+type Config struct {
+	// TLS configures client certificates (mTLS) and a custom CA for outbound requests.
+	TLS struct {
+		CertFile string `env:"OUTBOUND_HTTP_TLS_CERT_FILE"`
+		KeyFile  string `env:"OUTBOUND_HTTP_TLS_KEY_FILE"`
+		CAFile   string `env:"OUTBOUND_HTTP_TLS_CA_FILE"`
+	}
+	// ProxyURL routes all outbound requests through the proxy,
+	// when empty the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables are used.
+	ProxyURL            string        `env:"OUTBOUND_HTTP_PROXY_URL"`
+	Timeout             time.Duration `env:"OUTBOUND_HTTP_TIMEOUT"                 env-default:"10s"`
+	IdleConnTimeout     time.Duration `env:"OUTBOUND_HTTP_IDLE_CONN_TIMEOUT"       env-default:"90s"`
+	MaxIdleConns        int           `env:"OUTBOUND_HTTP_MAX_IDLE_CONNS"          env-default:"100"`
+	MaxIdleConnsPerHost int           `env:"OUTBOUND_HTTP_MAX_IDLE_CONNS_PER_HOST" env-default:"10"`
+}
+
+// New creates an HTTP client configured for outbound integration requests.
+func New(cfg *Config) (*http.Client, error) {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("httpclient: default transport is not *http.Transport")
+	}
+	transport = transport.Clone()
+
+	transport.Proxy = http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: parse proxy url: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("httpclient: proxy url %q must include scheme and host", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+
+	tlsCfg, err := buildTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   cfg.Timeout,
+	}, nil
+}
+
+//nolint:nilnil // nil means the transport keeps its default TLS configuration.
+func buildTLSConfig(cfg *Config) (*tls.Config, error) {
+	if cfg.TLS.CertFile == "" && cfg.TLS.KeyFile == "" && cfg.TLS.CAFile == "" {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.TLS.CertFile != "" || cfg.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: load tls key pair: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	if cfg.TLS.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: read tls ca file: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("httpclient: failed to append CA certificate")
+		}
+		tlsCfg.RootCAs = caCertPool
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,124 @@
+package httpclient_test
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/httpclient"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/notifier"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func newWebhookNotifier(client *http.Client) *notifier.WebhookNotifier {
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(context.Context, *warnly.WebhookDeadLetter) error { return nil },
+	}
+	return notifier.NewWebhookNotifier(store, []byte("encryption-key"), client, notifier.RetryPolicy{},
+		time.Now, slog.Default())
+}
+
+func TestNewRoutesIntegrationRequestsThroughProxy(t *testing.T) {
+	t.Parallel()
+
+	proxied := make(chan *http.Request, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(proxy.Close)
+
+	cfg := &httpclient.Config{ProxyURL: proxy.URL, Timeout: 5 * time.Second}
+	client, err := httpclient.New(cfg)
+	require.NoError(t, err)
+
+	err = newWebhookNotifier(client).SendAlertTriggered(t.Context(), &warnly.Alert{ID: 1},
+		&warnly.WebhookConfig{URL: "http://hooks.example.invalid/warnly"})
+	require.NoError(t, err)
+
+	req := <-proxied
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "hooks.example.invalid", req.Host)
+	assert.Equal(t, "/warnly", req.URL.Path)
+}
+
+func TestNewAppliesTimeoutToIntegrationRequests(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	const timeout = 50 * time.Millisecond
+
+	client, err := httpclient.New(&httpclient.Config{Timeout: timeout})
+	require.NoError(t, err)
+	assert.Equal(t, timeout, client.Timeout)
+
+	start := time.Now()
+	err = newWebhookNotifier(client).SendAlertTriggered(t.Context(), &warnly.Alert{ID: 1},
+		&warnly.WebhookConfig{URL: slow.URL})
+	require.Error(t, err)
+
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestNewTrustsConfiguredCA(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	untrusted, err := httpclient.New(&httpclient.Config{Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	_, err = untrusted.Get(srv.URL) //nolint:noctx // test request
+	require.Error(t, err)
+
+	cfg := &httpclient.Config{Timeout: 5 * time.Second}
+	cfg.TLS.CAFile = caFile
+	trusted, err := httpclient.New(cfg)
+	require.NoError(t, err)
+
+	resp, err := trusted.Get(srv.URL) //nolint:noctx // test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := httpclient.New(&httpclient.Config{ProxyURL: "proxy.internal:3128"})
+	require.Error(t, err)
+
+	cfg := &httpclient.Config{}
+	cfg.TLS.CertFile = filepath.Join(t.TempDir(), "missing.pem")
+	cfg.TLS.KeyFile = filepath.Join(t.TempDir(), "missing.key")
+	_, err = httpclient.New(cfg)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -26,8 +26,8 @@ const (
 )
 
 var expectedVersions = map[Driver]uint{
-	MySQL:      0,
-	Clickhouse: 0,
+	MySQL:      31,
+	Clickhouse: 7,
 }
 
 var driverToString = map[Driver]string{
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// ActivityStore is a mock implementation of warnly.ActivityStore.
+type ActivityStore struct {
+	CreateActivityFn    func(ctx context.Context, activity *warnly.Activity) error
+	ListIssueActivityFn func(ctx context.Context, issueID int64) ([]warnly.IssueActivity, error)
+}
+
+func (m *ActivityStore) CreateActivity(ctx context.Context, activity *warnly.Activity) error {
+	return m.CreateActivityFn(ctx, activity)
+}
+
+func (m *ActivityStore) ListIssueActivity(ctx context.Context, issueID int64) ([]warnly.IssueActivity, error) {
+	return m.ListIssueActivityFn(ctx, issueID)
+}
@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// AlertStore is a mock implementation of warnly.AlertStore.
+type AlertStore struct {
+	ListAlertsFn          func(ctx context.Context, teamIDs []int, projectName string, offset, limit int) ([]warnly.Alert, int, error)
+	CreateAlertFn         func(ctx context.Context, alert *warnly.Alert) error
+	UpdateAlertFn         func(ctx context.Context, alert *warnly.Alert) error
+	DeleteAlertFn         func(ctx context.Context, alertID int) error
+	GetAlertFn            func(ctx context.Context, alertID int) (*warnly.Alert, error)
+	ListAlertsByProjectFn func(ctx context.Context, projectID int) ([]warnly.Alert, error)
+	AcknowledgeAlertFn    func(ctx context.Context, ackToken string, at time.Time) error
+}
+
+func (m *AlertStore) ListAlerts(
+	ctx context.Context,
+	teamIDs []int,
+	projectName string,
+	offset, limit int,
+) ([]warnly.Alert, int, error) {
+	return m.ListAlertsFn(ctx, teamIDs, projectName, offset, limit)
+}
+
+func (m *AlertStore) CreateAlert(ctx context.Context, alert *warnly.Alert) error {
+	return m.CreateAlertFn(ctx, alert)
+}
+
+func (m *AlertStore) UpdateAlert(ctx context.Context, alert *warnly.Alert) error {
+	return m.UpdateAlertFn(ctx, alert)
+}
+
+func (m *AlertStore) DeleteAlert(ctx context.Context, alertID int) error {
+	return m.DeleteAlertFn(ctx, alertID)
+}
+
+func (m *AlertStore) GetAlert(ctx context.Context, alertID int) (*warnly.Alert, error) {
+	return m.GetAlertFn(ctx, alertID)
+}
+
+func (m *AlertStore) ListAlertsByProject(ctx context.Context, projectID int) ([]warnly.Alert, error) {
+	return m.ListAlertsByProjectFn(ctx, projectID)
+}
+
+func (m *AlertStore) AcknowledgeAlert(ctx context.Context, ackToken string, at time.Time) error {
+	return m.AcknowledgeAlertFn(ctx, ackToken, at)
+}
@@ -12,6 +12,10 @@ import (
 //nolint:lll // ignore
 type AnalyticsStore struct {
 	CalculateEventsFn       func(ctx context.Context, criteria *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error)
+	CalculateEventsByEnvFn  func(ctx context.Context, criteria *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error)
+	CalculateIssueEventsFn  func(ctx context.Context, criteria *warnly.ListIssueMetricsCriteria) ([]warnly.IssueEventsPerHour, error)
+	EventSeriesForIssuesFn  func(ctx context.Context, gids []int64, from, to time.Time, projectIDs []int) ([]warnly.IssueEventsPerHour, error)
+	CountUniqueUsersFn      func(ctx context.Context, criteria *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error)
 	CalculateFieldsFn       func(ctx context.Context, criteria warnly.FieldsCriteria) ([]warnly.TagCount, error)
 	CountFieldsFn           func(ctx context.Context, criteria *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error)
 	ListEventsFn            func(ctx context.Context, criteria *warnly.EventCriteria) ([]warnly.EventEntry, error)
@@ -19,15 +23,30 @@ type AnalyticsStore struct {
 	ListIssueMetricsFn      func(ctx context.Context, criteria *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error)
 	CalculateEventsPerDayFn func(ctx context.Context, criteria *warnly.EventDefCriteria) ([]warnly.EventPerDay, error)
 	GetIssueEventFn         func(ctx context.Context, criteria *warnly.EventDefCriteria) (*warnly.IssueEvent, error)
+	GetRawEventFn           func(ctx context.Context, criteria *warnly.EventDefCriteria) ([]byte, error)
 	ListSlowQueriesFn       func(ctx context.Context) ([]warnly.SQLQuery, error)
 	ListSchemasFn           func(ctx context.Context) ([]warnly.Schema, error)
 	ListErrorsFn            func(ctx context.Context, criteria warnly.ListErrorsCriteria) ([]warnly.AnalyticsStoreErr, error)
 	StoreEventFn            func(ctx context.Context, event *warnly.EventClickhouse) error
+	StoreEventSyncFn        func(ctx context.Context, event *warnly.EventClickhouse) error
 	ListFieldFiltersFn      func(ctx context.Context, criteria *warnly.FieldFilterCriteria) ([]warnly.Filter, error)
 	ListPopularTagsFn       func(ctx context.Context, criteria *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error)
 	ListTagValuesFn         func(ctx context.Context, criteria *warnly.ListTagValuesCriteria) ([]warnly.TagValueCount, error)
+	DeleteProjectEventsFn   func(ctx context.Context, projectID int) error
+	HasEventFn              func(ctx context.Context, projectID int, eventID string, since time.Time) (bool, error)
+	ListEventsByTraceFn     func(ctx context.Context, traceID string, projectIDs []int) ([]warnly.TraceEvent, error)
+	SuggestTagValuesFn      func(ctx context.Context, criteria *warnly.SuggestTagValuesCriteria) ([]warnly.TagValueCount, error)
 	GetFilteredGroupIDsFn   func(ctx context.Context, tokens []warnly.QueryToken, from, to time.Time, projectIDs []int) ([]int64, error)
+	ListUnhandledGroupIDsFn func(ctx context.Context, from, to time.Time, projectIDs []int) ([]int64, error)
+	SearchIssuesFn          func(ctx context.Context, query string, criteria *warnly.SearchIssuesCriteria) ([]warnly.IssueMatch, error)
 	GetEventPaginationFn    func(ctx context.Context, c *warnly.EventPaginationCriteria) (*warnly.EventPagination, error)
+	RollingBaselineFn       func(ctx context.Context, criteria *warnly.RollingBaselineCriteria) (*warnly.RollingBaseline, error)
+	CalculateEventGapsFn    func(ctx context.Context, criteria *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error)
+	ReleaseAdoptionFn       func(ctx context.Context, criteria *warnly.ReleaseAdoptionCriteria) ([]warnly.ReleaseAdoption, error)
+	CompareWindowsFn        func(ctx context.Context, criteria *warnly.CompareWindowsCriteria) ([]warnly.IssueWindowCounts, error)
+	StreamEventsFn          func(ctx context.Context, criteria *warnly.EventCriteria, fn func(event *warnly.EventEntry) error) error
+	ListRawEventsFn         func(ctx context.Context, criteria *warnly.RawEventsCriteria) ([]warnly.RawEvent, error)
+	RegroupEventsFn         func(ctx context.Context, criteria *warnly.RegroupEventsCriteria) error
 }
 
 func (m *AnalyticsStore) CalculateEvents(
@@ -37,6 +56,36 @@ func (m *AnalyticsStore) CalculateEvents(
 	return m.CalculateEventsFn(ctx, criteria)
 }
 
+func (m *AnalyticsStore) CalculateIssueEvents(
+	ctx context.Context,
+	criteria *warnly.ListIssueMetricsCriteria,
+) ([]warnly.IssueEventsPerHour, error) {
+	return m.CalculateIssueEventsFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) EventSeriesForIssues(
+	ctx context.Context,
+	gids []int64,
+	from, to time.Time,
+	projectIDs []int,
+) ([]warnly.IssueEventsPerHour, error) {
+	return m.EventSeriesForIssuesFn(ctx, gids, from, to, projectIDs)
+}
+
+func (m *AnalyticsStore) CountUniqueUsers(
+	ctx context.Context,
+	criteria *warnly.ListIssueMetricsCriteria,
+) ([]warnly.ProjectUsers, error) {
+	return m.CountUniqueUsersFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) CalculateEventsByEnv(
+	ctx context.Context,
+	criteria *warnly.ListIssueMetricsCriteria,
+) ([]warnly.EventsPerEnvHour, error) {
+	return m.CalculateEventsByEnvFn(ctx, criteria)
+}
+
 func (m *AnalyticsStore) CalculateFields(
 	ctx context.Context,
 	criteria warnly.FieldsCriteria,
@@ -86,6 +135,10 @@ func (m *AnalyticsStore) GetIssueEvent(
 	return m.GetIssueEventFn(ctx, criteria)
 }
 
+func (m *AnalyticsStore) GetRawEvent(ctx context.Context, criteria *warnly.EventDefCriteria) ([]byte, error) {
+	return m.GetRawEventFn(ctx, criteria)
+}
+
 func (m *AnalyticsStore) ListSlowQueries(ctx context.Context) ([]warnly.SQLQuery, error) {
 	return m.ListSlowQueriesFn(ctx)
 }
@@ -139,6 +192,23 @@ func (m *AnalyticsStore) GetFilteredGroupIDs(
 	return m.GetFilteredGroupIDsFn(ctx, tokens, from, to, projectIDs)
 }
 
+func (m *AnalyticsStore) SearchIssues(
+	ctx context.Context,
+	query string,
+	criteria *warnly.SearchIssuesCriteria,
+) ([]warnly.IssueMatch, error) {
+	return m.SearchIssuesFn(ctx, query, criteria)
+}
+
+func (m *AnalyticsStore) ListUnhandledGroupIDs(
+	ctx context.Context,
+	from,
+	to time.Time,
+	projectIDs []int,
+) ([]int64, error) {
+	return m.ListUnhandledGroupIDsFn(ctx, from, to, projectIDs)
+}
+
 func (m *AnalyticsStore) GetEventPagination(
 	ctx context.Context,
 	c *warnly.EventPaginationCriteria,
@@ -148,3 +218,73 @@ func (m *AnalyticsStore) GetEventPagination(
 	}
 	return &warnly.EventPagination{}, nil
 }
+
+func (m *AnalyticsStore) RollingBaseline(
+	ctx context.Context,
+	criteria *warnly.RollingBaselineCriteria,
+) (*warnly.RollingBaseline, error) {
+	return m.RollingBaselineFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) CalculateEventGaps(
+	ctx context.Context,
+	criteria *warnly.EventDefCriteria,
+) ([]warnly.EventGapBucket, error) {
+	return m.CalculateEventGapsFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) ReleaseAdoption(
+	ctx context.Context,
+	criteria *warnly.ReleaseAdoptionCriteria,
+) ([]warnly.ReleaseAdoption, error) {
+	return m.ReleaseAdoptionFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) CompareWindows(
+	ctx context.Context,
+	criteria *warnly.CompareWindowsCriteria,
+) ([]warnly.IssueWindowCounts, error) {
+	return m.CompareWindowsFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) SuggestTagValues(
+	ctx context.Context,
+	criteria *warnly.SuggestTagValuesCriteria,
+) ([]warnly.TagValueCount, error) {
+	return m.SuggestTagValuesFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) StreamEvents(
+	ctx context.Context,
+	criteria *warnly.EventCriteria,
+	fn func(event *warnly.EventEntry) error,
+) error {
+	return m.StreamEventsFn(ctx, criteria, fn)
+}
+
+func (m *AnalyticsStore) ListRawEvents(
+	ctx context.Context,
+	criteria *warnly.RawEventsCriteria,
+) ([]warnly.RawEvent, error) {
+	return m.ListRawEventsFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) RegroupEvents(ctx context.Context, criteria *warnly.RegroupEventsCriteria) error {
+	return m.RegroupEventsFn(ctx, criteria)
+}
+
+func (m *AnalyticsStore) DeleteProjectEvents(ctx context.Context, projectID int) error {
+	return m.DeleteProjectEventsFn(ctx, projectID)
+}
+
+func (m *AnalyticsStore) HasEvent(ctx context.Context, projectID int, eventID string, since time.Time) (bool, error) {
+	return m.HasEventFn(ctx, projectID, eventID, since)
+}
+
+func (m *AnalyticsStore) ListEventsByTrace(ctx context.Context, traceID string, projectIDs []int) ([]warnly.TraceEvent, error) {
+	return m.ListEventsByTraceFn(ctx, traceID, projectIDs)
+}
+
+func (m *AnalyticsStore) StoreEventSync(ctx context.Context, event *warnly.EventClickhouse) error {
+	return m.StoreEventSyncFn(ctx, event)
+}
@@ -8,10 +8,14 @@ import (
 
 // AssingmentStore is a mock implementation of warnly.AssingmentStore.
 type AssingmentStore struct {
-	ListAssingmentsFn     func(ctx context.Context, issueIDs []int64) ([]*warnly.AssignedUser, error)
-	CreateAssingmentFn    func(ctx context.Context, assignment *warnly.Assignment) error
-	DeleteAssignmentFn    func(ctx context.Context, issueID int64) error
-	ListAssignedFiltersFn func(ctx context.Context, criteria *warnly.GetAssignedFiltersCriteria) ([]warnly.Filter, error)
+	ListAssingmentsFn        func(ctx context.Context, issueIDs []int64) ([]*warnly.AssignedUser, error)
+	CreateAssingmentFn       func(ctx context.Context, assignment *warnly.Assignment) error
+	DeleteAssignmentFn       func(ctx context.Context, issueID int64) error
+	ListProjectAssingmentsFn func(ctx context.Context, projectID int) ([]*warnly.AssignedUser, error)
+	ListAssignedFiltersFn    func(ctx context.Context, criteria *warnly.GetAssignedFiltersCriteria) ([]warnly.Filter, error)
+
+	GetLastRotationAssigneeFn  func(ctx context.Context, teamID int) (int64, error)
+	SaveLastRotationAssigneeFn func(ctx context.Context, teamID int, userID int64) error
 }
 
 func (m *AssingmentStore) ListAssingments(ctx context.Context, issueIDs []int64) ([]*warnly.AssignedUser, error) {
@@ -26,9 +30,21 @@ func (m *AssingmentStore) DeleteAssignment(ctx context.Context, issueID int64) e
 	return m.DeleteAssignmentFn(ctx, issueID)
 }
 
+func (m *AssingmentStore) ListProjectAssingments(ctx context.Context, projectID int) ([]*warnly.AssignedUser, error) {
+	return m.ListProjectAssingmentsFn(ctx, projectID)
+}
+
 func (m *AssingmentStore) ListAssignedFilters(
 	ctx context.Context,
 	criteria *warnly.GetAssignedFiltersCriteria,
 ) ([]warnly.Filter, error) {
 	return m.ListAssignedFiltersFn(ctx, criteria)
 }
+
+func (m *AssingmentStore) GetLastRotationAssignee(ctx context.Context, teamID int) (int64, error) {
+	return m.GetLastRotationAssigneeFn(ctx, teamID)
+}
+
+func (m *AssingmentStore) SaveLastRotationAssignee(ctx context.Context, teamID int, userID int64) error {
+	return m.SaveLastRotationAssigneeFn(ctx, teamID, userID)
+}
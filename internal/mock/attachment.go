@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// AttachmentStore is a mock implementation of warnly.AttachmentStore.
+type AttachmentStore struct {
+	StoreAttachmentFn func(ctx context.Context, attachment *warnly.Attachment) error
+	ListAttachmentsFn func(ctx context.Context, projectID int, eventID string) ([]warnly.Attachment, error)
+	GetAttachmentFn   func(ctx context.Context, projectID int, eventID, filename string) (*warnly.Attachment, error)
+}
+
+func (m *AttachmentStore) StoreAttachment(ctx context.Context, attachment *warnly.Attachment) error {
+	return m.StoreAttachmentFn(ctx, attachment)
+}
+
+func (m *AttachmentStore) ListAttachments(ctx context.Context, projectID int, eventID string) ([]warnly.Attachment, error) {
+	return m.ListAttachmentsFn(ctx, projectID, eventID)
+}
+
+func (m *AttachmentStore) GetAttachment(
+	ctx context.Context,
+	projectID int,
+	eventID, filename string,
+) (*warnly.Attachment, error) {
+	return m.GetAttachmentFn(ctx, projectID, eventID, filename)
+}
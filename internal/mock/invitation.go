@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// InvitationStore is a mock implementation of warnly.InvitationStore.
+type InvitationStore struct {
+	CreateInvitationFn func(ctx context.Context, invitation *warnly.Invitation) (int64, error)
+	GetInvitationFn    func(ctx context.Context, id int64) (*warnly.Invitation, error)
+	AcceptInvitationFn func(ctx context.Context, id int64, at time.Time) error
+}
+
+func (m *InvitationStore) CreateInvitation(ctx context.Context, invitation *warnly.Invitation) (int64, error) {
+	return m.CreateInvitationFn(ctx, invitation)
+}
+
+func (m *InvitationStore) GetInvitation(ctx context.Context, id int64) (*warnly.Invitation, error) {
+	return m.GetInvitationFn(ctx, id)
+}
+
+func (m *InvitationStore) AcceptInvitation(ctx context.Context, id int64, at time.Time) error {
+	return m.AcceptInvitationFn(ctx, id, at)
+}
+
+// InvitationSender is a mock implementation of warnly.InvitationSender.
+type InvitationSender struct {
+	SendInvitationFn func(ctx context.Context, mail *warnly.InvitationMail) error
+}
+
+func (m *InvitationSender) SendInvitation(ctx context.Context, mail *warnly.InvitationMail) error {
+	return m.SendInvitationFn(ctx, mail)
+}
@@ -8,11 +8,21 @@ import (
 
 // IssueStore is a mock implementation of warnly.IssueStore.
 type IssueStore struct {
-	StoreIssueFn     func(ctx context.Context, issue *warnly.Issue) error
-	GetIssueByIDFn   func(ctx context.Context, id int64) (*warnly.Issue, error)
-	ListIssuesFn     func(ctx context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error)
-	UpdateLastSeenFn func(ctx context.Context, upd *warnly.UpdateLastSeen) error
-	GetIssueFn       func(ctx context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error)
+	StoreIssueFn         func(ctx context.Context, issue *warnly.Issue) error
+	GetIssueByIDFn       func(ctx context.Context, id int64) (*warnly.Issue, error)
+	ListIssuesFn         func(ctx context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error)
+	CountIssuesFn        func(ctx context.Context, criteria *warnly.CountIssuesCriteria) (warnly.IssueCounts, error)
+	UpdateLastSeenFn     func(ctx context.Context, upd *warnly.UpdateLastSeen) error
+	GetIssueFn           func(ctx context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error)
+	UpdateStatusFn       func(ctx context.Context, upd *warnly.UpdateIssueStatus) error
+	ReopenIssueFn        func(ctx context.Context, issueID int64) (bool, error)
+	ReopenIgnoredFn      func(ctx context.Context, issueID int64) (bool, error)
+	CountIgnoredEventFn  func(ctx context.Context, issueID int64) (int, error)
+	ListIgnoredIssuesFn  func(ctx context.Context, conditions []warnly.IgnoreCondition) ([]warnly.Issue, error)
+	RaisePriorityFn      func(ctx context.Context, issueID int64, priority warnly.IssuePriority) error
+	UpdatePriorityFn     func(ctx context.Context, issueID int64, priority warnly.IssuePriority) error
+	UpdateNoiseFiltersFn func(ctx context.Context, issueID int64, filters warnly.NoiseFilters) error
+	DeleteIssuesFn       func(ctx context.Context, issueIDs []int64) error
 }
 
 func (m *IssueStore) StoreIssue(ctx context.Context, issue *warnly.Issue) error {
@@ -27,6 +37,10 @@ func (m *IssueStore) ListIssues(ctx context.Context, criteria *warnly.ListIssues
 	return m.ListIssuesFn(ctx, criteria)
 }
 
+func (m *IssueStore) CountIssues(ctx context.Context, criteria *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+	return m.CountIssuesFn(ctx, criteria)
+}
+
 func (m *IssueStore) UpdateLastSeen(ctx context.Context, upd *warnly.UpdateLastSeen) error {
 	return m.UpdateLastSeenFn(ctx, upd)
 }
@@ -34,3 +48,62 @@ func (m *IssueStore) UpdateLastSeen(ctx context.Context, upd *warnly.UpdateLastS
 func (m *IssueStore) GetIssue(ctx context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
 	return m.GetIssueFn(ctx, criteria)
 }
+
+func (m *IssueStore) UpdateStatus(ctx context.Context, upd *warnly.UpdateIssueStatus) error {
+	return m.UpdateStatusFn(ctx, upd)
+}
+
+func (m *IssueStore) ReopenIssue(ctx context.Context, issueID int64) (bool, error) {
+	return m.ReopenIssueFn(ctx, issueID)
+}
+
+func (m *IssueStore) ReopenIgnored(ctx context.Context, issueID int64) (bool, error) {
+	return m.ReopenIgnoredFn(ctx, issueID)
+}
+
+func (m *IssueStore) CountIgnoredEvent(ctx context.Context, issueID int64) (int, error) {
+	return m.CountIgnoredEventFn(ctx, issueID)
+}
+
+func (m *IssueStore) ListIgnoredIssues(ctx context.Context, conditions []warnly.IgnoreCondition) ([]warnly.Issue, error) {
+	return m.ListIgnoredIssuesFn(ctx, conditions)
+}
+
+func (m *IssueStore) RaisePriority(ctx context.Context, issueID int64, priority warnly.IssuePriority) error {
+	return m.RaisePriorityFn(ctx, issueID, priority)
+}
+
+func (m *IssueStore) UpdatePriority(ctx context.Context, issueID int64, priority warnly.IssuePriority) error {
+	return m.UpdatePriorityFn(ctx, issueID, priority)
+}
+
+func (m *IssueStore) UpdateNoiseFilters(ctx context.Context, issueID int64, filters warnly.NoiseFilters) error {
+	return m.UpdateNoiseFiltersFn(ctx, issueID, filters)
+}
+
+func (m *IssueStore) DeleteIssues(ctx context.Context, issueIDs []int64) error {
+	return m.DeleteIssuesFn(ctx, issueIDs)
+}
+
+// IngestCache is a mock implementation of warnly.IngestCache.
+type IngestCache struct {
+	ForgetIssueFn      func(projectID int, hash string)
+	ForgetProjectKeyFn func(projectID int, key string)
+}
+
+func (m *IngestCache) ForgetIssue(projectID int, hash string) {
+	m.ForgetIssueFn(projectID, hash)
+}
+
+func (m *IngestCache) ForgetProjectKey(projectID int, key string) {
+	m.ForgetProjectKeyFn(projectID, key)
+}
+
+// IgnoredIssueReopener is a mock implementation of warnly.IgnoredIssueReopener.
+type IgnoredIssueReopener struct {
+	ReopenIgnoredIssuesFn func(ctx context.Context) (int, error)
+}
+
+func (m *IgnoredIssueReopener) ReopenIgnoredIssues(ctx context.Context) (int, error) {
+	return m.ReopenIgnoredIssuesFn(ctx)
+}
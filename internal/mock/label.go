@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// IssueLabelStore is a mock implementation of warnly.IssueLabelStore.
+type IssueLabelStore struct {
+	CreateLabelFn         func(ctx context.Context, label *warnly.IssueLabel) error
+	DeleteLabelFn         func(ctx context.Context, issueID int64, name string) error
+	ListLabelsFn          func(ctx context.Context, issueID int64) ([]warnly.IssueLabel, error)
+	ListLabeledIssueIDsFn func(ctx context.Context, teamIDs []int, name string) ([]int64, error)
+}
+
+func (m *IssueLabelStore) CreateLabel(ctx context.Context, label *warnly.IssueLabel) error {
+	return m.CreateLabelFn(ctx, label)
+}
+
+func (m *IssueLabelStore) DeleteLabel(ctx context.Context, issueID int64, name string) error {
+	return m.DeleteLabelFn(ctx, issueID, name)
+}
+
+func (m *IssueLabelStore) ListLabels(ctx context.Context, issueID int64) ([]warnly.IssueLabel, error) {
+	return m.ListLabelsFn(ctx, issueID)
+}
+
+func (m *IssueLabelStore) ListLabeledIssueIDs(ctx context.Context, teamIDs []int, name string) ([]int64, error) {
+	return m.ListLabeledIssueIDsFn(ctx, teamIDs, name)
+}
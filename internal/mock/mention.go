@@ -8,8 +8,9 @@ import (
 
 // MentionStore is a mock implementation of warnly.MentionStore.
 type MentionStore struct {
-	CreateMentionsFn func(ctx context.Context, mentions []warnly.Mention) error
-	DeleteMentionsFn func(ctx context.Context, messageID int) error
+	CreateMentionsFn         func(ctx context.Context, mentions []warnly.Mention) error
+	DeleteMentionsFn         func(ctx context.Context, messageID int) error
+	DeleteMessagesMentionsFn func(ctx context.Context, messageIDs []int) error
 }
 
 func (m *MentionStore) CreateMentions(ctx context.Context, mentions []warnly.Mention) error {
@@ -19,3 +20,7 @@ func (m *MentionStore) CreateMentions(ctx context.Context, mentions []warnly.Men
 func (m *MentionStore) DeleteMentions(ctx context.Context, messageID int) error {
 	return m.DeleteMentionsFn(ctx, messageID)
 }
+
+func (m *MentionStore) DeleteMessagesMentions(ctx context.Context, messageIDs []int) error {
+	return m.DeleteMessagesMentionsFn(ctx, messageIDs)
+}
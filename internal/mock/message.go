@@ -8,11 +8,14 @@ import (
 
 // MessageStore is a mock implementation of warnly.MessageStore.
 type MessageStore struct {
-	CreateMessageFn      func(ctx context.Context, message *warnly.Message) error
-	ListIssueMessagesFn  func(ctx context.Context, issueID int64) ([]warnly.IssueMessage, error)
-	CountMessagesByIDsFn func(ctx context.Context, issueIDs []int64) ([]warnly.MessageCount, error)
-	CountMessagesFn      func(ctx context.Context, issueID int64) (int, error)
-	DeleteMessageFn      func(ctx context.Context, messageID, userID int) error
+	CreateMessageFn       func(ctx context.Context, message *warnly.Message) error
+	ListIssueMessagesFn   func(ctx context.Context, issueID int64) ([]warnly.IssueMessage, error)
+	CountMessagesByIDsFn  func(ctx context.Context, issueIDs []int64) ([]warnly.MessageCount, error)
+	CountMessagesFn       func(ctx context.Context, issueID int64) (int, error)
+	ListCommentersFn      func(ctx context.Context, issueID int64) ([]int64, error)
+	DeleteMessageFn       func(ctx context.Context, messageID, userID int) error
+	ListExpiredMessagesFn func(ctx context.Context, criteria *warnly.ExpiredMessagesCriteria) ([]int, error)
+	DeleteMessagesFn      func(ctx context.Context, messageIDs []int) error
 }
 
 func (m *MessageStore) CreateMessage(ctx context.Context, message *warnly.Message) error {
@@ -31,6 +34,18 @@ func (m *MessageStore) CountMessages(ctx context.Context, issueID int64) (int, e
 	return m.CountMessagesFn(ctx, issueID)
 }
 
+func (m *MessageStore) ListCommenters(ctx context.Context, issueID int64) ([]int64, error) {
+	return m.ListCommentersFn(ctx, issueID)
+}
+
 func (m *MessageStore) DeleteMessage(ctx context.Context, messageID, userID int) error {
 	return m.DeleteMessageFn(ctx, messageID, userID)
 }
+
+func (m *MessageStore) ListExpiredMessages(ctx context.Context, criteria *warnly.ExpiredMessagesCriteria) ([]int, error) {
+	return m.ListExpiredMessagesFn(ctx, criteria)
+}
+
+func (m *MessageStore) DeleteMessages(ctx context.Context, messageIDs []int) error {
+	return m.DeleteMessagesFn(ctx, messageIDs)
+}
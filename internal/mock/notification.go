@@ -0,0 +1,121 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// IssueNotifier is a mock implementation of warnly.IssueNotifier.
+type IssueNotifier struct {
+	NotifyIssueResolvedFn   func(ctx context.Context, n *warnly.IssueResolvedNotification) error
+	NotifyIssueRegressedFn  func(ctx context.Context, n *warnly.IssueRegressedNotification) error
+	NotifyIssueCommentedFn  func(ctx context.Context, n *warnly.IssueCommentedNotification) error
+	NotifyIssueReappearedFn func(ctx context.Context, n *warnly.IssueReappearedNotification) error
+	NotifyIssueIgnoredFn    func(ctx context.Context, n *warnly.IssueIgnoredNotification) error
+}
+
+func (m *IssueNotifier) NotifyIssueResolved(ctx context.Context, n *warnly.IssueResolvedNotification) error {
+	return m.NotifyIssueResolvedFn(ctx, n)
+}
+
+func (m *IssueNotifier) NotifyIssueRegressed(ctx context.Context, n *warnly.IssueRegressedNotification) error {
+	return m.NotifyIssueRegressedFn(ctx, n)
+}
+
+func (m *IssueNotifier) NotifyIssueReappeared(ctx context.Context, n *warnly.IssueReappearedNotification) error {
+	return m.NotifyIssueReappearedFn(ctx, n)
+}
+
+func (m *IssueNotifier) NotifyIssueIgnored(ctx context.Context, n *warnly.IssueIgnoredNotification) error {
+	return m.NotifyIssueIgnoredFn(ctx, n)
+}
+
+func (m *IssueNotifier) NotifyIssueCommented(ctx context.Context, n *warnly.IssueCommentedNotification) error {
+	return m.NotifyIssueCommentedFn(ctx, n)
+}
+
+// NotificationStore is a mock implementation of warnly.NotificationStore.
+type NotificationStore struct {
+	CreateNotificationChannelFn func(ctx context.Context, channel *warnly.NotificationChannel) error
+	GetNotificationChannelFn    func(ctx context.Context, channelID int) (*warnly.NotificationChannel, error)
+	ListNotificationChannelsFn  func(ctx context.Context, teamID int) ([]warnly.NotificationChannel, error)
+	UpdateNotificationChannelFn func(ctx context.Context, channel *warnly.NotificationChannel) error
+	DeleteNotificationChannelFn func(ctx context.Context, channelID int) error
+	CreateWebhookConfigFn       func(ctx context.Context, config *warnly.WebhookConfig) error
+	GetWebhookConfigFn          func(ctx context.Context, channelID int) (*warnly.WebhookConfig, error)
+	UpdateWebhookConfigFn       func(ctx context.Context, config *warnly.WebhookConfig) error
+	CreateAlertNotificationFn   func(ctx context.Context, notification *warnly.AlertNotification) error
+	UpdateAlertNotificationFn   func(ctx context.Context, notification *warnly.AlertNotification) error
+	ListPendingNotificationsFn  func(ctx context.Context, limit int) ([]warnly.AlertNotification, error)
+	CreateWebhookDeadLetterFn   func(ctx context.Context, deadLetter *warnly.WebhookDeadLetter) error
+	ListWebhookDeadLettersFn    func(ctx context.Context, channelID, limit int) ([]warnly.WebhookDeadLetter, error)
+	AcquireAlertLockFn          func(ctx context.Context, lock *warnly.AlertLock) (bool, error)
+	ReleaseAlertLockFn          func(ctx context.Context, alertID int, instanceID string) error
+	CleanupExpiredLocksFn       func(ctx context.Context, now time.Time) error
+}
+
+func (m *NotificationStore) CreateNotificationChannel(ctx context.Context, channel *warnly.NotificationChannel) error {
+	return m.CreateNotificationChannelFn(ctx, channel)
+}
+
+func (m *NotificationStore) GetNotificationChannel(ctx context.Context, channelID int) (*warnly.NotificationChannel, error) {
+	return m.GetNotificationChannelFn(ctx, channelID)
+}
+
+func (m *NotificationStore) ListNotificationChannels(ctx context.Context, teamID int) ([]warnly.NotificationChannel, error) {
+	return m.ListNotificationChannelsFn(ctx, teamID)
+}
+
+func (m *NotificationStore) UpdateNotificationChannel(ctx context.Context, channel *warnly.NotificationChannel) error {
+	return m.UpdateNotificationChannelFn(ctx, channel)
+}
+
+func (m *NotificationStore) DeleteNotificationChannel(ctx context.Context, channelID int) error {
+	return m.DeleteNotificationChannelFn(ctx, channelID)
+}
+
+func (m *NotificationStore) CreateWebhookConfig(ctx context.Context, config *warnly.WebhookConfig) error {
+	return m.CreateWebhookConfigFn(ctx, config)
+}
+
+func (m *NotificationStore) GetWebhookConfig(ctx context.Context, channelID int) (*warnly.WebhookConfig, error) {
+	return m.GetWebhookConfigFn(ctx, channelID)
+}
+
+func (m *NotificationStore) UpdateWebhookConfig(ctx context.Context, config *warnly.WebhookConfig) error {
+	return m.UpdateWebhookConfigFn(ctx, config)
+}
+
+func (m *NotificationStore) CreateAlertNotification(ctx context.Context, notification *warnly.AlertNotification) error {
+	return m.CreateAlertNotificationFn(ctx, notification)
+}
+
+func (m *NotificationStore) UpdateAlertNotification(ctx context.Context, notification *warnly.AlertNotification) error {
+	return m.UpdateAlertNotificationFn(ctx, notification)
+}
+
+func (m *NotificationStore) ListPendingNotifications(ctx context.Context, limit int) ([]warnly.AlertNotification, error) {
+	return m.ListPendingNotificationsFn(ctx, limit)
+}
+
+func (m *NotificationStore) CreateWebhookDeadLetter(ctx context.Context, deadLetter *warnly.WebhookDeadLetter) error {
+	return m.CreateWebhookDeadLetterFn(ctx, deadLetter)
+}
+
+func (m *NotificationStore) ListWebhookDeadLetters(ctx context.Context, channelID, limit int) ([]warnly.WebhookDeadLetter, error) {
+	return m.ListWebhookDeadLettersFn(ctx, channelID, limit)
+}
+
+func (m *NotificationStore) AcquireAlertLock(ctx context.Context, lock *warnly.AlertLock) (bool, error) {
+	return m.AcquireAlertLockFn(ctx, lock)
+}
+
+func (m *NotificationStore) ReleaseAlertLock(ctx context.Context, alertID int, instanceID string) error {
+	return m.ReleaseAlertLockFn(ctx, alertID, instanceID)
+}
+
+func (m *NotificationStore) CleanupExpiredLocks(ctx context.Context, now time.Time) error {
+	return m.CleanupExpiredLocksFn(ctx, now)
+}
@@ -2,17 +2,49 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/vk-rv/warnly/internal/warnly"
 )
 
 // ProjectStore is a mock implementation of warnly.ProjectStore.
 type ProjectStore struct {
-	CreateProjectFn func(ctx context.Context, project *warnly.Project) error
-	GetProjectFn    func(ctx context.Context, projectID int) (*warnly.Project, error)
-	DeleteProjectFn func(ctx context.Context, projectID int) error
-	ListProjectsFn  func(ctx context.Context, teamIDs []int, name string) ([]warnly.Project, error)
-	GetOptionsFn    func(ctx context.Context, projectID int, projectKey string) (*warnly.ProjectOptions, error)
+	CreateProjectFn  func(ctx context.Context, project *warnly.Project) error
+	GetProjectFn     func(ctx context.Context, projectID int) (*warnly.Project, error)
+	DeleteProjectFn  func(ctx context.Context, projectID int) error
+	ListProjectsFn   func(ctx context.Context, teamIDs []int, name string) ([]warnly.Project, error)
+	ListProjectIDsFn func(ctx context.Context) ([]int, error)
+
+	SoftDeleteFn            func(ctx context.Context, projectID int, at time.Time) error
+	RestoreFn               func(ctx context.Context, projectID int) error
+	GetDeletedProjectFn     func(ctx context.Context, projectID int) (*warnly.Project, error)
+	ListDeletedProjectIDsFn func(ctx context.Context, before time.Time) ([]int, error)
+	GetOptionsFn            func(ctx context.Context, projectID int, projectKey string) (*warnly.ProjectOptions, error)
+
+	UpdateSampleRateFn        func(ctx context.Context, projectID int, sampleRate float64) error
+	UpdateDedupWindowFn       func(ctx context.Context, projectID int, window time.Duration) error
+	UpdateOptionsFn           func(ctx context.Context, projectID int, platform warnly.Platform, retentionDays uint8) error
+	UpdateSourceURLTemplateFn func(ctx context.Context, projectID int, template string) error
+	UpdateGroupingFn          func(ctx context.Context, projectID int, grouping warnly.GroupingStrategy) error
+
+	UpdatePriorityRulesFn func(ctx context.Context, projectID int, rules []warnly.PriorityRule) error
+	UpdateCodeOwnersFn    func(ctx context.Context, projectID int, owners []warnly.CodeOwner) error
+	UpdateTeamFn          func(ctx context.Context, projectID, teamID int) error
+
+	GetDefaultIssuesQueryFn  func(ctx context.Context, userID, projectID int) (string, error)
+	SaveDefaultIssuesQueryFn func(ctx context.Context, query *warnly.DefaultIssuesQuery) error
+
+	UpdateGroupingRulesFn func(ctx context.Context, projectID int, rules *warnly.GroupingRules) error
+	UpdateIngestFiltersFn func(ctx context.Context, projectID int, filters *warnly.IngestFilters) error
+	UpdateIngestSecretFn  func(ctx context.Context, projectID int, secret string) error
+
+	AddKeyFn           func(ctx context.Context, key *warnly.ProjectKey) error
+	ListKeysFn         func(ctx context.Context, projectID int) ([]warnly.ProjectKey, error)
+	RevokeKeyFn        func(ctx context.Context, projectID int, keyID int64, at time.Time) error
+	UpdatePrimaryKeyFn func(ctx context.Context, projectID int, key string) error
+
+	AddDiscardedEventsFn  func(ctx context.Context, projectID int, day time.Time, discarded []warnly.DiscardedEvents) error
+	ListDiscardedEventsFn func(ctx context.Context, projectID int, since time.Time) ([]warnly.DiscardedEvents, error)
 }
 
 func (m *ProjectStore) CreateProject(ctx context.Context, proj *warnly.Project) error {
@@ -31,6 +63,111 @@ func (m *ProjectStore) ListProjects(ctx context.Context, teamIDs []int, name str
 	return m.ListProjectsFn(ctx, teamIDs, name)
 }
 
+func (m *ProjectStore) ListProjectIDs(ctx context.Context) ([]int, error) {
+	return m.ListProjectIDsFn(ctx)
+}
+
+func (m *ProjectStore) SoftDelete(ctx context.Context, projectID int, at time.Time) error {
+	return m.SoftDeleteFn(ctx, projectID, at)
+}
+
+func (m *ProjectStore) Restore(ctx context.Context, projectID int) error {
+	return m.RestoreFn(ctx, projectID)
+}
+
+func (m *ProjectStore) GetDeletedProject(ctx context.Context, projectID int) (*warnly.Project, error) {
+	return m.GetDeletedProjectFn(ctx, projectID)
+}
+
+func (m *ProjectStore) ListDeletedProjectIDs(ctx context.Context, before time.Time) ([]int, error) {
+	return m.ListDeletedProjectIDsFn(ctx, before)
+}
+
 func (m *ProjectStore) GetOptions(ctx context.Context, projectID int, projectKey string) (*warnly.ProjectOptions, error) {
 	return m.GetOptionsFn(ctx, projectID, projectKey)
 }
+
+func (m *ProjectStore) AddKey(ctx context.Context, key *warnly.ProjectKey) error {
+	return m.AddKeyFn(ctx, key)
+}
+
+func (m *ProjectStore) ListKeys(ctx context.Context, projectID int) ([]warnly.ProjectKey, error) {
+	return m.ListKeysFn(ctx, projectID)
+}
+
+func (m *ProjectStore) RevokeKey(ctx context.Context, projectID int, keyID int64, at time.Time) error {
+	return m.RevokeKeyFn(ctx, projectID, keyID, at)
+}
+
+func (m *ProjectStore) UpdatePrimaryKey(ctx context.Context, projectID int, key string) error {
+	return m.UpdatePrimaryKeyFn(ctx, projectID, key)
+}
+
+func (m *ProjectStore) UpdateSampleRate(ctx context.Context, projectID int, sampleRate float64) error {
+	return m.UpdateSampleRateFn(ctx, projectID, sampleRate)
+}
+
+func (m *ProjectStore) UpdateDedupWindow(ctx context.Context, projectID int, window time.Duration) error {
+	return m.UpdateDedupWindowFn(ctx, projectID, window)
+}
+
+func (m *ProjectStore) UpdateOptions(ctx context.Context, projectID int, platform warnly.Platform, retentionDays uint8) error {
+	return m.UpdateOptionsFn(ctx, projectID, platform, retentionDays)
+}
+
+func (m *ProjectStore) UpdateSourceURLTemplate(ctx context.Context, projectID int, template string) error {
+	return m.UpdateSourceURLTemplateFn(ctx, projectID, template)
+}
+
+func (m *ProjectStore) UpdateGrouping(ctx context.Context, projectID int, grouping warnly.GroupingStrategy) error {
+	return m.UpdateGroupingFn(ctx, projectID, grouping)
+}
+
+func (m *ProjectStore) UpdatePriorityRules(ctx context.Context, projectID int, rules []warnly.PriorityRule) error {
+	return m.UpdatePriorityRulesFn(ctx, projectID, rules)
+}
+
+func (m *ProjectStore) UpdateGroupingRules(ctx context.Context, projectID int, rules *warnly.GroupingRules) error {
+	return m.UpdateGroupingRulesFn(ctx, projectID, rules)
+}
+
+func (m *ProjectStore) UpdateIngestFilters(ctx context.Context, projectID int, filters *warnly.IngestFilters) error {
+	return m.UpdateIngestFiltersFn(ctx, projectID, filters)
+}
+
+func (m *ProjectStore) UpdateIngestSecret(ctx context.Context, projectID int, secret string) error {
+	return m.UpdateIngestSecretFn(ctx, projectID, secret)
+}
+
+func (m *ProjectStore) AddDiscardedEvents(
+	ctx context.Context,
+	projectID int,
+	day time.Time,
+	discarded []warnly.DiscardedEvents,
+) error {
+	return m.AddDiscardedEventsFn(ctx, projectID, day, discarded)
+}
+
+func (m *ProjectStore) ListDiscardedEvents(
+	ctx context.Context,
+	projectID int,
+	since time.Time,
+) ([]warnly.DiscardedEvents, error) {
+	return m.ListDiscardedEventsFn(ctx, projectID, since)
+}
+
+func (m *ProjectStore) UpdateCodeOwners(ctx context.Context, projectID int, owners []warnly.CodeOwner) error {
+	return m.UpdateCodeOwnersFn(ctx, projectID, owners)
+}
+
+func (m *ProjectStore) UpdateTeam(ctx context.Context, projectID, teamID int) error {
+	return m.UpdateTeamFn(ctx, projectID, teamID)
+}
+
+func (m *ProjectStore) GetDefaultIssuesQuery(ctx context.Context, userID, projectID int) (string, error) {
+	return m.GetDefaultIssuesQueryFn(ctx, userID, projectID)
+}
+
+func (m *ProjectStore) SaveDefaultIssuesQuery(ctx context.Context, query *warnly.DefaultIssuesQuery) error {
+	return m.SaveDefaultIssuesQueryFn(ctx, query)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// SeenStore is a mock implementation of warnly.SeenStore.
+type SeenStore struct {
+	MarkSeenFn   func(ctx context.Context, view *warnly.IssueView) error
+	ListSeenByFn func(ctx context.Context, issueID int64) ([]warnly.IssueView, error)
+}
+
+func (m *SeenStore) MarkSeen(ctx context.Context, view *warnly.IssueView) error {
+	return m.MarkSeenFn(ctx, view)
+}
+
+func (m *SeenStore) ListSeenBy(ctx context.Context, issueID int64) ([]warnly.IssueView, error) {
+	return m.ListSeenByFn(ctx, issueID)
+}
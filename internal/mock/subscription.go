@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// SubscriptionStore is a mock implementation of warnly.SubscriptionStore.
+type SubscriptionStore struct {
+	CreateSubscriptionFn func(ctx context.Context, subscription *warnly.Subscription) error
+	DeleteSubscriptionFn func(ctx context.Context, issueID, userID int64) error
+	ListSubscribersFn    func(ctx context.Context, issueID int64) ([]int64, error)
+}
+
+func (m *SubscriptionStore) CreateSubscription(ctx context.Context, subscription *warnly.Subscription) error {
+	return m.CreateSubscriptionFn(ctx, subscription)
+}
+
+func (m *SubscriptionStore) DeleteSubscription(ctx context.Context, issueID, userID int64) error {
+	return m.DeleteSubscriptionFn(ctx, issueID, userID)
+}
+
+func (m *SubscriptionStore) ListSubscribers(ctx context.Context, issueID int64) ([]int64, error) {
+	return m.ListSubscribersFn(ctx, issueID)
+}
@@ -12,7 +12,7 @@ type TeamStore struct {
 	ListTeamsFn     func(ctx context.Context, userID int) ([]warnly.Team, error)
 	ListTeammatesFn func(ctx context.Context, teamIDs []int) ([]warnly.Teammate, error)
 	CreateTeamFn    func(ctx context.Context, team warnly.Team) error
-	AddUserToTeamFn func(ctx context.Context, createdAt time.Time, userID int64, teamID int) error
+	AddUserToTeamFn func(ctx context.Context, createdAt time.Time, userID int64, teamID int, role warnly.Role) error
 }
 
 func (m *TeamStore) ListTeams(ctx context.Context, userID int) ([]warnly.Team, error) {
@@ -27,6 +27,6 @@ func (m *TeamStore) CreateTeam(ctx context.Context, team warnly.Team) error {
 	return m.CreateTeamFn(ctx, team)
 }
 
-func (m *TeamStore) AddUserToTeam(ctx context.Context, createdAt time.Time, userID int64, teamID int) error {
-	return m.AddUserToTeamFn(ctx, createdAt, userID, teamID)
+func (m *TeamStore) AddUserToTeam(ctx context.Context, createdAt time.Time, userID int64, teamID int, role warnly.Role) error {
+	return m.AddUserToTeamFn(ctx, createdAt, userID, teamID, role)
 }
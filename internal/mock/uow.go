@@ -5,9 +5,59 @@ import (
 	"context"
 
 	"github.com/vk-rv/warnly/internal/uow"
+	"github.com/vk-rv/warnly/internal/warnly"
 )
 
 // StartUnitOfWork is a mock implementation of uow.StartUnitOfWork.
 func StartUnitOfWork(_ context.Context, _ uow.Type, fn uow.UnitOfWorkFn, _ ...any) error {
 	return nil
 }
+
+// UnitOfWork is a mock implementation of uow.UnitOfWork returning the configured stores.
+type UnitOfWork struct {
+	MentionStore      warnly.MentionStore
+	MessageStore      warnly.MessageStore
+	AssingmentStore   warnly.AssingmentStore
+	UserStore         warnly.UserStore
+	TeamStore         warnly.TeamStore
+	IssueStore        warnly.IssueStore
+	ActivityStore     warnly.ActivityStore
+	InvitationStore   warnly.InvitationStore
+	ProjectStore      warnly.ProjectStore
+	SubscriptionStore warnly.SubscriptionStore
+}
+
+// Start is a uow.StartUnitOfWork that runs fn with the mocked stores.
+func (m *UnitOfWork) Start(ctx context.Context, _ uow.Type, fn uow.UnitOfWorkFn, _ ...any) error {
+	return fn(ctx, m)
+}
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Mentions() warnly.MentionStore { return m.MentionStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Messages() warnly.MessageStore { return m.MessageStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Assignments() warnly.AssingmentStore { return m.AssingmentStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Users() warnly.UserStore { return m.UserStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Teams() warnly.TeamStore { return m.TeamStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Issues() warnly.IssueStore { return m.IssueStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Activities() warnly.ActivityStore { return m.ActivityStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Invitations() warnly.InvitationStore { return m.InvitationStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Projects() warnly.ProjectStore { return m.ProjectStore }
+
+//nolint:ireturn // mock
+func (m *UnitOfWork) Subscriptions() warnly.SubscriptionStore { return m.SubscriptionStore }
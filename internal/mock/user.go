@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// UserStore is a mock implementation of warnly.UserStore.
+type UserStore struct {
+	GetUserFn             func(ctx context.Context, email string) (*warnly.User, error)
+	GetUserByIdentifierFn func(ctx context.Context, identifier warnly.UserIdentifier) (*warnly.User, error)
+	CreateUserFn          func(ctx context.Context, email, username string, hashedPassword []byte) error
+	CreateUserOIDCFn      func(ctx context.Context, userData *warnly.GetOrCreateUserRequest) (int64, error)
+}
+
+func (m *UserStore) GetUser(ctx context.Context, email string) (*warnly.User, error) {
+	return m.GetUserFn(ctx, email)
+}
+
+func (m *UserStore) GetUserByIdentifier(ctx context.Context, identifier warnly.UserIdentifier) (*warnly.User, error) {
+	return m.GetUserByIdentifierFn(ctx, identifier)
+}
+
+func (m *UserStore) CreateUser(ctx context.Context, email, username string, hashedPassword []byte) error {
+	return m.CreateUserFn(ctx, email, username, hashedPassword)
+}
+
+func (m *UserStore) CreateUserOIDC(ctx context.Context, userData *warnly.GetOrCreateUserRequest) (int64, error) {
+	return m.CreateUserOIDCFn(ctx, userData)
+}
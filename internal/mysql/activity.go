@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// ActivityStore implements warnly.ActivityStore for MySQL.
+type ActivityStore struct {
+	db ExtendedDB
+}
+
+// NewActivityStore is a constructor of ActivityStore repository.
+func NewActivityStore(db ExtendedDB) *ActivityStore {
+	return &ActivityStore{db: db}
+}
+
+// CreateActivity records a new entry in the issue timeline.
+func (s *ActivityStore) CreateActivity(ctx context.Context, a *warnly.Activity) error {
+	const query = `INSERT INTO issue_activity (issue_id, actor_id, activity_type, detail, created_at)
+				   VALUES (?, ?, ?, ?, ?)`
+
+	actorID := sql.NullInt64{Int64: a.ActorID, Valid: a.ActorID != 0}
+
+	res, err := s.db.ExecContext(ctx, query, a.IssueID, actorID, a.Type, a.Detail, a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("mysql activity store: create activity: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("mysql activity store: create activity last insert id: %w", err)
+	}
+	a.ID = id
+
+	return nil
+}
+
+// ListIssueActivity lists the timeline of the issue in chronological order.
+func (s *ActivityStore) ListIssueActivity(ctx context.Context, issueID int64) ([]warnly.IssueActivity, error) {
+	const query = `SELECT a.id, a.issue_id, a.actor_id, COALESCE(u.name, ''), a.activity_type, a.detail, a.created_at
+		FROM issue_activity AS a
+		LEFT JOIN user AS u ON a.actor_id = u.id
+		WHERE a.issue_id = ? ORDER BY a.created_at, a.id`
+
+	rows, err := s.db.QueryContext(ctx, query, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql activity store: list issue activity: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql activity store: list issue activity, close rows: %w", cerr)
+		}
+	}()
+
+	var activities []warnly.IssueActivity
+	for rows.Next() {
+		var (
+			a       warnly.IssueActivity
+			actorID sql.NullInt64
+		)
+		if err := rows.Scan(&a.ID, &a.IssueID, &actorID, &a.ActorName, &a.Type, &a.Detail, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("mysql activity store: list issue activity, scan: %w", err)
+		}
+		a.ActorID = actorID.Int64
+		activities = append(activities, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql activity store: list issue activity, rows error: %w", err)
+	}
+
+	return activities, nil
+}
@@ -3,9 +3,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vk-rv/warnly/internal/warnly"
 )
@@ -33,8 +35,9 @@ func (s *AlertStore) ListAlerts(
 		totalCount int
 	)
 
+	// alerts of deleted projects are neither listed nor evaluated.
 	var (
-		conditions []string
+		conditions = []string{"p.deleted_at IS NULL"}
 		args       []any
 	)
 
@@ -52,10 +55,7 @@ func (s *AlertStore) ListAlerts(
 		args = append(args, projectName)
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = "WHERE " + strings.Join(conditions, " AND ")
-	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
 
 	countQuery := fmt.Sprintf(`
 		SELECT COUNT(*)
@@ -76,7 +76,10 @@ func (s *AlertStore) ListAlerts(
 		SELECT 
 			a.id, a.created_at, a.updated_at, a.last_triggered_at, a.resolved_at, a.notification_sent_at,
 			a.rule_name, a.description, a.status,
-			a.project_id, a.team_id, a.threshold, a.cond, a.timeframe, a.is_high_priority
+			a.project_id, a.team_id, a.threshold, a.cond, a.timeframe, a.is_high_priority,
+			a.baseline_windows, a.spike_multiplier,
+			a.escalation_chain, a.escalation_window_seconds, COALESCE(a.ack_token, ''), a.acknowledged_at,
+			a.escalation_level, a.escalated_at
 		FROM alert a
 		JOIN project p ON a.project_id = p.id
 		%s
@@ -95,48 +98,12 @@ func (s *AlertStore) ListAlerts(
 	}()
 
 	for rows.Next() {
-		var (
-			alert              warnly.Alert
-			lastTriggeredAt    sql.NullTime
-			resolvedAt         sql.NullTime
-			notificationSentAt sql.NullTime
-			description        sql.NullString
-		)
-		err := rows.Scan(
-			&alert.ID,
-			&alert.CreatedAt,
-			&alert.UpdatedAt,
-			&lastTriggeredAt,
-			&resolvedAt,
-			&notificationSentAt,
-			&alert.RuleName,
-			&description,
-			&alert.Status,
-			&alert.ProjectID,
-			&alert.TeamID,
-			&alert.Threshold,
-			&alert.Condition,
-			&alert.Timeframe,
-			&alert.HighPriority,
-		)
+		alert, err := scanAlert(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("mysql: scan alert: %w", err)
 		}
 
-		if lastTriggeredAt.Valid {
-			alert.LastTriggeredAt = &lastTriggeredAt.Time
-		}
-		if resolvedAt.Valid {
-			alert.ResolvedAt = &resolvedAt.Time
-		}
-		if notificationSentAt.Valid {
-			alert.NotificationSentAt = &notificationSentAt.Time
-		}
-		if description.Valid {
-			alert.Description = description.String
-		}
-
-		alerts = append(alerts, alert)
+		alerts = append(alerts, *alert)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -151,10 +118,16 @@ func (s *AlertStore) CreateAlert(ctx context.Context, alert *warnly.Alert) error
 	const query = `
 		INSERT INTO alert (
 			created_at, updated_at, rule_name, description, status,
-			project_id, team_id, threshold, cond, timeframe, is_high_priority) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			project_id, team_id, threshold, cond, timeframe, is_high_priority,
+			baseline_windows, spike_multiplier, escalation_chain, escalation_window_seconds)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	chain, err := marshalEscalationChain(alert.EscalationChain)
+	if err != nil {
+		return err
+	}
+
 	result, err := s.db.ExecContext(
 		ctx,
 		query,
@@ -169,6 +142,10 @@ func (s *AlertStore) CreateAlert(ctx context.Context, alert *warnly.Alert) error
 		alert.Condition,
 		alert.Timeframe,
 		alert.HighPriority,
+		alert.BaselineWindows,
+		alert.SpikeMultiplier,
+		chain,
+		int64(alert.EscalationWindow/time.Second),
 	)
 	if err != nil {
 		return fmt.Errorf("mysql: insert alert: %w", err)
@@ -185,15 +162,26 @@ func (s *AlertStore) CreateAlert(ctx context.Context, alert *warnly.Alert) error
 }
 
 // UpdateAlert updates an existing alert.
+// The acknowledgement is kept while the ack token stays the same, so an acknowledgement made
+// while the alert was being processed isn't lost; a new token of a new trigger clears it.
 func (s *AlertStore) UpdateAlert(ctx context.Context, alert *warnly.Alert) error {
 	const query = `
 		UPDATE alert
 		SET updated_at = ?, rule_name = ?, description = ?, status = ?,
 			threshold = ?, cond = ?, timeframe = ?, is_high_priority = ?,
-			last_triggered_at = ?, resolved_at = ?, notification_sent_at = ?
+			baseline_windows = ?, spike_multiplier = ?,
+			last_triggered_at = ?, resolved_at = ?, notification_sent_at = ?,
+			escalation_chain = ?, escalation_window_seconds = ?,
+			acknowledged_at = IF(ack_token <=> NULLIF(?, ''), acknowledged_at, NULL), ack_token = NULLIF(?, ''),
+			escalation_level = ?, escalated_at = ?
 		WHERE id = ?
 	`
 
+	chain, err := marshalEscalationChain(alert.EscalationChain)
+	if err != nil {
+		return err
+	}
+
 	res, err := s.db.ExecContext(
 		ctx,
 		query,
@@ -205,9 +193,17 @@ func (s *AlertStore) UpdateAlert(ctx context.Context, alert *warnly.Alert) error
 		alert.Condition,
 		alert.Timeframe,
 		alert.HighPriority,
+		alert.BaselineWindows,
+		alert.SpikeMultiplier,
 		alert.LastTriggeredAt,
 		alert.ResolvedAt,
 		alert.NotificationSentAt,
+		chain,
+		int64(alert.EscalationWindow/time.Second),
+		alert.AckToken,
+		alert.AckToken,
+		alert.EscalationLevel,
+		alert.EscalatedAt,
 		alert.ID,
 	)
 	if err != nil {
@@ -251,36 +247,15 @@ func (s *AlertStore) GetAlert(ctx context.Context, alertID int) (*warnly.Alert,
 		SELECT 
 			id, created_at, updated_at, last_triggered_at, resolved_at, notification_sent_at,
 			rule_name, description, status,
-			project_id, team_id, threshold, cond, timeframe, is_high_priority
+			project_id, team_id, threshold, cond, timeframe, is_high_priority,
+			baseline_windows, spike_multiplier,
+			escalation_chain, escalation_window_seconds, COALESCE(ack_token, ''), acknowledged_at,
+			escalation_level, escalated_at
 		FROM alert
 		WHERE id = ?
 	`
 
-	var (
-		alert              warnly.Alert
-		lastTriggeredAt    sql.NullTime
-		resolvedAt         sql.NullTime
-		notificationSentAt sql.NullTime
-		description        sql.NullString
-	)
-
-	err := s.db.QueryRowContext(ctx, query, alertID).Scan(
-		&alert.ID,
-		&alert.CreatedAt,
-		&alert.UpdatedAt,
-		&lastTriggeredAt,
-		&resolvedAt,
-		&notificationSentAt,
-		&alert.RuleName,
-		&description,
-		&alert.Status,
-		&alert.ProjectID,
-		&alert.TeamID,
-		&alert.Threshold,
-		&alert.Condition,
-		&alert.Timeframe,
-		&alert.HighPriority,
-	)
+	alert, err := scanAlert(s.db.QueryRowContext(ctx, query, alertID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("mysql: alert id %d not found: %w", alertID, warnly.ErrNotFound)
@@ -288,20 +263,7 @@ func (s *AlertStore) GetAlert(ctx context.Context, alertID int) (*warnly.Alert,
 		return nil, fmt.Errorf("mysql: get alert: %w", err)
 	}
 
-	if lastTriggeredAt.Valid {
-		alert.LastTriggeredAt = &lastTriggeredAt.Time
-	}
-	if resolvedAt.Valid {
-		alert.ResolvedAt = &resolvedAt.Time
-	}
-	if notificationSentAt.Valid {
-		alert.NotificationSentAt = &notificationSentAt.Time
-	}
-	if description.Valid {
-		alert.Description = description.String
-	}
-
-	return &alert, nil
+	return alert, nil
 }
 
 // ListAlertsByProject returns alerts for a project.
@@ -310,7 +272,10 @@ func (s *AlertStore) ListAlertsByProject(ctx context.Context, projectID int) ([]
 		SELECT 
 			id, created_at, updated_at, last_triggered_at, resolved_at, notification_sent_at,
 			rule_name, description, status,
-			project_id, team_id, threshold, cond, timeframe, is_high_priority
+			project_id, team_id, threshold, cond, timeframe, is_high_priority,
+			baseline_windows, spike_multiplier,
+			escalation_chain, escalation_window_seconds, COALESCE(ack_token, ''), acknowledged_at,
+			escalation_level, escalated_at
 		FROM alert
 		WHERE project_id = ?
 		ORDER BY created_at DESC
@@ -328,48 +293,12 @@ func (s *AlertStore) ListAlertsByProject(ctx context.Context, projectID int) ([]
 
 	var alerts []warnly.Alert
 	for rows.Next() {
-		var (
-			alert              warnly.Alert
-			lastTriggeredAt    sql.NullTime
-			resolvedAt         sql.NullTime
-			notificationSentAt sql.NullTime
-			description        sql.NullString
-		)
-		err := rows.Scan(
-			&alert.ID,
-			&alert.CreatedAt,
-			&alert.UpdatedAt,
-			&lastTriggeredAt,
-			&resolvedAt,
-			&notificationSentAt,
-			&alert.RuleName,
-			&description,
-			&alert.Status,
-			&alert.ProjectID,
-			&alert.TeamID,
-			&alert.Threshold,
-			&alert.Condition,
-			&alert.Timeframe,
-			&alert.HighPriority,
-		)
+		alert, err := scanAlert(rows)
 		if err != nil {
 			return nil, fmt.Errorf("mysql: scan alert: %w", err)
 		}
 
-		if lastTriggeredAt.Valid {
-			alert.LastTriggeredAt = &lastTriggeredAt.Time
-		}
-		if resolvedAt.Valid {
-			alert.ResolvedAt = &resolvedAt.Time
-		}
-		if notificationSentAt.Valid {
-			alert.NotificationSentAt = &notificationSentAt.Time
-		}
-		if description.Valid {
-			alert.Description = description.String
-		}
-
-		alerts = append(alerts, alert)
+		alerts = append(alerts, *alert)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -378,3 +307,114 @@ func (s *AlertStore) ListAlertsByProject(ctx context.Context, projectID int) ([]
 
 	return alerts, nil
 }
+
+// AcknowledgeAlert acknowledges the triggered alert the token was issued for.
+// Returns warnly.ErrNotFound if the token is unknown, the alert was resolved or already acknowledged.
+func (s *AlertStore) AcknowledgeAlert(ctx context.Context, ackToken string, at time.Time) error {
+	const query = `
+		UPDATE alert SET acknowledged_at = ?
+		WHERE ack_token = ? AND status = ? AND acknowledged_at IS NULL
+	`
+
+	res, err := s.db.ExecContext(ctx, query, at, ackToken, warnly.AlertStatusTriggered)
+	if err != nil {
+		return fmt.Errorf("mysql: acknowledge alert: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mysql: get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("mysql: acknowledge alert: %w", warnly.ErrNotFound)
+	}
+
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAlert scans an alert selected with all its columns.
+func scanAlert(row rowScanner) (*warnly.Alert, error) {
+	var (
+		alert                   warnly.Alert
+		lastTriggeredAt         sql.NullTime
+		resolvedAt              sql.NullTime
+		notificationSentAt      sql.NullTime
+		acknowledgedAt          sql.NullTime
+		escalatedAt             sql.NullTime
+		description             sql.NullString
+		escalationChain         []byte
+		escalationWindowSeconds uint32
+	)
+	err := row.Scan(
+		&alert.ID,
+		&alert.CreatedAt,
+		&alert.UpdatedAt,
+		&lastTriggeredAt,
+		&resolvedAt,
+		&notificationSentAt,
+		&alert.RuleName,
+		&description,
+		&alert.Status,
+		&alert.ProjectID,
+		&alert.TeamID,
+		&alert.Threshold,
+		&alert.Condition,
+		&alert.Timeframe,
+		&alert.HighPriority,
+		&alert.BaselineWindows,
+		&alert.SpikeMultiplier,
+		&escalationChain,
+		&escalationWindowSeconds,
+		&alert.AckToken,
+		&acknowledgedAt,
+		&alert.EscalationLevel,
+		&escalatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastTriggeredAt.Valid {
+		alert.LastTriggeredAt = &lastTriggeredAt.Time
+	}
+	if resolvedAt.Valid {
+		alert.ResolvedAt = &resolvedAt.Time
+	}
+	if notificationSentAt.Valid {
+		alert.NotificationSentAt = &notificationSentAt.Time
+	}
+	if acknowledgedAt.Valid {
+		alert.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if escalatedAt.Valid {
+		alert.EscalatedAt = &escalatedAt.Time
+	}
+	if description.Valid {
+		alert.Description = description.String
+	}
+	alert.EscalationWindow = time.Duration(escalationWindowSeconds) * time.Second
+	if len(escalationChain) > 0 {
+		if err := json.Unmarshal(escalationChain, &alert.EscalationChain); err != nil {
+			return nil, fmt.Errorf("unmarshal escalation chain: %w", err)
+		}
+	}
+
+	return &alert, nil
+}
+
+// marshalEscalationChain encodes the escalation chain of an alert, an empty chain is stored as NULL.
+func marshalEscalationChain(chain []int) ([]byte, error) {
+	if len(chain) == 0 {
+		return nil, nil
+	}
+	value, err := json.Marshal(chain)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: marshal escalation chain: %w", err)
+	}
+	return value, nil
+}
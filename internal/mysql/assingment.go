@@ -2,6 +2,8 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -20,21 +22,29 @@ func NewAssingmentStore(db ExtendedDB) *AssingmentStore {
 	return &AssingmentStore{db: db}
 }
 
-// CreateAssingment creates a new issue assignment in the database (assigns an issue to a user).
+// CreateAssingment creates a new issue assignment in the database (assigns an issue to a user or a team).
 func (s *AssingmentStore) CreateAssingment(ctx context.Context, a *warnly.Assignment) error {
-	const query = `INSERT INTO issue_assignment (issue_id, assigned_to_user_id, assigned_by_user_id, assigned_at)
-				   VALUES (?, ?, ?, ?)
+	const query = `INSERT INTO issue_assignment (issue_id, assigned_to_user_id, assigned_to_team_id, assigned_by_user_id, assigned_at)
+				   VALUES (?, ?, ?, ?, ?)
 				   ON DUPLICATE KEY UPDATE
 				   assigned_to_user_id = VALUES(assigned_to_user_id),
-				   assigned_to_team_id = NULL,
+				   assigned_to_team_id = VALUES(assigned_to_team_id),
 				   assigned_by_user_id = VALUES(assigned_by_user_id),
 				   assigned_at = VALUES(assigned_at);`
 
+	var userID, teamID sql.NullInt64
+	if a.AssigneeType == warnly.AssigneeTeam {
+		teamID = sql.NullInt64{Int64: a.AssignedToTeamID, Valid: true}
+	} else {
+		userID = sql.NullInt64{Int64: a.AssignedToUserID, Valid: true}
+	}
+
 	_, err := s.db.ExecContext(
 		ctx,
 		query,
 		a.IssueID,
-		a.AssignedToUserID,
+		userID,
+		teamID,
 		a.AssignedByUserID,
 		a.AssignedAt,
 	)
@@ -60,7 +70,7 @@ func (s *AssingmentStore) ListAssingments(ctx context.Context, issueIDs []int64)
 	placeholders, args := makePlaceholders(issueIDs)
 
 	query := fmt.Sprintf(`
-		SELECT issue_id, assigned_to_user_id
+		SELECT issue_id, assigned_to_user_id, assigned_to_team_id
 		FROM issue_assignment
 		WHERE issue_id IN (%s)
 	`, placeholders)
@@ -78,7 +88,7 @@ func (s *AssingmentStore) ListAssingments(ctx context.Context, issueIDs []int64)
 	var au []*warnly.AssignedUser
 	for rows.Next() {
 		var a warnly.AssignedUser
-		if err := rows.Scan(&a.IssueID, &a.AssignedToUserID); err != nil {
+		if err := rows.Scan(&a.IssueID, &a.AssignedToUserID, &a.AssignedToTeamID); err != nil {
 			return nil, fmt.Errorf("mysql issue assignment store: get assigned users, scan: %w", err)
 		}
 		au = append(au, &a)
@@ -90,6 +100,39 @@ func (s *AssingmentStore) ListAssingments(ctx context.Context, issueIDs []int64)
 	return au, nil
 }
 
+// ListProjectAssingments lists the assignments of all issues of a project.
+func (s *AssingmentStore) ListProjectAssingments(ctx context.Context, projectID int) ([]*warnly.AssignedUser, error) {
+	const query = `
+		SELECT a.issue_id, a.assigned_to_user_id, a.assigned_to_team_id
+		FROM issue_assignment AS a
+		INNER JOIN issue AS i ON i.id = a.issue_id
+		WHERE i.project_id = ?`
+
+	rows, err := s.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql issue assignment store: list project assignments: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql issue assignment store: list project assignments, close rows: %w", cerr)
+		}
+	}()
+
+	var au []*warnly.AssignedUser
+	for rows.Next() {
+		var a warnly.AssignedUser
+		if err := rows.Scan(&a.IssueID, &a.AssignedToUserID, &a.AssignedToTeamID); err != nil {
+			return nil, fmt.Errorf("mysql issue assignment store: list project assignments, scan: %w", err)
+		}
+		au = append(au, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql issue assignment store: list project assignments, rows error: %w", err)
+	}
+
+	return au, nil
+}
+
 func (s *AssingmentStore) ListAssignedFilters(
 	ctx context.Context,
 	criteria *warnly.GetAssignedFiltersCriteria,
@@ -160,3 +203,31 @@ func makePlaceholders[T constraints.Integer](ids []T) (string, []any) {
 
 	return strings.Join(placeholders, ","), args
 }
+
+// GetLastRotationAssignee returns the member who was assigned last by the team rotation.
+// Returns zero if the rotation hasn't started yet.
+func (s *AssingmentStore) GetLastRotationAssignee(ctx context.Context, teamID int) (int64, error) {
+	const query = `SELECT last_user_id FROM team_assignment_rotation WHERE team_id = ?`
+
+	var userID int64
+	if err := s.db.QueryRowContext(ctx, query, teamID).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("mysql issue assignment store: get last rotation assignee: %w", err)
+	}
+
+	return userID, nil
+}
+
+// SaveLastRotationAssignee stores the member who was assigned last by the team rotation.
+func (s *AssingmentStore) SaveLastRotationAssignee(ctx context.Context, teamID int, userID int64) error {
+	const query = `INSERT INTO team_assignment_rotation (team_id, last_user_id) VALUES (?, ?)
+				   ON DUPLICATE KEY UPDATE last_user_id = VALUES(last_user_id)`
+
+	if _, err := s.db.ExecContext(ctx, query, teamID, userID); err != nil {
+		return fmt.Errorf("mysql issue assignment store: save last rotation assignee: %w", err)
+	}
+
+	return nil
+}
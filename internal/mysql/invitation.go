@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// InvitationStore implements warnly.InvitationStore for MySQL.
+type InvitationStore struct {
+	db ExtendedDB
+}
+
+// NewInvitationStore is a constructor of InvitationStore.
+func NewInvitationStore(db ExtendedDB) *InvitationStore {
+	return &InvitationStore{db: db}
+}
+
+// CreateInvitation stores the invitation and returns its identifier.
+func (s *InvitationStore) CreateInvitation(ctx context.Context, inv *warnly.Invitation) (int64, error) {
+	const query = `INSERT INTO team_invitation (created_at, expires_at, email, team_id, role, invited_by)
+				   VALUES (?, ?, ?, ?, ?, ?)`
+
+	res, err := s.db.ExecContext(ctx, query, inv.CreatedAt, inv.ExpiresAt, inv.Email, inv.TeamID, inv.Role, inv.InvitedBy)
+	if err != nil {
+		return 0, fmt.Errorf("mysql invitation store: create invitation: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("mysql invitation store: create invitation last insert id: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetInvitation returns the invitation by identifier.
+func (s *InvitationStore) GetInvitation(ctx context.Context, id int64) (*warnly.Invitation, error) {
+	const query = `SELECT id, created_at, expires_at, accepted_at, email, team_id, role, invited_by
+				   FROM team_invitation WHERE id = ?`
+
+	var (
+		inv        warnly.Invitation
+		acceptedAt sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&inv.ID,
+		&inv.CreatedAt,
+		&inv.ExpiresAt,
+		&acceptedAt,
+		&inv.Email,
+		&inv.TeamID,
+		&inv.Role,
+		&inv.InvitedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, warnly.ErrNotFound
+		}
+		return nil, fmt.Errorf("mysql invitation store: get invitation: %w", err)
+	}
+	if acceptedAt.Valid {
+		inv.AcceptedAt = &acceptedAt.Time
+	}
+
+	return &inv, nil
+}
+
+// AcceptInvitation marks the invitation accepted. The update is conditional
+// so that a token used concurrently is accepted only once.
+func (s *InvitationStore) AcceptInvitation(ctx context.Context, id int64, at time.Time) error {
+	const query = `UPDATE team_invitation SET accepted_at = ? WHERE id = ? AND accepted_at IS NULL`
+
+	res, err := s.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		return fmt.Errorf("mysql invitation store: accept invitation: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mysql invitation store: accept invitation: %w", err)
+	}
+	if affected == 0 {
+		return warnly.ErrInvitationUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package mysql_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mysql"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestAcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	const query = `UPDATE team_invitation SET accepted_at = ? WHERE id = ? AND accepted_at IS NULL`
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		err      error
+		name     string
+		affected int64
+	}{
+		{name: "accepted", affected: 1},
+		{name: "already used", affected: 0, err: warnly.ErrInvitationUsed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec("^"+regexp.QuoteMeta(query)+"$").
+				WithArgs(at, int64(7)).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			err = mysql.NewInvitationStore(db).AcceptInvitation(t.Context(), 7, at)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,9 +3,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/vk-rv/warnly/internal/warnly"
@@ -24,9 +26,13 @@ func NewIssueStore(db ExtendedDB) *IssueStore {
 // GetIssue returns an issue by project identifier and hash obtained from event stacktrace or message.
 func (s *IssueStore) GetIssue(ctx context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
 	const query = `SELECT id, uuid, first_seen, last_seen, hash, message, view, 
-				   num_comments, project_id, priority FROM issue WHERE project_id = ? AND hash = ?`
+				   num_comments, project_id, priority, status, resolved_at, resolved_in_release,
+				   ignore_condition, ignore_threshold, ignored_at, ignored_until, noise_filters
+				   FROM issue WHERE project_id = ? AND hash = ?`
 
 	i := warnly.Issue{}
+	ic := ignoreColumns{}
+	var noiseFilters []byte
 	err := s.
 		db.
 		QueryRowContext(ctx, query, criteria.ProjectID, criteria.Hash).
@@ -39,13 +45,25 @@ func (s *IssueStore) GetIssue(ctx context.Context, criteria warnly.GetIssueCrite
 			&i.View,
 			&i.NumComments,
 			&i.ProjectID,
-			&i.Priority)
+			&i.Priority,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ResolvedInRelease,
+			&ic.condition,
+			&ic.threshold,
+			&ic.ignoredAt,
+			&ic.until,
+			&noiseFilters)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, warnly.ErrNotFound
 		}
 		return nil, fmt.Errorf("mysql issue store: get issue: %w", err)
 	}
+	i.Ignore = ic.rule(i.Status)
+	if i.NoiseFilters, err = unmarshalNoiseFilters(noiseFilters); err != nil {
+		return nil, err
+	}
 
 	return &i, nil
 }
@@ -53,10 +71,13 @@ func (s *IssueStore) GetIssue(ctx context.Context, criteria warnly.GetIssueCrite
 // GetIssueByID returns an issue by its unique database identifier.
 func (s *IssueStore) GetIssueByID(ctx context.Context, issueID int64) (*warnly.Issue, error) {
 	const query = `SELECT id, uuid, first_seen, last_seen, hash, message, view, 
-				   num_comments, project_id, priority, error_type 
+				   num_comments, project_id, priority, error_type, status, resolved_at, resolved_in_release,
+				   ignore_condition, ignore_threshold, ignored_at, ignored_until, noise_filters
 				   FROM issue WHERE id = ?`
 
 	i := &warnly.Issue{}
+	ic := ignoreColumns{}
+	var noiseFilters []byte
 	err := s.
 		db.
 		QueryRowContext(ctx, query, issueID).
@@ -70,21 +91,66 @@ func (s *IssueStore) GetIssueByID(ctx context.Context, issueID int64) (*warnly.I
 			&i.NumComments,
 			&i.ProjectID,
 			&i.Priority,
-			&i.ErrorType)
+			&i.ErrorType,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ResolvedInRelease,
+			&ic.condition,
+			&ic.threshold,
+			&ic.ignoredAt,
+			&ic.until,
+			&noiseFilters)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, warnly.ErrNotFound
 		}
 		return nil, fmt.Errorf("mysql issue store: get issue: %w", err)
 	}
+	i.Ignore = ic.rule(i.Status)
+	if i.NoiseFilters, err = unmarshalNoiseFilters(noiseFilters); err != nil {
+		return nil, err
+	}
 
 	return i, nil
 }
 
+// unmarshalNoiseFilters decodes the noise filters column of an issue row, nil when the issue has none.
+func unmarshalNoiseFilters(value []byte) (warnly.NoiseFilters, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	var filters warnly.NoiseFilters
+	if err := json.Unmarshal(value, &filters); err != nil {
+		return nil, fmt.Errorf("mysql issue store: unmarshal noise filters: %w", err)
+	}
+	return filters, nil
+}
+
+// ignoreColumns holds the ignore condition columns of an issue row.
+type ignoreColumns struct {
+	ignoredAt *time.Time
+	until     *time.Time
+	condition string
+	threshold int
+}
+
+// rule returns the ignore rule of an issue with the status, nil unless the issue is ignored.
+func (c *ignoreColumns) rule(status warnly.IssueStatus) *warnly.IgnoreRule {
+	if status != warnly.IssueStatusIgnored || c.ignoredAt == nil {
+		return nil
+	}
+	return &warnly.IgnoreRule{
+		IgnoredAt: *c.ignoredAt,
+		Until:     c.until,
+		Condition: warnly.IgnoreCondition(c.condition),
+		Threshold: c.threshold,
+	}
+}
+
 // ListIssues returns a list of issues for given project IDs and time range.
 func (s *IssueStore) ListIssues(ctx context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
 	query := `SELECT id, uuid, first_seen, last_seen, hash, message, view, num_comments,
-project_id, priority, error_type
+project_id, priority, error_type, status, resolved_at, resolved_in_release
 FROM issue WHERE project_id IN (?` + strings.Repeat(",?", len(criteria.ProjectIDs)-1) + `)
 AND ((last_seen BETWEEN ? AND ?) OR (first_seen BETWEEN ? AND ?))`
 
@@ -108,6 +174,12 @@ AND ((last_seen BETWEEN ? AND ?) OR (first_seen BETWEEN ? AND ?))`
 		}
 	}
 
+	if criteria.ExcludeIgnored {
+		query += ` AND status <> ?`
+		//nolint:makezero // false positive
+		args = append(args, warnly.IssueStatusIgnored)
+	}
+
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("mysql issue store: list issues: %w", err)
@@ -133,7 +205,10 @@ AND ((last_seen BETWEEN ? AND ?) OR (first_seen BETWEEN ? AND ?))`
 			&i.NumComments,
 			&i.ProjectID,
 			&i.Priority,
-			&i.ErrorType)
+			&i.ErrorType,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ResolvedInRelease)
 		if err != nil {
 			return nil, fmt.Errorf("mysql issue store: list issues: %w", err)
 		}
@@ -147,6 +222,88 @@ AND ((last_seen BETWEEN ? AND ?) OR (first_seen BETWEEN ? AND ?))`
 	return issues, nil
 }
 
+// CountIssues returns the number of issues seen in a period without listing them.
+func (s *IssueStore) CountIssues(ctx context.Context, criteria *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(first_seen > ?), 0)
+FROM issue WHERE project_id IN (?` + strings.Repeat(",?", len(criteria.ProjectIDs)-1) + `)
+AND ((last_seen BETWEEN ? AND ?) OR (first_seen BETWEEN ? AND ?))`
+
+	args := make([]any, 0, len(criteria.ProjectIDs)+6)
+	args = append(args, criteria.NewSince)
+	for _, id := range criteria.ProjectIDs {
+		args = append(args, id)
+	}
+	args = append(args, criteria.From, criteria.To, criteria.From, criteria.To)
+
+	if criteria.ExcludeIgnored {
+		query += ` AND status <> ?`
+		args = append(args, warnly.IssueStatusIgnored)
+	}
+
+	counts := warnly.IssueCounts{}
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(&counts.All, &counts.New); err != nil {
+		return warnly.IssueCounts{}, fmt.Errorf("mysql issue store: count issues: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ListIgnoredIssues returns the ignored issues of all projects ignored with one of the conditions.
+func (s *IssueStore) ListIgnoredIssues(ctx context.Context, conditions []warnly.IgnoreCondition) ([]warnly.Issue, error) {
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT id, hash, message, project_id, status,
+ignore_condition, ignore_threshold, ignored_at, ignored_until
+FROM issue WHERE status = ? AND ignore_condition IN (?` + strings.Repeat(",?", len(conditions)-1) + `)`
+
+	args := make([]any, 0, len(conditions)+1)
+	args = append(args, warnly.IssueStatusIgnored)
+	for _, c := range conditions {
+		args = append(args, string(c))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("mysql issue store: list ignored issues: %w", err)
+	}
+
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	var issues []warnly.Issue
+	for rows.Next() {
+		i := warnly.Issue{}
+		ic := ignoreColumns{}
+		err = rows.Scan(
+			&i.ID,
+			&i.Hash,
+			&i.Message,
+			&i.ProjectID,
+			&i.Status,
+			&ic.condition,
+			&ic.threshold,
+			&ic.ignoredAt,
+			&ic.until)
+		if err != nil {
+			return nil, fmt.Errorf("mysql issue store: list ignored issues: %w", err)
+		}
+		if i.Ignore = ic.rule(i.Status); i.Ignore != nil {
+			issues = append(issues, i)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql issue store: list ignored issues: %w", err)
+	}
+
+	return issues, nil
+}
+
 // StoreIssue stores a new issue in the database.
 func (s *IssueStore) StoreIssue(ctx context.Context, i *warnly.Issue) error {
 	const query = `INSERT INTO issue (uuid, first_seen, last_seen, hash, message, view, 
@@ -203,3 +360,176 @@ func (s *IssueStore) UpdateLastSeen(ctx context.Context, upd *warnly.UpdateLastS
 
 	return nil
 }
+
+// UpdateStatus changes the status of an issue, the ignore condition is cleared
+// unless the issue is being ignored and occurrences are counted anew.
+func (s *IssueStore) UpdateStatus(ctx context.Context, upd *warnly.UpdateIssueStatus) error {
+	const query = `UPDATE issue SET status = ?, resolved_at = ?, resolved_in_release = ?,
+				   ignore_condition = ?, ignore_threshold = ?, ignored_at = ?, ignored_until = ?, ignore_events = 0
+				   WHERE id = ?`
+
+	var (
+		condition    string
+		threshold    int
+		ignoredAt    *time.Time
+		ignoredUntil *time.Time
+	)
+	if upd.Ignore != nil {
+		condition = string(upd.Ignore.Condition)
+		threshold = upd.Ignore.Threshold
+		ignoredAt = &upd.Ignore.IgnoredAt
+		ignoredUntil = upd.Ignore.Until
+	}
+
+	_, err := s.db.ExecContext(ctx, query, upd.Status, upd.ResolvedAt, upd.ResolvedInRelease,
+		condition, threshold, ignoredAt, ignoredUntil, upd.IssueID)
+	if err != nil {
+		return fmt.Errorf("mysql issue store: update status: %w", err)
+	}
+
+	return nil
+}
+
+// ReopenIssue marks a resolved issue as unresolved again, reporting false when the issue was not resolved.
+// Only one of concurrent callers reopens the issue.
+func (s *IssueStore) ReopenIssue(ctx context.Context, issueID int64) (bool, error) {
+	const query = `UPDATE issue SET status = ?, resolved_at = NULL, resolved_in_release = ''
+				   WHERE id = ? AND status = ?`
+
+	res, err := s.db.ExecContext(ctx, query, warnly.IssueStatusUnresolved, issueID, warnly.IssueStatusResolved)
+	if err != nil {
+		return false, fmt.Errorf("mysql issue store: reopen issue: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("mysql issue store: reopen issue: rows affected: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// ReopenIgnored marks an ignored issue as unresolved again, reporting false when the issue was not ignored.
+// Only one of concurrent callers reopens the issue.
+func (s *IssueStore) ReopenIgnored(ctx context.Context, issueID int64) (bool, error) {
+	const query = `UPDATE issue SET status = ?, ignore_condition = '', ignore_threshold = 0,
+				   ignored_at = NULL, ignored_until = NULL, ignore_events = 0
+				   WHERE id = ? AND status = ?`
+
+	res, err := s.db.ExecContext(ctx, query, warnly.IssueStatusUnresolved, issueID, warnly.IssueStatusIgnored)
+	if err != nil {
+		return false, fmt.Errorf("mysql issue store: reopen ignored: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("mysql issue store: reopen ignored: rows affected: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// CountIgnoredEvent counts an occurrence of an ignored issue and returns the number of occurrences
+// since it was ignored, zero when the issue is not ignored. The counter is incremented and read
+// in a single statement through LAST_INSERT_ID, so concurrent events see distinct counts.
+func (s *IssueStore) CountIgnoredEvent(ctx context.Context, issueID int64) (int, error) {
+	const query = `UPDATE issue SET ignore_events = LAST_INSERT_ID(ignore_events + 1) WHERE id = ? AND status = ?`
+
+	res, err := s.db.ExecContext(ctx, query, issueID, warnly.IssueStatusIgnored)
+	if err != nil {
+		return 0, fmt.Errorf("mysql issue store: count ignored event: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("mysql issue store: count ignored event: rows affected: %w", err)
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	count, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("mysql issue store: count ignored event: last insert id: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// UpdatePriority sets the priority of an issue.
+func (s *IssueStore) UpdatePriority(ctx context.Context, issueID int64, priority warnly.IssuePriority) error {
+	const query = `UPDATE issue SET priority = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, priority, issueID); err != nil {
+		return fmt.Errorf("mysql issue store: update priority: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateNoiseFilters replaces the noise filters of an issue.
+func (s *IssueStore) UpdateNoiseFilters(ctx context.Context, issueID int64, filters warnly.NoiseFilters) error {
+	const query = `UPDATE issue SET noise_filters = ? WHERE id = ?`
+
+	var value []byte
+	if len(filters) > 0 {
+		var err error
+		if value, err = json.Marshal(filters); err != nil {
+			return fmt.Errorf("mysql issue store: marshal noise filters: %w", err)
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, value, issueID); err != nil {
+		return fmt.Errorf("mysql issue store: update noise filters: %w", err)
+	}
+
+	return nil
+}
+
+// RaisePriority sets the priority of an issue unless it is already higher.
+func (s *IssueStore) RaisePriority(ctx context.Context, issueID int64, priority warnly.IssuePriority) error {
+	const query = `UPDATE issue SET priority = GREATEST(priority, ?) WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, priority, issueID); err != nil {
+		return fmt.Errorf("mysql issue store: raise priority: %w", err)
+	}
+
+	return nil
+}
+
+// deleteIssuesQueries delete the rows that belong to issues, children before their parents.
+// The placeholder is replaced with the placeholders of the issue IDs.
+var deleteIssuesQueries = []string{
+	`DELETE mv FROM message_view AS mv INNER JOIN message AS m ON m.id = mv.message_id WHERE m.issue_id IN (%s)`,
+	`DELETE mn FROM mention AS mn INNER JOIN message AS m ON m.id = mn.message_id WHERE m.issue_id IN (%s)`,
+	`DELETE FROM message WHERE issue_id IN (%s)`,
+	`DELETE FROM issue_assignment WHERE issue_id IN (%s)`,
+	`DELETE FROM issue_assignment_history WHERE issue_id IN (%s)`,
+	`DELETE FROM issue_activity WHERE issue_id IN (%s)`,
+	`DELETE FROM issue_subscription WHERE issue_id IN (%s)`,
+	`DELETE FROM issue_label WHERE issue_id IN (%s)`,
+	`DELETE FROM issue_seen WHERE issue_id IN (%s)`,
+	`DELETE FROM issue WHERE id IN (%s)`,
+}
+
+// DeleteIssues deletes issues along with the rows that belong to them.
+// The issue rows are deleted last, so a delete that failed midway is completed by running it again.
+func (s *IssueStore) DeleteIssues(ctx context.Context, issueIDs []int64) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+
+	placeholders := "?" + strings.Repeat(",?", len(issueIDs)-1)
+	args := make([]any, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+
+	for _, query := range deleteIssuesQueries {
+		if _, err := s.db.ExecContext(ctx, fmt.Sprintf(query, placeholders), args...); err != nil {
+			return fmt.Errorf("mysql issue store: delete issues: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// IssueLabelStore implements warnly.IssueLabelStore for MySQL.
+type IssueLabelStore struct {
+	db ExtendedDB
+}
+
+// NewIssueLabelStore is a constructor of IssueLabelStore repository.
+func NewIssueLabelStore(db ExtendedDB) *IssueLabelStore {
+	return &IssueLabelStore{db: db}
+}
+
+// CreateLabel attaches the label to the issue, attaching it again keeps the existing label.
+func (s *IssueLabelStore) CreateLabel(ctx context.Context, label *warnly.IssueLabel) error {
+	const query = `INSERT INTO issue_label (issue_id, name, user_id, created_at) VALUES (?, ?, ?, ?)
+				   ON DUPLICATE KEY UPDATE issue_id = issue_id`
+
+	_, err := s.db.ExecContext(ctx, query, label.IssueID, label.Name, label.UserID, label.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("mysql issue label store: create label: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteLabel removes the label from the issue.
+func (s *IssueLabelStore) DeleteLabel(ctx context.Context, issueID int64, name string) error {
+	const query = `DELETE FROM issue_label WHERE issue_id = ? AND name = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, issueID, name); err != nil {
+		return fmt.Errorf("mysql issue label store: delete label: %w", err)
+	}
+
+	return nil
+}
+
+// ListLabels returns the labels of the issue ordered by name.
+func (s *IssueLabelStore) ListLabels(ctx context.Context, issueID int64) (labels []warnly.IssueLabel, err error) {
+	const query = `SELECT issue_id, name, user_id, created_at FROM issue_label WHERE issue_id = ? ORDER BY name`
+
+	rows, err := s.db.QueryContext(ctx, query, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql issue label store: list labels: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql issue label store: list labels, close rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var l warnly.IssueLabel
+		if err := rows.Scan(&l.IssueID, &l.Name, &l.UserID, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("mysql issue label store: scan label: %w", err)
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql issue label store: list labels rows: %w", err)
+	}
+
+	return labels, nil
+}
+
+// ListLabeledIssueIDs returns identifiers of the issues of the teams' projects that have the label,
+// the team is taken from the project, so that the labels follow a project transferred to another team.
+func (s *IssueLabelStore) ListLabeledIssueIDs(ctx context.Context, teamIDs []int, name string) (ids []int64, err error) {
+	if len(teamIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT l.issue_id FROM issue_label AS l
+INNER JOIN issue AS i ON i.id = l.issue_id
+INNER JOIN project AS p ON p.id = i.project_id
+WHERE p.team_id IN (?` + strings.Repeat(",?", len(teamIDs)-1) + `)
+AND l.name = ? ORDER BY l.issue_id`
+
+	args := make([]any, 0, len(teamIDs)+1)
+	for _, id := range teamIDs {
+		args = append(args, id)
+	}
+	args = append(args, name)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("mysql issue label store: list labeled issues: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql issue label store: list labeled issues, close rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("mysql issue label store: scan labeled issue: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql issue label store: list labeled issues rows: %w", err)
+	}
+
+	return ids, nil
+}
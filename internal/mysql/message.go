@@ -66,6 +66,35 @@ func (s *MessageStore) CountMessages(ctx context.Context, issueID int64) (int, e
 	return count, nil
 }
 
+// ListCommenters returns identifiers of the users who wrote in the issue discussion.
+func (s *MessageStore) ListCommenters(ctx context.Context, issueID int64) ([]int64, error) {
+	const query = `SELECT DISTINCT user_id FROM message WHERE issue_id = ?`
+
+	rows, err := s.db.QueryContext(ctx, query, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql message store: list commenters: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql message store: list commenters: %w", cerr)
+		}
+	}()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("mysql message store: list commenters scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql message store: list commenters rows: %w", err)
+	}
+
+	return ids, nil
+}
+
 // ListIssueMessages is a method that lists all messages (comments) in the issue discussion.
 func (s *MessageStore) ListIssueMessages(ctx context.Context, issueID int64) ([]warnly.IssueMessage, error) {
 	const query = `SELECT m.id, u.name, m.user_id, m.content, m.created_at
@@ -137,6 +166,19 @@ func (s *MentionStore) DeleteMentions(ctx context.Context, messageID int) error
 	return nil
 }
 
+// DeleteMessagesMentions deletes all mentions from the given issue comments.
+func (s *MentionStore) DeleteMessagesMentions(ctx context.Context, messageIDs []int) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	placeholders, args := makePlaceholders(messageIDs)
+	_, err := s.db.ExecContext(ctx, `DELETE FROM mention WHERE message_id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("mysql mention store: delete messages mentions: %w", err)
+	}
+	return nil
+}
+
 // DeleteMessage deletes a message in the issue discussion.
 func (s *MessageStore) DeleteMessage(ctx context.Context, messageID, userID int) error {
 	const query = `DELETE FROM message WHERE id = ? AND user_id = ?`
@@ -147,6 +189,57 @@ func (s *MessageStore) DeleteMessage(ctx context.Context, messageID, userID int)
 	return nil
 }
 
+// ListExpiredMessages returns identifiers of messages created before the criteria time,
+// skipping messages of unresolved issues when the criteria keeps open issues.
+func (s *MessageStore) ListExpiredMessages(ctx context.Context, criteria *warnly.ExpiredMessagesCriteria) ([]int, error) {
+	query := `SELECT m.id FROM message AS m JOIN issue AS i ON m.issue_id = i.id WHERE m.created_at < ?`
+	if criteria.KeepOpenIssues {
+		query += ` AND i.status = 'resolved'`
+	}
+	query += ` ORDER BY m.id LIMIT ?`
+
+	rows, err := s.db.QueryContext(ctx, query, criteria.Before, criteria.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("mysql message store: list expired messages: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql message store: list expired messages: %w", cerr)
+		}
+	}()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("mysql message store: list expired messages scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql message store: list expired messages rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+// DeleteMessages deletes messages by identifiers, views of the messages are deleted first.
+func (s *MessageStore) DeleteMessages(ctx context.Context, messageIDs []int) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	placeholders, args := makePlaceholders(messageIDs)
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM message_view WHERE message_id IN (`+placeholders+`)`, args...); err != nil {
+		return fmt.Errorf("mysql message store: delete message views: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM message WHERE id IN (`+placeholders+`)`, args...); err != nil {
+		return fmt.Errorf("mysql message store: delete messages: %w", err)
+	}
+
+	return nil
+}
+
 // CountMessagesByIDs counts all messages in the issue discussion by IDs.
 func (s *MessageStore) CountMessagesByIDs(ctx context.Context, issueIDs []int64) ([]warnly.MessageCount, error) {
 	if len(issueIDs) == 0 {
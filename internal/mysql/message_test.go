@@ -0,0 +1,84 @@
+package mysql_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/vk-rv/warnly/internal/mysql"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestListExpiredMessages(t *testing.T) {
+	t.Parallel()
+
+	before := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		keepOpenIssues bool
+	}{
+		{
+			name:           "KeepOpenIssues",
+			query:          `SELECT m.id FROM message AS m JOIN issue AS i ON m.issue_id = i.id WHERE m.created_at < ? AND i.status = 'resolved' ORDER BY m.id LIMIT ?`,
+			keepOpenIssues: true,
+		},
+		{
+			name:  "AllIssues",
+			query: `SELECT m.id FROM message AS m JOIN issue AS i ON m.issue_id = i.id WHERE m.created_at < ? ORDER BY m.id LIMIT ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery("^"+regexp.QuoteMeta(tt.query)+"$").
+				WithArgs(before, 100).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(7))
+
+			store := mysql.NewMessageStore(db)
+
+			ids, err := store.ListExpiredMessages(t.Context(), &warnly.ExpiredMessagesCriteria{
+				Before:         before,
+				KeepOpenIssues: tt.keepOpenIssues,
+				Limit:          100,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []int{3, 7}, ids)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteMessages(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM message_view WHERE message_id IN (?,?)`)).
+		WithArgs(3, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM message WHERE id IN (?,?)`)).
+		WithArgs(3, 7).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	store := mysql.NewMessageStore(db)
+
+	assert.NoError(t, store.DeleteMessages(t.Context(), []int{3, 7}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -336,6 +336,77 @@ func (s *NotificationStore) ListPendingNotifications(ctx context.Context, limit
 	return notifications, rows.Err()
 }
 
+// CreateWebhookDeadLetter stores a webhook delivery that failed after all attempts.
+func (s *NotificationStore) CreateWebhookDeadLetter(ctx context.Context, deadLetter *warnly.WebhookDeadLetter) error {
+	const query = `
+		INSERT INTO webhook_dead_letter (created_at, channel_id, url, payload, attempts, last_error)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := s.db.ExecContext(
+		ctx,
+		query,
+		deadLetter.CreatedAt,
+		deadLetter.ChannelID,
+		deadLetter.URL,
+		deadLetter.Payload,
+		deadLetter.Attempts,
+		deadLetter.LastError)
+	if err != nil {
+		return fmt.Errorf("mysql: insert webhook dead letter: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("mysql: get last insert id: %w", err)
+	}
+
+	deadLetter.ID = id
+
+	return nil
+}
+
+// ListWebhookDeadLetters returns the most recent failed deliveries of a channel.
+func (s *NotificationStore) ListWebhookDeadLetters(
+	ctx context.Context,
+	channelID, limit int,
+) ([]warnly.WebhookDeadLetter, error) {
+	const query = `
+		SELECT id, created_at, channel_id, url, payload, attempts, last_error
+		FROM webhook_dead_letter
+		WHERE channel_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+	rows, err := s.db.QueryContext(ctx, query, channelID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("mysql: query webhook dead letters: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	var deadLetters []warnly.WebhookDeadLetter
+	for rows.Next() {
+		var d warnly.WebhookDeadLetter
+		if err := rows.Scan(
+			&d.ID,
+			&d.CreatedAt,
+			&d.ChannelID,
+			&d.URL,
+			&d.Payload,
+			&d.Attempts,
+			&d.LastError,
+		); err != nil {
+			return nil, fmt.Errorf("mysql: scan webhook dead letter: %w", err)
+		}
+		deadLetters = append(deadLetters, d)
+	}
+
+	return deadLetters, rows.Err()
+}
+
 // AcquireAlertLock attempts to acquire a lock for processing an alert.
 func (s *NotificationStore) AcquireAlertLock(ctx context.Context, lock *warnly.AlertLock) (bool, error) {
 	const query = `
@@ -3,10 +3,13 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
 
@@ -20,7 +23,7 @@ func NewProjectStore(db ExtendedDB) *ProjectStore {
 	return &ProjectStore{db: db}
 }
 
-// CreateProject is a method that creates a new project.
+// CreateProject is a method that creates a new project along with its key.
 func (s *ProjectStore) CreateProject(ctx context.Context, p *warnly.Project) error {
 	const query = `INSERT INTO project (created_at, name, user_id, team_id, platform, project_key) VALUES (?, ?, ?, ?, ?, ?)`
 
@@ -43,11 +46,48 @@ func (s *ProjectStore) CreateProject(ctx context.Context, p *warnly.Project) err
 	}
 	p.ID = int(id)
 
+	if err := s.AddKey(ctx, &warnly.ProjectKey{CreatedAt: p.CreatedAt, ProjectID: p.ID, Key: p.Key}); err != nil {
+		return fmt.Errorf("mysql project store: create project: %w", err)
+	}
+
 	return nil
 }
 
-// DeleteProject deletes a project by project unique identifier.
+// purgeProjectQueries delete the rows that belong to a project, children before their parents.
+var purgeProjectQueries = []string{
+	`DELETE mv FROM message_view AS mv
+	 INNER JOIN message AS m ON m.id = mv.message_id
+	 INNER JOIN issue AS i ON i.id = m.issue_id
+	 WHERE i.project_id = ?`,
+	`DELETE mn FROM mention AS mn
+	 INNER JOIN message AS m ON m.id = mn.message_id
+	 INNER JOIN issue AS i ON i.id = m.issue_id
+	 WHERE i.project_id = ?`,
+	`DELETE m FROM message AS m INNER JOIN issue AS i ON i.id = m.issue_id WHERE i.project_id = ?`,
+	`DELETE a FROM issue_assignment AS a INNER JOIN issue AS i ON i.id = a.issue_id WHERE i.project_id = ?`,
+	`DELETE h FROM issue_assignment_history AS h INNER JOIN issue AS i ON i.id = h.issue_id WHERE i.project_id = ?`,
+	`DELETE a FROM issue_activity AS a INNER JOIN issue AS i ON i.id = a.issue_id WHERE i.project_id = ?`,
+	`DELETE s FROM issue_subscription AS s INNER JOIN issue AS i ON i.id = s.issue_id WHERE i.project_id = ?`,
+	`DELETE l FROM issue_label AS l INNER JOIN issue AS i ON i.id = l.issue_id WHERE i.project_id = ?`,
+	`DELETE v FROM issue_seen AS v INNER JOIN issue AS i ON i.id = v.issue_id WHERE i.project_id = ?`,
+	`DELETE FROM issue WHERE project_id = ?`,
+	`DELETE l FROM alert_lock AS l INNER JOIN alert AS a ON a.id = l.alert_id WHERE a.project_id = ?`,
+	`DELETE n FROM alert_notification AS n INNER JOIN alert AS a ON a.id = n.alert_id WHERE a.project_id = ?`,
+	`DELETE FROM alert WHERE project_id = ?`,
+	`DELETE FROM issue_default_query WHERE project_id = ?`,
+	`DELETE FROM discarded_event WHERE project_id = ?`,
+	`DELETE FROM project_key WHERE project_id = ?`,
+}
+
+// DeleteProject purges a project by project unique identifier along with the rows that belong to it.
+// The project row is deleted last, so a purge that failed midway is completed by running it again.
 func (s *ProjectStore) DeleteProject(ctx context.Context, projectID int) error {
+	for _, query := range purgeProjectQueries {
+		if _, err := s.db.ExecContext(ctx, query, projectID); err != nil {
+			return fmt.Errorf("mysql project store: delete project: %w", err)
+		}
+	}
+
 	const query = `DELETE FROM project WHERE id = ?`
 
 	res, err := s.db.ExecContext(ctx, query, projectID)
@@ -66,13 +106,95 @@ func (s *ProjectStore) DeleteProject(ctx context.Context, projectID int) error {
 	return nil
 }
 
+// SoftDelete marks the project deleted at the time.
+func (s *ProjectStore) SoftDelete(ctx context.Context, projectID int, at time.Time) error {
+	const query = `UPDATE project SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	res, err := s.db.ExecContext(ctx, query, at, projectID)
+	if err != nil {
+		return fmt.Errorf("mysql project store: soft delete project: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mysql project store: soft delete project: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("mysql project store: soft delete project with id %d: %w", projectID, warnly.ErrProjectNotFound)
+	}
+
+	return nil
+}
+
+// Restore brings back the deleted project.
+func (s *ProjectStore) Restore(ctx context.Context, projectID int) error {
+	const query = `UPDATE project SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+
+	res, err := s.db.ExecContext(ctx, query, projectID)
+	if err != nil {
+		return fmt.Errorf("mysql project store: restore project: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mysql project store: restore project: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("mysql project store: restore project with id %d: %w", projectID, warnly.ErrProjectNotFound)
+	}
+
+	return nil
+}
+
+// ListDeletedProjectIDs returns identifiers of projects deleted before the time.
+func (s *ProjectStore) ListDeletedProjectIDs(ctx context.Context, before time.Time) ([]int, error) {
+	const query = `SELECT id FROM project WHERE deleted_at < ? ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("mysql project store: list deleted project ids: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			err = cerr
+		}
+	}()
+
+	return scan(rows, func(rows *sql.Rows) (int, error) {
+		var id int
+		err := rows.Scan(&id)
+		return id, err
+	})
+}
+
 // GetProject returns a project by unique identifier.
-// Returns warnly.ErrProjectNotFound if project does not exist.
+// Returns warnly.ErrProjectNotFound if project does not exist or is deleted.
 func (s *ProjectStore) GetProject(ctx context.Context, projectID int) (*warnly.Project, error) {
-	const query = `SELECT id, created_at, name, user_id, team_id, platform, project_key FROM project WHERE id = ?`
+	const query = `SELECT id, created_at, name, user_id, team_id, platform, project_key, code_owners, deleted_at, source_url_template
+FROM project WHERE id = ? AND deleted_at IS NULL`
+
+	return s.getProject(ctx, query, projectID)
+}
+
+// GetDeletedProject returns a deleted project by unique identifier.
+// Returns warnly.ErrProjectNotFound if project does not exist or isn't deleted.
+func (s *ProjectStore) GetDeletedProject(ctx context.Context, projectID int) (*warnly.Project, error) {
+	const query = `SELECT id, created_at, name, user_id, team_id, platform, project_key, code_owners, deleted_at, source_url_template
+FROM project WHERE id = ? AND deleted_at IS NOT NULL`
+
+	return s.getProject(ctx, query, projectID)
+}
 
+// getProject returns the project selected by the query.
+func (s *ProjectStore) getProject(ctx context.Context, query string, projectID int) (*warnly.Project, error) {
 	p := &warnly.Project{}
-	err := s.db.QueryRowContext(ctx, query, projectID).Scan(&p.ID, &p.CreatedAt, &p.Name, &p.UserID, &p.TeamID, &p.Platform, &p.Key)
+	var (
+		codeOwners []byte
+		deletedAt  sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx, query, projectID).
+		Scan(&p.ID, &p.CreatedAt, &p.Name, &p.UserID, &p.TeamID, &p.Platform, &p.Key, &codeOwners, &deletedAt,
+			&p.SourceURLTemplate)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("mysql project store: get project with id %d: %w", projectID, warnly.ErrProjectNotFound)
@@ -80,15 +202,36 @@ func (s *ProjectStore) GetProject(ctx context.Context, projectID int) (*warnly.P
 		return nil, fmt.Errorf("mysql project store: get project: %w", err)
 	}
 
+	if len(codeOwners) > 0 {
+		if err := json.Unmarshal(codeOwners, &p.CodeOwners); err != nil {
+			return nil, fmt.Errorf("mysql project store: unmarshal code owners: %w", err)
+		}
+	}
+	if deletedAt.Valid {
+		p.DeletedAt = &deletedAt.Time
+	}
+
 	return p, nil
 }
 
 // GetOptions returns project options by project ID.
 func (s *ProjectStore) GetOptions(ctx context.Context, projectID int, projectKey string) (*warnly.ProjectOptions, error) {
-	const query = `SELECT id, name, platform FROM project WHERE id = ? AND project_key = ?`
+	const query = `SELECT p.id, p.name, p.team_id, p.platform, p.sample_rate, p.grouping_strategy, p.priority_rules,
+p.grouping_rules, COALESCE(p.ingest_secret, ''), p.dedup_window_seconds, p.retention_days, p.ingest_filters,
+k.revoked_at
+FROM project AS p INNER JOIN project_key AS k ON k.project_id = p.id
+WHERE p.id = ? AND k.project_key = ? AND p.deleted_at IS NULL`
 
 	opts := &warnly.ProjectOptions{}
-	err := s.db.QueryRowContext(ctx, query, projectID, projectKey).Scan(&opts.ID, &opts.Name, &opts.Platform)
+	var (
+		priorityRules, groupingRules, ingestFilters []byte
+		dedupWindowSeconds                          uint32
+		keyRevokedAt                                sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx, query, projectID, projectKey).
+		Scan(&opts.ID, &opts.Name, &opts.TeamID, &opts.Platform, &opts.SampleRate, &opts.Grouping,
+			&priorityRules, &groupingRules, &opts.IngestSecret, &dedupWindowSeconds,
+			&opts.RetentionDays, &ingestFilters, &keyRevokedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("mysql project store: get project options with id %d: %w", projectID, warnly.ErrProjectNotFound)
@@ -96,9 +239,275 @@ func (s *ProjectStore) GetOptions(ctx context.Context, projectID int, projectKey
 		return nil, fmt.Errorf("mysql project store: get project options: %w", err)
 	}
 
+	if len(priorityRules) > 0 {
+		if err := json.Unmarshal(priorityRules, &opts.PriorityRules); err != nil {
+			return nil, fmt.Errorf("mysql project store: unmarshal priority rules: %w", err)
+		}
+	}
+
+	if len(groupingRules) > 0 {
+		opts.GroupingRules = &warnly.GroupingRules{}
+		if err := json.Unmarshal(groupingRules, opts.GroupingRules); err != nil {
+			return nil, fmt.Errorf("mysql project store: unmarshal grouping rules: %w", err)
+		}
+	}
+
+	if len(ingestFilters) > 0 {
+		opts.IngestFilters = &warnly.IngestFilters{}
+		if err := json.Unmarshal(ingestFilters, opts.IngestFilters); err != nil {
+			return nil, fmt.Errorf("mysql project store: unmarshal ingest filters: %w", err)
+		}
+	}
+
+	opts.DedupWindow = time.Duration(dedupWindowSeconds) * time.Second
+	if keyRevokedAt.Valid {
+		opts.KeyRevokedAt = &keyRevokedAt.Time
+	}
+
 	return opts, nil
 }
 
+// AddKey adds an ingest key to the project.
+func (s *ProjectStore) AddKey(ctx context.Context, key *warnly.ProjectKey) error {
+	const query = `INSERT INTO project_key (created_at, project_id, project_key) VALUES (?, ?, ?)`
+
+	res, err := s.db.ExecContext(ctx, query, key.CreatedAt, key.ProjectID, key.Key)
+	if err != nil {
+		return fmt.Errorf("mysql project store: add key: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("mysql project store: add key: %w", err)
+	}
+	key.ID = id
+
+	return nil
+}
+
+// ListKeys returns the keys of the project, revoked ones included, oldest first.
+func (s *ProjectStore) ListKeys(ctx context.Context, projectID int) ([]warnly.ProjectKey, error) {
+	const query = `SELECT k.id, k.created_at, k.revoked_at, k.project_id, k.project_key, k.project_key = p.project_key
+FROM project_key AS k INNER JOIN project AS p ON p.id = k.project_id
+WHERE k.project_id = ? ORDER BY k.id`
+
+	rows, err := s.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql project store: list keys: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			err = cerr
+		}
+	}()
+
+	return scan(rows, func(rows *sql.Rows) (warnly.ProjectKey, error) {
+		var (
+			key       warnly.ProjectKey
+			revokedAt sql.NullTime
+		)
+		err := rows.Scan(&key.ID, &key.CreatedAt, &revokedAt, &key.ProjectID, &key.Key, &key.Primary)
+		if revokedAt.Valid {
+			key.RevokedAt = &revokedAt.Time
+		}
+		return key, err
+	})
+}
+
+// RevokeKey stops accepting the key at the time, a key revoked later than that is revoked earlier.
+// Returns warnly.ErrProjectKeyNotFound if the project has no such key or it is already revoked by the time.
+func (s *ProjectStore) RevokeKey(ctx context.Context, projectID int, keyID int64, at time.Time) error {
+	const query = `UPDATE project_key SET revoked_at = ?
+WHERE id = ? AND project_id = ? AND (revoked_at IS NULL OR revoked_at > ?)`
+
+	res, err := s.db.ExecContext(ctx, query, at, keyID, projectID, at)
+	if err != nil {
+		return fmt.Errorf("mysql project store: revoke key: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mysql project store: revoke key: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("mysql project store: revoke key with id %d: %w", keyID, warnly.ErrProjectKeyNotFound)
+	}
+
+	return nil
+}
+
+// UpdatePrimaryKey makes the key the one of the project DSN.
+func (s *ProjectStore) UpdatePrimaryKey(ctx context.Context, projectID int, key string) error {
+	const query = `UPDATE project SET project_key = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, key, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update primary key: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSampleRate updates the share of events kept for the project.
+func (s *ProjectStore) UpdateSampleRate(ctx context.Context, projectID int, sampleRate float64) error {
+	const query = `UPDATE project SET sample_rate = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, sampleRate, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update sample rate: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDedupWindow updates the window duplicate events of the project are not stored within.
+func (s *ProjectStore) UpdateDedupWindow(ctx context.Context, projectID int, window time.Duration) error {
+	const query = `UPDATE project SET dedup_window_seconds = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, uint32(window/time.Second), projectID); err != nil {
+		return fmt.Errorf("mysql project store: update dedup window: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateOptions updates the platform of the project and the number of days its new events are kept.
+func (s *ProjectStore) UpdateOptions(ctx context.Context, projectID int, platform warnly.Platform, retentionDays uint8) error {
+	const query = `UPDATE project SET platform = ?, retention_days = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, platform, retentionDays, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update options: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSourceURLTemplate updates the template of links from the project stack frames to the repository.
+func (s *ProjectStore) UpdateSourceURLTemplate(ctx context.Context, projectID int, template string) error {
+	const query = `UPDATE project SET source_url_template = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, template, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update source url template: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGrouping updates the grouping strategy of the project.
+func (s *ProjectStore) UpdateGrouping(ctx context.Context, projectID int, grouping warnly.GroupingStrategy) error {
+	const query = `UPDATE project SET grouping_strategy = ? WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, grouping, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update grouping: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePriorityRules replaces the priority rules of the project.
+func (s *ProjectStore) UpdatePriorityRules(ctx context.Context, projectID int, rules []warnly.PriorityRule) error {
+	const query = `UPDATE project SET priority_rules = ? WHERE id = ?`
+
+	var value []byte
+	if len(rules) > 0 {
+		var err error
+		if value, err = json.Marshal(rules); err != nil {
+			return fmt.Errorf("mysql project store: marshal priority rules: %w", err)
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, value, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update priority rules: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGroupingRules replaces the grouping rules of the project.
+func (s *ProjectStore) UpdateGroupingRules(ctx context.Context, projectID int, rules *warnly.GroupingRules) error {
+	const query = `UPDATE project SET grouping_rules = ? WHERE id = ?`
+
+	var value []byte
+	if !rules.IsZero() {
+		var err error
+		if value, err = json.Marshal(rules); err != nil {
+			return fmt.Errorf("mysql project store: marshal grouping rules: %w", err)
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, value, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update grouping rules: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIngestFilters replaces the ingest filters of the project.
+func (s *ProjectStore) UpdateIngestFilters(ctx context.Context, projectID int, filters *warnly.IngestFilters) error {
+	const query = `UPDATE project SET ingest_filters = ? WHERE id = ?`
+
+	var value []byte
+	if !filters.IsZero() {
+		var err error
+		if value, err = json.Marshal(filters); err != nil {
+			return fmt.Errorf("mysql project store: marshal ingest filters: %w", err)
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, value, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update ingest filters: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIngestSecret sets the secret ingested payloads of the project are signed with.
+func (s *ProjectStore) UpdateIngestSecret(ctx context.Context, projectID int, secret string) error {
+	const query = `UPDATE project SET ingest_secret = NULLIF(?, '') WHERE id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, secret, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update ingest secret: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTeam moves the project with its alerts to another team.
+// Returns warnly.ErrDuplicate if the team already has a project with the same name.
+func (s *ProjectStore) UpdateTeam(ctx context.Context, projectID, teamID int) error {
+	const query = `UPDATE project AS p
+				   LEFT JOIN alert AS a ON a.project_id = p.id
+				   SET p.team_id = ?, a.team_id = ?
+				   WHERE p.id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, teamID, teamID, projectID); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateKey {
+			return warnly.ErrDuplicate
+		}
+		return fmt.Errorf("mysql project store: update team: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCodeOwners replaces the code owner rules of the project.
+func (s *ProjectStore) UpdateCodeOwners(ctx context.Context, projectID int, owners []warnly.CodeOwner) error {
+	const query = `UPDATE project SET code_owners = ? WHERE id = ?`
+
+	var value []byte
+	if len(owners) > 0 {
+		var err error
+		if value, err = json.Marshal(owners); err != nil {
+			return fmt.Errorf("mysql project store: marshal code owners: %w", err)
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, value, projectID); err != nil {
+		return fmt.Errorf("mysql project store: update code owners: %w", err)
+	}
+
+	return nil
+}
+
 // ListProjects returns a list of projects by team unique identifiers.
 func (s *ProjectStore) ListProjects(
 	ctx context.Context,
@@ -119,6 +528,27 @@ func (s *ProjectStore) ListProjects(
 	return scan(rows, scanProject)
 }
 
+// ListProjectIDs returns identifiers of all projects that aren't deleted.
+func (s *ProjectStore) ListProjectIDs(ctx context.Context) ([]int, error) {
+	const query = `SELECT id FROM project WHERE deleted_at IS NULL ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("mysql project store: list project ids: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			err = cerr
+		}
+	}()
+
+	return scan(rows, func(rows *sql.Rows) (int, error) {
+		var id int
+		err := rows.Scan(&id)
+		return id, err
+	})
+}
+
 // scanProject scans a single project from sql.Rows.
 func scanProject(rows *sql.Rows) (warnly.Project, error) {
 	var p warnly.Project
@@ -132,7 +562,7 @@ func scanProject(rows *sql.Rows) (warnly.Project, error) {
 // scanTeammates scans a single teammate from sql.Rows.
 func scanTeammates(rows *sql.Rows) (warnly.Teammate, error) {
 	var t warnly.Teammate
-	err := rows.Scan(&t.ID, &t.Name, &t.Surname, &t.Email, &t.Username)
+	err := rows.Scan(&t.ID, &t.Name, &t.Surname, &t.Email, &t.Username, &t.Role, &t.Active)
 	if err != nil {
 		return warnly.Teammate{}, err
 	}
@@ -159,7 +589,8 @@ func scan[T any](rows *sql.Rows, scanFunc func(*sql.Rows) (T, error)) ([]T, erro
 
 // buildListProjectsQuery builds the SQL query and arguments for listing projects.
 func buildListProjectsQuery(teamIDs []int, name string) (string, []any) {
-	query := `SELECT id, name, platform FROM project WHERE team_id IN (` + strings.Repeat("?,", len(teamIDs)-1) + `?)`
+	query := `SELECT id, name, platform FROM project WHERE deleted_at IS NULL AND team_id IN (` +
+		strings.Repeat("?,", len(teamIDs)-1) + `?)`
 	if name != "" {
 		query += ` AND name LIKE ?`
 	}
@@ -174,3 +605,100 @@ func buildListProjectsQuery(teamIDs []int, name string) (string, []any) {
 
 	return query, args
 }
+
+// GetDefaultIssuesQuery returns the default issues query of the user for the project.
+func (s *ProjectStore) GetDefaultIssuesQuery(ctx context.Context, userID, projectID int) (string, error) {
+	const query = `SELECT query FROM issue_default_query WHERE user_id = ? AND project_id = ?`
+
+	var q string
+	if err := s.db.QueryRowContext(ctx, query, userID, projectID).Scan(&q); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", warnly.ErrNotFound
+		}
+		return "", fmt.Errorf("mysql project store: get default issues query: %w", err)
+	}
+
+	return q, nil
+}
+
+// SaveDefaultIssuesQuery stores the default issues query of the user for the project, an empty query removes it.
+func (s *ProjectStore) SaveDefaultIssuesQuery(ctx context.Context, q *warnly.DefaultIssuesQuery) error {
+	if q.Query == "" {
+		const query = `DELETE FROM issue_default_query WHERE user_id = ? AND project_id = ?`
+		if _, err := s.db.ExecContext(ctx, query, q.UserID, q.ProjectID); err != nil {
+			return fmt.Errorf("mysql project store: delete default issues query: %w", err)
+		}
+		return nil
+	}
+
+	const query = `INSERT INTO issue_default_query (user_id, project_id, query, updated_at) VALUES (?, ?, ?, ?) ` +
+		`ON DUPLICATE KEY UPDATE query = VALUES(query), updated_at = VALUES(updated_at)`
+	if _, err := s.db.ExecContext(ctx, query, q.UserID, q.ProjectID, q.Query, q.UpdatedAt); err != nil {
+		return fmt.Errorf("mysql project store: save default issues query: %w", err)
+	}
+
+	return nil
+}
+
+// AddDiscardedEvents adds the events dropped by SDKs of the project to the counts of the day.
+func (s *ProjectStore) AddDiscardedEvents(
+	ctx context.Context,
+	projectID int,
+	day time.Time,
+	discarded []warnly.DiscardedEvents,
+) error {
+	if len(discarded) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO discarded_event (project_id, day, reason, category, quantity) VALUES (?, ?, ?, ?, ?)` +
+		strings.Repeat(", (?, ?, ?, ?, ?)", len(discarded)-1) +
+		` ON DUPLICATE KEY UPDATE quantity = quantity + VALUES(quantity)`
+
+	date := day.UTC().Format(time.DateOnly)
+	args := make([]any, 0, len(discarded)*5)
+	for i := range discarded {
+		args = append(args, projectID, date, discarded[i].Reason, discarded[i].Category, discarded[i].Quantity)
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("mysql project store: add discarded events: %w", err)
+	}
+
+	return nil
+}
+
+// ListDiscardedEvents sums the events dropped by SDKs of the project by reason and category since the day,
+// the most dropped first.
+func (s *ProjectStore) ListDiscardedEvents(
+	ctx context.Context,
+	projectID int,
+	since time.Time,
+) ([]warnly.DiscardedEvents, error) {
+	const query = `SELECT reason, category, SUM(quantity) AS quantity FROM discarded_event ` +
+		`WHERE project_id = ? AND day >= ? GROUP BY reason, category ORDER BY quantity DESC, reason, category`
+
+	rows, err := s.db.QueryContext(ctx, query, projectID, since.UTC().Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("mysql project store: list discarded events: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			err = cerr
+		}
+	}()
+
+	discarded := []warnly.DiscardedEvents{}
+	for rows.Next() {
+		var d warnly.DiscardedEvents
+		if err := rows.Scan(&d.Reason, &d.Category, &d.Quantity); err != nil {
+			return nil, fmt.Errorf("mysql project store: scan discarded events: %w", err)
+		}
+		discarded = append(discarded, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql project store: iterate discarded events: %w", err)
+	}
+
+	return discarded, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vk-rv/warnly/internal/mysql"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
@@ -15,7 +16,8 @@ import (
 func TestGetProject(t *testing.T) {
 	t.Parallel()
 
-	const query = `SELECT id, created_at, name, user_id, team_id, platform, project_key FROM project WHERE id = ?`
+	const query = `SELECT id, created_at, name, user_id, team_id, platform, project_key, code_owners, deleted_at, source_url_template
+FROM project WHERE id = \? AND deleted_at IS NULL`
 
 	date := time.Date(2025, 1, 29, 6, 47, 9, 0, time.UTC)
 
@@ -32,18 +34,39 @@ func TestGetProject(t *testing.T) {
 			mockExpect: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(query).
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "user_id", "team_id", "platform", "project_key"}).
-						AddRow(63, date, "go-project", 1, 1, 1, "t3g88uo"))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "user_id", "team_id", "platform", "project_key", "code_owners", "deleted_at", "source_url_template"}).
+						AddRow(63, date, "go-project", 1, 1, 1, "t3g88uo", nil, nil, "https://github.com/org/repo/blob/{ref}/{path}#L{line}"))
 			},
 			expectedError: nil,
 			expectedProject: &warnly.Project{
-				ID:        63,
-				CreatedAt: date,
-				Name:      "go-project",
-				UserID:    1,
-				TeamID:    1,
-				Platform:  1,
-				Key:       "t3g88uo",
+				ID:                63,
+				CreatedAt:         date,
+				Name:              "go-project",
+				UserID:            1,
+				TeamID:            1,
+				Platform:          1,
+				Key:               "t3g88uo",
+				SourceURLTemplate: "https://github.com/org/repo/blob/{ref}/{path}#L{line}",
+			},
+		},
+		{
+			name: "CodeOwners",
+			mockExpect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "user_id", "team_id", "platform", "project_key", "code_owners", "deleted_at", "source_url_template"}).
+						AddRow(63, date, "go-project", 1, 1, 1, "t3g88uo", []byte(`[{"pattern":"internal/billing/","user_id":2}]`), nil, ""))
+			},
+			expectedError: nil,
+			expectedProject: &warnly.Project{
+				ID:         63,
+				CreatedAt:  date,
+				Name:       "go-project",
+				UserID:     1,
+				TeamID:     1,
+				Platform:   1,
+				Key:        "t3g88uo",
+				CodeOwners: []warnly.CodeOwner{{Pattern: "internal/billing/", UserID: 2}},
 			},
 		},
 		{
@@ -51,7 +74,7 @@ func TestGetProject(t *testing.T) {
 			mockExpect: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(query).
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "user_id", "team_id", "platform", "project_key"}))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "user_id", "team_id", "platform", "project_key", "code_owners", "deleted_at", "source_url_template"}))
 			},
 			expectedError:   fmt.Errorf("mysql project store: get project with id 1: %w", warnly.ErrProjectNotFound),
 			expectedProject: nil,
@@ -89,3 +112,56 @@ func TestGetProject(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOptionsKeyRevokedAt(t *testing.T) {
+	t.Parallel()
+
+	const query = `SELECT p.id, p.name, p.team_id, p.platform, p.sample_rate, p.grouping_strategy, p.priority_rules,
+p.grouping_rules, COALESCE\(p.ingest_secret, ''\), p.dedup_window_seconds, p.retention_days, p.ingest_filters,
+k.revoked_at
+FROM project AS p INNER JOIN project_key AS k ON k.project_id = p.id
+WHERE p.id = \? AND k.project_key = \? AND p.deleted_at IS NULL`
+
+	columns := []string{
+		"id", "name", "team_id", "platform", "sample_rate", "grouping_strategy", "priority_rules",
+		"grouping_rules", "ingest_secret", "dedup_window_seconds", "retention_days", "ingest_filters",
+		"revoked_at",
+	}
+	revokedAt := time.Date(2025, 1, 29, 6, 47, 9, 0, time.UTC)
+
+	tests := []struct {
+		revokedAt any
+		expected  *time.Time
+		name      string
+	}{
+		{name: "Active", revokedAt: nil, expected: nil},
+		{name: "Revoked", revokedAt: revokedAt, expected: &revokedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			defer db.Close()
+			mock.ExpectQuery(query).
+				WithArgs(63, "t3g88uo").
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(63, "go-project", 1, 1, 1.0, "message", nil, nil, "", 0, 30,
+						[]byte(`{"deny_environments":["local"]}`), tt.revokedAt))
+
+			store := mysql.NewProjectStore(db)
+
+			opts, err := store.GetOptions(t.Context(), 63, "t3g88uo")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, opts.KeyRevokedAt)
+			assert.Equal(t, uint8(30), opts.RetentionDays)
+			assert.Equal(t, &warnly.IngestFilters{DenyEnvironments: []string{"local"}}, opts.IngestFilters)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// SeenStore implements warnly.SeenStore for MySQL.
+type SeenStore struct {
+	db ExtendedDB
+}
+
+// NewSeenStore is a constructor of SeenStore repository.
+func NewSeenStore(db ExtendedDB) *SeenStore {
+	return &SeenStore{db: db}
+}
+
+// MarkSeen records that the user looked at the issue, viewing it again updates the time of the view.
+func (s *SeenStore) MarkSeen(ctx context.Context, view *warnly.IssueView) error {
+	const query = `INSERT INTO issue_seen (issue_id, user_id, seen_at) VALUES (?, ?, ?)
+				   ON DUPLICATE KEY UPDATE seen_at = VALUES(seen_at)`
+
+	if _, err := s.db.ExecContext(ctx, query, view.IssueID, view.UserID, view.SeenAt); err != nil {
+		return fmt.Errorf("mysql seen store: mark seen: %w", err)
+	}
+
+	return nil
+}
+
+// ListSeenBy returns the views of the issue, the most recent first.
+func (s *SeenStore) ListSeenBy(ctx context.Context, issueID int64) (views []warnly.IssueView, err error) {
+	const query = `SELECT issue_id, user_id, seen_at FROM issue_seen WHERE issue_id = ? ORDER BY seen_at DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql seen store: list seen by: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql seen store: list seen by, close rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var v warnly.IssueView
+		if err := rows.Scan(&v.IssueID, &v.UserID, &v.SeenAt); err != nil {
+			return nil, fmt.Errorf("mysql seen store: scan view: %w", err)
+		}
+		views = append(views, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql seen store: list seen by rows: %w", err)
+	}
+
+	return views, nil
+}
@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// SubscriptionStore implements warnly.SubscriptionStore for MySQL.
+type SubscriptionStore struct {
+	db ExtendedDB
+}
+
+// NewSubscriptionStore is a constructor of SubscriptionStore repository.
+func NewSubscriptionStore(db ExtendedDB) *SubscriptionStore {
+	return &SubscriptionStore{db: db}
+}
+
+// CreateSubscription subscribes the user to the issue, subscribing again keeps the existing subscription.
+func (s *SubscriptionStore) CreateSubscription(ctx context.Context, sub *warnly.Subscription) error {
+	const query = `INSERT INTO issue_subscription (issue_id, user_id, created_at) VALUES (?, ?, ?)
+				   ON DUPLICATE KEY UPDATE issue_id = issue_id`
+
+	if _, err := s.db.ExecContext(ctx, query, sub.IssueID, sub.UserID, sub.CreatedAt); err != nil {
+		return fmt.Errorf("mysql subscription store: create subscription: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSubscription unsubscribes the user from the issue.
+func (s *SubscriptionStore) DeleteSubscription(ctx context.Context, issueID, userID int64) error {
+	const query = `DELETE FROM issue_subscription WHERE issue_id = ? AND user_id = ?`
+
+	if _, err := s.db.ExecContext(ctx, query, issueID, userID); err != nil {
+		return fmt.Errorf("mysql subscription store: delete subscription: %w", err)
+	}
+
+	return nil
+}
+
+// ListSubscribers returns identifiers of the users subscribed to the issue.
+func (s *SubscriptionStore) ListSubscribers(ctx context.Context, issueID int64) (userIDs []int64, err error) {
+	const query = `SELECT user_id FROM issue_subscription WHERE issue_id = ? ORDER BY created_at, user_id`
+
+	rows, err := s.db.QueryContext(ctx, query, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("mysql subscription store: list subscribers: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("mysql subscription store: list subscribers, close rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("mysql subscription store: scan subscriber: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("mysql subscription store: list subscribers rows: %w", err)
+	}
+
+	return userIDs, nil
+}
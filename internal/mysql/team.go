@@ -51,7 +51,7 @@ func (s *TeamStore) ListTeammates(ctx context.Context, teamIDs []int) ([]warnly.
 		args[i] = id
 	}
 
-	query := fmt.Sprintf(`SELECT u.id, u.name, u.surname, u.email, u.username 
+	query := fmt.Sprintf(`SELECT u.id, u.name, u.surname, u.email, u.username, tr.role, tr.active 
 		FROM team_relation AS tr JOIN user AS u ON tr.user_id = u.id 
 		WHERE tr.team_id IN (%s)`, placeholders.String())
 
@@ -70,7 +70,9 @@ func (s *TeamStore) ListTeammates(ctx context.Context, teamIDs []int) ([]warnly.
 
 // ListTeams returns a list of teams by user unique identifier.
 func (s *TeamStore) ListTeams(ctx context.Context, userID int) ([]warnly.Team, error) {
-	const query = `SELECT t.id, t.created_at, t.name, t.owner_id FROM team_relation AS tr JOIN team AS t WHERE tr.user_id = ?`
+	const query = `SELECT t.id, t.created_at, t.name, t.owner_id, tr.role 
+		FROM team_relation AS tr JOIN team AS t ON tr.team_id = t.id 
+		WHERE tr.user_id = ?`
 
 	rows, err := s.db.QueryContext(ctx, query, userID)
 	if err != nil {
@@ -85,7 +87,7 @@ func (s *TeamStore) ListTeams(ctx context.Context, userID int) ([]warnly.Team, e
 	var teams []warnly.Team
 	for rows.Next() {
 		var t warnly.Team
-		if err := rows.Scan(&t.ID, &t.CreatedAt, &t.Name, &t.OwnerID); err != nil {
+		if err := rows.Scan(&t.ID, &t.CreatedAt, &t.Name, &t.OwnerID, &t.Role); err != nil {
 			return nil, fmt.Errorf("mysql team store: list teams scan: %w", err)
 		}
 		teams = append(teams, t)
@@ -98,10 +100,10 @@ func (s *TeamStore) ListTeams(ctx context.Context, userID int) ([]warnly.Team, e
 	return teams, nil
 }
 
-// AddUserToTeam adds a user to a team.
-func (s *TeamStore) AddUserToTeam(ctx context.Context, createdAt time.Time, userID int64, teamID int) error {
-	const query = `INSERT INTO team_relation (created_at, team_id, user_id) VALUES (?, ?, ?)`
-	_, err := s.db.ExecContext(ctx, query, createdAt, teamID, userID)
+// AddUserToTeam adds a user to a team with the given role.
+func (s *TeamStore) AddUserToTeam(ctx context.Context, createdAt time.Time, userID int64, teamID int, role warnly.Role) error {
+	const query = `INSERT INTO team_relation (created_at, team_id, user_id, role) VALUES (?, ?, ?, ?)`
+	_, err := s.db.ExecContext(ctx, query, createdAt, teamID, userID, role)
 	if err != nil {
 		return fmt.Errorf("mysql team store: add user to team: %w", err)
 	}
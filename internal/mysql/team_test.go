@@ -24,11 +24,11 @@ func TestListTeams(t *testing.T) {
 
 		date := time.Date(2025, 1, 29, 6, 47, 9, 0, time.UTC)
 
-		rows := sqlmock.NewRows([]string{"id", "created_at", "name", "owner_id"}).
-			AddRow(1, date, "Team A", 1).
-			AddRow(2, date, "Team B", 2)
+		rows := sqlmock.NewRows([]string{"id", "created_at", "name", "owner_id", "role"}).
+			AddRow(1, date, "Team A", 1, "admin").
+			AddRow(2, date, "Team B", 2, "viewer")
 
-		mock.ExpectQuery(`SELECT t.id, t.created_at, t.name, t.owner_id FROM team_relation AS tr JOIN team AS t WHERE tr.user_id = ?`).
+		mock.ExpectQuery(`SELECT t.id, t.created_at, t.name, t.owner_id, tr.role FROM team_relation AS tr JOIN team AS t ON tr.team_id = t.id WHERE tr.user_id = ?`).
 			WithArgs(1).
 			WillReturnRows(rows)
 
@@ -37,8 +37,8 @@ func TestListTeams(t *testing.T) {
 		require.Len(t, teams, 2)
 
 		expectedTeams := []warnly.Team{
-			{ID: 1, CreatedAt: date, Name: "Team A", OwnerID: 1},
-			{ID: 2, CreatedAt: date, Name: "Team B", OwnerID: 2},
+			{ID: 1, CreatedAt: date, Name: "Team A", OwnerID: 1, Role: warnly.RoleAdmin},
+			{ID: 2, CreatedAt: date, Name: "Team B", OwnerID: 2, Role: warnly.RoleViewer},
 		}
 
 		require.ElementsMatch(t, expectedTeams, teams)
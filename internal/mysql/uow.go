@@ -13,13 +13,18 @@ import (
 
 // unitOfWork implements uow.UnitOfWork interface.
 type unitOfWork struct {
-	messageStore    *MessageStore
-	mentionStore    *MentionStore
-	assingmentStore *AssingmentStore
-	userStore       *UserStore
-	teamStore       *TeamStore
-	tx              *sql.Tx
-	t               uow.Type
+	messageStore      *MessageStore
+	mentionStore      *MentionStore
+	assingmentStore   *AssingmentStore
+	userStore         *UserStore
+	teamStore         *TeamStore
+	issueStore        *IssueStore
+	activityStore     *ActivityStore
+	invitationStore   *InvitationStore
+	projectStore      *ProjectStore
+	subscriptionStore *SubscriptionStore
+	tx                *sql.Tx
+	t                 uow.Type
 }
 
 // key is an unexported type for keys defined in this package.
@@ -97,6 +102,21 @@ func (uw *unitOfWork) Users() warnly.UserStore { return uw.userStore }
 //nolint:ireturn // temporary
 func (uw *unitOfWork) Teams() warnly.TeamStore { return uw.teamStore }
 
+//nolint:ireturn // temporary
+func (uw *unitOfWork) Issues() warnly.IssueStore { return uw.issueStore }
+
+//nolint:ireturn // temporary
+func (uw *unitOfWork) Activities() warnly.ActivityStore { return uw.activityStore }
+
+//nolint:ireturn // temporary
+func (uw *unitOfWork) Invitations() warnly.InvitationStore { return uw.invitationStore }
+
+//nolint:ireturn // temporary
+func (uw *unitOfWork) Projects() warnly.ProjectStore { return uw.projectStore }
+
+//nolint:ireturn // temporary
+func (uw *unitOfWork) Subscriptions() warnly.SubscriptionStore { return uw.subscriptionStore }
+
 // add adds repository to the unitOfWork
 // by setting its db field to the current transaction.
 func (uw *unitOfWork) add(r any) error {
@@ -136,6 +156,41 @@ func (uw *unitOfWork) add(r any) error {
 			uw.teamStore = &r
 		}
 		return nil
+	case *IssueStore:
+		if uw.issueStore == nil {
+			r := *rep
+			r.db = uw.tx
+			uw.issueStore = &r
+		}
+		return nil
+	case *ActivityStore:
+		if uw.activityStore == nil {
+			r := *rep
+			r.db = uw.tx
+			uw.activityStore = &r
+		}
+		return nil
+	case *InvitationStore:
+		if uw.invitationStore == nil {
+			r := *rep
+			r.db = uw.tx
+			uw.invitationStore = &r
+		}
+		return nil
+	case *ProjectStore:
+		if uw.projectStore == nil {
+			r := *rep
+			r.db = uw.tx
+			uw.projectStore = &r
+		}
+		return nil
+	case *SubscriptionStore:
+		if uw.subscriptionStore == nil {
+			r := *rep
+			r.db = uw.tx
+			uw.subscriptionStore = &r
+		}
+		return nil
 	default:
 		return fmt.Errorf("invalid repository of type: %T", rep)
 	}
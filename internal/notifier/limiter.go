@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+)
+
+// destinationLimiter caps the number of notifications delivered concurrently
+// to a single destination. Sends beyond the cap wait in line until a slot frees up
+// or the context is done.
+type destinationLimiter struct {
+	slots map[string]*destinationSlots
+	mu    sync.Mutex
+	limit int
+}
+
+// destinationSlots is a semaphore for one destination, refs counts holders and waiters
+// so that the entry can be dropped once the destination is idle.
+type destinationSlots struct {
+	sem  chan struct{}
+	refs int
+}
+
+// newDestinationLimiter creates a limiter, a non-positive limit disables it.
+func newDestinationLimiter(limit int) *destinationLimiter {
+	return &destinationLimiter{
+		slots: make(map[string]*destinationSlots),
+		limit: limit,
+	}
+}
+
+// acquire waits for a free slot for the destination.
+// The returned function must be called to release the slot.
+func (l *destinationLimiter) acquire(ctx context.Context, destination string) (func(), error) {
+	if l.limit <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	slots, ok := l.slots[destination]
+	if !ok {
+		slots = &destinationSlots{sem: make(chan struct{}, l.limit)}
+		l.slots[destination] = slots
+	}
+	slots.refs++
+	l.mu.Unlock()
+
+	select {
+	case slots.sem <- struct{}{}:
+		return func() {
+			<-slots.sem
+			l.unref(destination, slots)
+		}, nil
+	case <-ctx.Done():
+		l.unref(destination, slots)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *destinationLimiter) unref(destination string, slots *destinationSlots) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots.refs--
+	if slots.refs == 0 {
+		delete(l.slots, destination)
+	}
+}
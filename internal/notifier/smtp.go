@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// SMTPConfig is the mail server invitations are delivered through.
+type SMTPConfig struct {
+	// Addr is the host:port of the mail server.
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// SMTPMailer sends invitations by email.
+type SMTPMailer struct {
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	cfg      SMTPConfig
+}
+
+// NewSMTPMailer creates a new SMTPMailer.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg, sendMail: smtp.SendMail}
+}
+
+// SendInvitation emails the link accepting the invitation.
+func (m *SMTPMailer) SendInvitation(ctx context.Context, mail *warnly.InvitationMail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(m.cfg.Addr)
+		if err != nil {
+			return fmt.Errorf("smtp mailer: parse address: %w", err)
+		}
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, host)
+	}
+
+	msg := invitationMessage(m.cfg.From, mail)
+	if err := m.sendMail(m.cfg.Addr, auth, m.cfg.From, []string{mail.Email}, msg); err != nil {
+		return fmt.Errorf("smtp mailer: send invitation: %w", err)
+	}
+
+	return nil
+}
+
+// invitationMessage formats the invitation as a plain text email.
+func invitationMessage(from string, mail *warnly.InvitationMail) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", mail.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "You are invited to join "+mail.TeamName+" on Warnly"))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s invited you to join the %s team on Warnly.\r\n\r\n", mail.Inviter, mail.TeamName)
+	fmt.Fprintf(&b, "Accept the invitation: %s\r\n\r\n", mail.Link)
+	fmt.Fprintf(&b, "The link expires on %s.\r\n", mail.ExpiresAt.UTC().Format(time.RFC1123))
+	return b.Bytes()
+}
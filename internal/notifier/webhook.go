@@ -17,18 +17,55 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/vk-rv/warnly/internal/warnly"
 )
 
+// SignatureHeader is the header carrying the timestamped HMAC-SHA256 signature of the body,
+// formatted as "t=<unix seconds>,v1=<hex signature>". The signature covers "<unix seconds>.<body>"
+// so that receivers can reject replayed requests by checking the timestamp.
+const SignatureHeader = "X-Warnly-Signature"
+
+// legacySignatureHeader carries the HMAC-SHA256 of the body alone, kept for existing receivers.
+const legacySignatureHeader = "X-Webhook-Signature"
+
+// RetryPolicy controls how failed webhook deliveries are retried.
+// The delay before attempt n+1 is BaseDelay * 2^(n-1), capped at MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when nothing is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// backoff returns the delay after the given failed attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
 // WebhookNotifier sends notifications via HTTP webhooks.
 type WebhookNotifier struct {
 	store         warnly.NotificationStore
 	now           func() time.Time
 	logger        *slog.Logger
 	httpClient    *http.Client
+	limiter       *destinationLimiter
 	encryptionKey []byte
+	retry         RetryPolicy
 }
 
 // NewWebhookNotifier creates a new WebhookNotifier.
@@ -36,18 +73,30 @@ func NewWebhookNotifier(
 	store warnly.NotificationStore,
 	encryptionKey []byte,
 	httpClient *http.Client,
+	retry RetryPolicy,
 	now func() time.Time,
 	logger *slog.Logger,
 ) *WebhookNotifier {
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
 	return &WebhookNotifier{
 		store:         store,
 		logger:        logger,
 		now:           now,
 		encryptionKey: deriveKey(encryptionKey),
 		httpClient:    httpClient,
+		limiter:       newDestinationLimiter(0),
+		retry:         retry,
 	}
 }
 
+// LimitConcurrency caps simultaneous deliveries to the same webhook URL, zero means no limit.
+// A delivery holds a slot only while its request is in flight, not during the backoff before a retry.
+func (wn *WebhookNotifier) LimitConcurrency(limit int) {
+	wn.limiter = newDestinationLimiter(limit)
+}
+
 // deriveKey derives a 32-byte key from the input using SHA-256.
 func deriveKey(key []byte) []byte {
 	hash := sha256.Sum256(key)
@@ -117,43 +166,211 @@ func (wn *WebhookNotifier) DecryptSecret(encryptedSecret string) (string, error)
 
 // AlertPayload represents the webhook payload for alert notifications.
 type AlertPayload struct {
-	Timestamp    time.Time `json:"timestamp"`
-	AlertName    string    `json:"alert_name"`
-	Status       string    `json:"status"`
-	Condition    string    `json:"condition"`
-	Timeframe    string    `json:"timeframe"`
-	AlertID      int       `json:"alert_id"`
-	ProjectID    int       `json:"project_id"`
-	TeamID       int       `json:"team_id"`
-	Threshold    int       `json:"threshold"`
-	HighPriority bool      `json:"high_priority"`
-}
-
-// SendWebhook sends a webhook notification.
-func (wn *WebhookNotifier) SendWebhook(ctx context.Context, config *warnly.WebhookConfig, payload *AlertPayload) error {
+	Timestamp time.Time `json:"timestamp"`
+	AlertName string    `json:"alert_name"`
+	Status    string    `json:"status"`
+	Condition string    `json:"condition"`
+	Timeframe string    `json:"timeframe"`
+	// AckToken acknowledges the trigger of an escalating alert and stops the escalation.
+	AckToken  string `json:"ack_token,omitempty"`
+	AlertID   int    `json:"alert_id"`
+	ProjectID int    `json:"project_id"`
+	TeamID    int    `json:"team_id"`
+	Threshold int    `json:"threshold"`
+	// EscalationLevel is the position of the notified channel in the escalation chain, starting at 1.
+	EscalationLevel int  `json:"escalation_level,omitempty"`
+	HighPriority    bool `json:"high_priority"`
+}
+
+// IssueResolvedPayload represents the webhook payload for resolved issue notifications.
+type IssueResolvedPayload struct {
+	Timestamp   time.Time          `json:"timestamp"`
+	Status      string             `json:"status"`
+	ProjectName string             `json:"project_name"`
+	Message     string             `json:"message"`
+	Note        string             `json:"note,omitempty"`
+	ResolvedBy  string             `json:"resolved_by"`
+	Recipients  []PayloadRecipient `json:"recipients"`
+	IssueID     int64              `json:"issue_id"`
+	ProjectID   int                `json:"project_id"`
+	TeamID      int                `json:"team_id"`
+}
+
+// IssueRegressedPayload represents the webhook payload for notifications of resolved issues
+// that recurred in a newer release.
+type IssueRegressedPayload struct {
+	Timestamp         time.Time          `json:"timestamp"`
+	Status            string             `json:"status"`
+	ProjectName       string             `json:"project_name"`
+	Message           string             `json:"message"`
+	ResolvedInRelease string             `json:"resolved_in_release"`
+	Release           string             `json:"release"`
+	Recipients        []PayloadRecipient `json:"recipients"`
+	IssueID           int64              `json:"issue_id"`
+	ProjectID         int                `json:"project_id"`
+	TeamID            int                `json:"team_id"`
+}
+
+// IssueReappearedPayload represents the webhook payload for notifications of ignored issues
+// reopened once the condition they were ignored until was met.
+type IssueReappearedPayload struct {
+	Timestamp       time.Time          `json:"timestamp"`
+	Status          string             `json:"status"`
+	ProjectName     string             `json:"project_name"`
+	Message         string             `json:"message"`
+	IgnoreCondition string             `json:"ignore_condition"`
+	Recipients      []PayloadRecipient `json:"recipients"`
+	IssueID         int64              `json:"issue_id"`
+	ProjectID       int                `json:"project_id"`
+	TeamID          int                `json:"team_id"`
+}
+
+// IssueIgnoredPayload represents the webhook payload for ignored issue notifications.
+type IssueIgnoredPayload struct {
+	Timestamp       time.Time          `json:"timestamp"`
+	Until           *time.Time         `json:"until,omitempty"`
+	Status          string             `json:"status"`
+	ProjectName     string             `json:"project_name"`
+	Message         string             `json:"message"`
+	IgnoreCondition string             `json:"ignore_condition"`
+	IgnoredBy       string             `json:"ignored_by"`
+	Recipients      []PayloadRecipient `json:"recipients"`
+	IssueID         int64              `json:"issue_id"`
+	ProjectID       int                `json:"project_id"`
+	TeamID          int                `json:"team_id"`
+	Threshold       int                `json:"threshold,omitempty"`
+}
+
+// IssueCommentedPayload represents the webhook payload for notifications of a new message
+// in the discussion of an issue, addressed to the subscribers of the issue.
+type IssueCommentedPayload struct {
+	Timestamp   time.Time          `json:"timestamp"`
+	Status      string             `json:"status"`
+	ProjectName string             `json:"project_name"`
+	Message     string             `json:"message"`
+	Content     string             `json:"content"`
+	Author      string             `json:"author"`
+	Recipients  []PayloadRecipient `json:"recipients"`
+	IssueID     int64              `json:"issue_id"`
+	ProjectID   int                `json:"project_id"`
+	TeamID      int                `json:"team_id"`
+}
+
+// PayloadRecipient is a user that should be notified about the payload.
+type PayloadRecipient struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	ID       int64  `json:"id"`
+}
+
+// SendWebhook sends a webhook notification. Network errors and 5xx responses are retried
+// with exponential backoff, deliveries that still fail are stored as dead letters.
+func (wn *WebhookNotifier) SendWebhook(ctx context.Context, config *warnly.WebhookConfig, payload any) error {
+	return wn.send(ctx, config, payload, true)
+}
+
+// SendTest sends a test notification to check a webhook configuration of the team.
+// The failure is returned to the user who configured the webhook, so it is not stored as a dead letter.
+func (wn *WebhookNotifier) SendTest(ctx context.Context, config *warnly.WebhookConfig, teamID int) error {
+	return wn.send(ctx, config, &AlertPayload{
+		AlertName: "Test Alert",
+		TeamID:    teamID,
+		Status:    "test",
+		Threshold: 100,
+		Condition: "occurrences",
+		Timeframe: "1h",
+		Timestamp: wn.now().UTC(),
+	}, false)
+}
+
+// send delivers the payload with retries, with deadLetter a delivery that still fails is stored.
+func (wn *WebhookNotifier) send(ctx context.Context, config *warnly.WebhookConfig, payload any, deadLetter bool) error {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("webhook notifier: marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("webhook notifier: create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
+	var secret []byte
 	if config.SecretEncrypted != "" {
-		secret, err := wn.DecryptSecret(config.SecretEncrypted)
+		decrypted, err := wn.DecryptSecret(config.SecretEncrypted)
 		if err != nil {
 			return fmt.Errorf("webhook notifier: decrypt secret: %w", err)
 		}
-		signature := computeHMAC(jsonData, []byte(secret))
-		req.Header.Set("X-Webhook-Signature", signature)
+		secret = []byte(decrypted)
+	}
+
+	attempt := 1
+	var deliveryErr error
+	for {
+		retryable, err := wn.deliverWithSlot(ctx, config.URL, jsonData, secret)
+		if err == nil {
+			return nil
+		}
+		deliveryErr = err
+		if !retryable || attempt >= wn.retry.MaxAttempts {
+			break
+		}
+
+		wn.logger.WarnContext(ctx, "webhook notifier: delivery failed, retrying",
+			slog.Int("attempt", attempt),
+			slog.Int("channel_id", config.ChannelID),
+			slog.Any("error", err))
+
+		if err := sleep(ctx, wn.retry.backoff(attempt)); err != nil {
+			deliveryErr = errors.Join(deliveryErr, err)
+			break
+		}
+		attempt++
+	}
+
+	if deadLetter {
+		wn.storeDeadLetter(ctx, config, jsonData, attempt, deliveryErr)
+	}
+
+	return fmt.Errorf("webhook notifier: delivery failed after %d attempt(s): %w", attempt, deliveryErr)
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// deliverWithSlot waits for a free slot of the destination and makes a single delivery attempt,
+// the slot is released before the caller backs off.
+func (wn *WebhookNotifier) deliverWithSlot(ctx context.Context, url string, body, secret []byte) (bool, error) {
+	release, err := wn.limiter.acquire(ctx, url)
+	if err != nil {
+		return false, fmt.Errorf("webhook notifier: wait for destination: %w", err)
+	}
+	defer release()
+
+	return wn.deliver(ctx, url, body, secret)
+}
+
+// deliver makes a single delivery attempt and reports whether a failure is worth retrying.
+func (wn *WebhookNotifier) deliver(ctx context.Context, url string, body, secret []byte) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("webhook notifier: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if secret != nil {
+		req.Header.Set(legacySignatureHeader, computeHMAC(body, secret))
+		req.Header.Set(SignatureHeader, signPayload(body, secret, wn.now()))
 	}
 
 	resp, err := wn.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("send request: %w", err)
+		return ctx.Err() == nil, fmt.Errorf("send request: %w", err)
 	}
 	defer func() {
 		if cerr := resp.Body.Close(); err == nil && cerr != nil {
@@ -162,14 +379,43 @@ func (wn *WebhookNotifier) SendWebhook(ctx context.Context, config *warnly.Webho
 	}()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, err := io.ReadAll(resp.Body)
+		respBody, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("webhook notifier: read response body: %w", err)
+			return true, fmt.Errorf("webhook notifier: read response body: %w", err)
 		}
-		return fmt.Errorf("webhook notifier: webhook returned non-2xx status: %d, body: %s", resp.StatusCode, string(body))
+		return resp.StatusCode >= http.StatusInternalServerError,
+			fmt.Errorf("webhook notifier: webhook returned non-2xx status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return false, nil
+}
+
+// storeDeadLetter persists a failed delivery. Failing to do so is only logged,
+// the delivery error is what the caller needs to know about.
+func (wn *WebhookNotifier) storeDeadLetter(
+	ctx context.Context,
+	config *warnly.WebhookConfig,
+	body []byte,
+	attempts int,
+	deliveryErr error,
+) {
+	lastError := ""
+	if deliveryErr != nil {
+		lastError = deliveryErr.Error()
 	}
 
-	return nil
+	if err := wn.store.CreateWebhookDeadLetter(context.WithoutCancel(ctx), &warnly.WebhookDeadLetter{
+		CreatedAt: wn.now().UTC(),
+		ChannelID: config.ChannelID,
+		URL:       config.URL,
+		Payload:   string(body),
+		Attempts:  attempts,
+		LastError: lastError,
+	}); err != nil {
+		wn.logger.ErrorContext(ctx, "webhook notifier: store dead letter",
+			slog.Int("channel_id", config.ChannelID),
+			slog.Any("error", err))
+	}
 }
 
 // SendAlertTriggered sends an alert triggered notification.
@@ -183,6 +429,7 @@ func (wn *WebhookNotifier) SendAlertTriggered(ctx context.Context, alert *warnly
 		Threshold:    alert.Threshold,
 		Condition:    getConditionName(alert.Condition),
 		Timeframe:    getTimeframeName(alert.Timeframe),
+		AckToken:     alert.AckToken,
 		HighPriority: alert.HighPriority,
 		Timestamp:    wn.now().UTC(),
 	}
@@ -190,6 +437,27 @@ func (wn *WebhookNotifier) SendAlertTriggered(ctx context.Context, alert *warnly
 	return wn.SendWebhook(ctx, config, payload)
 }
 
+// SendAlertEscalated sends a notification of a triggered alert nobody acknowledged
+// to the next channel in its escalation chain.
+func (wn *WebhookNotifier) SendAlertEscalated(ctx context.Context, alert *warnly.Alert, config *warnly.WebhookConfig) error {
+	payload := &AlertPayload{
+		AlertID:         alert.ID,
+		AlertName:       alert.RuleName,
+		ProjectID:       alert.ProjectID,
+		TeamID:          alert.TeamID,
+		Status:          "escalated",
+		Threshold:       alert.Threshold,
+		Condition:       getConditionName(alert.Condition),
+		Timeframe:       getTimeframeName(alert.Timeframe),
+		AckToken:        alert.AckToken,
+		EscalationLevel: alert.EscalationLevel,
+		HighPriority:    alert.HighPriority,
+		Timestamp:       wn.now().UTC(),
+	}
+
+	return wn.SendWebhook(ctx, config, payload)
+}
+
 // SendAlertResolved sends an alert resolved notification.
 func (wn *WebhookNotifier) SendAlertResolved(ctx context.Context, alert *warnly.Alert, config *warnly.WebhookConfig) error {
 	payload := &AlertPayload{
@@ -208,12 +476,142 @@ func (wn *WebhookNotifier) SendAlertResolved(ctx context.Context, alert *warnly.
 	return wn.SendWebhook(ctx, config, payload)
 }
 
+// SendIssueResolved sends an issue resolved notification addressed to the recipients.
+func (wn *WebhookNotifier) SendIssueResolved(
+	ctx context.Context,
+	n *warnly.IssueResolvedNotification,
+	config *warnly.WebhookConfig,
+) error {
+	payload := &IssueResolvedPayload{
+		IssueID:     n.IssueID,
+		ProjectID:   n.ProjectID,
+		ProjectName: n.ProjectName,
+		TeamID:      n.TeamID,
+		Status:      string(warnly.IssueStatusResolved),
+		Message:     n.Message,
+		Note:        n.Note,
+		ResolvedBy:  n.ResolvedBy.Username,
+		Recipients:  payloadRecipients(n.Recipients),
+		Timestamp:   n.ResolvedAt.UTC(),
+	}
+
+	return wn.SendWebhook(ctx, config, payload)
+}
+
+// SendIssueRegressed sends a notification of a resolved issue that recurred in a newer release.
+func (wn *WebhookNotifier) SendIssueRegressed(
+	ctx context.Context,
+	n *warnly.IssueRegressedNotification,
+	config *warnly.WebhookConfig,
+) error {
+	payload := &IssueRegressedPayload{
+		IssueID:           n.IssueID,
+		ProjectID:         n.ProjectID,
+		ProjectName:       n.ProjectName,
+		TeamID:            n.TeamID,
+		Status:            string(warnly.IssueStatusUnresolved),
+		Message:           n.Message,
+		ResolvedInRelease: n.ResolvedInRelease,
+		Release:           n.Release,
+		Recipients:        payloadRecipients(n.Recipients),
+		Timestamp:         n.RegressedAt.UTC(),
+	}
+
+	return wn.SendWebhook(ctx, config, payload)
+}
+
+// SendIssueReappeared sends a notification of an ignored issue reopened by its ignore condition.
+func (wn *WebhookNotifier) SendIssueReappeared(
+	ctx context.Context,
+	n *warnly.IssueReappearedNotification,
+	config *warnly.WebhookConfig,
+) error {
+	payload := &IssueReappearedPayload{
+		IssueID:         n.IssueID,
+		ProjectID:       n.ProjectID,
+		ProjectName:     n.ProjectName,
+		TeamID:          n.TeamID,
+		Status:          string(warnly.IssueStatusUnresolved),
+		Message:         n.Message,
+		IgnoreCondition: string(n.Condition),
+		Recipients:      payloadRecipients(n.Recipients),
+		Timestamp:       n.ReappearedAt.UTC(),
+	}
+
+	return wn.SendWebhook(ctx, config, payload)
+}
+
+// SendIssueIgnored sends an issue ignored notification addressed to the recipients.
+func (wn *WebhookNotifier) SendIssueIgnored(
+	ctx context.Context,
+	n *warnly.IssueIgnoredNotification,
+	config *warnly.WebhookConfig,
+) error {
+	payload := &IssueIgnoredPayload{
+		IssueID:         n.IssueID,
+		ProjectID:       n.ProjectID,
+		ProjectName:     n.ProjectName,
+		TeamID:          n.TeamID,
+		Status:          string(warnly.IssueStatusIgnored),
+		Message:         n.Message,
+		IgnoreCondition: string(n.Rule.Condition),
+		Threshold:       n.Rule.Threshold,
+		Until:           n.Rule.Until,
+		IgnoredBy:       n.IgnoredBy.Username,
+		Recipients:      payloadRecipients(n.Recipients),
+		Timestamp:       n.Rule.IgnoredAt.UTC(),
+	}
+
+	return wn.SendWebhook(ctx, config, payload)
+}
+
+// SendIssueCommented sends a notification of a new message in the issue discussion addressed to the recipients.
+func (wn *WebhookNotifier) SendIssueCommented(
+	ctx context.Context,
+	n *warnly.IssueCommentedNotification,
+	config *warnly.WebhookConfig,
+) error {
+	payload := &IssueCommentedPayload{
+		IssueID:     n.IssueID,
+		ProjectID:   n.ProjectID,
+		ProjectName: n.ProjectName,
+		TeamID:      n.TeamID,
+		Status:      "commented",
+		Message:     n.Message,
+		Content:     n.Content,
+		Author:      n.Author.Username,
+		Recipients:  payloadRecipients(n.Recipients),
+		Timestamp:   n.CreatedAt.UTC(),
+	}
+
+	return wn.SendWebhook(ctx, config, payload)
+}
+
+// payloadRecipients converts the teammates to notify into recipients of a payload.
+func payloadRecipients(teammates []warnly.Teammate) []PayloadRecipient {
+	recipients := make([]PayloadRecipient, 0, len(teammates))
+	for i := range teammates {
+		recipients = append(recipients, PayloadRecipient{
+			ID:       teammates[i].ID,
+			Username: teammates[i].Username,
+			Email:    teammates[i].Email,
+		})
+	}
+	return recipients
+}
+
 func getConditionName(condition warnly.AlertCondition) string {
 	switch condition {
 	case warnly.AlertConditionOccurrences:
 		return "occurrences"
 	case warnly.AlertConditionUsers:
 		return "users_affected"
+	case warnly.AlertConditionNewIssue:
+		return "new_issue"
+	case warnly.AlertConditionReoccurred:
+		return "reoccurred"
+	case warnly.AlertConditionSpike:
+		return "spike"
 	default:
 		return "unknown"
 	}
@@ -240,6 +638,16 @@ func getTimeframeName(timeframe warnly.AlertTimeframe) string {
 	}
 }
 
+// signPayload returns the SignatureHeader value for the body sent at the given time.
+func signPayload(body, secret []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	message := make([]byte, 0, len(ts)+1+len(body))
+	message = append(message, ts...)
+	message = append(message, '.')
+	message = append(message, body...)
+	return "t=" + ts + ",v1=" + computeHMAC(message, secret)
+}
+
 // computeHMAC computes HMAC-SHA256 signature.
 func computeHMAC(message, key []byte) string {
 	h := hmac.New(sha256.New, key)
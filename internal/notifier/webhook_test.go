@@ -0,0 +1,260 @@
+package notifier_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/notifier"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+type capturedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newCapturingServer(t *testing.T) (*httptest.Server, <-chan capturedRequest) {
+	t.Helper()
+
+	captured := make(chan capturedRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		captured <- capturedRequest{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, captured
+}
+
+func sign(message, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestSendWebhookSignsBodyWithTimestamp(t *testing.T) {
+	t.Parallel()
+
+	const secret = "s3cr3t"
+
+	sentAt := time.Date(2025, 11, 2, 10, 0, 0, 0, time.UTC)
+	wn := notifier.NewWebhookNotifier(nil, []byte("encryption-key"), http.DefaultClient, notifier.RetryPolicy{},
+		func() time.Time { return sentAt }, slog.Default())
+
+	encrypted, err := wn.EncryptSecret(secret)
+	require.NoError(t, err)
+
+	srv, captured := newCapturingServer(t)
+
+	err = wn.SendAlertTriggered(t.Context(), &warnly.Alert{ID: 42, RuleName: "High Error Rate"}, &warnly.WebhookConfig{
+		URL:             srv.URL,
+		SecretEncrypted: encrypted,
+	})
+	require.NoError(t, err)
+
+	req := <-captured
+
+	header := req.header.Get(notifier.SignatureHeader)
+	require.NotEmpty(t, header)
+
+	parts := strings.Split(header, ",")
+	require.Len(t, parts, 2)
+	ts, ok := strings.CutPrefix(parts[0], "t=")
+	require.True(t, ok)
+	signature, ok := strings.CutPrefix(parts[1], "v1=")
+	require.True(t, ok)
+
+	assert.Equal(t, strconv.FormatInt(sentAt.Unix(), 10), ts)
+	assert.Equal(t, sign(ts+"."+string(req.body), secret), signature)
+	assert.Equal(t, sign(string(req.body), secret), req.header.Get("X-Webhook-Signature"))
+}
+
+func TestSendWebhookWithoutSecretIsNotSigned(t *testing.T) {
+	t.Parallel()
+
+	wn := notifier.NewWebhookNotifier(nil, []byte("encryption-key"), http.DefaultClient, notifier.RetryPolicy{},
+		time.Now, slog.Default())
+
+	srv, captured := newCapturingServer(t)
+
+	err := wn.SendAlertResolved(t.Context(), &warnly.Alert{ID: 42}, &warnly.WebhookConfig{URL: srv.URL})
+	require.NoError(t, err)
+
+	req := <-captured
+	assert.Empty(t, req.header.Get(notifier.SignatureHeader))
+	assert.Empty(t, req.header.Get("X-Webhook-Signature"))
+}
+
+func testRetryPolicy() notifier.RetryPolicy {
+	return notifier.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestSendWebhookRetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(_ context.Context, _ *warnly.WebhookDeadLetter) error {
+			t.Error("delivered webhook must not be dead-lettered")
+			return nil
+		},
+	}
+	wn := notifier.NewWebhookNotifier(store, []byte("encryption-key"), http.DefaultClient, testRetryPolicy(),
+		time.Now, slog.Default())
+
+	err := wn.SendAlertTriggered(t.Context(), &warnly.Alert{ID: 42}, &warnly.WebhookConfig{URL: srv.URL, ChannelID: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestSendWebhookDeadLettersAfterLastAttempt(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	t.Cleanup(srv.Close)
+
+	var deadLetters []*warnly.WebhookDeadLetter
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(_ context.Context, d *warnly.WebhookDeadLetter) error {
+			deadLetters = append(deadLetters, d)
+			return nil
+		},
+	}
+	wn := notifier.NewWebhookNotifier(store, []byte("encryption-key"), http.DefaultClient, testRetryPolicy(),
+		time.Now, slog.Default())
+
+	err := wn.SendAlertTriggered(t.Context(), &warnly.Alert{ID: 42, RuleName: "High Error Rate"},
+		&warnly.WebhookConfig{URL: srv.URL, ChannelID: 7})
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 7, deadLetters[0].ChannelID)
+	assert.Equal(t, srv.URL, deadLetters[0].URL)
+	assert.Equal(t, 3, deadLetters[0].Attempts)
+	assert.Contains(t, deadLetters[0].Payload, `"alert_name":"High Error Rate"`)
+	assert.Contains(t, deadLetters[0].LastError, "500")
+}
+
+func TestSendWebhookDoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	var deadLetters []*warnly.WebhookDeadLetter
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(_ context.Context, d *warnly.WebhookDeadLetter) error {
+			deadLetters = append(deadLetters, d)
+			return nil
+		},
+	}
+	wn := notifier.NewWebhookNotifier(store, []byte("encryption-key"), http.DefaultClient, testRetryPolicy(),
+		time.Now, slog.Default())
+
+	err := wn.SendAlertResolved(t.Context(), &warnly.Alert{ID: 42}, &warnly.WebhookConfig{URL: srv.URL})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 1, deadLetters[0].Attempts)
+}
+
+func TestSendWebhookReleasesDestinationBetweenAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(_ context.Context, _ *warnly.WebhookDeadLetter) error { return nil },
+	}
+	wn := notifier.NewWebhookNotifier(store, []byte("encryption-key"), http.DefaultClient,
+		notifier.RetryPolicy{MaxAttempts: 2, BaseDelay: 5 * time.Second, MaxDelay: 5 * time.Second},
+		time.Now, slog.Default())
+	wn.LimitConcurrency(1)
+
+	config := &warnly.WebhookConfig{URL: srv.URL}
+	ctx, cancel := context.WithCancel(t.Context())
+	retrying := make(chan error, 1)
+	go func() {
+		retrying <- wn.SendAlertTriggered(ctx, &warnly.Alert{ID: 1}, config)
+	}()
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, 5*time.Second, time.Millisecond)
+
+	// The first send backs off after a failed attempt, the destination is free meanwhile.
+	sendCtx, sendCancel := context.WithTimeout(t.Context(), time.Second)
+	defer sendCancel()
+	require.NoError(t, wn.SendAlertTriggered(sendCtx, &warnly.Alert{ID: 2}, config))
+
+	cancel()
+	require.ErrorIs(t, <-retrying, context.Canceled)
+}
+
+func TestSendTestIsNotDeadLettered(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(_ context.Context, _ *warnly.WebhookDeadLetter) error {
+			t.Error("test send must not be dead-lettered")
+			return nil
+		},
+	}
+	wn := notifier.NewWebhookNotifier(store, []byte("encryption-key"), http.DefaultClient, testRetryPolicy(),
+		time.Now, slog.Default())
+
+	err := wn.SendTest(t.Context(), &warnly.WebhookConfig{URL: srv.URL, ChannelID: 7}, 1)
+	require.Error(t, err)
+}
@@ -7,6 +7,8 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/vk-rv/warnly/internal/warnly"
 	"github.com/vk-rv/warnly/internal/web"
@@ -107,6 +109,11 @@ func (h *AlertsHandler) CreateAlert(w http.ResponseWriter, r *http.Request) {
 
 	_, err = h.alertService.CreateAlert(ctx, req)
 	if err != nil {
+		if errors.Is(err, warnly.ErrInvalidAlertCondition) || errors.Is(err, warnly.ErrInvalidSpikeSettings) ||
+			errors.Is(err, warnly.ErrInvalidEscalation) {
+			h.writeError(ctx, w, http.StatusBadRequest, "create alert: invalid condition", err)
+			return
+		}
 		h.writeError(ctx, w, http.StatusInternalServerError, "create alert: can't create alert", err)
 		return
 	}
@@ -168,6 +175,11 @@ func (h *AlertsHandler) UpdateAlert(w http.ResponseWriter, r *http.Request) {
 
 	_, err = h.alertService.UpdateAlert(ctx, req)
 	if err != nil {
+		if errors.Is(err, warnly.ErrInvalidAlertCondition) || errors.Is(err, warnly.ErrInvalidSpikeSettings) ||
+			errors.Is(err, warnly.ErrInvalidEscalation) {
+			h.writeError(ctx, w, http.StatusBadRequest, "update alert: invalid condition", err)
+			return
+		}
 		h.writeError(ctx, w, http.StatusInternalServerError, "update alert: can't update alert", err)
 		return
 	}
@@ -197,6 +209,23 @@ func (h *AlertsHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// AcknowledgeAlert is a handler for POST /alerts/ack/{token}.
+// The token sent with the alert notification authorizes the request, so receivers can acknowledge without a session.
+func (h *AlertsHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := h.alertService.AcknowledgeAlert(ctx, r.PathValue("token")); err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "acknowledge alert", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "acknowledge alert", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *AlertsHandler) writeAlerts(
 	ctx context.Context,
 	w http.ResponseWriter,
@@ -254,16 +283,30 @@ func newAlertRequest(r *http.Request, user *warnly.User) (*warnly.CreateAlertReq
 		return nil, fmt.Errorf("create alert: parse timeframe: %w", err)
 	}
 
+	baselineWindows, spikeMultiplier, err := parseSpikeSettings(r)
+	if err != nil {
+		return nil, fmt.Errorf("create alert: %w", err)
+	}
+
+	escalationChain, escalationWindow, err := parseEscalation(r)
+	if err != nil {
+		return nil, fmt.Errorf("create alert: %w", err)
+	}
+
 	highPriority := r.FormValue("high_priority") == "true"
 
 	return &warnly.CreateAlertRequest{
-		User:         user,
-		ProjectID:    projectID,
-		RuleName:     ruleName,
-		Threshold:    threshold,
-		Condition:    warnly.AlertCondition(condition),
-		Timeframe:    warnly.AlertTimeframe(timeframe),
-		HighPriority: highPriority,
+		User:             user,
+		ProjectID:        projectID,
+		RuleName:         ruleName,
+		Threshold:        threshold,
+		Condition:        warnly.AlertCondition(condition),
+		Timeframe:        warnly.AlertTimeframe(timeframe),
+		BaselineWindows:  baselineWindows,
+		SpikeMultiplier:  spikeMultiplier,
+		EscalationChain:  escalationChain,
+		EscalationWindow: escalationWindow,
+		HighPriority:     highPriority,
 	}, nil
 }
 
@@ -298,17 +341,81 @@ func newUpdateAlertRequest(r *http.Request, user *warnly.User) (*warnly.UpdateAl
 		return nil, fmt.Errorf("update alert: parse timeframe: %w", err)
 	}
 
+	baselineWindows, spikeMultiplier, err := parseSpikeSettings(r)
+	if err != nil {
+		return nil, fmt.Errorf("update alert: %w", err)
+	}
+
+	escalationChain, escalationWindow, err := parseEscalation(r)
+	if err != nil {
+		return nil, fmt.Errorf("update alert: %w", err)
+	}
+	_, hasChain := r.Form["escalation_channel_ids"]
+	_, hasWindow := r.Form["escalation_window_minutes"]
+
 	highPriority := r.FormValue("high_priority") == "true"
 	status := r.FormValue("status")
 
 	return &warnly.UpdateAlertRequest{
-		User:         user,
-		AlertID:      alertID,
-		RuleName:     ruleName,
-		Threshold:    threshold,
-		Condition:    warnly.AlertCondition(condition),
-		Timeframe:    warnly.AlertTimeframe(timeframe),
-		HighPriority: highPriority,
-		Status:       warnly.AlertStatus(status),
+		User:             user,
+		AlertID:          alertID,
+		RuleName:         ruleName,
+		Threshold:        threshold,
+		Condition:        warnly.AlertCondition(condition),
+		Timeframe:        warnly.AlertTimeframe(timeframe),
+		BaselineWindows:  baselineWindows,
+		SpikeMultiplier:  spikeMultiplier,
+		EscalationChain:  escalationChain,
+		EscalationWindow: escalationWindow,
+		KeepEscalation:   !hasChain && !hasWindow,
+		HighPriority:     highPriority,
+		Status:           warnly.AlertStatus(status),
 	}, nil
 }
+
+// parseSpikeSettings parses the optional baseline window count and multiplier of a spike alert,
+// missing values are left zero for the service to fill in defaults.
+func parseSpikeSettings(r *http.Request) (int, float64, error) {
+	var (
+		baselineWindows int
+		spikeMultiplier float64
+		err             error
+	)
+	if v := r.FormValue("baseline_windows"); v != "" {
+		if baselineWindows, err = strconv.Atoi(v); err != nil {
+			return 0, 0, fmt.Errorf("parse baseline windows: %w", err)
+		}
+	}
+	if v := r.FormValue("spike_multiplier"); v != "" {
+		if spikeMultiplier, err = strconv.ParseFloat(v, 64); err != nil {
+			return 0, 0, fmt.Errorf("parse spike multiplier: %w", err)
+		}
+	}
+	return baselineWindows, spikeMultiplier, nil
+}
+
+// parseEscalation parses the optional comma separated channel IDs of the escalation chain
+// and the minutes the alert waits for an acknowledgement before escalating.
+func parseEscalation(r *http.Request) ([]int, time.Duration, error) {
+	var (
+		chain  []int
+		window time.Duration
+	)
+	if v := r.FormValue("escalation_channel_ids"); v != "" {
+		for id := range strings.SplitSeq(v, ",") {
+			channelID, err := strconv.Atoi(strings.TrimSpace(id))
+			if err != nil {
+				return nil, 0, fmt.Errorf("parse escalation channel ID: %w", err)
+			}
+			chain = append(chain, channelID)
+		}
+	}
+	if v := r.FormValue("escalation_window_minutes"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parse escalation window: %w", err)
+		}
+		window = time.Duration(minutes) * time.Minute
+	}
+	return chain, window, nil
+}
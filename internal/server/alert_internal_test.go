@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestNewUpdateAlertRequestEscalation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		form       url.Values
+		wantChain  []int
+		wantWindow time.Duration
+		wantKeep   bool
+	}{
+		{
+			name:     "absent",
+			form:     url.Values{},
+			wantKeep: true,
+		},
+		{
+			name:       "set",
+			form:       url.Values{"escalation_channel_ids": {"3, 4"}, "escalation_window_minutes": {"15"}},
+			wantChain:  []int{3, 4},
+			wantWindow: 15 * time.Minute,
+		},
+		{
+			name: "cleared",
+			form: url.Values{"escalation_channel_ids": {""}, "escalation_window_minutes": {""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			form := url.Values{
+				"rule_name":     {"Error spike"},
+				"threshold":     {"10"},
+				"condition":     {"1"},
+				"timeframe":     {"1"},
+				"high_priority": {"true"},
+			}
+			for k, v := range tt.form {
+				form[k] = v
+			}
+			r := httptest.NewRequestWithContext(t.Context(), http.MethodPut, "/alerts/1", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.SetPathValue("id", "1")
+
+			req, err := newUpdateAlertRequest(r, &warnly.User{ID: 1})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChain, req.EscalationChain)
+			assert.Equal(t, tt.wantWindow, req.EscalationWindow)
+			assert.Equal(t, tt.wantKeep, req.KeepEscalation)
+		})
+	}
+}
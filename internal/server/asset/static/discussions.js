@@ -11,7 +11,6 @@ window.discussionFunctions = {
     if (lastAtIndex !== -1) {
       this.comment = this.comment.substring(0, lastAtIndex) + '@' + user.name + ' ';
     }
-    this.mentionedUsers.push(user.id);
     this.showMentions = false;
     this.mentionFilter = '';
   },
@@ -25,13 +24,11 @@ window.discussionFunctions = {
   postComment: function() {
     htmx.ajax('POST', this.uri, {
       values: {
-        content: this.comment,
-        mentioned_users: this.mentionedUsers
+        content: this.comment
       },
       swap: 'outerHTML',
       target: '#messages'
     });
     this.comment = '';
-    this.mentionedUsers = [];
   }
 };
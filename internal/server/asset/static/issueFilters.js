@@ -6,6 +6,10 @@ window.issueFilters = function(initialData) {
     selectedProject: initialData.projectName || '',
     startDate: initialData.start || '',
     endDate: initialData.end || '',
+    unhandledOnly: initialData.unhandledOnly || false,
+    sortByRelevance: initialData.sortByRelevance || false,
+    sortBy: initialData.sortBy || '',
+    defaultQuerySaved: false,
 
     offset: initialData.offset || 0,
     limit: 50,
@@ -74,6 +78,7 @@ window.issueFilters = function(initialData) {
       const newQuery = queryParts.join(' ');
       if (this.searchQuery !== newQuery) {
         this.searchQuery = newQuery;
+        this.defaultQuerySaved = false;
         this.applyFilters();
       }
     },
@@ -157,19 +162,37 @@ window.issueFilters = function(initialData) {
         params.set('end', this.endDate);
       }
       
-      if (this.searchQuery) {
-        params.set('query', this.searchQuery);
-      }
+      // always sent, so that an empty search overrides the default query.
+      params.set('query', this.searchQuery);
       
       if (this.filters.length > 0) {
         params.set('filters', JSON.stringify(this.filters));
       }
+
+      if (this.unhandledOnly) {
+        params.set('unhandled', 'true');
+      }
+
+      if (this.sortByRelevance) {
+        params.set('sort', 'relevance');
+      } else if (this.sortBy) {
+        params.set('sort', this.sortBy);
+      }
       
       params.set('offset', this.offset);
       
       return params.toString();
     },
 
+    saveDefaultQuery() {
+      const body = new URLSearchParams();
+      body.set('project_name', this.selectedProject);
+      body.set('query', this.searchQuery);
+      fetch('/issues/default-query', { method: 'PUT', body: body }).then(response => {
+        this.defaultQuerySaved = response.ok;
+      });
+    },
+
     applyFilters() {
       const params = this.buildQueryParams();
       const url = '/?' + params + '&partial=body';
@@ -0,0 +1,11 @@
+// Stores the timezone of the browser so the server can render absolute timestamps in it.
+(function () {
+    try {
+        const tz = Intl.DateTimeFormat().resolvedOptions().timeZone;
+        if (tz) {
+            document.cookie = "tz=" + encodeURIComponent(tz) + "; path=/; max-age=31536000; SameSite=Lax";
+        }
+    } catch (e) {
+        // timezone is unavailable, the server falls back to UTC
+    }
+})();
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// attachmentHandler handles downloads of files sent along with events.
+type attachmentHandler struct {
+	*BaseHandler
+
+	svc    warnly.AttachmentService
+	logger *slog.Logger
+}
+
+// newAttachmentHandler creates a new attachmentHandler instance.
+func newAttachmentHandler(svc warnly.AttachmentService, logger *slog.Logger) *attachmentHandler {
+	return &attachmentHandler{
+		BaseHandler: NewBaseHandler(logger),
+		svc:         svc,
+		logger:      logger,
+	}
+}
+
+// downloadAttachment writes the attachment content as a file download.
+func (h *attachmentHandler) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "download attachment: parse project ID", err)
+		return
+	}
+
+	attachment, err := h.svc.GetAttachment(ctx, &warnly.GetAttachmentRequest{
+		User:      &user,
+		EventID:   r.PathValue("event_id"),
+		Filename:  r.PathValue("filename"),
+		ProjectID: projectID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrNotFound), errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "download attachment", err)
+		case errors.Is(err, warnly.ErrInvalidAttachment):
+			h.writeError(ctx, w, http.StatusBadRequest, "download attachment", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "download attachment", err)
+		}
+		return
+	}
+
+	// The content comes from SDKs, so it is always downloaded rather than rendered by the browser.
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Filename,
+	}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(attachment.Data)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err := w.Write(attachment.Data); err != nil {
+		h.logger.Error("download attachment: write", slog.Any("error", err))
+	}
+}
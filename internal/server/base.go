@@ -20,9 +20,9 @@ func NewBaseHandler(logger *slog.Logger) *BaseHandler {
 }
 
 func (h *BaseHandler) writeError(ctx context.Context, w http.ResponseWriter, code int, msg string, err error) {
-	h.logger.Error(msg, slog.Any("error", err))
+	h.logger.ErrorContext(ctx, msg, slog.Any("error", err))
 	w.WriteHeader(code)
 	if err = web.ServerError(strconv.Itoa(code), http.StatusText(code)).Render(ctx, w); err != nil {
-		h.logger.Error(msg+" server error web render", slog.Any("error", err))
+		h.logger.ErrorContext(ctx, msg+" server error web render", slog.Any("error", err))
 	}
 }
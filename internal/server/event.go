@@ -1,24 +1,60 @@
 package server
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
 
 const internalErrorDetail = "Internal Error"
 
-// ingestResponseError represents a standard API error response for event ingestion.
+// Error codes returned to SDKs in the ingest error envelope.
+const (
+	codeBadRequest       = "bad_request"
+	codeInvalidDSN       = "invalid_dsn"
+	codeInvalidEnvelope  = "invalid_envelope"
+	codeInvalidEvent     = "invalid_event"
+	codeProjectNotFound  = "project_not_found"
+	codeProjectNotReady  = "project_not_ready"
+	codeInvalidSignature = "invalid_signature"
+	codePayloadTooLarge  = "payload_too_large"
+	codeUnsupportedType  = "unsupported_type"
+	codeRequestTimeout   = "request_timeout"
+	codeAuthLockout      = "auth_lockout"
+	codeStoreUnavailable = "store_unavailable"
+	codeInternalError    = "internal_error"
+)
+
+// ingestResponseError is the error envelope returned by the ingestion API,
+// e.g. {"error":{"code":"invalid_envelope","detail":"..."}}.
 type ingestResponseError struct {
+	Error ingestErrorBody `json:"error"`
+}
+
+// ingestErrorBody describes why an ingest request was rejected.
+type ingestErrorBody struct {
+	Code   string `json:"code"`
 	Detail string `json:"detail"`
 	//nolint:tagliatelle // keeping existing json tag for backward compatibility
 	ErrorID string   `json:"errorId,omitempty"`
@@ -42,29 +78,97 @@ type ClientError interface {
 
 type IngestError struct {
 	Detail       string
+	Code         string
 	WrappedError error
 	Causes       []string
 	Status       int
+	// RetryAfter is sent in the Retry-After header when set.
+	RetryAfter time.Duration
 }
 
-// NewBadRequestError creates a 400 Bad Request error, saving the optional original error.
-func NewBadRequestError(detail string, originalErr error, causes ...string) *IngestError {
+// newIngestError creates an IngestError with the given status and error code.
+func newIngestError(status int, code, detail string, originalErr error, causes ...string) *IngestError {
 	return &IngestError{
-		Status:       http.StatusBadRequest,
+		Status:       status,
+		Code:         code,
 		Detail:       detail,
 		Causes:       causes,
 		WrappedError: originalErr,
 	}
 }
 
-// NewSizeLimitError creates a 400 error for size limit exceeded.
+// NewBadRequestError creates a 400 Bad Request error, saving the optional original error.
+func NewBadRequestError(detail string, originalErr error, causes ...string) *IngestError {
+	return newIngestError(http.StatusBadRequest, codeBadRequest, detail, originalErr, causes...)
+}
+
+// NewInvalidEnvelopeError creates a 400 error for an envelope that can't be parsed.
+func NewInvalidEnvelopeError(detail string, originalErr error, causes ...string) *IngestError {
+	return newIngestError(http.StatusBadRequest, codeInvalidEnvelope, detail, originalErr, causes...)
+}
+
+// NewInvalidEventError creates a 400 error for an event payload that can't be decoded.
+func NewInvalidEventError(detail string, originalErr error, causes ...string) *IngestError {
+	return newIngestError(http.StatusBadRequest, codeInvalidEvent, detail, originalErr, causes...)
+}
+
+// NewSizeLimitError creates a 413 error for size limit exceeded.
 func NewSizeLimitError(detail string) *IngestError {
-	return NewBadRequestError("envelope exceeded size limits", nil, detail)
+	return newIngestError(http.StatusRequestEntityTooLarge, codePayloadTooLarge, "envelope exceeded size limits", nil, detail)
 }
 
-// NewInvalidDSNError creates a 400 error specifically for bad DSN/key.
+// NewInvalidDSNError creates a 401 error specifically for bad DSN/key.
 func NewInvalidDSNError() *IngestError {
-	return NewBadRequestError("invalid DSN or project key.", nil)
+	return newIngestError(http.StatusUnauthorized, codeInvalidDSN, "invalid DSN or project key.", nil)
+}
+
+// NewInvalidSignatureError creates a 401 error for a payload of a project requiring signed ingestion
+// that has a missing or wrong signature.
+func NewInvalidSignatureError(originalErr error) *IngestError {
+	return newIngestError(http.StatusUnauthorized, codeInvalidSignature, "invalid payload signature", originalErr,
+		"the "+warnly.IngestSignatureHeader+" header must be the hex HMAC-SHA256 of the body")
+}
+
+// NewProjectNotFoundError creates a 404 error for a project that doesn't exist or doesn't match the key.
+func NewProjectNotFoundError(originalErr error) *IngestError {
+	return newIngestError(http.StatusNotFound, codeProjectNotFound, "project not found", originalErr,
+		"invalid project identifier or key")
+}
+
+// projectNotReadyRetryAfter is how long SDKs are asked to wait before sending events
+// of a project created moments ago again.
+const projectNotReadyRetryAfter = 5 * time.Second
+
+// NewProjectNotReadyError creates a 429 error for a key that doesn't match a project created moments ago yet,
+// SDKs retry after the Retry-After header rather than dropping the event.
+func NewProjectNotReadyError(originalErr error) *IngestError {
+	err := newIngestError(http.StatusTooManyRequests, codeProjectNotReady, "project is not ready yet", originalErr,
+		"the project was created recently, try again shortly")
+	err.RetryAfter = projectNotReadyRetryAfter
+	return err
+}
+
+// NewAuthLockoutError creates a 429 error for a source locked out after repeated authentication failures.
+func NewAuthLockoutError() *IngestError {
+	return newIngestError(http.StatusTooManyRequests, codeAuthLockout, "too many authentication failures", nil,
+		"check the DSN of the SDK and try again later")
+}
+
+// NewUnsupportedTypeError creates a 415 error for envelope items that can't be ingested.
+func NewUnsupportedTypeError(itemType string) *IngestError {
+	return newIngestError(http.StatusUnsupportedMediaType, codeUnsupportedType, "unsupported envelope item type", nil,
+		fmt.Sprintf("item type %q is not supported", itemType))
+}
+
+// NewReadTimeoutError creates a 408 error for a body that was not received in time.
+func NewReadTimeoutError(originalErr error) *IngestError {
+	return newIngestError(http.StatusRequestTimeout, codeRequestTimeout, "request body read timeout", originalErr)
+}
+
+// NewStoreEventError creates a 503 error for an event that could not be persisted, so the SDK may retry it.
+func NewStoreEventError(originalErr error) *IngestError {
+	return newIngestError(http.StatusServiceUnavailable, codeStoreUnavailable, "event not stored", originalErr,
+		"the event could not be persisted, try again later")
 }
 
 // Error implements the error interface.
@@ -91,8 +195,11 @@ func (e *IngestError) HTTPStatus() int {
 // Response returns the structured error response body.
 func (e *IngestError) Response() ingestResponseError {
 	return ingestResponseError{
-		Detail: e.Detail,
-		Causes: e.Causes,
+		Error: ingestErrorBody{
+			Code:   e.Code,
+			Detail: e.Detail,
+			Causes: e.Causes,
+		},
 	}
 }
 
@@ -100,124 +207,856 @@ func (e *IngestError) Response() ingestResponseError {
 type EventHandler struct {
 	svc    warnly.EventService
 	logger *slog.Logger
+	// droppedTransactions counts the performance transactions that are received but not stored yet.
+	droppedTransactions prometheus.Counter
+	// storeErrors counts the ingested events that could not be persisted.
+	storeErrors prometheus.Counter
+	// deduplicatedEvents counts the ingested events that were acknowledged but not stored as duplicates.
+	deduplicatedEvents prometheus.Counter
+	// filteredEvents counts the ingested events that were acknowledged but dropped by ingest or noise filters.
+	filteredEvents prometheus.Counter
+	// ingestDuration measures how long ingested events take to be stored.
+	ingestDuration prometheus.Histogram
+	// authFailures counts the ingest requests rejected because of a wrong project, key or signature.
+	authFailures *prometheus.CounterVec
+	// lockouts counts the authentication failures of every source within the lockout window,
+	// nil when sources aren't locked out.
+	lockouts *cache.Cache
+	// maxEnvelopeSize is the largest envelope that can be ingested, after decompression.
+	maxEnvelopeSize int64
+	// lockoutThreshold is the number of authentication failures within the window a source is locked out after.
+	lockoutThreshold int
+	// lockoutWindow is how long the authentication failures of a source are counted.
+	lockoutWindow time.Duration
 }
 
 // NewEventAPIHandler is a constructor of ventHandler.
-func NewEventAPIHandler(svc warnly.EventService, logger *slog.Logger) *EventHandler {
-	return &EventHandler{svc: svc, logger: logger}
+func NewEventAPIHandler(svc warnly.EventService, r prometheus.Registerer, logger *slog.Logger) *EventHandler {
+	return &EventHandler{
+		svc:             svc,
+		logger:          logger,
+		maxEnvelopeSize: DefaultMaxEnvelopeSize,
+		droppedTransactions: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "warnly_dropped_transactions_total",
+			Help: "Total number of ingested transaction envelope items that were dropped.",
+		}),
+		storeErrors: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "warnly_ingest_store_errors_total",
+			Help: "Total number of ingested events that could not be stored.",
+		}),
+		deduplicatedEvents: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "warnly_ingest_deduplicated_events_total",
+			Help: "Total number of ingested events that were not stored as duplicates of recent events.",
+		}),
+		filteredEvents: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "warnly_ingest_filtered_events_total",
+			Help: "Total number of ingested events that were dropped by ingest or noise filters.",
+		}),
+		ingestDuration: promauto.With(r).NewHistogram(prometheus.HistogramOpts{
+			Name:    "warnly_ingest_duration_seconds",
+			Help:    "Duration of storing ingested events in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		authFailures: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Name: "warnly_ingest_auth_failures_total",
+			Help: "Total number of ingest requests rejected because of a wrong project, key or signature.",
+		}, []string{"reason"}),
+	}
+}
+
+// SetMaxEnvelopeSize sets the largest envelope that can be ingested, a non-positive size keeps the default.
+func (h *EventHandler) SetMaxEnvelopeSize(size int64) {
+	if size > 0 {
+		h.maxEnvelopeSize = size
+	}
+}
+
+// SetAuthLockout locks out a source for the rest of the window once it fails to authenticate threshold times
+// within the window, so that requests with wrong keys don't reach the project store.
+// Sources aren't locked out when either the threshold or the window isn't positive.
+func (h *EventHandler) SetAuthLockout(threshold int, window time.Duration) {
+	if threshold <= 0 || window <= 0 {
+		h.lockouts = nil
+		return
+	}
+	h.lockoutThreshold = threshold
+	h.lockoutWindow = window
+	h.lockouts = cache.New(window, window)
 }
 
 // IngestEvent ingests new event.
 func (h *EventHandler) IngestEvent(w http.ResponseWriter, r *http.Request) {
-	res, err := h.handleIngestEvent(r)
-	if err != nil {
-		var clientErr ClientError
-		if errors.As(err, &clientErr) {
-			h.logger.Error("ingest client error", slog.Any("error", clientErr), slog.Int("status", clientErr.HTTPStatus()))
+	h.serveIngest(w, r, h.handleIngestEvent)
+}
 
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(clientErr.HTTPStatus())
+// StoreEvent ingests a bare event sent to the legacy store endpoint by older SDKs,
+// which post a single event rather than an envelope.
+func (h *EventHandler) StoreEvent(w http.ResponseWriter, r *http.Request) {
+	h.serveIngest(w, r, h.handleStoreEvent)
+}
 
-			if err := json.NewEncoder(w).Encode(clientErr.Response()); err != nil {
-				h.logger.Error("encode client error response", slog.Any("error", err), slog.Int("status", clientErr.HTTPStatus()))
-			}
-			return
+// outcomeAccepted is the outcome of an ingest request that was accepted,
+// rejected requests have the code of their error as the outcome.
+const outcomeAccepted = "accepted"
+
+// ingestLog collects what an ingest request carried and how long ingesting it took,
+// so that a single line is logged per request whatever its outcome.
+// A nil ingestLog records nothing, envelopes of a batch aren't logged one by one.
+type ingestLog struct {
+	eventID   string
+	itemType  string
+	parse     time.Duration
+	store     time.Duration
+	projectID int
+	size      int
+}
+
+// setRequest records the project and the size of the ingested payload.
+func (l *ingestLog) setRequest(projectID, size int) {
+	if l != nil {
+		l.projectID, l.size = projectID, size
+	}
+}
+
+// setItem records the type of the ingested item and the event ID, empty when there is no event.
+func (l *ingestLog) setItem(itemType, eventID string) {
+	if l != nil {
+		l.itemType, l.eventID = itemType, eventID
+	}
+}
+
+// addParse adds the time spent parsing the payload since start.
+func (l *ingestLog) addParse(start time.Time) {
+	if l != nil {
+		l.parse += time.Since(start)
+	}
+}
+
+// addStore adds the time spent storing the event since start.
+func (l *ingestLog) addStore(start time.Time) {
+	if l != nil {
+		l.store += time.Since(start)
+	}
+}
+
+// logIngest writes the line of the ingest request, at debug level when it was accepted and at error level otherwise.
+// The request ID is added by the logger from the context.
+func (h *EventHandler) logIngest(ctx context.Context, l *ingestLog, status int, outcome string, err error, errorID string) {
+	attrs := []slog.Attr{
+		slog.Int("project_id", l.projectID),
+		slog.String("event_id", l.eventID),
+		slog.String("item_type", l.itemType),
+		slog.Int("bytes", l.size),
+		slog.Duration("parse_duration", l.parse),
+		slog.Duration("store_duration", l.store),
+		slog.String("outcome", outcome),
+		slog.Int("status", status),
+	}
+	if err == nil {
+		h.logger.LogAttrs(ctx, slog.LevelDebug, "ingest", attrs...)
+		return
+	}
+	attrs = append(attrs, slog.Any("error", err))
+	if errorID != "" {
+		attrs = append(attrs, slog.String("errorId", errorID))
+	}
+	h.logger.LogAttrs(ctx, slog.LevelError, "ingest", attrs...)
+}
+
+// serveIngest ingests the request with handle and writes the ID of the ingested event,
+// sources failing to authenticate too often are locked out. A line is logged for every request.
+func (h *EventHandler) serveIngest(
+	w http.ResponseWriter,
+	r *http.Request,
+	handle func(r *http.Request, l *ingestLog) (warnly.IngestEventResult, error),
+) {
+	ctx := r.Context()
+	l := &ingestLog{}
+	if projectID, err := strconv.Atoi(r.PathValue("project_id")); err == nil {
+		l.projectID = projectID
+	}
+
+	source := remoteHost(r.RemoteAddr)
+	var (
+		res warnly.IngestEventResult
+		err error
+	)
+	if h.lockedOut(source) {
+		err = NewAuthLockoutError()
+	} else {
+		res, err = handle(r, l)
+		if reason, ok := authFailureReason(err); ok {
+			h.recordAuthFailure(reason, source)
 		}
-		id := warnly.MustNanoID()
-		h.logger.Error("ingest new event", slog.Any("error", err), slog.String("errorId", id))
-		w.WriteHeader(http.StatusInternalServerError)
-		if err := json.NewEncoder(w).Encode(ingestResponseError{
-			Detail:  internalErrorDetail,
-			ErrorID: id,
-		}); err != nil {
-			h.logger.Error("encode error response", slog.Any("error", err))
+	}
+	if err != nil {
+		status, resp := ingestErrorResponse(err)
+		var ingestErr *IngestError
+		if errors.As(err, &ingestErr) && ingestErr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(ingestErr.RetryAfter.Seconds())))
 		}
+		h.logIngest(ctx, l, status, resp.Error.Code, err, resp.Error.ErrorID)
+		h.writeIngestResponse(ctx, w, status, resp)
 		return
 	}
+
+	// Queued events are stored asynchronously, SDKs treat 202 Accepted as a success as well.
+	status := http.StatusOK
+	if res.Queued {
+		status = http.StatusAccepted
+	}
+
+	h.logIngest(ctx, l, status, outcomeAccepted, nil, "")
+	h.writeIngestResponse(ctx, w, status, ingestResponseSuccess{ID: res.EventID})
+}
+
+// writeIngestResponse writes the JSON response of an ingest request with the status.
+func (h *EventHandler) writeIngestResponse(ctx context.Context, w http.ResponseWriter, status int, resp any) {
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(ingestResponseSuccess{
-		ID: res.EventID,
-	}); err != nil {
-		h.logger.Error("encode success response", slog.Any("error", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.ErrorContext(ctx, "encode ingest response", slog.Any("error", err), slog.Int("status", status))
+	}
+}
+
+// writeIngestError logs the error and writes the error envelope of a rejected ingest request.
+func (h *EventHandler) writeIngestError(ctx context.Context, w http.ResponseWriter, err error) {
+	status, resp := ingestErrorResponse(err)
+	h.logIngestError(ctx, "ingest error", err, status, resp)
+	h.writeIngestResponse(ctx, w, status, resp)
+}
+
+// logIngestError logs the error of an ingest request that isn't logged by serveIngest.
+func (h *EventHandler) logIngestError(ctx context.Context, msg string, err error, status int, resp ingestResponseError) {
+	attrs := []slog.Attr{slog.Any("error", err), slog.Int("status", status), slog.String("outcome", resp.Error.Code)}
+	if resp.Error.ErrorID != "" {
+		attrs = append(attrs, slog.String("errorId", resp.Error.ErrorID))
+	}
+	h.logger.LogAttrs(ctx, slog.LevelError, msg, attrs...)
+}
+
+// ingestErrorResponse returns the status and the error envelope of a rejected ingest request,
+// errors that aren't client errors are referred to by a new error ID so they can be found in the logs.
+func ingestErrorResponse(err error) (int, ingestResponseError) {
+	var clientErr ClientError
+	if errors.As(err, &clientErr) {
+		return clientErr.HTTPStatus(), clientErr.Response()
+	}
+	return http.StatusInternalServerError, ingestResponseError{
+		Error: ingestErrorBody{
+			Code:    codeInternalError,
+			Detail:  internalErrorDetail,
+			ErrorID: warnly.MustNanoID(),
+		},
 	}
 }
 
+// DefaultMaxEnvelopeSize is the largest envelope that can be ingested unless configured otherwise.
+const DefaultMaxEnvelopeSize int64 = 4 * 1024 * 1024 // 4MB
+
 // handleIngestEvent handles the actual logic of ingesting an event.
-func (h *EventHandler) handleIngestEvent(r *http.Request) (warnly.IngestEventResult, error) {
-	ctx := r.Context()
+func (h *EventHandler) handleIngestEvent(r *http.Request, l *ingestLog) (warnly.IngestEventResult, error) {
+	in, err := h.readIngestRequest(r)
+	if err != nil {
+		return warnly.IngestEventResult{}, err
+	}
+	in.log = l
+	l.setRequest(in.projectID, len(in.payload))
 
-	res := warnly.IngestEventResult{}
+	return h.ingestEnvelope(r.Context(), in)
+}
+
+// handleStoreEvent ingests the bare event of a legacy store request the way the event item of an envelope is.
+func (h *EventHandler) handleStoreEvent(r *http.Request, l *ingestLog) (warnly.IngestEventResult, error) {
+	in, err := h.readIngestRequest(r)
+	if err != nil {
+		return warnly.IngestEventResult{}, err
+	}
+	in.log = l
+	l.setRequest(in.projectID, len(in.payload))
+
+	return h.ingestEventItem(r.Context(), in, in.payload, nil)
+}
 
+// readIngestRequest reads the project, the project key and the body of an ingest request.
+// The key is read from the X-Sentry-Auth header or, when the header has none, from the sentry_key
+// query parameter that SDKs unable to set headers, e.g. browser SDKs sending with sendBeacon, use.
+func (h *EventHandler) readIngestRequest(r *http.Request) (*envelopeRequest, error) {
 	projectID, err := strconv.Atoi(r.PathValue("project_id"))
 	if err != nil {
-		return res, NewBadRequestError("invalid project identifier", err, "project_id must be an integer")
+		return nil, NewProjectNotFoundError(err)
 	}
 
 	xSentryAuth := r.Header.Get("X-Sentry-Auth")
 	pKey, err := projectKey(xSentryAuth)
 	if err != nil {
-		if pk, err2 := projectKey(strings.TrimPrefix(xSentryAuth, "Sentry ")); err2 == nil {
-			pKey = pk
-		} else {
-			return res, err
+		pKey, err = projectKey(strings.TrimPrefix(xSentryAuth, "Sentry "))
+	}
+	if err != nil {
+		if pKey = r.URL.Query().Get("sentry_key"); pKey == "" {
+			return nil, err
 		}
 	}
 
+	b, err := h.readIngestBody(r, h.maxEnvelopeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelopeRequest{
+		payload:    b,
+		projectKey: pKey,
+		ip:         r.RemoteAddr,
+		signature:  r.Header.Get(warnly.IngestSignatureHeader),
+		projectID:  projectID,
+	}, nil
+}
+
+// readIngestBody reads the request body of at most limit bytes.
+// A gzip or deflate (zlib) encoded body is decompressed, as is a body without Content-Encoding
+// that is base64 encoded zlib data, the way legacy SDKs send store requests. The limit applies both
+// to the compressed and to the decompressed size so that a small body can't expand without bounds.
+func (h *EventHandler) readIngestBody(r *http.Request, limit int64) ([]byte, error) {
 	defer func() {
 		if err := r.Body.Close(); err != nil {
-			h.logger.Error("failed to close request body", slog.Any("error", err))
+			h.logger.ErrorContext(r.Context(), "failed to close request body", slog.Any("error", err))
 		}
 	}()
 
-	const maxEnvelopeSize int64 = 1 * 1024 * 1024 // 1MB
-	r.Body = http.MaxBytesReader(nil, r.Body, maxEnvelopeSize)
-	b, err := io.ReadAll(r.Body)
+	r.Body = http.MaxBytesReader(nil, r.Body, limit)
+	body := io.Reader(r.Body)
+	encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "gzip":
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, readBodyError(err, limit)
+		}
+		defer func() {
+			if err := zr.Close(); err != nil {
+				h.logger.ErrorContext(r.Context(), "failed to close gzip reader", slog.Any("error", err))
+			}
+		}()
+		body = zr
+	case "deflate":
+		zr, err := zlib.NewReader(r.Body)
+		if err != nil {
+			return nil, readBodyError(err, limit)
+		}
+		defer func() {
+			if err := zr.Close(); err != nil {
+				h.logger.ErrorContext(r.Context(), "failed to close zlib reader", slog.Any("error", err))
+			}
+		}()
+		body = zr
+	}
+
+	b, err := readLimited(body, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON and envelopes start with a brace, anything else may be base64 encoded zlib data.
+	if encoding == "" && len(b) > 0 && b[0] != '{' {
+		return inflateBase64(b, limit)
+	}
+
+	return b, nil
+}
+
+// inflateBase64 decompresses a base64 encoded zlib body of at most limit bytes decompressed.
+// A body that isn't base64 encoded is returned as is to be rejected as a malformed payload.
+func inflateBase64(b []byte, limit int64) ([]byte, error) {
+	encoded := bytes.TrimSpace(b)
+	compressed := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(compressed, encoded)
+	if err != nil || n == 0 {
+		return b, nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(compressed[:n]))
+	if err != nil {
+		return nil, readBodyError(err, limit)
+	}
+	// the data is in memory, closing the reader can't fail after it was read.
+	defer func() { _ = zr.Close() }()
+
+	return readLimited(zr, limit)
+}
+
+// readLimited reads the body, failing when it is larger than limit bytes.
+func readLimited(body io.Reader, limit int64) ([]byte, error) {
+	// one byte over the limit tells an oversized decompressed body apart from one of exactly the limit.
+	b, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, readBodyError(err, limit)
+	}
+	if int64(len(b)) > limit {
+		return nil, NewSizeLimitError(fmt.Sprintf("max %d bytes", limit))
+	}
+	return b, nil
+}
+
+// readBodyError maps an error reading the ingest request body to the client error.
+func readBodyError(err error, limit int64) error {
+	var (
+		maxBytesErr *http.MaxBytesError
+		corruptErr  flate.CorruptInputError
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return NewInvalidEnvelopeError("empty request body", err, "no payload provided")
+	case errors.As(err, &maxBytesErr), errors.Is(err, http.ErrBodyReadAfterClose):
+		return NewSizeLimitError(fmt.Sprintf("max %d bytes", limit))
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		return NewReadTimeoutError(err)
+	case errors.Is(err, gzip.ErrHeader), errors.Is(err, gzip.ErrChecksum),
+		errors.Is(err, zlib.ErrHeader), errors.Is(err, zlib.ErrChecksum),
+		errors.As(err, &corruptErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return NewBadRequestError("invalid compressed body", err, "failed to decompress payload")
+	default:
+		return NewBadRequestError("failed to read request body", err, "failed to decode payload")
+	}
+}
+
+// envelopeRequest is an envelope, or the bare event of a legacy store request, to ingest into the project.
+type envelopeRequest struct {
+	// log records the ingestion of the request, nil when it isn't logged.
+	log        *ingestLog
+	payload    []byte
+	projectKey string
+	ip         string
+	signature  string
+	projectID  int
+}
+
+// ingestEnvelope parses the envelope and ingests its event and client reports.
+func (h *EventHandler) ingestEnvelope(ctx context.Context, in *envelopeRequest) (warnly.IngestEventResult, error) {
+	res := warnly.IngestEventResult{}
+
+	start := time.Now()
+	env, err := parseEnvelope(in.payload)
+	in.log.addParse(start)
 	if err != nil {
-		if errors.Is(err, io.EOF) {
-			return res, NewBadRequestError("empty request body", err, "no payload provided")
+		return res, err
+	}
+
+	// The event is authenticated when it is ingested, an envelope without one is authenticated up front
+	// so that nothing is acknowledged or counted for a wrong key or signature.
+	if env.event == nil {
+		err := h.svc.AuthenticateIngest(ctx, warnly.IngestRequest{
+			ProjectKey: in.projectKey,
+			ProjectID:  in.projectID,
+			Payload:    in.payload,
+			Signature:  in.signature,
+		})
+		if err != nil {
+			return res, ingestServiceError("authenticate envelope", err)
 		}
-		if errors.Is(err, http.ErrBodyReadAfterClose) || strings.Contains(err.Error(), "http: request body too large") {
-			return res, NewSizeLimitError(fmt.Sprintf("max %d bytes", maxEnvelopeSize))
+	}
+
+	if len(env.clientReports) > 0 {
+		err := h.svc.RecordClientReports(ctx, &warnly.ClientReportsRequest{
+			Reports:    env.clientReports,
+			ProjectKey: in.projectKey,
+			ProjectID:  in.projectID,
+		})
+		if err != nil {
+			return res, ingestServiceError("record client reports", err)
 		}
-		return res, NewBadRequestError("failed to read request body", err, "failed to decode payload")
 	}
 
-	lines := strings.Split(string(b), "\n")
-	if len(lines) < 3 {
-		return res, NewBadRequestError("invalid event envelope", nil, "premature end of input: too few lines")
+	if env.event != nil {
+		res, err = h.ingestEventItem(ctx, in, env.event, env.attachments)
+		if err != nil {
+			return res, err
+		}
+	} else {
+		res.EventID = env.eventID
+		switch {
+		case env.transactions > 0:
+			in.log.setItem(warnly.EnvelopeItemTransaction, "")
+		case len(env.clientReports) > 0:
+			in.log.setItem(warnly.EnvelopeItemClientReport, "")
+		}
 	}
 
-	content := lines[2]
+	// Transactions are accepted so that SDKs with performance monitoring enabled don't retry them,
+	// but there is no tracing store yet.
+	h.droppedTransactions.Add(float64(env.transactions))
 
+	return res, nil
+}
+
+// ingestServiceError maps an error of the event service to the error response of the ingest request.
+func ingestServiceError(msg string, err error) error {
+	switch {
+	case errors.Is(err, warnly.ErrProjectNotReady):
+		return NewProjectNotReadyError(err)
+	case errors.Is(err, warnly.ErrProjectNotFound):
+		return NewProjectNotFoundError(err)
+	case errors.Is(err, warnly.ErrInvalidSignature):
+		return NewInvalidSignatureError(err)
+	default:
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+}
+
+// ingestEventItem decodes the event payload and ingests it along with its attachments.
+func (h *EventHandler) ingestEventItem(
+	ctx context.Context,
+	in *envelopeRequest,
+	payload []byte,
+	attachments []warnly.Attachment,
+) (warnly.IngestEventResult, error) {
+	in.log.setItem(warnly.EnvelopeItemEvent, "")
+	start := time.Now()
 	event := warnly.EventBody{}
-	if err := json.Unmarshal([]byte(content), &event); err != nil {
-		return res, NewBadRequestError("invalid event body", err, "failed to unmarshal JSON payload")
+	err := json.Unmarshal(payload, &event)
+	in.log.addParse(start)
+	if err != nil {
+		return warnly.IngestEventResult{}, NewInvalidEventError("invalid event body", err, "failed to unmarshal JSON payload")
 	}
 	if event.EventID == "" {
 		id := uuid.New()
 		event.EventID = hex.EncodeToString(id[:])
 	}
+	in.log.setItem(warnly.EnvelopeItemEvent, event.EventID)
 
 	req := warnly.IngestRequest{
-		Event:      &event,
-		ProjectKey: pKey,
-		ProjectID:  projectID,
-		IP:         r.RemoteAddr,
+		Event:       &event,
+		RawEvent:    payload,
+		Attachments: attachments,
+		ProjectKey:  in.projectKey,
+		ProjectID:   in.projectID,
+		IP:          in.ip,
+		Payload:     in.payload,
+		Signature:   in.signature,
 	}
 
-	res, err = h.svc.IngestEvent(ctx, req)
+	start = time.Now()
+	res, err := h.svc.IngestEvent(ctx, req)
+	h.ingestDuration.Observe(time.Since(start).Seconds())
+	in.log.addStore(start)
 	if err != nil {
-		if errors.Is(err, warnly.ErrProjectNotFound) {
-			return res, NewBadRequestError("project not found", err, "invalid project identifier or key")
+		if errors.Is(err, warnly.ErrStoreEvent) {
+			h.storeErrors.Inc()
+			return res, NewStoreEventError(err)
 		}
-		return res, fmt.Errorf("ingest event: %w", err)
+		return res, ingestServiceError("ingest event", err)
+	}
+	if res.Deduplicated {
+		h.deduplicatedEvents.Inc()
+	}
+	if res.Filtered {
+		h.filteredEvents.Inc()
 	}
 
 	return res, nil
 }
 
+// EnvelopeSchema serves the schema of the accepted envelopes as JSON,
+// so that tooling of custom SDKs can validate payloads before sending them.
+func (h *EventHandler) EnvelopeSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(warnly.EnvelopeSchema()); err != nil {
+		h.logger.ErrorContext(r.Context(), "envelope schema: encode response", slog.Any("error", err))
+	}
+}
+
+// Batch ingestion limits.
+const (
+	// maxBatchSize is the largest batch of envelopes that can be ingested at once.
+	maxBatchSize int64 = 10 * 1024 * 1024 // 10MB
+	// maxBatchEnvelopes is the largest number of envelopes in a batch.
+	maxBatchEnvelopes = 100
+)
+
+// batchEnvelope is a line of a batch, the envelope along with the project it is sent to.
+type batchEnvelope struct {
+	Envelope  string `json:"envelope"`
+	SentryKey string `json:"sentry_key"`
+	// Signature is required for projects with signed ingestion, see warnly.IngestSignatureHeader.
+	Signature string `json:"signature"`
+	ProjectID int    `json:"project_id"`
+}
+
+// batchEnvelopeResult is the outcome of a batch envelope, either the event ID or the error.
+type batchEnvelopeResult struct {
+	Error *ingestErrorBody `json:"error,omitempty"`
+	ID    string           `json:"id,omitempty"`
+}
+
+// ingestBatchResponse summarizes a batch, results are in the order of the batch envelopes.
+type ingestBatchResponse struct {
+	Results  []batchEnvelopeResult `json:"results"`
+	Accepted int                   `json:"accepted"`
+	Failed   int                   `json:"failed"`
+}
+
+// IngestBatch ingests newline-delimited envelopes forwarded at once by a proxy.
+// Every line is a JSON object with the envelope and the project it is sent to,
+// envelopes are ingested independently so one bad envelope doesn't fail the others.
+func (h *EventHandler) IngestBatch(w http.ResponseWriter, r *http.Request) {
+	b, err := h.readIngestBody(r, maxBatchSize)
+	if err != nil {
+		h.writeIngestError(r.Context(), w, err)
+		return
+	}
+
+	lines := make([][]byte, 0, bytes.Count(b, []byte("\n"))+1)
+	for line := range bytes.SplitSeq(b, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		h.writeIngestError(r.Context(), w, NewInvalidEnvelopeError("empty batch", nil, "no envelopes provided"))
+		return
+	}
+	if len(lines) > maxBatchEnvelopes {
+		h.writeIngestError(r.Context(), w, NewSizeLimitError(fmt.Sprintf("max %d envelopes", maxBatchEnvelopes)))
+		return
+	}
+
+	source := remoteHost(r.RemoteAddr)
+	resp := ingestBatchResponse{Results: make([]batchEnvelopeResult, 0, len(lines))}
+	for _, line := range lines {
+		var (
+			res warnly.IngestEventResult
+			err error
+		)
+		if h.lockedOut(source) {
+			err = NewAuthLockoutError()
+		} else {
+			res, err = h.ingestBatchEnvelope(r, source, line)
+		}
+		if err != nil {
+			status, errResp := ingestErrorResponse(err)
+			h.logIngestError(r.Context(), "ingest batch error", err, status, errResp)
+			body := errResp.Error
+			resp.Results = append(resp.Results, batchEnvelopeResult{Error: &body})
+			resp.Failed++
+			continue
+		}
+		resp.Results = append(resp.Results, batchEnvelopeResult{ID: res.EventID})
+		resp.Accepted++
+	}
+
+	h.writeIngestResponse(r.Context(), w, http.StatusOK, resp)
+}
+
+// ingestBatchEnvelope ingests an envelope of the batch.
+func (h *EventHandler) ingestBatchEnvelope(r *http.Request, source string, line []byte) (warnly.IngestEventResult, error) {
+	be := batchEnvelope{}
+	if err := json.Unmarshal(line, &be); err != nil {
+		return warnly.IngestEventResult{}, NewInvalidEnvelopeError("invalid batch line", err, "batch line is not valid JSON")
+	}
+	if be.SentryKey == "" {
+		h.recordAuthFailure(codeInvalidDSN, source)
+		return warnly.IngestEventResult{}, NewInvalidDSNError()
+	}
+	if int64(len(be.Envelope)) > h.maxEnvelopeSize {
+		return warnly.IngestEventResult{}, NewSizeLimitError(fmt.Sprintf("max %d bytes", h.maxEnvelopeSize))
+	}
+
+	res, err := h.ingestEnvelope(r.Context(), &envelopeRequest{
+		payload:    []byte(be.Envelope),
+		projectKey: be.SentryKey,
+		ip:         r.RemoteAddr,
+		signature:  be.Signature,
+		projectID:  be.ProjectID,
+	})
+	if reason, ok := authFailureReason(err); ok {
+		h.recordAuthFailure(reason, source)
+	}
+
+	return res, err
+}
+
+// authFailureReason returns the error code of an ingest request rejected because of a wrong project, key or signature.
+// A project that isn't ready counts too, as it is only reported for the right key and is retried by SDKs.
+func authFailureReason(err error) (string, bool) {
+	var ingestErr *IngestError
+	if !errors.As(err, &ingestErr) {
+		return "", false
+	}
+	switch ingestErr.Code {
+	case codeInvalidDSN, codeProjectNotFound, codeProjectNotReady, codeInvalidSignature:
+		return ingestErr.Code, true
+	default:
+		return "", false
+	}
+}
+
+// recordAuthFailure counts the authentication failure of the source by its reason, one of the error codes.
+// Failures aren't counted per project, as the project is given by the client and would make the number
+// of label values unbounded.
+func (h *EventHandler) recordAuthFailure(reason, source string) {
+	h.authFailures.WithLabelValues(reason).Inc()
+
+	if h.lockouts == nil {
+		return
+	}
+	// the failures are counted for the window since the first one.
+	if err := h.lockouts.Add(source, 1, h.lockoutWindow); err == nil {
+		return
+	}
+	if _, err := h.lockouts.IncrementInt(source, 1); err != nil {
+		// the window has just passed.
+		h.lockouts.Set(source, 1, h.lockoutWindow)
+	}
+}
+
+// lockedOut reports whether the source failed to authenticate too many times within the lockout window.
+func (h *EventHandler) lockedOut(source string) bool {
+	if h.lockouts == nil {
+		return false
+	}
+	failures, found := h.lockouts.Get(source)
+	if !found {
+		return false
+	}
+	n, ok := failures.(int)
+	return ok && n >= h.lockoutThreshold
+}
+
+// remoteHost returns the host of the remote address, or the address itself when it has no port.
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// envelope holds the items of an ingested envelope.
+type envelope struct {
+	eventID       string
+	event         []byte
+	attachments   []warnly.Attachment
+	clientReports []warnly.ClientReport
+	// transactions is the number of transaction items, they are not stored.
+	transactions int
+}
+
+// parseEnvelope parses the envelope header followed by the event item and optional attachment items.
+// Attachments are read by their declared length since they may contain newlines,
+// while the event payload ends at the newline. An envelope needs an event, a transaction or a client report;
+// the event is nil for an envelope without one.
+func parseEnvelope(b []byte) (*envelope, error) {
+	newline := []byte("\n")
+
+	line, rest, ok := bytes.Cut(b, newline)
+	if !ok || len(rest) == 0 {
+		return nil, NewInvalidEnvelopeError("invalid event envelope", nil, "premature end of input: too few lines")
+	}
+	header := warnly.EnvelopeHeader{}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, NewInvalidEnvelopeError("invalid envelope header", nil, "envelope header is not valid JSON")
+	}
+
+	env := &envelope{eventID: header.EventID}
+	var unsupported string
+	for len(rest) > 0 {
+		var line []byte
+		line, rest, _ = bytes.Cut(rest, newline)
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		item := warnly.EnvelopeItemHeader{}
+		if err := json.Unmarshal(line, &item); err != nil {
+			return nil, NewInvalidEnvelopeError("invalid envelope item header", err, "item header is not valid JSON")
+		}
+
+		switch item.Type {
+		case warnly.EnvelopeItemEvent:
+			var payload []byte
+			payload, rest, _ = bytes.Cut(rest, newline)
+			if env.event == nil {
+				env.event = payload
+			}
+		case warnly.EnvelopeItemAttachment:
+			if item.Length < 0 {
+				return nil, NewInvalidEnvelopeError("invalid envelope item", nil,
+					fmt.Sprintf("attachment %q has a negative length", item.Filename))
+			}
+			if item.Length > warnly.MaxAttachmentSize {
+				return nil, NewSizeLimitError(
+					fmt.Sprintf("attachment %q is larger than %d bytes", item.Filename, warnly.MaxAttachmentSize))
+			}
+			if item.Length > len(rest) {
+				return nil, NewInvalidEnvelopeError("invalid envelope item", nil,
+					fmt.Sprintf("attachment %q is shorter than its declared length", item.Filename))
+			}
+			contentType := item.ContentType
+			if contentType == "" {
+				contentType = warnly.DefaultAttachmentContentType
+			}
+			env.attachments = append(env.attachments, warnly.Attachment{
+				Filename:    item.Filename,
+				ContentType: contentType,
+				Data:        rest[:item.Length],
+				Size:        item.Length,
+			})
+			rest = bytes.TrimPrefix(rest[item.Length:], newline)
+		case warnly.EnvelopeItemTransaction:
+			env.transactions++
+			var err error
+			if _, rest, err = cutItemPayload(rest, item); err != nil {
+				return nil, err
+			}
+		case warnly.EnvelopeItemClientReport:
+			var (
+				payload []byte
+				err     error
+			)
+			if payload, rest, err = cutItemPayload(rest, item); err != nil {
+				return nil, err
+			}
+			report := warnly.ClientReport{}
+			if err := json.Unmarshal(payload, &report); err != nil {
+				return nil, NewInvalidEnvelopeError("invalid client report", err, "client report is not valid JSON")
+			}
+			env.clientReports = append(env.clientReports, report)
+		default:
+			// Items that can't be ingested, e.g. sessions, are skipped.
+			if unsupported == "" {
+				unsupported = item.Type
+			}
+			var err error
+			if _, rest, err = cutItemPayload(rest, item); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if env.event == nil && env.transactions == 0 && len(env.clientReports) == 0 {
+		if unsupported != "" {
+			return nil, NewUnsupportedTypeError(unsupported)
+		}
+		return nil, NewInvalidEnvelopeError("invalid event envelope", nil, "envelope has no event item")
+	}
+
+	return env, nil
+}
+
+// cutItemPayload returns the item payload of the declared length, or up to the newline
+// if the length is not declared, and the envelope remainder after it.
+// A negative length is an invalid envelope.
+func cutItemPayload(rest []byte, item warnly.EnvelopeItemHeader) (payload, remainder []byte, err error) {
+	if item.Length < 0 {
+		return nil, nil, NewInvalidEnvelopeError("invalid envelope item", nil,
+			fmt.Sprintf("%s item has a negative length", item.Type))
+	}
+	if item.Length > 0 && item.Length <= len(rest) {
+		return rest[:item.Length], bytes.TrimPrefix(rest[item.Length:], []byte("\n")), nil
+	}
+	payload, remainder, _ = bytes.Cut(rest, []byte("\n"))
+	return payload, remainder, nil
+}
+
 func projectKey(xHeaderAuth string) (string, error) {
 	var projectKey string
 
@@ -1,7 +1,14 @@
 package server
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
+
+	"github.com/vk-rv/warnly/internal/warnly"
 )
 
 func TestProjectKey(t *testing.T) {
@@ -71,3 +78,91 @@ func TestProjectKey(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEnvelope(t *testing.T) {
+	t.Parallel()
+
+	screenshot := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+	logFile := []byte("line 1\nline 2\n")
+
+	var b bytes.Buffer
+	b.WriteString(`{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc","dsn":"https://e12d836b15bb49d7bbf99e64295d995b@sentry.io/42"}` + "\n")
+	b.WriteString(`{"type":"event","length":65}` + "\n")
+	b.WriteString(`{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc","message":"hello"}` + "\n")
+	b.WriteString(`{"type":"attachment","length":8,"filename":"screenshot.png","content_type":"image/png"}` + "\n")
+	b.Write(screenshot)
+	b.WriteString("\n")
+	b.WriteString(`{"type":"attachment","length":14,"filename":"app.log"}` + "\n")
+	b.Write(logFile)
+
+	env, err := parseEnvelope(b.Bytes())
+	if err != nil {
+		t.Fatalf("parseEnvelope() error = %v", err)
+	}
+
+	if got, want := string(env.event), `{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc","message":"hello"}`; got != want {
+		t.Errorf("event = %s, want %s", got, want)
+	}
+
+	want := []warnly.Attachment{
+		{Filename: "screenshot.png", ContentType: "image/png", Data: screenshot, Size: len(screenshot)},
+		{Filename: "app.log", ContentType: warnly.DefaultAttachmentContentType, Data: logFile, Size: len(logFile)},
+	}
+	if !reflect.DeepEqual(env.attachments, want) {
+		t.Errorf("attachments = %+v, want %+v", env.attachments, want)
+	}
+}
+
+func TestParseEnvelopeErrors(t *testing.T) {
+	t.Parallel()
+
+	const header = `{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc"}` + "\n"
+	const event = `{"type":"event"}` + "\n" + `{"message":"hello"}` + "\n"
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "attachment too large",
+			body:       header + event + fmt.Sprintf(`{"type":"attachment","length":%d,"filename":"core"}`, warnly.MaxAttachmentSize+1) + "\n",
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "attachment shorter than its length",
+			body:       header + event + `{"type":"attachment","length":10,"filename":"app.log"}` + "\n" + "short",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "attachment with a negative length",
+			body:       header + event + `{"type":"attachment","length":-1,"filename":"app.log"}` + "\n" + "hello",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "session with a negative length",
+			body:       header + event + `{"type":"session","length":-1}` + "\n" + `{"sid":"1"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "attachments without event",
+			body:       header + `{"type":"attachment","length":5,"filename":"app.log"}` + "\n" + "hello",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseEnvelope([]byte(tt.body))
+			var ingestErr *IngestError
+			if !errors.As(err, &ingestErr) {
+				t.Fatalf("parseEnvelope() error = %v, want IngestError", err)
+			}
+			if ingestErr.Status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", ingestErr.Status, tt.wantStatus)
+			}
+		})
+	}
+}
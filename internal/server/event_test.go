@@ -1,15 +1,26 @@
 package server_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
 	"github.com/vk-rv/warnly/internal/server"
+	"github.com/vk-rv/warnly/internal/stdlog"
 	"github.com/vk-rv/warnly/internal/svc/event"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
@@ -64,9 +75,12 @@ func TestServer_HandleEventIngestion(t *testing.T) {
 			event.Queue{
 				Enabled: false,
 			},
+			nil,
+			nil,
 			nowTime,
+			logger,
 		)
-		eventHandler := server.NewEventAPIHandler(svc, logger)
+		eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
 
 		w, r := getIngestRequest(ctx, body)
 
@@ -106,17 +120,22 @@ func TestServer_HandleEventIngestion(t *testing.T) {
 			event.Queue{
 				Enabled: false,
 			},
+			nil,
+			nil,
 			nowTime,
+			logger,
 		)
-		eventHandler := server.NewEventAPIHandler(svc, logger)
+		eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
 
 		w, r := getIngestRequest(ctx, body)
 		r.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=sentry.go/0.30.0, sentry_key=invalidkey")
 
 		eventHandler.IngestEvent(w, r)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.JSONEq(t, `{"detail":"project not found","causes":["invalid project identifier or key"]}`, w.Body.String())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t,
+			`{"error":{"code":"project_not_found","detail":"project not found","causes":["invalid project identifier or key"]}}`,
+			w.Body.String())
 	})
 
 	t.Run("event ingestion with error problem from event service", func(t *testing.T) {
@@ -127,7 +146,7 @@ func TestServer_HandleEventIngestion(t *testing.T) {
 		logger, _ := getTestLogger()
 
 		svc := NewTestEventService(assert.AnError)
-		eventHandler := server.NewEventAPIHandler(svc, logger)
+		eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
 
 		w, r := getIngestRequest(ctx, body)
 
@@ -135,18 +154,134 @@ func TestServer_HandleEventIngestion(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		var resp struct {
-			Detail string `json:"detail"`
-			//nolint:tagliatelle // keep ErrorID as is for backward compatibility
-			ErrorID string `json:"errorId"`
+			Error struct {
+				Code   string `json:"code"`
+				Detail string `json:"detail"`
+				//nolint:tagliatelle // keep ErrorID as is for backward compatibility
+				ErrorID string `json:"errorId"`
+			} `json:"error"`
 		}
 		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-		assert.Equal(t, "Internal Error", resp.Detail)
-		require.NoError(t, warnly.ValidateNanoID("errorId", resp.ErrorID))
+		assert.Equal(t, "internal_error", resp.Error.Code)
+		assert.Equal(t, "Internal Error", resp.Error.Detail)
+		require.NoError(t, warnly.ValidateNanoID("errorId", resp.Error.ErrorID))
 	})
 }
 
+func TestServer_HandleEventIngestionKeyInQuery(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(nil)
+	eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+	w, r := getIngestRequest(t.Context(), body)
+	r.Header.Del("X-Sentry-Auth")
+	r.URL.RawQuery = "sentry_version=7&sentry_key=" + testProjectKey
+
+	eventHandler.IngestEvent(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, svc.ingested, 1)
+	assert.Equal(t, testProjectKey, svc.ingested[0].ProjectKey)
+}
+
+func TestServer_HandleEventIngestionErrorEnvelope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		svcErr     error
+		setup      func(r *http.Request)
+		name       string
+		wantCode   string
+		body       []byte
+		wantStatus int
+	}{
+		{
+			name:       "truncated envelope",
+			body:       bytes.SplitAfter(body, []byte("\n"))[0],
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_envelope",
+		},
+		{
+			name:       "nonexistent project",
+			body:       body,
+			svcErr:     warnly.ErrProjectNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "project_not_found",
+		},
+		{
+			name: "bad DSN",
+			body: body,
+			setup: func(r *http.Request) {
+				r.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=sentry.go/0.30.0")
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "invalid_dsn",
+		},
+		{
+			name:       "malformed envelope header",
+			body:       append([]byte("not json\n"), bytes.SplitAfterN(body, []byte("\n"), 2)[1]...),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_envelope",
+		},
+		{
+			name:       "oversized payload",
+			body:       bytes.Repeat([]byte("a"), int(server.DefaultMaxEnvelopeSize)+1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "payload_too_large",
+		},
+		{
+			name:       "unsupported event type",
+			body:       bytes.Replace(body, []byte(`{"type":"event"`), []byte(`{"type":"profile"`), 1),
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantCode:   "unsupported_type",
+		},
+		{
+			name:       "invalid signature",
+			body:       body,
+			svcErr:     warnly.ErrInvalidSignature,
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "invalid_signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, _ := getTestLogger()
+			eventHandler := server.NewEventAPIHandler(NewTestEventService(tt.svcErr), prometheus.NewRegistry(), logger)
+
+			w, r := getIngestRequest(t.Context(), tt.body)
+			if tt.setup != nil {
+				tt.setup(r)
+			}
+
+			eventHandler.IngestEvent(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			var resp struct {
+				Error struct {
+					Code   string `json:"code"`
+					Detail string `json:"detail"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			assert.NotEmpty(t, resp.Error.Detail)
+		})
+	}
+}
+
 type testEventService struct {
-	err error
+	err           error
+	ingested      []warnly.IngestRequest
+	authenticated []warnly.IngestRequest
+	reports       []warnly.ClientReportsRequest
+	deduplicated  bool
+	filtered      bool
 }
 
 func NewTestEventService(err error) *testEventService {
@@ -154,7 +289,776 @@ func NewTestEventService(err error) *testEventService {
 }
 
 func (s *testEventService) IngestEvent(ctx context.Context, req warnly.IngestRequest) (warnly.IngestEventResult, error) {
-	return warnly.IngestEventResult{}, s.err
+	s.ingested = append(s.ingested, req)
+	return warnly.IngestEventResult{EventID: req.Event.EventID, Deduplicated: s.deduplicated, Filtered: s.filtered}, s.err
+}
+
+func (s *testEventService) AuthenticateIngest(ctx context.Context, req warnly.IngestRequest) error {
+	s.authenticated = append(s.authenticated, req)
+	return s.err
+}
+
+func (s *testEventService) RecordClientReports(ctx context.Context, req *warnly.ClientReportsRequest) error {
+	s.reports = append(s.reports, *req)
+	return s.err
+}
+
+func TestServer_HandleEventIngestionClientReport(t *testing.T) {
+	t.Parallel()
+
+	const envelope = `{"sent_at":"2025-10-04T02:33:58.305163+03:00"}` + "\n" +
+		`{"type":"client_report"}` + "\n" +
+		`{"timestamp":1759534438.3,"discarded_events":[{"reason":"sample_rate","category":"error","quantity":3},` +
+		`{"reason":"ratelimit_backoff","category":"transaction","quantity":1}]}` + "\n"
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(nil)
+	eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+	w, r := getIngestRequest(t.Context(), []byte(envelope))
+
+	eventHandler.IngestEvent(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, svc.ingested)
+	require.Len(t, svc.reports, 1)
+	assert.Equal(t, 1, svc.reports[0].ProjectID)
+	assert.Equal(t, []warnly.ClientReport{{DiscardedEvents: []warnly.DiscardedEvents{
+		{Reason: "sample_rate", Category: "error", Quantity: 3},
+		{Reason: "ratelimit_backoff", Category: "transaction", Quantity: 1},
+	}}}, svc.reports[0].Reports)
+}
+
+func TestServer_HandleEventIngestionDropsTransactions(t *testing.T) {
+	t.Parallel()
+
+	const header = `{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc","sent_at":"2025-10-04T02:33:58.305163+03:00"}` + "\n"
+	const transaction = `{"type":"transaction","length":101}` + "\n" +
+		`{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc","type":"transaction","transaction":"GET /","spans":[]}` + "\n"
+	const event = `{"type":"event"}` + "\n" + `{"event_id":"3708a788c39c44508a3c9442214b2f9f","message":"hello"}` + "\n"
+
+	tests := []struct {
+		name              string
+		body              string
+		wantID            string
+		wantIngested      int
+		wantAuthenticated int
+	}{
+		{
+			name:         "event along with a transaction is stored",
+			body:         header + transaction + event,
+			wantID:       "3708a788c39c44508a3c9442214b2f9f",
+			wantIngested: 1,
+		},
+		{
+			name:              "transaction only",
+			body:              header + transaction,
+			wantID:            "9ec79c33ec9942ab8353589fcb2e04dc",
+			wantIngested:      0,
+			wantAuthenticated: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, _ := getTestLogger()
+			svc := NewTestEventService(nil)
+			registry := prometheus.NewRegistry()
+			eventHandler := server.NewEventAPIHandler(svc, registry, logger)
+
+			w, r := getIngestRequest(t.Context(), []byte(tt.body))
+
+			eventHandler.IngestEvent(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, `{"id":"`+tt.wantID+`"}`, w.Body.String())
+			require.Len(t, svc.ingested, tt.wantIngested)
+			if tt.wantIngested > 0 {
+				assert.Equal(t, "hello", svc.ingested[0].Event.Message)
+			}
+			assert.Len(t, svc.authenticated, tt.wantAuthenticated)
+
+			const want = `
+# HELP warnly_dropped_transactions_total Total number of ingested transaction envelope items that were dropped.
+# TYPE warnly_dropped_transactions_total counter
+warnly_dropped_transactions_total 1
+`
+			require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(want), "warnly_dropped_transactions_total"))
+		})
+	}
+}
+
+func TestServer_HandleEventIngestionRejectsUnauthenticatedTransactions(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc"}` + "\n" +
+		`{"type":"transaction"}` + "\n" +
+		`{"event_id":"9ec79c33ec9942ab8353589fcb2e04dc","type":"transaction","transaction":"GET /","spans":[]}` + "\n"
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(warnly.ErrProjectNotFound)
+	registry := prometheus.NewRegistry()
+	eventHandler := server.NewEventAPIHandler(svc, registry, logger)
+
+	w, r := getIngestRequest(t.Context(), []byte(body))
+
+	eventHandler.IngestEvent(w, r)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	require.Len(t, svc.authenticated, 1)
+	assert.Equal(t, 1, svc.authenticated[0].ProjectID)
+
+	const want = `
+# HELP warnly_dropped_transactions_total Total number of ingested transaction envelope items that were dropped.
+# TYPE warnly_dropped_transactions_total counter
+warnly_dropped_transactions_total 0
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(want), "warnly_dropped_transactions_total"))
+}
+
+func TestServer_HandleEventIngestionCountsDuplicates(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"event_id":"3708a788c39c44508a3c9442214b2f9f"}` + "\n" +
+		`{"type":"event"}` + "\n" + `{"event_id":"3708a788c39c44508a3c9442214b2f9f","message":"hello"}` + "\n"
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(nil)
+	svc.deduplicated = true
+	registry := prometheus.NewRegistry()
+	eventHandler := server.NewEventAPIHandler(svc, registry, logger)
+
+	w, r := getIngestRequest(t.Context(), []byte(body))
+
+	eventHandler.IngestEvent(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	const want = `
+# HELP warnly_ingest_deduplicated_events_total Total number of ingested events that were not stored as duplicates of recent events.
+# TYPE warnly_ingest_deduplicated_events_total counter
+warnly_ingest_deduplicated_events_total 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(want), "warnly_ingest_deduplicated_events_total"))
+}
+
+func TestServer_HandleEventIngestionCountsFiltered(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"event_id":"4708a788c39c44508a3c9442214b2f9f"}` + "\n" +
+		`{"type":"event"}` + "\n" + `{"event_id":"4708a788c39c44508a3c9442214b2f9f","message":"hello"}` + "\n"
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(nil)
+	svc.filtered = true
+	registry := prometheus.NewRegistry()
+	eventHandler := server.NewEventAPIHandler(svc, registry, logger)
+
+	w, r := getIngestRequest(t.Context(), []byte(body))
+
+	eventHandler.IngestEvent(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	const want = `
+# HELP warnly_ingest_filtered_events_total Total number of ingested events that were dropped by ingest or noise filters.
+# TYPE warnly_ingest_filtered_events_total counter
+warnly_ingest_filtered_events_total 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(want), "warnly_ingest_filtered_events_total"))
+}
+
+func TestServer_HandleEventIngestionStoreError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		storeErr   error
+		wantStatus int
+		wantErrors string
+	}{
+		{name: "stored", wantStatus: http.StatusOK, wantErrors: "0"},
+		{name: "store failure", storeErr: assert.AnError, wantStatus: http.StatusServiceUnavailable, wantErrors: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+					issue.ID = 1
+					return nil
+				},
+			}
+			stored := 0
+			storeEvent := func(_ context.Context, _ *warnly.EventClickhouse) error {
+				stored++
+				return tt.storeErr
+			}
+			analyticsStore := &mock.AnalyticsStore{StoreEventFn: storeEvent, StoreEventSyncFn: storeEvent}
+
+			logger, _ := getTestLogger()
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, nowTime, logger)
+			svc.ConfirmStores(event.Confirmation{NewIssues: true})
+			registry := prometheus.NewRegistry()
+			eventHandler := server.NewEventAPIHandler(svc, registry, logger)
+
+			w, r := getIngestRequest(t.Context(), body)
+
+			eventHandler.IngestEvent(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, 1, stored)
+			if tt.storeErr != nil {
+				var resp struct {
+					Error struct {
+						Code string `json:"code"`
+					} `json:"error"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "store_unavailable", resp.Error.Code)
+			}
+
+			want := `
+# HELP warnly_ingest_store_errors_total Total number of ingested events that could not be stored.
+# TYPE warnly_ingest_store_errors_total counter
+warnly_ingest_store_errors_total ` + tt.wantErrors + `
+`
+			require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(want), "warnly_ingest_store_errors_total"))
+		})
+	}
+}
+
+// producerFunc produces the records with the function.
+type producerFunc func(ctx context.Context, rs ...warnly.Record) error
+
+func (f producerFunc) Produce(ctx context.Context, rs ...warnly.Record) error { return f(ctx, rs...) }
+
+func (producerFunc) Healthy(context.Context) error { return nil }
+
+func (producerFunc) Close() error { return nil }
+
+func TestServer_HandleEventIngestionQueued(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		queue      bool
+		wantStatus int
+	}{
+		{name: "stored", wantStatus: http.StatusOK},
+		{name: "queued", queue: true, wantStatus: http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+					issue.ID = 1
+					return nil
+				},
+			}
+			stored := 0
+			storeEvent := func(_ context.Context, _ *warnly.EventClickhouse) error {
+				stored++
+				return nil
+			}
+			analyticsStore := &mock.AnalyticsStore{StoreEventFn: storeEvent, StoreEventSyncFn: storeEvent}
+			var produced []warnly.Record
+			queue := event.Queue{
+				Enabled: tt.queue,
+				Producer: producerFunc(func(_ context.Context, rs ...warnly.Record) error {
+					produced = append(produced, rs...)
+					return nil
+				}),
+			}
+
+			logger, _ := getTestLogger()
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, queue, nil, nil, nowTime, logger)
+			eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+			w, r := getIngestRequest(t.Context(), body)
+
+			eventHandler.IngestEvent(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, `{"id":"3708a788c39c44508a3c9442214b2f9f"}`, w.Body.String())
+			if tt.queue {
+				assert.Len(t, produced, 1)
+				assert.Zero(t, stored)
+			} else {
+				assert.Empty(t, produced)
+				assert.Equal(t, 1, stored)
+			}
+		})
+	}
+}
+
+func TestServer_HandleEventIngestionNewProject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		createdAt      time.Time
+		wantStatus     int
+		wantRetryAfter string
+		wantCode       string
+	}{
+		{
+			name:           "created moments ago",
+			createdAt:      nowTime().Add(-5 * time.Second),
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: "5",
+			wantCode:       "project_not_ready",
+		},
+		{
+			name:       "created long ago",
+			createdAt:  nowTime().Add(-time.Hour),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "project_not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, _ int, _ string) (*warnly.ProjectOptions, error) {
+					return nil, warnly.ErrProjectNotFound
+				},
+				GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+					return &warnly.Project{ID: projectID, CreatedAt: tt.createdAt, Key: testProjectKey}, nil
+				},
+			}
+
+			logger, _ := getTestLogger()
+			svc := event.NewEventService(projectStore, &mock.IssueStore{}, cache.New(time.Minute, time.Minute),
+				&mock.AnalyticsStore{}, event.Queue{}, nil, nil, nowTime, logger)
+			svc.RejectUnknownProjects(event.UnknownProjects{CacheTTL: time.Minute, Grace: time.Minute})
+			reg := prometheus.NewRegistry()
+			eventHandler := server.NewEventAPIHandler(svc, reg, logger)
+
+			w, r := getIngestRequest(t.Context(), body)
+
+			eventHandler.IngestEvent(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantRetryAfter, w.Header().Get("Retry-After"))
+			require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP warnly_ingest_auth_failures_total Total number of ingest requests rejected because of a wrong project, key or signature.
+# TYPE warnly_ingest_auth_failures_total counter
+warnly_ingest_auth_failures_total{reason="`+tt.wantCode+`"} 1
+`), "warnly_ingest_auth_failures_total"))
+			var resp struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+		})
+	}
+}
+
+func TestServer_HandleEventIngestionIdempotent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		storeErrs  []error
+		wantStatus []int
+		wantStored int
+	}{
+		{
+			name:       "retry of a stored event",
+			storeErrs:  []error{nil, nil},
+			wantStatus: []int{http.StatusOK, http.StatusOK},
+			wantStored: 1,
+		},
+		{
+			name:       "retry of a failed event",
+			storeErrs:  []error{assert.AnError, nil},
+			wantStatus: []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStored: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+					issue.ID = 1
+					return nil
+				},
+				UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+			}
+			stored := 0
+			storeEvent := func(_ context.Context, _ *warnly.EventClickhouse) error {
+				err := tt.storeErrs[stored]
+				stored++
+				return err
+			}
+			analyticsStore := &mock.AnalyticsStore{StoreEventFn: storeEvent, StoreEventSyncFn: storeEvent}
+
+			logger, _ := getTestLogger()
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, nowTime, logger)
+			svc.ConfirmStores(event.Confirmation{NewIssues: true})
+			svc.DedupEventIDs(event.Idempotency{Window: time.Minute})
+			eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+			for _, status := range tt.wantStatus {
+				w, r := getIngestRequest(t.Context(), body)
+				eventHandler.IngestEvent(w, r)
+				assert.Equal(t, status, w.Code)
+			}
+
+			assert.Equal(t, tt.wantStored, stored)
+		})
+	}
+}
+
+func TestServer_HandleEventIngestionDuration(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := getTestLogger()
+	registry := prometheus.NewRegistry()
+	eventHandler := server.NewEventAPIHandler(NewTestEventService(nil), registry, logger)
+
+	w, r := getIngestRequest(t.Context(), body)
+
+	eventHandler.IngestEvent(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	var found bool
+	for _, f := range families {
+		if f.GetName() != "warnly_ingest_duration_seconds" {
+			continue
+		}
+		found = true
+		require.Len(t, f.GetMetric(), 1)
+		assert.Equal(t, uint64(1), f.GetMetric()[0].GetHistogram().GetSampleCount())
+	}
+	assert.True(t, found, "warnly_ingest_duration_seconds is not registered")
+}
+
+func TestServer_HandleEventIngestionSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	gzipped := func(t *testing.T, b []byte) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write(b)
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	deflated := func(t *testing.T, b []byte) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, err := zw.Write(b)
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	base64Deflated := func(t *testing.T, b []byte) []byte {
+		t.Helper()
+		return []byte(base64.StdEncoding.EncodeToString(deflated(t, b)))
+	}
+
+	limit := int64(len(body))
+	// highly compressible, the compressed body is far below the limit.
+	bomb := append(bytes.Clone(body), bytes.Repeat([]byte(" "), 1024*1024)...)
+
+	tests := []struct {
+		name       string
+		body       []byte
+		encoding   string
+		wantCode   string
+		wantStatus int
+	}{
+		{
+			name:       "payload of the limit",
+			body:       body,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "oversized payload",
+			body:       append(bytes.Clone(body), '\n'),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "payload_too_large",
+		},
+		{
+			name:       "gzip payload",
+			body:       gzipped(t, body),
+			encoding:   "gzip",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "gzip payload oversized after decompression",
+			body:       gzipped(t, bomb),
+			encoding:   "gzip",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "payload_too_large",
+		},
+		{
+			name:       "invalid gzip payload",
+			body:       body,
+			encoding:   "gzip",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "bad_request",
+		},
+		{
+			name:       "deflate payload",
+			body:       deflated(t, body),
+			encoding:   "deflate",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "deflate payload oversized after decompression",
+			body:       deflated(t, bomb),
+			encoding:   "deflate",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "payload_too_large",
+		},
+		{
+			name:       "invalid deflate payload",
+			body:       body,
+			encoding:   "deflate",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "bad_request",
+		},
+		{
+			name:       "base64 zlib payload",
+			body:       base64Deflated(t, body),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "base64 zlib payload oversized after decompression",
+			body:       base64Deflated(t, bomb),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "payload_too_large",
+		},
+		{
+			name:       "base64 payload that isn't zlib",
+			body:       []byte(base64.StdEncoding.EncodeToString([]byte("not zlib"))),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "bad_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, _ := getTestLogger()
+			svc := NewTestEventService(nil)
+			eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+			eventHandler.SetMaxEnvelopeSize(limit)
+
+			w, r := getIngestRequest(t.Context(), tt.body)
+			if tt.encoding != "" {
+				r.Header.Set("Content-Encoding", tt.encoding)
+			}
+
+			eventHandler.IngestEvent(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				require.Len(t, svc.ingested, 1)
+				assert.Equal(t, "3708a788c39c44508a3c9442214b2f9f", svc.ingested[0].Event.EventID)
+				return
+			}
+			var resp struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			assert.Empty(t, svc.ingested)
+		})
+	}
+}
+
+func TestServer_HandleEventIngestionAuthLockout(t *testing.T) {
+	t.Parallel()
+
+	const window = 200 * time.Millisecond
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(warnly.ErrProjectNotFound)
+	reg := prometheus.NewRegistry()
+	eventHandler := server.NewEventAPIHandler(svc, reg, logger)
+	eventHandler.SetAuthLockout(3, window)
+
+	ingest := func(remoteAddr string) int {
+		w, r := getIngestRequest(t.Context(), body)
+		r.RemoteAddr = remoteAddr
+		eventHandler.IngestEvent(w, r)
+		return w.Code
+	}
+
+	for range 3 {
+		assert.Equal(t, http.StatusNotFound, ingest("192.0.2.1:1234"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, ingest("192.0.2.1:4321"), "the source is locked out regardless of the port")
+	assert.Len(t, svc.ingested, 3, "requests of a locked out source aren't authenticated")
+	assert.Equal(t, http.StatusNotFound, ingest("192.0.2.2:1234"), "other sources aren't locked out")
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP warnly_ingest_auth_failures_total Total number of ingest requests rejected because of a wrong project, key or signature.
+# TYPE warnly_ingest_auth_failures_total counter
+warnly_ingest_auth_failures_total{reason="project_not_found"} 4
+`), "warnly_ingest_auth_failures_total"))
+
+	time.Sleep(window + 50*time.Millisecond)
+
+	svc.err = nil
+	assert.Equal(t, http.StatusOK, ingest("192.0.2.1:1234"), "the lockout ends with the window")
+}
+
+func TestServer_HandleEventIngestionBatch(t *testing.T) {
+	t.Parallel()
+
+	envelope := func(eventID, message string) string {
+		return `{"event_id":"` + eventID + `"}` + "\n" +
+			`{"type":"event"}` + "\n" +
+			`{"event_id":"` + eventID + `","message":"` + message + `"}` + "\n"
+	}
+	line := func(env string) string {
+		b, err := json.Marshal(map[string]any{"project_id": 1, "sentry_key": testProjectKey, "envelope": env})
+		require.NoError(t, err)
+		return string(b) + "\n"
+	}
+
+	batch := line(envelope("3708a788c39c44508a3c9442214b2f9f", "first")) +
+		line("not an envelope") +
+		line(envelope("9ec79c33ec9942ab8353589fcb2e04dc", "third"))
+
+	logger, _ := getTestLogger()
+	svc := NewTestEventService(nil)
+	eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+	w, r := getIngestRequest(t.Context(), []byte(batch))
+
+	eventHandler.IngestBatch(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	require.Len(t, svc.ingested, 2)
+	assert.Equal(t, "first", svc.ingested[0].Event.Message)
+	assert.Equal(t, "third", svc.ingested[1].Event.Message)
+	assert.Equal(t, testProjectKey, svc.ingested[1].ProjectKey)
+	assert.Equal(t, 1, svc.ingested[1].ProjectID)
+
+	var resp struct {
+		Results []struct {
+			Error *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+			ID string `json:"id"`
+		} `json:"results"`
+		Accepted int `json:"accepted"`
+		Failed   int `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Accepted)
+	assert.Equal(t, 1, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, "3708a788c39c44508a3c9442214b2f9f", resp.Results[0].ID)
+	assert.Nil(t, resp.Results[0].Error)
+	assert.Empty(t, resp.Results[1].ID)
+	require.NotNil(t, resp.Results[1].Error)
+	assert.Equal(t, "invalid_envelope", resp.Results[1].Error.Code)
+	assert.Equal(t, "9ec79c33ec9942ab8353589fcb2e04dc", resp.Results[2].ID)
+	assert.Nil(t, resp.Results[2].Error)
+}
+
+func TestServer_HandleEventIngestionBatchErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   string
+		wantStatus int
+	}{
+		{
+			name:       "empty batch",
+			body:       "\n\n",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_envelope",
+		},
+		{
+			name:       "too many envelopes",
+			body:       strings.Repeat(`{"project_id":1,"sentry_key":"k","envelope":""}`+"\n", 101),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "payload_too_large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, _ := getTestLogger()
+			svc := NewTestEventService(nil)
+			eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+			w, r := getIngestRequest(t.Context(), []byte(tt.body))
+
+			eventHandler.IngestBatch(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var resp struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			assert.Empty(t, svc.ingested)
+		})
+	}
 }
 
 func TestIngestErrors(t *testing.T) {
@@ -186,7 +1090,8 @@ func TestIngestErrors(t *testing.T) {
 	t.Run("NewSizeLimitError", func(t *testing.T) {
 		t.Parallel()
 		err := server.NewSizeLimitError("too large")
-		assert.Equal(t, http.StatusBadRequest, err.Status)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, err.Status)
+		assert.Equal(t, "payload_too_large", err.Code)
 		assert.Equal(t, "envelope exceeded size limits", err.Detail)
 		assert.Equal(t, []string{"too large"}, err.Causes)
 		require.NoError(t, err.WrappedError)
@@ -196,7 +1101,8 @@ func TestIngestErrors(t *testing.T) {
 	t.Run("NewInvalidDSNError", func(t *testing.T) {
 		t.Parallel()
 		err := server.NewInvalidDSNError()
-		assert.Equal(t, http.StatusBadRequest, err.Status)
+		assert.Equal(t, http.StatusUnauthorized, err.Status)
+		assert.Equal(t, "invalid_dsn", err.Code)
 		assert.Equal(t, "invalid DSN or project key.", err.Detail)
 		assert.Empty(t, err.Causes)
 		require.NoError(t, err.WrappedError)
@@ -218,3 +1124,144 @@ func TestIngestErrors(t *testing.T) {
 		require.NoError(t, unwrapped)
 	})
 }
+
+func TestServer_HandleStoreEvent(t *testing.T) {
+	t.Parallel()
+
+	storeBody := bytes.Split(body, []byte("\n"))[2]
+
+	t.Run("legacy store payload", func(t *testing.T) {
+		t.Parallel()
+
+		logger, _ := getTestLogger()
+		svc := NewTestEventService(nil)
+		eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+		w, r := getIngestRequest(t.Context(), storeBody)
+
+		eventHandler.StoreEvent(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":"3708a788c39c44508a3c9442214b2f9f"}`, w.Body.String())
+		require.Len(t, svc.ingested, 1)
+		assert.Equal(t, testProjectKey, svc.ingested[0].ProjectKey)
+		assert.Equal(t, "3708a788c39c44508a3c9442214b2f9f", svc.ingested[0].Event.EventID)
+		assert.Empty(t, svc.ingested[0].Attachments)
+	})
+
+	t.Run("store and envelope payloads group into one issue", func(t *testing.T) {
+		t.Parallel()
+
+		var stored *warnly.Issue
+		issueStore := &mock.IssueStore{
+			GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+				if stored == nil || stored.Hash != criteria.Hash {
+					return nil, warnly.ErrNotFound
+				}
+				return stored, nil
+			},
+			StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+				require.Nil(t, stored, "the envelope event opened a second issue")
+				issue.ID = 1
+				stored = issue
+				return nil
+			},
+			UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error {
+				return nil
+			},
+		}
+		projectStore := &mock.ProjectStore{
+			GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+				return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+			},
+		}
+		var events []*warnly.EventClickhouse
+		storeEvent := func(_ context.Context, ev *warnly.EventClickhouse) error {
+			events = append(events, ev)
+			return nil
+		}
+		analyticsStore := &mock.AnalyticsStore{StoreEventFn: storeEvent, StoreEventSyncFn: storeEvent}
+
+		logger, _ := getTestLogger()
+		svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+			analyticsStore, event.Queue{}, nil, nil, nowTime, logger)
+		eventHandler := server.NewEventAPIHandler(svc, prometheus.NewRegistry(), logger)
+
+		w, r := getIngestRequest(t.Context(), storeBody)
+		eventHandler.StoreEvent(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		envelope := bytes.ReplaceAll(body, []byte("3708a788c39c44508a3c9442214b2f9f"), []byte("4819b899d40d55619d4a9553325c3f0a"))
+		w, r = getIngestRequest(t.Context(), envelope)
+		eventHandler.IngestEvent(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		require.NotNil(t, stored)
+		require.Len(t, events, 2)
+		assert.Equal(t, events[0].GroupID, events[1].GroupID)
+	})
+}
+
+func TestServer_HandleEventIngestionLog(t *testing.T) {
+	t.Parallel()
+
+	logLine := func(t *testing.T, buf *bytes.Buffer) map[string]any {
+		t.Helper()
+		var line map[string]any
+		for l := range bytes.SplitSeq(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+			entry := map[string]any{}
+			require.NoError(t, json.Unmarshal(l, &entry))
+			if entry["msg"] == "ingest" {
+				require.Nil(t, line, "a single line is logged per request")
+				line = entry
+			}
+		}
+		require.NotNil(t, line, "the ingest line is logged")
+		return line
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		eventHandler := server.NewEventAPIHandler(NewTestEventService(nil), prometheus.NewRegistry(), logger)
+
+		w, r := getIngestRequest(t.Context(), body)
+		eventHandler.IngestEvent(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		line := logLine(t, buf)
+		assert.Equal(t, "DEBUG", line["level"])
+		assert.InDelta(t, 1, line["project_id"], 0)
+		assert.Equal(t, "3708a788c39c44508a3c9442214b2f9f", line["event_id"])
+		assert.Equal(t, warnly.EnvelopeItemEvent, line["item_type"])
+		assert.InDelta(t, len(body), line["bytes"], 0)
+		assert.Contains(t, line, "parse_duration")
+		assert.Contains(t, line, "store_duration")
+		assert.Equal(t, "accepted", line["outcome"])
+		assert.InDelta(t, http.StatusOK, line["status"], 0)
+		assert.NotContains(t, line, "error")
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		logger := stdlog.NewSlogLogger(buf, false)
+		eventHandler := server.NewEventAPIHandler(NewTestEventService(nil), prometheus.NewRegistry(), logger)
+
+		w, r := getIngestRequest(stdlog.WithRequestID(t.Context(), "req-1"), []byte("{}\n"))
+		eventHandler.IngestEvent(w, r)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+
+		line := logLine(t, buf)
+		assert.Equal(t, "ERROR", line["level"])
+		assert.Equal(t, "req-1", line["request_id"], "the request ID of the middleware is reused")
+		assert.InDelta(t, 1, line["project_id"], 0)
+		assert.InDelta(t, 3, line["bytes"], 0)
+		assert.Equal(t, "invalid_envelope", line["outcome"])
+		assert.InDelta(t, http.StatusBadRequest, line["status"], 0)
+		assert.Contains(t, line["error"], "invalid event envelope")
+	})
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+	"github.com/vk-rv/warnly/internal/web"
+)
+
+const (
+	// msgInvitationUnavailable is displayed when the invitation link is malformed, expired or used.
+	msgInvitationUnavailable = "The invitation is invalid, expired or has already been used. Ask your team admin for a new one."
+	// msgInvitationPasswordTooShort is displayed when the chosen password is too short.
+	msgInvitationPasswordTooShort = "The password must be at least 8 characters long."
+	// msgInvitationUserExists is displayed when the invited email already has an account.
+	msgInvitationUserExists = "An account with this email already exists, sign in instead."
+)
+
+// inviteResponse is the response of the invitation endpoint.
+type inviteResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Link      string    `json:"link"`
+	Sent      bool      `json:"sent"`
+}
+
+// invite handles POST /teams/{team_id}/invitations, inviting a person to the team by email.
+func (h *rootHandler) invite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	teamID, err := strconv.Atoi(r.PathValue("team_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "invite: parse team ID", err)
+		return
+	}
+
+	result, err := h.svc.Invite(ctx, &warnly.InviteRequest{
+		User:   &user,
+		Email:  r.FormValue("email"),
+		Role:   warnly.Role(r.FormValue("role")),
+		TeamID: teamID,
+	})
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, warnly.ErrInvalidEmail), errors.Is(err, warnly.ErrInvalidRole):
+			code = http.StatusBadRequest
+		case errors.Is(err, warnly.ErrUserExists):
+			code = http.StatusConflict
+		case errors.Is(err, warnly.ErrPermissionDenied):
+			code = http.StatusForbidden
+		case errors.Is(err, warnly.ErrTeamNotFound):
+			code = http.StatusNotFound
+		}
+		h.writeError(ctx, w, code, "invite: invite", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(inviteResponse{
+		ExpiresAt: result.Invitation.ExpiresAt,
+		Email:     result.Invitation.Email,
+		Role:      string(result.Invitation.Role),
+		Link:      result.Link,
+		Sent:      result.Sent,
+	}); err != nil {
+		h.logger.Error("invite: encode response", slog.Any("error", err))
+	}
+}
+
+// getInvitation handles GET /invitations/{token}, rendering the sign up form of the invited person.
+func (h *rootHandler) getInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token := r.PathValue("token")
+
+	inv, err := h.svc.GetInvitation(ctx, token)
+	if err != nil {
+		h.writeInvitationError(w, r, token, "", err)
+		return
+	}
+
+	if err := web.AcceptInvitation(token, inv.Email, "").Render(ctx, w); err != nil {
+		h.logger.Error("get invitation: web render", slog.Any("error", err))
+	}
+}
+
+// acceptInvitation handles POST /invitations/{token}, creating the invited user and signing them in.
+func (h *rootHandler) acceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token := r.PathValue("token")
+
+	result, err := h.svc.AcceptInvitation(ctx, &warnly.AcceptInvitationRequest{
+		Token:    token,
+		Username: r.PostFormValue("username"),
+		Password: r.PostFormValue("password"),
+	})
+	if err != nil {
+		email := ""
+		if errors.Is(err, warnly.ErrPasswordTooShort) {
+			// the form is shown again, the invitation is still valid.
+			if inv, getErr := h.svc.GetInvitation(ctx, token); getErr == nil {
+				email = inv.Email
+			}
+		}
+		h.writeInvitationError(w, r, token, email, err)
+		return
+	}
+
+	if err := saveCookie(w, r, h.cookieStore, *result.User, false, h.rememberDays); err != nil {
+		h.writeError(ctx, w, http.StatusInternalServerError, "accept invitation: save cookie", err)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// writeInvitationError renders the invitation page with the message explaining the error.
+func (h *rootHandler) writeInvitationError(w http.ResponseWriter, r *http.Request, token, email string, err error) {
+	ctx := r.Context()
+
+	var code int
+	var msg string
+	switch {
+	case errors.Is(err, warnly.ErrInvalidInvitation),
+		errors.Is(err, warnly.ErrInvitationExpired),
+		errors.Is(err, warnly.ErrInvitationUsed):
+		code, msg = http.StatusNotFound, msgInvitationUnavailable
+	case errors.Is(err, warnly.ErrPasswordTooShort):
+		code, msg = http.StatusBadRequest, msgInvitationPasswordTooShort
+	case errors.Is(err, warnly.ErrUserExists):
+		code, msg = http.StatusConflict, msgInvitationUserExists
+	default:
+		h.writeError(ctx, w, http.StatusInternalServerError, "invitation", err)
+		return
+	}
+
+	h.logger.Error("invitation", slog.Any("error", err))
+	w.WriteHeader(code)
+	if err := web.AcceptInvitation(token, email, msg).Render(ctx, w); err != nil {
+		h.logger.Error("invitation: web render", slog.Any("error", err))
+	}
+}
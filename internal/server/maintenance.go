@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// maxMaintenanceModeSize is the maximum size of a maintenance mode request body in bytes.
+const maxMaintenanceModeSize = 1 << 10
+
+// maintenanceHandler reports and toggles the read-only maintenance mode.
+type maintenanceHandler struct {
+	*BaseHandler
+
+	svc    warnly.ProjectService
+	logger *slog.Logger
+}
+
+// newMaintenanceHandler is a constructor of a maintenance handler.
+func newMaintenanceHandler(svc warnly.ProjectService, logger *slog.Logger) *maintenanceHandler {
+	return &maintenanceHandler{BaseHandler: NewBaseHandler(logger), svc: svc, logger: logger}
+}
+
+// getReadOnly returns whether the read-only maintenance mode is on as JSON.
+func (h *maintenanceHandler) getReadOnly(w http.ResponseWriter, _ *http.Request) {
+	h.writeMode(w, "get read-only mode")
+}
+
+// setReadOnly turns the read-only maintenance mode on or off, ingest keeps running either way.
+// The mode is per process and held in memory, behind a load balancer every instance is toggled separately.
+func (h *maintenanceHandler) setReadOnly(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var mode warnly.MaintenanceMode
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxMaintenanceModeSize)).Decode(&mode); err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "set read-only mode: decode request", err)
+		return
+	}
+
+	h.svc.SetReadOnly(mode.ReadOnly)
+
+	user := getUser(ctx)
+	h.logger.Info("read-only mode changed",
+		slog.Bool("read_only", mode.ReadOnly),
+		slog.Int64("user_id", user.ID))
+
+	h.writeMode(w, "set read-only mode")
+}
+
+// writeMode writes the current maintenance mode as JSON.
+func (h *maintenanceHandler) writeMode(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(warnly.MaintenanceMode{ReadOnly: h.svc.ReadOnly()}); err != nil {
+		h.logger.Error(msg+": encode", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+type testMaintenanceService struct {
+	warnly.ProjectService
+
+	readOnly bool
+}
+
+func (s *testMaintenanceService) ReadOnly() bool { return s.readOnly }
+
+func (s *testMaintenanceService) SetReadOnly(readOnly bool) { s.readOnly = readOnly }
+
+func TestMaintenanceAPI(t *testing.T) {
+	t.Parallel()
+
+	svc := &testMaintenanceService{}
+	h := newMaintenanceHandler(svc, slog.Default())
+	admin := newAdminMW([]string{"admin@example.com"}, slog.Default())
+
+	do := func(method, body, email string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		ctx := NewContextWithUser(t.Context(), warnly.User{ID: 1, Email: email})
+		r := httptest.NewRequestWithContext(ctx, method, "/api/v1/system/read-only", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		admin.requireAdmin(handler)(w, r)
+		return w
+	}
+
+	w := do(http.MethodPut, `{"read_only":true}`, "admin@example.com", h.setReadOnly)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"read_only":true}`, w.Body.String())
+	assert.True(t, svc.readOnly)
+
+	w = do(http.MethodGet, "", "admin@example.com", h.getReadOnly)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"read_only":true}`, w.Body.String())
+
+	w = do(http.MethodPut, `{"read_only":false}`, "user@example.com", h.setReadOnly)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	assert.True(t, svc.readOnly)
+
+	w = do(http.MethodPut, `not json`, "admin@example.com", h.setReadOnly)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodPut, `{"read_only":false}`, "admin@example.com", h.setReadOnly)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, svc.readOnly)
+}
+
+func TestReadOnlyRejectsChanges(t *testing.T) {
+	t.Parallel()
+
+	svc := &testMaintenanceService{readOnly: true}
+	mw := newReadOnlyMW(svc, slog.Default())
+
+	served := 0
+	handler := mw.rejectChanges(func(w http.ResponseWriter, _ *http.Request) {
+		served++
+		w.WriteHeader(http.StatusNoContent)
+	})
+	do := func(method string) int {
+		r := httptest.NewRequestWithContext(t.Context(), method, "/projects/1/settings/sample-rate", http.NoBody)
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodGet))
+	assert.Equal(t, http.StatusServiceUnavailable, do(http.MethodPut))
+	assert.Equal(t, http.StatusServiceUnavailable, do(http.MethodPost))
+	assert.Equal(t, http.StatusServiceUnavailable, do(http.MethodDelete))
+	assert.Equal(t, 1, served)
+
+	svc.SetReadOnly(false)
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodPut))
+	assert.Equal(t, 2, served)
+}
@@ -2,15 +2,24 @@ package server
 
 import (
 	"bufio"
+	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vk-rv/warnly/internal/stdlog"
+	"github.com/vk-rv/warnly/internal/warnly"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // prometheusMW is a middleware for Prometheus metrics.
@@ -89,6 +98,165 @@ func (rec *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return hj.Hijack()
 }
 
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach the connection.
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+const (
+	// requestIDHeader carries the ID correlating a request with its logs.
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLen is the longest request ID accepted from a client.
+	maxRequestIDLen = 128
+)
+
+// requestID is a middleware tagging the request with the ID sent by the client or a new one,
+// the ID is echoed in the response and added to the records logged with the request context.
+func requestID(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = warnly.NewUUID().String()
+		}
+		w.Header().Set(requestIDHeader, id)
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("http.request.id", id))
+		handler.ServeHTTP(w, r.WithContext(stdlog.WithRequestID(r.Context(), id)))
+	}
+}
+
+// validRequestID reports whether the client request ID can be logged as it is.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := range len(id) {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// ingestCORSHeaders are the request headers SDKs running in browsers send to the ingest endpoints.
+const ingestCORSHeaders = "Content-Type, Content-Encoding, X-Sentry-Auth, " + warnly.IngestSignatureHeader
+
+// corsMW lets browsers send requests of other origins to the routes it wraps.
+type corsMW struct {
+	origins   []string
+	anyOrigin bool
+}
+
+// newCORSMW is a constructor of corsMW, "*" allows any origin and no origins disable cross-origin requests.
+func newCORSMW(origins []string) *corsMW {
+	mw := &corsMW{origins: make([]string, 0, len(origins))}
+	for _, origin := range origins {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		switch origin {
+		case "":
+		case "*":
+			mw.anyOrigin = true
+		default:
+			mw.origins = append(mw.origins, origin)
+		}
+	}
+	return mw
+}
+
+// allow sets the CORS headers of responses to requests of allowed origins.
+func (mw *corsMW) allow(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mw.allowOrigin(w, r)
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// preflight answers the CORS preflight requests of the wrapped routes.
+func (mw *corsMW) preflight(w http.ResponseWriter, r *http.Request) {
+	if !mw.allowOrigin(w, r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", ingestCORSHeaders)
+	w.Header().Set("Access-Control-Max-Age", "86400")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowOrigin sets the header allowing the origin of the request, reports whether the origin is allowed.
+func (mw *corsMW) allowOrigin(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	if mw.anyOrigin {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return true
+	}
+	w.Header().Add("Vary", "Origin")
+	if !slices.Contains(mw.origins, origin) {
+		return false
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	return true
+}
+
+// errBodyReadTimeout is returned when the request body is not read before its deadline.
+var errBodyReadTimeout = fmt.Errorf("request body read timeout: %w", os.ErrDeadlineExceeded)
+
+// bodyReadTimeoutMW replaces the server-wide read timeout for a route with its own
+// body read deadline, so slow but legitimate uploads are not cut off by the global limit.
+type bodyReadTimeoutMW struct {
+	now     func() time.Time
+	logger  *slog.Logger
+	timeout time.Duration
+}
+
+// newBodyReadTimeoutMW is a constructor of bodyReadTimeoutMW.
+func newBodyReadTimeoutMW(timeout time.Duration, now func() time.Time, logger *slog.Logger) *bodyReadTimeoutMW {
+	return &bodyReadTimeoutMW{timeout: timeout, now: now, logger: logger}
+}
+
+// responseWriteGrace is the time left to write a response once the body read deadline is reached.
+const responseWriteGrace = 15 * time.Second
+
+// limit extends the connection read deadline and wraps the body with a reader
+// that refuses to read past the same deadline. The write deadline is moved as well,
+// otherwise the server-wide write timeout could expire while the body is still being read.
+func (mw *bodyReadTimeoutMW) limit(handler http.HandlerFunc) http.HandlerFunc {
+	if mw.timeout <= 0 {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		deadline := mw.now().Add(mw.timeout)
+		rc := http.NewResponseController(w)
+		if err := rc.SetReadDeadline(deadline); err != nil {
+			mw.logger.Warn("body read timeout: set read deadline", slog.Any("error", err))
+		}
+		if err := rc.SetWriteDeadline(deadline.Add(responseWriteGrace)); err != nil {
+			mw.logger.Warn("body read timeout: set write deadline", slog.Any("error", err))
+		}
+		r.Body = &deadlineReader{ReadCloser: r.Body, deadline: deadline, now: mw.now}
+
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// deadlineReader is a request body that fails reads once its deadline has passed.
+type deadlineReader struct {
+	io.ReadCloser
+
+	deadline time.Time
+	now      func() time.Time
+}
+
+// Read implements the io.Reader interface.
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	if d.now().After(d.deadline) {
+		return 0, errBodyReadTimeout
+	}
+	return d.ReadCloser.Read(p)
+}
+
 type emailMatcherMW struct {
 	logger     *slog.Logger
 	rgxsEmails []*regexp.Regexp
@@ -128,3 +296,71 @@ func (mw *emailMatcherMW) emailMatch(handler http.HandlerFunc) http.HandlerFunc
 		handler.ServeHTTP(w, r)
 	}
 }
+
+// adminMW restricts handlers to the users allowed to use the admin API.
+type adminMW struct {
+	*BaseHandler
+
+	emails map[string]struct{}
+}
+
+func newAdminMW(emails []string, logger *slog.Logger) *adminMW {
+	mw := &adminMW{BaseHandler: NewBaseHandler(logger), emails: make(map[string]struct{}, len(emails))}
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			mw.emails[email] = struct{}{}
+		}
+	}
+	return mw
+}
+
+// isAdmin reports whether the user is allowed to use the admin API, emails are compared case-insensitively.
+func (mw *adminMW) isAdmin(user *warnly.User) bool {
+	_, ok := mw.emails[strings.ToLower(user.Email)]
+	return ok
+}
+
+// requireAdmin responds with 403 Forbidden to authenticated users who are not admins.
+func (mw *adminMW) requireAdmin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		user := getUser(ctx)
+		if !mw.isAdmin(&user) {
+			mw.writeError(ctx, w, http.StatusForbidden, "admin: user is not an admin",
+				fmt.Errorf("user %d is not allowed to %s %s", user.ID, r.Method, r.URL.Path))
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// readOnlyMW rejects changes while the read-only maintenance mode is on.
+type readOnlyMW struct {
+	*BaseHandler
+
+	svc warnly.ProjectService
+}
+
+func newReadOnlyMW(svc warnly.ProjectService, logger *slog.Logger) *readOnlyMW {
+	return &readOnlyMW{BaseHandler: NewBaseHandler(logger), svc: svc}
+}
+
+// rejectChanges responds with 503 Service Unavailable to requests other than GET, HEAD and OPTIONS
+// while the read-only maintenance mode is on, so that no handler has to check the mode itself.
+func (mw *readOnlyMW) rejectChanges(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if mw.svc.ReadOnly() {
+				mw.writeError(r.Context(), w, http.StatusServiceUnavailable, "read-only: reject change",
+					fmt.Errorf("%s %s: %w", r.Method, r.URL.Path, warnly.ErrReadOnly))
+				return
+			}
+		}
+
+		handler.ServeHTTP(w, r)
+	}
+}
@@ -3,12 +3,15 @@ package server
 import (
 	"bufio"
 	"context"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -320,3 +323,86 @@ func TestEmailMatcherMiddleware_MultiplePatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestBodyReadTimeoutMiddleware_SlowBodyCompletes(t *testing.T) {
+	t.Parallel()
+
+	const chunks = 4
+
+	mw := newBodyReadTimeoutMW(5*time.Second, time.Now, slog.New(slog.DiscardHandler))
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusRequestTimeout)
+			return
+		}
+		_, _ = w.Write(b)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(newPrometheusMW(prometheus.NewRegistry(), time.Now).
+		recordLatency(mw.limit(handler))))
+	// the server-wide read timeout is shorter than it takes to upload the body
+	srv.Config.ReadTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	pr, pw := io.Pipe()
+	go func() {
+		for range chunks {
+			time.Sleep(75 * time.Millisecond)
+			if _, err := pw.Write([]byte("chunk")); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		_ = pw.Close()
+	}()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, pr)
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, strings.Repeat("chunk", chunks), string(b))
+}
+
+func TestBodyReadTimeoutMiddleware_DeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	mw := newBodyReadTimeoutMW(time.Second, func() time.Time { return now }, slog.New(slog.DiscardHandler))
+
+	var readErr error
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		now = start.Add(2 * time.Second)
+		_, readErr = io.ReadAll(r.Body)
+	}
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, testPattern, strings.NewReader("payload"))
+	mw.limit(handler)(httptest.NewRecorder(), req)
+
+	require.ErrorIs(t, readErr, os.ErrDeadlineExceeded)
+}
+
+func TestBodyReadTimeoutMiddleware_Disabled(t *testing.T) {
+	t.Parallel()
+
+	mw := newBodyReadTimeoutMW(0, time.Now, slog.New(slog.DiscardHandler))
+
+	var body io.ReadCloser
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body = r.Body
+	}
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, testPattern, strings.NewReader("payload"))
+	mw.limit(handler)(httptest.NewRecorder(), req)
+
+	_, wrapped := body.(*deadlineReader)
+	assert.False(t, wrapped, "body should not be wrapped when the timeout is disabled")
+}
@@ -2,11 +2,15 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/vk-rv/warnly/internal/warnly"
 	"github.com/vk-rv/warnly/internal/web"
@@ -65,6 +69,7 @@ func (h *ProjectHandler) DeleteAssignment(w http.ResponseWriter, r *http.Request
 		Period:    period,
 		Start:     h.getFormOrQuery(r, "start"),
 		End:       h.getFormOrQuery(r, "end"),
+		Levels:    warnly.ParseLevels(h.getFormOrQuery(r, "level")),
 		Page:      h.getPage(h.getFormOrQuery(r, "page")),
 	}, &user)
 	if err != nil {
@@ -87,22 +92,38 @@ func (h *ProjectHandler) AssignIssue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID, err := strconv.Atoi(r.FormValue("user_id"))
-	if err != nil {
-		h.writeError(ctx, w, http.StatusBadRequest, "assign issue: parse user ID", err)
-		return
-	}
-
 	req := &warnly.AssignIssueRequest{
 		IssueID:   issueID,
 		ProjectID: projectID,
 		User:      &user,
-		UserID:    userID,
+	}
+
+	if teamID := r.FormValue("team_id"); teamID != "" {
+		req.TeamID, err = strconv.Atoi(teamID)
+		if err != nil {
+			h.writeError(ctx, w, http.StatusBadRequest, "assign issue: parse team ID", err)
+			return
+		}
+	} else {
+		req.UserID, err = strconv.Atoi(r.FormValue("user_id"))
+		if err != nil {
+			h.writeError(ctx, w, http.StatusBadRequest, "assign issue: parse user ID", err)
+			return
+		}
 	}
 
 	err = h.svc.AssignIssue(ctx, req)
 	if err != nil {
-		h.writeError(ctx, w, http.StatusInternalServerError, "assign issue: assign issue", err)
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, warnly.ErrInvalidAssignee):
+			code = http.StatusBadRequest
+		case errors.Is(err, warnly.ErrPermissionDenied):
+			code = http.StatusForbidden
+		case errors.Is(err, warnly.ErrReadOnly):
+			code = http.StatusServiceUnavailable
+		}
+		h.writeError(ctx, w, code, "assign issue: assign issue", err)
 		return
 	}
 
@@ -119,6 +140,7 @@ func (h *ProjectHandler) AssignIssue(w http.ResponseWriter, r *http.Request) {
 		Period:    period,
 		Start:     h.getFormOrQuery(r, "start"),
 		End:       h.getFormOrQuery(r, "end"),
+		Levels:    warnly.ParseLevels(h.getFormOrQuery(r, "level")),
 		Page:      h.getPage(h.getFormOrQuery(r, "page")),
 	}, &user)
 	if err != nil {
@@ -153,6 +175,7 @@ func (h *ProjectHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 		Period:    r.URL.Query().Get("period"),
 		Start:     r.URL.Query().Get("start"),
 		End:       r.URL.Query().Get("end"),
+		Levels:    warnly.ParseLevels(r.URL.Query().Get("level")),
 		ProjectID: projectID,
 		IssueID:   issueID,
 		User:      &user,
@@ -174,6 +197,204 @@ func (h *ProjectHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetRawEvent returns the event JSON payload as it was sent by the SDK.
+func (h *ProjectHandler) GetRawEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "get raw event: get project and issue", err)
+		return
+	}
+
+	raw, err := h.svc.GetRawEvent(ctx, &warnly.GetRawEventRequest{
+		User:      &user,
+		EventID:   r.PathValue("event_id"),
+		ProjectID: projectID,
+		IssueID:   issueID,
+	})
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "get raw event", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "get raw event", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if _, err := w.Write(raw); err != nil {
+		h.logger.Error("get raw event: write", slog.Any("error", err))
+	}
+}
+
+// ReleaseAdoption returns users and errors of the recent releases of a project as JSON.
+func (h *ProjectHandler) ReleaseAdoption(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "release adoption: parse project ID", err)
+		return
+	}
+
+	releases, err := h.svc.ReleaseAdoption(ctx, &warnly.ReleaseAdoptionRequest{
+		User:      &user,
+		Period:    r.URL.Query().Get("period"),
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "release adoption", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "release adoption", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(releases); err != nil {
+		h.logger.Error("release adoption: encode", slog.Any("error", err))
+	}
+}
+
+// CompareWindows returns the issue activity of a project in two time windows,
+// as JSON when the client accepts it and as a page otherwise.
+func (h *ProjectHandler) CompareWindows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "compare windows: parse project ID", err)
+		return
+	}
+
+	q := r.URL.Query()
+	baselineFrom, baselineTo, err := warnly.ParseTimeRange(q.Get("baseline_start"), q.Get("baseline_end"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "compare windows: parse baseline", err)
+		return
+	}
+	currentFrom, currentTo, err := warnly.ParseTimeRange(q.Get("current_start"), q.Get("current_end"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "compare windows: parse current", err)
+		return
+	}
+
+	res, err := h.svc.CompareWindows(ctx, &warnly.CompareWindowsRequest{
+		User:      &user,
+		Baseline:  warnly.TimeWindow{From: baselineFrom, To: baselineTo},
+		Current:   warnly.TimeWindow{From: currentFrom, To: currentTo},
+		ProjectID: projectID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrInvalidTimeWindow):
+			h.writeError(ctx, w, http.StatusBadRequest, "compare windows", err)
+		case errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "compare windows", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "compare windows", err)
+		}
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			h.logger.Error("compare windows: encode", slog.Any("error", err))
+		}
+		return
+	}
+
+	if r.Header.Get(htmxHeader) != "" {
+		if err := web.CompareWindowsHtmx(res).Render(ctx, w); err != nil {
+			h.logger.Error("compare windows htmx web render", slog.Any("error", err))
+		}
+	} else {
+		if err := web.CompareWindows(res, &user).Render(ctx, w); err != nil {
+			h.logger.Error("compare windows web render", slog.Any("error", err))
+		}
+	}
+}
+
+// ListDiscardedEvents returns the events SDKs of a project dropped client-side as JSON.
+func (h *ProjectHandler) ListDiscardedEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "list discarded events: parse project ID", err)
+		return
+	}
+
+	discarded, err := h.svc.ListDiscardedEvents(ctx, &warnly.ListDiscardedEventsRequest{
+		User:      &user,
+		Period:    r.URL.Query().Get("period"),
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "list discarded events", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "list discarded events", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(discarded); err != nil {
+		h.logger.Error("list discarded events: encode", slog.Any("error", err))
+	}
+}
+
+// SuggestTagValues returns the most popular values of a tag starting with the q prefix as JSON,
+// it backs the autocomplete of the search query.
+func (h *ProjectHandler) SuggestTagValues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "suggest tag values: parse project ID", err)
+		return
+	}
+
+	values, err := h.svc.SuggestTagValues(ctx, &warnly.SuggestTagValuesRequest{
+		User:      &user,
+		Tag:       r.PathValue("key"),
+		Prefix:    r.URL.Query().Get("q"),
+		Period:    r.URL.Query().Get("period"),
+		ProjectID: projectID,
+	})
+	if err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "suggest tag values", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "suggest tag values", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(values); err != nil {
+		h.logger.Error("suggest tag values: encode", slog.Any("error", err))
+	}
+}
+
 // ListFields renders list of fields related to an issue with some statistics,
 // e.g. how many times a field like browser or os was seen in events.
 func (h *ProjectHandler) ListFields(w http.ResponseWriter, r *http.Request) {
@@ -258,7 +479,14 @@ func (h *ProjectHandler) PostMessage(w http.ResponseWriter, r *http.Request) {
 
 	discussion, err := h.svc.CreateMessage(ctx, req)
 	if err != nil {
-		h.writeError(ctx, w, http.StatusInternalServerError, "post discussion: create discussion", err)
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, warnly.ErrPermissionDenied):
+			code = http.StatusForbidden
+		case errors.Is(err, warnly.ErrReadOnly):
+			code = http.StatusServiceUnavailable
+		}
+		h.writeError(ctx, w, code, "post discussion: create discussion", err)
 		return
 	}
 
@@ -274,22 +502,11 @@ func newMessageRequest(r *http.Request, projectID, issueID int, user *warnly.Use
 		return nil, errors.New("content cannot be empty")
 	}
 
-	mentionedUsers := r.Form["mentioned_users"]
-	mentionedUserIDs := make([]int, 0, len(mentionedUsers))
-	for _, mentionedUser := range mentionedUsers {
-		id, err := strconv.Atoi(mentionedUser)
-		if err != nil {
-			return nil, fmt.Errorf("invalid mentioned user ID: %s", mentionedUser)
-		}
-		mentionedUserIDs = append(mentionedUserIDs, id)
-	}
-
 	return &warnly.CreateMessageRequest{
-		ProjectID:      projectID,
-		IssueID:        issueID,
-		User:           user,
-		Content:        content,
-		MentionedUsers: mentionedUserIDs,
+		ProjectID: projectID,
+		IssueID:   issueID,
+		User:      user,
+		Content:   content,
 	}, nil
 }
 
@@ -341,6 +558,7 @@ func (h *ProjectHandler) GetIssue(w http.ResponseWriter, r *http.Request) {
 		EventID:   r.URL.Query().Get("event_id"),
 		User:      &user,
 		Source:    warnly.GetIssueRequestSource(r.URL.Query().Get("source")),
+		Location:  userLocation(r),
 	}
 
 	issue, err := h.svc.GetIssue(ctx, req)
@@ -352,6 +570,259 @@ func (h *ProjectHandler) GetIssue(w http.ResponseWriter, r *http.Request) {
 	h.writeIssue(ctx, w, r, issue, &user)
 }
 
+// ResolveIssue marks an issue as resolved with an optional resolution note.
+func (h *ProjectHandler) ResolveIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "resolve issue: get project and issue", err)
+		return
+	}
+
+	req := &warnly.ResolveIssueRequest{
+		IssueID:   issueID,
+		ProjectID: projectID,
+		User:      &user,
+		Note:      r.FormValue("note"),
+		Release:   r.FormValue("release"),
+		Notify:    r.FormValue("notify") == "true",
+	}
+
+	if err := h.svc.ResolveIssue(ctx, req); err != nil {
+		if errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "resolve issue: resolve issue", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "resolve issue: resolve issue", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IgnoreIssue hides an issue until it occurs threshold more times, affects threshold more users
+// or the given number of minutes passes, depending on the condition of the form.
+func (h *ProjectHandler) IgnoreIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "ignore issue: get project and issue", err)
+		return
+	}
+
+	req := &warnly.IgnoreIssueRequest{
+		IssueID:   issueID,
+		ProjectID: projectID,
+		User:      &user,
+		Condition: warnly.IgnoreCondition(r.FormValue("condition")),
+	}
+	switch req.Condition {
+	case warnly.IgnoreConditionCount, warnly.IgnoreConditionUsers:
+		req.Threshold, err = strconv.Atoi(r.FormValue("threshold"))
+		if err != nil {
+			h.writeError(ctx, w, http.StatusBadRequest, "ignore issue: parse threshold", err)
+			return
+		}
+	case warnly.IgnoreConditionTime:
+		minutes, err := strconv.Atoi(r.FormValue("minutes"))
+		if err != nil {
+			h.writeError(ctx, w, http.StatusBadRequest, "ignore issue: parse minutes", err)
+			return
+		}
+		req.Duration = time.Duration(minutes) * time.Minute
+	}
+
+	if err := h.svc.IgnoreIssue(ctx, req); err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrInvalidIgnoreCondition):
+			h.writeError(ctx, w, http.StatusBadRequest, "ignore issue: ignore issue", err)
+		case errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "ignore issue: ignore issue", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "ignore issue: ignore issue", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SubscribeIssue subscribes the user to new messages and status changes of the issue.
+func (h *ProjectHandler) SubscribeIssue(w http.ResponseWriter, r *http.Request) {
+	h.updateSubscription(w, r, "subscribe issue", h.svc.Subscribe)
+}
+
+// UnsubscribeIssue unsubscribes the user from the issue.
+func (h *ProjectHandler) UnsubscribeIssue(w http.ResponseWriter, r *http.Request) {
+	h.updateSubscription(w, r, "unsubscribe issue", h.svc.Unsubscribe)
+}
+
+// updateSubscription changes the subscription of the user to the issue of the request with update.
+func (h *ProjectHandler) updateSubscription(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	update func(ctx context.Context, req *warnly.IssueSubscriptionRequest) error,
+) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, op+": get project and issue", err)
+		return
+	}
+
+	if err := update(ctx, &warnly.IssueSubscriptionRequest{
+		User:      &user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+	}); err != nil {
+		if errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, op, err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, op, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListIssueLabels renders the labels of the issue.
+func (h *ProjectHandler) ListIssueLabels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "list issue labels: get project and issue", err)
+		return
+	}
+
+	labels, err := h.svc.ListLabels(ctx, &warnly.IssueLabelRequest{
+		User:      &user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+	})
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "list issue labels", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "list issue labels", err)
+		return
+	}
+
+	if err := web.IssueLabels(projectID, int64(issueID), labels).Render(ctx, w); err != nil {
+		h.logger.Error("list issue labels web render", slog.Any("error", err))
+	}
+}
+
+// AddIssueLabel attaches the label of the form to the issue.
+func (h *ProjectHandler) AddIssueLabel(w http.ResponseWriter, r *http.Request) {
+	h.updateLabel(w, r, "add issue label", r.FormValue("label"), h.svc.AddLabel)
+}
+
+// RemoveIssueLabel removes the label of the path from the issue.
+func (h *ProjectHandler) RemoveIssueLabel(w http.ResponseWriter, r *http.Request) {
+	h.updateLabel(w, r, "remove issue label", r.PathValue("label"), h.svc.RemoveLabel)
+}
+
+// AddNoiseFilter drops the events of the issue that have the tags of the filter at ingest,
+// the filter is the tag key=value pairs separated by commas.
+func (h *ProjectHandler) AddNoiseFilter(w http.ResponseWriter, r *http.Request) {
+	h.updateNoiseFilter(w, r, "add noise filter", h.svc.AddNoiseFilter)
+}
+
+// RemoveNoiseFilter stops dropping the events of the issue by the filter.
+func (h *ProjectHandler) RemoveNoiseFilter(w http.ResponseWriter, r *http.Request) {
+	h.updateNoiseFilter(w, r, "remove noise filter", h.svc.RemoveNoiseFilter)
+}
+
+// updateNoiseFilter changes the noise filter of the issue of the request with update.
+func (h *ProjectHandler) updateNoiseFilter(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	update func(ctx context.Context, req *warnly.NoiseFilterRequest) error,
+) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, op+": get project and issue", err)
+		return
+	}
+
+	if err := update(ctx, &warnly.NoiseFilterRequest{
+		User:      &user,
+		Filter:    r.FormValue("filter"),
+		ProjectID: projectID,
+		IssueID:   issueID,
+	}); err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrInvalidNoiseFilter) || errors.Is(err, warnly.ErrTooManyNoiseFilters):
+			h.writeError(ctx, w, http.StatusBadRequest, op, err)
+		case errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, op, err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, op, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateLabel changes the label of the issue of the request with update.
+func (h *ProjectHandler) updateLabel(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	label string,
+	update func(ctx context.Context, req *warnly.IssueLabelRequest) error,
+) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, issueID, err := getProjectIssue(r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, op+": get project and issue", err)
+		return
+	}
+
+	if err := update(ctx, &warnly.IssueLabelRequest{
+		User:      &user,
+		Label:     label,
+		ProjectID: projectID,
+		IssueID:   issueID,
+	}); err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrInvalidLabel):
+			h.writeError(ctx, w, http.StatusBadRequest, op, err)
+		case errors.Is(err, warnly.ErrNotFound) || errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, op, err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, op, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // SearchProjectByName is a method that searches projects by name.
 func (h *ProjectHandler) SearchProjectByName(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -395,11 +866,19 @@ func (h *ProjectHandler) ProjectDetails(w http.ResponseWriter, r *http.Request)
 		Period:    r.URL.Query().Get("period"),
 		Start:     r.URL.Query().Get("start"),
 		End:       r.URL.Query().Get("end"),
+		Levels:    warnly.ParseLevels(r.URL.Query().Get("level")),
+		Cursor:    r.URL.Query().Get("cursor"),
 		Page:      h.getPage(r.URL.Query().Get("page")),
+
+		IncludeIgnored: r.URL.Query().Get("ignored") == "true",
 	}
 
 	details, err := h.svc.GetProjectDetails(ctx, req, &user)
 	if err != nil {
+		if errors.Is(err, warnly.ErrInvalidIssueCursor) {
+			h.writeError(ctx, w, http.StatusBadRequest, "project details: parse cursor", err)
+			return
+		}
 		h.writeError(ctx, w, http.StatusInternalServerError, "project details: get project details", err)
 		return
 	}
@@ -457,6 +936,14 @@ func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 			w.Header().Add("Hx-Redirect", "/")
 			return
 		}
+		if errors.Is(err, warnly.ErrPermissionDenied) {
+			h.writeError(ctx, w, http.StatusForbidden, "delete project: delete project", err)
+			return
+		}
+		if errors.Is(err, warnly.ErrReadOnly) {
+			h.writeError(ctx, w, http.StatusServiceUnavailable, "delete project: delete project", err)
+			return
+		}
 		h.writeError(ctx, w, http.StatusInternalServerError, "delete project: delete project", err)
 		return
 	}
@@ -464,6 +951,68 @@ func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Hx-Redirect", "/projects")
 }
 
+// TransferProject moves a project to the team of the team_id form value.
+func (h *ProjectHandler) TransferProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "transfer project: parse project ID", err)
+		return
+	}
+
+	teamID, err := strconv.Atoi(r.FormValue("team_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "transfer project: parse team ID", err)
+		return
+	}
+
+	err = h.svc.TransferProject(ctx, &warnly.TransferProjectRequest{
+		User:      &user,
+		ProjectID: projectID,
+		TeamID:    teamID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrProjectNotFound), errors.Is(err, warnly.ErrTeamNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "transfer project", err)
+		case errors.Is(err, warnly.ErrDuplicate):
+			h.writeError(ctx, w, http.StatusConflict, "transfer project", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "transfer project", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProject brings back a project deleted within the deletion grace period.
+func (h *ProjectHandler) RestoreProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "restore project: parse project ID", err)
+		return
+	}
+
+	if err := h.svc.RestoreProject(ctx, projectID, &user); err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "restore project", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "restore project", err)
+		return
+	}
+
+	w.Header().Add("Hx-Redirect", "/projects/"+strconv.Itoa(projectID))
+}
+
 // ProjectSettings is a method that renders project settings page.
 func (h *ProjectHandler) ProjectSettings(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -477,13 +1026,58 @@ func (h *ProjectHandler) ProjectSettings(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	project, err := h.svc.GetProject(ctx, projectID, &user)
+	opts, err := h.svc.GetProjectOptions(ctx, projectID, &user)
 	if err != nil {
-		h.writeError(ctx, w, http.StatusInternalServerError, "project settings: get project", err)
+		h.writeError(ctx, w, http.StatusInternalServerError, "project settings: get project options", err)
 		return
 	}
 
-	h.writeProjectSettings(ctx, w, r, project, &user)
+	h.writeProjectSettings(ctx, w, r, opts, &user)
+}
+
+// UpdateProjectSettings changes the platform and the event retention of a project and renders its settings.
+func (h *ProjectHandler) UpdateProjectSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "update project settings: parse project ID", err)
+		return
+	}
+
+	retentionDays, err := strconv.Atoi(r.FormValue("retention_days"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "update project settings: parse retention days", err)
+		return
+	}
+
+	err = h.svc.UpdateProjectOptions(ctx, &warnly.UpdateProjectOptionsRequest{
+		User:          &user,
+		ProjectID:     projectID,
+		Platform:      warnly.PlatformByName(r.FormValue("platform")),
+		RetentionDays: retentionDays,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "update project settings", err)
+		case errors.Is(err, warnly.ErrInvalidRetention), errors.Is(err, warnly.ErrInvalidPlatform):
+			h.writeError(ctx, w, http.StatusBadRequest, "update project settings", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "update project settings", err)
+		}
+		return
+	}
+
+	opts, err := h.svc.GetProjectOptions(ctx, projectID, &user)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusInternalServerError, "update project settings: get project options", err)
+		return
+	}
+
+	h.writeProjectSettings(ctx, w, r, opts, &user)
 }
 
 // CreateProject creates a new project.
@@ -500,13 +1094,82 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.CreateProject(ctx, req, &user)
 	if err != nil {
-		h.writeError(ctx, w, http.StatusInternalServerError, "create new project: create project", err)
+		code := http.StatusInternalServerError
+		if errors.Is(err, warnly.ErrReadOnly) {
+			code = http.StatusServiceUnavailable
+		}
+		h.writeError(ctx, w, code, "create new project: create project", err)
 		return
 	}
 
 	h.writeGettingStarted(w, r, res)
 }
 
+// maxManifestSize is the maximum size of a project import manifest in bytes.
+const maxManifestSize = 1 << 20
+
+// importProjectsRequest is the body of a project import.
+type importProjectsRequest struct {
+	Projects []warnly.ProjectManifestEntry `json:"projects"`
+}
+
+// importedProject is a project of the import response.
+type importedProject struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	DSN      string `json:"dsn"`
+	ID       int    `json:"id"`
+	TeamID   int    `json:"team_id"`
+	Created  bool   `json:"created"`
+}
+
+// importProjectsResponse is the response of a project import.
+type importProjectsResponse struct {
+	Projects []importedProject `json:"projects"`
+}
+
+// ImportProjects creates the projects of a JSON manifest and responds with their DSNs.
+func (h *ProjectHandler) ImportProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	var manifest importProjectsRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxManifestSize)).Decode(&manifest); err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "import projects: decode manifest", err)
+		return
+	}
+
+	res, err := h.svc.ImportProjects(ctx, &warnly.ImportProjectsRequest{User: &user, Projects: manifest.Projects})
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, warnly.ErrInvalidManifest) {
+			code = http.StatusBadRequest
+		}
+		h.writeError(ctx, w, code, "import projects", err)
+		return
+	}
+
+	resp := importProjectsResponse{Projects: make([]importedProject, 0, len(res.Projects))}
+	for i := range res.Projects {
+		p := &res.Projects[i]
+		resp.Projects = append(resp.Projects, importedProject{
+			ID:       p.ID,
+			Name:     p.Name,
+			Platform: p.Platform,
+			DSN:      p.DSN,
+			TeamID:   p.TeamID,
+			Created:  p.Created,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("import projects: encode", slog.Any("error", err))
+	}
+}
+
 // GetPlatforms renders possible project platforms.
 func (h *ProjectHandler) GetPlatforms(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -543,6 +1206,23 @@ func getProjectIssue(r *http.Request) (projectID, issueID int, err error) {
 	return projectID, issueID, nil
 }
 
+// timezoneCookie is set by the browser with the IANA timezone of the user.
+const timezoneCookie = "tz"
+
+// userLocation returns the timezone of the user taken from the timezone cookie.
+// UTC is returned when the cookie is missing or holds an unknown timezone.
+func userLocation(r *http.Request) *time.Location {
+	cookie, err := r.Cookie(timezoneCookie)
+	if err != nil {
+		return time.UTC
+	}
+	name, err := url.QueryUnescape(cookie.Value)
+	if err != nil {
+		return time.UTC
+	}
+	return warnly.LoadLocation(name)
+}
+
 // getPage parses the page number from string to int.
 func (h *ProjectHandler) getPage(page string) int {
 	const defaultPage = 1
@@ -583,15 +1263,15 @@ func (h *ProjectHandler) writeProjectSettings(
 	ctx context.Context,
 	w http.ResponseWriter,
 	r *http.Request,
-	project *warnly.Project,
+	opts *warnly.ProjectOptions,
 	user *warnly.User,
 ) {
 	if r.Header.Get(htmxHeader) != "" {
-		if err := web.ProjectSettingsHtmx(project).Render(ctx, w); err != nil {
+		if err := web.ProjectSettingsHtmx(opts).Render(ctx, w); err != nil {
 			h.logger.Error("project settings htmx web render", slog.Any("error", err))
 		}
 	} else {
-		if err := web.ProjectSettings(project, user).Render(ctx, w); err != nil {
+		if err := web.ProjectSettings(opts, user).Render(ctx, w); err != nil {
 			h.logger.Error("project settings web render", slog.Any("error", err))
 		}
 	}
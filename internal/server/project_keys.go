@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// projectKeyResponse is an ingest key of a project.
+type projectKeyResponse struct {
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Key       string     `json:"key"`
+	DSN       string     `json:"dsn"`
+	ID        int64      `json:"id"`
+	Primary   bool       `json:"primary"`
+}
+
+func newProjectKeyResponse(key *warnly.ProjectKey) projectKeyResponse {
+	return projectKeyResponse{
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+		Key:       key.Key,
+		DSN:       key.DSN,
+		ID:        key.ID,
+		Primary:   key.Primary,
+	}
+}
+
+// ListKeys returns the ingest keys of a project as JSON.
+func (h *ProjectHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	const msg = "list keys"
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse project ID", err)
+		return
+	}
+
+	user := getUser(r.Context())
+	keys, err := h.svc.ListKeys(r.Context(), &warnly.ProjectKeysRequest{User: &user, ProjectID: projectID})
+	if err != nil {
+		h.writeKeyError(r.Context(), w, msg, err)
+		return
+	}
+
+	res := make([]projectKeyResponse, len(keys))
+	for i := range keys {
+		res[i] = newProjectKeyResponse(&keys[i])
+	}
+	h.writeKeys(w, http.StatusOK, msg, res)
+}
+
+// AddKey adds an ingest key to a project and returns it as JSON.
+func (h *ProjectHandler) AddKey(w http.ResponseWriter, r *http.Request) {
+	const msg = "add key"
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse project ID", err)
+		return
+	}
+
+	user := getUser(r.Context())
+	key, err := h.svc.AddKey(r.Context(), &warnly.ProjectKeysRequest{User: &user, ProjectID: projectID})
+	if err != nil {
+		h.writeKeyError(r.Context(), w, msg, err)
+		return
+	}
+
+	h.writeKeys(w, http.StatusCreated, msg, newProjectKeyResponse(key))
+}
+
+// RotateKey replaces the key of the project DSN and returns the new key as JSON,
+// the previous key is accepted until the grace_period query parameter, e.g. "24h", passes.
+func (h *ProjectHandler) RotateKey(w http.ResponseWriter, r *http.Request) {
+	const msg = "rotate key"
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse project ID", err)
+		return
+	}
+
+	grace, ok := h.parseGracePeriod(w, r, msg, r.URL.Query().Get("grace_period"))
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	key, err := h.svc.RotateKey(r.Context(), &warnly.RotateProjectKeyRequest{
+		User:        &user,
+		GracePeriod: grace,
+		ProjectID:   projectID,
+	})
+	if err != nil {
+		h.writeKeyError(r.Context(), w, msg, err)
+		return
+	}
+
+	h.writeKeys(w, http.StatusCreated, msg, newProjectKeyResponse(key))
+}
+
+// RevokeKey stops accepting an additional key of a project once the grace_period query parameter passes,
+// immediately without it.
+func (h *ProjectHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	const msg = "revoke key"
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse project ID", err)
+		return
+	}
+
+	keyID, err := strconv.ParseInt(r.PathValue("key_id"), 10, 64)
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse key ID", err)
+		return
+	}
+
+	grace, ok := h.parseGracePeriod(w, r, msg, r.URL.Query().Get("grace_period"))
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err = h.svc.RevokeKey(r.Context(), &warnly.RevokeProjectKeyRequest{
+		User:        &user,
+		GracePeriod: grace,
+		KeyID:       keyID,
+		ProjectID:   projectID,
+	})
+	if err != nil {
+		h.writeKeyError(r.Context(), w, msg, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseGracePeriod parses the grace period of a key rotation or revocation, empty is no grace period.
+// It writes the error response and returns false when the period is malformed.
+func (h *ProjectHandler) parseGracePeriod(w http.ResponseWriter, r *http.Request, msg, value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, true
+	}
+	grace, err := time.ParseDuration(value)
+	if err == nil && grace < 0 {
+		err = errors.New("negative grace period")
+	}
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse grace period", err)
+		return 0, false
+	}
+	return grace, true
+}
+
+// writeKeys writes the keys as JSON, the keys are credentials and aren't cached.
+func (h *ProjectHandler) writeKeys(w http.ResponseWriter, status int, msg string, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Error(msg+": encode", slog.Any("error", err))
+	}
+}
+
+// writeKeyError writes the error response of a key operation.
+func (h *ProjectHandler) writeKeyError(ctx context.Context, w http.ResponseWriter, msg string, err error) {
+	if errors.Is(err, warnly.ErrProjectKeyNotFound) {
+		h.writeError(ctx, w, http.StatusNotFound, msg, err)
+		return
+	}
+	h.writeSettingsResult(ctx, w, msg, err, warnly.ErrPrimaryProjectKey)
+}
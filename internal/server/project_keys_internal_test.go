@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// testKeysService manages the keys of project 1, key 1 is the key of the project DSN.
+type testKeysService struct {
+	warnly.ProjectService
+
+	revoked *warnly.RevokeProjectKeyRequest
+}
+
+var testKeyCreatedAt = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *testKeysService) ListKeys(_ context.Context, req *warnly.ProjectKeysRequest) ([]warnly.ProjectKey, error) {
+	if req.ProjectID != 1 {
+		return nil, warnly.ErrProjectNotFound
+	}
+	return []warnly.ProjectKey{
+		{CreatedAt: testKeyCreatedAt, Key: "k1", DSN: "http://k1@localhost:8080/ingest/1", ID: 1, ProjectID: 1, Primary: true},
+	}, nil
+}
+
+func (s *testKeysService) AddKey(_ context.Context, req *warnly.ProjectKeysRequest) (*warnly.ProjectKey, error) {
+	if req.ProjectID != 1 {
+		return nil, warnly.ErrProjectNotFound
+	}
+	return &warnly.ProjectKey{CreatedAt: testKeyCreatedAt, Key: "k2", DSN: "http://k2@localhost:8080/ingest/1", ID: 2, ProjectID: 1}, nil
+}
+
+func (s *testKeysService) RevokeKey(_ context.Context, req *warnly.RevokeProjectKeyRequest) error {
+	switch {
+	case req.ProjectID != 1:
+		return warnly.ErrProjectNotFound
+	case req.KeyID == 1:
+		return warnly.ErrPrimaryProjectKey
+	case req.KeyID != 2:
+		return warnly.ErrProjectKeyNotFound
+	}
+	s.revoked = req
+	return nil
+}
+
+func TestProjectKeysAPI(t *testing.T) {
+	t.Parallel()
+
+	user := warnly.User{ID: 7}
+
+	tests := []struct {
+		wantRevoked *warnly.RevokeProjectKeyRequest
+		name        string
+		method      string
+		path        string
+		wantBody    string
+		wantCode    int
+	}{
+		{
+			name:     "list",
+			method:   http.MethodGet,
+			path:     "/projects/1/keys",
+			wantCode: http.StatusOK,
+			wantBody: `[{"created_at":"2025-03-01T12:00:00Z","key":"k1","dsn":"http://k1@localhost:8080/ingest/1","id":1,"primary":true}]`,
+		},
+		{
+			name:     "list keys of an unknown project",
+			method:   http.MethodGet,
+			path:     "/projects/2/keys",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "add",
+			method:   http.MethodPost,
+			path:     "/projects/1/keys",
+			wantCode: http.StatusCreated,
+			wantBody: `{"created_at":"2025-03-01T12:00:00Z","key":"k2","dsn":"http://k2@localhost:8080/ingest/1","id":2,"primary":false}`,
+		},
+		{
+			name:        "revoke after a grace period",
+			method:      http.MethodDelete,
+			path:        "/projects/1/keys/2?grace_period=1h",
+			wantCode:    http.StatusNoContent,
+			wantRevoked: &warnly.RevokeProjectKeyRequest{User: &user, GracePeriod: time.Hour, KeyID: 2, ProjectID: 1},
+		},
+		{
+			name:     "revoke the key of the DSN",
+			method:   http.MethodDelete,
+			path:     "/projects/1/keys/1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "revoke an unknown key",
+			method:   http.MethodDelete,
+			path:     "/projects/1/keys/3",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "revoke with a negative grace period",
+			method:   http.MethodDelete,
+			path:     "/projects/1/keys/2?grace_period=-1h",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &testKeysService{}
+			h := NewProjectHandler(svc, slog.Default())
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /projects/{project_id}/keys", h.ListKeys)
+			mux.HandleFunc("POST /projects/{project_id}/keys", h.AddKey)
+			mux.HandleFunc("DELETE /projects/{project_id}/keys/{key_id}", h.RevokeKey)
+
+			ctx := NewContextWithUser(t.Context(), user)
+			r := httptest.NewRequestWithContext(ctx, tt.method, tt.path, http.NoBody)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			require.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+			assert.Equal(t, tt.wantRevoked, svc.revoked)
+		})
+	}
+}
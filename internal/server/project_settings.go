@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// maxProjectSettingsSize is the maximum size of a project settings request body in bytes.
+const maxProjectSettingsSize = 64 << 10
+
+// sampleRateRequest is the body of a sample rate change.
+type sampleRateRequest struct {
+	SampleRate float64 `json:"sample_rate"`
+}
+
+// SetSampleRate changes the share of incoming events stored for a project.
+func (h *ProjectHandler) SetSampleRate(w http.ResponseWriter, r *http.Request) {
+	const msg = "set sample rate"
+
+	var body sampleRateRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetSampleRate(r.Context(), &warnly.SetSampleRateRequest{
+		User:       &user,
+		SampleRate: body.SampleRate,
+		ProjectID:  projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidSampleRate)
+}
+
+// dedupWindowRequest is the body of a dedup window change, the window is a duration such as "30s".
+type dedupWindowRequest struct {
+	Window string `json:"window"`
+}
+
+// SetDedupWindow changes the window duplicate events of a project are stored once within, "0s" turns it off.
+func (h *ProjectHandler) SetDedupWindow(w http.ResponseWriter, r *http.Request) {
+	const msg = "set dedup window"
+
+	var body dedupWindowRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	window, err := time.ParseDuration(body.Window)
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse window", err)
+		return
+	}
+
+	user := getUser(r.Context())
+	err = h.svc.SetDedupWindow(r.Context(), &warnly.SetDedupWindowRequest{
+		User:      &user,
+		Window:    window,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidDedupWindow)
+}
+
+// groupingRequest is the body of a grouping strategy change.
+type groupingRequest struct {
+	Grouping warnly.GroupingStrategy `json:"grouping"`
+}
+
+// SetGrouping changes how message-only events of a project are grouped into issues.
+func (h *ProjectHandler) SetGrouping(w http.ResponseWriter, r *http.Request) {
+	const msg = "set grouping"
+
+	var body groupingRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetGrouping(r.Context(), &warnly.SetGroupingRequest{
+		User:      &user,
+		Grouping:  body.Grouping,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidGrouping)
+}
+
+// priorityRulesRequest is the body of a priority rules change.
+type priorityRulesRequest struct {
+	Rules []warnly.PriorityRule `json:"rules"`
+}
+
+// SetPriorityRules replaces the rules that set the priority of a project's issues by event tags.
+func (h *ProjectHandler) SetPriorityRules(w http.ResponseWriter, r *http.Request) {
+	const msg = "set priority rules"
+
+	var body priorityRulesRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetPriorityRules(r.Context(), &warnly.SetPriorityRulesRequest{
+		User:      &user,
+		Rules:     body.Rules,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidPriorityRule)
+}
+
+// codeOwnersRequest is the body of a code owners change.
+type codeOwnersRequest struct {
+	Owners []warnly.CodeOwner `json:"owners"`
+}
+
+// SetCodeOwners replaces the rules that suggest assignees of a project's issues by stack frame paths.
+func (h *ProjectHandler) SetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	const msg = "set code owners"
+
+	var body codeOwnersRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetCodeOwners(r.Context(), &warnly.SetCodeOwnersRequest{
+		User:      &user,
+		Owners:    body.Owners,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidCodeOwner)
+}
+
+// groupingRulesRequest is the body of a grouping rules change, without rules the rules are cleared.
+type groupingRulesRequest struct {
+	Rules *warnly.GroupingRules `json:"rules"`
+}
+
+// SetGroupingRules replaces the rules that exclude framework frames from the issue view
+// and normalize exceptions and messages before grouping.
+func (h *ProjectHandler) SetGroupingRules(w http.ResponseWriter, r *http.Request) {
+	const msg = "set grouping rules"
+
+	var body groupingRulesRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetGroupingRules(r.Context(), &warnly.SetGroupingRulesRequest{
+		User:      &user,
+		Rules:     body.Rules,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidGroupingRules)
+}
+
+// sourceURLTemplateRequest is the body of a source URL template change, an empty template disables the links.
+type sourceURLTemplateRequest struct {
+	Template string `json:"template"`
+}
+
+// SetSourceURLTemplate changes the template of links from a project's stack frames to the repository.
+func (h *ProjectHandler) SetSourceURLTemplate(w http.ResponseWriter, r *http.Request) {
+	const msg = "set source url template"
+
+	var body sourceURLTemplateRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetSourceURLTemplate(r.Context(), &warnly.SetSourceURLTemplateRequest{
+		User:      &user,
+		Template:  body.Template,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidSourceURLTemplate)
+}
+
+// ingestFiltersRequest is the body of an ingest filters change, without filters every event is kept.
+type ingestFiltersRequest struct {
+	Filters *warnly.IngestFilters `json:"filters"`
+}
+
+// SetIngestFilters replaces the environment and release patterns that drop events of a project at ingest.
+func (h *ProjectHandler) SetIngestFilters(w http.ResponseWriter, r *http.Request) {
+	const msg = "set ingest filters"
+
+	var body ingestFiltersRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	err := h.svc.SetIngestFilters(r.Context(), &warnly.SetIngestFiltersRequest{
+		User:      &user,
+		Filters:   body.Filters,
+		ProjectID: projectID,
+	})
+	h.writeSettingsResult(r.Context(), w, msg, err, warnly.ErrInvalidIngestFilters)
+}
+
+// ingestSigningRequest is the body of an ingest signing change.
+type ingestSigningRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ingestSigningResponse carries the new ingest secret, it is empty when signing is disabled.
+type ingestSigningResponse struct {
+	Secret string `json:"secret"`
+}
+
+// SetIngestSigning requires signed payloads for ingestion into a project, or stops requiring them.
+// The new secret is shown only in the response, enabling signing again rotates it.
+func (h *ProjectHandler) SetIngestSigning(w http.ResponseWriter, r *http.Request) {
+	const msg = "set ingest signing"
+
+	var body ingestSigningRequest
+	projectID, ok := h.decodeSettings(w, r, msg, &body)
+	if !ok {
+		return
+	}
+
+	user := getUser(r.Context())
+	secret, err := h.svc.SetIngestSigning(r.Context(), &warnly.SetIngestSigningRequest{
+		User:      &user,
+		ProjectID: projectID,
+		Enabled:   body.Enabled,
+	})
+	if err != nil {
+		h.writeSettingsResult(r.Context(), w, msg, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(ingestSigningResponse{Secret: secret}); err != nil {
+		h.logger.Error(msg+": encode", slog.Any("error", err))
+	}
+}
+
+// decodeSettings parses the project ID and decodes the JSON body of a project settings change.
+// It writes the error response and returns false when the request is malformed.
+func (h *ProjectHandler) decodeSettings(w http.ResponseWriter, r *http.Request, msg string, v any) (int, bool) {
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": parse project ID", err)
+		return 0, false
+	}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProjectSettingsSize)).Decode(v); err != nil {
+		h.writeError(r.Context(), w, http.StatusBadRequest, msg+": decode request", err)
+		return 0, false
+	}
+
+	return projectID, true
+}
+
+// writeSettingsResult answers a project settings change,
+// errors wrapping one of the invalid errors are reported as bad requests.
+func (h *ProjectHandler) writeSettingsResult(ctx context.Context, w http.ResponseWriter, msg string, err error, invalid ...error) {
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, warnly.ErrProjectNotFound):
+		h.writeError(ctx, w, http.StatusNotFound, msg, err)
+	case errors.Is(err, warnly.ErrPermissionDenied):
+		h.writeError(ctx, w, http.StatusForbidden, msg, err)
+	case slices.ContainsFunc(invalid, func(target error) bool { return errors.Is(err, target) }):
+		h.writeError(ctx, w, http.StatusBadRequest, msg, err)
+	default:
+		h.writeError(ctx, w, http.StatusInternalServerError, msg, err)
+	}
+}
@@ -0,0 +1,337 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// testSettingsService records the project settings changes and rejects them for projects other than 1.
+type testSettingsService struct {
+	warnly.ProjectService
+
+	req any
+}
+
+func (s *testSettingsService) change(projectID int, req any, validate error) error {
+	if projectID != 1 {
+		return warnly.ErrProjectNotFound
+	}
+	if validate != nil {
+		return validate
+	}
+	s.req = req
+	return nil
+}
+
+func (s *testSettingsService) SetSampleRate(_ context.Context, req *warnly.SetSampleRateRequest) error {
+	return s.change(req.ProjectID, req, warnly.ValidateSampleRate(req.SampleRate))
+}
+
+func (s *testSettingsService) SetGrouping(_ context.Context, req *warnly.SetGroupingRequest) error {
+	return s.change(req.ProjectID, req, warnly.ValidateGrouping(req.Grouping))
+}
+
+func (s *testSettingsService) SetPriorityRules(_ context.Context, req *warnly.SetPriorityRulesRequest) error {
+	return s.change(req.ProjectID, req, warnly.ValidatePriorityRules(req.Rules))
+}
+
+func (s *testSettingsService) SetCodeOwners(_ context.Context, req *warnly.SetCodeOwnersRequest) error {
+	return s.change(req.ProjectID, req, warnly.ValidateCodeOwners(req.Owners))
+}
+
+func (s *testSettingsService) SetIngestSigning(_ context.Context, req *warnly.SetIngestSigningRequest) (string, error) {
+	if err := s.change(req.ProjectID, req, nil); err != nil || !req.Enabled {
+		return "", err
+	}
+	return "3f2a", nil
+}
+
+func (s *testSettingsService) SetGroupingRules(_ context.Context, req *warnly.SetGroupingRulesRequest) error {
+	var validate error
+	if req.Rules != nil {
+		validate = req.Rules.Validate()
+	}
+	return s.change(req.ProjectID, req, validate)
+}
+
+func (s *testSettingsService) SetIngestFilters(_ context.Context, req *warnly.SetIngestFiltersRequest) error {
+	var validate error
+	if req.Filters != nil {
+		validate = req.Filters.Validate()
+	}
+	return s.change(req.ProjectID, req, validate)
+}
+
+func (s *testSettingsService) SetDedupWindow(_ context.Context, req *warnly.SetDedupWindowRequest) error {
+	return s.change(req.ProjectID, req, warnly.ValidateDedupWindow(req.Window))
+}
+
+func (s *testSettingsService) SetSourceURLTemplate(_ context.Context, req *warnly.SetSourceURLTemplateRequest) error {
+	return s.change(req.ProjectID, req, warnly.ValidateSourceURLTemplate(req.Template))
+}
+
+func TestProjectSettingsAPI(t *testing.T) {
+	t.Parallel()
+
+	user := warnly.User{ID: 7}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		path     string
+		body     string
+		handler  func(h *ProjectHandler) http.HandlerFunc
+		wantCode int
+		wantReq  any
+	}{
+		{
+			name:     "sample rate",
+			pattern:  "PUT /projects/{project_id}/settings/sample-rate",
+			path:     "/projects/1/settings/sample-rate",
+			body:     `{"sample_rate":0.25}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetSampleRate },
+			wantCode: http.StatusNoContent,
+			wantReq:  &warnly.SetSampleRateRequest{User: &user, SampleRate: 0.25, ProjectID: 1},
+		},
+		{
+			name:     "invalid sample rate",
+			pattern:  "PUT /projects/{project_id}/settings/sample-rate",
+			path:     "/projects/1/settings/sample-rate",
+			body:     `{"sample_rate":1.5}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetSampleRate },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "sample rate of an unknown project",
+			pattern:  "PUT /projects/{project_id}/settings/sample-rate",
+			path:     "/projects/2/settings/sample-rate",
+			body:     `{"sample_rate":0.25}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetSampleRate },
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "dedup window",
+			pattern:  "PUT /projects/{project_id}/settings/dedup-window",
+			path:     "/projects/1/settings/dedup-window",
+			body:     `{"window":"30s"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetDedupWindow },
+			wantCode: http.StatusNoContent,
+			wantReq:  &warnly.SetDedupWindowRequest{User: &user, Window: 30 * time.Second, ProjectID: 1},
+		},
+		{
+			name:     "dedup window too long",
+			pattern:  "PUT /projects/{project_id}/settings/dedup-window",
+			path:     "/projects/1/settings/dedup-window",
+			body:     `{"window":"2h"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetDedupWindow },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "dedup window that isn't a duration",
+			pattern:  "PUT /projects/{project_id}/settings/dedup-window",
+			path:     "/projects/1/settings/dedup-window",
+			body:     `{"window":"soon"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetDedupWindow },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "grouping",
+			pattern:  "PUT /projects/{project_id}/settings/grouping",
+			path:     "/projects/1/settings/grouping",
+			body:     `{"grouping":"top_in_app_frame"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetGrouping },
+			wantCode: http.StatusNoContent,
+			wantReq:  &warnly.SetGroupingRequest{User: &user, Grouping: warnly.GroupingByTopInAppFrame, ProjectID: 1},
+		},
+		{
+			name:     "unknown grouping",
+			pattern:  "PUT /projects/{project_id}/settings/grouping",
+			path:     "/projects/1/settings/grouping",
+			body:     `{"grouping":"stack"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetGrouping },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "grouping rules",
+			pattern:  "PUT /projects/{project_id}/settings/grouping-rules",
+			path:     "/projects/1/settings/grouping-rules",
+			body:     `{"rules":{"not_in_app_prefixes":["/app/vendor/"],"ignore_message_types":["TimeoutError"]}}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetGroupingRules },
+			wantCode: http.StatusNoContent,
+			wantReq: &warnly.SetGroupingRulesRequest{
+				User: &user,
+				Rules: &warnly.GroupingRules{
+					NotInAppPrefixes:   []string{"/app/vendor/"},
+					IgnoreMessageTypes: []string{"TimeoutError"},
+				},
+				ProjectID: 1,
+			},
+		},
+		{
+			name:     "cleared grouping rules",
+			pattern:  "PUT /projects/{project_id}/settings/grouping-rules",
+			path:     "/projects/1/settings/grouping-rules",
+			body:     `{}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetGroupingRules },
+			wantCode: http.StatusNoContent,
+			wantReq:  &warnly.SetGroupingRulesRequest{User: &user, ProjectID: 1},
+		},
+		{
+			name:     "grouping rule with an empty prefix",
+			pattern:  "PUT /projects/{project_id}/settings/grouping-rules",
+			path:     "/projects/1/settings/grouping-rules",
+			body:     `{"rules":{"not_in_app_prefixes":[""]}}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetGroupingRules },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "ingest filters",
+			pattern:  "PUT /projects/{project_id}/settings/ingest-filters",
+			path:     "/projects/1/settings/ingest-filters",
+			body:     `{"filters":{"deny_environments":["local"],"deny_releases":["*-rc*"]}}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetIngestFilters },
+			wantCode: http.StatusNoContent,
+			wantReq: &warnly.SetIngestFiltersRequest{
+				User: &user,
+				Filters: &warnly.IngestFilters{
+					DenyEnvironments: []string{"local"},
+					DenyReleases:     []string{"*-rc*"},
+				},
+				ProjectID: 1,
+			},
+		},
+		{
+			name:     "ingest filter with a malformed pattern",
+			pattern:  "PUT /projects/{project_id}/settings/ingest-filters",
+			path:     "/projects/1/settings/ingest-filters",
+			body:     `{"filters":{"allow_releases":["[1.2"]}}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetIngestFilters },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "priority rules",
+			pattern:  "PUT /projects/{project_id}/settings/priority-rules",
+			path:     "/projects/1/settings/priority-rules",
+			body:     `{"rules":[{"tag_key":"env","tag_value":"staging","priority":1}]}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetPriorityRules },
+			wantCode: http.StatusNoContent,
+			wantReq: &warnly.SetPriorityRulesRequest{
+				User:      &user,
+				Rules:     []warnly.PriorityRule{{TagKey: "env", TagValue: "staging", Priority: warnly.PriorityLow}},
+				ProjectID: 1,
+			},
+		},
+		{
+			name:     "priority rule without a tag",
+			pattern:  "PUT /projects/{project_id}/settings/priority-rules",
+			path:     "/projects/1/settings/priority-rules",
+			body:     `{"rules":[{"tag_key":"env","priority":3}]}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetPriorityRules },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "code owners",
+			pattern:  "PUT /projects/{project_id}/settings/code-owners",
+			path:     "/projects/1/settings/code-owners",
+			body:     `{"owners":[{"pattern":"internal/billing/","user_id":2}]}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetCodeOwners },
+			wantCode: http.StatusNoContent,
+			wantReq: &warnly.SetCodeOwnersRequest{
+				User:      &user,
+				Owners:    []warnly.CodeOwner{{Pattern: "internal/billing/", UserID: 2}},
+				ProjectID: 1,
+			},
+		},
+		{
+			name:     "code owner without a user",
+			pattern:  "PUT /projects/{project_id}/settings/code-owners",
+			path:     "/projects/1/settings/code-owners",
+			body:     `{"owners":[{"pattern":"*.go"}]}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetCodeOwners },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "source url template",
+			pattern:  "PUT /projects/{project_id}/settings/source-url-template",
+			path:     "/projects/1/settings/source-url-template",
+			body:     `{"template":"https://github.com/org/repo/blob/{ref}/{path}#L{line}"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetSourceURLTemplate },
+			wantCode: http.StatusNoContent,
+			wantReq: &warnly.SetSourceURLTemplateRequest{
+				User:      &user,
+				Template:  "https://github.com/org/repo/blob/{ref}/{path}#L{line}",
+				ProjectID: 1,
+			},
+		},
+		{
+			name:     "source url template without a path",
+			pattern:  "PUT /projects/{project_id}/settings/source-url-template",
+			path:     "/projects/1/settings/source-url-template",
+			body:     `{"template":"https://github.com/org/repo"}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetSourceURLTemplate },
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "disable ingest signing",
+			pattern:  "PUT /projects/{project_id}/settings/ingest-signing",
+			path:     "/projects/1/settings/ingest-signing",
+			body:     `{"enabled":false}`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetIngestSigning },
+			wantCode: http.StatusOK,
+			wantReq:  &warnly.SetIngestSigningRequest{User: &user, ProjectID: 1},
+		},
+		{
+			name:     "malformed body",
+			pattern:  "PUT /projects/{project_id}/settings/sample-rate",
+			path:     "/projects/1/settings/sample-rate",
+			body:     `{"sample_rate":`,
+			handler:  func(h *ProjectHandler) http.HandlerFunc { return h.SetSampleRate },
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &testSettingsService{}
+			mux := http.NewServeMux()
+			mux.HandleFunc(tt.pattern, tt.handler(NewProjectHandler(svc, slog.Default())))
+
+			ctx := NewContextWithUser(t.Context(), user)
+			r := httptest.NewRequestWithContext(ctx, http.MethodPut, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			require.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantReq, svc.req)
+		})
+	}
+}
+
+func TestSetIngestSigningReturnsSecret(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /projects/{project_id}/settings/ingest-signing",
+		NewProjectHandler(&testSettingsService{}, slog.Default()).SetIngestSigning)
+
+	ctx := NewContextWithUser(t.Context(), warnly.User{ID: 7})
+	r := httptest.NewRequestWithContext(ctx, http.MethodPut, "/projects/1/settings/ingest-signing",
+		strings.NewReader(`{"enabled":true}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"secret":"3f2a"}`, w.Body.String())
+}
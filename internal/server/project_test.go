@@ -3,12 +3,17 @@ package server_test
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/google/uuid"
 	"github.com/microcosm-cc/bluemonday"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vk-rv/warnly/internal/server"
@@ -112,13 +117,19 @@ func TestServer_ListProjects(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -166,13 +177,19 @@ func TestServer_ListProjects(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -184,13 +201,13 @@ func TestServer_ListProjects(t *testing.T) {
 			Name:      "team-1",
 			OwnerID:   testOwnerID,
 		}))
-		require.NoError(t, s.teamStore.AddUserToTeam(ctx, nowTime(), int64(testOwnerID), 1))
+		require.NoError(t, s.teamStore.AddUserToTeam(ctx, nowTime(), int64(testOwnerID), 1, warnly.RoleAdmin))
 		require.NoError(t, s.teamStore.CreateTeam(ctx, warnly.Team{
 			CreatedAt: nowTime(),
 			Name:      "team-2",
 			OwnerID:   testOwnerID,
 		}))
-		require.NoError(t, s.teamStore.AddUserToTeam(ctx, nowTime(), int64(testOwnerID), 2))
+		require.NoError(t, s.teamStore.AddUserToTeam(ctx, nowTime(), int64(testOwnerID), 2, warnly.RoleAdmin))
 
 		require.NoError(t, s.projectStore.CreateProject(ctx, &warnly.Project{
 			CreatedAt: nowTime(),
@@ -238,13 +255,19 @@ func TestServer_ListProjects(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -298,13 +321,19 @@ func TestServer_ListProjects(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -350,13 +379,19 @@ func TestServer_ListProjects(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -394,9 +429,12 @@ func TestServer_ListProjects(t *testing.T) {
 			event.Queue{
 				Enabled: false,
 			},
+			nil,
+			nil,
 			nowHalfAnHourBefore,
+			logger,
 		)
-		eventHandler := server.NewEventAPIHandler(eventSvc, logger)
+		eventHandler := server.NewEventAPIHandler(eventSvc, prometheus.NewRegistry(), logger)
 
 		projectSvc := project.NewProjectService(
 			s.projectStore,
@@ -405,13 +443,19 @@ func TestServer_ListProjects(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -475,13 +519,19 @@ func TestServer_DeleteProject(t *testing.T) {
 			s.issueStore,
 			s.messageStore,
 			s.mentionStore,
+			s.activityStore,
+			s.subscriptionStore,
+			s.labelStore,
+			s.seenStore,
 			s.olap,
+			nil,
 			s.uow,
 			bluemonday.NewPolicy(),
 			testBaseURL,
 			testBaseScheme,
 			testBaseURL,
 			testBaseScheme,
+			project.Options{},
 			nowTime,
 			logger,
 		)
@@ -577,9 +627,12 @@ func TestServer_HandleProjectDetails(t *testing.T) {
 				event.Queue{
 					Enabled: false,
 				},
+				nil,
+				nil,
 				nowHalfAnHourBefore,
+				logger,
 			)
-			eventHandler := server.NewEventAPIHandler(eventSvc, logger)
+			eventHandler := server.NewEventAPIHandler(eventSvc, prometheus.NewRegistry(), logger)
 
 			projectSvc := project.NewProjectService(
 				s.projectStore,
@@ -588,13 +641,19 @@ func TestServer_HandleProjectDetails(t *testing.T) {
 				s.issueStore,
 				s.messageStore,
 				s.mentionStore,
+				s.activityStore,
+				s.subscriptionStore,
+				s.labelStore,
+				s.seenStore,
 				s.olap,
+				nil,
 				s.uow,
 				bluemonday.NewPolicy(),
 				testBaseURL,
 				testBaseScheme,
 				testBaseURL,
 				testBaseScheme,
+				project.Options{},
 				nowTime,
 				logger,
 			)
@@ -631,3 +690,350 @@ func TestServer_HandleProjectDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_GetRawEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	testDB, _ := testMySQLDatabaseInstance.NewDatabase(t)
+	testOlapDB, _ := testClickHouseDatabaseInstance.NewDatabase(t)
+	logger, _ := getTestLogger()
+	s := getTestStores(testDB, testOlapDB, logger)
+
+	eventSvc := event.NewEventService(
+		s.projectStore,
+		s.issueStore,
+		s.memoryCache,
+		s.olap,
+		event.Queue{
+			Enabled: false,
+		},
+		nil,
+		nil,
+		nowHalfAnHourBefore,
+		logger,
+	)
+	eventHandler := server.NewEventAPIHandler(eventSvc, prometheus.NewRegistry(), logger)
+
+	projectSvc := project.NewProjectService(
+		s.projectStore,
+		s.assingmentStore,
+		s.teamStore,
+		s.issueStore,
+		s.messageStore,
+		s.mentionStore,
+		s.activityStore,
+		s.subscriptionStore,
+		s.labelStore,
+		s.seenStore,
+		s.olap,
+		nil,
+		s.uow,
+		bluemonday.NewPolicy(),
+		testBaseURL,
+		testBaseScheme,
+		testBaseURL,
+		testBaseScheme,
+		project.Options{},
+		nowTime,
+		logger,
+	)
+	projectHandler := server.NewProjectHandler(projectSvc, logger)
+
+	require.NoError(t, setupTestUserAndTeam(ctx, s, nowTime()))
+	require.NoError(t, s.projectStore.CreateProject(ctx, &warnly.Project{
+		CreatedAt: nowTime(),
+		Name:      testProjectName,
+		Key:       testProjectKey,
+		UserID:    testOwnerID,
+		TeamID:    testOwnerID,
+		Platform:  warnly.PlatformGolang,
+	}))
+
+	wIngest, rIngest := getIngestRequest(ctx, zapsentryEventWithErr)
+	eventHandler.IngestEvent(wIngest, rIngest)
+	require.Equal(t, http.StatusOK, wIngest.Code)
+
+	const eventID = "243f84fd26384830b657fe30ea2956bc"
+	payload := strings.Split(string(zapsentryEventWithErr), "\n")[2]
+
+	w, r := getRawEventRequest(ctx, 1, eventID)
+	projectHandler.GetRawEvent(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, payload, w.Body.String())
+
+	w, r = getRawEventRequest(ctx, 1, strings.ReplaceAll(uuid.New().String(), "-", ""))
+	projectHandler.GetRawEvent(w, r)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestServer_IngestLoggerAndTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	testDB, _ := testMySQLDatabaseInstance.NewDatabase(t)
+	testOlapDB, _ := testClickHouseDatabaseInstance.NewDatabase(t)
+	logger, _ := getTestLogger()
+	s := getTestStores(testDB, testOlapDB, logger)
+
+	eventSvc := event.NewEventService(
+		s.projectStore,
+		s.issueStore,
+		s.memoryCache,
+		s.olap,
+		event.Queue{
+			Enabled: false,
+		},
+		nil,
+		nil,
+		nowHalfAnHourBefore,
+		logger,
+	)
+	eventHandler := server.NewEventAPIHandler(eventSvc, prometheus.NewRegistry(), logger)
+
+	require.NoError(t, setupTestUserAndTeam(ctx, s, nowTime()))
+	require.NoError(t, s.projectStore.CreateProject(ctx, &warnly.Project{
+		CreatedAt: nowTime(),
+		Name:      testProjectName,
+		Key:       testProjectKey,
+		UserID:    testOwnerID,
+		TeamID:    testOwnerID,
+		Platform:  warnly.PlatformGolang,
+	}))
+
+	// the sampled zap payload doesn't name a logger, it is sent along with a transaction here.
+	zapWithTransaction := strings.Replace(string(zapsentryEventWithErr),
+		`"level":"error",`, `"level":"error","logger":"zap","transaction":"GET /checkout",`, 1)
+
+	tests := []struct {
+		payload     []byte
+		eventID     string
+		logger      string
+		transaction string
+		groupID     int
+	}{
+		{payload: zerologErrEvent, eventID: "62fe54af6ffc460eb57b92c67c7c283d", logger: "zerolog", groupID: 1},
+		{
+			payload:     []byte(zapWithTransaction),
+			eventID:     "243f84fd26384830b657fe30ea2956bc",
+			logger:      "zap",
+			transaction: "GET /checkout",
+			groupID:     2,
+		},
+	}
+	for _, tt := range tests {
+		w, r := getIngestRequest(ctx, tt.payload)
+		eventHandler.IngestEvent(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		ev, err := s.olap.GetIssueEvent(ctx, &warnly.EventDefCriteria{
+			From:      nowTime().Add(-time.Hour),
+			To:        nowTime(),
+			EventID:   tt.eventID,
+			GroupID:   tt.groupID,
+			ProjectID: 1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tt.logger, ev.Logger)
+		assert.Equal(t, tt.transaction, ev.Transaction)
+
+		details := &warnly.IssueDetails{LastEvent: ev}
+		assert.Equal(t, tt.logger, details.Logger())
+		assert.Equal(t, tt.transaction, details.Transaction())
+	}
+
+	groupIDs, err := s.olap.GetFilteredGroupIDs(ctx, []warnly.QueryToken{
+		{Key: "transaction", Operator: "is", Value: "GET /checkout"},
+	}, nowTime().Add(-time.Hour), nowTime(), []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, groupIDs)
+
+	groupIDs, err = s.olap.GetFilteredGroupIDs(ctx, []warnly.QueryToken{
+		{Key: "logger", Operator: "is", Value: "zerolog"},
+	}, nowTime().Add(-time.Hour), nowTime(), []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, groupIDs)
+}
+
+func TestServer_IssueNoiseFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	testDB, _ := testMySQLDatabaseInstance.NewDatabase(t)
+	testOlapDB, _ := testClickHouseDatabaseInstance.NewDatabase(t)
+	logger, _ := getTestLogger()
+	s := getTestStores(testDB, testOlapDB, logger)
+
+	projectSvc := project.NewProjectService(
+		s.projectStore,
+		s.assingmentStore,
+		s.teamStore,
+		s.issueStore,
+		s.messageStore,
+		s.mentionStore,
+		s.activityStore,
+		s.subscriptionStore,
+		s.labelStore,
+		s.seenStore,
+		s.olap,
+		nil,
+		s.uow,
+		bluemonday.NewPolicy(),
+		testBaseURL,
+		testBaseScheme,
+		testBaseURL,
+		testBaseScheme,
+		project.Options{},
+		nowTime,
+		logger,
+	)
+	eventSvc := event.NewEventService(
+		s.projectStore,
+		s.issueStore,
+		s.memoryCache,
+		s.olap,
+		event.Queue{
+			Enabled: false,
+		},
+		nil,
+		nil,
+		nowTime,
+		logger,
+	)
+	registry := prometheus.NewRegistry()
+	eventHandler := server.NewEventAPIHandler(eventSvc, registry, logger)
+
+	require.NoError(t, setupTestUserAndTeam(ctx, s, nowTime()))
+	require.NoError(t, s.projectStore.CreateProject(ctx, &warnly.Project{
+		CreatedAt: nowTime(),
+		Name:      testProjectName,
+		Key:       testProjectKey,
+		UserID:    testOwnerID,
+		TeamID:    testOwnerID,
+		Platform:  warnly.PlatformGolang,
+	}))
+
+	// the replacements keep the length of the envelope item.
+	ingest := func(eventID, release string) {
+		payload := strings.ReplaceAll(string(zerologErrEvent), "62fe54af6ffc460eb57b92c67c7c283d", eventID)
+		payload = strings.ReplaceAll(payload, "177aa93-dirty", release)
+		w, r := getIngestRequest(ctx, []byte(payload))
+		eventHandler.IngestEvent(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	ingest("62fe54af6ffc460eb57b92c67c7c283d", "177aa93-dirty")
+
+	user := testUser
+	require.NoError(t, projectSvc.AddNoiseFilter(ctx, &warnly.NoiseFilterRequest{
+		User:      &user,
+		Filter:    "release=177aa93-dirty",
+		ProjectID: 1,
+		IssueID:   1,
+	}))
+	// the issue is cached by ingestion until it expires.
+	s.memoryCache.Flush()
+
+	ingest("72fe54af6ffc460eb57b92c67c7c283d", "177aa93-dirty")
+	ingest("82fe54af6ffc460eb57b92c67c7c283d", "177aa94-dirty")
+
+	metrics, err := s.olap.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: []int{1},
+		GroupIDs:   []int64{1},
+		From:       nowTime().Add(-time.Hour),
+		To:         nowTime().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, uint64(2), metrics[0].TimesSeen, "the event matching the noise filter is not counted")
+	const want = `
+# HELP warnly_ingest_filtered_events_total Total number of ingested events that were dropped by ingest or noise filters.
+# TYPE warnly_ingest_filtered_events_total counter
+warnly_ingest_filtered_events_total 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(want), "warnly_ingest_filtered_events_total"))
+}
+
+func TestServer_ProjectSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	testDB, _ := testMySQLDatabaseInstance.NewDatabase(t)
+	testOlapDB, _ := testClickHouseDatabaseInstance.NewDatabase(t)
+	logger, _ := getTestLogger()
+	s := getTestStores(testDB, testOlapDB, logger)
+
+	projectSvc := project.NewProjectService(
+		s.projectStore,
+		s.assingmentStore,
+		s.teamStore,
+		s.issueStore,
+		s.messageStore,
+		s.mentionStore,
+		s.activityStore,
+		s.subscriptionStore,
+		s.labelStore,
+		s.seenStore,
+		s.olap,
+		nil,
+		s.uow,
+		bluemonday.NewPolicy(),
+		testBaseURL,
+		testBaseScheme,
+		testBaseURL,
+		testBaseScheme,
+		project.Options{},
+		nowTime,
+		logger,
+	)
+	projectHandler := server.NewProjectHandler(projectSvc, logger)
+
+	require.NoError(t, setupTestUserAndTeam(ctx, s, nowTime()))
+	require.NoError(t, s.projectStore.CreateProject(ctx, &warnly.Project{
+		CreatedAt: nowTime(),
+		Name:      testProjectName,
+		Key:       testProjectKey,
+		UserID:    testOwnerID,
+		TeamID:    testOwnerID,
+		Platform:  warnly.PlatformGolang,
+	}))
+
+	settingsRequest := func(method string, form url.Values) (*httptest.ResponseRecorder, *http.Request) {
+		r := httptest.NewRequestWithContext(server.NewContextWithUser(ctx, testUser), method,
+			"/settings/projects/"+testProjectIDStr, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.SetPathValue("id", testProjectIDStr)
+		return httptest.NewRecorder(), r
+	}
+	retention := func(w *httptest.ResponseRecorder) string {
+		doc, err := goquery.NewDocumentFromReader(w.Body)
+		require.NoError(t, err)
+		value, _ := doc.Find("#retention_days").Attr("value")
+		return value
+	}
+
+	w, r := settingsRequest(http.MethodGet, nil)
+	projectHandler.ProjectSettings(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "90", retention(w), "events are kept for the default retention")
+
+	w, r = settingsRequest(http.MethodPost, url.Values{"platform": {"go"}, "retention_days": {"365"}})
+	projectHandler.UpdateProjectSettings(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, r = settingsRequest(http.MethodPost, url.Values{"platform": {"go"}, "retention_days": {"30"}})
+	projectHandler.UpdateProjectSettings(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "30", retention(w))
+
+	opts, err := s.projectStore.GetOptions(ctx, 1, testProjectKey)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(30), opts.RetentionDays)
+}
@@ -56,7 +56,7 @@ func (mw *recoverMw) recover(handler http.HandlerFunc) http.HandlerFunc {
 				if !ok {
 					err = fmt.Errorf("%v", rvr)
 				}
-				mw.logger.Error(err.Error(), "event", "panic", "stack", "...\n"+string(buf))
+				mw.logger.ErrorContext(r.Context(), err.Error(), "event", "panic", "stack", "...\n"+string(buf))
 
 				if r.Header.Get("Connection") != "Upgrade" {
 					if r.Header.Get(htmxHeader) != "" {
@@ -66,7 +66,7 @@ func (mw *recoverMw) recover(handler http.HandlerFunc) http.HandlerFunc {
 							strconv.Itoa(http.StatusInternalServerError),
 							http.StatusText(http.StatusInternalServerError),
 						).Render(r.Context(), w); err != nil {
-							mw.logger.Error("server error web render", slog.Any("error", err))
+							mw.logger.ErrorContext(r.Context(), "server error web render", slog.Any("error", err))
 						}
 					}
 				}
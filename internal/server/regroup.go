@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// regroupHandler manages jobs that regroup stored events of a project.
+type regroupHandler struct {
+	*BaseHandler
+
+	svc    warnly.RegroupService
+	logger *slog.Logger
+}
+
+// newRegroupHandler creates a new regroupHandler instance.
+func newRegroupHandler(svc warnly.RegroupService, logger *slog.Logger) *regroupHandler {
+	return &regroupHandler{
+		BaseHandler: NewBaseHandler(logger),
+		svc:         svc,
+		logger:      logger,
+	}
+}
+
+// startRegroup starts regrouping the project's events and writes the job progress as JSON.
+func (h *regroupHandler) startRegroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "start regroup: parse project ID", err)
+		return
+	}
+
+	progress, err := h.svc.StartRegroup(ctx, &warnly.RegroupRequest{User: &user, ProjectID: projectID})
+	if err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "start regroup", err)
+		case errors.Is(err, warnly.ErrPermissionDenied):
+			h.writeError(ctx, w, http.StatusForbidden, "start regroup", err)
+		case errors.Is(err, warnly.ErrRegroupRunning):
+			h.writeError(ctx, w, http.StatusConflict, "start regroup", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "start regroup", err)
+		}
+		return
+	}
+
+	h.writeProgress(w, http.StatusAccepted, progress)
+}
+
+// getRegroup writes the progress of the last regroup job of the project as JSON.
+func (h *regroupHandler) getRegroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "get regroup: parse project ID", err)
+		return
+	}
+
+	progress, err := h.svc.GetRegroup(ctx, &warnly.RegroupRequest{User: &user, ProjectID: projectID})
+	if err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) || errors.Is(err, warnly.ErrNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "get regroup", err)
+			return
+		}
+		if errors.Is(err, warnly.ErrPermissionDenied) {
+			h.writeError(ctx, w, http.StatusForbidden, "get regroup", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "get regroup", err)
+		return
+	}
+
+	h.writeProgress(w, http.StatusOK, progress)
+}
+
+// cancelRegroup cancels the running regroup job of the project.
+func (h *regroupHandler) cancelRegroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	projectID, err := strconv.Atoi(r.PathValue("project_id"))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, "cancel regroup: parse project ID", err)
+		return
+	}
+
+	if err := h.svc.CancelRegroup(ctx, &warnly.RegroupRequest{User: &user, ProjectID: projectID}); err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) || errors.Is(err, warnly.ErrNotFound) {
+			h.writeError(ctx, w, http.StatusNotFound, "cancel regroup", err)
+			return
+		}
+		if errors.Is(err, warnly.ErrPermissionDenied) {
+			h.writeError(ctx, w, http.StatusForbidden, "cancel regroup", err)
+			return
+		}
+		h.writeError(ctx, w, http.StatusInternalServerError, "cancel regroup", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeProgress writes the regroup job progress as JSON.
+func (h *regroupHandler) writeProgress(w http.ResponseWriter, status int, progress *warnly.RegroupProgress) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(progress); err != nil {
+		h.logger.Error("regroup: encode progress", slog.Any("error", err))
+	}
+}
@@ -24,8 +24,6 @@ const (
 	sessionID        = "session"
 )
 
-const defaultPeriod = "14d"
-
 const (
 	// msgInvalidLoginCredentials is the message displayed on the login page when the user provides invalid credentials.
 	msgInvalidLoginCredentials = "Invalid login credentials."
@@ -254,11 +252,6 @@ func (h *rootHandler) index(w http.ResponseWriter, r *http.Request) {
 
 	user := getUser(ctx)
 
-	period := r.URL.Query().Get("period")
-	if period == "" {
-		period = defaultPeriod
-	}
-
 	offset, err := parseOffset(r.URL.Query().Get("offset"))
 	if err != nil {
 		h.writeError(ctx, w, http.StatusBadRequest, "list issues: parse offset", err)
@@ -267,13 +260,22 @@ func (h *rootHandler) index(w http.ResponseWriter, r *http.Request) {
 
 	req := &warnly.ListIssuesRequest{
 		User:        &user,
-		Period:      period,
+		Period:      r.URL.Query().Get("period"),
 		Start:       r.URL.Query().Get("start"),
 		End:         r.URL.Query().Get("end"),
 		Query:       r.URL.Query().Get("query"),
 		ProjectName: r.URL.Query().Get("project_name"),
 		Offset:      offset,
 		Limit:       50,
+
+		UnhandledOnly: r.URL.Query().Get("unhandled") == "true",
+		Sort:          r.URL.Query().Get("sort"),
+		AssignedTo:    r.URL.Query().Get("assigned"),
+		Label:         r.URL.Query().Get("label"),
+
+		IncludeIgnored: r.URL.Query().Get("ignored") == "true",
+		// an explicit query, even an empty one, overrides the default query.
+		UseDefaultQuery: !r.URL.Query().Has("query"),
 	}
 
 	result, err := h.projectSvc.ListIssues(ctx, req)
@@ -285,6 +287,33 @@ func (h *rootHandler) index(w http.ResponseWriter, r *http.Request) {
 	h.writeIndex(w, r, result, &user)
 }
 
+// saveDefaultQuery handles the request to set the query applied to the issues list by default.
+func (h *rootHandler) saveDefaultQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUser(ctx)
+
+	req := &warnly.SaveDefaultIssuesQueryRequest{
+		User:        &user,
+		ProjectName: r.FormValue("project_name"),
+		Query:       r.FormValue("query"),
+	}
+
+	if err := h.projectSvc.SaveDefaultIssuesQuery(ctx, req); err != nil {
+		switch {
+		case errors.Is(err, warnly.ErrDefaultIssuesQueryTooLong):
+			h.writeError(ctx, w, http.StatusBadRequest, "save default query", err)
+		case errors.Is(err, warnly.ErrProjectNotFound):
+			h.writeError(ctx, w, http.StatusNotFound, "save default query", err)
+		default:
+			h.writeError(ctx, w, http.StatusInternalServerError, "save default query", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // listTagValues handles the request to list values for a tag.
 func (h *rootHandler) listTagValues(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -31,13 +31,27 @@ type Backend struct {
 	SystemService       warnly.SystemService
 	AlertService        warnly.AlertService
 	NotificationService warnly.NotificationService
+	AttachmentService   warnly.AttachmentService
+	RegroupService      warnly.RegroupService
 	OIDC                *OIDC
 	Reg                 *prometheus.Registry
 	Logger              *slog.Logger
 	CookieStore         *session.CookieStore
+	// AdminEmails are the emails of the users allowed to use the admin API.
+	AdminEmails         []string
 	RememberSessionDays int
-	IsHTTPS             bool
-	IsDemo              bool
+	IngestReadTimeout   time.Duration
+	// IngestMaxBodySize is the largest envelope in bytes that can be ingested, zero keeps the default.
+	IngestMaxBodySize int64
+	// IngestAuthLockoutThreshold is the number of ingest authentication failures within the window
+	// a source is locked out after, zero disables the lockout.
+	IngestAuthLockoutThreshold int
+	// IngestAuthLockoutWindow is how long ingest authentication failures of a source are counted.
+	IngestAuthLockoutWindow time.Duration
+	// IngestAllowedOrigins are the origins browsers may send events from, "*" allows any origin.
+	IngestAllowedOrigins []string
+	IsHTTPS              bool
+	IsDemo               bool
 }
 
 type OIDC struct {
@@ -70,15 +84,33 @@ func NewHandler(b *Backend) (*Handler, error) {
 
 	emailMatcherMw := newEmailMatcherMW(b.OIDC.EmailMatches, b.Logger)
 
+	ingestReadTimeoutMw := newBodyReadTimeoutMW(b.IngestReadTimeout, b.Now, b.Logger.With(
+		slog.String("middleware", "ingest_read_timeout"),
+	))
+
 	chainWithoutAuth := func(handler http.HandlerFunc) http.HandlerFunc {
 		handler = prometheusMw.recordLatency(handler)
 		handler = recoverMw.recover(handler)
 		csrfMiddleware := http.NewCrossOriginProtection()
 		handler = http.HandlerFunc(csrfMiddleware.Handler(handler).ServeHTTP)
-		return handler
+		return requestID(handler)
 	}
 
-	chain := func(handler http.HandlerFunc) http.HandlerFunc {
+	ingestCORSMw := newCORSMW(b.IngestAllowedOrigins)
+
+	// ingest requests are authenticated by the project key instead of the session cookie,
+	// so browser SDKs of other origins may send them.
+	chainIngest := func(handler http.HandlerFunc) http.HandlerFunc {
+		handler = ingestReadTimeoutMw.limit(handler)
+		handler = ingestCORSMw.allow(handler)
+		handler = prometheusMw.recordLatency(handler)
+		handler = recoverMw.recover(handler)
+		return requestID(handler)
+	}
+
+	// chainWritable serves changes even in read-only maintenance mode,
+	// it is only used to sign out and to turn the mode off.
+	chainWritable := func(handler http.HandlerFunc) http.HandlerFunc {
 		handler = authenticateMw.authenticate(handler)
 		if len(b.OIDC.EmailMatches) > 0 {
 			handler = emailMatcherMw.emailMatch(handler)
@@ -86,6 +118,14 @@ func NewHandler(b *Backend) (*Handler, error) {
 		return chainWithoutAuth(handler)
 	}
 
+	readOnlyMw := newReadOnlyMW(b.ProjectService, b.Logger.With(
+		slog.String("middleware", "read_only"),
+	))
+
+	chain := func(handler http.HandlerFunc) http.HandlerFunc {
+		return chainWritable(readOnlyMw.rejectChanges(handler))
+	}
+
 	systemHandler := newSystemHandler(b.SystemService, b.CookieStore, b.Logger.With(
 		slog.String("handler", "system"),
 	))
@@ -93,6 +133,19 @@ func NewHandler(b *Backend) (*Handler, error) {
 	mux.HandleFunc("GET /system/schema", chain(systemHandler.listSchemas))
 	mux.HandleFunc("GET /system/errors", chain(systemHandler.listErrors))
 
+	adminMw := newAdminMW(b.AdminEmails, b.Logger.With(
+		slog.String("middleware", "admin"),
+	))
+	mux.HandleFunc("GET /api/v1/system/slow-queries", chain(adminMw.requireAdmin(systemHandler.apiListSlowQueries)))
+	mux.HandleFunc("GET /api/v1/system/schemas", chain(adminMw.requireAdmin(systemHandler.apiListSchemas)))
+	mux.HandleFunc("GET /api/v1/system/errors", chain(adminMw.requireAdmin(systemHandler.apiListErrors)))
+
+	maintenanceHandler := newMaintenanceHandler(b.ProjectService, b.Logger.With(
+		slog.String("handler", "maintenance"),
+	))
+	mux.HandleFunc("GET /api/v1/system/read-only", chain(adminMw.requireAdmin(maintenanceHandler.getReadOnly)))
+	mux.HandleFunc("PUT /api/v1/system/read-only", chainWritable(adminMw.requireAdmin(maintenanceHandler.setReadOnly)))
+
 	settingsHandler := newSettingsHandler(b.NotificationService, b.Logger.With(
 		slog.String("handler", "settings"),
 	))
@@ -109,9 +162,11 @@ func NewHandler(b *Backend) (*Handler, error) {
 			slog.String("handler", "session"),
 		))
 
-	eventAPIHandler := NewEventAPIHandler(b.EventService, b.Logger.With(
+	eventAPIHandler := NewEventAPIHandler(b.EventService, b.Reg, b.Logger.With(
 		slog.String("handler", "event"),
 	))
+	eventAPIHandler.SetMaxEnvelopeSize(b.IngestMaxBodySize)
+	eventAPIHandler.SetAuthLockout(b.IngestAuthLockoutThreshold, b.IngestAuthLockoutWindow)
 
 	projectHandler := NewProjectHandler(b.ProjectService, b.Logger.With(
 		slog.String("handler", "project"),
@@ -125,6 +180,14 @@ func NewHandler(b *Backend) (*Handler, error) {
 		slog.String("handler", "notification"),
 	))
 
+	attachmentHandler := newAttachmentHandler(b.AttachmentService, b.Logger.With(
+		slog.String("handler", "attachment"),
+	))
+
+	regroupHandler := newRegroupHandler(b.RegroupService, b.Logger.With(
+		slog.String("handler", "regroup"),
+	))
+
 	mux.HandleFunc("GET /notready", chain(func(w http.ResponseWriter, r *http.Request) {
 		if err := web.InDevelopment().Render(r.Context(), w); err != nil {
 			b.Logger.Error("not ready web render", slog.Any("error", err))
@@ -158,22 +221,57 @@ func NewHandler(b *Backend) (*Handler, error) {
 	}))
 
 	mux.HandleFunc("GET /settings/projects/{id}", chain(projectHandler.ProjectSettings))
+	mux.HandleFunc("POST /settings/projects/{id}", chain(projectHandler.UpdateProjectSettings))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/sample-rate", chain(projectHandler.SetSampleRate))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/dedup-window", chain(projectHandler.SetDedupWindow))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/grouping", chain(projectHandler.SetGrouping))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/grouping-rules", chain(projectHandler.SetGroupingRules))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/priority-rules", chain(projectHandler.SetPriorityRules))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/code-owners", chain(projectHandler.SetCodeOwners))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/source-url-template", chain(projectHandler.SetSourceURLTemplate))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/ingest-filters", chain(projectHandler.SetIngestFilters))
+	mux.HandleFunc("PUT /projects/{project_id}/settings/ingest-signing", chain(projectHandler.SetIngestSigning))
+	mux.HandleFunc("GET /projects/{project_id}/keys", chain(projectHandler.ListKeys))
+	mux.HandleFunc("POST /projects/{project_id}/keys", chain(projectHandler.AddKey))
+	mux.HandleFunc("POST /projects/{project_id}/keys/rotate", chain(projectHandler.RotateKey))
+	mux.HandleFunc("DELETE /projects/{project_id}/keys/{key_id}", chain(projectHandler.RevokeKey))
 
 	mux.HandleFunc("GET /projects/q", chain(projectHandler.SearchProjectByName))
 	mux.HandleFunc("GET /projects/{id}", chain(projectHandler.ProjectDetails))
 	mux.HandleFunc("GET /projects", chain(projectHandler.ListProjects))
 	mux.HandleFunc("GET /projects/new", chain(projectHandler.GetPlatforms))
 	mux.HandleFunc("POST /projects", chain(projectHandler.CreateProject))
+	mux.HandleFunc("POST /projects/import", chain(projectHandler.ImportProjects))
 	mux.HandleFunc("GET /projects/{projectID}/getting-started", chain(projectHandler.GettingStarted))
 	mux.HandleFunc("DELETE /projects/{id}", chain(projectHandler.DeleteProject))
+	mux.HandleFunc("POST /projects/{id}/restore", chain(projectHandler.RestoreProject))
+	mux.HandleFunc("POST /projects/{project_id}/transfer", chain(projectHandler.TransferProject))
 	mux.HandleFunc("GET /projects/{project_id}/issues/{issue_id}", chain(projectHandler.GetIssue))
 	mux.HandleFunc("GET /projects/{project_id}/issues/{issue_id}/discussions", chain(projectHandler.GetDiscussions))
 	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/discussions", chain(projectHandler.PostMessage))
 	mux.HandleFunc("DELETE /projects/{project_id}/issues/{issue_id}/discussions/{message_id}", chain(projectHandler.DeleteMessage))
 	mux.HandleFunc("GET /projects/{project_id}/issues/{issue_id}/fields", chain(projectHandler.ListFields))
 	mux.HandleFunc("GET /projects/{project_id}/issues/{issue_id}/events", chain(projectHandler.ListEvents))
+	mux.HandleFunc("GET /projects/{project_id}/issues/{issue_id}/events/{event_id}/raw", chain(projectHandler.GetRawEvent))
+	mux.HandleFunc("GET /projects/{project_id}/releases/adoption", chain(projectHandler.ReleaseAdoption))
+	mux.HandleFunc("GET /projects/{project_id}/compare", chain(projectHandler.CompareWindows))
+	mux.HandleFunc("GET /projects/{project_id}/discarded-events", chain(projectHandler.ListDiscardedEvents))
+	mux.HandleFunc("GET /projects/{project_id}/tags/{key}/values", chain(projectHandler.SuggestTagValues))
+	mux.HandleFunc("GET /projects/{project_id}/events/{event_id}/attachments/{filename}", chain(attachmentHandler.downloadAttachment))
+	mux.HandleFunc("POST /projects/{project_id}/regroup", chain(regroupHandler.startRegroup))
+	mux.HandleFunc("GET /projects/{project_id}/regroup", chain(regroupHandler.getRegroup))
+	mux.HandleFunc("DELETE /projects/{project_id}/regroup", chain(regroupHandler.cancelRegroup))
 	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/assignments", chain(projectHandler.AssignIssue))
 	mux.HandleFunc("DELETE /projects/{project_id}/issues/{issue_id}/assignments", chain(projectHandler.DeleteAssignment))
+	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/resolve", chain(projectHandler.ResolveIssue))
+	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/ignore", chain(projectHandler.IgnoreIssue))
+	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/subscription", chain(projectHandler.SubscribeIssue))
+	mux.HandleFunc("DELETE /projects/{project_id}/issues/{issue_id}/subscription", chain(projectHandler.UnsubscribeIssue))
+	mux.HandleFunc("GET /projects/{project_id}/issues/{issue_id}/labels", chain(projectHandler.ListIssueLabels))
+	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/labels", chain(projectHandler.AddIssueLabel))
+	mux.HandleFunc("DELETE /projects/{project_id}/issues/{issue_id}/labels/{label}", chain(projectHandler.RemoveIssueLabel))
+	mux.HandleFunc("POST /projects/{project_id}/issues/{issue_id}/noise-filters", chain(projectHandler.AddNoiseFilter))
+	mux.HandleFunc("DELETE /projects/{project_id}/issues/{issue_id}/noise-filters", chain(projectHandler.RemoveNoiseFilter))
 
 	mux.HandleFunc("GET /alerts", chain(alertsHandler.ListAlerts))
 	mux.HandleFunc("GET /alerts/new", chain(alertsHandler.CreateAlertGet))
@@ -181,6 +279,7 @@ func NewHandler(b *Backend) (*Handler, error) {
 	mux.HandleFunc("GET /alerts/{id}/edit", chain(alertsHandler.EditAlertGet))
 	mux.HandleFunc("PUT /alerts/{id}", chain(alertsHandler.UpdateAlert))
 	mux.HandleFunc("DELETE /alerts/{id}", chain(alertsHandler.DeleteAlert))
+	mux.HandleFunc("POST /alerts/ack/{token}", chainWithoutAuth(readOnlyMw.rejectChanges(alertsHandler.AcknowledgeAlert)))
 
 	mux.HandleFunc("POST /settings/webhook", chain(notificationHandler.SaveWebhook))
 
@@ -209,9 +308,19 @@ func NewHandler(b *Backend) (*Handler, error) {
 	mux.HandleFunc("GET /", chain(rootHandler.index))
 	mux.HandleFunc("GET /oidc/{provider_name}/callback", chainWithoutAuth(rootHandler.oidcCallback))
 	mux.HandleFunc("GET /api/search/tag-values", chain(rootHandler.listTagValues))
-	mux.HandleFunc("DELETE /session", chain(rootHandler.destroy))
-
-	mux.HandleFunc("POST /ingest/api/{project_id}/envelope/", chainWithoutAuth(eventAPIHandler.IngestEvent))
+	mux.HandleFunc("PUT /issues/default-query", chain(rootHandler.saveDefaultQuery))
+	mux.HandleFunc("DELETE /session", chainWritable(rootHandler.destroy))
+	mux.HandleFunc("POST /teams/{team_id}/invitations", chain(rootHandler.invite))
+	mux.HandleFunc("GET /invitations/{token}", chainWithoutAuth(rootHandler.getInvitation))
+	mux.HandleFunc("POST /invitations/{token}", chainWithoutAuth(readOnlyMw.rejectChanges(rootHandler.acceptInvitation)))
+
+	mux.HandleFunc("POST /ingest/api/{project_id}/envelope/", chainIngest(eventAPIHandler.IngestEvent))
+	mux.HandleFunc("OPTIONS /ingest/api/{project_id}/envelope/", chainIngest(ingestCORSMw.preflight))
+	mux.HandleFunc("POST /ingest/api/{project_id}/store/", chainIngest(eventAPIHandler.StoreEvent))
+	mux.HandleFunc("OPTIONS /ingest/api/{project_id}/store/", chainIngest(ingestCORSMw.preflight))
+	mux.HandleFunc("POST /ingest/batch", chainIngest(eventAPIHandler.IngestBatch))
+	mux.HandleFunc("OPTIONS /ingest/batch", chainIngest(ingestCORSMw.preflight))
+	mux.HandleFunc("GET /ingest/envelope-schema", chainIngest(eventAPIHandler.EnvelopeSchema))
 
 	return &Handler{ServeMux: mux}, nil
 }
@@ -1,8 +1,12 @@
 package server_test
 
 import (
+	"bytes"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vk-rv/warnly/internal/server"
 	"github.com/vk-rv/warnly/internal/session"
+	"github.com/vk-rv/warnly/internal/stdlog"
 )
 
 func TestNewHandlerReturnsValidHandler(t *testing.T) {
@@ -43,3 +48,133 @@ func TestNewHandlerReturnsValidHandler(t *testing.T) {
 	assert.NotNil(t, handler)
 	assert.NotNil(t, handler.ServeMux)
 }
+
+func TestNewHandlerIngestCORS(t *testing.T) {
+	t.Parallel()
+
+	const origin = "https://app.example.com"
+
+	newHandler := func(t *testing.T, origins []string) *server.Handler {
+		t.Helper()
+		handler, err := server.NewHandler(&server.Backend{
+			Now:                  time.Now,
+			EventService:         NewTestEventService(nil),
+			OIDC:                 &server.OIDC{ProviderName: "test"},
+			Reg:                  prometheus.NewRegistry(),
+			Logger:               slog.New(slog.DiscardHandler),
+			CookieStore:          session.NewCookieStore(time.Now, []byte("test-secret-key")),
+			IngestAllowedOrigins: origins,
+		})
+		require.NoError(t, err)
+		return handler
+	}
+
+	preflight := func(path string) *http.Request {
+		r := httptest.NewRequestWithContext(t.Context(), http.MethodOptions, path, nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		r.Header.Set("Access-Control-Request-Headers", "content-type,x-sentry-auth")
+		return r
+	}
+
+	t.Run("preflight", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newHandler(t, []string{"*"})
+		for _, path := range []string{"/ingest/api/1/envelope/", "/ingest/api/1/store/", "/ingest/batch"} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, preflight(path))
+
+			assert.Equal(t, http.StatusNoContent, w.Code, path)
+			assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"), path)
+			assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodPost, path)
+			assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "X-Sentry-Auth", path)
+		}
+	})
+
+	t.Run("cross-origin post", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newHandler(t, []string{"*"})
+		w, r := getIngestRequest(t.Context(), body)
+		r.URL.Path = "/ingest/api/" + testProjectIDStr + "/envelope/"
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Sec-Fetch-Site", "cross-site")
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("configured origins", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newHandler(t, []string{"https://other.example.com", origin + "/"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, preflight("/ingest/batch"))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, origin, w.Header().Get("Access-Control-Allow-Origin"))
+
+		r := preflight("/ingest/batch")
+		r.Header.Set("Origin", "https://evil.example.com")
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("app routes", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newHandler(t, []string{"*"})
+		r := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login", strings.NewReader("email=a@example.com"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Sec-Fetch-Site", "cross-site")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "app routes keep the cross-origin protection")
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestNewHandlerRequestID(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	handler, err := server.NewHandler(&server.Backend{
+		Now:          time.Now,
+		EventService: NewTestEventService(nil),
+		OIDC:         &server.OIDC{ProviderName: "test"},
+		Reg:          prometheus.NewRegistry(),
+		Logger:       stdlog.NewSlogLogger(&logs, false),
+		CookieStore:  session.NewCookieStore(time.Now, []byte("test-secret-key")),
+	})
+	require.NoError(t, err)
+
+	ingest := func(requestID string) *httptest.ResponseRecorder {
+		w, r := getIngestRequest(t.Context(), body)
+		r.URL.Path = "/ingest/api/invalid/envelope/"
+		if requestID != "" {
+			r.Header.Set("X-Request-ID", requestID)
+		}
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	const requestID = "f9a5e1c2-client-id"
+	for range 2 {
+		w := ingest(requestID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, requestID, w.Header().Get("X-Request-ID"), "the client request ID is echoed")
+	}
+	assert.Contains(t, logs.String(), `"request_id":"`+requestID+`"`, "the error log includes the request ID")
+
+	generated := ingest("").Header().Get("X-Request-ID")
+	assert.Len(t, generated, 36)
+	assert.NotEqual(t, generated, ingest("").Header().Get("X-Request-ID"), "every request gets its own ID")
+
+	assert.NotEqual(t, "id\nforged", ingest("id\nforged").Header().Get("X-Request-ID"), "IDs that can't be logged are replaced")
+	assert.Len(t, ingest(strings.Repeat("a", 129)).Header().Get("X-Request-ID"), 36)
+}
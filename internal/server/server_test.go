@@ -67,32 +67,40 @@ func TestMain(m *testing.M) {
 }
 
 type testStores struct {
-	projectStore    warnly.ProjectStore
-	assingmentStore warnly.AssingmentStore
-	messageStore    warnly.MessageStore
-	mentionStore    warnly.MentionStore
-	teamStore       warnly.TeamStore
-	userStore       warnly.UserStore
-	issueStore      warnly.IssueStore
-	memoryCache     *cache.Cache
-	olap            *ch.ClickhouseStore
-	uow             uow.StartUnitOfWork
+	projectStore      warnly.ProjectStore
+	assingmentStore   warnly.AssingmentStore
+	messageStore      warnly.MessageStore
+	mentionStore      warnly.MentionStore
+	activityStore     warnly.ActivityStore
+	subscriptionStore warnly.SubscriptionStore
+	labelStore        warnly.IssueLabelStore
+	seenStore         warnly.SeenStore
+	teamStore         warnly.TeamStore
+	userStore         warnly.UserStore
+	issueStore        warnly.IssueStore
+	memoryCache       *cache.Cache
+	olap              *ch.ClickhouseStore
+	uow               uow.StartUnitOfWork
 }
 
 func getTestStores(testDB *sql.DB, testOlapDB clickhouse.Conn, logger *slog.Logger) testStores {
 	olap := ch.NewClickhouseStore(testOlapDB, svcotel.NewNoopProvider())
 	olap.EnableAsyncInsertWait()
 	return testStores{
-		projectStore:    mysql.NewProjectStore(testDB),
-		assingmentStore: mysql.NewAssingmentStore(testDB),
-		messageStore:    mysql.NewMessageStore(testDB),
-		mentionStore:    mysql.NewMentionStore(testDB),
-		teamStore:       mysql.NewTeamStore(testDB),
-		userStore:       mysql.NewUserStore(testDB),
-		issueStore:      mysql.NewIssueStore(testDB),
-		memoryCache:     cache.New(5*time.Minute, 10*time.Minute),
-		olap:            olap,
-		uow:             mysql.NewUOW(testDB, logger),
+		projectStore:      mysql.NewProjectStore(testDB),
+		assingmentStore:   mysql.NewAssingmentStore(testDB),
+		messageStore:      mysql.NewMessageStore(testDB),
+		mentionStore:      mysql.NewMentionStore(testDB),
+		activityStore:     mysql.NewActivityStore(testDB),
+		subscriptionStore: mysql.NewSubscriptionStore(testDB),
+		labelStore:        mysql.NewIssueLabelStore(testDB),
+		seenStore:         mysql.NewSeenStore(testDB),
+		teamStore:         mysql.NewTeamStore(testDB),
+		userStore:         mysql.NewUserStore(testDB),
+		issueStore:        mysql.NewIssueStore(testDB),
+		memoryCache:       cache.New(5*time.Minute, 10*time.Minute),
+		olap:              olap,
+		uow:               mysql.NewUOW(testDB, logger),
 	}
 }
 
@@ -151,6 +159,20 @@ func getListProjectsRequest(ctx context.Context, name string, teamID int) (*http
 	return w, r
 }
 
+func getRawEventRequest(ctx context.Context, issueID int, eventID string) (*httptest.ResponseRecorder, *http.Request) {
+	path := fmt.Sprintf("/projects/%s/issues/%d/events/%s/raw", testProjectIDStr, issueID, eventID)
+	r := httptest.NewRequestWithContext(
+		server.NewContextWithUser(ctx, testUser),
+		http.MethodGet,
+		path,
+		http.NoBody)
+	r.SetPathValue(testProjectIDKey, testProjectIDStr)
+	r.SetPathValue("issue_id", strconv.Itoa(issueID))
+	r.SetPathValue("event_id", eventID)
+	w := httptest.NewRecorder()
+	return w, r
+}
+
 func getDeleteProjectRequest(ctx context.Context, projectID int) (*httptest.ResponseRecorder, *http.Request) {
 	path := fmt.Sprintf("/projects/%d", projectID)
 	r := httptest.NewRequestWithContext(
@@ -187,7 +209,7 @@ func setupTestUserAndTeam(ctx context.Context, s testStores, now time.Time) erro
 	}); err != nil {
 		return err
 	}
-	if err := s.teamStore.AddUserToTeam(ctx, now, int64(testOwnerID), testOwnerID); err != nil {
+	if err := s.teamStore.AddUserToTeam(ctx, now, int64(testOwnerID), testOwnerID, warnly.RoleAdmin); err != nil {
 		return err
 	}
 	return nil
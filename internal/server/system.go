@@ -2,6 +2,7 @@
 package server
 
 import (
+	"encoding/json"
 	"log/slog"
 	"net/http"
 
@@ -76,6 +77,56 @@ func (h *systemHandler) listErrors(w http.ResponseWriter, r *http.Request) {
 	h.writeErrors(w, r, result, &user)
 }
 
+// apiListSlowQueries returns slow queries from olap as JSON.
+func (h *systemHandler) apiListSlowQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := h.svc.ListSlowQueries(ctx)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusInternalServerError, "api list slow queries", err)
+		return
+	}
+
+	writeSystemJSON(h, w, "api list slow queries", result)
+}
+
+// apiListSchemas returns olap database schemas from largest to smallest as JSON.
+func (h *systemHandler) apiListSchemas(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := h.svc.ListSchemas(ctx)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusInternalServerError, "api list schemas", err)
+		return
+	}
+
+	writeSystemJSON(h, w, "api list schemas", result)
+}
+
+// apiListErrors returns recent errors from olap system for the last 24 hours as JSON.
+func (h *systemHandler) apiListErrors(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := h.svc.ListErrors(ctx)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusInternalServerError, "api list errors", err)
+		return
+	}
+
+	writeSystemJSON(h, w, "api list errors", result)
+}
+
+// writeSystemJSON writes the result as JSON, empty results are written as an empty array.
+func writeSystemJSON[T any](h *systemHandler, w http.ResponseWriter, msg string, result []T) {
+	if result == nil {
+		result = []T{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Error(msg+": encode", slog.Any("error", err))
+	}
+}
+
 // writeQueriesResponse writes slow queries response.
 func (h *systemHandler) writeQueriesResponse(
 	w http.ResponseWriter,
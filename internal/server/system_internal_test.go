@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+type testSystemService struct {
+	queries []warnly.SQLQuery
+	schemas []warnly.Schema
+	errs    []warnly.AnalyticsStoreErr
+}
+
+func (s *testSystemService) ListSlowQueries(context.Context) ([]warnly.SQLQuery, error) {
+	return s.queries, nil
+}
+
+func (s *testSystemService) ListSchemas(context.Context) ([]warnly.Schema, error) {
+	return s.schemas, nil
+}
+
+func (s *testSystemService) ListErrors(context.Context) ([]warnly.AnalyticsStoreErr, error) {
+	return s.errs, nil
+}
+
+func TestSystemAPI(t *testing.T) {
+	t.Parallel()
+
+	svc := &testSystemService{
+		queries: []warnly.SQLQuery{{NormalizedQuery: "SELECT 1", NormalizedQueryHash: "abc", TotalCalls: 7, AvgDuration: 1.5}},
+		schemas: []warnly.Schema{{Name: "event", Engine: "ReplacingMergeTree", TotalRows: 42, TotalBytes: 1024}},
+	}
+	h := newSystemHandler(svc, nil, slog.Default())
+	admin := newAdminMW([]string{"admin@example.com", " Ops@Example.com "}, slog.Default())
+
+	tests := []struct {
+		handler    http.HandlerFunc
+		name       string
+		email      string
+		wantBody   string
+		wantStatus int
+	}{
+		{
+			name:       "slow queries",
+			handler:    h.apiListSlowQueries,
+			email:      "admin@example.com",
+			wantStatus: http.StatusOK,
+			wantBody: `[{"normalized_query":"SELECT 1","total_read_bytes":"","normalized_query_hash":"abc",
+				"total_read_bytes_numeric":0,"avg_duration":1.5,"avg_result_rows":0,"calls_per_minute":0,"total_calls":7,
+				"percentage_iops":0,"percentage_runtime":0,"percent":0,"read_bytes":0}]`,
+		},
+		{
+			name:       "schemas",
+			handler:    h.apiListSchemas,
+			email:      "ops@example.com",
+			wantStatus: http.StatusOK,
+			wantBody: `[{"name":"event","readable_bytes":"","engine":"ReplacingMergeTree","partition_key":"",
+				"total_bytes":1024,"total_rows":42}]`,
+		},
+		{
+			name:       "no errors",
+			handler:    h.apiListErrors,
+			email:      "ADMIN@example.com",
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name:       "regular user",
+			handler:    h.apiListSchemas,
+			email:      "user@example.com",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := NewContextWithUser(t.Context(), warnly.User{ID: 2, Email: tt.email})
+			r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/system", http.NoBody)
+			w := httptest.NewRecorder()
+
+			admin.requireAdmin(tt.handler)(w, r)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestSystemAPIErrorsShape(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	svc := &testSystemService{errs: []warnly.AnalyticsStoreErr{{Name: "TOO_MANY_PARTS", Count: 3, MaxLastErrorTime: at}}}
+	h := newSystemHandler(svc, nil, slog.Default())
+
+	ctx := NewContextWithUser(t.Context(), warnly.User{ID: 1, Email: "admin@example.com"})
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/system/errors", http.NoBody)
+	w := httptest.NewRecorder()
+
+	h.apiListErrors(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, []map[string]any{{
+		"name":                "TOO_MANY_PARTS",
+		"count":               float64(3),
+		"max_last_error_time": "2025-03-01T10:00:00Z",
+	}}, got)
+}
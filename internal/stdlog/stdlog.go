@@ -3,11 +3,49 @@
 package stdlog
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 )
 
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID, records logged with the context include it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by the context or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler adds the request ID of the context to the records it handles.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds the request ID attribute and calls the underlying handler.
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns the handler wrapping the underlying handler with the attributes.
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns the handler wrapping the underlying handler with the group.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
 // SlogLogger is the implementation of Logger using slog.
 type SlogLogger struct {
 	logger *slog.Logger
@@ -18,7 +56,8 @@ func NewLogger(logger *slog.Logger) *SlogLogger {
 	return &SlogLogger{logger: logger}
 }
 
-// NewSlogLogger creates a new slog.Logger instance with the specified writer and format.
+// NewSlogLogger creates a new slog.Logger instance with the specified writer and format,
+// records logged with a context carrying a request ID include it.
 func NewSlogLogger(w io.Writer, isText bool) *slog.Logger {
 	var handler slog.Handler
 	if isText {
@@ -26,7 +65,7 @@ func NewSlogLogger(w io.Writer, isText bool) *slog.Logger {
 	} else {
 		handler = slog.NewJSONHandler(w, nil)
 	}
-	return slog.New(handler)
+	return slog.New(contextHandler{Handler: handler})
 }
 
 // Logf logs informational messages using slog's Info level.
@@ -117,3 +117,24 @@ func TestNewSlogLogger_JSON(t *testing.T) {
 		t.Errorf("output does not contain expected JSON message: %s", output)
 	}
 }
+
+func TestNewSlogLogger_RequestID(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := stdlog.NewSlogLogger(&buf, false).With(slog.String("handler", "event"))
+
+	logger.ErrorContext(stdlog.WithRequestID(t.Context(), "req-1"), "ingest client error")
+	logger.Error("no context")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines: %s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-1"`) {
+		t.Errorf("output does not contain the request ID: %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("output without a request ID in the context contains one: %s", lines[1])
+	}
+}
@@ -84,6 +84,19 @@ func (s *AlertService) ListAlerts(ctx context.Context, req *warnly.ListAlertsReq
 
 // CreateAlert creates a new alert.
 func (s *AlertService) CreateAlert(ctx context.Context, req *warnly.CreateAlertRequest) (*warnly.Alert, error) {
+	if !req.Condition.IsValid() {
+		return nil, warnly.ErrInvalidAlertCondition
+	}
+
+	baselineWindows, spikeMultiplier, err := spikeSettings(req.Condition, req.BaselineWindows, req.SpikeMultiplier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := warnly.ValidateEscalation(req.HighPriority, req.EscalationChain, req.EscalationWindow); err != nil {
+		return nil, err
+	}
+
 	project, err := s.projectStore.GetProject(ctx, req.ProjectID)
 	if err != nil {
 		return nil, err
@@ -106,26 +119,24 @@ func (s *AlertService) CreateAlert(ctx context.Context, req *warnly.CreateAlertR
 		return nil, errors.New("user does not have access to this project")
 	}
 
-	description := fmt.Sprintf("Alert when more than %d %s in %s",
-		req.Threshold,
-		getConditionText(req.Condition),
-		getTimeframeText(req.Timeframe),
-	)
-
 	now := s.now().UTC()
 	alert := &warnly.Alert{
-		CreatedAt:    now,
-		UpdatedAt:    now,
-		RuleName:     req.RuleName,
-		Description:  description,
-		Status:       warnly.AlertStatusActive,
-		ProjectID:    req.ProjectID,
-		TeamID:       project.TeamID,
-		Threshold:    req.Threshold,
-		Condition:    req.Condition,
-		Timeframe:    req.Timeframe,
-		HighPriority: req.HighPriority,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		RuleName:         req.RuleName,
+		Status:           warnly.AlertStatusActive,
+		ProjectID:        req.ProjectID,
+		TeamID:           project.TeamID,
+		Threshold:        req.Threshold,
+		Condition:        req.Condition,
+		Timeframe:        req.Timeframe,
+		BaselineWindows:  baselineWindows,
+		SpikeMultiplier:  spikeMultiplier,
+		EscalationChain:  req.EscalationChain,
+		EscalationWindow: req.EscalationWindow,
+		HighPriority:     req.HighPriority,
 	}
+	alert.Description = describeAlert(alert)
 
 	if err := s.alertStore.CreateAlert(ctx, alert); err != nil {
 		return nil, err
@@ -136,11 +147,28 @@ func (s *AlertService) CreateAlert(ctx context.Context, req *warnly.CreateAlertR
 
 // UpdateAlert updates an existing alert.
 func (s *AlertService) UpdateAlert(ctx context.Context, req *warnly.UpdateAlertRequest) (*warnly.Alert, error) {
+	if !req.Condition.IsValid() {
+		return nil, warnly.ErrInvalidAlertCondition
+	}
+
+	baselineWindows, spikeMultiplier, err := spikeSettings(req.Condition, req.BaselineWindows, req.SpikeMultiplier)
+	if err != nil {
+		return nil, err
+	}
+
 	alert, err := s.alertStore.GetAlert(ctx, req.AlertID)
 	if err != nil {
 		return nil, err
 	}
 
+	chain, window := req.EscalationChain, req.EscalationWindow
+	if req.KeepEscalation && req.HighPriority {
+		chain, window = alert.EscalationChain, alert.EscalationWindow
+	}
+	if err := warnly.ValidateEscalation(req.HighPriority, chain, window); err != nil {
+		return nil, err
+	}
+
 	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
 	if err != nil {
 		return nil, err
@@ -162,16 +190,16 @@ func (s *AlertService) UpdateAlert(ctx context.Context, req *warnly.UpdateAlertR
 	alert.Threshold = req.Threshold
 	alert.Condition = req.Condition
 	alert.Timeframe = req.Timeframe
+	alert.BaselineWindows = baselineWindows
+	alert.SpikeMultiplier = spikeMultiplier
 	alert.HighPriority = req.HighPriority
+	alert.EscalationChain = chain
+	alert.EscalationWindow = window
 	if req.Status != "" {
 		alert.Status = req.Status
 	}
 
-	alert.Description = fmt.Sprintf("Alert when more than %d %s in %s",
-		req.Threshold,
-		getConditionText(req.Condition),
-		getTimeframeText(req.Timeframe),
-	)
+	alert.Description = describeAlert(alert)
 
 	if err := s.alertStore.UpdateAlert(ctx, alert); err != nil {
 		return nil, err
@@ -238,6 +266,53 @@ func (s *AlertService) GetAlert(ctx context.Context, alertID int, user *warnly.U
 	return alert, nil
 }
 
+// AcknowledgeAlert stops the escalation of the triggered alert the token was issued for.
+// The token is sent with the alert notifications and is the only credential needed.
+func (s *AlertService) AcknowledgeAlert(ctx context.Context, ackToken string) error {
+	if ackToken == "" {
+		return fmt.Errorf("alert service: acknowledge alert: %w", warnly.ErrNotFound)
+	}
+
+	return s.alertStore.AcknowledgeAlert(ctx, ackToken, s.now().UTC())
+}
+
+// spikeSettings fills in the default baseline and multiplier and validates them for spike alerts.
+func spikeSettings(condition warnly.AlertCondition, baselineWindows int, multiplier float64) (int, float64, error) {
+	if baselineWindows == 0 {
+		baselineWindows = warnly.DefaultBaselineWindows
+	}
+	if multiplier == 0 {
+		multiplier = warnly.DefaultSpikeMultiplier
+	}
+	if condition == warnly.AlertConditionSpike {
+		if err := warnly.ValidateSpikeSettings(baselineWindows, multiplier); err != nil {
+			return 0, 0, err
+		}
+	}
+	return baselineWindows, multiplier, nil
+}
+
+func describeAlert(alert *warnly.Alert) string {
+	switch alert.Condition {
+	case warnly.AlertConditionNewIssue:
+		return "Alert when a new issue is first seen"
+	case warnly.AlertConditionReoccurred:
+		return "Alert when a resolved issue reoccurs within " + getTimeframeText(alert.Timeframe)
+	case warnly.AlertConditionSpike:
+		return fmt.Sprintf("Alert when events in %s exceed %gx the average of the previous %d",
+			getTimeframeText(alert.Timeframe),
+			alert.SpikeMultiplier,
+			alert.BaselineWindows,
+		)
+	default:
+		return fmt.Sprintf("Alert when more than %d %s in %s",
+			alert.Threshold,
+			getConditionText(alert.Condition),
+			getTimeframeText(alert.Timeframe),
+		)
+	}
+}
+
 func getConditionText(condition warnly.AlertCondition) string {
 	switch condition {
 	case warnly.AlertConditionOccurrences:
@@ -0,0 +1,117 @@
+// Package assignment implements automatic assignment of new issues.
+package assignment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// StrategyRoundRobin is the name of the round-robin strategy in configuration.
+const StrategyRoundRobin = "round_robin"
+
+// AutoAssigner assigns new issues using the first strategy that picks an assignee.
+// More specific strategies (e.g. assignment rules) go first, round-robin is a fallback
+// that always picks someone as long as the team has active members.
+type AutoAssigner struct {
+	assingmentStore warnly.AssingmentStore
+	now             func() time.Time
+	logger          *slog.Logger
+	strategies      []warnly.AssignmentStrategy
+}
+
+// NewAutoAssigner creates a new AutoAssigner, strategies are tried in order.
+func NewAutoAssigner(
+	assingmentStore warnly.AssingmentStore,
+	now func() time.Time,
+	logger *slog.Logger,
+	strategies ...warnly.AssignmentStrategy,
+) *AutoAssigner {
+	return &AutoAssigner{
+		assingmentStore: assingmentStore,
+		now:             now,
+		logger:          logger,
+		strategies:      strategies,
+	}
+}
+
+// AssignNewIssue assigns the issue to the user picked by the first matching strategy.
+// Issues nobody is picked for are left unassigned.
+func (a *AutoAssigner) AssignNewIssue(ctx context.Context, issue *warnly.Issue, teamID int) error {
+	for _, strategy := range a.strategies {
+		userID, ok, err := strategy.PickAssignee(ctx, issue, teamID)
+		if err != nil {
+			return fmt.Errorf("auto assign issue %d: %w", issue.ID, err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := a.assingmentStore.CreateAssingment(ctx, &warnly.Assignment{
+			AssigneeType:     warnly.AssigneeUser,
+			IssueID:          issue.ID,
+			AssignedToUserID: userID,
+			AssignedByUserID: 0, // automatic assignment
+			AssignedAt:       a.now().UTC(),
+		}); err != nil {
+			return fmt.Errorf("auto assign issue %d: %w", issue.ID, err)
+		}
+
+		a.logger.DebugContext(ctx, "issue assigned automatically",
+			slog.Int64("issue_id", issue.ID),
+			slog.Int64("user_id", userID),
+			slog.Int("team_id", teamID))
+
+		return nil
+	}
+
+	return nil
+}
+
+// RoundRobin rotates assignment of new issues among active members of the team.
+type RoundRobin struct {
+	teamStore       warnly.TeamStore
+	assingmentStore warnly.AssingmentStore
+	// mu serializes picks within the instance so that simultaneous issues
+	// don't land on the same member.
+	mu sync.Mutex
+}
+
+// NewRoundRobin creates a new round-robin assignment strategy.
+func NewRoundRobin(teamStore warnly.TeamStore, assingmentStore warnly.AssingmentStore) *RoundRobin {
+	return &RoundRobin{
+		teamStore:       teamStore,
+		assingmentStore: assingmentStore,
+	}
+}
+
+// PickAssignee picks the active member that follows the last assigned one.
+func (r *RoundRobin) PickAssignee(ctx context.Context, _ *warnly.Issue, teamID int) (int64, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members, err := r.teamStore.ListTeammates(ctx, []int{teamID})
+	if err != nil {
+		return 0, false, fmt.Errorf("round robin: list teammates: %w", err)
+	}
+
+	lastUserID, err := r.assingmentStore.GetLastRotationAssignee(ctx, teamID)
+	if err != nil {
+		return 0, false, fmt.Errorf("round robin: %w", err)
+	}
+
+	next, ok := warnly.NextInRotation(members, lastUserID)
+	if !ok {
+		return 0, false, nil
+	}
+
+	if err := r.assingmentStore.SaveLastRotationAssignee(ctx, teamID, next.ID); err != nil {
+		return 0, false, fmt.Errorf("round robin: %w", err)
+	}
+
+	return next.ID, true, nil
+}
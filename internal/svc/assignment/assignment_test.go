@@ -0,0 +1,116 @@
+package assignment_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/svc/assignment"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+const teamID = 10
+
+func newAssingmentStore(assigned *[]int64) *mock.AssingmentStore {
+	var last int64
+	return &mock.AssingmentStore{
+		GetLastRotationAssigneeFn: func(_ context.Context, _ int) (int64, error) {
+			return last, nil
+		},
+		SaveLastRotationAssigneeFn: func(_ context.Context, _ int, userID int64) error {
+			last = userID
+			return nil
+		},
+		CreateAssingmentFn: func(_ context.Context, a *warnly.Assignment) error {
+			*assigned = append(*assigned, a.AssignedToUserID)
+			return nil
+		},
+	}
+}
+
+func newTeamStore(members []warnly.Teammate) *mock.TeamStore {
+	return &mock.TeamStore{
+		ListTeammatesFn: func(_ context.Context, teamIDs []int) ([]warnly.Teammate, error) {
+			if len(teamIDs) != 1 || teamIDs[0] != teamID {
+				return nil, errors.New("unexpected team")
+			}
+			return members, nil
+		},
+	}
+}
+
+func newAutoAssigner(assingmentStore warnly.AssingmentStore, strategies ...warnly.AssignmentStrategy) *assignment.AutoAssigner {
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return assignment.NewAutoAssigner(assingmentStore, now, slog.Default(), strategies...)
+}
+
+func TestRoundRobinDistributesAcrossActiveMembers(t *testing.T) {
+	t.Parallel()
+
+	members := []warnly.Teammate{
+		{ID: 1, Username: "alice", Active: true},
+		{ID: 2, Username: "bob", Active: false},
+		{ID: 3, Username: "carol", Active: true},
+		{ID: 4, Username: "dave", Active: true},
+	}
+
+	var assigned []int64
+	assingmentStore := newAssingmentStore(&assigned)
+	assigner := newAutoAssigner(assingmentStore, assignment.NewRoundRobin(newTeamStore(members), assingmentStore))
+
+	for i := range 6 {
+		err := assigner.AssignNewIssue(t.Context(), &warnly.Issue{ID: int64(100 + i)}, teamID)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []int64{1, 3, 4, 1, 3, 4}, assigned)
+}
+
+func TestRoundRobinLeavesIssueUnassignedWithoutActiveMembers(t *testing.T) {
+	t.Parallel()
+
+	members := []warnly.Teammate{
+		{ID: 2, Username: "bob", Active: false},
+	}
+
+	var assigned []int64
+	assingmentStore := newAssingmentStore(&assigned)
+	assigner := newAutoAssigner(assingmentStore, assignment.NewRoundRobin(newTeamStore(members), assingmentStore))
+
+	require.NoError(t, assigner.AssignNewIssue(t.Context(), &warnly.Issue{ID: 100}, teamID))
+	assert.Empty(t, assigned)
+}
+
+// fixedStrategy picks the same user for every issue, or nobody when userID is zero.
+type fixedStrategy struct {
+	userID int64
+}
+
+func (f fixedStrategy) PickAssignee(_ context.Context, _ *warnly.Issue, _ int) (int64, bool, error) {
+	return f.userID, f.userID != 0, nil
+}
+
+func TestAutoAssignerUsesFirstMatchingStrategy(t *testing.T) {
+	t.Parallel()
+
+	members := []warnly.Teammate{
+		{ID: 1, Username: "alice", Active: true},
+		{ID: 3, Username: "carol", Active: true},
+	}
+
+	var assigned []int64
+	assingmentStore := newAssingmentStore(&assigned)
+	roundRobin := assignment.NewRoundRobin(newTeamStore(members), assingmentStore)
+
+	require.NoError(t, newAutoAssigner(assingmentStore, fixedStrategy{userID: 42}, roundRobin).
+		AssignNewIssue(t.Context(), &warnly.Issue{ID: 100}, teamID))
+	require.NoError(t, newAutoAssigner(assingmentStore, fixedStrategy{}, roundRobin).
+		AssignNewIssue(t.Context(), &warnly.Issue{ID: 101}, teamID))
+
+	assert.Equal(t, []int64{42, 1}, assigned)
+}
@@ -0,0 +1,63 @@
+// Package attachment provides the implementation of the warnly.AttachmentService interface.
+package attachment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// AttachmentService implements warnly.AttachmentService interface.
+type AttachmentService struct {
+	attachmentStore warnly.AttachmentStore
+	projectStore    warnly.ProjectStore
+	teamStore       warnly.TeamStore
+}
+
+// NewAttachmentService is a constructor of AttachmentService.
+// attachmentStore may be nil when attachments are not stored, then no attachment is found.
+func NewAttachmentService(
+	attachmentStore warnly.AttachmentStore,
+	projectStore warnly.ProjectStore,
+	teamStore warnly.TeamStore,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentStore: attachmentStore,
+		projectStore:    projectStore,
+		teamStore:       teamStore,
+	}
+}
+
+// GetAttachment returns the attachment of an event of a project that belongs to one of the user teams.
+func (s *AttachmentService) GetAttachment(
+	ctx context.Context,
+	req *warnly.GetAttachmentRequest,
+) (*warnly.Attachment, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectStore.GetProject(ctx, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := false
+	for i := range teams {
+		if teams[i].ID == project.TeamID {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return nil, warnly.ErrProjectNotFound
+	}
+
+	if s.attachmentStore == nil {
+		return nil, fmt.Errorf("attachments are not stored: %w", warnly.ErrNotFound)
+	}
+
+	return s.attachmentStore.GetAttachment(ctx, project.ID, req.EventID, req.Filename)
+}
@@ -4,13 +4,21 @@ package event
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"net"
 	"net/netip"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -26,8 +34,18 @@ type EventService struct {
 	cache        *cache.Cache
 	sf           *singleflight.Group
 	olap         warnly.AnalyticsStore
+	autoAssigner warnly.IssueAutoAssigner
+	attachments  warnly.AttachmentStore
+	notifier     warnly.IssueNotifier
+	subscribers  Subscribers
 	now          func() time.Time
+	logger       *slog.Logger
 	queue        Queue
+	confirmation Confirmation
+	idempotency  Idempotency
+	unknown      UnknownProjects
+	// dropped counts events discarded by sampling per project, values are *atomic.Uint64.
+	dropped sync.Map
 }
 
 type Queue struct {
@@ -35,14 +53,50 @@ type Queue struct {
 	Enabled  bool
 }
 
+// Confirmation selects the events stored with an insert that waits until the event is persisted,
+// other events are acknowledged to the SDK before the insert is flushed.
+// It applies only when events are stored in olap directly rather than through the queue.
+type Confirmation struct {
+	// Rate is the fraction of events confirmed, from 0 to 1.
+	Rate float64
+	// NewIssues confirms the first event of every new issue.
+	NewIssues bool
+}
+
+// Idempotency makes ingestion of an event retried with the same event ID, e.g. by a proxy,
+// store the event only once.
+type Idempotency struct {
+	// Window is how long the IDs of ingested events are remembered, zero disables the check.
+	Window time.Duration
+	// VerifyStored also looks the event ID up in olap when it isn't remembered,
+	// e.g. after a restart, at the cost of a query per event.
+	VerifyStored bool
+}
+
+// UnknownProjects sets how events of projects that don't exist or don't match the key are rejected.
+type UnknownProjects struct {
+	// CacheTTL is how long a failed project lookup is remembered, so that SDKs with a wrong DSN
+	// don't query the database with every event. Zero disables the negative cache.
+	CacheTTL time.Duration
+	// Grace is how long after a project was created events with its key are rejected with
+	// warnly.ErrProjectNotReady rather than warnly.ErrProjectNotFound while the key isn't visible yet,
+	// so that SDKs retry them. Events with any other key are not found. Zero disables the grace window.
+	Grace time.Duration
+}
+
 // NewEventService is a constructor of event service.
+// autoAssigner may be nil, in which case new issues are left unassigned.
+// attachmentStore may be nil, in which case event attachments are discarded.
 func NewEventService(
 	projectStore warnly.ProjectStore,
 	issueStore warnly.IssueStore,
 	inMemCache *cache.Cache,
 	olap warnly.AnalyticsStore,
 	queue Queue,
+	autoAssigner warnly.IssueAutoAssigner,
+	attachmentStore warnly.AttachmentStore,
 	now func() time.Time,
+	logger *slog.Logger,
 ) *EventService {
 	return &EventService{
 		projectStore: projectStore,
@@ -51,18 +105,90 @@ func NewEventService(
 		olap:         olap,
 		sf:           &singleflight.Group{},
 		queue:        queue,
+		autoAssigner: autoAssigner,
+		attachments:  attachmentStore,
 		now:          now,
+		logger:       logger,
 	}
 }
 
+// ConfirmStores sets the events stored with an insert that waits until the event is persisted.
+func (s *EventService) ConfirmStores(c Confirmation) {
+	s.confirmation = c
+}
+
+// DedupEventIDs sets how retried events with an already ingested event ID are recognized.
+func (s *EventService) DedupEventIDs(i Idempotency) {
+	s.idempotency = i
+}
+
+// RejectUnknownProjects sets how lookups of unknown projects are cached and which are retried.
+func (s *EventService) RejectUnknownProjects(u UnknownProjects) {
+	s.unknown = u
+}
+
+// Subscribers finds the subscribers of reopened issues, the notifications of regressions
+// and reappearances are addressed to them.
+type Subscribers struct {
+	Subscriptions warnly.SubscriptionStore
+	Teams         warnly.TeamStore
+}
+
+// NotifySubscribers sets where the subscribers of reopened issues are found.
+// Without it the notifications of reopened issues have no recipients.
+func (s *EventService) NotifySubscribers(subscribers Subscribers) {
+	s.subscribers = subscribers
+}
+
+// NotifyRegressions sets the notifier told about resolved issues reopened after recurring
+// in a newer release and about ignored issues reopened by their ignore condition.
+// Without it such issues are reopened silently.
+func (s *EventService) NotifyRegressions(notifier warnly.IssueNotifier) {
+	s.notifier = notifier
+}
+
 // IngestEvent ingests a new event into the system.
+// An event with the ID of an event ingested within the idempotency window is acknowledged without being stored.
+// The request is authenticated first, so that the IDs of ingested events are only revealed to their project.
 func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest) (warnly.IngestEventResult, error) {
-	res := warnly.IngestEventResult{}
+	opts, err := s.authenticate(ctx, req)
+	if err != nil {
+		return warnly.IngestEventResult{}, err
+	}
 
-	opts, err := s.getProjectOptions(ctx, req)
+	if s.idempotency.Window <= 0 || req.Event == nil || req.Event.EventID == "" {
+		return s.ingestEvent(ctx, req, opts)
+	}
+
+	key := fmt.Sprintf("ingest:%d:%s", req.ProjectID, req.Event.EventID)
+	// Add fails when the key is already cached, so concurrent retries store a single event.
+	retried := s.cache.Add(key, struct{}{}, s.idempotency.Window) != nil
+	if !retried && s.idempotency.VerifyStored {
+		stored, err := s.olap.HasEvent(ctx, req.ProjectID, req.Event.EventID, s.now().UTC().Add(-s.idempotency.Window))
+		if err != nil {
+			s.cache.Delete(key)
+			return warnly.IngestEventResult{}, fmt.Errorf("event service ingest: look up event id: %w", err)
+		}
+		retried = stored
+	}
+	if retried {
+		return warnly.IngestEventResult{EventID: req.Event.EventID, Deduplicated: true}, nil
+	}
+
+	res, err := s.ingestEvent(ctx, req, opts)
 	if err != nil {
-		return res, err
+		// Forget the ID of an event that failed to be ingested, so that its retry is.
+		s.cache.Delete(key)
 	}
+	return res, err
+}
+
+func (s *EventService) ingestEvent(
+	ctx context.Context,
+	req warnly.IngestRequest,
+	opts *warnly.ProjectOptions,
+) (warnly.IngestEventResult, error) {
+	res := warnly.IngestEventResult{}
 
 	ipv4, ipv6, err := s.extractIP(req.IP)
 	if err != nil {
@@ -71,25 +197,44 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 
 	event := req.Event
 
-	eventHash, err := warnly.GetNormalizedHash(event)
+	// Filtered events are acknowledged like stored ones, so that SDKs don't retry them.
+	if opts.IngestFilters.Drops(event.Environment, event.Release) {
+		res.EventID = event.EventID
+		res.Filtered = true
+		return res, nil
+	}
+
+	eventHash, err := warnly.GetGroupingHash(event, opts.Grouping, opts.GroupingRules)
 	if err != nil {
 		return res, err
 	}
+	// In-app flags are overridden once the event is grouped, so that issues of events
+	// the SDK marked differently aren't split.
+	warnly.ApplyInApp(event, opts.Platform, opts.GroupingRules)
 
-	cacheKey := fmt.Sprintf("%d:%s", req.ProjectID, eventHash)
+	cacheKey := issueCacheKey(req.ProjectID, eventHash)
 
 	exceptionType := warnly.GetExceptionType(event.Exception, event.Message)
 	exceptionValue := warnly.GetExceptionValue(event.Exception, warnly.DefaultMessage)
-	view := warnly.GetBreaker(event.Exception)
+	view := warnly.GetBreaker(event.Exception, opts.GroupingRules)
+	tkv := makeTags(event)
+	// boost is the priority the project's rules give the event, zero when no rule matches.
+	boost := warnly.BoostPriority(0, opts.PriorityRules, tkv.keys, tkv.values)
 
 	issueInfo := warnly.IssueInfo{}
-	var ok bool
+	var ok, newIssue bool
 	iss, found := s.cache.Get(cacheKey)
 	if found {
 		issueInfo, ok = iss.(warnly.IssueInfo)
 		if !ok {
 			return res, errors.New("event service ingest: cache issue info type assertion")
 		}
+		// Noisy events aren't stored, so they don't count to the issue metrics and don't change the issue.
+		if issueInfo.NoiseFilters.Drops(tkv.keys, tkv.values) {
+			res.EventID = event.EventID
+			res.Filtered = true
+			return res, nil
+		}
 		_, err, _ := s.sf.Do(cacheKey, s.updateLastSeen(ctx, &warnly.UpdateLastSeen{
 			IssueID:   issueInfo.ID,
 			LastSeen:  s.now().UTC(),
@@ -100,6 +245,26 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 		if err != nil {
 			return res, fmt.Errorf("event service ingest: update last seen %w", err)
 		}
+		if err := s.raisePriority(ctx, issueInfo.ID, boost); err != nil {
+			return res, err
+		}
+		if warnly.IsRegression(issueInfo.ResolvedInRelease, event.Release) {
+			if err := s.reopenRegressed(ctx, opts, issueInfo.ID, exceptionValue, issueInfo.ResolvedInRelease, event.Release); err != nil {
+				return res, err
+			}
+			issueInfo.ResolvedInRelease = ""
+			s.cache.Set(cacheKey, issueInfo, cache.DefaultExpiration)
+		}
+		if issueInfo.Ignore != nil {
+			ignored, err := s.checkIgnored(ctx, opts, issueInfo.ID, issueInfo.Ignore, exceptionValue)
+			if err != nil {
+				return res, err
+			}
+			if !ignored {
+				issueInfo.Ignore = nil
+				s.cache.Set(cacheKey, issueInfo, cache.DefaultExpiration)
+			}
+		}
 	} else {
 		issue, err := s.issueStore.GetIssue(ctx, warnly.GetIssueCriteria{
 			ProjectID: req.ProjectID,
@@ -121,12 +286,39 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 				View:        view,
 				NumComments: 0,
 				ProjectID:   req.ProjectID,
-				Priority:    warnly.PriorityHigh,
+				Priority:    newIssuePriority(boost),
 			}
 			if _, err, _ := s.sf.Do(cacheKey, s.storeIssue(ctx, issue)); err != nil {
 				return res, fmt.Errorf("event service ingest: store issue %w", err)
 			}
+			newIssue = true
+			// Concurrent events of the same new issue share a single store call,
+			// only the caller whose issue was actually stored has its ID set.
+			if s.autoAssigner != nil && issue.ID != 0 {
+				if err := s.autoAssigner.AssignNewIssue(ctx, issue, opts.TeamID); err != nil {
+					s.logger.ErrorContext(ctx, "event service ingest: auto assign new issue", slog.Any("error", err))
+				}
+			}
 		} else {
+			if issue.NoiseFilters.Drops(tkv.keys, tkv.values) {
+				// the checks of a resolved or ignored issue are left to the next event it is cached for.
+				issueInfo = warnly.IssueInfo{
+					ID:           issue.ID,
+					UUID:         issue.UUID.String(),
+					Hash:         issue.Hash,
+					NoiseFilters: issue.NoiseFilters,
+				}
+				if issue.IsResolved() {
+					issueInfo.ResolvedInRelease = issue.ResolvedInRelease
+				}
+				if issue.IsIgnored() {
+					issueInfo.Ignore = issue.Ignore
+				}
+				s.cache.Set(cacheKey, issueInfo, cache.DefaultExpiration)
+				res.EventID = event.EventID
+				res.Filtered = true
+				return res, nil
+			}
 			if _, err, _ := s.sf.Do(cacheKey, s.updateLastSeen(ctx, &warnly.UpdateLastSeen{
 				IssueID:   issue.ID,
 				LastSeen:  s.now().UTC(),
@@ -136,12 +328,54 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 			})); err != nil {
 				return res, fmt.Errorf("event service ingest: update last seen %w", err)
 			}
+			if boost > issue.Priority {
+				if err := s.raisePriority(ctx, issue.ID, boost); err != nil {
+					return res, err
+				}
+			}
+		}
+		issueInfo = warnly.IssueInfo{
+			ID:           issue.ID,
+			UUID:         issue.UUID.String(),
+			Hash:         issue.Hash,
+			NoiseFilters: issue.NoiseFilters,
+		}
+		if issue.IsResolved() {
+			if warnly.IsRegression(issue.ResolvedInRelease, event.Release) {
+				if err := s.reopenRegressed(ctx, opts, issue.ID, exceptionValue, issue.ResolvedInRelease, event.Release); err != nil {
+					return res, err
+				}
+			} else {
+				issueInfo.ResolvedInRelease = issue.ResolvedInRelease
+			}
+		}
+		if issue.IsIgnored() {
+			ignored, err := s.checkIgnored(ctx, opts, issue.ID, issue.Ignore, exceptionValue)
+			if err != nil {
+				return res, err
+			}
+			if ignored {
+				issueInfo.Ignore = issue.Ignore
+			}
 		}
-		issueInfo = warnly.IssueInfo{ID: issue.ID, UUID: issue.UUID.String(), Hash: issue.Hash}
 		s.cache.Set(cacheKey, issueInfo, cache.DefaultExpiration)
 	}
 
-	tkv := makeTags(event)
+	// The first event of an issue is always kept so that every issue has at least one event to show.
+	if !newIssue && !warnly.KeepSampled(event.EventID, opts.SampleRate) {
+		s.countDropped(req.ProjectID)
+		res.EventID = event.EventID
+		res.Dropped = true
+		return res, nil
+	}
+
+	// Duplicates still update the issue's last seen above, they are only not stored again.
+	if opts.DedupWindow > 0 && s.isDuplicate(req.ProjectID, issueInfo.UUID, event, exceptionValue, opts.DedupWindow) {
+		res.EventID = event.EventID
+		res.Deduplicated = true
+		return res, nil
+	}
+
 	ckv, err := makeContexts(event)
 	if err != nil {
 		return res, err
@@ -150,6 +384,7 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 	ev := &warnly.EventClickhouse{
 		EventID:                 event.EventID,
 		Deleted:                 0,
+		Unhandled:               boolToUint8(warnly.IsUnhandled(event.Exception)),
 		GroupID:                 uint64(issueInfo.ID),
 		RetentionDays:           opts.RetentionDays,
 		User:                    makeUser(event),
@@ -162,12 +397,17 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 		Platform:                uint8(warnly.PlatformByName(event.Platform)),
 		Env:                     event.Environment,
 		Release:                 event.Release,
+		Dist:                    event.Dist,
+		Logger:                  event.Logger,
+		Transaction:             event.Transaction,
+		TraceID:                 event.Contexts.Trace.TraceID,
 		Message:                 event.Message,
 		Level:                   warnly.GetLevel(event.Level),
 		SDKID:                   warnly.GetSDKID(event.SDK.Name),
 		SDKVersion:              event.SDK.Version,
 		Title:                   exceptionType + ": " + exceptionValue,
 		IPv4:                    ipv4,
+		Raw:                     string(req.RawEvent),
 		IPv6:                    ipv6,
 		ContextsKey:             ckv.keys,
 		ContextsValue:           ckv.values,
@@ -181,12 +421,12 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 		ExceptionFramesFilename: warnly.GetExceptionFramesFilename(event.Exception),
 		ExceptionFramesFunction: warnly.GetExceptionFramesFunction(event.Exception),
 		ExceptionFramesLineNo:   warnly.GetExceptionFramesLineNo(event.Exception),
-		ExceptionFramesInApp:    warnly.GetExceptionFramesInApp(event.Exception),
+		ExceptionFramesInApp:    warnly.GetExceptionFramesInApp(event.Exception, opts.GroupingRules),
 	}
 
 	// If no exception frames, try to extract from threads (e.g. Rust SDK).
 	if len(ev.ExceptionFramesFunction) == 0 {
-		populateThreadFrames(ev, event)
+		populateThreadFrames(ev, event, opts.GroupingRules)
 	}
 
 	if s.queue.Enabled {
@@ -198,19 +438,53 @@ func (s *EventService) IngestEvent(ctx context.Context, req warnly.IngestRequest
 			Topic: warnly.QueueTopic,
 			Value: value,
 		}); err != nil {
-			return res, fmt.Errorf("event service ingest: produce event to queue %w", err)
-		}
-	} else {
-		if err := s.olap.StoreEvent(ctx, ev); err != nil {
-			return res, fmt.Errorf("event service ingest: store event in olap %w", err)
+			return res, fmt.Errorf("event service ingest: produce event to queue: %w: %w", warnly.ErrStoreEvent, err)
 		}
+		res.Queued = true
+	} else if err := s.storeEvent(ctx, ev, newIssue); err != nil {
+		return res, err
 	}
 
+	s.storeAttachments(ctx, req, ev)
+
 	res.EventID = ev.EventID
 
 	return res, nil
 }
 
+// storeEvent stores the event in olap, waiting until it is persisted when the confirmation selects it.
+func (s *EventService) storeEvent(ctx context.Context, ev *warnly.EventClickhouse, newIssue bool) error {
+	store := s.olap.StoreEvent
+	if (newIssue && s.confirmation.NewIssues) || warnly.KeepSampled(ev.EventID, s.confirmation.Rate) {
+		store = s.olap.StoreEventSync
+	}
+	if err := store(ctx, ev); err != nil {
+		return fmt.Errorf("event service ingest: store event in olap: %w: %w", warnly.ErrStoreEvent, err)
+	}
+	return nil
+}
+
+// storeAttachments stores files sent along with the event.
+// The event is already stored, so failures are logged rather than returned
+// to keep the SDK from sending the event again.
+func (s *EventService) storeAttachments(ctx context.Context, req warnly.IngestRequest, ev *warnly.EventClickhouse) {
+	if s.attachments == nil {
+		return
+	}
+	for i := range req.Attachments {
+		a := req.Attachments[i]
+		a.ProjectID = req.ProjectID
+		a.EventID = ev.EventID
+		a.CreatedAt = ev.CreatedAt
+		if err := s.attachments.StoreAttachment(ctx, &a); err != nil {
+			s.logger.ErrorContext(ctx, "event service ingest: store attachment",
+				slog.String("event_id", ev.EventID),
+				slog.String("filename", a.Filename),
+				slog.Any("error", err))
+		}
+	}
+}
+
 type kv struct {
 	keys   []string
 	values []string
@@ -257,7 +531,9 @@ func makeContexts(event *warnly.EventBody) (kv, error) {
 		contextsValues = append(contextsValues, event.Contexts.OS.Name)
 	}
 
-	for k, v := range event.Extra {
+	// extra keys are sorted so the contexts keep a stable order between events.
+	for _, k := range slices.Sorted(maps.Keys(event.Extra)) {
+		v := event.Extra[k]
 		contextsKeys = append(contextsKeys, "extra."+k)
 		if str, ok := v.(string); ok {
 			contextsValues = append(contextsValues, str)
@@ -289,6 +565,19 @@ func makeTags(event *warnly.EventBody) kv {
 		tagsKeys = append(tagsKeys, "release")
 		tagsValues = append(tagsValues, event.Release)
 	}
+	// the distribution tells apart builds of a mobile release.
+	if event.Dist != "" {
+		tagsKeys = append(tagsKeys, "dist")
+		tagsValues = append(tagsValues, event.Dist)
+	}
+	if event.Logger != "" {
+		tagsKeys = append(tagsKeys, "logger")
+		tagsValues = append(tagsValues, event.Logger)
+	}
+	if event.Transaction != "" {
+		tagsKeys = append(tagsKeys, "transaction")
+		tagsValues = append(tagsValues, event.Transaction)
+	}
 	if event.ServerName != "" {
 		tagsKeys = append(tagsKeys, "server_name")
 		tagsValues = append(tagsValues, event.ServerName)
@@ -308,6 +597,13 @@ func makeTags(event *warnly.EventBody) kv {
 	return kv{keys: tagsKeys, values: tagsValues}
 }
 
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func makeUser(event *warnly.EventBody) string {
 	user := ""
 	if event.User.ID != "" {
@@ -316,6 +612,24 @@ func makeUser(event *warnly.EventBody) string {
 	return user
 }
 
+// ForgetIssue drops the cached info of the issue, it implements warnly.IngestCache.
+func (s *EventService) ForgetIssue(projectID int, hash string) {
+	s.cache.Delete(issueCacheKey(projectID, hash))
+}
+
+// ForgetProjectKey drops the project options cached for the key, it implements warnly.IngestCache.
+func (s *EventService) ForgetProjectKey(projectID int, key string) {
+	s.cache.Delete(projectOptionsCacheKey(projectID, key))
+}
+
+func issueCacheKey(projectID int, hash string) string {
+	return fmt.Sprintf("%d:%s", projectID, hash)
+}
+
+func projectOptionsCacheKey(projectID int, key string) string {
+	return fmt.Sprintf("project_options:%d:%s", projectID, key)
+}
+
 // updateLastSeen updates the last seen time of an issue in oltp database.
 func (s *EventService) updateLastSeen(ctx context.Context, upd *warnly.UpdateLastSeen) func() (any, error) {
 	return func() (any, error) {
@@ -326,6 +640,73 @@ func (s *EventService) updateLastSeen(ctx context.Context, upd *warnly.UpdateLas
 	}
 }
 
+// AuthenticateIngest checks the project key and the payload signature of an envelope without an event.
+func (s *EventService) AuthenticateIngest(ctx context.Context, req warnly.IngestRequest) error {
+	_, err := s.authenticate(ctx, req)
+	return err
+}
+
+// authenticate returns the options of the project the request is sent to
+// once its key and, for projects with a signing secret, the payload signature are checked.
+func (s *EventService) authenticate(ctx context.Context, req warnly.IngestRequest) (*warnly.ProjectOptions, error) {
+	opts, err := s.getProjectOptions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IngestSecret != "" {
+		if err := warnly.VerifyIngestSignature(opts.IngestSecret, req.Payload, req.Signature); err != nil {
+			return nil, fmt.Errorf("event service ingest: project %d: %w", req.ProjectID, err)
+		}
+	}
+
+	return opts, nil
+}
+
+// RecordClientReports adds up the events SDKs of a project dropped before sending them
+// to the counts of the current day. Discards without a reason or category are ignored.
+func (s *EventService) RecordClientReports(ctx context.Context, req *warnly.ClientReportsRequest) error {
+	opts, err := s.getProjectOptions(ctx, warnly.IngestRequest{ProjectKey: req.ProjectKey, ProjectID: req.ProjectID})
+	if err != nil {
+		return err
+	}
+
+	type discardKey struct{ reason, category string }
+	quantities := make(map[discardKey]uint64)
+	var keys []discardKey
+	for i := range req.Reports {
+		for _, d := range req.Reports[i].DiscardedEvents {
+			if d.Quantity == 0 || !validDiscardField(d.Reason) || !validDiscardField(d.Category) {
+				continue
+			}
+			key := discardKey{reason: d.Reason, category: d.Category}
+			if _, ok := quantities[key]; !ok {
+				keys = append(keys, key)
+			}
+			quantities[key] += d.Quantity
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	discarded := make([]warnly.DiscardedEvents, len(keys))
+	for i, key := range keys {
+		discarded[i] = warnly.DiscardedEvents{Reason: key.reason, Category: key.category, Quantity: quantities[key]}
+	}
+
+	if err := s.projectStore.AddDiscardedEvents(ctx, opts.ID, s.now().UTC(), discarded); err != nil {
+		return fmt.Errorf("event service record client reports: %w", err)
+	}
+
+	return nil
+}
+
+// validDiscardField reports whether a discard reason or category can be stored.
+func validDiscardField(field string) bool {
+	return field != "" && len(field) <= warnly.MaxDiscardFieldLength
+}
+
 // storeIssue stores an issue in oltp database.
 func (s *EventService) storeIssue(ctx context.Context, issue *warnly.Issue) func() (any, error) {
 	return func() (any, error) {
@@ -337,27 +718,350 @@ func (s *EventService) storeIssue(ctx context.Context, issue *warnly.Issue) func
 }
 
 // getProjectOptions retrieves project options such as event retention days from database.
+// Returns warnly.ErrProjectNotFound if the project key is revoked, the grace period of a revoked key
+// is respected even while its options are cached. Failed lookups are cached for UnknownProjects.CacheTTL,
+// warnly.ErrProjectNotReady is returned for projects created within UnknownProjects.Grace.
 func (s *EventService) getProjectOptions(ctx context.Context, req warnly.IngestRequest) (*warnly.ProjectOptions, error) {
-	key := fmt.Sprintf("project_options:%d:%s", req.ProjectID, req.ProjectKey)
+	key := projectOptionsCacheKey(req.ProjectID, req.ProjectKey)
 	if opts, found := s.cache.Get(key); found {
 		projOpts, ok := opts.(*warnly.ProjectOptions)
 		if !ok {
 			return nil, errors.New("event service get project options: cache project options type assertion")
 		}
+		if err := s.checkKeyRevoked(projOpts, req.ProjectID); err != nil {
+			return nil, err
+		}
 		return projOpts, nil
 	}
 
+	unknownKey := fmt.Sprintf("unknown_project:%d:%s", req.ProjectID, req.ProjectKey)
+	if cached, found := s.cache.Get(unknownKey); found {
+		err, ok := cached.(error)
+		if !ok {
+			return nil, errors.New("event service get project options: cache unknown project type assertion")
+		}
+		return nil, err
+	}
+
 	opts, err := s.projectStore.GetOptions(ctx, req.ProjectID, req.ProjectKey)
 	if err != nil {
+		if errors.Is(err, warnly.ErrProjectNotFound) {
+			return nil, s.rejectUnknownProject(ctx, unknownKey, req, err)
+		}
 		return nil, err
 	}
-	opts.RetentionDays = 90
 
 	s.cache.Set(key, opts, time.Minute*10)
 
+	if err := s.checkKeyRevoked(opts, req.ProjectID); err != nil {
+		return nil, err
+	}
+
 	return opts, nil
 }
 
+// rejectUnknownProject returns warnly.ErrProjectNotReady when the request has the key of a project
+// created within the grace window, whose key row isn't visible yet, and notFound otherwise,
+// remembering the error for the cache TTL. A project that isn't ready is remembered
+// no longer than its grace window lasts.
+func (s *EventService) rejectUnknownProject(ctx context.Context, key string, req warnly.IngestRequest, notFound error) error {
+	err, ttl := notFound, s.unknown.CacheTTL
+	if s.unknown.Grace > 0 {
+		project, getErr := s.projectStore.GetProject(ctx, req.ProjectID)
+		switch {
+		case getErr == nil:
+			// a wrong key is not found even for a new project, so that guessing keys isn't retried.
+			if subtle.ConstantTimeCompare([]byte(project.Key), []byte(req.ProjectKey)) != 1 {
+				break
+			}
+			if left := project.CreatedAt.Add(s.unknown.Grace).Sub(s.now()); left > 0 {
+				err = fmt.Errorf("event service: project %d is within the grace window: %w", req.ProjectID, warnly.ErrProjectNotReady)
+				ttl = min(ttl, left)
+			}
+		case !errors.Is(getErr, warnly.ErrProjectNotFound):
+			return fmt.Errorf("event service get project options: get project: %w", getErr)
+		}
+	}
+
+	if ttl > 0 {
+		s.cache.Set(key, err, ttl)
+	}
+
+	return err
+}
+
+// checkKeyRevoked returns warnly.ErrProjectNotFound if the key the options were requested with is revoked.
+func (s *EventService) checkKeyRevoked(opts *warnly.ProjectOptions, projectID int) error {
+	if opts.KeyRevokedAt != nil && !s.now().Before(*opts.KeyRevokedAt) {
+		return fmt.Errorf("event service: project %d key revoked: %w", projectID, warnly.ErrProjectNotFound)
+	}
+	return nil
+}
+
+// reopenRegressed reopens a resolved issue that recurred in a release newer than the one that fixed it
+// and notifies the project team. Of concurrent events of the same regression only one notifies.
+func (s *EventService) reopenRegressed(
+	ctx context.Context,
+	opts *warnly.ProjectOptions,
+	issueID int64,
+	message, resolvedIn, release string,
+) error {
+	reopened, err := s.issueStore.ReopenIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("event service ingest: reopen issue %w", err)
+	}
+	if !reopened || s.notifier == nil {
+		return nil
+	}
+
+	if err := s.notifier.NotifyIssueRegressed(ctx, &warnly.IssueRegressedNotification{
+		RegressedAt:       s.now().UTC(),
+		ProjectName:       opts.Name,
+		Message:           message,
+		ResolvedInRelease: resolvedIn,
+		Release:           release,
+		Recipients:        s.listSubscribers(ctx, opts.TeamID, issueID),
+		IssueID:           issueID,
+		ProjectID:         opts.ID,
+		TeamID:            opts.TeamID,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "event service ingest: notify issue regressed", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// checkIgnored counts the event against the condition of an ignored issue, reopening the issue
+// and notifying the project team once the condition is met. It reports whether the issue stays ignored.
+// Affected users aren't counted per event, issues ignored until they affect more users
+// are reopened by ReopenIgnoredIssues.
+func (s *EventService) checkIgnored(
+	ctx context.Context,
+	opts *warnly.ProjectOptions,
+	issueID int64,
+	rule *warnly.IgnoreRule,
+	message string,
+) (bool, error) {
+	now := s.now().UTC()
+
+	seen := 0
+	switch rule.Condition {
+	case warnly.IgnoreConditionCount:
+		n, err := s.issueStore.CountIgnoredEvent(ctx, issueID)
+		if err != nil {
+			return false, fmt.Errorf("event service ingest: count ignored event %w", err)
+		}
+		// The issue was reopened or changed since it was read.
+		if n == 0 {
+			return false, nil
+		}
+		seen = n
+	case warnly.IgnoreConditionUsers:
+		return true, nil
+	}
+	if !rule.Met(seen, now) {
+		return true, nil
+	}
+
+	if _, err := s.reopenIgnored(ctx, opts, issueID, rule.Condition, message, now); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// ReopenIgnoredIssues reopens the ignored issues whose ignore time has passed or that affected
+// the threshold number of users since they were ignored, whether their events keep arriving or not,
+// and notifies the project teams. Issues ignored until a number of occurrences are counted
+// by the ingestion instead. It returns the number of reopened issues.
+func (s *EventService) ReopenIgnoredIssues(ctx context.Context) (int, error) {
+	issues, err := s.issueStore.ListIgnoredIssues(ctx, []warnly.IgnoreCondition{
+		warnly.IgnoreConditionTime,
+		warnly.IgnoreConditionUsers,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("event service reopen ignored issues: list ignored issues: %w", err)
+	}
+
+	now := s.now().UTC()
+	projects := make(map[int]*warnly.ProjectOptions)
+	reopened := 0
+	for i := range issues {
+		issue := &issues[i]
+		met, err := s.ignoreConditionMet(ctx, issue, now)
+		if err != nil {
+			return reopened, err
+		}
+		if !met {
+			continue
+		}
+
+		opts, ok := projects[issue.ProjectID]
+		if !ok {
+			project, err := s.projectStore.GetProject(ctx, issue.ProjectID)
+			if err != nil {
+				return reopened, fmt.Errorf("event service reopen ignored issues: get project: %w", err)
+			}
+			opts = &warnly.ProjectOptions{ID: project.ID, Name: project.Name, TeamID: project.TeamID}
+			projects[issue.ProjectID] = opts
+		}
+
+		ok, err = s.reopenIgnored(ctx, opts, issue.ID, issue.Ignore.Condition, issue.Message, now)
+		if err != nil {
+			return reopened, err
+		}
+		if ok {
+			s.ForgetIssue(issue.ProjectID, issue.Hash)
+			reopened++
+		}
+	}
+
+	return reopened, nil
+}
+
+// ignoreConditionMet reports whether the time or users condition of an ignored issue is met at now.
+func (s *EventService) ignoreConditionMet(ctx context.Context, issue *warnly.Issue, now time.Time) (bool, error) {
+	rule := issue.Ignore
+	if rule.Condition != warnly.IgnoreConditionUsers {
+		return rule.Met(0, now), nil
+	}
+
+	metrics, err := s.olap.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		From:       rule.IgnoredAt,
+		To:         now,
+		ProjectIDs: []int{issue.ProjectID},
+		GroupIDs:   []int64{issue.ID},
+	})
+	if err != nil {
+		return false, fmt.Errorf("event service reopen ignored issues: ignored issue metrics: %w", err)
+	}
+	seen := 0
+	if m, ok := warnly.GetMetrics(metrics, issue.ID); ok {
+		seen = int(m.UserCount)
+	}
+
+	return rule.Met(seen, now), nil
+}
+
+// reopenIgnored reopens an ignored issue whose condition is met and notifies the project team
+// and the subscribers of the issue. Of concurrent callers only one reopens the issue and notifies,
+// it reports whether the issue was reopened by this call.
+func (s *EventService) reopenIgnored(
+	ctx context.Context,
+	opts *warnly.ProjectOptions,
+	issueID int64,
+	condition warnly.IgnoreCondition,
+	message string,
+	now time.Time,
+) (bool, error) {
+	reopened, err := s.issueStore.ReopenIgnored(ctx, issueID)
+	if err != nil {
+		return false, fmt.Errorf("event service: reopen ignored issue %w", err)
+	}
+	if !reopened || s.notifier == nil {
+		return reopened, nil
+	}
+
+	if err := s.notifier.NotifyIssueReappeared(ctx, &warnly.IssueReappearedNotification{
+		ReappearedAt: now,
+		ProjectName:  opts.Name,
+		Message:      message,
+		Condition:    condition,
+		Recipients:   s.listSubscribers(ctx, opts.TeamID, issueID),
+		IssueID:      issueID,
+		ProjectID:    opts.ID,
+		TeamID:       opts.TeamID,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "event service: notify issue reappeared", slog.Any("error", err))
+	}
+
+	return true, nil
+}
+
+// listSubscribers returns the members of the project team subscribed to the issue.
+// The issue is already reopened, so failures are only logged and the notification has no recipients.
+func (s *EventService) listSubscribers(ctx context.Context, teamID int, issueID int64) []warnly.Teammate {
+	if s.subscribers.Subscriptions == nil || s.subscribers.Teams == nil {
+		return nil
+	}
+
+	subscriberIDs, err := s.subscribers.Subscriptions.ListSubscribers(ctx, issueID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "event service ingest: list subscribers", slog.Any("error", err))
+		return nil
+	}
+	if len(subscriberIDs) == 0 {
+		return nil
+	}
+
+	teammates, err := s.subscribers.Teams.ListTeammates(ctx, []int{teamID})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "event service ingest: list teammates", slog.Any("error", err))
+		return nil
+	}
+
+	return slices.DeleteFunc(teammates, func(t warnly.Teammate) bool {
+		return !slices.Contains(subscriberIDs, t.ID)
+	})
+}
+
+// newIssuePriority returns the priority of a new issue, the one given by the project's priority rules
+// or the default when no rule matched the first event.
+func newIssuePriority(boost warnly.IssuePriority) warnly.IssuePriority {
+	if boost == 0 {
+		return warnly.DefaultIssuePriority
+	}
+	return boost
+}
+
+// raisePriority raises the priority of an issue to the one given by the project's priority rules.
+// Zero priority means no rule matched the event.
+func (s *EventService) raisePriority(ctx context.Context, issueID int64, priority warnly.IssuePriority) error {
+	if priority == 0 {
+		return nil
+	}
+	if err := s.issueStore.RaisePriority(ctx, issueID, priority); err != nil {
+		return fmt.Errorf("event service ingest: raise priority %w", err)
+	}
+	return nil
+}
+
+// DroppedEvents returns the number of events of a project discarded by sampling
+// since the service started. Together with the stored events it allows estimating totals.
+func (s *EventService) DroppedEvents(projectID int) uint64 {
+	counter, ok := s.dropped.Load(projectID)
+	if !ok {
+		return 0
+	}
+	return counter.(*atomic.Uint64).Load() //nolint:forcetypeassert // only *atomic.Uint64 is stored
+}
+
+// isDuplicate reports whether an event with the same ID, or of the same issue with the same
+// message and user, was ingested within the window. The event is remembered for the window otherwise.
+func (s *EventService) isDuplicate(
+	projectID int,
+	primaryHash string,
+	event *warnly.EventBody,
+	message string,
+	window time.Duration,
+) bool {
+	content := sha256.Sum256([]byte(primaryHash + "\x00" + message + "\x00" + makeUser(event)))
+	idKey := fmt.Sprintf("dedup:%d:id:%s", projectID, event.EventID)
+	contentKey := fmt.Sprintf("dedup:%d:content:%s", projectID, hex.EncodeToString(content[:]))
+
+	// Add fails when the key is already cached, so concurrent duplicates store a single event.
+	idSeen := s.cache.Add(idKey, struct{}{}, window) != nil
+	contentSeen := s.cache.Add(contentKey, struct{}{}, window) != nil
+
+	return idSeen || contentSeen
+}
+
+// countDropped increments the sampled out events counter of a project.
+func (s *EventService) countDropped(projectID int) {
+	counter, _ := s.dropped.LoadOrStore(projectID, &atomic.Uint64{})
+	counter.(*atomic.Uint64).Add(1) //nolint:forcetypeassert // only *atomic.Uint64 is stored
+}
+
 // extractIP extracts IPv4 and IPv6 addresses from a given IP address string.
 func (s *EventService) extractIP(ipaddr string) (ipv4, ipv6 string, err error) {
 	ip, _, err := net.SplitHostPort(ipaddr)
@@ -381,7 +1085,7 @@ func (s *EventService) extractIP(ipaddr string) (ipv4, ipv6 string, err error) {
 	return ipv4, ipv6, nil
 }
 
-func populateThreadFrames(ev *warnly.EventClickhouse, event *warnly.EventBody) {
+func populateThreadFrames(ev *warnly.EventClickhouse, event *warnly.EventBody, rules *warnly.GroupingRules) {
 	threadFrames := event.GetThreadFrames()
 	if len(threadFrames) == 0 {
 		return
@@ -398,7 +1102,7 @@ func populateThreadFrames(ev *warnly.EventClickhouse, event *warnly.EventBody) {
 		ev.ExceptionFramesAbsPath[i] = threadFrames[i].AbsPath
 		ev.ExceptionFramesLineNo[i] = threadFrames[i].LineNo
 		ev.ExceptionFramesColNo[i] = threadFrames[i].LineNo
-		if threadFrames[i].InApp {
+		if threadFrames[i].InApp && rules.IsInApp(&threadFrames[i]) {
 			ev.ExceptionFramesInApp[i] = 1
 		}
 		name := threadFrames[i].AbsPath
@@ -1 +1,1717 @@
 package event_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/svc/event"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+const (
+	testProjectID  = 1
+	testProjectKey = "d7a8fbb3"
+)
+
+func newSampledService(sampleRate float64, issueStore *mock.IssueStore, stored *int) *event.EventService {
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: sampleRate}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+			*stored++
+			return nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	return event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+}
+
+func newIngestRequest(eventID string) warnly.IngestRequest {
+	return warnly.IngestRequest{
+		Event: &warnly.EventBody{
+			EventID: eventID,
+			Message: "connection refused",
+		},
+		ProjectKey: testProjectKey,
+		ProjectID:  testProjectID,
+		IP:         "127.0.0.1:52400",
+	}
+}
+
+func TestIngestEventNewIssueBypassesSampling(t *testing.T) {
+	t.Parallel()
+
+	stored := 0
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 10
+			return nil
+		},
+	}
+	svc := newSampledService(0, issueStore, &stored)
+
+	res, err := svc.IngestEvent(t.Context(), newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a"))
+	require.NoError(t, err)
+	assert.False(t, res.Dropped)
+	assert.Equal(t, 1, stored)
+	assert.Zero(t, svc.DroppedEvents(testProjectID))
+}
+
+func TestIngestEventSamplingIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	const sampleRate = 0.5
+
+	stored := 0
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: 10, UUID: warnly.NewUUID(), Hash: criteria.Hash}, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error {
+			return nil
+		},
+	}
+	svc := newSampledService(sampleRate, issueStore, &stored)
+
+	eventIDs := []string{
+		"5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a",
+		"0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b",
+		"a1b2c3d4e5f60718293a4b5c6d7e8f90",
+		"ffeeddccbbaa99887766554433221100",
+	}
+
+	var dropped uint64
+	for _, eventID := range eventIDs {
+		want := warnly.KeepSampled(eventID, sampleRate)
+		for range 3 {
+			res, err := svc.IngestEvent(t.Context(), newIngestRequest(eventID))
+			require.NoError(t, err)
+			assert.Equal(t, eventID, res.EventID)
+			assert.Equal(t, !want, res.Dropped)
+			if res.Dropped {
+				dropped++
+			}
+		}
+	}
+
+	assert.Equal(t, dropped, svc.DroppedEvents(testProjectID))
+	assert.Equal(t, len(eventIDs)*3-int(dropped), stored)
+}
+
+func TestIngestEventGroupsByTopInAppFrame(t *testing.T) {
+	t.Parallel()
+
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, Grouping: warnly.GroupingByTopInAppFrame}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+
+	var issues []*warnly.Issue
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			for _, issue := range issues {
+				if issue.Hash == criteria.Hash {
+					return issue, nil
+				}
+			}
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = int64(len(issues) + 1)
+			issues = append(issues, issue)
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	for i, message := range []string{"order A-17 failed: card declined", "order Z-9 failed: warehouse unreachable"} {
+		req := newIngestRequest(fmt.Sprintf("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6%d", i))
+		req.Event.Message = message
+		req.Event.Level = "error"
+		req.Event.Threads = warnly.ThreadList{{
+			Current: true,
+			StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+				{Module: "main", Function: "main", LineNo: 95, InApp: true},
+				{Module: "main", Function: "processOrders", LineNo: 149, ContextLine: "logger.Error(msg)", InApp: true},
+			}},
+		}}
+
+		_, err := svc.IngestEvent(t.Context(), req)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, issues, 1)
+}
+
+func TestIngestEventGroupsByMessageTemplate(t *testing.T) {
+	t.Parallel()
+
+	rules := &warnly.GroupingRules{MessageRules: []warnly.MessageRule{warnly.MessageRuleTimestamps, warnly.MessageRuleNumbers}}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, GroupingRules: rules}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+
+	var issues []*warnly.Issue
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			for _, issue := range issues {
+				if issue.Hash == criteria.Hash {
+					return issue, nil
+				}
+			}
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = int64(len(issues) + 1)
+			issues = append(issues, issue)
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	messages := []string{"Hor error at 10:00, attempt 3", "Hor error at 10:01, attempt 4", "Hor pool exhausted"}
+	gids := make([]int64, 0, len(messages))
+	for i, message := range messages {
+		req := newIngestRequest(fmt.Sprintf("6a2d3e9b1c8f4e7d0b4c3f2e1a5d6c7%d", i))
+		req.Event.Message = message
+		req.Event.Level = "error"
+
+		_, err := svc.IngestEvent(t.Context(), req)
+		require.NoError(t, err)
+		gids = append(gids, issues[len(issues)-1].ID)
+	}
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, gids[0], gids[1], "timestamped messages of the same template share the issue")
+	assert.NotEqual(t, gids[0], gids[2])
+}
+
+func TestIngestEventFlattensContextsInStableOrder(t *testing.T) {
+	t.Parallel()
+
+	// trimmed sentry-go payload, extra keys are deliberately out of order.
+	const payload = `{"contexts":{"device":{"arch":"arm64","num_cpu":8},"os":{"name":"darwin"},` +
+		`"runtime":{"go_maxprocs":8,"go_numcgocalls":0,"go_numroutines":7,"name":"go","version":"go1.24.1"}},` +
+		`"event_id":"d1a2f49ae8614c22b8d0d660517f41ad","extra":{"tag_key":"bar","context":"example","key":"1"},` +
+		`"level":"error","message":"Hor error","platform":"go","user":{"id":"1","ip_address":"127.0.0.1"}}`
+
+	var stored []*warnly.EventClickhouse
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+			stored = append(stored, ev)
+			return nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 1
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	for range 3 {
+		var body warnly.EventBody
+		require.NoError(t, json.Unmarshal([]byte(payload), &body))
+		req := newIngestRequest(body.EventID)
+		req.Event = &body
+
+		_, err := svc.IngestEvent(t.Context(), req)
+		require.NoError(t, err)
+	}
+
+	want := []string{
+		"user.ip", "device.arch", "device.num_cpu",
+		"runtime.name", "runtime.version", "runtime.go_maxprocs", "runtime.go_numroutines",
+		"os.name", "extra.context", "extra.key", "extra.tag_key",
+	}
+	require.NotEmpty(t, stored)
+	for _, ev := range stored {
+		assert.Equal(t, want, ev.ContextsKey)
+	}
+
+	groups := (&warnly.IssueDetails{LastEvent: &warnly.IssueEvent{
+		ContextsKey:   stored[0].ContextsKey,
+		ContextsValue: stored[0].ContextsValue,
+	}}).ContextGroups()
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Title)
+	}
+	assert.Equal(t, []string{"Device", "OS", "Runtime", "User", "Extra"}, names)
+}
+
+func TestIngestEventStoresDist(t *testing.T) {
+	t.Parallel()
+
+	var stored *warnly.EventClickhouse
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+			stored = ev
+			return nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 1
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	var body warnly.EventBody
+	require.NoError(t, json.Unmarshal([]byte(`{"event_id":"d1a2f49ae8614c22b8d0d660517f41ad",`+
+		`"level":"error","message":"crash","platform":"cocoa","release":"app@1.4.0","dist":"412"}`), &body))
+	req := newIngestRequest(body.EventID)
+	req.Event = &body
+
+	_, err := svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+
+	require.NotNil(t, stored)
+	assert.Equal(t, "app@1.4.0", stored.Release)
+	assert.Equal(t, "412", stored.Dist)
+	i := slices.Index(stored.TagsKey, "dist")
+	require.NotEqual(t, -1, i, "the distribution is searchable as a tag")
+	assert.Equal(t, "412", stored.TagsValue[i])
+}
+
+func TestIngestEventStoresTraceID(t *testing.T) {
+	t.Parallel()
+
+	var stored *warnly.EventClickhouse
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+			stored = ev
+			return nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 1
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	var body warnly.EventBody
+	require.NoError(t, json.Unmarshal([]byte(`{"event_id":"d1a2f49ae8614c22b8d0d660517f41ad",`+
+		`"level":"error","message":"crash","platform":"go",`+
+		`"contexts":{"trace":{"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7"}}}`), &body))
+	req := newIngestRequest(body.EventID)
+	req.Event = &body
+
+	_, err := svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+
+	require.NotNil(t, stored)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", stored.TraceID)
+}
+
+func TestIngestEventBoostsPriorityByTag(t *testing.T) {
+	t.Parallel()
+
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{
+				ID:         projectID,
+				SampleRate: 1,
+				PriorityRules: []warnly.PriorityRule{
+					{TagKey: "env", TagValue: "staging", Priority: warnly.PriorityLow},
+					{TagKey: "critical", TagValue: "true", Priority: warnly.PriorityHigh},
+				},
+			}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+
+	var issues []*warnly.Issue
+	var raised []warnly.IssuePriority
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			for _, issue := range issues {
+				if issue.Hash == criteria.Hash {
+					return issue, nil
+				}
+			}
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = int64(len(issues) + 1)
+			issues = append(issues, issue)
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+		RaisePriorityFn: func(_ context.Context, issueID int64, priority warnly.IssuePriority) error {
+			issues[issueID-1].Priority = max(issues[issueID-1].Priority, priority)
+			raised = append(raised, priority)
+			return nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	_, err := svc.IngestEvent(t.Context(), newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b60"))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, warnly.PriorityHigh, issues[0].Priority, "new issues are high priority by default")
+
+	req := newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b61")
+	req.Event.Message = "disk full"
+	req.Event.Tags = map[string]string{"env": "staging"}
+	_, err = svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, warnly.PriorityLow, issues[1].Priority, "a rule lowers the priority of a new issue")
+
+	req = newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b62")
+	req.Event.Message = "disk full"
+	req.Event.Tags = map[string]string{"critical": "true"}
+	_, err = svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, warnly.PriorityHigh, issues[1].Priority)
+	assert.Equal(t, []warnly.IssuePriority{warnly.PriorityHigh}, raised)
+}
+
+func TestIngestEventStoresAttachments(t *testing.T) {
+	t.Parallel()
+
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 10
+			return nil
+		},
+	}
+	var stored []warnly.Attachment
+	attachmentStore := &mock.AttachmentStore{
+		StoreAttachmentFn: func(_ context.Context, a *warnly.Attachment) error {
+			stored = append(stored, *a)
+			return nil
+		},
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, attachmentStore, func() time.Time { return now }, slog.Default())
+
+	req := newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a")
+	req.Attachments = []warnly.Attachment{
+		{Filename: "screenshot.png", ContentType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}, Size: 4},
+	}
+
+	_, err := svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []warnly.Attachment{{
+		CreatedAt:   now,
+		EventID:     "5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a",
+		Filename:    "screenshot.png",
+		ContentType: "image/png",
+		Data:        []byte{0x89, 'P', 'N', 'G'},
+		Size:        4,
+		ProjectID:   testProjectID,
+	}}, stored)
+}
+
+func TestIngestEventGroupingRulesExcludeVendorFrames(t *testing.T) {
+	t.Parallel()
+
+	frames := []warnly.Frame{
+		{Module: "main", Function: "main", AbsPath: "/app/main.go", LineNo: 12},
+		{Module: "shop/checkout", Function: "Pay", AbsPath: "/app/checkout/pay.go", LineNo: 48},
+		{Module: "github.com/acme/payments", Function: "Charge", AbsPath: "/app/vendor/github.com/acme/payments/charge.go", LineNo: 97},
+	}
+
+	tests := []struct {
+		rules     *warnly.GroupingRules
+		name      string
+		wantView  string
+		wantInApp warnly.Uint8Array
+	}{
+		{
+			name:      "no rules",
+			wantView:  "github.com/acme/payments in Charge",
+			wantInApp: warnly.Uint8Array{1, 1, 1},
+		},
+		{
+			name:      "vendor prefix",
+			rules:     &warnly.GroupingRules{NotInAppPrefixes: []string{"/app/vendor/"}},
+			wantView:  "shop/checkout in Pay",
+			wantInApp: warnly.Uint8Array{1, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, GroupingRules: tt.rules}, nil
+				},
+			}
+			var stored *warnly.EventClickhouse
+			analyticsStore := &mock.AnalyticsStore{
+				StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+					stored = ev
+					return nil
+				},
+			}
+			var issue *warnly.Issue
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, iss *warnly.Issue) error {
+					iss.ID = 1
+					issue = iss
+					return nil
+				},
+			}
+			now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+			req := newIngestRequest("9b2d7c4e1f0a4b3c8d6e5f4a3b2c1d0e")
+			req.Event.Exception = []warnly.Exception{
+				{Type: "*errors.errorString", Value: "card declined", StackTrace: warnly.StackTrace{Frames: frames}},
+			}
+			_, err := svc.IngestEvent(t.Context(), req)
+			require.NoError(t, err)
+
+			require.NotNil(t, issue)
+			assert.Equal(t, tt.wantView, issue.View)
+			require.NotNil(t, stored)
+			assert.Equal(t, tt.wantInApp, stored.ExceptionFramesInApp)
+		})
+	}
+}
+
+func TestIngestEventGoDependencyFramesAreNotInApp(t *testing.T) {
+	t.Parallel()
+
+	frames := []warnly.Frame{
+		{Module: "main", Function: "main", AbsPath: "/app/main.go", LineNo: 12, InApp: true},
+		{Module: "shop/checkout", Function: "Pay", AbsPath: "/app/checkout/pay.go", LineNo: 48, InApp: true},
+		{Module: "database/sql", Function: "(*DB).QueryContext", AbsPath: "/usr/local/go/src/database/sql/sql.go", LineNo: 1793, InApp: true},
+		{Module: "github.com/lib/pq", Function: "(*conn).query", AbsPath: "/root/go/pkg/mod/github.com/lib/pq@v1.10.9/conn.go", LineNo: 885, InApp: true},
+	}
+
+	tests := []struct {
+		rules    *warnly.GroupingRules
+		name     string
+		platform warnly.Platform
+		wantView string
+	}{
+		{
+			name:     "go project",
+			platform: warnly.PlatformGolang,
+			wantView: "shop/checkout in Pay",
+		},
+		{
+			name:     "in-app prefix overrides the heuristics",
+			platform: warnly.PlatformGolang,
+			rules:    &warnly.GroupingRules{InAppPrefixes: []string{"github.com/lib/pq"}},
+			wantView: "github.com/lib/pq in (*conn).query",
+		},
+		{
+			name:     "other platforms trust the sdk",
+			platform: warnly.PlatformRust,
+			wantView: "github.com/lib/pq in (*conn).query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, Platform: tt.platform, GroupingRules: tt.rules}, nil
+				},
+			}
+			analyticsStore := &mock.AnalyticsStore{
+				StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+			}
+			var issue *warnly.Issue
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, iss *warnly.Issue) error {
+					iss.ID = 1
+					issue = iss
+					return nil
+				},
+			}
+			now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+			req := newIngestRequest("3e8a1c5b7d9f4a2c6e0b8d1f3a5c7e9b")
+			req.Event.Exception = []warnly.Exception{
+				{Type: "*pq.Error", Value: "relation does not exist", StackTrace: warnly.StackTrace{Frames: slices.Clone(frames)}},
+			}
+			_, err := svc.IngestEvent(t.Context(), req)
+			require.NoError(t, err)
+
+			require.NotNil(t, issue)
+			assert.Equal(t, tt.wantView, issue.View)
+		})
+	}
+}
+
+func TestIngestEventVerifiesSignature(t *testing.T) {
+	t.Parallel()
+
+	const secret = "8f4e2b1c9d7a6e5f4c3b2a1908f7e6d5"
+	payload := []byte(`{"event_id":"7c1e5a2b9d3f4e6a8b0c1d2e3f4a5b6c","message":"connection refused"}`)
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(payload)
+	valid := hex.EncodeToString(h.Sum(nil))
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		wantErr   error
+		wantStore int
+	}{
+		{name: "signing disabled", wantStore: 1},
+		{name: "valid signature", secret: secret, signature: valid, wantStore: 1},
+		{name: "missing signature", secret: secret, wantErr: warnly.ErrInvalidSignature},
+		{name: "wrong signature", secret: secret, signature: hex.EncodeToString([]byte("forged")), wantErr: warnly.ErrInvalidSignature},
+		{name: "malformed signature", secret: secret, signature: "not hex", wantErr: warnly.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, IngestSecret: tt.secret}, nil
+				},
+			}
+			stored := 0
+			analyticsStore := &mock.AnalyticsStore{
+				StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+					stored++
+					return nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+					issue.ID = 1
+					return nil
+				},
+			}
+			now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+			req := newIngestRequest("7c1e5a2b9d3f4e6a8b0c1d2e3f4a5b6c")
+			req.Payload = payload
+			req.Signature = tt.signature
+			_, err := svc.IngestEvent(t.Context(), req)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantStore, stored)
+		})
+	}
+}
+
+func TestIngestEventRejectsRevokedKey(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	revoked := now.Add(-time.Minute)
+	inGrace := now.Add(time.Hour)
+	revokedAt := map[string]*time.Time{
+		"newkey1": nil,
+		"oldkey1": &revoked,
+		"oldkey2": &inGrace,
+	}
+
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, projectKey string) (*warnly.ProjectOptions, error) {
+			at, ok := revokedAt[projectKey]
+			if !ok {
+				return nil, warnly.ErrProjectNotFound
+			}
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, KeyRevokedAt: at}, nil
+		},
+	}
+	stored := 0
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+			stored++
+			return nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 1
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, func() time.Time { return now }, slog.Default())
+
+	ingest := func(key, eventID string) error {
+		req := newIngestRequest(eventID)
+		req.ProjectKey = key
+		_, err := svc.IngestEvent(t.Context(), req)
+		return err
+	}
+
+	require.NoError(t, ingest("newkey1", "1a2b3c4d5e6f40718293a4b5c6d7e8f9"))
+	require.NoError(t, ingest("oldkey2", "2a2b3c4d5e6f40718293a4b5c6d7e8f9"), "the key is accepted within the grace period")
+	require.ErrorIs(t, ingest("oldkey1", "3a2b3c4d5e6f40718293a4b5c6d7e8f9"), warnly.ErrProjectNotFound)
+	// the options of the revoked key are cached now.
+	require.ErrorIs(t, ingest("oldkey1", "4a2b3c4d5e6f40718293a4b5c6d7e8f9"), warnly.ErrProjectNotFound)
+	assert.Equal(t, 2, stored)
+
+	// the key is revoked while its options are cached.
+	revokedAt["newkey1"] = &revoked
+	svc.ForgetProjectKey(testProjectID, "newkey1")
+	require.ErrorIs(t, ingest("newkey1", "5a2b3c4d5e6f40718293a4b5c6d7e8f9"), warnly.ErrProjectNotFound)
+}
+
+func TestIngestEventCachesUnknownProjects(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		created bool
+		lookups int
+	)
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			lookups++
+			if !created {
+				return nil, warnly.ErrProjectNotFound
+			}
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 1
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+	svc.RejectUnknownProjects(event.UnknownProjects{CacheTTL: 50 * time.Millisecond})
+
+	ingest := func() error {
+		_, err := svc.IngestEvent(t.Context(), newIngestRequest("6a2b3c4d5e6f40718293a4b5c6d7e8f9"))
+		return err
+	}
+	lookupCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return lookups
+	}
+
+	require.ErrorIs(t, ingest(), warnly.ErrProjectNotFound)
+	require.ErrorIs(t, ingest(), warnly.ErrProjectNotFound)
+	assert.Equal(t, 1, lookupCount(), "the failed lookup is cached")
+
+	mu.Lock()
+	created = true
+	mu.Unlock()
+
+	require.ErrorIs(t, ingest(), warnly.ErrProjectNotFound, "the failed lookup is cached until it expires")
+	require.Eventually(t, func() bool { return ingest() == nil }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, lookupCount())
+}
+
+func TestIngestEventNewProjectGrace(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		key       string
+		getErr    error
+		wantErr   error
+	}{
+		{name: "created moments ago", createdAt: now.Add(-10 * time.Second), key: testProjectKey, wantErr: warnly.ErrProjectNotReady},
+		{name: "created moments ago with another key", createdAt: now.Add(-10 * time.Second), key: "0b9e4c11",
+			wantErr: warnly.ErrProjectNotFound},
+		{name: "created long ago", createdAt: now.Add(-time.Hour), key: testProjectKey, wantErr: warnly.ErrProjectNotFound},
+		{name: "no such project", getErr: warnly.ErrProjectNotFound, wantErr: warnly.ErrProjectNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, _ int, _ string) (*warnly.ProjectOptions, error) {
+					return nil, warnly.ErrProjectNotFound
+				},
+				GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					return &warnly.Project{ID: projectID, CreatedAt: tt.createdAt, Key: tt.key}, nil
+				},
+			}
+
+			svc := event.NewEventService(projectStore, &mock.IssueStore{}, cache.New(time.Minute, time.Minute),
+				&mock.AnalyticsStore{}, event.Queue{}, nil, nil, func() time.Time { return now }, slog.Default())
+			svc.RejectUnknownProjects(event.UnknownProjects{CacheTTL: time.Minute, Grace: time.Minute})
+
+			_, err := svc.IngestEvent(t.Context(), newIngestRequest("7a2b3c4d5e6f40718293a4b5c6d7e8f9"))
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, errors.Is(tt.wantErr, warnly.ErrProjectNotReady), errors.Is(err, warnly.ErrProjectNotReady))
+		})
+	}
+}
+
+func TestRecordClientReportsAggregates(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotProjectID int
+		gotDay       time.Time
+		gotDiscarded []warnly.DiscardedEvents
+	)
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID}, nil
+		},
+		AddDiscardedEventsFn: func(_ context.Context, projectID int, day time.Time, discarded []warnly.DiscardedEvents) error {
+			gotProjectID, gotDay, gotDiscarded = projectID, day, discarded
+			return nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, &mock.IssueStore{}, cache.New(time.Minute, time.Minute),
+		&mock.AnalyticsStore{}, event.Queue{}, nil, nil, now, slog.Default())
+
+	err := svc.RecordClientReports(t.Context(), &warnly.ClientReportsRequest{
+		Reports: []warnly.ClientReport{
+			{DiscardedEvents: []warnly.DiscardedEvents{
+				{Reason: "sample_rate", Category: "error", Quantity: 3},
+				{Reason: "queue_overflow", Category: "transaction", Quantity: 0},
+				{Reason: "", Category: "error", Quantity: 5},
+			}},
+			{DiscardedEvents: []warnly.DiscardedEvents{
+				{Reason: "sample_rate", Category: "error", Quantity: 2},
+				{Reason: "ratelimit_backoff", Category: "transaction", Quantity: 1},
+			}},
+		},
+		ProjectKey: testProjectKey,
+		ProjectID:  testProjectID,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, testProjectID, gotProjectID)
+	assert.Equal(t, now(), gotDay)
+	assert.Equal(t, []warnly.DiscardedEvents{
+		{Reason: "sample_rate", Category: "error", Quantity: 5},
+		{Reason: "ratelimit_backoff", Category: "transaction", Quantity: 1},
+	}, gotDiscarded)
+}
+
+func TestIngestEventConfirmsStores(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		confirmation event.Confirmation
+		existing     bool
+		storeErr     error
+		wantSync     int
+		wantAsync    int
+	}{
+		{name: "disabled", wantAsync: 1},
+		{name: "new issue", confirmation: event.Confirmation{NewIssues: true}, wantSync: 1},
+		{name: "existing issue", confirmation: event.Confirmation{NewIssues: true}, existing: true, wantAsync: 1},
+		{name: "all traffic", confirmation: event.Confirmation{Rate: 1}, existing: true, wantSync: 1},
+		{name: "store failure", confirmation: event.Confirmation{Rate: 1}, storeErr: assert.AnError, wantSync: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					if tt.existing {
+						return &warnly.Issue{ID: 1, UUID: warnly.NewUUID()}, nil
+					}
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+					issue.ID = 1
+					return nil
+				},
+				UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error {
+					return nil
+				},
+			}
+			syncStores, asyncStores := 0, 0
+			analyticsStore := &mock.AnalyticsStore{
+				StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+					asyncStores++
+					return tt.storeErr
+				},
+				StoreEventSyncFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+					syncStores++
+					return tt.storeErr
+				},
+			}
+			now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+			svc.ConfirmStores(tt.confirmation)
+
+			_, err := svc.IngestEvent(t.Context(), newIngestRequest("5b2c8e1f3a4d4c6b9e7f0a1b2c3d4e5f"))
+			if tt.storeErr != nil {
+				require.ErrorIs(t, err, warnly.ErrStoreEvent)
+				require.ErrorIs(t, err, tt.storeErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSync, syncStores)
+			assert.Equal(t, tt.wantAsync, asyncStores)
+		})
+	}
+}
+
+func TestIngestEventDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		window      time.Duration
+		second      warnly.IngestRequest
+		wantStored  int
+		wantDeduped bool
+	}{
+		{
+			name:       "disabled",
+			second:     newIngestRequest("7c1e5a2b9d3f4e6a8b0c1d2e3f4a5b6c"),
+			wantStored: 2,
+		},
+		{
+			name:        "same event ID",
+			window:      10 * time.Second,
+			second:      newIngestRequest("7c1e5a2b9d3f4e6a8b0c1d2e3f4a5b6c"),
+			wantStored:  1,
+			wantDeduped: true,
+		},
+		{
+			name:        "same issue, message and user",
+			window:      10 * time.Second,
+			second:      newIngestRequest("8d2f6b3c0e4a5f7b9c1d2e3f4a5b6c7d"),
+			wantStored:  1,
+			wantDeduped: true,
+		},
+		{
+			name:   "another user",
+			window: 10 * time.Second,
+			second: func() warnly.IngestRequest {
+				req := newIngestRequest("8d2f6b3c0e4a5f7b9c1d2e3f4a5b6c7d")
+				req.Event.User.ID = "42"
+				return req
+			}(),
+			wantStored: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, DedupWindow: tt.window}, nil
+				},
+			}
+			issueUUID := warnly.NewUUID()
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return nil, warnly.ErrNotFound
+				},
+				StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+					issue.ID = 1
+					issue.UUID = issueUUID
+					return nil
+				},
+				UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error {
+					return nil
+				},
+			}
+			stored := 0
+			analyticsStore := &mock.AnalyticsStore{
+				StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+					stored++
+					return nil
+				},
+			}
+			now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+			res, err := svc.IngestEvent(t.Context(), newIngestRequest("7c1e5a2b9d3f4e6a8b0c1d2e3f4a5b6c"))
+			require.NoError(t, err)
+			assert.False(t, res.Deduplicated)
+
+			res, err = svc.IngestEvent(t.Context(), tt.second)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDeduped, res.Deduplicated)
+			assert.Equal(t, tt.second.Event.EventID, res.EventID)
+
+			assert.Equal(t, tt.wantStored, stored)
+		})
+	}
+}
+
+func TestIngestEventReopensIssueRecurringInNewerRelease(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		releases []string
+		reopened bool
+	}{
+		{name: "newer release", releases: []string{"1.3.0"}, reopened: true},
+		{name: "same release", releases: []string{"1.2.0"}, reopened: false},
+		{name: "older release", releases: []string{"1.1.0"}, reopened: false},
+		{name: "no release", releases: []string{""}, reopened: false},
+		{name: "newer release after cached same release", releases: []string{"1.2.0", "1.3.0", "1.3.0"}, reopened: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolvedAt := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+			issue := &warnly.Issue{
+				ID:                7,
+				UUID:              warnly.NewUUID(),
+				Status:            warnly.IssueStatusResolved,
+				ResolvedAt:        &resolvedAt,
+				ResolvedInRelease: "1.2.0",
+				ProjectID:         testProjectID,
+			}
+			projectStore := &mock.ProjectStore{
+				GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+					return &warnly.ProjectOptions{ID: projectID, Name: "backend", TeamID: 3, SampleRate: 1}, nil
+				},
+			}
+			analyticsStore := &mock.AnalyticsStore{
+				StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+			}
+			issueStore := &mock.IssueStore{
+				GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+					return issue, nil
+				},
+				UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+				ReopenIssueFn: func(_ context.Context, issueID int64) (bool, error) {
+					assert.Equal(t, issue.ID, issueID)
+					if !issue.IsResolved() {
+						return false, nil
+					}
+					issue.Status = warnly.IssueStatusUnresolved
+					issue.ResolvedAt = nil
+					issue.ResolvedInRelease = ""
+					return true, nil
+				},
+			}
+			var notifications []*warnly.IssueRegressedNotification
+			notifier := &mock.IssueNotifier{
+				NotifyIssueRegressedFn: func(_ context.Context, n *warnly.IssueRegressedNotification) error {
+					notifications = append(notifications, n)
+					return nil
+				},
+			}
+			now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+				analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+			svc.NotifyRegressions(notifier)
+			svc.NotifySubscribers(event.Subscribers{
+				Subscriptions: &mock.SubscriptionStore{
+					ListSubscribersFn: func(_ context.Context, issueID int64) ([]int64, error) {
+						assert.Equal(t, issue.ID, issueID)
+						return []int64{2}, nil
+					},
+				},
+				Teams: &mock.TeamStore{
+					ListTeammatesFn: func(_ context.Context, teamIDs []int) ([]warnly.Teammate, error) {
+						assert.Equal(t, []int{3}, teamIDs)
+						return []warnly.Teammate{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}}, nil
+					},
+				},
+			})
+
+			for i, release := range tt.releases {
+				req := newIngestRequest(fmt.Sprintf("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b%02d", i))
+				req.Event.Release = release
+				_, err := svc.IngestEvent(t.Context(), req)
+				require.NoError(t, err)
+			}
+
+			if !tt.reopened {
+				assert.True(t, issue.IsResolved())
+				assert.Equal(t, "1.2.0", issue.ResolvedInRelease)
+				assert.Empty(t, notifications)
+				return
+			}
+
+			assert.Equal(t, warnly.IssueStatusUnresolved, issue.Status)
+			assert.Nil(t, issue.ResolvedAt)
+			require.Len(t, notifications, 1)
+			assert.Equal(t, &warnly.IssueRegressedNotification{
+				RegressedAt:       now(),
+				ProjectName:       "backend",
+				Message:           warnly.DefaultMessage,
+				ResolvedInRelease: "1.2.0",
+				Release:           "1.3.0",
+				Recipients:        []warnly.Teammate{{ID: 2, Username: "bob"}},
+				IssueID:           issue.ID,
+				ProjectID:         testProjectID,
+				TeamID:            3,
+			}, notifications[0])
+		})
+	}
+}
+
+func TestIngestEventReopensIssueResolvedAfterItWasCached(t *testing.T) {
+	t.Parallel()
+
+	issue := &warnly.Issue{ID: 7, UUID: warnly.NewUUID(), ProjectID: testProjectID}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+	reopened := false
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			issue.Hash = criteria.Hash
+			return issue, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+		ReopenIssueFn: func(_ context.Context, _ int64) (bool, error) {
+			reopened = true
+			return true, nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+	svc.NotifyRegressions(&mock.IssueNotifier{
+		NotifyIssueRegressedFn: func(context.Context, *warnly.IssueRegressedNotification) error { return nil },
+	})
+
+	req := newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b00")
+	req.Event.Release = "1.2.0"
+	_, err := svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+
+	resolvedAt := now()
+	issue.Status = warnly.IssueStatusResolved
+	issue.ResolvedAt = &resolvedAt
+	issue.ResolvedInRelease = "1.2.0"
+	svc.ForgetIssue(issue.ProjectID, issue.Hash)
+
+	req = newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b01")
+	req.Event.Release = "1.3.0"
+	_, err = svc.IngestEvent(t.Context(), req)
+	require.NoError(t, err)
+	assert.True(t, reopened)
+}
+
+func TestIngestEventReopensIgnoredIssueOnTenthEvent(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	rule, err := warnly.NewIgnoreRule(warnly.IgnoreConditionCount, 10, 0, now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	issue := &warnly.Issue{
+		ID:        7,
+		UUID:      warnly.NewUUID(),
+		Status:    warnly.IssueStatusIgnored,
+		Ignore:    rule,
+		ProjectID: testProjectID,
+	}
+	ignoredEvents := 0
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, Name: "backend", TeamID: 3, SampleRate: 1}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return issue, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+		CountIgnoredEventFn: func(_ context.Context, issueID int64) (int, error) {
+			assert.Equal(t, issue.ID, issueID)
+			if !issue.IsIgnored() {
+				return 0, nil
+			}
+			ignoredEvents++
+			return ignoredEvents, nil
+		},
+		ReopenIgnoredFn: func(_ context.Context, issueID int64) (bool, error) {
+			assert.Equal(t, issue.ID, issueID)
+			if !issue.IsIgnored() {
+				return false, nil
+			}
+			issue.Status = warnly.IssueStatusUnresolved
+			issue.Ignore = nil
+			return true, nil
+		},
+	}
+	var notifications []*warnly.IssueReappearedNotification
+	notifier := &mock.IssueNotifier{
+		NotifyIssueReappearedFn: func(_ context.Context, n *warnly.IssueReappearedNotification) error {
+			notifications = append(notifications, n)
+			return nil
+		},
+	}
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+	svc.NotifyRegressions(notifier)
+	svc.NotifySubscribers(event.Subscribers{
+		Subscriptions: &mock.SubscriptionStore{
+			ListSubscribersFn: func(_ context.Context, issueID int64) ([]int64, error) {
+				assert.Equal(t, issue.ID, issueID)
+				return []int64{2}, nil
+			},
+		},
+		Teams: &mock.TeamStore{
+			ListTeammatesFn: func(_ context.Context, teamIDs []int) ([]warnly.Teammate, error) {
+				assert.Equal(t, []int{3}, teamIDs)
+				return []warnly.Teammate{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}}, nil
+			},
+		},
+	})
+
+	for i := 1; i <= 11; i++ {
+		_, err := svc.IngestEvent(t.Context(), newIngestRequest(fmt.Sprintf("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b%02d", i)))
+		require.NoError(t, err)
+
+		if i < 10 {
+			assert.Equal(t, warnly.IssueStatusIgnored, issue.Status, "event %d", i)
+			assert.Empty(t, notifications)
+			continue
+		}
+		assert.Equal(t, warnly.IssueStatusUnresolved, issue.Status, "event %d", i)
+	}
+
+	assert.Equal(t, 10, ignoredEvents)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, &warnly.IssueReappearedNotification{
+		ReappearedAt: now(),
+		ProjectName:  "backend",
+		Message:      warnly.DefaultMessage,
+		Condition:    warnly.IgnoreConditionCount,
+		Recipients:   []warnly.Teammate{{ID: 2, Username: "bob"}},
+		IssueID:      issue.ID,
+		ProjectID:    testProjectID,
+		TeamID:       3,
+	}, notifications[0])
+}
+
+func TestReopenIgnoredIssues(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	ignore := func(condition warnly.IgnoreCondition, threshold int, duration time.Duration) *warnly.IgnoreRule {
+		rule, err := warnly.NewIgnoreRule(condition, threshold, duration, now().Add(-2*time.Hour))
+		require.NoError(t, err)
+		return rule
+	}
+	ignored := []warnly.Issue{
+		{ID: 1, Hash: "a", Message: "expired", ProjectID: testProjectID, Ignore: ignore(warnly.IgnoreConditionTime, 0, time.Hour)},
+		{ID: 2, Hash: "b", Message: "snoozed", ProjectID: testProjectID, Ignore: ignore(warnly.IgnoreConditionTime, 0, 3*time.Hour)},
+		{ID: 3, Hash: "c", Message: "widespread", ProjectID: testProjectID, Ignore: ignore(warnly.IgnoreConditionUsers, 5, 0)},
+		{ID: 4, Hash: "d", Message: "contained", ProjectID: testProjectID, Ignore: ignore(warnly.IgnoreConditionUsers, 5, 0)},
+	}
+	for i := range ignored {
+		ignored[i].Status = warnly.IssueStatusIgnored
+	}
+
+	var reopened []int64
+	issueStore := &mock.IssueStore{
+		ListIgnoredIssuesFn: func(_ context.Context, conditions []warnly.IgnoreCondition) ([]warnly.Issue, error) {
+			// occurrences are counted by the ingestion.
+			assert.ElementsMatch(t, []warnly.IgnoreCondition{warnly.IgnoreConditionTime, warnly.IgnoreConditionUsers}, conditions)
+			return ignored, nil
+		},
+		ReopenIgnoredFn: func(_ context.Context, issueID int64) (bool, error) {
+			reopened = append(reopened, issueID)
+			return true, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		ListIssueMetricsFn: func(_ context.Context, criteria *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			require.Len(t, criteria.GroupIDs, 1)
+			assert.Equal(t, now().Add(-2*time.Hour), criteria.From, "users are counted since the issue was ignored")
+			users := map[int64]uint64{3: 5, 4: 4}[criteria.GroupIDs[0]]
+			return []warnly.IssueMetrics{{GID: uint64(criteria.GroupIDs[0]), UserCount: users}}, nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			return &warnly.Project{ID: projectID, Name: "backend", TeamID: 3}, nil
+		},
+	}
+	var notifications []*warnly.IssueReappearedNotification
+	notifier := &mock.IssueNotifier{
+		NotifyIssueReappearedFn: func(_ context.Context, n *warnly.IssueReappearedNotification) error {
+			notifications = append(notifications, n)
+			return nil
+		},
+	}
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+	svc.NotifyRegressions(notifier)
+
+	n, err := svc.ReopenIgnoredIssues(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []int64{1, 3}, reopened, "without waiting for the next event of the issues")
+	require.Len(t, notifications, 2)
+	assert.Equal(t, &warnly.IssueReappearedNotification{
+		ReappearedAt: now(),
+		ProjectName:  "backend",
+		Message:      "expired",
+		Condition:    warnly.IgnoreConditionTime,
+		IssueID:      1,
+		ProjectID:    testProjectID,
+		TeamID:       3,
+	}, notifications[0])
+	assert.Equal(t, warnly.IgnoreConditionUsers, notifications[1].Condition)
+}
+
+func TestIngestEventDoesNotCountUsersOfIgnoredIssue(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	rule, err := warnly.NewIgnoreRule(warnly.IgnoreConditionUsers, 1, 0, now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	issue := &warnly.Issue{
+		ID:        7,
+		UUID:      warnly.NewUUID(),
+		Status:    warnly.IssueStatusIgnored,
+		Ignore:    rule,
+		ProjectID: testProjectID,
+	}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	// the issue metrics aren't listed per event, the mock would panic otherwise.
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error { return nil },
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return issue, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	for i := range 3 {
+		req := newIngestRequest(fmt.Sprintf("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b%02d", i))
+		req.Event.User.ID = fmt.Sprint(i)
+		_, err := svc.IngestEvent(t.Context(), req)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, warnly.IssueStatusIgnored, issue.Status)
+}
+
+func TestIngestEventUsesProjectRetention(t *testing.T) {
+	t.Parallel()
+
+	var stored *warnly.EventClickhouse
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+			stored = ev
+			return nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1, RetentionDays: 30}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, _ warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 1
+			return nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	_, err := svc.IngestEvent(t.Context(), newIngestRequest("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a"))
+	require.NoError(t, err)
+
+	require.NotNil(t, stored)
+	assert.Equal(t, uint8(30), stored.RetentionDays)
+}
+
+func TestIngestEventIngestFilters(t *testing.T) {
+	t.Parallel()
+
+	var stored []string
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{
+				ID:         projectID,
+				SampleRate: 1,
+				IngestFilters: &warnly.IngestFilters{
+					DenyEnvironments: []string{"local", "dev-*"},
+					DenyReleases:     []string{"*-rc*"},
+				},
+			}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+			stored = append(stored, ev.Env+" "+ev.Release)
+			return nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: 10, UUID: warnly.NewUUID(), Hash: criteria.Hash}, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	tests := []struct {
+		env, release string
+		wantFiltered bool
+	}{
+		{env: "local", release: "app@1.0.0", wantFiltered: true},
+		{env: "production", release: "app@1.0.0"},
+		{env: "dev-alice", release: "app@1.0.0", wantFiltered: true},
+		{env: "production", release: "app@1.1.0-rc1", wantFiltered: true},
+	}
+
+	for i, tt := range tests {
+		req := newIngestRequest(fmt.Sprintf("5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6%d", i))
+		req.Event.Environment = tt.env
+		req.Event.Release = tt.release
+
+		res, err := svc.IngestEvent(t.Context(), req)
+		require.NoError(t, err, "filtered events are acknowledged")
+		assert.Equal(t, req.Event.EventID, res.EventID)
+		assert.Equal(t, tt.wantFiltered, res.Filtered, "%s %s", tt.env, tt.release)
+	}
+
+	assert.Equal(t, []string{"production app@1.0.0"}, stored)
+	assert.Zero(t, svc.DroppedEvents(testProjectID))
+}
+
+func TestIngestEventNoiseFilters(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stored          []string
+		lastSeenUpdates int
+	)
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, ev *warnly.EventClickhouse) error {
+			stored = append(stored, ev.Env+" "+ev.Release)
+			return nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return &warnly.Issue{
+				ID:   10,
+				UUID: warnly.NewUUID(),
+				Hash: criteria.Hash,
+				NoiseFilters: warnly.NoiseFilters{
+					{Tags: []warnly.NoiseTag{{Key: "env", Value: "production"}, {Key: "release", Value: "2.0.0-canary"}}},
+				},
+			}, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error {
+			lastSeenUpdates++
+			return nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+
+	tests := []struct {
+		env, release string
+		wantFiltered bool
+	}{
+		// the issue isn't cached yet.
+		{env: "production", release: "2.0.0-canary", wantFiltered: true},
+		{env: "production", release: "2.0.0"},
+		{env: "staging", release: "2.0.0-canary"},
+		// the issue is cached now.
+		{env: "production", release: "2.0.0-canary", wantFiltered: true},
+	}
+
+	for i, tt := range tests {
+		req := newIngestRequest(fmt.Sprintf("8f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6%d", i))
+		req.Event.Environment = tt.env
+		req.Event.Release = tt.release
+
+		res, err := svc.IngestEvent(t.Context(), req)
+		require.NoError(t, err, "noisy events are acknowledged")
+		assert.Equal(t, tt.wantFiltered, res.Filtered, "%s %s", tt.env, tt.release)
+	}
+
+	assert.Equal(t, []string{"production 2.0.0", "staging 2.0.0-canary"}, stored)
+	assert.Equal(t, 2, lastSeenUpdates, "noisy events don't update the last seen time of the issue")
+}
+
+func TestIngestEventVerifiesStoredEventIDs(t *testing.T) {
+	t.Parallel()
+
+	const eventID = "5f1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a"
+
+	stored := 0
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: 10, UUID: warnly.NewUUID(), Hash: criteria.Hash}, nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, _ *warnly.UpdateLastSeen) error { return nil },
+	}
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, _ string) (*warnly.ProjectOptions, error) {
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	analyticsStore := &mock.AnalyticsStore{
+		StoreEventFn: func(_ context.Context, _ *warnly.EventClickhouse) error {
+			stored++
+			return nil
+		},
+		HasEventFn: func(_ context.Context, projectID int, id string, since time.Time) (bool, error) {
+			assert.Equal(t, testProjectID, projectID)
+			assert.Equal(t, now.Add(-time.Minute), since)
+			// the event was stored before the service restarted.
+			return id == eventID, nil
+		},
+	}
+
+	svc := event.NewEventService(projectStore, issueStore, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, func() time.Time { return now }, slog.Default())
+	svc.DedupEventIDs(event.Idempotency{Window: time.Minute, VerifyStored: true})
+
+	res, err := svc.IngestEvent(t.Context(), newIngestRequest(eventID))
+	require.NoError(t, err)
+	assert.True(t, res.Deduplicated)
+	assert.Equal(t, eventID, res.EventID)
+	assert.Zero(t, stored)
+
+	res, err = svc.IngestEvent(t.Context(), newIngestRequest("0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b"))
+	require.NoError(t, err)
+	assert.False(t, res.Deduplicated)
+	assert.Equal(t, 1, stored)
+}
+
+func TestIngestEventDedupAfterAuthentication(t *testing.T) {
+	t.Parallel()
+
+	const eventID = "6a1c2f8a0b7e4d6c9a3b2e1f0d4c5b6a"
+
+	lookups := 0
+	projectStore := &mock.ProjectStore{
+		GetOptionsFn: func(_ context.Context, projectID int, key string) (*warnly.ProjectOptions, error) {
+			if key != testProjectKey {
+				return nil, warnly.ErrProjectNotFound
+			}
+			return &warnly.ProjectOptions{ID: projectID, SampleRate: 1}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		HasEventFn: func(_ context.Context, _ int, id string, _ time.Time) (bool, error) {
+			lookups++
+			return id == eventID, nil
+		},
+	}
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	svc := event.NewEventService(projectStore, &mock.IssueStore{}, cache.New(time.Minute, time.Minute),
+		analyticsStore, event.Queue{}, nil, nil, now, slog.Default())
+	svc.DedupEventIDs(event.Idempotency{Window: time.Minute, VerifyStored: true})
+
+	req := newIngestRequest(eventID)
+	req.ProjectKey = "0b9e4c11"
+
+	res, err := svc.IngestEvent(t.Context(), req)
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound, "a known event ID isn't acknowledged to a wrong key")
+	assert.False(t, res.Deduplicated)
+	assert.Zero(t, lookups)
+
+	res, err = svc.IngestEvent(t.Context(), newIngestRequest(eventID))
+	require.NoError(t, err)
+	assert.True(t, res.Deduplicated, "the rejected request doesn't mark the event ID as ingested")
+	assert.Equal(t, 1, lookups)
+}
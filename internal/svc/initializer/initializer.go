@@ -82,7 +82,7 @@ func (s *InitService) Init(ctx context.Context, email, password string) error {
 			return fmt.Errorf("create team: %w", err)
 		}
 
-		if err := uw.Teams().AddUserToTeam(ctx, now, user.ID, warnly.DefaultTeamID); err != nil {
+		if err := uw.Teams().AddUserToTeam(ctx, now, user.ID, warnly.DefaultTeamID, warnly.RoleAdmin); err != nil {
 			return fmt.Errorf("add user to team: %w", err)
 		}
 
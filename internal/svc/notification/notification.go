@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/vk-rv/warnly/internal/notifier"
@@ -151,18 +152,7 @@ func (s *NotificationService) SaveWebhookConfig(
 		}
 	}
 
-	if err := s.webhookNotifier.SendWebhook(ctx, webhookConfig, &notifier.AlertPayload{
-		AlertID:      0,
-		AlertName:    "Test Alert",
-		ProjectID:    0,
-		TeamID:       req.TeamID,
-		Status:       "test",
-		Threshold:    100,
-		Condition:    "occurrences",
-		Timeframe:    "1h",
-		HighPriority: false,
-		Timestamp:    s.now().UTC(),
-	}); err != nil {
+	if err := s.webhookNotifier.SendTest(ctx, webhookConfig, req.TeamID); err != nil {
 		return fmt.Errorf("send test webhook: %w", err)
 	}
 
@@ -222,3 +212,277 @@ func (s *NotificationService) GetWebhookConfigWithSecretByTeamID(
 		Secret: secret,
 	}, nil
 }
+
+// ListFailedDeliveries returns the most recent webhook deliveries of a team
+// that failed after all attempts.
+func (s *NotificationService) ListFailedDeliveries(
+	ctx context.Context,
+	req *warnly.ListFailedDeliveriesRequest,
+) ([]warnly.WebhookDeadLetter, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+	if !slices.ContainsFunc(teams, func(t warnly.Team) bool { return t.ID == req.TeamID }) {
+		return nil, warnly.ErrNotFound
+	}
+
+	channels, err := s.notificationStore.ListNotificationChannels(ctx, req.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("list notification channels: %w", err)
+	}
+
+	const defaultLimit = 50
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	failed := []warnly.WebhookDeadLetter{}
+	for i := range channels {
+		if channels[i].ChannelType != warnly.NotificationChannelWebhook {
+			continue
+		}
+		deadLetters, err := s.notificationStore.ListWebhookDeadLetters(ctx, channels[i].ID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("list webhook dead letters: %w", err)
+		}
+		failed = append(failed, deadLetters...)
+	}
+
+	slices.SortStableFunc(failed, func(a, b warnly.WebhookDeadLetter) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	if len(failed) > limit {
+		failed = failed[:limit]
+	}
+
+	return failed, nil
+}
+
+// NotifyIssueResolved sends the resolved issue notification to the webhook of the project team.
+// Teams without a configured webhook are skipped silently.
+func (s *NotificationService) NotifyIssueResolved(ctx context.Context, n *warnly.IssueResolvedNotification) error {
+	config, err := s.GetWebhookConfigByTeamID(ctx, n.TeamID)
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if config.URL == "" {
+		return nil
+	}
+
+	if err := s.webhookNotifier.SendIssueResolved(ctx, n, config); err != nil {
+		return fmt.Errorf("send issue resolved webhook: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyIssueRegressed sends the regressed issue notification to the webhook of the project team.
+// Teams without a configured webhook are skipped silently.
+func (s *NotificationService) NotifyIssueRegressed(ctx context.Context, n *warnly.IssueRegressedNotification) error {
+	config, err := s.GetWebhookConfigByTeamID(ctx, n.TeamID)
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if config.URL == "" {
+		return nil
+	}
+
+	if err := s.webhookNotifier.SendIssueRegressed(ctx, n, config); err != nil {
+		return fmt.Errorf("send issue regressed webhook: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyIssueReappeared sends the notification of an ignored issue reopened by its ignore condition
+// to the webhook of the project team. Teams without a configured webhook are skipped silently.
+func (s *NotificationService) NotifyIssueReappeared(ctx context.Context, n *warnly.IssueReappearedNotification) error {
+	config, err := s.GetWebhookConfigByTeamID(ctx, n.TeamID)
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if config.URL == "" {
+		return nil
+	}
+
+	if err := s.webhookNotifier.SendIssueReappeared(ctx, n, config); err != nil {
+		return fmt.Errorf("send issue reappeared webhook: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyIssueIgnored sends the ignored issue notification to the webhook of the project team.
+// Teams without a configured webhook are skipped silently.
+func (s *NotificationService) NotifyIssueIgnored(ctx context.Context, n *warnly.IssueIgnoredNotification) error {
+	config, err := s.GetWebhookConfigByTeamID(ctx, n.TeamID)
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if config.URL == "" {
+		return nil
+	}
+
+	if err := s.webhookNotifier.SendIssueIgnored(ctx, n, config); err != nil {
+		return fmt.Errorf("send issue ignored webhook: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyIssueCommented sends the notification of a new message to the webhook of the project team.
+// Teams without a configured webhook are skipped silently.
+func (s *NotificationService) NotifyIssueCommented(ctx context.Context, n *warnly.IssueCommentedNotification) error {
+	config, err := s.GetWebhookConfigByTeamID(ctx, n.TeamID)
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if config.URL == "" {
+		return nil
+	}
+
+	if err := s.webhookNotifier.SendIssueCommented(ctx, n, config); err != nil {
+		return fmt.Errorf("send issue commented webhook: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyAlert sends the alert notification to the webhook. Deliveries to the same URL
+// beyond the configured concurrency wait for a free slot.
+func (s *NotificationService) NotifyAlert(
+	ctx context.Context,
+	alert *warnly.Alert,
+	config *warnly.WebhookConfig,
+	notificationType warnly.AlertNotificationType,
+) error {
+	switch notificationType {
+	case warnly.AlertNotificationTriggered:
+		return s.webhookNotifier.SendAlertTriggered(ctx, alert, config)
+	case warnly.AlertNotificationEscalated:
+		return s.webhookNotifier.SendAlertEscalated(ctx, alert, config)
+	default:
+		return s.webhookNotifier.SendAlertResolved(ctx, alert, config)
+	}
+}
+
+// Dispatch sends the alert notification to the enabled channels one after another and records
+// the outcome of every delivery. A failing channel doesn't stop delivery to the others,
+// the failures are returned joined. Notifications held back by the alert cooldown
+// reach no channel. When a trigger notification reaches at least one channel,
+// alert.NotificationSentAt is set and the caller is expected to store the alert.
+func (s *NotificationService) Dispatch(
+	ctx context.Context,
+	alert *warnly.Alert,
+	channels []warnly.NotificationChannel,
+	notificationType warnly.AlertNotificationType,
+) error {
+	now := s.now().UTC()
+	if alert.NotificationSuppressed(notificationType, now) {
+		s.logger.Info("alert notification suppressed by cooldown",
+			slog.Int("alert_id", alert.ID),
+			slog.String("notification_type", string(notificationType)),
+		)
+		return nil
+	}
+
+	var errs []error
+	delivered := false
+	for i := range channels {
+		if !channels[i].Enabled {
+			continue
+		}
+		if err := s.notifyChannel(ctx, alert, &channels[i], notificationType); err != nil {
+			errs = append(errs, fmt.Errorf("channel %d: %w", channels[i].ID, err))
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered && notificationType == warnly.AlertNotificationTriggered {
+		alert.NotificationSentAt = &now
+	}
+
+	return errors.Join(errs...)
+}
+
+// notifyChannel sends the notification to the channel and records the delivery outcome.
+func (s *NotificationService) notifyChannel(
+	ctx context.Context,
+	alert *warnly.Alert,
+	channel *warnly.NotificationChannel,
+	notificationType warnly.AlertNotificationType,
+) error {
+	notification := &warnly.AlertNotification{
+		CreatedAt:        s.now().UTC(),
+		AlertID:          alert.ID,
+		ChannelID:        channel.ID,
+		NotificationType: notificationType,
+		Status:           warnly.AlertNotificationPending,
+	}
+
+	if err := s.notificationStore.CreateAlertNotification(ctx, notification); err != nil {
+		return fmt.Errorf("create notification record: %w", err)
+	}
+
+	sendErr := s.sendToChannel(ctx, alert, channel, notificationType)
+	if sendErr != nil {
+		notification.Status = warnly.AlertNotificationFailed
+		notification.ErrorMessage = sendErr.Error()
+	} else {
+		notification.Status = warnly.AlertNotificationSent
+		sentAt := s.now().UTC()
+		notification.SentAt = &sentAt
+	}
+
+	if err := s.notificationStore.UpdateAlertNotification(ctx, notification); err != nil {
+		s.logger.Error("failed to update notification record",
+			slog.Int64("notification_id", notification.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	return sendErr
+}
+
+// sendToChannel delivers the notification through the channel type.
+func (s *NotificationService) sendToChannel(
+	ctx context.Context,
+	alert *warnly.Alert,
+	channel *warnly.NotificationChannel,
+	notificationType warnly.AlertNotificationType,
+) error {
+	switch channel.ChannelType {
+	case warnly.NotificationChannelWebhook:
+		config, err := s.notificationStore.GetWebhookConfig(ctx, channel.ID)
+		if err != nil {
+			return fmt.Errorf("get webhook config: %w", err)
+		}
+
+		if config.VerifiedAt == nil {
+			return errors.New("webhook not verified")
+		}
+
+		return s.NotifyAlert(ctx, alert, config, notificationType)
+
+	default:
+		return fmt.Errorf("unsupported channel type: %s", channel.ChannelType)
+	}
+}
@@ -0,0 +1,252 @@
+package notification_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/notifier"
+	"github.com/vk-rv/warnly/internal/svc/notification"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// blockingDestination is a webhook receiver that holds requests until released
+// and records the highest number of requests it served at once.
+type blockingDestination struct {
+	server      *httptest.Server
+	received    chan struct{}
+	release     chan struct{}
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func newBlockingDestination(t *testing.T) *blockingDestination {
+	t.Helper()
+
+	d := &blockingDestination{
+		received: make(chan struct{}, 16),
+		release:  make(chan struct{}),
+	}
+	d.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := d.inFlight.Add(1)
+		defer d.inFlight.Add(-1)
+		for {
+			current := d.maxInFlight.Load()
+			if n <= current || d.maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		d.received <- struct{}{}
+		<-d.release
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(d.server.Close)
+
+	return d
+}
+
+func newService(limit int) *notification.NotificationService {
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	store := &mock.NotificationStore{
+		CreateWebhookDeadLetterFn: func(context.Context, *warnly.WebhookDeadLetter) error { return nil },
+	}
+	webhookNotifier := notifier.NewWebhookNotifier(store, []byte("key"), &http.Client{Timeout: 5 * time.Second},
+		notifier.RetryPolicy{}, now, slog.Default())
+	webhookNotifier.LimitConcurrency(limit)
+	return notification.NewNotificationService(nil, nil, webhookNotifier, now, slog.Default())
+}
+
+func TestNotifyAlertLimitsConcurrencyPerDestination(t *testing.T) {
+	t.Parallel()
+
+	const sends = 3
+
+	svc := newService(1)
+	shared := newBlockingDestination(t)
+	other := newBlockingDestination(t)
+
+	alert := &warnly.Alert{ID: 1, RuleName: "Error spike"}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, sends+1)
+	for range sends {
+		wg.Go(func() {
+			errs <- svc.NotifyAlert(t.Context(), alert, &warnly.WebhookConfig{URL: shared.server.URL}, warnly.AlertNotificationTriggered)
+		})
+	}
+
+	select {
+	case <-shared.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first notification was not delivered")
+	}
+
+	// The shared destination is busy, a different destination must not wait for it.
+	otherDone := make(chan error, 1)
+	go func() {
+		otherDone <- svc.NotifyAlert(t.Context(), alert, &warnly.WebhookConfig{URL: other.server.URL}, warnly.AlertNotificationTriggered)
+	}()
+	select {
+	case <-other.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification to another destination was blocked")
+	}
+	close(other.release)
+	require.NoError(t, <-otherDone)
+
+	select {
+	case <-shared.received:
+		t.Fatal("second notification reached the busy destination")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(shared.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), shared.maxInFlight.Load())
+}
+
+func TestNotifyAlertWaitingForDestinationRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	svc := newService(1)
+	dest := newBlockingDestination(t)
+	defer close(dest.release)
+
+	alert := &warnly.Alert{ID: 1, RuleName: "Error spike"}
+	config := &warnly.WebhookConfig{URL: dest.server.URL}
+
+	go func() {
+		_ = svc.NotifyAlert(context.Background(), alert, config, warnly.AlertNotificationTriggered)
+	}()
+	<-dest.received
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	err := svc.NotifyAlert(ctx, alert, config, warnly.AlertNotificationResolved)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDispatchAppliesCooldownAcrossChannels(t *testing.T) {
+	t.Parallel()
+
+	var received atomic.Int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(failing.Close)
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(working.Close)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	clock := func() time.Time { return now }
+	verifiedAt := start.Add(-time.Hour)
+	urls := map[int]string{1: failing.URL, 2: working.URL}
+
+	var records []warnly.AlertNotification
+	store := &mock.NotificationStore{
+		GetWebhookConfigFn: func(_ context.Context, channelID int) (*warnly.WebhookConfig, error) {
+			return &warnly.WebhookConfig{ChannelID: channelID, URL: urls[channelID], VerifiedAt: &verifiedAt}, nil
+		},
+		CreateAlertNotificationFn: func(context.Context, *warnly.AlertNotification) error { return nil },
+		UpdateAlertNotificationFn: func(_ context.Context, n *warnly.AlertNotification) error {
+			records = append(records, *n)
+			return nil
+		},
+		CreateWebhookDeadLetterFn: func(context.Context, *warnly.WebhookDeadLetter) error { return nil },
+	}
+	webhookNotifier := notifier.NewWebhookNotifier(store, []byte("key"), &http.Client{Timeout: 5 * time.Second},
+		notifier.RetryPolicy{}, clock, slog.Default())
+	svc := notification.NewNotificationService(store, nil, webhookNotifier, clock, slog.Default())
+
+	channels := []warnly.NotificationChannel{
+		{ID: 1, ChannelType: warnly.NotificationChannelWebhook, Enabled: true},
+		{ID: 2, ChannelType: warnly.NotificationChannelWebhook, Enabled: true},
+		{ID: 3, ChannelType: warnly.NotificationChannelWebhook, Enabled: false},
+	}
+	alert := &warnly.Alert{
+		ID:              1,
+		RuleName:        "Error spike",
+		Status:          warnly.AlertStatusTriggered,
+		Timeframe:       warnly.AlertTimeframe15Min,
+		LastTriggeredAt: &start,
+	}
+
+	err := svc.Dispatch(t.Context(), alert, channels, warnly.AlertNotificationTriggered)
+	require.ErrorContains(t, err, "channel 1")
+	assert.Equal(t, int32(1), received.Load(), "the failing channel doesn't block the other one")
+	require.Len(t, records, 2)
+	assert.Equal(t, warnly.AlertNotificationFailed, records[0].Status)
+	assert.Equal(t, warnly.AlertNotificationSent, records[1].Status)
+	require.NotNil(t, alert.NotificationSentAt)
+	assert.Equal(t, start, *alert.NotificationSentAt)
+
+	// The rule flaps on the next tick, within the cooldown of the first notification.
+	now = start.Add(time.Minute)
+	triggeredAgain := now
+	alert.LastTriggeredAt = &triggeredAgain
+	require.NoError(t, svc.Dispatch(t.Context(), alert, channels, warnly.AlertNotificationTriggered))
+	require.NoError(t, svc.Dispatch(t.Context(), alert, channels, warnly.AlertNotificationResolved))
+	assert.Equal(t, int32(1), received.Load(), "suppressed notifications reach no channel")
+	assert.Len(t, records, 2)
+	assert.Equal(t, start, *alert.NotificationSentAt)
+
+	now = start.Add(16 * time.Minute)
+	require.Error(t, svc.Dispatch(t.Context(), alert, channels, warnly.AlertNotificationTriggered))
+	assert.Equal(t, int32(2), received.Load(), "notifications resume after the cooldown")
+	assert.Equal(t, now, *alert.NotificationSentAt)
+}
+
+func TestListFailedDeliveriesMergesWebhookChannels(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := &mock.NotificationStore{
+		ListNotificationChannelsFn: func(context.Context, int) ([]warnly.NotificationChannel, error) {
+			return []warnly.NotificationChannel{
+				{ID: 1, ChannelType: warnly.NotificationChannelWebhook},
+				{ID: 2, ChannelType: warnly.NotificationChannelWebhook},
+			}, nil
+		},
+		ListWebhookDeadLettersFn: func(_ context.Context, channelID, _ int) ([]warnly.WebhookDeadLetter, error) {
+			if channelID == 1 {
+				return []warnly.WebhookDeadLetter{
+					{ID: 1, ChannelID: 1, CreatedAt: start.Add(2 * time.Minute)},
+					{ID: 2, ChannelID: 1, CreatedAt: start},
+				}, nil
+			}
+			return []warnly.WebhookDeadLetter{{ID: 3, ChannelID: 2, CreatedAt: start.Add(time.Minute)}}, nil
+		},
+	}
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(context.Context, int) ([]warnly.Team, error) { return []warnly.Team{{ID: 10}}, nil },
+	}
+	svc := notification.NewNotificationService(store, teamStore, nil, time.Now, slog.Default())
+
+	failed, err := svc.ListFailedDeliveries(t.Context(), &warnly.ListFailedDeliveriesRequest{
+		User:   &warnly.User{ID: 1},
+		TeamID: 10,
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, failed, 2)
+	assert.Equal(t, int64(1), failed[0].ID)
+	assert.Equal(t, int64(3), failed[1].ID)
+}
@@ -5,10 +5,15 @@ package project
 import (
 	"cmp"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/microcosm-cc/bluemonday"
@@ -23,21 +28,61 @@ const (
 
 // ProjectService implements warnly.ProjectService interface.
 type ProjectService struct {
-	now             func() time.Time
-	projectStore    warnly.ProjectStore
-	assingmentStore warnly.AssingmentStore
-	teamStore       warnly.TeamStore
-	issueStore      warnly.IssueStore
-	analyticsStore  warnly.AnalyticsStore
-	messageStore    warnly.MessageStore
-	mentionStore    warnly.MentionStore
-	uow             uow.StartUnitOfWork
-	sanitizerPolicy *bluemonday.Policy
-	logger          *slog.Logger
-	baseURL         string
-	scheme          string
-	publicBaseURL   string
-	publicScheme    string
+	now               func() time.Time
+	projectStore      warnly.ProjectStore
+	assingmentStore   warnly.AssingmentStore
+	teamStore         warnly.TeamStore
+	issueStore        warnly.IssueStore
+	analyticsStore    warnly.AnalyticsStore
+	messageStore      warnly.MessageStore
+	mentionStore      warnly.MentionStore
+	activityStore     warnly.ActivityStore
+	subscriptionStore warnly.SubscriptionStore
+	labelStore        warnly.IssueLabelStore
+	seenStore         warnly.SeenStore
+	issueNotifier     warnly.IssueNotifier
+	ingestCache       warnly.IngestCache
+	uow               uow.StartUnitOfWork
+	sanitizerPolicy   *bluemonday.Policy
+	logger            *slog.Logger
+	baseURL           string
+	scheme            string
+	publicBaseURL     string
+	publicScheme      string
+	issuesPeriod      string
+	pageSize          int
+	maxIssues         int
+	deletionGrace     time.Duration
+	newIssueWindow    time.Duration
+	hotnessHalfLife   time.Duration
+	visibleFrames     int
+	readOnly          atomic.Bool
+}
+
+// Options tune the listing defaults of ProjectService, zero values keep the defaults.
+type Options struct {
+	// IssuesPeriod is the time range of the issues list when no period is requested,
+	// e.g. 7d, warnly.DefaultIssuesPeriod by default.
+	IssuesPeriod string
+	// PageSize is the number of issues on a page of the project details, warnly.PageSize by default.
+	PageSize int
+	// MaxIssues caps the number of issues returned by ListIssues, warnly.MaxListIssues by default.
+	MaxIssues int
+	// DeletionGracePeriod is how long a deleted project can be restored,
+	// warnly.DefaultProjectDeletionGracePeriod by default.
+	DeletionGracePeriod time.Duration
+	// NewIssueWindow is how long an issue is listed as new after it was first seen,
+	// warnly.DefaultNewIssueWindow by default.
+	NewIssueWindow time.Duration
+	// VisibleFrames overrides the number of stack frames shown before the rest are collapsed,
+	// zero keeps the default of the project platform.
+	VisibleFrames int
+	// HotnessHalfLife is how fast events stop counting to the hotness of an issue,
+	// warnly.DefaultHotnessHalfLife by default.
+	HotnessHalfLife time.Duration
+	// ReadOnly starts the service in read-only maintenance mode, mutating actions are rejected
+	// with warnly.ErrReadOnly until it is turned off with SetReadOnly.
+	ReadOnly bool
 }
 
 // NewProjectService is a constructor of project service.
@@ -48,33 +93,133 @@ func NewProjectService(
 	issueStore warnly.IssueStore,
 	messageStore warnly.MessageStore,
 	mentionStore warnly.MentionStore,
+	activityStore warnly.ActivityStore,
+	subscriptionStore warnly.SubscriptionStore,
+	labelStore warnly.IssueLabelStore,
+	seenStore warnly.SeenStore,
 	analyticsStore warnly.AnalyticsStore,
+	issueNotifier warnly.IssueNotifier,
 	uw uow.StartUnitOfWork,
 	policy *bluemonday.Policy,
 	baseURL string,
 	scheme string,
 	publicBaseURL string,
 	publicScheme string,
+	opts Options,
 	now func() time.Time,
 	logger *slog.Logger,
 ) *ProjectService {
-	return &ProjectService{
-		assingmentStore: assingmentStore,
-		projectStore:    projectStore,
-		teamStore:       teamStore,
-		issueStore:      issueStore,
-		messageStore:    messageStore,
-		mentionStore:    mentionStore,
-		analyticsStore:  analyticsStore,
-		baseURL:         baseURL,
-		scheme:          scheme,
-		publicBaseURL:   publicBaseURL,
-		publicScheme:    publicScheme,
-		uow:             uw,
-		sanitizerPolicy: policy,
-		logger:          logger,
-		now:             now,
+	if opts.IssuesPeriod == "" {
+		opts.IssuesPeriod = warnly.DefaultIssuesPeriod
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = warnly.PageSize
+	}
+	if opts.MaxIssues <= 0 {
+		opts.MaxIssues = warnly.MaxListIssues
+	}
+	if opts.DeletionGracePeriod <= 0 {
+		opts.DeletionGracePeriod = warnly.DefaultProjectDeletionGracePeriod
+	}
+	if opts.NewIssueWindow <= 0 {
+		opts.NewIssueWindow = warnly.DefaultNewIssueWindow
+	}
+	if opts.HotnessHalfLife <= 0 {
+		opts.HotnessHalfLife = warnly.DefaultHotnessHalfLife
+	}
+	s := &ProjectService{
+		assingmentStore:   assingmentStore,
+		projectStore:      projectStore,
+		teamStore:         teamStore,
+		issueStore:        issueStore,
+		messageStore:      messageStore,
+		mentionStore:      mentionStore,
+		activityStore:     activityStore,
+		subscriptionStore: subscriptionStore,
+		labelStore:        labelStore,
+		seenStore:         seenStore,
+		analyticsStore:    analyticsStore,
+		issueNotifier:     issueNotifier,
+		baseURL:           baseURL,
+		scheme:            scheme,
+		publicBaseURL:     publicBaseURL,
+		publicScheme:      publicScheme,
+		uow:               uw,
+		sanitizerPolicy:   policy,
+		logger:            logger,
+		now:               now,
+		issuesPeriod:      opts.IssuesPeriod,
+		pageSize:          opts.PageSize,
+		maxIssues:         opts.MaxIssues,
+		deletionGrace:     opts.DeletionGracePeriod,
+		newIssueWindow:    opts.NewIssueWindow,
+		visibleFrames:     opts.VisibleFrames,
+		hotnessHalfLife:   opts.HotnessHalfLife,
+	}
+	s.readOnly.Store(opts.ReadOnly)
+	return s
+}
+
+// ReadOnly reports whether mutating actions are rejected with warnly.ErrReadOnly.
+func (s *ProjectService) ReadOnly() bool {
+	return s.readOnly.Load()
+}
+
+// SetReadOnly turns the read-only maintenance mode on or off, ingest and reads are not affected.
+// The mode is held in memory, so it applies to this process only and is lost on restart.
+func (s *ProjectService) SetReadOnly(readOnly bool) {
+	s.readOnly.Store(readOnly)
+}
+
+// SetIngestCache sets the ingest cache the issues whose status or filters changed
+// and the keys of projects whose ingest options changed are dropped from.
+func (s *ProjectService) SetIngestCache(c warnly.IngestCache) {
+	s.ingestCache = c
+}
+
+// forgetIssue drops the issue from the ingest cache, if one is set.
+func (s *ProjectService) forgetIssue(issue *warnly.Issue) {
+	if s.ingestCache != nil {
+		s.ingestCache.ForgetIssue(issue.ProjectID, issue.Hash)
+	}
+}
+
+// forgetProjectKey drops the options of the project key from the ingest cache, if one is set.
+func (s *ProjectService) forgetProjectKey(projectID int, key string) {
+	if s.ingestCache != nil {
+		s.ingestCache.ForgetProjectKey(projectID, key)
+	}
+}
+
+// forgetProjectKeys drops the options of all keys of the project from the ingest cache, if one is set,
+// so that events are ingested with the changed options without waiting for them to expire.
+func (s *ProjectService) forgetProjectKeys(ctx context.Context, projectID int) error {
+	if s.ingestCache == nil {
+		return nil
+	}
+
+	keys, err := s.projectStore.ListKeys(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		s.ingestCache.ForgetProjectKey(projectID, keys[i].Key)
+	}
+
+	return nil
+}
+
+// isNewIssue reports whether an issue first seen at the given time is still within the new issue window.
+func (s *ProjectService) isNewIssue(firstSeen time.Time) bool {
+	return firstSeen.UTC().After(s.now().UTC().Add(-s.newIssueWindow))
+}
+
+// visibleFramesOf returns the number of stack frames shown expanded for the issues of a platform.
+func (s *ProjectService) visibleFramesOf(platform warnly.Platform) int {
+	if s.visibleFrames > 0 {
+		return s.visibleFrames
 	}
+	return platform.VisibleFrames()
 }
 
 // CreateProject creates a new project.
@@ -83,6 +228,10 @@ func (s *ProjectService) CreateProject(
 	req *warnly.CreateProjectRequest,
 	user *warnly.User,
 ) (*warnly.ProjectInfo, error) {
+	if s.ReadOnly() {
+		return nil, warnly.ErrReadOnly
+	}
+
 	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
 	if err != nil {
 		return nil, err
@@ -113,7 +262,11 @@ func (s *ProjectService) CreateProject(
 		Key:       key,
 	}
 
-	if err := s.projectStore.CreateProject(ctx, project); err != nil {
+	// the project and its key are created together, so that the DSN is never returned for a project without a key.
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		return uw.Projects().CreateProject(ctx, project)
+	}, s.projectStore)
+	if err != nil {
 		return nil, err
 	}
 
@@ -125,8 +278,95 @@ func (s *ProjectService) CreateProject(
 	}, nil
 }
 
-// DeleteProject deletes a project by unique identifier.
+// ImportProjects creates the projects of a manifest, skipping the ones that already exist
+// in the team, so that importing the same manifest again returns the same DSNs.
+// The whole manifest is validated before any project is created.
+func (s *ProjectService) ImportProjects(
+	ctx context.Context,
+	req *warnly.ImportProjectsRequest,
+) (*warnly.ImportProjectsResult, error) {
+	if len(req.Projects) == 0 || len(req.Projects) > warnly.MaxManifestProjects {
+		return nil, fmt.Errorf("%w: expected 1 to %d projects, got %d",
+			warnly.ErrInvalidManifest, warnly.MaxManifestProjects, len(req.Projects))
+	}
+
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range req.Projects {
+		entry := &req.Projects[i]
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: project %d: %w", warnly.ErrInvalidManifest, i+1, err)
+		}
+		if !slices.ContainsFunc(teams, func(t warnly.Team) bool { return t.ID == entry.TeamID }) {
+			return nil, fmt.Errorf("%w: project %d: team %d not found", warnly.ErrInvalidManifest, i+1, entry.TeamID)
+		}
+	}
+
+	res := &warnly.ImportProjectsResult{Projects: make([]warnly.ImportedProject, 0, len(req.Projects))}
+	for i := range req.Projects {
+		entry := &req.Projects[i]
+
+		existing, err := s.findTeamProject(ctx, entry.TeamID, entry.Name)
+		if err != nil {
+			return nil, fmt.Errorf("import project %q: %w", entry.Name, err)
+		}
+		if existing != nil {
+			res.Projects = append(res.Projects, warnly.ImportedProject{
+				ProjectInfo: warnly.ProjectInfo{
+					ID:       existing.ID,
+					Name:     existing.Name,
+					DSN:      projectDSN(existing.ID, existing.Key, s.publicBaseURL, s.publicScheme),
+					Platform: strings.ToLower(existing.Platform.String()),
+				},
+				TeamID: existing.TeamID,
+			})
+			continue
+		}
+
+		info, err := s.CreateProject(ctx, &warnly.CreateProjectRequest{
+			ProjectName: entry.Name,
+			Platform:    entry.Platform,
+			TeamID:      entry.TeamID,
+		}, req.User)
+		if err != nil {
+			return nil, fmt.Errorf("import project %q: %w", entry.Name, err)
+		}
+		res.Projects = append(res.Projects, warnly.ImportedProject{
+			ProjectInfo: *info,
+			TeamID:      entry.TeamID,
+			Created:     true,
+		})
+	}
+
+	return res, nil
+}
+
+// findTeamProject returns the team project with exactly the given name, or nil if there is none.
+func (s *ProjectService) findTeamProject(ctx context.Context, teamID int, name string) (*warnly.Project, error) {
+	projects, err := s.projectStore.ListProjects(ctx, []int{teamID}, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		if projects[i].Name == name {
+			return s.projectStore.GetProject(ctx, projects[i].ID)
+		}
+	}
+
+	return nil, nil //nolint:nilnil // no project is not an error
+}
+
+// DeleteProject marks a project deleted by unique identifier, the project is hidden
+// and purged with its events once the deletion grace period passes unless it is restored.
 func (s *ProjectService) DeleteProject(ctx context.Context, projectID int, user *warnly.User) error {
+	if s.ReadOnly() {
+		return warnly.ErrReadOnly
+	}
+
 	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
 	if err != nil {
 		return err
@@ -137,13 +377,35 @@ func (s *ProjectService) DeleteProject(ctx context.Context, projectID int, user
 		return err
 	}
 
-	for _, team := range teams {
-		if team.ID == project.TeamID {
-			return s.projectStore.DeleteProject(ctx, projectID)
-		}
+	if err := requireRole(teams, project.TeamID, warnly.RoleAdmin); err != nil {
+		return err
 	}
 
-	return warnly.ErrProjectNotFound
+	return s.projectStore.SoftDelete(ctx, projectID, s.now().UTC())
+}
+
+// RestoreProject brings back a project deleted within the deletion grace period.
+func (s *ProjectService) RestoreProject(ctx context.Context, projectID int, user *warnly.User) error {
+	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
+	if err != nil {
+		return err
+	}
+
+	project, err := s.projectStore.GetDeletedProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	// the project past the grace period may be purged at any moment.
+	if s.now().UTC().Sub(*project.DeletedAt) >= s.deletionGrace {
+		return warnly.ErrProjectNotFound
+	}
+
+	if err := requireRole(teams, project.TeamID, warnly.RoleAdmin); err != nil {
+		return err
+	}
+
+	return s.projectStore.Restore(ctx, projectID)
 }
 
 // GetProject returns a project by unique identifier.
@@ -213,6 +475,18 @@ func (s *ProjectService) ListProjects(
 
 	assignEventsToProjects(projects, eventsMap)
 
+	users, err := s.analyticsStore.CountUniqueUsers(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: projectIDS,
+		From:       currentTime.Add(-24 * time.Hour),
+		To:         currentTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		projects[i].UniqueUsers = uniqueUsersOf(users, projects[i].ID)
+	}
+
 	return &warnly.ListProjectsResult{Teams: teams, Projects: projects, Criteria: criteria}, nil
 }
 
@@ -243,6 +517,19 @@ func (s *ProjectService) GetProjectDetails(
 		req.Issues = warnly.IssuesTypeAll
 	}
 
+	// The first page and the pages after a cursor are listed by the analytics store, so that projects
+	// with many issues aren't paginated in memory. New issues are few and are always paginated in memory,
+	// as are the pages requested by number without a cursor.
+	var cursor *warnly.IssueCursor
+	if req.Cursor != "" && req.Issues == warnly.IssuesTypeAll {
+		var err error
+		cursor, err = warnly.ParseIssueCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	paged := req.Issues == warnly.IssuesTypeAll && (cursor != nil || req.Page <= 1)
+
 	project, err := s.GetProject(ctx, req.ProjectID, user)
 	if err != nil {
 		return nil, err
@@ -253,37 +540,74 @@ func (s *ProjectService) GetProjectDetails(
 		return nil, err
 	}
 
-	issues, err := s.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
-		ProjectIDs: []int{project.ID},
-		From:       from,
-		To:         to,
-	})
-	if err != nil {
-		return nil, err
+	levels := req.Levels
+	if len(levels) == 0 {
+		levels = warnly.DefaultIssueLevels
 	}
-	if len(issues) == 0 {
-		period := req.Period
-		if period == "" {
-			period = defaultPeriod
-		}
-		return &warnly.ProjectDetails{
-			Project: project,
-			Period:  period,
-			Page:    req.Page,
-			Issues:  req.Issues,
-		}, nil
+
+	var (
+		issues     []warnly.Issue
+		issueList  []warnly.IssueEntry
+		counts     warnly.IssueCounts
+		nextCursor string
+	)
+	if paged {
+		issueList, issues, nextCursor, err = s.listIssuePage(ctx, project.ID, cursor, levels, from, to, !req.IncludeIgnored)
+		if err != nil {
+			return nil, err
+		}
+		counts, err = s.issueStore.CountIssues(ctx, &warnly.CountIssuesCriteria{
+			ProjectIDs:     []int{project.ID},
+			From:           from,
+			To:             to,
+			NewSince:       s.now().UTC().Add(-s.newIssueWindow),
+			ExcludeIgnored: !req.IncludeIgnored,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		issues, err = s.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
+			ProjectIDs:     []int{project.ID},
+			From:           from,
+			To:             to,
+			ExcludeIgnored: !req.IncludeIgnored,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) == 0 {
+			period := req.Period
+			if period == "" {
+				period = defaultPeriod
+			}
+			return &warnly.ProjectDetails{
+				Project: project,
+				Period:  period,
+				Levels:  levels,
+				Page:    req.Page,
+				Issues:  req.Issues,
+			}, nil
+		}
+
+		issueList, err = s.listIssueEntries(ctx, project.ID, issues, levels, from, to)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	events, err := s.analyticsStore.CalculateEvents(ctx, &warnly.ListIssueMetricsCriteria{
+	eventsCriteria := &warnly.ListIssueMetricsCriteria{
 		ProjectIDs: []int{project.ID},
 		From:       from,
 		To:         to,
-	})
+	}
+	// the hourly counts are summed from the environments, so that the events are scanned once.
+	eventsByEnv, err := s.analyticsStore.CalculateEventsByEnv(ctx, eventsCriteria)
 	if err != nil {
 		return nil, err
 	}
 
-	issueList, err := s.listIssueEntries(ctx, project.ID, issues, from, to)
+	users, err := s.analyticsStore.CountUniqueUsers(ctx, eventsCriteria)
 	if err != nil {
 		return nil, err
 	}
@@ -293,18 +617,29 @@ func (s *ProjectService) GetProjectDetails(
 		return nil, err
 	}
 
-	project.Events = events
-	project.AllLength = len(issueList)
-	project.NewIssueList = filterRecentIssues(issueList, s.now())
-	project.NewLength = len(project.NewIssueList)
-	project.IssueList = paginate(issueList, req.Page, warnly.PageSize)
-	project.NewIssueList = paginate(project.NewIssueList, req.Page, warnly.PageSize)
+	project.Events = warnly.EnvEventsList(eventsByEnv).Hourly()
+	project.UniqueUsers = uniqueUsersOf(users, project.ID)
+	if paged {
+		project.AllLength = counts.All
+		project.NewLength = counts.New
+		project.IssueList = issueList
+		project.ResultIssueList = issueList
+	} else {
+		project.AllLength = len(issueList)
+		project.NewIssueList = filterNewIssues(issueList)
+		project.NewLength = len(project.NewIssueList)
+		project.IssueList = paginate(issueList, req.Page, s.pageSize)
+		project.NewIssueList = paginate(project.NewIssueList, req.Page, s.pageSize)
+		if req.Issues == warnly.IssuesTypeAll && req.Page*s.pageSize < len(issueList) {
+			nextCursor = warnly.CursorAt(&project.IssueList[len(project.IssueList)-1]).String()
+		}
 
-	switch req.Issues {
-	case warnly.IssuesTypeAll:
-		project.ResultIssueList = project.IssueList
-	case warnly.IssuesTypeNew:
-		project.ResultIssueList = project.NewIssueList
+		switch req.Issues {
+		case warnly.IssuesTypeAll:
+			project.ResultIssueList = project.IssueList
+		case warnly.IssuesTypeNew:
+			project.ResultIssueList = project.NewIssueList
+		}
 	}
 
 	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
@@ -315,7 +650,12 @@ func (s *ProjectService) GetProjectDetails(
 		return nil, err
 	}
 
-	assignments, err := s.buildTeammateAssigns(ctx, teammates, issues)
+	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.buildTeammateAssigns(ctx, teammates, teams, issues)
 	if err != nil {
 		return nil, err
 	}
@@ -329,9 +669,13 @@ func (s *ProjectService) GetProjectDetails(
 		Project:     project,
 		Teammates:   teammates,
 		Assignments: assignments,
+		EventsByEnv: eventsByEnv,
 		Period:      period,
+		Levels:      levels,
 		Page:        req.Page,
 		Issues:      req.Issues,
+		Cursor:      cursorParam(cursor),
+		NextCursor:  nextCursor,
 	}, nil
 }
 
@@ -360,9 +704,15 @@ func (s *ProjectService) GetDiscussion(ctx context.Context, req *warnly.GetDiscu
 		return nil, err
 	}
 
+	activity, err := s.activityStore.ListIssueActivity(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &warnly.Discussion{
 		Teammates: teammates,
 		Messages:  messages,
+		Activity:  activity,
 		Info: warnly.DiscussionInfo{
 			ProjectID:      project.ID,
 			IssueID:        req.IssueID,
@@ -371,6 +721,27 @@ func (s *ProjectService) GetDiscussion(ctx context.Context, req *warnly.GetDiscu
 	}, nil
 }
 
+// GetIssueActivity returns the timeline of an issue in chronological order.
+func (s *ProjectService) GetIssueActivity(
+	ctx context.Context,
+	req *warnly.GetIssueActivityRequest,
+) ([]warnly.IssueActivity, error) {
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := s.issueStore.GetIssueByID(ctx, int64(req.IssueID))
+	if err != nil {
+		return nil, err
+	}
+	if issue.ProjectID != project.ID {
+		return nil, warnly.ErrNotFound
+	}
+
+	return s.activityStore.ListIssueActivity(ctx, issue.ID)
+}
+
 // ListFields returns a list of fields related to an issue.
 // e.g. how many times a field like "browser" or "os" was seen in events.
 func (s *ProjectService) ListFields(ctx context.Context, req *warnly.ListFieldsRequest) (*warnly.ListFieldsResult, error) {
@@ -428,8 +799,9 @@ func (s *ProjectService) ListFields(ctx context.Context, req *warnly.ListFieldsR
 
 // ListEvents handles "All Errors" page showing all error events per issue.
 func (s *ProjectService) ListEvents(ctx context.Context, req *warnly.ListEventsRequest) (*warnly.ListEventsResult, error) {
-	tokens := warnly.ParseQuery(req.Query)
+	tokens, user := takeUser(warnly.ParseQuery(req.Query))
 	raw, structured := convertTokensToCriteria(tokens)
+	userEmail, userID := warnly.UserFilter(user)
 
 	project, err := s.GetProject(ctx, req.ProjectID, req.User)
 	if err != nil {
@@ -469,6 +841,9 @@ func (s *ProjectService) ListEvents(ctx context.Context, req *warnly.ListEventsR
 		To:        to,
 		Message:   raw,
 		Tags:      structured,
+		UserEmail: userEmail,
+		UserID:    userID,
+		Levels:    req.Levels,
 		Limit:     defaultLimit,
 		Offset:    req.Offset,
 	}
@@ -499,6 +874,9 @@ func (s *ProjectService) ListEvents(ctx context.Context, req *warnly.ListEventsR
 		ProjectID:   project.ID,
 		IssueID:     req.IssueID,
 		Offset:      req.Offset,
+		Limit:       defaultLimit,
+		HasNext:     uint64(req.Offset+defaultLimit) < totalEvents,
+		HasPrev:     req.Offset > 0,
 		Request:     req,
 		PopularTags: popularTags,
 	}, nil
@@ -517,6 +895,9 @@ func parseDuration(period string, defaultDur time.Duration) (time.Duration, erro
 }
 
 func parseTimeRange(s *ProjectService, req *warnly.ListIssuesRequest) (time.Time, time.Time, error) {
+	if req.Period == "" {
+		req.Period = s.issuesPeriod
+	}
 	if req.Start != "" && req.End != "" {
 		from, to, err := warnly.ParseTimeRange(req.Start, req.End)
 		return from, to, err
@@ -579,13 +960,52 @@ func (s *ProjectService) ListIssues(ctx context.Context, req *warnly.ListIssuesR
 		return nil, err
 	}
 
-	var groupIDs []int64
-	if req.Query != "" {
-		tokens := warnly.ParseQuery(req.Query)
-		groupIDs, err = s.analyticsStore.GetFilteredGroupIDs(ctx, tokens, from, to, projectIDS)
+	if req.UseDefaultQuery && req.Query == "" {
+		if err := s.applyDefaultQuery(ctx, req, projects); err != nil {
+			return nil, err
+		}
+	}
+
+	tokens, assignee := takeAssignee(warnly.ParseQuery(req.Query))
+	if req.AssignedTo != "" {
+		assignee = req.AssignedTo
+	}
+	tokens, label := takeLabel(tokens)
+	if req.Label != "" {
+		label = req.Label
+	}
+
+	var (
+		groupIDs []int64
+		scores   map[int64]float64
+	)
+	if len(tokens) > 0 {
+		groupIDs, scores, err = s.filterGroupIDs(ctx, tokens, req.Sort, from, to, projectIDS)
+		if err != nil {
+			return nil, err
+		}
+		if len(groupIDs) == 0 {
+			return &warnly.ListIssuesResult{
+				RequestedProject: req.ProjectName,
+				Request:          req,
+				LastProject:      &lastProject,
+				Issues:           []warnly.IssueEntry{},
+				Projects:         projects,
+				PopularTags:      popularTags,
+				TotalIssues:      0,
+			}, nil
+		}
+	}
+
+	if req.UnhandledOnly {
+		unhandledIDs, err := s.analyticsStore.ListUnhandledGroupIDs(ctx, from, to, projectIDS)
 		if err != nil {
 			return nil, err
 		}
+		if len(tokens) > 0 {
+			unhandledIDs = intersectGroupIDs(groupIDs, unhandledIDs)
+		}
+		groupIDs = unhandledIDs
 		if len(groupIDs) == 0 {
 			return &warnly.ListIssuesResult{
 				RequestedProject: req.ProjectName,
@@ -600,15 +1020,30 @@ func (s *ProjectService) ListIssues(ctx context.Context, req *warnly.ListIssuesR
 	}
 
 	issues, err := s.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
-		ProjectIDs: projectIDS,
-		GroupIDs:   groupIDs,
-		From:       from,
-		To:         to,
+		ProjectIDs:     projectIDS,
+		GroupIDs:       groupIDs,
+		From:           from,
+		To:             to,
+		ExcludeIgnored: !req.IncludeIgnored,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if assignee != "" {
+		issues, err = s.filterByAssignee(ctx, issues, assignee, req.User, teamIDS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if label != "" {
+		issues, err = s.filterByLabel(ctx, issues, label, teamIDS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	totalIssues := len(issues)
 
 	if len(issues) == 0 {
@@ -623,27 +1058,34 @@ func (s *ProjectService) ListIssues(ctx context.Context, req *warnly.ListIssuesR
 		}, nil
 	}
 
-	issueList, err := s.buildIssueList(ctx, projectIDS, issues, from, to)
+	issueList, err := s.buildIssueList(ctx, projectIDS, issues, from, to, req.Sort)
 	if err != nil {
 		return nil, err
 	}
 
-	issueList, err = s.populateMessagesCount(ctx, issueList)
-	if err != nil {
-		return nil, err
+	if scores != nil {
+		slices.SortStableFunc(issueList, func(a, b warnly.IssueEntry) int {
+			return cmp.Compare(scores[b.ID], scores[a.ID])
+		})
 	}
 
+	// the page is cut from the sorted list, so the top issues by the active sort are returned.
 	totalAfterFilters := len(issueList)
-	if req.Limit > 0 {
-		start := req.Offset
-		end := req.Offset + req.Limit
-		if start > len(issueList) {
-			start = len(issueList)
-		}
-		if end > len(issueList) {
-			end = len(issueList)
-		}
-		issueList = issueList[start:end]
+	limit := req.Limit
+	if limit <= 0 || limit > s.maxIssues {
+		limit = s.maxIssues
+	}
+	start := min(max(req.Offset, 0), len(issueList))
+	end := min(start+limit, len(issueList))
+	issueList = issueList[start:end]
+
+	if err := s.attachSparklines(ctx, projectIDS, issueList, to); err != nil {
+		return nil, err
+	}
+
+	issueList, err = s.populateMessagesCount(ctx, issueList)
+	if err != nil {
+		return nil, err
 	}
 
 	return &warnly.ListIssuesResult{
@@ -654,429 +1096,2032 @@ func (s *ProjectService) ListIssues(ctx context.Context, req *warnly.ListIssuesR
 		RequestedProject: req.ProjectName,
 		PopularTags:      popularTags,
 		TotalIssues:      totalAfterFilters,
+		HasMore:          end < totalAfterFilters,
 	}, nil
 }
 
-// GetIssue returns detailed information about a specific issue.
-func (s *ProjectService) GetIssue(ctx context.Context, req *warnly.GetIssueRequest) (*warnly.IssueDetails, error) {
-	project, err := s.GetProject(ctx, req.ProjectID, req.User)
-	if err != nil {
-		return nil, err
+// filterGroupIDs returns group IDs matching the query tokens.
+// When the issues are sorted by relevance, the search text is matched with the full-text search
+// and the match scores of the issues are returned as well.
+func (s *ProjectService) filterGroupIDs(
+	ctx context.Context,
+	tokens []warnly.QueryToken,
+	sortBy string,
+	from, to time.Time,
+	projectIDs []int,
+) ([]int64, map[int64]float64, error) {
+	text, phrase := searchText(tokens)
+	if sortBy != warnly.IssueSortRelevance || text == "" {
+		groupIDs, err := s.analyticsStore.GetFilteredGroupIDs(ctx, tokens, from, to, projectIDs)
+		return groupIDs, nil, err
 	}
 
-	issue, err := s.issueStore.GetIssueByID(ctx, int64(req.IssueID))
+	matches, err := s.analyticsStore.SearchIssues(ctx, text, &warnly.SearchIssuesCriteria{
+		From:       from,
+		To:         to,
+		ProjectIDs: projectIDs,
+		Phrase:     phrase,
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	dur, err := warnly.ParseDuration(req.Period)
-	if err != nil {
-		return nil, err
+	groupIDs := make([]int64, len(matches))
+	scores := make(map[int64]float64, len(matches))
+	for i := range matches {
+		groupIDs[i] = matches[i].GID
+		scores[matches[i].GID] = matches[i].Score
 	}
 
-	to := s.now().UTC()
-	from := to.Add(-dur)
-	_ = from
+	filters := slices.DeleteFunc(tokens, func(t warnly.QueryToken) bool { return t.IsRawText })
+	if len(filters) == 0 {
+		return groupIDs, scores, nil
+	}
 
-	metrics, err := s.analyticsStore.ListIssueMetrics(
-		ctx,
-		&warnly.ListIssueMetricsCriteria{
-			ProjectIDs: []int{project.ID},
-			GroupIDs:   []int64{issue.ID},
-			From:       issue.FirstSeen.Add(time.Minute * -10),
-			To:         to,
-		})
+	filtered, err := s.analyticsStore.GetFilteredGroupIDs(ctx, filters, from, to, projectIDs)
 	if err != nil {
-		return nil, err
-	}
-	if len(metrics) == 0 {
-		return nil, fmt.Errorf("issue metrics not found: %d", issue.ID)
+		return nil, nil, err
 	}
-	metric := metrics[0]
 
-	events, err := s.analyticsStore.CalculateEventsPerDay(
-		ctx,
-		&warnly.EventDefCriteria{
-			GroupID:   req.IssueID,
-			ProjectID: issue.ProjectID,
-			From:      to.Add(-30 * 24 * time.Hour),
-			To:        to,
-		})
+	return intersectGroupIDs(filtered, groupIDs), scores, nil
+}
+
+// takeAssignee removes the assigned:<assignee> tokens from the query tokens
+// and returns the assignee of the last one, the issues are filtered by assignments rather than tags.
+func takeAssignee(tokens []warnly.QueryToken) ([]warnly.QueryToken, string) {
+	assignee := ""
+	tokens = slices.DeleteFunc(tokens, func(t warnly.QueryToken) bool {
+		if t.IsRawText || t.Key != warnly.AssignedFilterKey || t.Operator != "is" {
+			return false
+		}
+		assignee = t.Value
+		return true
+	})
+	return tokens, assignee
+}
+
+// takeUser removes the user:<email or ID> tokens from the query tokens
+// and returns the user of the last one, the events are filtered by their user rather than tags.
+func takeUser(tokens []warnly.QueryToken) ([]warnly.QueryToken, string) {
+	user := ""
+	tokens = slices.DeleteFunc(tokens, func(t warnly.QueryToken) bool {
+		if !t.IsUser || t.Operator != "is" {
+			return false
+		}
+		user = t.Value
+		return true
+	})
+	return tokens, user
+}
+
+// takeLabel removes the label:<label> tokens from the query tokens
+// and returns the label of the last one, the issues are filtered by labels rather than tags.
+func takeLabel(tokens []warnly.QueryToken) ([]warnly.QueryToken, string) {
+	label := ""
+	tokens = slices.DeleteFunc(tokens, func(t warnly.QueryToken) bool {
+		if t.IsRawText || t.Key != warnly.LabelFilterKey || t.Operator != "is" {
+			return false
+		}
+		label = t.Value
+		return true
+	})
+	return tokens, label
+}
+
+// filterByLabel keeps the issues the teams attached the label to,
+// no issue has a label that can't be attached.
+func (s *ProjectService) filterByLabel(
+	ctx context.Context,
+	issues []warnly.Issue,
+	label string,
+	teamIDs []int,
+) ([]warnly.Issue, error) {
+	if len(issues) == 0 {
+		return issues, nil
+	}
+
+	name, err := warnly.NormalizeLabel(label)
+	if err != nil {
+		return issues[:0], nil
+	}
+
+	labeled, err := s.labelStore.ListLabeledIssueIDs(ctx, teamIDs, name)
 	if err != nil {
 		return nil, err
 	}
 
-	total30Days, total24Hours := calculateTotalEvents(events)
+	return slices.DeleteFunc(issues, func(issue warnly.Issue) bool {
+		return !slices.Contains(labeled, issue.ID)
+	}), nil
+}
 
-	isNew := issue.FirstSeen.After(to.Add(-7 * 24 * time.Hour))
+// filterByAssignee keeps the issues assigned to the user the assignee refers to, which is
+// warnly.AssignedToMe, a user ID or a username of a teammate; warnly.AssignedToNone keeps unassigned issues.
+func (s *ProjectService) filterByAssignee(
+	ctx context.Context,
+	issues []warnly.Issue,
+	assignee string,
+	user *warnly.User,
+	teamIDs []int,
+) ([]warnly.Issue, error) {
+	if len(issues) == 0 {
+		return issues, nil
+	}
 
-	fieldCount, fieldValue, err := s.calculateFieldMetrics(ctx, req.IssueID, project.ID, issue.FirstSeen, to)
+	unassigned := assignee == warnly.AssignedToNone || assignee == warnly.AssignedToNoneAlias
+	var userID int64
+	if !unassigned {
+		var err error
+		if userID, err = s.assigneeUserID(ctx, assignee, user, teamIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	assignments, err := s.assingmentStore.ListAssingments(ctx, extractIssueIDs(issues))
 	if err != nil {
 		return nil, err
 	}
 
-	event, err := s.analyticsStore.GetIssueEvent(
-		ctx,
-		&warnly.EventDefCriteria{
-			GroupID:   req.IssueID,
-			ProjectID: project.ID,
-			From:      metric.LastSeen.Add(-1 * time.Minute),
-			To:        metric.LastSeen.Add(1 * time.Minute),
-			EventID:   req.EventID,
-		})
+	assigned := make(map[int64]*warnly.AssignedUser, len(assignments))
+	for _, a := range assignments {
+		assigned[a.IssueID] = a
+	}
+
+	return slices.DeleteFunc(issues, func(issue warnly.Issue) bool {
+		a, ok := assigned[issue.ID]
+		if unassigned {
+			return ok
+		}
+		return !ok || a.AssigneeType() != warnly.AssigneeUser || !a.AssignedToUserID.Valid ||
+			a.AssignedToUserID.Int64 != userID
+	}), nil
+}
+
+// assigneeUserID resolves the assignee of the issues filter to a user ID.
+// A username that isn't a teammate of the user resolves to zero and matches no issue.
+func (s *ProjectService) assigneeUserID(
+	ctx context.Context,
+	assignee string,
+	user *warnly.User,
+	teamIDs []int,
+) (int64, error) {
+	if assignee == warnly.AssignedToMe {
+		return user.ID, nil
+	}
+	if id, err := strconv.ParseInt(assignee, 10, 64); err == nil {
+		return id, nil
+	}
+
+	teammates, err := s.teamStore.ListTeammates(ctx, teamIDs)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	for i := range teammates {
+		if teammates[i].Username == assignee {
+			return teammates[i].ID, nil
+		}
 	}
 
-	nextID, prevID, firstID, lastID, err := s.getEventPaginationIDs(
-		ctx,
-		project.ID,
-		int(issue.ID),
-		event.EventID,
-		event.CreatedAt,
-		to,
+	return 0, nil
+}
+
+// searchText joins the raw text of the query tokens and reports whether it is a quoted phrase.
+func searchText(tokens []warnly.QueryToken) (string, bool) {
+	var (
+		parts  []string
+		phrase bool
 	)
+	for i := range tokens {
+		if !tokens[i].IsRawText {
+			continue
+		}
+		parts = append(parts, tokens[i].Value)
+		phrase = phrase || strings.Contains(tokens[i].Value, " ")
+	}
+	return strings.Join(parts, " "), phrase
+}
+
+// intersectGroupIDs returns group IDs present in both lists, in the order of b.
+func intersectGroupIDs(a, b []int64) []int64 {
+	res := make([]int64, 0, min(len(a), len(b)))
+	for i := range b {
+		if slices.Contains(a, b[i]) {
+			res = append(res, b[i])
+		}
+	}
+	return res
+}
+
+// GetRawEvent returns the event payload as it was sent by the SDK.
+func (s *ProjectService) GetRawEvent(ctx context.Context, req *warnly.GetRawEventRequest) ([]byte, error) {
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
 	if err != nil {
 		return nil, err
 	}
 
-	messagesCount, err := s.messageStore.CountMessages(ctx, int64(req.IssueID))
+	issue, err := s.issueStore.GetIssueByID(ctx, int64(req.IssueID))
 	if err != nil {
 		return nil, err
 	}
+	if issue.ProjectID != project.ID {
+		return nil, fmt.Errorf("issue %d of project %d: %w", issue.ID, project.ID, warnly.ErrNotFound)
+	}
 
-	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
-		User:      req.User,
+	return s.analyticsStore.GetRawEvent(ctx, &warnly.EventDefCriteria{
+		EventID:   req.EventID,
+		GroupID:   req.IssueID,
 		ProjectID: project.ID,
 	})
+}
+
+// ReleaseAdoption returns users and errors of the most recent releases of a project.
+func (s *ProjectService) ReleaseAdoption(
+	ctx context.Context,
+	req *warnly.ReleaseAdoptionRequest,
+) ([]warnly.ReleaseAdoption, error) {
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
 	if err != nil {
 		return nil, err
 	}
 
-	assignments, err := s.buildTeammateAssigns(ctx, teammates, []warnly.Issue{*issue})
+	period := req.Period
+	if period == "" {
+		period = warnly.DefaultAdoptionPeriod
+	}
+	from, to, err := s.getTimeRangeFromPeriod(period)
 	if err != nil {
 		return nil, err
 	}
 
-	return &warnly.IssueDetails{
-		IssueID:       issue.ID,
-		ProjectID:     project.ID,
-		ProjectName:   project.Name,
-		ErrorType:     issue.ErrorType,
-		View:          issue.View,
-		ErrorValue:    issue.Message,
-		Message:       event.Message,
-		Priority:      issue.Priority,
-		IsNew:         isNew,
-		FirstSeen:     metric.FirstSeen,
-		LastSeen:      metric.LastSeen,
-		TimesSeen:     metric.TimesSeen,
-		UserCount:     metric.UserCount,
-		Total30Days:   total30Days,
-		Total24Hours:  total24Hours,
-		TagCount:      fieldCount,
-		TagValueNum:   fieldValue,
-		LastEvent:     event,
-		StackDetails:  warnly.GetStackDetails(event),
-		Platform:      project.Platform,
-		MessagesCount: messagesCount,
-		Assignments:   assignments,
-		Teammates:     teammates,
-		Request:       req,
-		NextEventID:   nextID,
-		PrevEventID:   prevID,
-		FirstEventID:  firstID,
-		LastEventID:   lastID,
-	}, nil
+	return s.analyticsStore.ReleaseAdoption(ctx, &warnly.ReleaseAdoptionCriteria{
+		From:      from,
+		To:        to,
+		ProjectID: project.ID,
+		Limit:     warnly.MaxAdoptionReleases,
+	})
 }
 
-// ListTeammates returns a list of teammates associated with the user.
-func (s *ProjectService) ListTeammates(
+// CompareWindows returns the number of events of each issue of a project in the baseline and the current windows,
+// the issues that changed the most first, at most warnly.MaxComparedIssues of them.
+func (s *ProjectService) CompareWindows(
 	ctx context.Context,
-	req *warnly.ListTeammatesRequest,
-) ([]warnly.Teammate, error) {
-	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
-	if err != nil {
+	req *warnly.CompareWindowsRequest,
+) (*warnly.WindowComparison, error) {
+	if err := req.Baseline.Validate(); err != nil {
 		return nil, err
 	}
-
-	if len(teams) == 0 {
-		return nil, warnly.ErrNotFound
+	if err := req.Current.Validate(); err != nil {
+		return nil, err
 	}
 
-	teamIDS := extractTeamIDs(teams)
-
-	teammates, err := s.teamStore.ListTeammates(ctx, teamIDS)
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
 	if err != nil {
 		return nil, err
 	}
 
-	return teammates, nil
-}
-
-// DeleteMessage deletes a user message from the issue discussion.
-func (s *ProjectService) DeleteMessage(
-	ctx context.Context,
-	req *warnly.DeleteMessageRequest,
-) (*warnly.Discussion, error) {
-	project, err := s.GetProject(ctx, req.ProjectID, req.User)
+	counts, err := s.analyticsStore.CompareWindows(ctx, &warnly.CompareWindowsCriteria{
+		Baseline:   req.Baseline,
+		Current:    req.Current,
+		ProjectIDs: []int{project.ID},
+		Limit:      warnly.MaxComparedIssues,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
-		User:      req.User,
+	res := &warnly.WindowComparison{
+		Baseline:  req.Baseline,
+		Current:   req.Current,
+		Issues:    make([]warnly.IssueComparison, 0, len(counts)),
 		ProjectID: project.ID,
+	}
+	if len(counts) == 0 {
+		return res, nil
+	}
+
+	groupIDs := make([]int64, len(counts))
+	for i := range counts {
+		groupIDs[i] = counts[i].GroupID
+	}
+
+	// an issue with events in either window was last seen after the earliest window started.
+	from := req.Baseline.From
+	if req.Current.From.Before(from) {
+		from = req.Current.From
+	}
+	issues, err := s.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
+		ProjectIDs: []int{project.ID},
+		GroupIDs:   groupIDs,
+		From:       from,
+		To:         s.now().UTC(),
 	})
 	if err != nil {
 		return nil, err
 	}
+	issueByID := make(map[int64]*warnly.Issue, len(issues))
+	for i := range issues {
+		issueByID[issues[i].ID] = &issues[i]
+	}
 
-	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
-		if err := uw.Messages().DeleteMessage(ctx, req.MessageID, int(req.User.ID)); err != nil {
-			return err
+	for _, c := range counts {
+		issue, ok := issueByID[c.GroupID]
+		if !ok {
+			continue
 		}
-		return uw.Mentions().DeleteMentions(ctx, req.MessageID)
-	}, s.messageStore, s.mentionStore)
+		res.Issues = append(res.Issues, warnly.IssueComparison{
+			Message:   issue.Message,
+			ErrorType: issue.ErrorType,
+			IssueID:   issue.ID,
+			Baseline:  c.Baseline,
+			Current:   c.Current,
+			Delta:     int64(c.Current) - int64(c.Baseline),
+			New:       c.Baseline == 0,
+		})
+	}
+
+	return res, nil
+}
+
+// SuggestTagValues returns the most popular values of a project tag starting with a prefix,
+// at most warnly.MaxTagValueSuggestions of them.
+func (s *ProjectService) SuggestTagValues(
+	ctx context.Context,
+	req *warnly.SuggestTagValuesRequest,
+) ([]warnly.TagValueCount, error) {
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
 	if err != nil {
 		return nil, err
 	}
 
-	messages, err := s.messageStore.ListIssueMessages(ctx, int64(req.IssueID))
+	period := req.Period
+	if period == "" {
+		period = warnly.DefaultTagSuggestionsPeriod
+	}
+	from, to, err := s.getTimeRangeFromPeriod(period)
 	if err != nil {
 		return nil, err
 	}
 
-	return &warnly.Discussion{
-		Teammates: teammates,
-		Messages:  messages,
-		Info: warnly.DiscussionInfo{
-			ProjectID: project.ID,
-			IssueID:   req.IssueID,
-		},
-	}, nil
+	return s.analyticsStore.SuggestTagValues(ctx, &warnly.SuggestTagValuesCriteria{
+		From:      from,
+		To:        to,
+		Tag:       req.Tag,
+		Prefix:    strings.TrimSpace(req.Prefix),
+		ProjectID: project.ID,
+		Limit:     warnly.MaxTagValueSuggestions,
+	})
 }
 
-// CreateMessage creates a new message in the issue discussion.
-func (s *ProjectService) CreateMessage(
+// ListDiscardedEvents returns the events SDKs of a project dropped client-side by reason and category.
+func (s *ProjectService) ListDiscardedEvents(
 	ctx context.Context,
-	req *warnly.CreateMessageRequest,
-) (*warnly.Discussion, error) {
+	req *warnly.ListDiscardedEventsRequest,
+) ([]warnly.DiscardedEvents, error) {
 	project, err := s.GetProject(ctx, req.ProjectID, req.User)
 	if err != nil {
 		return nil, err
 	}
 
-	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
-		User:      req.User,
-		ProjectID: project.ID,
-	})
+	period := req.Period
+	if period == "" {
+		period = warnly.DefaultDiscardedEventsPeriod
+	}
+	from, _, err := s.getTimeRangeFromPeriod(period)
 	if err != nil {
 		return nil, err
 	}
 
-	slices.Sort(req.MentionedUsers)
-	mentioned := slices.Compact(req.MentionedUsers)
+	return s.projectStore.ListDiscardedEvents(ctx, project.ID, from)
+}
 
-	content := s.sanitizerPolicy.Sanitize(req.Content)
-	if content == "" {
-		s.logger.Error("message empty or sanitizer didn't allow input",
-			slog.String("content", req.Content),
-			slog.Int64("user_id", req.User.ID),
-			slog.String("user", req.User.Name))
-		messages, err := s.messageStore.ListIssueMessages(ctx, int64(req.IssueID))
-		if err != nil {
-			return nil, err
-		}
-		return &warnly.Discussion{
-			Teammates: teammates,
-			Messages:  messages,
-			Info: warnly.DiscussionInfo{
-				ProjectID: project.ID,
-				IssueID:   req.IssueID,
-			},
-		}, nil
+// GetIssue returns detailed information about a specific issue.
+func (s *ProjectService) GetIssue(ctx context.Context, req *warnly.GetIssueRequest) (*warnly.IssueDetails, error) {
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
+	if err != nil {
+		return nil, err
 	}
-	req.Content = content
 
-	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
-		now := s.now().UTC()
-		message := &warnly.Message{
-			IssueID:   int64(req.IssueID),
-			UserID:    int(req.User.ID),
-			Content:   req.Content,
-			CreatedAt: now,
-		}
-		if err := uw.Messages().CreateMessage(ctx, message); err != nil {
-			return err
-		}
+	issue, err := s.issueStore.GetIssueByID(ctx, int64(req.IssueID))
+	if err != nil {
+		return nil, err
+	}
 
-		if len(mentioned) == 0 {
-			return nil
-		}
+	dur, err := warnly.ParseDuration(req.Period)
+	if err != nil {
+		return nil, err
+	}
 
-		mentions := make([]warnly.Mention, 0, len(mentioned))
-		for i := range mentioned {
-			mentions = append(mentions, warnly.Mention{
-				MessageID:       message.ID,
-				MentionedUserID: mentioned[i],
-				CreatedAt:       now,
-			})
-		}
+	to := s.now().UTC()
+	from := to.Add(-dur)
 
-		return s.mentionStore.CreateMentions(ctx, mentions)
-	}, s.messageStore, s.mentionStore)
+	metrics, err := s.analyticsStore.ListIssueMetrics(
+		ctx,
+		&warnly.ListIssueMetricsCriteria{
+			ProjectIDs: []int{project.ID},
+			GroupIDs:   []int64{issue.ID},
+			From:       issue.FirstSeen.Add(time.Minute * -10),
+			To:         to,
+		})
 	if err != nil {
 		return nil, err
 	}
-
-	messages, err := s.messageStore.ListIssueMessages(ctx, int64(req.IssueID))
+	if len(metrics) == 0 {
+		return nil, fmt.Errorf("issue metrics not found: %d", issue.ID)
+	}
+	metric := metrics[0]
+
+	events, err := s.analyticsStore.CalculateEventsPerDay(
+		ctx,
+		&warnly.EventDefCriteria{
+			GroupID:   req.IssueID,
+			ProjectID: issue.ProjectID,
+			From:      to.Add(-30 * 24 * time.Hour),
+			To:        to,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	total30Days, total24Hours := calculateTotalEvents(events)
+
+	// daily counts are too coarse to tell the current rate of the issue.
+	total1Hour, err := s.analyticsStore.CountEvents(
+		ctx,
+		&warnly.EventCriteria{
+			GroupID:   req.IssueID,
+			ProjectID: issue.ProjectID,
+			From:      to.Add(-time.Hour),
+			To:        to,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	eventGaps, err := s.analyticsStore.CalculateEventGaps(
+		ctx,
+		&warnly.EventDefCriteria{
+			GroupID:   req.IssueID,
+			ProjectID: issue.ProjectID,
+			From:      to.Add(-30 * 24 * time.Hour),
+			To:        to,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	fieldCount, fieldValue, err := s.calculateFieldMetrics(ctx, req.IssueID, project.ID, issue.FirstSeen, to)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.analyticsStore.GetIssueEvent(
+		ctx,
+		&warnly.EventDefCriteria{
+			GroupID:   req.IssueID,
+			ProjectID: project.ID,
+			From:      metric.LastSeen.Add(-1 * time.Minute),
+			To:        metric.LastSeen.Add(1 * time.Minute),
+			EventID:   req.EventID,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	nextID, prevID, firstID, lastID, err := s.getEventPaginationIDs(
+		ctx,
+		project.ID,
+		int(issue.ID),
+		event.EventID,
+		event.CreatedAt,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	messagesCount, err := s.messageStore.CountMessages(ctx, int64(req.IssueID))
+	if err != nil {
+		return nil, err
+	}
+
+	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
+		User:      req.User,
+		ProjectID: project.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.buildTeammateAssigns(ctx, teammates, teams, []warnly.Issue{*issue})
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers, err := s.subscriptionStore.ListSubscribers(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := s.labelStore.ListLabels(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seenBy, err := s.markSeen(ctx, req, issue.ID, teammates)
+	if err != nil {
+		return nil, err
+	}
+
+	traceEvents, err := s.listTraceEvents(ctx, event, teams)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := warnly.GetStackDetails(event)
+
+	return &warnly.IssueDetails{
+		IssueID:           issue.ID,
+		ProjectID:         project.ID,
+		ProjectName:       project.Name,
+		ErrorType:         issue.ErrorType,
+		View:              issue.View,
+		ErrorValue:        issue.Message,
+		Message:           event.Message,
+		Priority:          issue.Priority,
+		IsNew:             s.isNewIssue(issue.FirstSeen),
+		NewInPeriod:       !issue.FirstSeen.Before(from),
+		FirstSeen:         metric.FirstSeen,
+		LastSeen:          metric.LastSeen,
+		TimesSeen:         metric.TimesSeen,
+		UserCount:         metric.UserCount,
+		Total30Days:       total30Days,
+		Total24Hours:      total24Hours,
+		Total1Hour:        total1Hour,
+		TagCount:          fieldCount,
+		TagValueNum:       fieldValue,
+		LastEvent:         event,
+		StackDetails:      stack,
+		Platform:          project.Platform,
+		VisibleFrames:     s.visibleFramesOf(project.Platform),
+		SourceURLTemplate: project.SourceURLTemplate,
+		Subscribed:        slices.Contains(subscribers, req.User.ID),
+		Ignore:            issue.Ignore,
+		Labels:            labels,
+		SeenBy:            seenBy,
+		TraceEvents:       traceEvents,
+		MessagesCount:     messagesCount,
+		Assignments:       assignments,
+		Teammates:         teammates,
+		Teams:             teams,
+		Request:           req,
+		NextEventID:       nextID,
+		PrevEventID:       prevID,
+		FirstEventID:      firstID,
+		LastEventID:       lastID,
+
+		SuggestedAssignee: suggestAssignee(project.CodeOwners, stack, teammates, assignments, issue.ID),
+		EventGaps:         eventGaps,
+	}, nil
+}
+
+// listTraceEvents returns the other events of the trace the event happened in
+// across the projects of the teams, nil when the event wasn't sent with a trace.
+func (s *ProjectService) listTraceEvents(
+	ctx context.Context,
+	event *warnly.IssueEvent,
+	teams []warnly.Team,
+) ([]warnly.TraceEvent, error) {
+	if event.TraceID == "" || len(teams) == 0 {
+		return nil, nil
+	}
+
+	projects, err := s.projectStore.ListProjects(ctx, extractTeamIDs(teams), "")
+	if err != nil {
+		return nil, err
+	}
+
+	projectIDs := make([]int, 0, len(projects))
+	for i := range projects {
+		projectIDs = append(projectIDs, projects[i].ID)
+	}
+
+	events, err := s.analyticsStore.ListEventsByTrace(ctx, event.TraceID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(events, func(ev warnly.TraceEvent) bool { return ev.EventID == event.EventID }), nil
+}
+
+// markSeen records that the user viewed the issue and returns the teammates who have seen it,
+// the most recent viewer first.
+func (s *ProjectService) markSeen(
+	ctx context.Context,
+	req *warnly.GetIssueRequest,
+	issueID int64,
+	teammates []warnly.Teammate,
+) ([]warnly.Teammate, error) {
+	err := s.seenStore.MarkSeen(ctx, &warnly.IssueView{
+		IssueID: issueID,
+		UserID:  req.User.ID,
+		SeenAt:  s.now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := s.seenStore.ListSeenBy(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	seenBy := make([]warnly.Teammate, 0, len(views))
+	for _, view := range views {
+		idx := slices.IndexFunc(teammates, func(t warnly.Teammate) bool { return t.ID == view.UserID })
+		if idx >= 0 {
+			seenBy = append(seenBy, teammates[idx])
+		}
+	}
+
+	return seenBy, nil
+}
+
+// ListTeammates returns a list of teammates associated with the user.
+func (s *ProjectService) ListTeammates(
+	ctx context.Context,
+	req *warnly.ListTeammatesRequest,
+) ([]warnly.Teammate, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(teams) == 0 {
+		return nil, warnly.ErrNotFound
+	}
+
+	teamIDS := extractTeamIDs(teams)
+
+	teammates, err := s.teamStore.ListTeammates(ctx, teamIDS)
+	if err != nil {
+		return nil, err
+	}
+
+	return teammates, nil
+}
+
+// DeleteMessage deletes a user message from the issue discussion.
+func (s *ProjectService) DeleteMessage(
+	ctx context.Context,
+	req *warnly.DeleteMessageRequest,
+) (*warnly.Discussion, error) {
+	project, err := s.GetProject(ctx, req.ProjectID, req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
+		User:      req.User,
+		ProjectID: project.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		if err := uw.Messages().DeleteMessage(ctx, req.MessageID, int(req.User.ID)); err != nil {
+			return err
+		}
+		return uw.Mentions().DeleteMentions(ctx, req.MessageID)
+	}, s.messageStore, s.mentionStore)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.messageStore.ListIssueMessages(ctx, int64(req.IssueID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &warnly.Discussion{
+		Teammates: teammates,
+		Messages:  messages,
+		Info: warnly.DiscussionInfo{
+			ProjectID: project.ID,
+			IssueID:   req.IssueID,
+		},
+	}, nil
+}
+
+// CreateMessage creates a new message in the issue discussion.
+func (s *ProjectService) CreateMessage(
+	ctx context.Context,
+	req *warnly.CreateMessageRequest,
+) (*warnly.Discussion, error) {
+	if s.ReadOnly() {
+		return nil, warnly.ErrReadOnly
+	}
+
+	_, project, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return nil, err
+	}
+
+	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
+		User:      req.User,
+		ProjectID: project.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content := s.sanitizerPolicy.Sanitize(req.Content)
+	if content == "" {
+		s.logger.Error("message empty or sanitizer didn't allow input",
+			slog.String("content", req.Content),
+			slog.Int64("user_id", req.User.ID),
+			slog.String("user", req.User.Name))
+		messages, err := s.messageStore.ListIssueMessages(ctx, int64(req.IssueID))
+		if err != nil {
+			return nil, err
+		}
+		return &warnly.Discussion{
+			Teammates: teammates,
+			Messages:  messages,
+			Info: warnly.DiscussionInfo{
+				ProjectID: project.ID,
+				IssueID:   req.IssueID,
+			},
+		}, nil
+	}
+	req.Content = content
+
+	// Mentions are parsed from the stored content, so they always match what is displayed.
+	projectTeammates, err := s.teamStore.ListTeammates(ctx, []int{project.TeamID})
+	if err != nil {
+		return nil, err
+	}
+	mentioned := warnly.ParseMentions(content, projectTeammates)
+
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		now := s.now().UTC()
+		message := &warnly.Message{
+			IssueID:   int64(req.IssueID),
+			UserID:    int(req.User.ID),
+			Content:   req.Content,
+			CreatedAt: now,
+		}
+		if err := uw.Messages().CreateMessage(ctx, message); err != nil {
+			return err
+		}
+
+		if err := uw.Activities().CreateActivity(ctx, &warnly.Activity{
+			CreatedAt: now,
+			Type:      warnly.ActivityMessageAdded,
+			IssueID:   message.IssueID,
+			ActorID:   req.User.ID,
+		}); err != nil {
+			return err
+		}
+
+		if err := uw.Subscriptions().CreateSubscription(ctx, &warnly.Subscription{
+			CreatedAt: now,
+			IssueID:   message.IssueID,
+			UserID:    req.User.ID,
+		}); err != nil {
+			return err
+		}
+
+		if len(mentioned) == 0 {
+			return nil
+		}
+
+		mentions := make([]warnly.Mention, 0, len(mentioned))
+		for i := range mentioned {
+			mentions = append(mentions, warnly.Mention{
+				MessageID:       message.ID,
+				MentionedUserID: int(mentioned[i]),
+				CreatedAt:       now,
+			})
+		}
+
+		return s.mentionStore.CreateMentions(ctx, mentions)
+	}, s.messageStore, s.mentionStore, s.activityStore, s.subscriptionStore)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyCommented(ctx, project, req)
+
+	messages, err := s.messageStore.ListIssueMessages(ctx, int64(req.IssueID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &warnly.Discussion{
+		Teammates: teammates,
+		Messages:  messages,
+		Info: warnly.DiscussionInfo{
+			ProjectID: project.ID,
+			IssueID:   req.IssueID,
+		},
+	}, nil
+}
+
+// DeleteAssignment unassigns an issue from a user.
+func (s *ProjectService) DeleteAssignment(ctx context.Context, req *warnly.UnassignIssueRequest) error {
+	issue, _, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	return s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		if err := uw.Assignments().DeleteAssignment(ctx, issue.ID); err != nil {
+			return err
+		}
+		return uw.Activities().CreateActivity(ctx, &warnly.Activity{
+			CreatedAt: s.now().UTC(),
+			Type:      warnly.ActivityUnassigned,
+			IssueID:   issue.ID,
+			ActorID:   req.User.ID,
+		})
+	}, s.assingmentStore, s.activityStore)
+}
+
+// AssignIssue assigns an issue to a user or to one of the user's teams.
+func (s *ProjectService) AssignIssue(ctx context.Context, req *warnly.AssignIssueRequest) error {
+	if s.ReadOnly() {
+		return warnly.ErrReadOnly
+	}
+
+	assigneeType, err := req.AssigneeType()
+	if err != nil {
+		return err
+	}
+
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return err
+	}
+	if len(teams) == 0 {
+		return warnly.ErrNotFound
+	}
+
+	project, err := s.projectStore.GetProject(ctx, req.ProjectID)
+	if err != nil {
+		return err
+	}
+	if err := requireRole(teams, project.TeamID, warnly.RoleMember); err != nil {
+		return err
+	}
+	if _, err := s.projectIssue(ctx, project, req.IssueID); err != nil {
+		return err
+	}
+
+	teammates, err := s.teamStore.ListTeammates(ctx, extractTeamIDs(teams))
+	if err != nil {
+		return err
+	}
+	if err := s.validateTeammate(teammates, teams, req.UserID, req.TeamID); err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	assign := &warnly.Assignment{
+		AssignedAt:       now,
+		AssigneeType:     assigneeType,
+		IssueID:          int64(req.IssueID),
+		AssignedToUserID: int64(req.UserID),
+		AssignedToTeamID: int64(req.TeamID),
+		AssignedByUserID: req.User.ID,
+	}
+
+	return s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		if err := uw.Assignments().CreateAssingment(ctx, assign); err != nil {
+			return err
+		}
+		if err := uw.Activities().CreateActivity(ctx, &warnly.Activity{
+			CreatedAt: now,
+			Type:      warnly.ActivityAssigned,
+			Detail:    assigneeName(teammates, teams, req),
+			IssueID:   assign.IssueID,
+			ActorID:   req.User.ID,
+		}); err != nil {
+			return err
+		}
+
+		if assigneeType != warnly.AssigneeUser {
+			return nil
+		}
+
+		return uw.Subscriptions().CreateSubscription(ctx, &warnly.Subscription{
+			CreatedAt: now,
+			IssueID:   assign.IssueID,
+			UserID:    assign.AssignedToUserID,
+		})
+	}, s.assingmentStore, s.activityStore, s.subscriptionStore)
+}
+
+// assigneeName returns the name of the user or the team the issue is assigned to.
+func assigneeName(teammates []warnly.Teammate, teams []warnly.Team, req *warnly.AssignIssueRequest) string {
+	if req.TeamID != 0 {
+		for i := range teams {
+			if teams[i].ID == req.TeamID {
+				return "team " + teams[i].Name
+			}
+		}
+		return ""
+	}
+	for i := range teammates {
+		if teammates[i].ID == int64(req.UserID) {
+			return teammates[i].Name
+		}
+	}
+	return ""
+}
+
+// ResolveIssue marks an issue as resolved. The status change and the resolution note
+// are written in one transaction, notifications are dispatched after the commit
+// so that an unavailable receiver doesn't roll the resolution back.
+func (s *ProjectService) ResolveIssue(ctx context.Context, req *warnly.ResolveIssueRequest) error {
+	issue, project, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	note := ""
+	if req.Note != "" {
+		note = s.sanitizerPolicy.Sanitize(req.Note)
+	}
+
+	now := s.now().UTC()
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		if err := uw.Issues().UpdateStatus(ctx, &warnly.UpdateIssueStatus{
+			IssueID:           issue.ID,
+			Status:            warnly.IssueStatusResolved,
+			ResolvedAt:        &now,
+			ResolvedInRelease: strings.TrimSpace(req.Release),
+		}); err != nil {
+			return err
+		}
+
+		if err := uw.Activities().CreateActivity(ctx, &warnly.Activity{
+			CreatedAt: now,
+			Type:      warnly.ActivityStatusChanged,
+			Detail:    string(warnly.IssueStatusResolved),
+			IssueID:   issue.ID,
+			ActorID:   req.User.ID,
+		}); err != nil {
+			return err
+		}
+
+		if note == "" {
+			return nil
+		}
+
+		return uw.Messages().CreateMessage(ctx, &warnly.Message{
+			IssueID:   issue.ID,
+			UserID:    int(req.User.ID),
+			Content:   note,
+			CreatedAt: now,
+		})
+	}, s.issueStore, s.messageStore, s.activityStore)
+	if err != nil {
+		return err
+	}
+	// Events of the issue in later releases reopen it, which the cached info of the open issue hides.
+	s.forgetIssue(issue)
+
+	recipients, err := s.listIssueFollowers(ctx, req.User, issue.ID, req.Notify)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	if err := s.issueNotifier.NotifyIssueResolved(ctx, &warnly.IssueResolvedNotification{
+		ResolvedAt:  now,
+		ResolvedBy:  req.User,
+		ProjectName: project.Name,
+		Message:     issue.Message,
+		Note:        note,
+		Recipients:  recipients,
+		IssueID:     issue.ID,
+		ProjectID:   project.ID,
+		TeamID:      project.TeamID,
+	}); err != nil {
+		s.logger.Error("resolve issue: notify followers",
+			slog.Int64("issue_id", issue.ID),
+			slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// IgnoreIssue hides an issue until its ignore condition is met, the issue is reopened
+// by the ingestion of its events and the project team is notified then.
+// The subscribers of the issue are notified after the commit.
+func (s *ProjectService) IgnoreIssue(ctx context.Context, req *warnly.IgnoreIssueRequest) error {
+	now := s.now().UTC()
+	rule, err := warnly.NewIgnoreRule(req.Condition, req.Threshold, req.Duration, now)
+	if err != nil {
+		return err
+	}
+
+	issue, project, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		if err := uw.Issues().UpdateStatus(ctx, &warnly.UpdateIssueStatus{
+			IssueID: issue.ID,
+			Status:  warnly.IssueStatusIgnored,
+			Ignore:  rule,
+		}); err != nil {
+			return err
+		}
+
+		return uw.Activities().CreateActivity(ctx, &warnly.Activity{
+			CreatedAt: now,
+			Type:      warnly.ActivityStatusChanged,
+			Detail:    string(warnly.IssueStatusIgnored),
+			IssueID:   issue.ID,
+			ActorID:   req.User.ID,
+		})
+	}, s.issueStore, s.activityStore)
+	if err != nil {
+		return err
+	}
+	// Events of the issue are counted against its ignore condition, which the cached info of the open issue hides.
+	s.forgetIssue(issue)
+
+	recipients, err := s.listIssueFollowers(ctx, req.User, issue.ID, false)
+	if err != nil {
+		s.logger.Error("ignore issue: list subscribers", slog.Int64("issue_id", issue.ID), slog.Any("error", err))
+		return nil
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	if err := s.issueNotifier.NotifyIssueIgnored(ctx, &warnly.IssueIgnoredNotification{
+		IgnoredBy:   req.User,
+		Rule:        rule,
+		ProjectName: project.Name,
+		Message:     issue.Message,
+		Recipients:  recipients,
+		IssueID:     issue.ID,
+		ProjectID:   project.ID,
+		TeamID:      project.TeamID,
+	}); err != nil {
+		s.logger.Error("ignore issue: notify subscribers", slog.Int64("issue_id", issue.ID), slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// notifyCommented notifies the subscribers of the issue about the new message.
+// The message is already stored, so failures are only logged.
+func (s *ProjectService) notifyCommented(ctx context.Context, project *warnly.Project, req *warnly.CreateMessageRequest) {
+	issueID := int64(req.IssueID)
+
+	recipients, err := s.listIssueFollowers(ctx, req.User, issueID, false)
+	if err != nil {
+		s.logger.Error("create message: list subscribers", slog.Int64("issue_id", issueID), slog.Any("error", err))
+		return
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	issue, err := s.issueStore.GetIssueByID(ctx, issueID)
+	if err != nil {
+		s.logger.Error("create message: get issue", slog.Int64("issue_id", issueID), slog.Any("error", err))
+		return
+	}
+
+	if err := s.issueNotifier.NotifyIssueCommented(ctx, &warnly.IssueCommentedNotification{
+		CreatedAt:   s.now().UTC(),
+		Author:      req.User,
+		ProjectName: project.Name,
+		Message:     issue.Message,
+		Content:     req.Content,
+		Recipients:  recipients,
+		IssueID:     issueID,
+		ProjectID:   project.ID,
+		TeamID:      project.TeamID,
+	}); err != nil {
+		s.logger.Error("create message: notify subscribers", slog.Int64("issue_id", issueID), slog.Any("error", err))
+	}
+}
+
+// listIssueFollowers returns the teammates that should hear about changes of the issue:
+// its subscribers and, with withParticipants, the user the issue is assigned to
+// and the users who wrote in its discussion. The user who made the change is left out.
+func (s *ProjectService) listIssueFollowers(
+	ctx context.Context,
+	actor *warnly.User,
+	issueID int64,
+	withParticipants bool,
+) ([]warnly.Teammate, error) {
+	followerIDs, err := s.subscriptionStore.ListSubscribers(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if withParticipants {
+		commenterIDs, err := s.messageStore.ListCommenters(ctx, issueID)
+		if err != nil {
+			return nil, err
+		}
+		followerIDs = append(followerIDs, commenterIDs...)
+
+		assigned, err := s.assingmentStore.ListAssingments(ctx, []int64{issueID})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range assigned {
+			if a.AssignedToUserID.Valid {
+				followerIDs = append(followerIDs, a.AssignedToUserID.Int64)
+			}
+		}
+	}
+
+	followerIDs = slices.DeleteFunc(followerIDs, func(id int64) bool { return id == actor.ID })
+	if len(followerIDs) == 0 {
+		return nil, nil
+	}
+
+	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{User: actor})
+	if err != nil {
+		return nil, err
+	}
+
+	followers := make([]warnly.Teammate, 0, len(followerIDs))
+	for i := range teammates {
+		id := teammates[i].ID
+		if !slices.Contains(followerIDs, id) || slices.ContainsFunc(followers, func(f warnly.Teammate) bool { return f.ID == id }) {
+			continue
+		}
+		followers = append(followers, teammates[i])
+	}
+
+	return followers, nil
+}
+
+// Subscribe subscribes the user to new messages and status changes of an issue.
+func (s *ProjectService) Subscribe(ctx context.Context, req *warnly.IssueSubscriptionRequest) error {
+	issue, _, err := s.getProjectIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionStore.CreateSubscription(ctx, &warnly.Subscription{
+		CreatedAt: s.now().UTC(),
+		IssueID:   issue.ID,
+		UserID:    req.User.ID,
+	})
+}
+
+// Unsubscribe unsubscribes the user from an issue.
+func (s *ProjectService) Unsubscribe(ctx context.Context, req *warnly.IssueSubscriptionRequest) error {
+	issue, _, err := s.getProjectIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionStore.DeleteSubscription(ctx, issue.ID, req.User.ID)
+}
+
+// getProjectIssue returns the issue and its project when the issue belongs to a project of the user.
+func (s *ProjectService) getProjectIssue(
+	ctx context.Context,
+	user *warnly.User,
+	projectID, issueID int,
+) (*warnly.Issue, *warnly.Project, error) {
+	project, err := s.GetProject(ctx, projectID, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue, err := s.projectIssue(ctx, project, issueID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return issue, project, nil
+}
+
+// getMemberIssue returns the issue and its project when the issue belongs to a project
+// the user is at least a member of the team of, viewers can't change issues.
+func (s *ProjectService) getMemberIssue(
+	ctx context.Context,
+	user *warnly.User,
+	projectID, issueID int,
+) (*warnly.Issue, *warnly.Project, error) {
+	project, err := s.getProjectWithRole(ctx, projectID, user, warnly.RoleMember)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue, err := s.projectIssue(ctx, project, issueID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return issue, project, nil
+}
+
+// projectIssue returns the issue if it belongs to the project, warnly.ErrNotFound otherwise.
+func (s *ProjectService) projectIssue(ctx context.Context, project *warnly.Project, issueID int) (*warnly.Issue, error) {
+	issue, err := s.issueStore.GetIssueByID(ctx, int64(issueID))
+	if err != nil {
+		return nil, err
+	}
+	if issue.ProjectID != project.ID {
+		return nil, warnly.ErrNotFound
+	}
+
+	return issue, nil
+}
+
+// AddLabel attaches a label to an issue, the label is shared by the team of the issue's project.
+func (s *ProjectService) AddLabel(ctx context.Context, req *warnly.IssueLabelRequest) error {
+	name, err := warnly.NormalizeLabel(req.Label)
+	if err != nil {
+		return err
+	}
+
+	issue, _, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	return s.labelStore.CreateLabel(ctx, &warnly.IssueLabel{
+		CreatedAt: s.now().UTC(),
+		Name:      name,
+		IssueID:   issue.ID,
+		UserID:    req.User.ID,
+	})
+}
+
+// RemoveLabel removes a label from an issue.
+func (s *ProjectService) RemoveLabel(ctx context.Context, req *warnly.IssueLabelRequest) error {
+	name, err := warnly.NormalizeLabel(req.Label)
+	if err != nil {
+		return err
+	}
+
+	issue, _, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	return s.labelStore.DeleteLabel(ctx, issue.ID, name)
+}
+
+// ListLabels returns the labels of an issue ordered by name.
+func (s *ProjectService) ListLabels(ctx context.Context, req *warnly.IssueLabelRequest) ([]warnly.IssueLabel, error) {
+	issue, _, err := s.getProjectIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.labelStore.ListLabels(ctx, issue.ID)
+}
+
+// AddNoiseFilter drops the events of an issue that have the tags of the filter at ingest.
+// Adding a filter the issue already has keeps the existing one. The filter applies to events
+// ingested once the cached issue expires, stored events keep counting to the issue metrics.
+func (s *ProjectService) AddNoiseFilter(ctx context.Context, req *warnly.NoiseFilterRequest) error {
+	filter, err := warnly.ParseNoiseFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	issue, _, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	if issue.NoiseFilters.Index(filter) >= 0 {
+		return nil
+	}
+	if len(issue.NoiseFilters) >= warnly.MaxNoiseFilters {
+		return warnly.ErrTooManyNoiseFilters
+	}
+
+	if err := s.issueStore.UpdateNoiseFilters(ctx, issue.ID, append(issue.NoiseFilters, filter)); err != nil {
+		return err
+	}
+	// the cached issue is dropped along with its filters, so that the next events are filtered.
+	s.forgetIssue(issue)
+
+	return nil
+}
+
+// RemoveNoiseFilter stops dropping the events of an issue by the filter.
+func (s *ProjectService) RemoveNoiseFilter(ctx context.Context, req *warnly.NoiseFilterRequest) error {
+	filter, err := warnly.ParseNoiseFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	issue, _, err := s.getMemberIssue(ctx, req.User, req.ProjectID, req.IssueID)
+	if err != nil {
+		return err
+	}
+
+	i := issue.NoiseFilters.Index(filter)
+	if i < 0 {
+		return nil
+	}
+
+	if err := s.issueStore.UpdateNoiseFilters(ctx, issue.ID, slices.Delete(issue.NoiseFilters, i, i+1)); err != nil {
+		return err
+	}
+	s.forgetIssue(issue)
+
+	return nil
+}
+
+// ListPopularTags lists popular tag keys for search suggestions.
+func (s *ProjectService) ListPopularTags(ctx context.Context, req *warnly.ListPopularTagsRequest) ([]warnly.TagCount, error) {
+	projectIDs, err := s.getProjectIDs(ctx, req.User, req.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := s.getTimeRangeFromPeriod(req.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := &warnly.ListPopularTagsCriteria{
+		ProjectIDs: projectIDs,
+		From:       from,
+		To:         to,
+		Limit:      req.Limit,
+	}
+
+	return s.analyticsStore.ListPopularTags(ctx, criteria)
+}
+
+// ListTagValues lists popular values for a given tag.
+func (s *ProjectService) ListTagValues(ctx context.Context, req *warnly.ListTagValuesRequest) ([]warnly.TagValueCount, error) {
+	projectIDs, err := s.getProjectIDs(ctx, req.User, req.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to time.Time
+	if req.Period == "" && req.Start == "" && req.End == "" {
+		req.Period = defaultPeriod
+	}
+	if req.Period != "" {
+		dur, err := warnly.ParseDuration(req.Period)
+		if err != nil {
+			return nil, err
+		}
+		now := s.now().UTC()
+		from = now.Add(-dur)
+		to = now
+	} else {
+		from, to, err = warnly.ParseTimeRange(req.Start, req.End)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	criteria := &warnly.ListTagValuesCriteria{
+		Tag:        req.Tag,
+		ProjectIDs: projectIDs,
+		From:       from,
+		To:         to,
+		Limit:      req.Limit,
+	}
+
+	return s.analyticsStore.ListTagValues(ctx, criteria)
+}
+
+// SearchProject searches for a project by name within the user's teams.
+func (s *ProjectService) SearchProject(ctx context.Context, name string, user *warnly.User) (*warnly.Project, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(teams) == 0 {
+		return nil, warnly.ErrNotFound
+	}
+
+	teamIDs := make([]int, 0, len(teams))
+	for i := range teams {
+		teamIDs = append(teamIDs, teams[i].ID)
+	}
+
+	projects, err := s.projectStore.ListProjects(ctx, teamIDs, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, warnly.ErrProjectNotFound
+	}
+
+	for i := range projects {
+		if projects[i].Name == name {
+			return &projects[i], nil
+		}
+	}
+
+	return nil, warnly.ErrProjectNotFound
+}
+
+// SetSampleRate changes the share of incoming events stored for a project.
+func (s *ProjectService) SetSampleRate(ctx context.Context, req *warnly.SetSampleRateRequest) error {
+	if err := warnly.ValidateSampleRate(req.SampleRate); err != nil {
+		return err
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	if err := s.projectStore.UpdateSampleRate(ctx, req.ProjectID, req.SampleRate); err != nil {
+		return err
+	}
+
+	return s.forgetProjectKeys(ctx, req.ProjectID)
+}
+
+// SetDedupWindow changes the window duplicate events of a project are not stored within.
+func (s *ProjectService) SetDedupWindow(ctx context.Context, req *warnly.SetDedupWindowRequest) error {
+	if err := warnly.ValidateDedupWindow(req.Window); err != nil {
+		return err
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	return s.projectStore.UpdateDedupWindow(ctx, req.ProjectID, req.Window)
+}
+
+// GetProjectOptions returns the options of a project, e.g. its platform and event retention.
+func (s *ProjectService) GetProjectOptions(
+	ctx context.Context,
+	projectID int,
+	user *warnly.User,
+) (*warnly.ProjectOptions, error) {
+	project, err := s.GetProject(ctx, projectID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.projectStore.GetOptions(ctx, project.ID, project.Key)
+}
+
+// UpdateProjectOptions changes the platform and the event retention of a project.
+// The retention applies to events ingested once the cached options of the project expire,
+// stored events are kept for the retention they were ingested with.
+func (s *ProjectService) UpdateProjectOptions(ctx context.Context, req *warnly.UpdateProjectOptionsRequest) error {
+	if err := warnly.ValidateRetentionDays(req.RetentionDays); err != nil {
+		return err
+	}
+	if !slices.Contains(warnly.Platforms, req.Platform) {
+		return warnly.ErrInvalidPlatform
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	return s.projectStore.UpdateOptions(ctx, req.ProjectID, req.Platform, uint8(req.RetentionDays))
+}
+
+// SetSourceURLTemplate changes the template of links from a project's stack frames to the repository.
+func (s *ProjectService) SetSourceURLTemplate(ctx context.Context, req *warnly.SetSourceURLTemplateRequest) error {
+	template := strings.TrimSpace(req.Template)
+	if err := warnly.ValidateSourceURLTemplate(template); err != nil {
+		return err
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	return s.projectStore.UpdateSourceURLTemplate(ctx, req.ProjectID, template)
+}
+
+// SetGrouping changes how message-only events of a project are grouped into issues.
+// Issues created before the change keep their grouping.
+func (s *ProjectService) SetGrouping(ctx context.Context, req *warnly.SetGroupingRequest) error {
+	if err := warnly.ValidateGrouping(req.Grouping); err != nil {
+		return err
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	return s.projectStore.UpdateGrouping(ctx, req.ProjectID, req.Grouping)
+}
+
+// SetPriorityRules replaces the rules that set the priority of a project's issues by event tags.
+// Rules apply to events ingested after the change, existing issues keep their priority until then.
+func (s *ProjectService) SetPriorityRules(ctx context.Context, req *warnly.SetPriorityRulesRequest) error {
+	if err := warnly.ValidatePriorityRules(req.Rules); err != nil {
+		return err
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	return s.projectStore.UpdatePriorityRules(ctx, req.ProjectID, req.Rules)
+}
+
+// SetGroupingRules replaces the rules that exclude framework frames from the issue view,
+// group exceptions of the listed types regardless of their messages and normalize messages before grouping.
+// Rules apply to events ingested after the change, stored events keep their frames and issues.
+func (s *ProjectService) SetGroupingRules(ctx context.Context, req *warnly.SetGroupingRulesRequest) error {
+	if req.Rules != nil {
+		if err := req.Rules.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	return s.projectStore.UpdateGroupingRules(ctx, req.ProjectID, req.Rules)
+}
+
+// SetIngestFilters replaces the environment and release patterns events of a project are dropped by at ingest.
+// Events stored before the change are kept.
+func (s *ProjectService) SetIngestFilters(ctx context.Context, req *warnly.SetIngestFiltersRequest) error {
+	if req.Filters != nil {
+		if err := req.Filters.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	if err := s.projectStore.UpdateIngestFilters(ctx, req.ProjectID, req.Filters); err != nil {
+		return err
+	}
+
+	return s.forgetProjectKeys(ctx, req.ProjectID)
+}
+
+// SetIngestSigning generates a new signing secret for the project or removes it.
+// Events of a project with a secret are only ingested with a valid signature.
+func (s *ProjectService) SetIngestSigning(ctx context.Context, req *warnly.SetIngestSigningRequest) (string, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return "", err
+	}
+
+	project, err := s.projectStore.GetProject(ctx, req.ProjectID)
+	if err != nil {
+		return "", err
+	}
+
+	// the ingest secret is a credential of the project, only admins may rotate it.
+	if err := requireRole(teams, project.TeamID, warnly.RoleAdmin); err != nil {
+		return "", err
+	}
+
+	secret := ""
+	if req.Enabled {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return "", fmt.Errorf("project service: generate ingest secret: %w", err)
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	if err := s.projectStore.UpdateIngestSecret(ctx, req.ProjectID, secret); err != nil {
+		return "", err
+	}
+
+	// events signed with the previous secret or sent without a signature are rejected from now on.
+	if err := s.forgetProjectKeys(ctx, req.ProjectID); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// ListKeys returns the ingest keys of a project along with their DSNs.
+func (s *ProjectService) ListKeys(ctx context.Context, req *warnly.ProjectKeysRequest) ([]warnly.ProjectKey, error) {
+	if _, err := s.GetProject(ctx, req.ProjectID, req.User); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.projectStore.ListKeys(ctx, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		keys[i].DSN = projectDSN(req.ProjectID, keys[i].Key, s.publicBaseURL, s.publicScheme)
+	}
+
+	return keys, nil
+}
+
+// sampleEvent is the event payload of the sample ingest command, the event ID is left out
+// so that every run of the command ingests a new event instead of a duplicate.
+const sampleEvent = `{"message":"This is a test event sent from the warnly onboarding.","level":"error","platform":"other"}`
+
+// SampleIngestCommand returns a curl command sending a test event in an envelope
+// to the project with the key of its DSN, so that a new project can be tried out without an SDK.
+func (s *ProjectService) SampleIngestCommand(ctx context.Context, projectID int, user *warnly.User) (string, error) {
+	project, err := s.GetProject(ctx, projectID, user)
+	if err != nil {
+		return "", err
+	}
+
+	dsn := projectDSN(project.ID, project.Key, s.publicBaseURL, s.publicScheme)
+	endpoint := fmt.Sprintf("%s://%s/ingest/api/%d/envelope/", s.publicScheme, s.publicBaseURL, project.ID)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "printf '%%s\\n' '{\"dsn\":\"%s\"}' '{\"type\":\"event\"}' '%s' | \\\n", dsn, sampleEvent)
+	fmt.Fprintf(&b, "  curl -X POST '%s' \\\n", endpoint)
+	b.WriteString("  -H 'Content-Type: application/x-sentry-envelope' \\\n")
+	fmt.Fprintf(&b, "  -H 'X-Sentry-Auth: Sentry sentry_version=7, sentry_key=%s, sentry_client=curl' \\\n", project.Key)
+	b.WriteString("  --data-binary @-")
+
+	return b.String(), nil
+}
+
+// AddKey adds an ingest key to a project, events are accepted with it in addition to the key of the project DSN.
+func (s *ProjectService) AddKey(ctx context.Context, req *warnly.ProjectKeysRequest) (*warnly.ProjectKey, error) {
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return nil, err
+	}
+
+	key, err := s.newKey(req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.projectStore.AddKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// RotateKey replaces the key of the project DSN with a new one.
+// The previous key is accepted until the grace period passes, so that SDKs can be reconfigured.
+func (s *ProjectService) RotateKey(ctx context.Context, req *warnly.RotateProjectKeyRequest) (*warnly.ProjectKey, error) {
+	project, err := s.getAdminProject(ctx, req.ProjectID, req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.newKey(req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	key.Primary = true
+
+	previous := ""
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		keys, err := uw.Projects().ListKeys(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		if err := uw.Projects().AddKey(ctx, key); err != nil {
+			return err
+		}
+		if err := uw.Projects().UpdatePrimaryKey(ctx, project.ID, key.Key); err != nil {
+			return err
+		}
+		for i := range keys {
+			if keys[i].Primary {
+				previous = keys[i].Key
+				return uw.Projects().RevokeKey(ctx, project.ID, keys[i].ID, key.CreatedAt.Add(max(req.GracePeriod, 0)))
+			}
+		}
+		return nil
+	}, s.projectStore)
+	if err != nil {
+		return nil, err
+	}
+	s.forgetProjectKey(project.ID, previous)
+
+	return key, nil
+}
+
+// RevokeKey stops accepting an additional key of a project once the grace period passes.
+// The key of the project DSN can't be revoked, it is rotated instead.
+func (s *ProjectService) RevokeKey(ctx context.Context, req *warnly.RevokeProjectKeyRequest) error {
+	if _, err := s.getAdminProject(ctx, req.ProjectID, req.User); err != nil {
+		return err
+	}
+
+	keys, err := s.projectStore.ListKeys(ctx, req.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(keys, func(k warnly.ProjectKey) bool { return k.ID == req.KeyID })
+	if i == -1 {
+		return warnly.ErrProjectKeyNotFound
+	}
+	if keys[i].Primary {
+		return warnly.ErrPrimaryProjectKey
+	}
+
+	if err := s.projectStore.RevokeKey(ctx, req.ProjectID, req.KeyID, s.now().UTC().Add(max(req.GracePeriod, 0))); err != nil {
+		return err
+	}
+	s.forgetProjectKey(req.ProjectID, keys[i].Key)
+
+	return nil
+}
+
+// getAdminProject returns the project if the user is an admin of the project team.
+// Ingest keys and settings are credentials and configuration of the project, only admins may change them.
+func (s *ProjectService) getAdminProject(ctx context.Context, projectID int, user *warnly.User) (*warnly.Project, error) {
+	return s.getProjectWithRole(ctx, projectID, user, warnly.RoleAdmin)
+}
+
+// getProjectWithRole returns the project if the user has at least the role in the project team.
+func (s *ProjectService) getProjectWithRole(
+	ctx context.Context,
+	projectID int,
+	user *warnly.User,
+	role warnly.Role,
+) (*warnly.Project, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectStore.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireRole(teams, project.TeamID, role); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// newKey generates a new ingest key of the project.
+func (s *ProjectService) newKey(projectID int) (*warnly.ProjectKey, error) {
+	key, err := warnly.NewNanoID()
 	if err != nil {
 		return nil, err
 	}
 
-	return &warnly.Discussion{
-		Teammates: teammates,
-		Messages:  messages,
-		Info: warnly.DiscussionInfo{
-			ProjectID: project.ID,
-			IssueID:   req.IssueID,
-		},
+	return &warnly.ProjectKey{
+		CreatedAt: s.now().UTC(),
+		Key:       key,
+		DSN:       projectDSN(projectID, key, s.publicBaseURL, s.publicScheme),
+		ProjectID: projectID,
 	}, nil
 }
 
-// DeleteAssignment unassigns an issue from a user.
-func (s *ProjectService) DeleteAssignment(ctx context.Context, req *warnly.UnassignIssueRequest) error {
-	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
-		User: req.User,
-	})
-	if err != nil {
-		return err
-	}
-	if err := s.validateTeammate(teammates, int(req.User.ID)); err != nil {
+// SetCodeOwners replaces the rules that suggest assignees of a project's issues.
+// Every owner must be a member of the project team.
+func (s *ProjectService) SetCodeOwners(ctx context.Context, req *warnly.SetCodeOwnersRequest) error {
+	if err := warnly.ValidateCodeOwners(req.Owners); err != nil {
 		return err
 	}
 
-	return s.assingmentStore.DeleteAssignment(ctx, int64(req.IssueID))
-}
-
-// AssignIssue assigns an issue to a user.
-func (s *ProjectService) AssignIssue(ctx context.Context, req *warnly.AssignIssueRequest) error {
-	teammates, err := s.ListTeammates(ctx, &warnly.ListTeammatesRequest{
-		User: req.User,
-	})
+	project, err := s.getAdminProject(ctx, req.ProjectID, req.User)
 	if err != nil {
 		return err
 	}
-	if err := s.validateTeammate(teammates, req.UserID); err != nil {
+
+	teammates, err := s.teamStore.ListTeammates(ctx, []int{project.TeamID})
+	if err != nil {
 		return err
 	}
 
-	now := s.now().UTC()
-	assign := &warnly.Assignment{
-		AssignedAt:       now,
-		IssueID:          int64(req.IssueID),
-		AssignedToUserID: int64(req.UserID),
-		AssignedByUserID: req.User.ID,
+	for i := range req.Owners {
+		if !slices.ContainsFunc(teammates, func(t warnly.Teammate) bool { return t.ID == req.Owners[i].UserID }) {
+			return fmt.Errorf("%w: user %d is not a member of the project team", warnly.ErrInvalidCodeOwner, req.Owners[i].UserID)
+		}
 	}
 
-	return s.assingmentStore.CreateAssingment(ctx, assign)
+	return s.projectStore.UpdateCodeOwners(ctx, project.ID, req.Owners)
 }
 
-// ListPopularTags lists popular tag keys for search suggestions.
-func (s *ProjectService) ListPopularTags(ctx context.Context, req *warnly.ListPopularTagsRequest) ([]warnly.TagCount, error) {
-	projectIDs, err := s.getProjectIDs(ctx, req.User, req.ProjectName)
+// TransferProject moves a project to another team, the user must be an admin of the project team
+// and a member of the new team.
+// Issues assigned to the old team or to users outside of the new team are unassigned
+// and code owners who aren't members of the new team are removed.
+func (s *ProjectService) TransferProject(ctx context.Context, req *warnly.TransferProjectRequest) error {
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	from, to, err := s.getTimeRangeFromPeriod(req.Period)
+	project, err := s.projectStore.GetProject(ctx, req.ProjectID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	criteria := &warnly.ListPopularTagsCriteria{
-		ProjectIDs: projectIDs,
-		From:       from,
-		To:         to,
-		Limit:      req.Limit,
+	if err := requireRole(teams, project.TeamID, warnly.RoleAdmin); err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(teams, func(t warnly.Team) bool { return t.ID == req.TeamID }) {
+		return warnly.ErrTeamNotFound
 	}
 
-	return s.analyticsStore.ListPopularTags(ctx, criteria)
-}
-
-// ListTagValues lists popular values for a given tag.
-func (s *ProjectService) ListTagValues(ctx context.Context, req *warnly.ListTagValuesRequest) ([]warnly.TagValueCount, error) {
-	projectIDs, err := s.getProjectIDs(ctx, req.User, req.ProjectName)
+	teammates, err := s.teamStore.ListTeammates(ctx, []int{req.TeamID})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	inTeam := func(userID int64) bool {
+		return slices.ContainsFunc(teammates, func(t warnly.Teammate) bool { return t.ID == userID })
 	}
 
-	var from, to time.Time
-	if req.Period == "" && req.Start == "" && req.End == "" {
-		req.Period = defaultPeriod
+	if err := s.projectStore.UpdateTeam(ctx, project.ID, req.TeamID); err != nil {
+		return err
 	}
-	if req.Period != "" {
-		dur, err := warnly.ParseDuration(req.Period)
-		if err != nil {
-			return nil, err
-		}
-		now := s.now().UTC()
-		from = now.Add(-dur)
-		to = now
-	} else {
-		from, to, err = warnly.ParseTimeRange(req.Start, req.End)
-		if err != nil {
-			return nil, err
+
+	owners := slices.DeleteFunc(slices.Clone(project.CodeOwners), func(o warnly.CodeOwner) bool {
+		return !inTeam(o.UserID)
+	})
+	if len(owners) != len(project.CodeOwners) {
+		if err := s.projectStore.UpdateCodeOwners(ctx, project.ID, owners); err != nil {
+			return err
 		}
 	}
 
-	criteria := &warnly.ListTagValuesCriteria{
-		Tag:        req.Tag,
-		ProjectIDs: projectIDs,
-		From:       from,
-		To:         to,
-		Limit:      req.Limit,
+	assignments, err := s.assingmentStore.ListProjectAssingments(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+	assignments = slices.DeleteFunc(assignments, func(a *warnly.AssignedUser) bool {
+		if a.AssigneeType() == warnly.AssigneeTeam {
+			return a.AssignedToTeamID.Int64 == int64(req.TeamID)
+		}
+		return inTeam(a.AssignedToUserID.Int64)
+	})
+	if len(assignments) == 0 {
+		return nil
 	}
 
-	return s.analyticsStore.ListTagValues(ctx, criteria)
+	now := s.now().UTC()
+	return s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		for _, a := range assignments {
+			if err := uw.Assignments().DeleteAssignment(ctx, a.IssueID); err != nil {
+				return err
+			}
+			if err := uw.Activities().CreateActivity(ctx, &warnly.Activity{
+				CreatedAt: now,
+				Type:      warnly.ActivityUnassigned,
+				IssueID:   a.IssueID,
+				ActorID:   req.User.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, s.assingmentStore, s.activityStore)
 }
 
-// SearchProject searches for a project by name within the user's teams.
-func (s *ProjectService) SearchProject(ctx context.Context, name string, user *warnly.User) (*warnly.Project, error) {
-	teams, err := s.teamStore.ListTeams(ctx, int(user.ID))
+// applyDefaultQuery sets the query of the request to the default query of the user, if there is one.
+func (s *ProjectService) applyDefaultQuery(ctx context.Context, req *warnly.ListIssuesRequest, projects []warnly.Project) error {
+	projectID, ok := defaultQueryProjectID(projects, req.ProjectName)
+	if !ok {
+		return nil
+	}
+
+	query, err := s.projectStore.GetDefaultIssuesQuery(ctx, int(req.User.ID), projectID)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil
+		}
+		return err
 	}
 
-	if len(teams) == 0 {
-		return nil, warnly.ErrNotFound
+	req.Query = query
+
+	return nil
+}
+
+// SaveDefaultIssuesQuery sets the query applied to the issues list when the user opens it without a query.
+func (s *ProjectService) SaveDefaultIssuesQuery(ctx context.Context, req *warnly.SaveDefaultIssuesQueryRequest) error {
+	query := strings.TrimSpace(req.Query)
+	if len(query) > warnly.MaxDefaultIssuesQueryLength {
+		return warnly.ErrDefaultIssuesQueryTooLong
 	}
 
-	teamIDs := make([]int, 0, len(teams))
-	for i := range teams {
-		teamIDs = append(teamIDs, teams[i].ID)
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return err
+	}
+	if len(teams) == 0 {
+		return warnly.ErrProjectNotFound
 	}
 
-	projects, err := s.projectStore.ListProjects(ctx, teamIDs, name)
+	projects, err := s.projectStore.ListProjects(ctx, extractTeamIDs(teams), "")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if len(projects) == 0 {
-		return nil, warnly.ErrProjectNotFound
+
+	projectID, ok := defaultQueryProjectID(projects, req.ProjectName)
+	if !ok {
+		return warnly.ErrProjectNotFound
 	}
 
+	return s.projectStore.SaveDefaultIssuesQuery(ctx, &warnly.DefaultIssuesQuery{
+		UpdatedAt: s.now().UTC(),
+		Query:     query,
+		UserID:    int(req.User.ID),
+		ProjectID: projectID,
+	})
+}
+
+// defaultQueryProjectID returns the project ID the default issues query is kept under,
+// 0 for the list of issues of all projects. Returns false if there is no such project.
+func defaultQueryProjectID(projects []warnly.Project, projectName string) (int, bool) {
+	if projectName == "" {
+		return 0, true
+	}
 	for i := range projects {
-		if projects[i].Name == name {
-			return &projects[i], nil
+		if projects[i].Name == projectName {
+			return projects[i].ID, true
 		}
 	}
+	return 0, false
+}
 
-	return nil, warnly.ErrProjectNotFound
+// suggestAssignee returns the teammate owning the top in-app frame of an unassigned issue.
+func suggestAssignee(
+	owners []warnly.CodeOwner,
+	stack []warnly.StackDetail,
+	teammates []warnly.Teammate,
+	assignments *warnly.Assignments,
+	issueID int64,
+) *warnly.Teammate {
+	if _, ok := assignments.AssignedUser(issueID); ok {
+		return nil
+	}
+	if _, ok := assignments.AssignedTeam(issueID); ok {
+		return nil
+	}
+
+	userID, ok := warnly.SuggestOwner(owners, stack)
+	if !ok {
+		return nil
+	}
+	for i := range teammates {
+		if teammates[i].ID == userID {
+			return &teammates[i]
+		}
+	}
+	return nil
+}
+
+// requireRole returns ErrPermissionDenied unless the user has at least the given role in the team,
+// ErrProjectNotFound if the user isn't a member of the team at all.
+func requireRole(teams []warnly.Team, teamID int, role warnly.Role) error {
+	for i := range teams {
+		if teams[i].ID != teamID {
+			continue
+		}
+		if !teams[i].Role.Allows(role) {
+			return warnly.ErrPermissionDenied
+		}
+		return nil
+	}
+	return warnly.ErrProjectNotFound
 }
 
-// validateTeammate checks if a user is part of the teammates list.
-func (s *ProjectService) validateTeammate(teammates []warnly.Teammate, userID int) error {
+// validateTeammate checks if a user is part of the teammates list or,
+// for team assignments (non-zero teamID), that the team is one of the teams the current user is a member of.
+func (s *ProjectService) validateTeammate(teammates []warnly.Teammate, teams []warnly.Team, userID, teamID int) error {
+	if teamID != 0 {
+		for i := range teams {
+			if teams[i].ID == teamID {
+				return nil
+			}
+		}
+		return fmt.Errorf("team %d is not a team of the user", teamID)
+	}
+
 	for i := range teammates {
 		if teammates[i].ID == int64(userID) {
 			return nil
@@ -1158,13 +3203,16 @@ func extractIssueIDs(issues []warnly.Issue) []int64 {
 	return ids
 }
 
-// buildIssueList builds a list of issue entries with their associated metrics and sorts them.
+// buildIssueList builds a list of issue entries with their associated metrics and sorts them
+// by frequency, by users affected when sortBy is warnly.IssueSortUsers
+// or by hotness when sortBy is warnly.IssueSortHot.
 func (s *ProjectService) buildIssueList(
 	ctx context.Context,
 	projectIDS []int,
 	issues []warnly.Issue,
 	from,
 	to time.Time,
+	sortBy string,
 ) ([]warnly.IssueEntry, error) {
 	ids := extractIssueIDs(issues)
 
@@ -1188,15 +3236,18 @@ func (s *ProjectService) buildIssueList(
 			continue
 		}
 		iss := warnly.IssueEntry{
-			ID:        issues[i].ID,
-			Type:      issues[i].ErrorType,
-			View:      issues[i].View,
-			Message:   issues[i].Message,
-			ProjectID: issues[i].ProjectID,
-			LastSeen:  metric.LastSeen,
-			FirstSeen: metric.FirstSeen,
-			TimesSeen: metric.TimesSeen,
-			UserCount: metric.UserCount,
+			ID:          issues[i].ID,
+			Type:        issues[i].ErrorType,
+			View:        issues[i].View,
+			Message:     issues[i].Message,
+			Level:       warnly.LevelName(metric.Level),
+			IsNew:       s.isNewIssue(issues[i].FirstSeen),
+			NewInPeriod: !issues[i].FirstSeen.Before(from),
+			ProjectID:   issues[i].ProjectID,
+			LastSeen:    metric.LastSeen,
+			FirstSeen:   metric.FirstSeen,
+			TimesSeen:   metric.TimesSeen,
+			UserCount:   metric.UserCount,
 		}
 		issueList = append(issueList, iss)
 	}
@@ -1207,16 +3258,112 @@ func (s *ProjectService) buildIssueList(
 		}
 		return cmp.Compare(b.TimesSeen, a.TimesSeen)
 	}
+	switch sortBy {
+	case warnly.IssueSortUsers:
+		byFrequency := compareFn
+		compareFn = func(a, b warnly.IssueEntry) int {
+			if a.UserCount == b.UserCount {
+				return byFrequency(a, b)
+			}
+			return cmp.Compare(b.UserCount, a.UserCount)
+		}
+	case warnly.IssueSortHot:
+		if err := s.scoreHotness(ctx, projectIDS, issueList, from, to); err != nil {
+			return nil, err
+		}
+		byFrequency := compareFn
+		compareFn = func(a, b warnly.IssueEntry) int {
+			if a.Hotness == b.Hotness {
+				return byFrequency(a, b)
+			}
+			return cmp.Compare(b.Hotness, a.Hotness)
+		}
+	}
 	slices.SortFunc(issueList, compareFn)
 
 	return issueList, nil
 }
 
+// attachSparklines sets the hourly event series of the last warnly.SparklineHours hours before to
+// of the issue entries of the projects, the series of all issues are calculated at once.
+func (s *ProjectService) attachSparklines(
+	ctx context.Context,
+	projectIDs []int,
+	issueList []warnly.IssueEntry,
+	to time.Time,
+) error {
+	if len(issueList) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(issueList))
+	for i := range issueList {
+		ids[i] = issueList[i].ID
+	}
+
+	from := to.UTC().Truncate(time.Hour).Add(-(warnly.SparklineHours - 1) * time.Hour)
+	events, err := s.analyticsStore.EventSeriesForIssues(ctx, ids, from, to, projectIDs)
+	if err != nil {
+		return err
+	}
+
+	hours := make(map[int64][]warnly.IssueEventsPerHour, len(issueList))
+	for _, e := range events {
+		hours[e.GroupID] = append(hours[e.GroupID], e)
+	}
+
+	for i := range issueList {
+		issueList[i].Sparkline = warnly.NewSparkline(hours[issueList[i].ID], to)
+	}
+
+	return nil
+}
+
+// scoreHotness sets the hotness of the issue entries from their hourly event counts within the range.
+func (s *ProjectService) scoreHotness(
+	ctx context.Context,
+	projectIDs []int,
+	issueList []warnly.IssueEntry,
+	from, to time.Time,
+) error {
+	if len(issueList) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(issueList))
+	for i := range issueList {
+		ids[i] = issueList[i].ID
+	}
+
+	events, err := s.analyticsStore.CalculateIssueEvents(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: projectIDs,
+		GroupIDs:   ids,
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		return err
+	}
+
+	hours := make(map[int64][]warnly.IssueEventsPerHour, len(issueList))
+	for _, e := range events {
+		hours[e.GroupID] = append(hours[e.GroupID], e)
+	}
+
+	now := s.now().UTC()
+	for i := range issueList {
+		issueList[i].Hotness = warnly.Hotness(hours[issueList[i].ID], issueList[i].LastSeen, s.hotnessHalfLife, now)
+	}
+
+	return nil
+}
+
 // listIssueEntries lists issue entries for a specific project along with their metrics.
 func (s *ProjectService) listIssueEntries(
 	ctx context.Context,
 	projectID int,
 	issues []warnly.Issue,
+	levels []string,
 	from, to time.Time,
 ) ([]warnly.IssueEntry, error) {
 	ids := make([]int64, len(issues))
@@ -1229,6 +3376,7 @@ func (s *ProjectService) listIssueEntries(
 		&warnly.ListIssueMetricsCriteria{
 			ProjectIDs: []int{projectID},
 			GroupIDs:   ids,
+			Levels:     levels,
 			From:       from,
 			To:         to,
 		},
@@ -1243,28 +3391,105 @@ func (s *ProjectService) listIssueEntries(
 		if !ok {
 			continue
 		}
-		issueList = append(issueList, warnly.IssueEntry{
-			ID:        issues[i].ID,
-			Type:      issues[i].ErrorType,
-			View:      issues[i].View,
-			Message:   issues[i].Message,
-			LastSeen:  metric.LastSeen,
-			FirstSeen: metric.FirstSeen,
-			TimesSeen: metric.TimesSeen,
-			UserCount: metric.UserCount,
-		})
+		issueList = append(issueList, s.newIssueEntry(&issues[i], &metric, from))
 	}
 
-	slices.SortFunc(issueList, func(a, b warnly.IssueEntry) int {
-		if a.TimesSeen == b.TimesSeen {
-			return cmp.Compare(b.LastSeen.Unix(), a.LastSeen.Unix())
-		}
-		return cmp.Compare(b.TimesSeen, a.TimesSeen)
-	})
+	slices.SortFunc(issueList, warnly.CompareIssueEntries)
 
 	return issueList, nil
 }
 
+// listIssuePage lists the page of issue entries that follows the cursor, from the start of the list
+// when it is nil, along with their issues and the cursor of the next page, empty on the last page.
+// The next cursor is taken from the metrics, so that the issues left out of the page,
+// e.g. ignored ones, don't end the list early.
+func (s *ProjectService) listIssuePage(
+	ctx context.Context,
+	projectID int,
+	cursor *warnly.IssueCursor,
+	levels []string,
+	from, to time.Time,
+	excludeIgnored bool,
+) ([]warnly.IssueEntry, []warnly.Issue, string, error) {
+	issueMetrics, err := s.analyticsStore.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: []int{projectID},
+		Levels:     levels,
+		From:       from,
+		To:         to,
+		After:      cursor,
+		Limit:      s.pageSize + 1,
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(issueMetrics) == 0 {
+		return nil, nil, "", nil
+	}
+
+	var nextCursor string
+	if len(issueMetrics) > s.pageSize {
+		issueMetrics = issueMetrics[:s.pageSize]
+		last := &issueMetrics[len(issueMetrics)-1]
+		nextCursor = (&warnly.IssueCursor{
+			LastSeen:  last.LastSeen.Truncate(time.Second),
+			TimesSeen: last.TimesSeen,
+			ID:        int64(last.GID),
+		}).String()
+	}
+
+	ids := make([]int64, len(issueMetrics))
+	for i := range issueMetrics {
+		ids[i] = int64(issueMetrics[i].GID)
+	}
+	issues, err := s.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
+		ProjectIDs:     []int{projectID},
+		GroupIDs:       ids,
+		From:           from,
+		To:             to,
+		ExcludeIgnored: excludeIgnored,
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	issueList := make([]warnly.IssueEntry, 0, len(issueMetrics))
+	for i := range issueMetrics {
+		metric := &issueMetrics[i]
+		j := slices.IndexFunc(issues, func(issue warnly.Issue) bool { return issue.ID == int64(metric.GID) })
+		if j < 0 {
+			continue
+		}
+		issueList = append(issueList, s.newIssueEntry(&issues[j], metric, from))
+	}
+
+	return issueList, issues, nextCursor, nil
+}
+
+// cursorParam returns the cursor encoded, empty when there is none.
+func cursorParam(cursor *warnly.IssueCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return cursor.String()
+}
+
+// newIssueEntry returns the entry of the issue with its metrics over the period starting at from.
+func (s *ProjectService) newIssueEntry(issue *warnly.Issue, metric *warnly.IssueMetrics, from time.Time) warnly.IssueEntry {
+	return warnly.IssueEntry{
+		ID:          issue.ID,
+		Type:        issue.ErrorType,
+		View:        issue.View,
+		Message:     issue.Message,
+		Level:       warnly.LevelName(metric.Level),
+		IsNew:       s.isNewIssue(issue.FirstSeen),
+		NewInPeriod: !issue.FirstSeen.Before(from),
+		LastSeen:    metric.LastSeen,
+		FirstSeen:   metric.FirstSeen,
+		TimesSeen:   metric.TimesSeen,
+		UserCount:   metric.UserCount,
+	}
+}
+
 // projectDSN constructs a DSN string for a project.
 func projectDSN(projectID int, key, baseURL, scheme string) string {
 	return fmt.Sprintf("%s://%s@%s/%d", scheme, key, baseURL+"/ingest", projectID)
@@ -1300,6 +3525,16 @@ func assignEventsToProjects(projects []warnly.Project, eventsMap map[int][]warnl
 	}
 }
 
+// uniqueUsersOf returns the unique user count of the project, zero when it has none.
+func uniqueUsersOf(users []warnly.ProjectUsers, projectID int) uint64 {
+	for i := range users {
+		if users[i].ProjectID == projectID {
+			return users[i].Count
+		}
+	}
+	return 0
+}
+
 // populateMessagesCount populates the messages count for each issue in the list.
 func (s *ProjectService) populateMessagesCount(
 	ctx context.Context,
@@ -1324,14 +3559,16 @@ func (s *ProjectService) populateMessagesCount(
 	return issueList, nil
 }
 
-// buildTeammateAssigns builds a mapping of issues to their assigned teammates.
+// buildTeammateAssigns builds a mapping of issues to their assigned teammates and teams.
 func (s *ProjectService) buildTeammateAssigns(
 	ctx context.Context,
 	teammates []warnly.Teammate,
+	teams []warnly.Team,
 	issues []warnly.Issue,
 ) (*warnly.Assignments, error) {
 	assignments := &warnly.Assignments{
 		IssueToAssigned: make(map[int64]*warnly.Teammate),
+		IssueToTeam:     make(map[int64]*warnly.Team),
 	}
 
 	if len(issues) == 0 || len(teammates) == 0 {
@@ -1350,10 +3587,22 @@ func (s *ProjectService) buildTeammateAssigns(
 		teammateMap[teammate.ID] = &teammates[i]
 	}
 
+	teamMap := make(map[int64]*warnly.Team, len(teams))
+	for i := range teams {
+		teamMap[int64(teams[i].ID)] = &teams[i]
+	}
+
 	for _, assigned := range assignedUsers {
-		if assigned.AssignedToUserID.Valid {
-			teammate, ok := teammateMap[assigned.AssignedToUserID.Int64]
-			if ok {
+		switch assigned.AssigneeType() {
+		case warnly.AssigneeTeam:
+			if team, ok := teamMap[assigned.AssignedToTeamID.Int64]; ok {
+				assignments.IssueToTeam[assigned.IssueID] = team
+			}
+		case warnly.AssigneeUser:
+			if !assigned.AssignedToUserID.Valid {
+				continue
+			}
+			if teammate, ok := teammateMap[assigned.AssignedToUserID.Int64]; ok {
 				assignments.IssueToAssigned[assigned.IssueID] = teammate
 			}
 		}
@@ -1389,11 +3638,11 @@ func paginate(issueList []warnly.IssueEntry, page, pageSize int) []warnly.IssueE
 	return issueList[start:end]
 }
 
-// filterRecentIssues filters and returns issues that have been first seen within the last 7 days from 'now'.
-func filterRecentIssues(issueList []warnly.IssueEntry, now time.Time) []warnly.IssueEntry {
+// filterNewIssues filters and returns issues that have been first seen within the new issue window.
+func filterNewIssues(issueList []warnly.IssueEntry) []warnly.IssueEntry {
 	res := make([]warnly.IssueEntry, 0, len(issueList))
 	for i := range issueList {
-		if issueList[i].FirstSeen.UTC().After(now.UTC().Add(-7 * 24 * time.Hour)) {
+		if issueList[i].IsNew {
 			res = append(res, issueList[i])
 		}
 	}
@@ -1610,8 +3859,9 @@ func convertTokensToCriteria(tokens []warnly.QueryToken) (string, map[string]war
 			rawBuilder.WriteString(tokens[i].Value)
 		} else {
 			structured[tokens[i].Key] = warnly.QueryValue{
-				Value: tokens[i].Value,
-				IsNot: tokens[i].Operator == "is not",
+				Value:     tokens[i].Value,
+				IsNot:     tokens[i].Operator == "is not",
+				IsContext: tokens[i].IsContext,
 			}
 		}
 	}
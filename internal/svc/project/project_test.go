@@ -3,7 +3,11 @@ package project_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,9 +16,120 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/vk-rv/warnly/internal/mock"
 	"github.com/vk-rv/warnly/internal/svc/project"
+	"github.com/vk-rv/warnly/internal/uow"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
 
+// testService sets the dependencies of a project service built by newTestService,
+// the ones a test leaves unset are empty mocks or the defaults most tests use.
+type testService struct {
+	ProjectStore      warnly.ProjectStore
+	AssingmentStore   warnly.AssingmentStore
+	TeamStore         warnly.TeamStore
+	IssueStore        warnly.IssueStore
+	MessageStore      warnly.MessageStore
+	MentionStore      warnly.MentionStore
+	ActivityStore     warnly.ActivityStore
+	SubscriptionStore warnly.SubscriptionStore
+	LabelStore        warnly.IssueLabelStore
+	SeenStore         warnly.SeenStore
+	AnalyticsStore    warnly.AnalyticsStore
+	Notifier          warnly.IssueNotifier
+	UnitOfWork        uow.StartUnitOfWork
+	Policy            *bluemonday.Policy
+	Now               func() time.Time
+	BaseURL           string
+	Scheme            string
+	PublicBaseURL     string
+	PublicScheme      string
+	Options           project.Options
+}
+
+// newTestService builds a project service of the dependencies.
+func newTestService(deps testService) *project.ProjectService {
+	if deps.ProjectStore == nil {
+		deps.ProjectStore = &mock.ProjectStore{}
+	}
+	if deps.AssingmentStore == nil {
+		deps.AssingmentStore = &mock.AssingmentStore{}
+	}
+	if deps.TeamStore == nil {
+		deps.TeamStore = &mock.TeamStore{}
+	}
+	if deps.IssueStore == nil {
+		deps.IssueStore = &mock.IssueStore{}
+	}
+	if deps.MessageStore == nil {
+		deps.MessageStore = &mock.MessageStore{}
+	}
+	if deps.MentionStore == nil {
+		deps.MentionStore = &mock.MentionStore{}
+	}
+	if deps.ActivityStore == nil {
+		deps.ActivityStore = &mock.ActivityStore{}
+	}
+	if deps.SubscriptionStore == nil {
+		deps.SubscriptionStore = &mock.SubscriptionStore{}
+	}
+	if deps.LabelStore == nil {
+		deps.LabelStore = &mock.IssueLabelStore{}
+	}
+	if deps.SeenStore == nil {
+		deps.SeenStore = &mock.SeenStore{}
+	}
+	if deps.AnalyticsStore == nil {
+		deps.AnalyticsStore = &mock.AnalyticsStore{}
+	}
+	if deps.Notifier == nil {
+		deps.Notifier = &mock.IssueNotifier{}
+	}
+	if deps.UnitOfWork == nil {
+		deps.UnitOfWork = mock.StartUnitOfWork
+	}
+	if deps.Policy == nil {
+		deps.Policy = bluemonday.NewPolicy()
+	}
+	if deps.Now == nil {
+		deps.Now = time.Now
+	}
+	if deps.BaseURL == "" {
+		deps.BaseURL = "localhost:8080"
+	}
+	if deps.Scheme == "" {
+		deps.Scheme = "http"
+	}
+	if deps.PublicBaseURL == "" {
+		deps.PublicBaseURL = deps.BaseURL
+	}
+	if deps.PublicScheme == "" {
+		deps.PublicScheme = deps.Scheme
+	}
+
+	return project.NewProjectService(
+		deps.ProjectStore,
+		deps.AssingmentStore,
+		deps.TeamStore,
+		deps.IssueStore,
+		deps.MessageStore,
+		deps.MentionStore,
+		deps.ActivityStore,
+		deps.SubscriptionStore,
+		deps.LabelStore,
+		deps.SeenStore,
+		deps.AnalyticsStore,
+		deps.Notifier,
+		deps.UnitOfWork,
+		deps.Policy,
+		deps.BaseURL,
+		deps.Scheme,
+		deps.PublicBaseURL,
+		deps.PublicScheme,
+		deps.Options,
+		deps.Now,
+		slog.Default(),
+	)
+}
+
 // TestNewProjectServiceReturnsValidService tests that NewProjectService creates a valid ProjectService.
 func TestNewProjectServiceReturnsValidService(t *testing.T) {
 	t.Parallel()
@@ -39,13 +154,19 @@ func TestNewProjectServiceReturnsValidService(t *testing.T) {
 		issueStore,
 		messageStore,
 		mentionStore,
+		&mock.ActivityStore{},
+		&mock.SubscriptionStore{},
+		&mock.IssueLabelStore{},
+		&mock.SeenStore{},
 		analyticsStore,
+		&mock.IssueNotifier{},
 		mock.StartUnitOfWork,
 		bluemonday.NewPolicy(),
 		"localhost:8080",
 		"https",
 		"example.com",
 		"https",
+		project.Options{},
 		customTimeFunc,
 		slog.Default(),
 	)
@@ -75,23 +196,11 @@ func TestCreateProjectSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		UnitOfWork:   (&mock.UnitOfWork{ProjectStore: projectStore}).Start,
+	})
 
 	req := &warnly.CreateProjectRequest{
 		ProjectName: "Test Project",
@@ -108,6 +217,138 @@ func TestCreateProjectSuccess(t *testing.T) {
 	assert.NotEmpty(t, result.DSN)
 }
 
+func TestImportProjectsIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	user := &warnly.User{ID: 1}
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: 10, Name: "Backend"}, {ID: 20, Name: "Frontend"}}, nil
+		},
+	}
+
+	var created []warnly.Project
+	projectStore := &mock.ProjectStore{
+		CreateProjectFn: func(_ context.Context, proj *warnly.Project) error {
+			proj.ID = len(created) + 1
+			created = append(created, *proj)
+			return nil
+		},
+		ListProjectsFn: func(_ context.Context, teamIDs []int, name string) ([]warnly.Project, error) {
+			var projects []warnly.Project
+			for _, p := range created {
+				if slices.Contains(teamIDs, p.TeamID) && strings.Contains(p.Name, name) {
+					projects = append(projects, p)
+				}
+			}
+			return projects, nil
+		},
+		GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			p := created[projectID-1]
+			return &p, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:  projectStore,
+		TeamStore:     teamStore,
+		UnitOfWork:    (&mock.UnitOfWork{ProjectStore: projectStore}).Start,
+		PublicBaseURL: "warnly.example.com",
+		PublicScheme:  "https",
+	})
+
+	req := &warnly.ImportProjectsRequest{
+		User: user,
+		Projects: []warnly.ProjectManifestEntry{
+			{Name: "api", Platform: "go", TeamID: 10},
+			{Name: "api-worker", Platform: "go", TeamID: 10},
+			{Name: "api", Platform: "rust", TeamID: 20},
+		},
+	}
+
+	first, err := svc.ImportProjects(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, first.Projects, 3)
+	require.Len(t, created, 3)
+
+	for i, p := range first.Projects {
+		assert.True(t, p.Created)
+		assert.Equal(t, req.Projects[i].Name, p.Name)
+		assert.Equal(t, req.Projects[i].TeamID, p.TeamID)
+		assert.Equal(t, fmt.Sprintf("https://%s@warnly.example.com/ingest/%d", created[i].Key, created[i].ID), p.DSN)
+	}
+
+	second, err := svc.ImportProjects(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, second.Projects, 3)
+	assert.Len(t, created, 3, "re-import must not create projects")
+
+	for i, p := range second.Projects {
+		assert.False(t, p.Created)
+		assert.Equal(t, first.Projects[i].ID, p.ID)
+		assert.Equal(t, first.Projects[i].DSN, p.DSN)
+		assert.Equal(t, req.Projects[i].Platform, p.Platform)
+	}
+}
+
+func TestImportProjectsRejectsInvalidManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		projects []warnly.ProjectManifestEntry
+	}{
+		{name: "empty manifest"},
+		{
+			name:     "unknown platform",
+			projects: []warnly.ProjectManifestEntry{{Name: "api", Platform: "cobol", TeamID: 10}},
+		},
+		{
+			name:     "missing name",
+			projects: []warnly.ProjectManifestEntry{{Platform: "go", TeamID: 10}},
+		},
+		{
+			name: "team the user is not a member of",
+			projects: []warnly.ProjectManifestEntry{
+				{Name: "api", Platform: "go", TeamID: 10},
+				{Name: "billing", Platform: "go", TeamID: 30},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			teamStore := &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Backend"}}, nil
+				},
+			}
+			projectStore := &mock.ProjectStore{
+				CreateProjectFn: func(_ context.Context, _ *warnly.Project) error {
+					t.Error("no project must be created from an invalid manifest")
+					return nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: projectStore,
+				TeamStore:    teamStore,
+				UnitOfWork:   (&mock.UnitOfWork{ProjectStore: projectStore}).Start,
+			})
+
+			_, err := svc.ImportProjects(t.Context(), &warnly.ImportProjectsRequest{
+				User:     &warnly.User{ID: 1},
+				Projects: tt.projects,
+			})
+			require.ErrorIs(t, err, warnly.ErrInvalidManifest)
+		})
+	}
+}
+
 func TestDeleteProjectSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -119,7 +360,7 @@ func TestDeleteProjectSuccess(t *testing.T) {
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
 			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
+				{ID: teamID, Name: "Team A", Role: warnly.RoleAdmin},
 			}, nil
 		},
 	}
@@ -132,201 +373,395 @@ func TestDeleteProjectSuccess(t *testing.T) {
 				Name:   "Test Project",
 			}, nil
 		},
-		DeleteProjectFn: func(_ context.Context, _ int) error {
+		SoftDeleteFn: func(_ context.Context, _ int, _ time.Time) error {
 			return nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	// events are deleted by the project reaper once the grace period passes, not inline,
+	// the analytics store mock would panic otherwise.
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
 
 	err := svc.DeleteProject(ctx, projectID, user)
 
 	assert.NoError(t, err)
 }
 
-func TestGetProjectSuccess(t *testing.T) {
+func TestDeleteProjectIsRestorable(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
 	user := &warnly.User{ID: 1}
-	projectID := 5
 	teamID := 10
+	deletedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
 
+	// projects is an in-memory project table, a deleted project has its deletion time set.
+	projects := map[int]*warnly.Project{
+		5: {ID: 5, TeamID: teamID, Name: "checkout"},
+		6: {ID: 6, TeamID: teamID, Name: "billing"},
+	}
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			if p, ok := projects[projectID]; ok && p.DeletedAt == nil {
+				return p, nil
+			}
+			return nil, warnly.ErrProjectNotFound
+		},
+		GetDeletedProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			if p, ok := projects[projectID]; ok && p.DeletedAt != nil {
+				return p, nil
+			}
+			return nil, warnly.ErrProjectNotFound
+		},
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			var list []warnly.Project
+			for _, id := range []int{5, 6} {
+				if projects[id].DeletedAt == nil {
+					list = append(list, *projects[id])
+				}
+			}
+			return list, nil
+		},
+		SoftDeleteFn: func(_ context.Context, projectID int, at time.Time) error {
+			projects[projectID].DeletedAt = &at
+			return nil
+		},
+		RestoreFn: func(_ context.Context, projectID int) error {
+			projects[projectID].DeletedAt = nil
+			return nil
+		},
+	}
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+			return []warnly.Team{{ID: teamID, Name: "Team A", Role: warnly.RoleAdmin}}, nil
 		},
 	}
 
-	projectStore := &mock.ProjectStore{
-		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
-			return &warnly.Project{
-				ID:     projectID,
-				TeamID: teamID,
-				Name:   "Test Project",
-			}, nil
+	now := deletedAt
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		AnalyticsStore: &mock.AnalyticsStore{
+			CalculateEventsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error) {
+				return []warnly.EventsPerHour{}, nil
+			},
 		},
+		Options: project.Options{DeletionGracePeriod: 24 * time.Hour},
+		Now:     func() time.Time { return now },
+	})
+
+	listNames := func() []string {
+		t.Helper()
+		result, err := svc.ListProjects(t.Context(), &warnly.ListProjectsCriteria{}, user)
+		require.NoError(t, err)
+		names := make([]string, 0, len(result.Projects))
+		for i := range result.Projects {
+			names = append(names, result.Projects[i].Name)
+		}
+		return names
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
-
-	result, err := svc.GetProject(ctx, projectID, user)
+	require.NoError(t, svc.DeleteProject(t.Context(), 5, user))
+	assert.Equal(t, deletedAt, *projects[5].DeletedAt)
+	assert.Equal(t, []string{"billing"}, listNames())
+	_, err := svc.GetProject(t.Context(), 5, user)
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound)
 
+	now = deletedAt.Add(23 * time.Hour)
+	require.NoError(t, svc.RestoreProject(t.Context(), 5, user))
+	assert.Equal(t, []string{"checkout", "billing"}, listNames())
+	restored, err := svc.GetProject(t.Context(), 5, user)
 	require.NoError(t, err)
-	assert.Equal(t, projectID, result.ID)
-	assert.Equal(t, teamID, result.TeamID)
-	assert.Equal(t, "Test Project", result.Name)
+	assert.Equal(t, "checkout", restored.Name)
+
+	// the project deleted longer than the grace period ago awaits purging.
+	require.NoError(t, svc.DeleteProject(t.Context(), 5, user))
+	now = now.Add(24 * time.Hour)
+	require.ErrorIs(t, svc.RestoreProject(t.Context(), 5, user), warnly.ErrProjectNotFound)
+	assert.Equal(t, []string{"billing"}, listNames())
 }
 
-func TestListProjectsSuccess(t *testing.T) {
+func TestTransferProjectSuccess(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
 	user := &warnly.User{ID: 1}
-	teamID := 10
+	projectID := 5
+	fromTeamID, toTeamID := 10, 20
 
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+			return []warnly.Team{{ID: fromTeamID, Name: "Team A", Role: warnly.RoleAdmin}, {ID: toTeamID, Name: "Team B"}}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, teamIDs []int) ([]warnly.Teammate, error) {
+			assert.Equal(t, []int{toTeamID}, teamIDs)
+			return []warnly.Teammate{{ID: 1, Username: "alice"}, {ID: 3, Username: "carol"}}, nil
 		},
 	}
 
+	var movedTo int
+	var owners []warnly.CodeOwner
 	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{
-				{ID: 1, TeamID: teamID, Name: "Project 1"},
-				{ID: 2, TeamID: teamID, Name: "Project 2"},
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:     projectID,
+				TeamID: fromTeamID,
+				Name:   "Test Project",
+				CodeOwners: []warnly.CodeOwner{
+					{Pattern: "app/billing/**", UserID: 2},
+					{Pattern: "app/auth/**", UserID: 3},
+				},
 			}, nil
 		},
+		UpdateTeamFn: func(_ context.Context, id, teamID int) error {
+			assert.Equal(t, projectID, id)
+			movedTo = teamID
+			return nil
+		},
+		UpdateCodeOwnersFn: func(_ context.Context, _ int, o []warnly.CodeOwner) error {
+			owners = o
+			return nil
+		},
 	}
 
-	analyticsStore := &mock.AnalyticsStore{
-		CalculateEventsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error) {
-			return []warnly.EventsPerHour{
-				{ProjectID: 1, Count: 10},
-				{ProjectID: 2, Count: 20},
+	var unassigned []int64
+	assignmentStore := &mock.AssingmentStore{
+		ListProjectAssingmentsFn: func(_ context.Context, _ int) ([]*warnly.AssignedUser, error) {
+			return []*warnly.AssignedUser{
+				{IssueID: 1, AssignedToUserID: sql.NullInt64{Int64: 1, Valid: true}},
+				{IssueID: 2, AssignedToUserID: sql.NullInt64{Int64: 2, Valid: true}},
+				{IssueID: 3, AssignedToTeamID: sql.NullInt64{Int64: int64(fromTeamID), Valid: true}},
+				{IssueID: 4, AssignedToTeamID: sql.NullInt64{Int64: int64(toTeamID), Valid: true}},
 			}, nil
 		},
+		DeleteAssignmentFn: func(_ context.Context, issueID int64) error {
+			unassigned = append(unassigned, issueID)
+			return nil
+		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	var activities int
+	activityStore := &mock.ActivityStore{
+		CreateActivityFn: func(_ context.Context, a *warnly.Activity) error {
+			assert.Equal(t, warnly.ActivityUnassigned, a.Type)
+			activities++
+			return nil
+		},
+	}
 
-	criteria := &warnly.ListProjectsCriteria{}
-	result, err := svc.ListProjects(ctx, criteria, user)
+	uw := &mock.UnitOfWork{AssingmentStore: assignmentStore, ActivityStore: activityStore}
+
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		ActivityStore:   activityStore,
+		UnitOfWork:      uw.Start,
+	})
 
+	err := svc.TransferProject(t.Context(), &warnly.TransferProjectRequest{
+		User:      user,
+		ProjectID: projectID,
+		TeamID:    toTeamID,
+	})
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result.Projects, 2)
-	assert.Len(t, result.Teams, 1)
-	assert.Equal(t, "Project 1", result.Projects[0].Name)
-	assert.Equal(t, "Project 2", result.Projects[1].Name)
+
+	assert.Equal(t, toTeamID, movedTo)
+	// bob isn't a member of the new team, neither are issues assigned to the old team.
+	assert.Equal(t, []warnly.CodeOwner{{Pattern: "app/auth/**", UserID: 3}}, owners)
+	assert.Equal(t, []int64{2, 3}, unassigned)
+	assert.Equal(t, 2, activities)
 }
 
-func TestListProjectsNoProjects(t *testing.T) {
+func TestTransferProjectRejectsForeignTeam(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	teamID := 10
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: 10, Name: "Team A", Role: warnly.RoleAdmin}}, nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+		},
+	}
+
+	// the project isn't moved, the mocks would panic otherwise.
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
 
+	err := svc.TransferProject(t.Context(), &warnly.TransferProjectRequest{
+		User:      &warnly.User{ID: 1},
+		ProjectID: 5,
+		TeamID:    20,
+	})
+	require.ErrorIs(t, err, warnly.ErrTeamNotFound)
+}
+
+func TestRotateAndRevokeKeys(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	const projectID = 5
+
+	role := warnly.RoleAdmin
+	keys := []warnly.ProjectKey{{ID: 1, ProjectID: projectID, Key: "oldkey1", Primary: true}}
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+			return &warnly.Project{ID: id, TeamID: 10, Name: "Test Project"}, nil
+		},
+		ListKeysFn: func(_ context.Context, _ int) ([]warnly.ProjectKey, error) {
+			return slices.Clone(keys), nil
+		},
+		AddKeyFn: func(_ context.Context, key *warnly.ProjectKey) error {
+			key.ID = int64(len(keys) + 1)
+			keys = append(keys, warnly.ProjectKey{ID: key.ID, ProjectID: key.ProjectID, Key: key.Key})
+			return nil
+		},
+		UpdatePrimaryKeyFn: func(_ context.Context, _ int, key string) error {
+			for i := range keys {
+				keys[i].Primary = keys[i].Key == key
+			}
+			return nil
+		},
+		RevokeKeyFn: func(_ context.Context, _ int, keyID int64, at time.Time) error {
+			keys[keyID-1].RevokedAt = &at
+			return nil
+		},
+	}
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+			return []warnly.Team{{ID: 10, Name: "Team A", Role: role}}, nil
 		},
 	}
+	uw := &mock.UnitOfWork{ProjectStore: projectStore}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		UnitOfWork:   uw.Start,
+		Now:          func() time.Time { return now },
+	})
+	user := &warnly.User{ID: 1}
+	var forgotten []string
+	svc.SetIngestCache(&mock.IngestCache{
+		ForgetProjectKeyFn: func(_ int, key string) { forgotten = append(forgotten, key) },
+	})
+
+	rotated, err := svc.RotateKey(t.Context(), &warnly.RotateProjectKeyRequest{
+		User:        user,
+		ProjectID:   projectID,
+		GracePeriod: 24 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.True(t, rotated.Primary)
+	assert.Equal(t, "http://"+rotated.Key+"@localhost:8080/ingest/5", rotated.DSN)
+	require.Len(t, keys, 2)
+	assert.False(t, keys[0].Primary)
+	assert.Equal(t, now.Add(24*time.Hour), *keys[0].RevokedAt, "the previous key is accepted for the grace period")
+	assert.True(t, keys[1].Primary)
+	assert.Nil(t, keys[1].RevokedAt)
+
+	err = svc.RevokeKey(t.Context(), &warnly.RevokeProjectKeyRequest{User: user, ProjectID: projectID, KeyID: rotated.ID})
+	require.ErrorIs(t, err, warnly.ErrPrimaryProjectKey)
+
+	added, err := svc.AddKey(t.Context(), &warnly.ProjectKeysRequest{User: user, ProjectID: projectID})
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeKey(t.Context(), &warnly.RevokeProjectKeyRequest{User: user, ProjectID: projectID, KeyID: added.ID}))
+	assert.Equal(t, now, *keys[2].RevokedAt)
+	assert.Equal(t, []string{"oldkey1", added.Key}, forgotten, "revoked keys are dropped from the ingest cache")
+
+	err = svc.RevokeKey(t.Context(), &warnly.RevokeProjectKeyRequest{User: user, ProjectID: projectID, KeyID: 42})
+	require.ErrorIs(t, err, warnly.ErrProjectKeyNotFound)
+
+	role = warnly.RoleMember
+	_, err = svc.RotateKey(t.Context(), &warnly.RotateProjectKeyRequest{User: user, ProjectID: projectID})
+	require.ErrorIs(t, err, warnly.ErrPermissionDenied)
+	assert.Len(t, keys, 3)
+}
+
+func TestIngestOptionChangesForgetProjectKeys(t *testing.T) {
+	t.Parallel()
 
+	const projectID = 5
+	user := &warnly.User{ID: 1}
 	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{}, nil
+		GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+			return &warnly.Project{ID: id, TeamID: 10, Name: "Test Project"}, nil
+		},
+		ListKeysFn: func(_ context.Context, _ int) ([]warnly.ProjectKey, error) {
+			return []warnly.ProjectKey{
+				{ID: 1, ProjectID: projectID, Key: "primary", Primary: true},
+				{ID: 2, ProjectID: projectID, Key: "added"},
+			}, nil
 		},
+		UpdateIngestSecretFn:  func(context.Context, int, string) error { return nil },
+		UpdateSampleRateFn:    func(context.Context, int, float64) error { return nil },
+		UpdateIngestFiltersFn: func(context.Context, int, *warnly.IngestFilters) error { return nil },
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	tests := []struct {
+		name string
+		call func(svc *project.ProjectService) error
+	}{
+		{
+			name: "signing",
+			call: func(svc *project.ProjectService) error {
+				_, err := svc.SetIngestSigning(t.Context(), &warnly.SetIngestSigningRequest{
+					User:      user,
+					ProjectID: projectID,
+					Enabled:   true,
+				})
+				return err
+			},
+		},
+		{
+			name: "sample rate",
+			call: func(svc *project.ProjectService) error {
+				return svc.SetSampleRate(t.Context(), &warnly.SetSampleRateRequest{User: user, SampleRate: 0.5, ProjectID: projectID})
+			},
+		},
+		{
+			name: "ingest filters",
+			call: func(svc *project.ProjectService) error {
+				return svc.SetIngestFilters(t.Context(), &warnly.SetIngestFiltersRequest{User: user, ProjectID: projectID})
+			},
+		},
+	}
 
-	criteria := &warnly.ListProjectsCriteria{}
-	result, err := svc.ListProjects(ctx, criteria, user)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
 
-	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Empty(t, result.Projects)
-	assert.Len(t, result.Teams, 1)
+			svc := newTestService(testService{
+				ProjectStore: projectStore,
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A", Role: warnly.RoleAdmin}}, nil
+					},
+				},
+			})
+			var forgotten []string
+			svc.SetIngestCache(&mock.IngestCache{
+				ForgetProjectKeyFn: func(_ int, key string) { forgotten = append(forgotten, key) },
+			})
+
+			require.NoError(t, tt.call(svc))
+			assert.Equal(t, []string{"primary", "added"}, forgotten, "the cached options of every key are dropped")
+		})
+	}
 }
 
-func TestListProjectsNoEvents(t *testing.T) {
+func TestGetProjectSuccess(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
 	user := &warnly.User{ID: 1}
+	projectID := 5
 	teamID := 10
 
 	teamStore := &mock.TeamStore{
@@ -338,36 +773,258 @@ func TestListProjectsNoEvents(t *testing.T) {
 	}
 
 	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{
-				{ID: 1, TeamID: teamID, Name: "Project 1"},
-			}, nil
-		},
-	}
-
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:     projectID,
+				TeamID: teamID,
+				Name:   "Test Project",
+			}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
+
+	result, err := svc.GetProject(ctx, projectID, user)
+
+	require.NoError(t, err)
+	assert.Equal(t, projectID, result.ID)
+	assert.Equal(t, teamID, result.TeamID)
+	assert.Equal(t, "Test Project", result.Name)
+}
+
+func TestReadOnlyRejectsMutations(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	projectID := 5
+	teamID := 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A", Role: warnly.RoleAdmin},
+			}, nil
+		},
+	}
+
+	created := 0
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:     projectID,
+				TeamID: teamID,
+				Name:   "Test Project",
+			}, nil
+		},
+		CreateProjectFn: func(_ context.Context, proj *warnly.Project) error {
+			created++
+			proj.ID = projectID
+			return nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		UnitOfWork:   (&mock.UnitOfWork{ProjectStore: projectStore}).Start,
+		Options:      project.Options{ReadOnly: true},
+	})
+	require.True(t, svc.ReadOnly())
+
+	createReq := &warnly.CreateProjectRequest{ProjectName: "Test Project", TeamID: teamID, Platform: "go"}
+
+	_, err := svc.CreateProject(ctx, createReq, user)
+	require.ErrorIs(t, err, warnly.ErrReadOnly)
+	assert.Zero(t, created)
+
+	err = svc.DeleteProject(ctx, projectID, user)
+	require.ErrorIs(t, err, warnly.ErrReadOnly)
+
+	_, err = svc.CreateMessage(ctx, &warnly.CreateMessageRequest{
+		User: user, Content: "hello", ProjectID: projectID, IssueID: 1,
+	})
+	require.ErrorIs(t, err, warnly.ErrReadOnly)
+
+	err = svc.AssignIssue(ctx, &warnly.AssignIssueRequest{User: user, IssueID: 1, ProjectID: projectID, UserID: 2})
+	require.ErrorIs(t, err, warnly.ErrReadOnly)
+
+	result, err := svc.GetProject(ctx, projectID, user)
+	require.NoError(t, err)
+	assert.Equal(t, projectID, result.ID)
+
+	svc.SetReadOnly(false)
+
+	_, err = svc.CreateProject(ctx, createReq, user)
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+}
+
+func TestSampleIngestCommand(t *testing.T) {
+	t.Parallel()
+
+	const teamID = 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: teamID, Name: "Team A"}}, nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			if projectID == 7 {
+				return &warnly.Project{ID: projectID, TeamID: teamID + 1, Key: "f00dcafe"}, nil
+			}
+			return &warnly.Project{ID: projectID, TeamID: teamID, Key: "0c2e1f7a"}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:  projectStore,
+		TeamStore:     teamStore,
+		PublicBaseURL: "warnly.example.com",
+		PublicScheme:  "https",
+	})
+
+	cmd, err := svc.SampleIngestCommand(t.Context(), 5, &warnly.User{ID: 1})
+	require.NoError(t, err)
+	assert.Contains(t, cmd, `{"dsn":"https://0c2e1f7a@warnly.example.com/ingest/5"}`)
+	assert.Contains(t, cmd, "curl -X POST 'https://warnly.example.com/ingest/api/5/envelope/'")
+	assert.Contains(t, cmd, "sentry_key=0c2e1f7a")
+	assert.Contains(t, cmd, `{"type":"event"}`)
+
+	_, err = svc.SampleIngestCommand(t.Context(), 7, &warnly.User{ID: 1})
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound, "the project belongs to another team")
+}
+
+func TestListProjectsSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{
+				{ID: 1, TeamID: teamID, Name: "Project 1"},
+				{ID: 2, TeamID: teamID, Name: "Project 2"},
+			}, nil
+		},
+	}
+
+	analyticsStore := &mock.AnalyticsStore{
+		CalculateEventsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error) {
+			return []warnly.EventsPerHour{
+				{ProjectID: 1, Count: 10},
+				{ProjectID: 2, Count: 20},
+			}, nil
+		},
+		CountUniqueUsersFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+			assert.Equal(t, []int{1, 2}, c.ProjectIDs)
+			return []warnly.ProjectUsers{{ProjectID: 2, Count: 7}}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		AnalyticsStore: analyticsStore,
+	})
+
+	criteria := &warnly.ListProjectsCriteria{}
+	result, err := svc.ListProjects(ctx, criteria, user)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Len(t, result.Projects, 2)
+	assert.Len(t, result.Teams, 1)
+	assert.Equal(t, "Project 1", result.Projects[0].Name)
+	assert.Equal(t, "Project 2", result.Projects[1].Name)
+	assert.Zero(t, result.Projects[0].UniqueUsers)
+	assert.Equal(t, uint64(7), result.Projects[1].UniqueUsers)
+}
+
+func TestListProjectsNoProjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
+
+	criteria := &warnly.ListProjectsCriteria{}
+	result, err := svc.ListProjects(ctx, criteria, user)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Empty(t, result.Projects)
+	assert.Len(t, result.Teams, 1)
+}
+
+func TestListProjectsNoEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{
+				{ID: 1, TeamID: teamID, Name: "Project 1"},
+			}, nil
+		},
+	}
+
 	analyticsStore := &mock.AnalyticsStore{
 		CalculateEventsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error) {
 			return []warnly.EventsPerHour{}, nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		AnalyticsStore: analyticsStore,
+	})
 
 	criteria := &warnly.ListProjectsCriteria{}
 	result, err := svc.ListProjects(ctx, criteria, user)
@@ -395,23 +1052,9 @@ func TestListTeamsSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		&mock.ProjectStore{},
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		TeamStore: teamStore,
+	})
 
 	result, err := svc.ListTeams(ctx, user)
 
@@ -435,23 +1078,9 @@ func TestListTeamsUserNoTeams(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		&mock.ProjectStore{},
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		TeamStore: teamStore,
+	})
 
 	result, err := svc.ListTeams(ctx, user)
 
@@ -494,7 +1123,8 @@ func TestGetProjectDetailsSuccess(t *testing.T) {
 	}
 
 	issueStore := &mock.IssueStore{
-		ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+		ListIssuesFn: func(_ context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			assert.True(t, criteria.ExcludeIgnored, "ignored issues are left out by default")
 			return []warnly.Issue{
 				{
 					ID:        1,
@@ -505,6 +1135,11 @@ func TestGetProjectDetailsSuccess(t *testing.T) {
 				},
 			}, nil
 		},
+		CountIssuesFn: func(_ context.Context, criteria *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+			assert.True(t, criteria.ExcludeIgnored, "ignored issues aren't counted by default")
+			assert.Equal(t, customTime.Add(-warnly.DefaultNewIssueWindow), criteria.NewSince)
+			return warnly.IssueCounts{All: 1, New: 1}, nil
+		},
 		GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
 			return &warnly.Issue{
 				ID:        1,
@@ -517,12 +1152,20 @@ func TestGetProjectDetailsSuccess(t *testing.T) {
 	}
 
 	analyticsStore := &mock.AnalyticsStore{
-		CalculateEventsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error) {
-			return []warnly.EventsPerHour{
-				{ProjectID: projectID, Count: 10},
+		CalculateEventsByEnvFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+			assert.Equal(t, []int{projectID}, c.ProjectIDs)
+			return []warnly.EventsPerEnvHour{
+				{ProjectID: projectID, Env: "production", Count: 3},
+				{ProjectID: projectID, Env: "staging", Count: 7},
 			}, nil
 		},
-		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+		CountUniqueUsersFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+			assert.Equal(t, []int{projectID}, c.ProjectIDs)
+			return []warnly.ProjectUsers{{ProjectID: projectID, Count: 4}}, nil
+		},
+		ListIssueMetricsFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			assert.Nil(t, c.After)
+			assert.Equal(t, warnly.PageSize+1, c.Limit, "the first page is limited by the analytics store")
 			return []warnly.IssueMetrics{
 				{
 					GID:       1,
@@ -543,23 +1186,14 @@ func TestGetProjectDetailsSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		messageStore,
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		customTimeFunc,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		MessageStore:   messageStore,
+		AnalyticsStore: analyticsStore,
+		Now:            customTimeFunc,
+	})
 
 	req := &warnly.ProjectDetailsRequest{
 		ProjectID: projectID,
@@ -574,75 +1208,544 @@ func TestGetProjectDetailsSuccess(t *testing.T) {
 	assert.NotNil(t, result.Project)
 	assert.Equal(t, projectID, result.Project.ID)
 	assert.Equal(t, "Test Project", result.Project.Name)
-	assert.NotEmpty(t, result.Project.Events)
+	assert.Equal(t, warnly.EventsList{{ProjectID: projectID, Count: 10}}, result.Project.Events,
+		"the hourly counts are summed from the environments")
 	assert.Equal(t, 1, result.Project.AllLength)
+	assert.Equal(t, 1, result.Project.NewLength)
+	assert.Empty(t, result.NextCursor)
+	assert.Equal(t, uint64(4), result.Project.UniqueUsers)
+	assert.Equal(t, []warnly.EnvTotal{{Env: "staging", Count: 7}, {Env: "production", Count: 3}}, result.EventsByEnv.Totals())
 }
 
-func TestGetProjectDetailsNoIssues(t *testing.T) {
+func TestGetProjectDetailsLevels(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	projectID := 5
-	teamID := 10
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const projectID = 5
 
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+			return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
 		},
 		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
 			return []warnly.Teammate{}, nil
 		},
 	}
-
 	projectStore := &mock.ProjectStore{
 		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
-			return &warnly.Project{
-				ID:     projectID,
-				TeamID: teamID,
-				Name:   "Test Project",
-			}, nil
+			return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
 		},
 	}
-
 	issueStore := &mock.IssueStore{
 		ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
-			return []warnly.Issue{}, nil
+			return []warnly.Issue{
+				{ID: 1, ProjectID: projectID, ErrorType: "TypeError", Message: "connection refused"},
+				{ID: 2, ProjectID: projectID, Message: "slow query"},
+			}, nil
+		},
+		CountIssuesFn: func(_ context.Context, _ *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+			return warnly.IssueCounts{All: 2}, nil
 		},
 	}
-
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
-
-	req := &warnly.ProjectDetailsRequest{
-		ProjectID: projectID,
-		Issues:    warnly.IssuesTypeAll,
-		Period:    "24h",
+	// the events of issue 1 are errors and fatals, the events of issue 2 are warnings.
+	eventLevels := map[uint64][]warnly.Level{
+		1: {warnly.LevelError, warnly.LevelError, warnly.LevelFatal},
+		2: {warnly.LevelWarning, warnly.LevelWarning},
 	}
-
+	analyticsStore := &mock.AnalyticsStore{
+		CalculateEventsByEnvFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+			return nil, nil
+		},
+		CountUniqueUsersFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+			return nil, nil
+		},
+		ListIssueMetricsFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			var metrics []warnly.IssueMetrics
+			for _, gid := range []uint64{1, 2} {
+				m := warnly.IssueMetrics{GID: gid, FirstSeen: now.Add(-time.Hour), LastSeen: now}
+				for _, level := range eventLevels[gid] {
+					if len(c.Levels) == 0 || slices.Contains(c.Levels, warnly.LevelName(level)) {
+						m.TimesSeen++
+						m.Level = level
+					}
+				}
+				if m.TimesSeen > 0 {
+					metrics = append(metrics, m)
+				}
+			}
+			return metrics, nil
+		},
+	}
+	messageStore := &mock.MessageStore{
+		CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+			return nil, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		MessageStore:   messageStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return now },
+	})
+
+	result, err := svc.GetProjectDetails(t.Context(), &warnly.ProjectDetailsRequest{
+		ProjectID: projectID,
+		Period:    "24h",
+	}, &warnly.User{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, warnly.DefaultIssueLevels, result.Levels)
+	assert.Equal(t, warnly.IssueLevelViews[0].Levels, result.LevelsParam())
+	require.Len(t, result.Project.IssueList, 1, "warnings are hidden by default")
+	assert.Equal(t, int64(1), result.Project.IssueList[0].ID)
+
+	result, err = svc.GetProjectDetails(t.Context(), &warnly.ProjectDetailsRequest{
+		ProjectID: projectID,
+		Period:    "24h",
+		Levels:    warnly.ParseLevels("warning"),
+	}, &warnly.User{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "warning", result.LevelsParam())
+	assert.False(t, result.HasLevelView())
+	require.Len(t, result.Project.IssueList, 1)
+	assert.Equal(t, int64(2), result.Project.IssueList[0].ID)
+	assert.Equal(t, "warning", result.Project.IssueList[0].Level)
+	assert.Equal(t, uint64(2), result.Project.IssueList[0].TimesSeen)
+}
+
+func TestGetProjectDetailsNewIssues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	const projectID = 5
+
+	// issue 1 is 3 days old, issue 2 was first seen an hour ago.
+	issues := []warnly.Issue{
+		{ID: 1, ProjectID: projectID, ErrorType: "TypeError", FirstSeen: now.Add(-72 * time.Hour)},
+		{ID: 2, ProjectID: projectID, ErrorType: "KeyError", FirstSeen: now.Add(-time.Hour)},
+	}
+
+	newService := func(window time.Duration) *project.ProjectService {
+		return newTestService(testService{
+			ProjectStore: &mock.ProjectStore{
+				GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+					return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+				},
+			},
+			TeamStore: &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+				},
+				ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+					return []warnly.Teammate{}, nil
+				},
+			},
+			IssueStore: &mock.IssueStore{
+				ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+					return issues, nil
+				},
+			},
+			MessageStore: &mock.MessageStore{
+				CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+					return nil, nil
+				},
+			},
+			AnalyticsStore: &mock.AnalyticsStore{
+				CalculateEventsByEnvFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+					return nil, nil
+				},
+				CountUniqueUsersFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+					return nil, nil
+				},
+				// both issues have events within the queried period only.
+				ListIssueMetricsFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return []warnly.IssueMetrics{
+						{GID: 1, TimesSeen: 2, FirstSeen: c.From.Add(time.Hour), LastSeen: now},
+						{GID: 2, TimesSeen: 1, FirstSeen: now.Add(-time.Hour), LastSeen: now},
+					}, nil
+				},
+			},
+			Options: project.Options{NewIssueWindow: window},
+			Now:     func() time.Time { return now },
+		})
+	}
+
+	tests := []struct {
+		name        string
+		window      time.Duration
+		wantNew     map[int64]bool
+		wantNewList []int64
+	}{
+		{
+			name:        "default window",
+			wantNew:     map[int64]bool{1: true, 2: true},
+			wantNewList: []int64{1, 2},
+		},
+		{
+			name:        "window shorter than the issue age",
+			window:      48 * time.Hour,
+			wantNew:     map[int64]bool{1: false, 2: true},
+			wantNewList: []int64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := newService(tt.window).GetProjectDetails(t.Context(), &warnly.ProjectDetailsRequest{
+				ProjectID: projectID,
+				Issues:    warnly.IssuesTypeNew,
+				Period:    "24h",
+			}, &warnly.User{ID: 1})
+			require.NoError(t, err)
+
+			require.Len(t, result.Project.IssueList, 2)
+			for _, issue := range result.Project.IssueList {
+				assert.Equal(t, tt.wantNew[issue.ID], issue.IsNew, "issue %d is new overall", issue.ID)
+				assert.Equal(t, issue.ID == 2, issue.NewInPeriod, "only issue %d is first seen within 24h", 2)
+			}
+
+			newIDs := make([]int64, 0, len(result.Project.NewIssueList))
+			for _, issue := range result.Project.NewIssueList {
+				newIDs = append(newIDs, issue.ID)
+			}
+			assert.ElementsMatch(t, tt.wantNewList, newIDs)
+		})
+	}
+}
+
+func TestGetProjectDetailsPageSize(t *testing.T) {
+	t.Parallel()
+
+	const projectID = 5
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	issues := make([]warnly.Issue, 7)
+	metrics := make([]warnly.IssueMetrics, len(issues))
+	for i := range issues {
+		issues[i] = warnly.Issue{
+			ID:        int64(i + 1),
+			ProjectID: projectID,
+			ErrorType: "TypeError",
+			Message:   fmt.Sprintf("error %d", i+1),
+			FirstSeen: now.Add(-time.Duration(i+1) * time.Hour),
+		}
+		metrics[i] = warnly.IssueMetrics{
+			GID:       uint64(i + 1),
+			TimesSeen: 1,
+			FirstSeen: issues[i].FirstSeen,
+			LastSeen:  issues[i].FirstSeen,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		opts      project.Options
+		page      int
+		wantLen   int
+		wantFirst int64
+	}{
+		{name: "default page size", page: 2, wantLen: 2, wantFirst: 6},
+		{name: "custom page size", opts: project.Options{PageSize: 3}, page: 1, wantLen: 3, wantFirst: 1},
+		{name: "custom page size, middle page", opts: project.Options{PageSize: 3}, page: 2, wantLen: 3, wantFirst: 4},
+		{name: "custom page size, last page", opts: project.Options{PageSize: 3}, page: 3, wantLen: 1, wantFirst: 7},
+		{name: "custom page size, past the end", opts: project.Options{PageSize: 3}, page: 4, wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			teamStore := &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+				},
+				ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+					return []warnly.Teammate{}, nil
+				},
+			}
+			projectStore := &mock.ProjectStore{
+				GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+					return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+					return issues, nil
+				},
+				CountIssuesFn: func(_ context.Context, _ *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+					return warnly.IssueCounts{All: len(issues)}, nil
+				},
+			}
+			analyticsStore := &mock.AnalyticsStore{
+				CalculateEventsByEnvFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+					return nil, nil
+				},
+				CountUniqueUsersFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+					return nil, nil
+				},
+				ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return metrics, nil
+				},
+			}
+			messageStore := &mock.MessageStore{
+				CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+					return []warnly.MessageCount{}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore:   projectStore,
+				TeamStore:      teamStore,
+				IssueStore:     issueStore,
+				MessageStore:   messageStore,
+				AnalyticsStore: analyticsStore,
+				Options:        tt.opts,
+				Now:            func() time.Time { return now },
+			})
+
+			result, err := svc.GetProjectDetails(t.Context(), &warnly.ProjectDetailsRequest{
+				ProjectID: projectID,
+				Issues:    warnly.IssuesTypeAll,
+				Period:    "24h",
+				Page:      tt.page,
+			}, &warnly.User{ID: 1})
+			require.NoError(t, err)
+
+			assert.Equal(t, len(issues), result.Project.AllLength)
+			require.Len(t, result.Project.IssueList, tt.wantLen)
+			if tt.wantLen > 0 {
+				assert.Equal(t, tt.wantFirst, result.Project.IssueList[0].ID)
+			}
+		})
+	}
+}
+
+func TestGetProjectDetailsCursorPages(t *testing.T) {
+	t.Parallel()
+
+	const (
+		projectID = 5
+		pageSize  = 3
+	)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// issues tie on times seen and on last seen, so that the whole keyset is needed to order them.
+	issues := make([]warnly.Issue, 11)
+	metrics := make([]warnly.IssueMetrics, len(issues))
+	for i := range issues {
+		issues[i] = warnly.Issue{ID: int64(i + 1), ProjectID: projectID, FirstSeen: now.Add(-48 * time.Hour)}
+		metrics[i] = warnly.IssueMetrics{
+			GID:       uint64(i + 1),
+			TimesSeen: uint64(10 - i/4),
+			FirstSeen: now.Add(-2 * time.Hour),
+			LastSeen:  now.Add(-time.Duration(i%2) * time.Minute),
+		}
+	}
+	want := make([]int64, 0, len(issues))
+	{
+		entries := make([]warnly.IssueEntry, len(metrics))
+		for i := range metrics {
+			entries[i] = warnly.IssueEntry{ID: int64(metrics[i].GID), TimesSeen: metrics[i].TimesSeen, LastSeen: metrics[i].LastSeen}
+		}
+		slices.SortFunc(entries, warnly.CompareIssueEntries)
+		for i := range entries {
+			want = append(want, entries[i].ID)
+		}
+	}
+
+	// the last issue of the first page is ignored, so that the page is short of an issue
+	// but the list goes on past it.
+	ignored := want[pageSize-1]
+	want = slices.DeleteFunc(want, func(id int64) bool { return id == ignored })
+
+	issueStore := &mock.IssueStore{
+		ListIssuesFn: func(_ context.Context, c *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			var res []warnly.Issue
+			for i := range issues {
+				if issues[i].ID != ignored && (len(c.GroupIDs) == 0 || slices.Contains(c.GroupIDs, issues[i].ID)) {
+					res = append(res, issues[i])
+				}
+			}
+			return res, nil
+		},
+		CountIssuesFn: func(_ context.Context, _ *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+			return warnly.IssueCounts{All: len(issues) - 1}, nil
+		},
+	}
+	var keysetQueries int
+	analyticsStore := &mock.AnalyticsStore{
+		CalculateEventsByEnvFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+			return nil, nil
+		},
+		CountUniqueUsersFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+			return nil, nil
+		},
+		// the keyset predicate and the limit the analytics store pushes down.
+		ListIssueMetricsFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			if c.Limit == 0 {
+				return metrics, nil
+			}
+			keysetQueries++
+			assert.Empty(t, c.GroupIDs)
+			assert.Equal(t, pageSize+1, c.Limit)
+			res := slices.Clone(metrics)
+			slices.SortFunc(res, func(a, b warnly.IssueMetrics) int {
+				return warnly.CompareIssueEntries(
+					warnly.IssueEntry{ID: int64(a.GID), TimesSeen: a.TimesSeen, LastSeen: a.LastSeen},
+					warnly.IssueEntry{ID: int64(b.GID), TimesSeen: b.TimesSeen, LastSeen: b.LastSeen})
+			})
+			if c.After != nil {
+				after := warnly.IssueEntry{ID: c.After.ID, TimesSeen: c.After.TimesSeen, LastSeen: c.After.LastSeen}
+				res = slices.DeleteFunc(res, func(m warnly.IssueMetrics) bool {
+					return warnly.CompareIssueEntries(
+						warnly.IssueEntry{ID: int64(m.GID), TimesSeen: m.TimesSeen, LastSeen: m.LastSeen}, after) <= 0
+				})
+			}
+			return res[:min(len(res), c.Limit)], nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+				return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+			},
+			ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+				return []warnly.Teammate{}, nil
+			},
+		},
+		IssueStore: issueStore,
+		MessageStore: &mock.MessageStore{
+			CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+				return []warnly.MessageCount{}, nil
+			},
+		},
+		AnalyticsStore: analyticsStore,
+		Options:        project.Options{PageSize: pageSize},
+		Now:            func() time.Time { return now },
+	})
+
+	var (
+		got    []int64
+		cursor string
+	)
+	for page := 1; ; page++ {
+		require.LessOrEqual(t, page, len(issues), "the pages don't end")
+		result, err := svc.GetProjectDetails(t.Context(), &warnly.ProjectDetailsRequest{
+			ProjectID: projectID,
+			Issues:    warnly.IssuesTypeAll,
+			Period:    "24h",
+			Page:      page,
+			Cursor:    cursor,
+		}, &warnly.User{ID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, len(issues)-1, result.Project.AllLength)
+		assert.LessOrEqual(t, len(result.Project.ResultIssueList), pageSize)
+		for i := range result.Project.ResultIssueList {
+			got = append(got, result.Project.ResultIssueList[i].ID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	assert.Equal(t, want, got, "every issue is listed once and in order")
+	assert.Equal(t, 4, keysetQueries, "every page is listed with the keyset")
+
+	_, err := svc.GetProjectDetails(t.Context(), &warnly.ProjectDetailsRequest{
+		ProjectID: projectID,
+		Issues:    warnly.IssuesTypeAll,
+		Cursor:    "not a cursor",
+	}, &warnly.User{ID: 1})
+	require.ErrorIs(t, err, warnly.ErrInvalidIssueCursor)
+}
+
+func TestGetProjectDetailsNoIssues(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	projectID := 5
+	teamID := 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:     projectID,
+				TeamID: teamID,
+				Name:   "Test Project",
+			}, nil
+		},
+	}
+
+	issueStore := &mock.IssueStore{
+		ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			return []warnly.Issue{}, nil
+		},
+		CountIssuesFn: func(_ context.Context, _ *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+			return warnly.IssueCounts{}, nil
+		},
+	}
+
+	analyticsStore := &mock.AnalyticsStore{
+		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			return nil, nil
+		},
+		CalculateEventsByEnvFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+			return nil, nil
+		},
+		CountUniqueUsersFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+			return nil, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		IssueStore:   issueStore,
+		MessageStore: &mock.MessageStore{
+			CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+				return nil, nil
+			},
+		},
+		AnalyticsStore: analyticsStore,
+	})
+
+	req := &warnly.ProjectDetailsRequest{
+		ProjectID: projectID,
+		Issues:    warnly.IssuesTypeAll,
+		Period:    "24h",
+	}
+
 	result, err := svc.GetProjectDetails(ctx, req, user)
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, projectID, result.Project.ID)
 	assert.Equal(t, 0, result.Project.AllLength)
+	assert.Empty(t, result.Project.IssueList)
+	assert.Empty(t, result.NextCursor)
 }
 
 func TestGetProjectDetailsWithTeammates(t *testing.T) {
@@ -679,13 +1782,17 @@ func TestGetProjectDetailsWithTeammates(t *testing.T) {
 				},
 			}, nil
 		},
+		CountIssuesFn: func(_ context.Context, _ *warnly.CountIssuesCriteria) (warnly.IssueCounts, error) {
+			return warnly.IssueCounts{All: 1, New: 1}, nil
+		},
 	}
 
 	analyticsStore := &mock.AnalyticsStore{
-		CalculateEventsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerHour, error) {
-			return []warnly.EventsPerHour{
-				{ProjectID: projectID, Count: 10},
-			}, nil
+		CalculateEventsByEnvFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.EventsPerEnvHour, error) {
+			return nil, nil
+		},
+		CountUniqueUsersFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.ProjectUsers, error) {
+			return nil, nil
 		},
 		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
 			return []warnly.IssueMetrics{
@@ -732,23 +1839,15 @@ func TestGetProjectDetailsWithTeammates(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		assingmentStore,
-		teamStore2,
-		issueStore,
-		messageStore,
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		customTimeFunc,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assingmentStore,
+		TeamStore:       teamStore2,
+		IssueStore:      issueStore,
+		MessageStore:    messageStore,
+		AnalyticsStore:  analyticsStore,
+		Now:             customTimeFunc,
+	})
 
 	req := &warnly.ProjectDetailsRequest{
 		ProjectID: projectID,
@@ -816,23 +1915,21 @@ func TestGetDiscussionSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		messageStore,
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		IssueStore:   issueStore,
+		MessageStore: messageStore,
+		ActivityStore: &mock.ActivityStore{
+			ListIssueActivityFn: func(_ context.Context, id int64) ([]warnly.IssueActivity, error) {
+				assert.Equal(t, int64(issueID), id)
+				return []warnly.IssueActivity{
+					{Activity: warnly.Activity{Type: warnly.ActivityAssigned, Detail: "John Doe", ActorID: 1}, ActorName: "John Doe"},
+					{Activity: warnly.Activity{Type: warnly.ActivityMessageAdded, ActorID: 1}, ActorName: "John Doe"},
+				}, nil
+			},
+		},
+	})
 
 	req := &warnly.GetDiscussionsRequest{
 		ProjectID: projectID,
@@ -852,6 +1949,8 @@ func TestGetDiscussionSuccess(t *testing.T) {
 	assert.Equal(t, "John Doe", result.Teammates[0].Name)
 	assert.Equal(t, "First message", result.Messages[0].Content)
 	assert.Equal(t, "Second message", result.Messages[1].Content)
+	require.Len(t, result.Activity, 2)
+	assert.Equal(t, warnly.ActivityAssigned, result.Activity[0].Type)
 }
 
 func TestGetDiscussionNoMessages(t *testing.T) {
@@ -904,23 +2003,17 @@ func TestGetDiscussionNoMessages(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		messageStore,
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		IssueStore:   issueStore,
+		MessageStore: messageStore,
+		ActivityStore: &mock.ActivityStore{
+			ListIssueActivityFn: func(_ context.Context, _ int64) ([]warnly.IssueActivity, error) {
+				return nil, nil
+			},
+		},
+	})
 
 	req := &warnly.GetDiscussionsRequest{
 		ProjectID: projectID,
@@ -933,6 +2026,7 @@ func TestGetDiscussionNoMessages(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Empty(t, result.Messages)
+	assert.Empty(t, result.Activity)
 	assert.Len(t, result.Teammates, 1)
 }
 
@@ -1021,23 +2115,13 @@ func TestListFieldsSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
 
 	req := &warnly.ListFieldsRequest{
 		ProjectID: projectID,
@@ -1111,23 +2195,13 @@ func TestListFieldsNoFields(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
 
 	req := &warnly.ListFieldsRequest{
 		ProjectID: projectID,
@@ -1217,23 +2291,13 @@ func TestListEventsSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
 
 	req := &warnly.ListEventsRequest{
 		ProjectID: projectID,
@@ -1257,6 +2321,91 @@ func TestListEventsSuccess(t *testing.T) {
 	assert.Equal(t, "event-2", result.Events[1].EventID)
 }
 
+func TestListEventsByUser(t *testing.T) {
+	t.Parallel()
+
+	projectID := 5
+	issueID := 100
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantEmail   string
+		wantID      string
+		wantMessage string
+		wantTags    map[string]warnly.QueryValue
+	}{
+		{
+			name:      "by email",
+			query:     "user:jane@example.com",
+			wantEmail: "jane@example.com",
+			wantTags:  map[string]warnly.QueryValue{},
+		},
+		{
+			name:        "by id with other filters",
+			query:       `user:42 env:production timeout`,
+			wantID:      "42",
+			wantMessage: "timeout",
+			wantTags:    map[string]warnly.QueryValue{"env": {Value: "production"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var counted, listed *warnly.EventCriteria
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+						return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: int64(issueID), ProjectID: projectID, FirstSeen: customTime.Add(-time.Hour)}, nil
+					},
+				},
+				AnalyticsStore: &mock.AnalyticsStore{
+					CountEventsFn: func(_ context.Context, c *warnly.EventCriteria) (uint64, error) {
+						counted = c
+						return 1, nil
+					},
+					ListEventsFn: func(_ context.Context, c *warnly.EventCriteria) ([]warnly.EventEntry, error) {
+						listed = c
+						return []warnly.EventEntry{{EventID: "event-1", UserEmail: "jane@example.com", User: "42"}}, nil
+					},
+					CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+						return []warnly.TagCount{}, nil
+					},
+				},
+				Now: func() time.Time { return customTime },
+			})
+
+			_, err := svc.ListEvents(t.Context(), &warnly.ListEventsRequest{
+				ProjectID: projectID,
+				IssueID:   issueID,
+				Query:     tt.query,
+				User:      &warnly.User{ID: 1},
+			})
+			require.NoError(t, err)
+
+			require.NotNil(t, listed)
+			assert.Same(t, counted, listed, "events are counted and listed with the same criteria")
+			assert.Equal(t, tt.wantEmail, listed.UserEmail)
+			assert.Equal(t, tt.wantID, listed.UserID)
+			assert.Equal(t, tt.wantMessage, listed.Message)
+			assert.Equal(t, tt.wantTags, listed.Tags, "the user isn't filtered as a tag")
+		})
+	}
+}
+
 func TestListEventsNoEvents(t *testing.T) {
 	t.Parallel()
 
@@ -1309,23 +2458,13 @@ func TestListEventsNoEvents(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
 
 	req := &warnly.ListEventsRequest{
 		ProjectID: projectID,
@@ -1341,19 +2480,123 @@ func TestListEventsNoEvents(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, uint64(0), result.TotalEvents)
 	assert.Empty(t, result.Events)
+	assert.Equal(t, 0, result.PageCount())
+	assert.False(t, result.HasNext)
+	assert.False(t, result.HasPrev)
 }
 
-func TestListIssuesSuccess(t *testing.T) {
+func TestListEventsPagination(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	teamID := 10
 	projectID := 5
+	issueID := 100
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	teamStore := &mock.TeamStore{
-		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+	tests := []struct {
+		name        string
+		offset      int
+		totalEvents uint64
+		pageEvents  int
+		hasPrev     bool
+		hasNext     bool
+		currentPage int
+	}{
+		{
+			name:        "first page",
+			offset:      0,
+			totalEvents: 120,
+			pageEvents:  50,
+			hasPrev:     false,
+			hasNext:     true,
+			currentPage: 1,
+		},
+		{
+			name:        "middle page",
+			offset:      50,
+			totalEvents: 120,
+			pageEvents:  50,
+			hasPrev:     true,
+			hasNext:     true,
+			currentPage: 2,
+		},
+		{
+			name:        "last partial page",
+			offset:      100,
+			totalEvents: 120,
+			pageEvents:  20,
+			hasPrev:     true,
+			hasNext:     false,
+			currentPage: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			analyticsStore := &mock.AnalyticsStore{
+				CountEventsFn: func(_ context.Context, _ *warnly.EventCriteria) (uint64, error) {
+					return tt.totalEvents, nil
+				},
+				ListEventsFn: func(_ context.Context, c *warnly.EventCriteria) ([]warnly.EventEntry, error) {
+					assert.Equal(t, tt.offset, c.Offset)
+					assert.Equal(t, 50, c.Limit)
+					return make([]warnly.EventEntry, tt.pageEvents), nil
+				},
+				CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+					return []warnly.TagCount{}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+						return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: int64(issueID), ProjectID: projectID, FirstSeen: customTime.Add(-24 * time.Hour)}, nil
+					},
+				},
+				AnalyticsStore: analyticsStore,
+				Now:            func() time.Time { return customTime },
+			})
+
+			result, err := svc.ListEvents(t.Context(), &warnly.ListEventsRequest{
+				ProjectID: projectID,
+				IssueID:   issueID,
+				Offset:    tt.offset,
+				User:      &warnly.User{ID: 1},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, 50, result.Limit)
+			assert.Equal(t, tt.hasPrev, result.HasPrev)
+			assert.Equal(t, tt.hasNext, result.HasNext)
+			assert.Equal(t, 3, result.PageCount())
+			assert.Equal(t, tt.currentPage, result.CurrentPage())
+			assert.Len(t, result.Events, tt.pageEvents)
+		})
+	}
+}
+
+func TestListIssuesSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+	projectID := 5
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
 			return []warnly.Team{
 				{ID: teamID, Name: "Team A"},
 			}, nil
@@ -1399,6 +2642,9 @@ func TestListIssuesSuccess(t *testing.T) {
 				{GID: 2, TimesSeen: 30, UserCount: 8, FirstSeen: customTime.Add(-12 * time.Hour), LastSeen: customTime},
 			}, nil
 		},
+		EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+			return nil, nil
+		},
 		ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
 			return []warnly.TagCount{
 				{Tag: "browser", Count: 100},
@@ -1416,23 +2662,14 @@ func TestListIssuesSuccess(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		messageStore,
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		MessageStore:   messageStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
 
 	req := &warnly.ListIssuesRequest{
 		User:   user,
@@ -1453,6 +2690,110 @@ func TestListIssuesSuccess(t *testing.T) {
 	assert.Equal(t, 5, result.Issues[0].MessagesCount)
 }
 
+func TestListIssuesMaxIssues(t *testing.T) {
+	t.Parallel()
+
+	const projectID = 5
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// issue n is seen n times, so the most frequent issues come first.
+	issues := make([]warnly.Issue, 8)
+	metrics := make([]warnly.IssueMetrics, len(issues))
+	for i := range issues {
+		issues[i] = warnly.Issue{ID: int64(i + 1), ProjectID: projectID, FirstSeen: now.Add(-time.Hour)}
+		metrics[i] = warnly.IssueMetrics{
+			GID:       uint64(i + 1),
+			TimesSeen: uint64(i + 1),
+			FirstSeen: now.Add(-time.Hour),
+			LastSeen:  now,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		opts        project.Options
+		limit       int
+		offset      int
+		wantIDs     []int64
+		wantHasMore bool
+	}{
+		{name: "no limit is capped by the default", limit: 0, wantIDs: []int64{8, 7, 6, 5, 4, 3, 2, 1}},
+		{name: "limit above the maximum", opts: project.Options{MaxIssues: 3}, limit: 50, wantIDs: []int64{8, 7, 6}, wantHasMore: true},
+		{name: "no limit", opts: project.Options{MaxIssues: 3}, wantIDs: []int64{8, 7, 6}, wantHasMore: true},
+		{name: "limit below the maximum", opts: project.Options{MaxIssues: 3}, limit: 2, wantIDs: []int64{8, 7}, wantHasMore: true},
+		{name: "next page", opts: project.Options{MaxIssues: 3}, limit: 3, offset: 3, wantIDs: []int64{5, 4, 3}, wantHasMore: true},
+		{name: "last page", opts: project.Options{MaxIssues: 3}, limit: 3, offset: 6, wantIDs: []int64{2, 1}},
+		{name: "past the end", opts: project.Options{MaxIssues: 3}, limit: 3, offset: 9, wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			teamStore := &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+				},
+			}
+			projectStore := &mock.ProjectStore{
+				ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+					return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+				},
+			}
+			issueStore := &mock.IssueStore{
+				ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+					return issues, nil
+				},
+			}
+			analyticsStore := &mock.AnalyticsStore{
+				ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return metrics, nil
+				},
+				EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+					return nil, nil
+				},
+				ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+					return []warnly.TagCount{}, nil
+				},
+			}
+			var counted []int64
+			messageStore := &mock.MessageStore{
+				CountMessagesByIDsFn: func(_ context.Context, ids []int64) ([]warnly.MessageCount, error) {
+					counted = ids
+					return []warnly.MessageCount{}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore:   projectStore,
+				TeamStore:      teamStore,
+				IssueStore:     issueStore,
+				MessageStore:   messageStore,
+				AnalyticsStore: analyticsStore,
+				Options:        tt.opts,
+				Now:            func() time.Time { return now },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:   &warnly.User{ID: 1},
+				Period: "24h",
+				Offset: tt.offset,
+				Limit:  tt.limit,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, len(result.Issues))
+			for i := range result.Issues {
+				ids[i] = result.Issues[i].ID
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantIDs, counted, "messages are counted only for the returned issues")
+			assert.Equal(t, len(issues), result.TotalIssues)
+			assert.Equal(t, tt.wantHasMore, result.HasMore)
+		})
+	}
+}
+
 func TestListIssuesNoIssues(t *testing.T) {
 	t.Parallel()
 
@@ -1485,6 +2826,9 @@ func TestListIssuesNoIssues(t *testing.T) {
 	}
 
 	analyticsStore := &mock.AnalyticsStore{
+		EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+			return nil, nil
+		},
 		ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
 			return []warnly.TagCount{
 				{Tag: "browser", Count: 100},
@@ -1492,23 +2836,13 @@ func TestListIssuesNoIssues(t *testing.T) {
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		issueStore,
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
 
 	req := &warnly.ListIssuesRequest{
 		User:   user,
@@ -1525,15 +2859,13 @@ func TestListIssuesNoIssues(t *testing.T) {
 	assert.Equal(t, 0, result.TotalIssues)
 }
 
-func TestDeleteMessageSuccess(t *testing.T) {
+func TestListIssuesUnhandledOnly(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
 	user := &warnly.User{ID: 1}
-	projectID := 5
 	teamID := 10
-	issueID := 100
-	messageID := 42
+	projectID := 5
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	teamStore := &mock.TeamStore{
@@ -1542,270 +2874,789 @@ func TestDeleteMessageSuccess(t *testing.T) {
 				{ID: teamID, Name: "Team A"},
 			}, nil
 		},
-		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
-			return []warnly.Teammate{
-				{ID: 1, Name: "John Doe", Email: "john@example.com"},
-				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
-			}, nil
-		},
 	}
 
 	projectStore := &mock.ProjectStore{
-		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
-			return &warnly.Project{
-				ID:     projectID,
-				TeamID: teamID,
-				Name:   "Test Project",
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{
+				{ID: projectID, TeamID: teamID, Name: "Test Project"},
 			}, nil
 		},
 	}
 
-	messageStore := &mock.MessageStore{
-		DeleteMessageFn: func(_ context.Context, _ int, _ int) error {
-			return nil
-		},
-		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
-			return []warnly.IssueMessage{
-				{
-					ID:        1,
-					UserID:    2,
-					Username:  "Jane Smith",
-					Content:   "First message",
-					CreatedAt: customTime.Add(-2 * time.Hour),
-				},
-				{
-					ID:        2,
-					UserID:    1,
-					Username:  "John Doe",
-					Content:   "Second message",
-					CreatedAt: customTime.Add(-1 * time.Hour),
-				},
-			}, nil
-		},
+	allIssues := []warnly.Issue{
+		{ID: 1, ProjectID: projectID, ErrorType: "panic", Message: "runtime error: index out of range"},
+		{ID: 2, ProjectID: projectID, ErrorType: "*errors.errorString", Message: "record not found"},
 	}
 
-	mentionStore := &mock.MentionStore{
-		DeleteMentionsFn: func(_ context.Context, _ int) error {
-			return nil
+	issueStore := &mock.IssueStore{
+		ListIssuesFn: func(_ context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			if len(criteria.GroupIDs) == 0 {
+				return allIssues, nil
+			}
+			issues := []warnly.Issue{}
+			for i := range allIssues {
+				if slices.Contains(criteria.GroupIDs, allIssues[i].ID) {
+					issues = append(issues, allIssues[i])
+				}
+			}
+			return issues, nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		messageStore,
-		mentionStore,
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	unhandledCalled := false
+	analyticsStore := &mock.AnalyticsStore{
+		ListUnhandledGroupIDsFn: func(_ context.Context, _, _ time.Time, projectIDs []int) ([]int64, error) {
+			unhandledCalled = true
+			assert.Equal(t, []int{projectID}, projectIDs)
+			return []int64{1}, nil
+		},
+		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			return []warnly.IssueMetrics{
+				{GID: 1, TimesSeen: 3, UserCount: 1, LastSeen: customTime},
+				{GID: 2, TimesSeen: 40, UserCount: 7, LastSeen: customTime},
+			}, nil
+		},
+		EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+			return nil, nil
+		},
+		ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+			return []warnly.TagCount{}, nil
+		},
+	}
 
-	req := &warnly.DeleteMessageRequest{
-		User:      user,
-		ProjectID: projectID,
-		IssueID:   issueID,
-		MessageID: messageID,
+	messageStore := &mock.MessageStore{
+		CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+			return []warnly.MessageCount{}, nil
+		},
 	}
 
-	result, err := svc.DeleteMessage(ctx, req)
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		IssueStore:     issueStore,
+		MessageStore:   messageStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
+
+	result, err := svc.ListIssues(ctx, &warnly.ListIssuesRequest{
+		User:          user,
+		Period:        "24h",
+		UnhandledOnly: true,
+	})
 
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, projectID, result.Info.ProjectID)
-	assert.Equal(t, issueID, result.Info.IssueID)
-	assert.Len(t, result.Teammates, 2)
-	assert.Len(t, result.Messages, 2)
+	require.True(t, unhandledCalled)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, int64(1), result.Issues[0].ID)
+	assert.Equal(t, 1, result.TotalIssues)
 }
 
-func TestCreateMessageSuccess(t *testing.T) {
+func TestListIssuesSortByRelevance(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1, Name: "John Doe"}
 	projectID := 5
-	teamID := 10
-	issueID := 100
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	teamStore := &mock.TeamStore{
-		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+	allIssues := []warnly.Issue{
+		{ID: 1, ProjectID: projectID, ErrorType: "*url.Error", Message: "dial tcp: i/o timeout"},
+		{ID: 2, ProjectID: projectID, ErrorType: "*errors.errorString", Message: "retrying after timeout"},
+		{ID: 3, ProjectID: projectID, ErrorType: "panic", Message: "runtime error: index out of range"},
+	}
+
+	tests := []struct {
+		name string
+		sort string
+		want []int64
+	}{
+		{
+			name: "relevance puts the strong title match first",
+			sort: warnly.IssueSortRelevance,
+			want: []int64{1, 2},
 		},
-		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
-			return []warnly.Teammate{
-				{ID: 1, Name: "John Doe", Email: "john@example.com"},
-				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
-			}, nil
+		{
+			name: "default order is by frequency",
+			sort: "",
+			want: []int64{2, 1},
 		},
 	}
 
-	projectStore := &mock.ProjectStore{
-		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
-			return &warnly.Project{
-				ID:     projectID,
-				TeamID: teamID,
-				Name:   "Test Project",
-			}, nil
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var filterTokens []warnly.QueryToken
+			analyticsStore := &mock.AnalyticsStore{
+				SearchIssuesFn: func(_ context.Context, query string, c *warnly.SearchIssuesCriteria) ([]warnly.IssueMatch, error) {
+					assert.Equal(t, "timeout", query)
+					assert.False(t, c.Phrase)
+					assert.Equal(t, []int{projectID}, c.ProjectIDs)
+					return []warnly.IssueMatch{{GID: 1, Score: 3}, {GID: 2, Score: 1}}, nil
+				},
+				GetFilteredGroupIDsFn: func(_ context.Context, tokens []warnly.QueryToken, _, _ time.Time, _ []int) ([]int64, error) {
+					filterTokens = tokens
+					return []int64{1, 2}, nil
+				},
+				ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return []warnly.IssueMetrics{
+						{GID: 1, TimesSeen: 3, LastSeen: customTime},
+						{GID: 2, TimesSeen: 40, LastSeen: customTime},
+						{GID: 3, TimesSeen: 90, LastSeen: customTime},
+					}, nil
+				},
+				EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+					return nil, nil
+				},
+				ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+					return []warnly.TagCount{}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+						return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					ListIssuesFn: func(_ context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+						issues := []warnly.Issue{}
+						for i := range allIssues {
+							if slices.Contains(criteria.GroupIDs, allIssues[i].ID) {
+								issues = append(issues, allIssues[i])
+							}
+						}
+						return issues, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+						return []warnly.MessageCount{}, nil
+					},
+				},
+				AnalyticsStore: analyticsStore,
+				Now:            func() time.Time { return customTime },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:   &warnly.User{ID: 1},
+				Period: "24h",
+				Query:  "timeout env:prod",
+				Sort:   tt.sort,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(result.Issues))
+			for i := range result.Issues {
+				ids = append(ids, result.Issues[i].ID)
+			}
+			assert.Equal(t, tt.want, ids)
+
+			if tt.sort == warnly.IssueSortRelevance {
+				assert.Equal(t, []warnly.QueryToken{{Key: "env", Operator: "is", Value: "prod"}}, filterTokens,
+					"search text is matched by the full-text search, tags by the filter")
+			}
+		})
+	}
+}
+
+func TestListIssuesSortByUsers(t *testing.T) {
+	t.Parallel()
+
+	projectID := 5
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		sort string
+		want []int64
+	}{
+		{
+			name: "default order is by events",
+			sort: "",
+			want: []int64{1, 2, 3},
+		},
+		{
+			name: "users order puts the issue affecting more users first",
+			sort: warnly.IssueSortUsers,
+			want: []int64{2, 1, 3},
 		},
 	}
 
-	messageStore := &mock.MessageStore{
-		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
-			return []warnly.IssueMessage{
-				{
-					ID:        1,
-					UserID:    1,
-					Username:  "John Doe",
-					Content:   "Test message",
-					CreatedAt: customTime,
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+						return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+					},
 				},
-			}, nil
-		},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+						return []warnly.Issue{
+							{ID: 1, ProjectID: projectID, ErrorType: "TimeoutError", Message: "upstream timed out"},
+							{ID: 2, ProjectID: projectID, ErrorType: "TypeError", Message: "cart is undefined"},
+							{ID: 3, ProjectID: projectID, ErrorType: "RangeError", Message: "invalid array length"},
+						}, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+						return []warnly.MessageCount{}, nil
+					},
+				},
+				AnalyticsStore: &mock.AnalyticsStore{
+					ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+						// a retry loop of a single user floods issue 1, issue 2 hits many users a few times.
+						return []warnly.IssueMetrics{
+							{GID: 1, TimesSeen: 500, UserCount: 1, LastSeen: customTime},
+							{GID: 2, TimesSeen: 40, UserCount: 35, LastSeen: customTime},
+							{GID: 3, TimesSeen: 10, UserCount: 1, LastSeen: customTime},
+						}, nil
+					},
+					EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+						return nil, nil
+					},
+					ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+						return []warnly.TagCount{}, nil
+					},
+				},
+				Now: func() time.Time { return customTime },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:   &warnly.User{ID: 1},
+				Period: "24h",
+				Sort:   tt.sort,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(result.Issues))
+			for i := range result.Issues {
+				ids = append(ids, result.Issues[i].ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
 	}
+}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		messageStore,
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+func TestListIssuesSortByHot(t *testing.T) {
+	t.Parallel()
 
-	req := &warnly.CreateMessageRequest{
-		User:           user,
-		Content:        "<p>This is a valid message</p>",
-		MentionedUsers: []int{2},
-		ProjectID:      projectID,
-		IssueID:        issueID,
+	projectID := 5
+	now := time.Date(2024, 1, 1, 12, 20, 0, 0, time.UTC)
+	hour := func(ago int) time.Time { return now.Truncate(time.Hour).Add(-time.Duration(ago) * time.Hour) }
+
+	tests := []struct {
+		name string
+		sort string
+		want []int64
+	}{
+		{
+			name: "default order is by events",
+			sort: "",
+			want: []int64{1, 2},
+		},
+		{
+			name: "hot order puts the recently spiking issue first",
+			sort: warnly.IssueSortHot,
+			want: []int64{2, 1},
+		},
 	}
 
-	result, err := svc.CreateMessage(ctx, req)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
 
-	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, projectID, result.Info.ProjectID)
-	assert.Equal(t, issueID, result.Info.IssueID)
-	assert.Len(t, result.Teammates, 2)
-	assert.Len(t, result.Messages, 1)
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+						return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+						return []warnly.Issue{
+							{ID: 1, ProjectID: projectID, ErrorType: "TimeoutError", Message: "upstream timed out"},
+							{ID: 2, ProjectID: projectID, ErrorType: "TypeError", Message: "cart is undefined"},
+						}, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+						return []warnly.MessageCount{}, nil
+					},
+				},
+				AnalyticsStore: &mock.AnalyticsStore{
+					ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+						return []warnly.IssueMetrics{
+							{GID: 1, TimesSeen: 500, LastSeen: hour(20)},
+							{GID: 2, TimesSeen: 60, LastSeen: now.Add(-5 * time.Minute)},
+						}, nil
+					},
+					CalculateIssueEventsFn: func(
+						_ context.Context,
+						criteria *warnly.ListIssueMetricsCriteria,
+					) ([]warnly.IssueEventsPerHour, error) {
+						assert.ElementsMatch(t, []int64{1, 2}, criteria.GroupIDs)
+						// issue 1 flooded last night, issue 2 started spiking within the last hours.
+						return []warnly.IssueEventsPerHour{
+							{TS: hour(0), GroupID: 2, Count: 45},
+							{TS: hour(1), GroupID: 2, Count: 15},
+							{TS: hour(20), GroupID: 1, Count: 200},
+							{TS: hour(21), GroupID: 1, Count: 300},
+						}, nil
+					},
+					EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+						return nil, nil
+					},
+					ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+						return []warnly.TagCount{}, nil
+					},
+				},
+				Now: func() time.Time { return now },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:   &warnly.User{ID: 1},
+				Period: "24h",
+				Sort:   tt.sort,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(result.Issues))
+			for i := range result.Issues {
+				ids = append(ids, result.Issues[i].ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
 }
 
-func TestCreateMessageWithMentions(t *testing.T) {
+func TestListIssuesAssignedTo(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1, Name: "John Doe"}
 	projectID := 5
-	teamID := 10
-	issueID := 100
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	teamStore := &mock.TeamStore{
-		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+	tests := []struct {
+		name       string
+		query      string
+		assignedTo string
+		want       []int64
+	}{
+		{
+			name:       "assigned to me",
+			assignedTo: warnly.AssignedToMe,
+			want:       []int64{1},
 		},
-		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
-			return []warnly.Teammate{
-				{ID: 1, Name: "John Doe", Email: "john@example.com"},
-				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
-				{ID: 3, Name: "Bob Johnson", Email: "bob@example.com"},
-			}, nil
+		{
+			name:  "assigned to me in the query",
+			query: "assigned:me",
+			want:  []int64{1},
 		},
-	}
-
-	projectStore := &mock.ProjectStore{
-		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
-			return &warnly.Project{
-				ID:     projectID,
-				TeamID: teamID,
-				Name:   "Test Project",
-			}, nil
+		{
+			name:  "assigned to a teammate by username",
+			query: "assigned:bob",
+			want:  []int64{2},
+		},
+		{
+			name:  "unassigned issues",
+			query: "assigned:none",
+			want:  []int64{4},
 		},
 	}
 
-	messageStore := &mock.MessageStore{
-		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
-			return []warnly.IssueMessage{
-				{
-					ID:        1,
-					UserID:    1,
-					Username:  "John Doe",
-					Content:   "Mentioning @Jane Smith and @Bob Johnson",
-					CreatedAt: customTime,
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+						return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+					},
+				},
+				AssingmentStore: &mock.AssingmentStore{
+					ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+						return []*warnly.AssignedUser{
+							{IssueID: 1, AssignedToUserID: sql.NullInt64{Int64: 1, Valid: true}},
+							{IssueID: 2, AssignedToUserID: sql.NullInt64{Int64: 2, Valid: true}},
+							{IssueID: 3, AssignedToTeamID: sql.NullInt64{Int64: 10, Valid: true}},
+						}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+					ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+						return []warnly.Teammate{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+						return []warnly.Issue{
+							{ID: 1, ProjectID: projectID, ErrorType: "TimeoutError", Message: "upstream timed out"},
+							{ID: 2, ProjectID: projectID, ErrorType: "TypeError", Message: "cart is undefined"},
+							{ID: 3, ProjectID: projectID, ErrorType: "RangeError", Message: "invalid array length"},
+							{ID: 4, ProjectID: projectID, ErrorType: "KeyError", Message: "'user_id'"},
+						}, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+						return []warnly.MessageCount{}, nil
+					},
+				},
+				AnalyticsStore: &mock.AnalyticsStore{
+					ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+						return []warnly.IssueMetrics{
+							{GID: 1, TimesSeen: 40, LastSeen: customTime},
+							{GID: 2, TimesSeen: 30, LastSeen: customTime},
+							{GID: 3, TimesSeen: 20, LastSeen: customTime},
+							{GID: 4, TimesSeen: 10, LastSeen: customTime},
+						}, nil
+					},
+					EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+						return nil, nil
+					},
+					ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+						return []warnly.TagCount{}, nil
+					},
 				},
+				Now: func() time.Time { return customTime },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:       &warnly.User{ID: 1},
+				Period:     "24h",
+				Query:      tt.query,
+				AssignedTo: tt.assignedTo,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(result.Issues))
+			for i := range result.Issues {
+				ids = append(ids, result.Issues[i].ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestListIssuesSparkline(t *testing.T) {
+	t.Parallel()
+
+	projectID := 5
+	customTime := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	var (
+		calls      int
+		seriesGIDs []int64
+	)
+	analyticsStore := &mock.AnalyticsStore{
+		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			return []warnly.IssueMetrics{
+				{GID: 1, TimesSeen: 40, LastSeen: customTime},
+				{GID: 2, TimesSeen: 30, LastSeen: customTime},
+				{GID: 3, TimesSeen: 20, LastSeen: customTime},
+			}, nil
+		},
+		EventSeriesForIssuesFn: func(_ context.Context, gids []int64, from, to time.Time, projectIDs []int) ([]warnly.IssueEventsPerHour, error) {
+			calls++
+			seriesGIDs = gids
+			assert.Equal(t, []int{projectID}, projectIDs)
+			assert.Equal(t, time.Date(2023, 12, 31, 13, 0, 0, 0, time.UTC), from)
+			assert.Equal(t, customTime, to)
+			return []warnly.IssueEventsPerHour{
+				{TS: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), GroupID: 1, Count: 4},
+				{TS: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), GroupID: 1, Count: 6},
+				{TS: time.Date(2023, 12, 31, 13, 0, 0, 0, time.UTC), GroupID: 2, Count: 9},
 			}, nil
 		},
+		ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+			return []warnly.TagCount{}, nil
+		},
 	}
 
-	mentionStore := &mock.MentionStore{
-		CreateMentionsFn: func(_ context.Context, _ []warnly.Mention) error {
-			return nil
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+				return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+				return []warnly.Issue{
+					{ID: 1, ProjectID: projectID, ErrorType: "TimeoutError", Message: "upstream timed out"},
+					{ID: 2, ProjectID: projectID, ErrorType: "TypeError", Message: "cart is undefined"},
+					{ID: 3, ProjectID: projectID, ErrorType: "RangeError", Message: "invalid array length"},
+				}, nil
+			},
 		},
+		MessageStore: &mock.MessageStore{
+			CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+				return []warnly.MessageCount{}, nil
+			},
+		},
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
+
+	result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+		User:   &warnly.User{ID: 1},
+		Period: "24h",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 3)
+	assert.Equal(t, 1, calls)
+	assert.ElementsMatch(t, []int64{1, 2, 3}, seriesGIDs, "the series of all issues are fetched at once")
+
+	sparklines := make(map[int64][]int, len(result.Issues))
+	for _, issue := range result.Issues {
+		require.Len(t, issue.Sparkline, warnly.SparklineHours)
+		sparklines[issue.ID] = issue.Sparkline
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		messageStore,
-		mentionStore,
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	last := warnly.SparklineHours - 1
+	assert.Equal(t, 6, sparklines[1][last])
+	assert.Equal(t, 4, sparklines[1][last-1])
+	assert.Equal(t, 9, sparklines[2][0])
+	assert.Zero(t, sparklines[2][last])
+	assert.Equal(t, make([]int, warnly.SparklineHours), sparklines[3], "an issue without recent events has a flat series")
 
-	req := &warnly.CreateMessageRequest{
-		User:           user,
-		Content:        "<p>Mentioning @Jane Smith and @Bob Johnson</p>",
-		MentionedUsers: []int{2, 3},
-		ProjectID:      projectID,
-		IssueID:        issueID,
+	result, err = svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+		User:   &warnly.User{ID: 1},
+		Period: "24h",
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 2)
+	assert.ElementsMatch(t, []int64{1, 2}, seriesGIDs, "the series are fetched for the issues of the page only")
+}
+
+func TestListIssuesDefaultQuery(t *testing.T) {
+	t.Parallel()
+
+	projectID := 5
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	allIssues := []warnly.Issue{
+		{ID: 1, ProjectID: projectID, ErrorType: "TypeError", Message: "x is undefined"},
+		{ID: 2, ProjectID: projectID, ErrorType: "ReferenceError", Message: "y is not defined"},
 	}
 
-	result, err := svc.CreateMessage(ctx, req)
+	tests := []struct {
+		name          string
+		projectName   string
+		query         string
+		wantQuery     string
+		wantIDs       []int64
+		useDefault    bool
+		wantProjectID int
+	}{
+		{
+			name:          "default query of the project is applied",
+			projectName:   "Test Project",
+			useDefault:    true,
+			wantProjectID: projectID,
+			wantQuery:     "env:production",
+			wantIDs:       []int64{1},
+		},
+		{
+			name:          "default query of all projects is applied",
+			useDefault:    true,
+			wantProjectID: 0,
+			wantQuery:     "env:production",
+			wantIDs:       []int64{1},
+		},
+		{
+			name:        "explicit query overrides the default",
+			projectName: "Test Project",
+			query:       "env:staging",
+			wantQuery:   "env:staging",
+			wantIDs:     []int64{2},
+		},
+		{
+			name:        "explicit empty query overrides the default",
+			projectName: "Test Project",
+			wantIDs:     []int64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+					return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+				},
+				GetDefaultIssuesQueryFn: func(_ context.Context, userID, gotProjectID int) (string, error) {
+					assert.True(t, tt.useDefault, "default query must not be looked up for an explicit query")
+					assert.Equal(t, 1, userID)
+					assert.Equal(t, tt.wantProjectID, gotProjectID)
+					return "env:production", nil
+				},
+			}
+
+			analyticsStore := &mock.AnalyticsStore{
+				GetFilteredGroupIDsFn: func(_ context.Context, tokens []warnly.QueryToken, _, _ time.Time, _ []int) ([]int64, error) {
+					require.Len(t, tokens, 1)
+					if tokens[0].Value == "production" {
+						return []int64{1}, nil
+					}
+					return []int64{2}, nil
+				},
+				ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return []warnly.IssueMetrics{
+						{GID: 1, TimesSeen: 20, LastSeen: customTime},
+						{GID: 2, TimesSeen: 10, LastSeen: customTime},
+					}, nil
+				},
+				EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+					return nil, nil
+				},
+				ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+					return []warnly.TagCount{}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: projectStore,
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					ListIssuesFn: func(_ context.Context, criteria *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+						if len(criteria.GroupIDs) == 0 {
+							return allIssues, nil
+						}
+						issues := []warnly.Issue{}
+						for i := range allIssues {
+							if slices.Contains(criteria.GroupIDs, allIssues[i].ID) {
+								issues = append(issues, allIssues[i])
+							}
+						}
+						return issues, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+						return []warnly.MessageCount{}, nil
+					},
+				},
+				AnalyticsStore: analyticsStore,
+				Now:            func() time.Time { return customTime },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:            &warnly.User{ID: 1},
+				Period:          "24h",
+				ProjectName:     tt.projectName,
+				Query:           tt.query,
+				UseDefaultQuery: tt.useDefault,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(result.Issues))
+			for i := range result.Issues {
+				ids = append(ids, result.Issues[i].ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantQuery, result.Request.Query, "applied query is shown in the search bar")
+		})
+	}
+}
+
+func TestSaveDefaultIssuesQuery(t *testing.T) {
+	t.Parallel()
+
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var saved *warnly.DefaultIssuesQuery
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+				return []warnly.Project{{ID: 5, TeamID: 10, Name: "Test Project"}}, nil
+			},
+			SaveDefaultIssuesQueryFn: func(_ context.Context, query *warnly.DefaultIssuesQuery) error {
+				saved = query
+				return nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+			},
+		},
+		Now: func() time.Time { return customTime },
+	})
+
+	user := &warnly.User{ID: 1}
 
+	err := svc.SaveDefaultIssuesQuery(t.Context(), &warnly.SaveDefaultIssuesQueryRequest{
+		User:        user,
+		ProjectName: "Test Project",
+		Query:       " env:production is:unresolved ",
+	})
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, projectID, result.Info.ProjectID)
-	assert.Equal(t, issueID, result.Info.IssueID)
-	assert.Len(t, result.Teammates, 3)
-	assert.Len(t, result.Messages, 1)
+	assert.Equal(t, &warnly.DefaultIssuesQuery{
+		UpdatedAt: customTime,
+		Query:     "env:production is:unresolved",
+		UserID:    1,
+		ProjectID: 5,
+	}, saved)
+
+	err = svc.SaveDefaultIssuesQuery(t.Context(), &warnly.SaveDefaultIssuesQueryRequest{
+		User:        user,
+		ProjectName: "Unknown",
+		Query:       "env:production",
+	})
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound)
+
+	err = svc.SaveDefaultIssuesQuery(t.Context(), &warnly.SaveDefaultIssuesQueryRequest{
+		User:  user,
+		Query: strings.Repeat("a", warnly.MaxDefaultIssuesQueryLength+1),
+	})
+	require.ErrorIs(t, err, warnly.ErrDefaultIssuesQueryTooLong)
 }
 
-func TestListTagValuesSuccess(t *testing.T) {
+func TestDeleteMessageSuccess(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
 	user := &warnly.User{ID: 1}
+	projectID := 5
 	teamID := 10
+	issueID := 100
+	messageID := 42
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	teamStore := &mock.TeamStore{
@@ -1814,303 +3665,678 @@ func TestListTagValuesSuccess(t *testing.T) {
 				{ID: teamID, Name: "Team A"},
 			}, nil
 		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{
+				{ID: 1, Name: "John Doe", Email: "john@example.com"},
+				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+			}, nil
+		},
 	}
 
 	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{
-				{ID: 1, TeamID: teamID, Name: "Test Project"},
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:     projectID,
+				TeamID: teamID,
+				Name:   "Test Project",
 			}, nil
 		},
 	}
 
-	analyticsStore := &mock.AnalyticsStore{
-		ListTagValuesFn: func(_ context.Context, _ *warnly.ListTagValuesCriteria) ([]warnly.TagValueCount, error) {
-			return []warnly.TagValueCount{
-				{Value: "Chrome", Count: 150},
-				{Value: "Firefox", Count: 80},
-				{Value: "Safari", Count: 45},
+	messageStore := &mock.MessageStore{
+		DeleteMessageFn: func(_ context.Context, _ int, _ int) error {
+			return nil
+		},
+		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
+			return []warnly.IssueMessage{
+				{
+					ID:        1,
+					UserID:    2,
+					Username:  "Jane Smith",
+					Content:   "First message",
+					CreatedAt: customTime.Add(-2 * time.Hour),
+				},
+				{
+					ID:        2,
+					UserID:    1,
+					Username:  "John Doe",
+					Content:   "Second message",
+					CreatedAt: customTime.Add(-1 * time.Hour),
+				},
 			}, nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	mentionStore := &mock.MentionStore{
+		DeleteMentionsFn: func(_ context.Context, _ int) error {
+			return nil
+		},
+	}
 
-	req := &warnly.ListTagValuesRequest{
-		User:   user,
-		Tag:    "browser",
-		Period: "24h",
-		Limit:  10,
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		MessageStore: messageStore,
+		MentionStore: mentionStore,
+		Now:          func() time.Time { return customTime },
+	})
+
+	req := &warnly.DeleteMessageRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+		MessageID: messageID,
 	}
 
-	result, err := svc.ListTagValues(ctx, req)
+	result, err := svc.DeleteMessage(ctx, req)
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 3)
-	assert.Equal(t, "Chrome", result[0].Value)
-	assert.Equal(t, uint64(150), result[0].Count)
-	assert.Equal(t, "Firefox", result[1].Value)
-	assert.Equal(t, uint64(80), result[1].Count)
-	assert.Equal(t, "Safari", result[2].Value)
-	assert.Equal(t, uint64(45), result[2].Count)
+	assert.Equal(t, projectID, result.Info.ProjectID)
+	assert.Equal(t, issueID, result.Info.IssueID)
+	assert.Len(t, result.Teammates, 2)
+	assert.Len(t, result.Messages, 2)
 }
 
-func TestListTagValuesNoResults(t *testing.T) {
+func TestCreateMessageSuccess(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
-	user := &warnly.User{ID: 1}
+	user := &warnly.User{ID: 1, Name: "John Doe"}
+	projectID := 5
 	teamID := 10
+	issueID := 100
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
 			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
+				{ID: teamID, Name: "Team A", Role: warnly.RoleMember},
+			}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{
+				{ID: 1, Name: "John Doe", Email: "john@example.com"},
+				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
 			}, nil
 		},
 	}
 
 	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{
-				{ID: 1, TeamID: teamID, Name: "Test Project"},
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:     projectID,
+				TeamID: teamID,
+				Name:   "Test Project",
 			}, nil
 		},
 	}
 
-	analyticsStore := &mock.AnalyticsStore{
-		ListTagValuesFn: func(_ context.Context, _ *warnly.ListTagValuesCriteria) ([]warnly.TagValueCount, error) {
-			return []warnly.TagValueCount{}, nil
+	messageStore := &mock.MessageStore{
+		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
+			return []warnly.IssueMessage{
+				{
+					ID:        1,
+					UserID:    1,
+					Username:  "John Doe",
+					Content:   "Test message",
+					CreatedAt: customTime,
+				},
+			}, nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: projectID}, nil
+			},
+		},
+		MessageStore: messageStore,
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		Now: func() time.Time { return customTime },
+	})
 
-	req := &warnly.ListTagValuesRequest{
-		User:   user,
-		Tag:    "browser",
-		Period: "24h",
-		Limit:  10,
+	req := &warnly.CreateMessageRequest{
+		User:      user,
+		Content:   "<p>This is a valid message</p>",
+		ProjectID: projectID,
+		IssueID:   issueID,
 	}
 
-	result, err := svc.ListTagValues(ctx, req)
+	result, err := svc.CreateMessage(ctx, req)
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Empty(t, result)
+	assert.Equal(t, projectID, result.Info.ProjectID)
+	assert.Equal(t, issueID, result.Info.IssueID)
+	assert.Len(t, result.Teammates, 2)
+	assert.Len(t, result.Messages, 1)
 }
 
-func TestSearchProjectSuccess(t *testing.T) {
+func TestCreateMessageSanitizerPolicy(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	teamID := 10
-	projectID := 5
-	projectName := "Test Project"
+	const (
+		projectID = 5
+		teamID    = 10
+		issueID   = 100
+		content   = `<p>see <a href="https://ci.example.com/runs/1">the run</a> <img src="https://example.com/x.png"></p>`
+	)
 
-	teamStore := &mock.TeamStore{
-		ListTeamsFn: func(_ context.Context, userID int) ([]warnly.Team, error) {
-			assert.Equal(t, 1, userID)
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+	tests := []struct {
+		name   string
+		policy string
+		want   string
+	}{
+		{
+			name:   "basic policy keeps links but not images",
+			policy: project.SanitizerPolicyBasic,
+			want:   `<p>see <a href="https://ci.example.com/runs/1" rel="nofollow noopener" target="_blank">the run</a> </p>`,
+		},
+		{
+			name:   "strict policy strips links",
+			policy: project.SanitizerPolicyStrict,
+			want:   "see the run ",
 		},
 	}
 
-	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, teamIDs []int, name string) ([]warnly.Project, error) {
-			assert.Len(t, teamIDs, 1)
-			assert.Equal(t, teamID, teamIDs[0])
-			assert.Equal(t, projectName, name)
-			return []warnly.Project{
-				{ID: projectID, TeamID: teamID, Name: projectName},
-			}, nil
-		},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := project.NewSanitizerPolicy(tt.policy)
+			require.NoError(t, err)
+
+			var stored []string
+			messageStore := &mock.MessageStore{
+				CreateMessageFn: func(_ context.Context, m *warnly.Message) error {
+					stored = append(stored, m.Content)
+					return nil
+				},
+				ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
+					return nil, nil
+				},
+			}
+			subscriptionStore := &mock.SubscriptionStore{
+				CreateSubscriptionFn: func(context.Context, *warnly.Subscription) error { return nil },
+				ListSubscribersFn:    func(context.Context, int64) ([]int64, error) { return nil, nil },
+			}
+			uw := &mock.UnitOfWork{
+				MessageStore: messageStore,
+				ActivityStore: &mock.ActivityStore{
+					CreateActivityFn: func(_ context.Context, _ *warnly.Activity) error { return nil },
+				},
+				SubscriptionStore: subscriptionStore,
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+						return &warnly.Project{ID: projectID, TeamID: teamID, Name: "api"}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: teamID, Name: "Team A", Role: warnly.RoleMember}}, nil
+					},
+					ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+						return []warnly.Teammate{{ID: 1, Username: "john"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: id, ProjectID: projectID}, nil
+					},
+				},
+				MessageStore:      messageStore,
+				SubscriptionStore: subscriptionStore,
+				UnitOfWork:        uw.Start,
+				Policy:            policy,
+			})
+
+			user := &warnly.User{ID: 1, Username: "john"}
+			_, err = svc.CreateMessage(t.Context(), &warnly.CreateMessageRequest{
+				User:      user,
+				Content:   content,
+				ProjectID: projectID,
+				IssueID:   issueID,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, stored)
+
+			_, err = svc.CreateMessage(t.Context(), &warnly.CreateMessageRequest{
+				User:      user,
+				Content:   `<img src="https://example.com/x.png">`,
+				ProjectID: projectID,
+				IssueID:   issueID,
+			})
+			require.NoError(t, err)
+			assert.Len(t, stored, 1, "content that sanitizes to empty isn't stored")
+		})
 	}
+}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+func TestNewSanitizerPolicy(t *testing.T) {
+	t.Parallel()
 
-	result, err := svc.SearchProject(ctx, projectName, user)
+	for _, name := range []string{"", project.SanitizerPolicyUGC, project.SanitizerPolicyBasic, project.SanitizerPolicyStrict} {
+		policy, err := project.NewSanitizerPolicy(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "hi", policy.Sanitize("hi<script>alert(1)</script>"), name)
+	}
 
-	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, projectID, result.ID)
-	assert.Equal(t, teamID, result.TeamID)
-	assert.Equal(t, projectName, result.Name)
+	_, err := project.NewSanitizerPolicy("loose")
+	require.Error(t, err)
 }
 
-func TestSearchProjectMultipleProjects(t *testing.T) {
+func TestCreateMessageNotifiesSubscribers(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	teamID := 10
-	projectID := 5
-	projectName := "Backend API"
+	author := &warnly.User{ID: 1, Username: "john"}
+	const (
+		projectID = 5
+		teamID    = 10
+		issueID   = 100
+	)
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
+			return []warnly.Team{{ID: teamID, Name: "Team A", Role: warnly.RoleMember}}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{
+				{ID: 1, Username: "john"},
+				{ID: 2, Username: "jane"},
+				{ID: 3, Username: "bob", Email: "bob@example.com"},
 			}, nil
 		},
 	}
-
 	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{
-				{ID: projectID, TeamID: teamID, Name: "Backend API"},
-				{ID: 6, TeamID: teamID, Name: "Frontend Web"},
-				{ID: 7, TeamID: teamID, Name: "Mobile App"},
-			}, nil
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{ID: projectID, TeamID: teamID, Name: "api"}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: id, ProjectID: projectID, Message: "nil pointer dereference"}, nil
+		},
+	}
+	messageStore := &mock.MessageStore{
+		CreateMessageFn: func(_ context.Context, _ *warnly.Message) error { return nil },
+		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
+			return nil, nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	var subscribed []warnly.Subscription
+	subscriptionStore := &mock.SubscriptionStore{
+		CreateSubscriptionFn: func(_ context.Context, s *warnly.Subscription) error {
+			subscribed = append(subscribed, *s)
+			return nil
+		},
+		ListSubscribersFn: func(_ context.Context, id int64) ([]int64, error) {
+			assert.Equal(t, int64(issueID), id)
+			return []int64{3, 1}, nil
+		},
+	}
+	uw := &mock.UnitOfWork{
+		MessageStore: messageStore,
+		ActivityStore: &mock.ActivityStore{
+			CreateActivityFn: func(_ context.Context, _ *warnly.Activity) error { return nil },
+		},
+		SubscriptionStore: subscriptionStore,
+	}
+	mentionStore := &mock.MentionStore{
+		CreateMentionsFn: func(_ context.Context, _ []warnly.Mention) error { return nil },
+	}
 
-	result, err := svc.SearchProject(ctx, projectName, user)
+	var notified *warnly.IssueCommentedNotification
+	notifier := &mock.IssueNotifier{
+		NotifyIssueCommentedFn: func(_ context.Context, n *warnly.IssueCommentedNotification) error {
+			notified = n
+			return nil
+		},
+	}
 
+	svc := newTestService(testService{
+		ProjectStore:      projectStore,
+		TeamStore:         teamStore,
+		IssueStore:        issueStore,
+		MessageStore:      messageStore,
+		MentionStore:      mentionStore,
+		SubscriptionStore: subscriptionStore,
+		Notifier:          notifier,
+		UnitOfWork:        uw.Start,
+		Now:               func() time.Time { return customTime },
+	})
+
+	_, err := svc.CreateMessage(ctx, &warnly.CreateMessageRequest{
+		User:      author,
+		Content:   "@jane looking into it",
+		ProjectID: projectID,
+		IssueID:   issueID,
+	})
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, projectID, result.ID)
-	assert.Equal(t, projectName, result.Name)
+
+	assert.Equal(t, []warnly.Subscription{{CreatedAt: customTime, IssueID: issueID, UserID: 1}}, subscribed,
+		"the author is subscribed to the issue")
+
+	require.NotNil(t, notified, "the subscriber is notified without being mentioned")
+	require.Len(t, notified.Recipients, 1, "the author isn't notified about their own message")
+	assert.Equal(t, int64(3), notified.Recipients[0].ID)
+	assert.Equal(t, "@jane looking into it", notified.Content)
+	assert.Equal(t, "nil pointer dereference", notified.Message)
+	assert.Equal(t, author, notified.Author)
+	assert.Equal(t, int64(issueID), notified.IssueID)
+	assert.Equal(t, teamID, notified.TeamID)
 }
 
-func TestSearchProjectNotFound(t *testing.T) {
+func TestSubscribeIssue(t *testing.T) {
 	t.Parallel()
 
-	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	teamID := 10
+	user := &warnly.User{ID: 7}
+	var subscribers []int64
+	subscriptionStore := &mock.SubscriptionStore{
+		CreateSubscriptionFn: func(_ context.Context, s *warnly.Subscription) error {
+			require.Equal(t, int64(100), s.IssueID)
+			subscribers = append(subscribers, s.UserID)
+			return nil
+		},
+		DeleteSubscriptionFn: func(_ context.Context, issueID, userID int64) error {
+			require.Equal(t, int64(100), issueID)
+			subscribers = slices.DeleteFunc(subscribers, func(id int64) bool { return id == userID })
+			return nil
+		},
+	}
 
-	teamStore := &mock.TeamStore{
-		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
-			}, nil
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+				return &warnly.Project{ID: id, TeamID: 10}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10}}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: 5}, nil
+			},
+		},
+		SubscriptionStore: subscriptionStore,
+	})
+
+	req := &warnly.IssueSubscriptionRequest{User: user, ProjectID: 5, IssueID: 100}
+	require.NoError(t, svc.Subscribe(t.Context(), req))
+	assert.Equal(t, []int64{7}, subscribers)
+
+	require.NoError(t, svc.Unsubscribe(t.Context(), req))
+	assert.Empty(t, subscribers)
+
+	err := svc.Subscribe(t.Context(), &warnly.IssueSubscriptionRequest{User: user, ProjectID: 6, IssueID: 100})
+	require.ErrorIs(t, err, warnly.ErrNotFound, "the issue belongs to another project")
+	assert.Empty(t, subscribers)
+}
+
+func TestIssueLabels(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 7}
+	var labels []warnly.IssueLabel
+	labelStore := &mock.IssueLabelStore{
+		CreateLabelFn: func(_ context.Context, l *warnly.IssueLabel) error {
+			labels = append(labels, *l)
+			return nil
+		},
+		DeleteLabelFn: func(_ context.Context, issueID int64, name string) error {
+			labels = slices.DeleteFunc(labels, func(l warnly.IssueLabel) bool {
+				return l.IssueID == issueID && l.Name == name
+			})
+			return nil
+		},
+		ListLabelsFn: func(_ context.Context, issueID int64) ([]warnly.IssueLabel, error) {
+			require.Equal(t, int64(100), issueID)
+			return labels, nil
 		},
 	}
 
-	projectStore := &mock.ProjectStore{
-		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
-			return []warnly.Project{}, nil
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+				return &warnly.Project{ID: id, TeamID: 10}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Role: warnly.RoleMember}}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: 5}, nil
+			},
+		},
+		LabelStore: labelStore,
+	})
+
+	ctx := t.Context()
+	req := &warnly.IssueLabelRequest{User: user, Label: " Needs-Repro ", ProjectID: 5, IssueID: 100}
+	require.NoError(t, svc.AddLabel(ctx, req))
+
+	listed, err := svc.ListLabels(ctx, &warnly.IssueLabelRequest{User: user, ProjectID: 5, IssueID: 100})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "needs-repro", listed[0].Name)
+	assert.Equal(t, int64(100), listed[0].IssueID)
+	assert.Equal(t, int64(7), listed[0].UserID)
+
+	err = svc.AddLabel(ctx, &warnly.IssueLabelRequest{User: user, Label: "needs repro!", ProjectID: 5, IssueID: 100})
+	require.ErrorIs(t, err, warnly.ErrInvalidLabel)
+
+	err = svc.AddLabel(ctx, &warnly.IssueLabelRequest{User: user, Label: "regression", ProjectID: 6, IssueID: 100})
+	require.ErrorIs(t, err, warnly.ErrNotFound, "the issue belongs to another project")
+	assert.Len(t, labels, 1)
+
+	require.NoError(t, svc.RemoveLabel(ctx, req))
+	assert.Empty(t, labels)
+}
+
+func TestIssueNoiseFilters(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 7}
+	var filters warnly.NoiseFilters
+	updates := 0
+	issueStore := &mock.IssueStore{
+		GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: id, ProjectID: 5, NoiseFilters: slices.Clone(filters)}, nil
+		},
+		UpdateNoiseFiltersFn: func(_ context.Context, issueID int64, f warnly.NoiseFilters) error {
+			require.Equal(t, int64(100), issueID)
+			filters = f
+			updates++
+			return nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		&mock.AssingmentStore{},
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+				return &warnly.Project{ID: id, TeamID: 10}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Role: warnly.RoleMember}}, nil
+			},
+		},
+		IssueStore: issueStore,
+	})
+	forgot := 0
+	svc.SetIngestCache(&mock.IngestCache{
+		ForgetIssueFn: func(int, string) { forgot++ },
+	})
 
-	result, err := svc.SearchProject(ctx, "Nonexistent Project", user)
+	ctx := t.Context()
+	req := &warnly.NoiseFilterRequest{User: user, Filter: "user_agent=HeadlessChrome, env=production", ProjectID: 5, IssueID: 100}
+	require.NoError(t, svc.AddNoiseFilter(ctx, req))
+	require.Len(t, filters, 1)
+	assert.Equal(t, "env=production, user_agent=HeadlessChrome", filters[0].String())
+
+	// the same tags in another order are the same filter.
+	require.NoError(t, svc.AddNoiseFilter(ctx, &warnly.NoiseFilterRequest{
+		User: user, Filter: "env=production,user_agent=HeadlessChrome", ProjectID: 5, IssueID: 100,
+	}))
+	assert.Equal(t, 1, updates)
+
+	err := svc.AddNoiseFilter(ctx, &warnly.NoiseFilterRequest{User: user, Filter: "env", ProjectID: 5, IssueID: 100})
+	require.ErrorIs(t, err, warnly.ErrInvalidNoiseFilter)
+
+	err = svc.AddNoiseFilter(ctx, &warnly.NoiseFilterRequest{User: user, Filter: "env=qa", ProjectID: 6, IssueID: 100})
+	require.ErrorIs(t, err, warnly.ErrNotFound, "the issue belongs to another project")
+
+	require.NoError(t, svc.RemoveNoiseFilter(ctx, req))
+	assert.Empty(t, filters)
+	assert.Equal(t, 2, forgot, "the cached issue is dropped on every change of its filters")
+}
 
-	require.Error(t, err)
-	assert.Nil(t, result)
-	assert.Equal(t, warnly.ErrProjectNotFound, err)
+func TestListIssuesByLabel(t *testing.T) {
+	t.Parallel()
+
+	projectID := 5
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		label string
+		want  []int64
+	}{
+		{
+			name:  "label of the request",
+			label: "regression",
+			want:  []int64{1, 3},
+		},
+		{
+			name:  "label in the query",
+			query: "label:needs-repro",
+			want:  []int64{2},
+		},
+		{
+			name:  "label nobody attached",
+			label: "flaky",
+			want:  []int64{},
+		},
+		{
+			name:  "label that can't be attached",
+			query: "label:not/a/label",
+			want:  []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+						return []warnly.Project{{ID: projectID, TeamID: 10, Name: "Test Project"}}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					ListIssuesFn: func(_ context.Context, _ *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+						return []warnly.Issue{
+							{ID: 1, ProjectID: projectID, ErrorType: "TimeoutError", Message: "upstream timed out"},
+							{ID: 2, ProjectID: projectID, ErrorType: "TypeError", Message: "cart is undefined"},
+							{ID: 3, ProjectID: projectID, ErrorType: "RangeError", Message: "invalid array length"},
+						}, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesByIDsFn: func(_ context.Context, _ []int64) ([]warnly.MessageCount, error) {
+						return []warnly.MessageCount{}, nil
+					},
+				},
+				LabelStore: &mock.IssueLabelStore{
+					ListLabeledIssueIDsFn: func(_ context.Context, teamIDs []int, name string) ([]int64, error) {
+						require.Equal(t, []int{10}, teamIDs)
+						return map[string][]int64{
+							"regression":  {1, 3},
+							"needs-repro": {2},
+						}[name], nil
+					},
+				},
+				AnalyticsStore: &mock.AnalyticsStore{
+					ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+						return []warnly.IssueMetrics{
+							{GID: 1, TimesSeen: 40, LastSeen: customTime},
+							{GID: 2, TimesSeen: 30, LastSeen: customTime},
+							{GID: 3, TimesSeen: 20, LastSeen: customTime},
+						}, nil
+					},
+					EventSeriesForIssuesFn: func(context.Context, []int64, time.Time, time.Time, []int) ([]warnly.IssueEventsPerHour, error) {
+						return nil, nil
+					},
+					ListPopularTagsFn: func(_ context.Context, _ *warnly.ListPopularTagsCriteria) ([]warnly.TagCount, error) {
+						return []warnly.TagCount{}, nil
+					},
+				},
+				Now: func() time.Time { return customTime },
+			})
+
+			result, err := svc.ListIssues(t.Context(), &warnly.ListIssuesRequest{
+				User:   &warnly.User{ID: 1},
+				Period: "24h",
+				Query:  tt.query,
+				Label:  tt.label,
+			})
+			require.NoError(t, err)
+
+			ids := make([]int64, 0, len(result.Issues))
+			for i := range result.Issues {
+				ids = append(ids, result.Issues[i].ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
 }
 
-func TestGetIssueSuccess(t *testing.T) {
+func TestCreateMessageWithMentions(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
-	user := &warnly.User{ID: 1}
+	user := &warnly.User{ID: 1, Name: "John Doe"}
 	projectID := 5
-	issueID := 100
 	teamID := 10
+	issueID := 100
 	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-	issueFirstSeen := customTime.Add(-30 * 24 * time.Hour)
-	lastSeen := customTime.Add(-1 * time.Hour)
 
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
 			return []warnly.Team{
-				{ID: teamID, Name: "Team A"},
+				{ID: teamID, Name: "Team A", Role: warnly.RoleMember},
 			}, nil
 		},
 		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
 			return []warnly.Teammate{
-				{ID: 1, Name: "John Doe", Email: "john@example.com"},
-				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+				{ID: 1, Name: "John Doe", Username: "john", Email: "john@example.com"},
+				{ID: 2, Name: "Jane Smith", Username: "jane", Email: "jane@example.com"},
+				{ID: 3, Name: "Bob Johnson", Username: "bob", Email: "bob@example.com"},
 			}, nil
 		},
 	}
@@ -2118,259 +4344,2282 @@ func TestGetIssueSuccess(t *testing.T) {
 	projectStore := &mock.ProjectStore{
 		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
 			return &warnly.Project{
-				ID:       projectID,
-				TeamID:   teamID,
-				Name:     "Test Project",
-				Platform: warnly.PlatformGolang,
+				ID:     projectID,
+				TeamID: teamID,
+				Name:   "Test Project",
 			}, nil
 		},
 	}
 
-	issueStore := &mock.IssueStore{
-		GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
-			return &warnly.Issue{
-				ID:        int64(issueID),
-				ProjectID: projectID,
-				ErrorType: "RuntimeError",
-				Message:   "Division by zero",
-				View:      "main.go:42",
-				FirstSeen: issueFirstSeen,
-				Priority:  warnly.PriorityHigh,
+	messageStore := &mock.MessageStore{
+		ListIssueMessagesFn: func(_ context.Context, _ int64) ([]warnly.IssueMessage, error) {
+			return []warnly.IssueMessage{
+				{
+					ID:        1,
+					UserID:    1,
+					Username:  "John Doe",
+					Content:   "Mentioning @Jane Smith and @Bob Johnson",
+					CreatedAt: customTime,
+				},
 			}, nil
 		},
 	}
 
-	analyticsStore := &mock.AnalyticsStore{
-		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
-			return []warnly.IssueMetrics{
-				{
-					GID:       uint64(issueID),
-					FirstSeen: issueFirstSeen,
-					LastSeen:  lastSeen,
-					TimesSeen: 150,
-					UserCount: 25,
-				},
-			}, nil
+	var mentions []warnly.Mention
+	mentionStore := &mock.MentionStore{
+		CreateMentionsFn: func(_ context.Context, m []warnly.Mention) error {
+			mentions = m
+			return nil
 		},
-		CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
-			return []warnly.EventPerDay{
-				{Time: customTime, GID: uint64(issueID), Count: 25},
-				{Time: customTime.Add(-1 * 24 * time.Hour), GID: uint64(issueID), Count: 50},
-			}, nil
+	}
+	uw := &mock.UnitOfWork{
+		MessageStore: &mock.MessageStore{
+			CreateMessageFn: func(_ context.Context, m *warnly.Message) error {
+				m.ID = 42
+				return nil
+			},
 		},
-		CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
-			return []warnly.TagCount{
-				{Tag: "browser", Count: 60},
-				{Tag: "os", Count: 150},
-			}, nil
+		ActivityStore: &mock.ActivityStore{
+			CreateActivityFn: func(_ context.Context, _ *warnly.Activity) error { return nil },
 		},
-		CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
-			return []warnly.FieldValueNum{
-				{Tag: "browser", Value: "Chrome", Count: 40, PercentsOfTotal: 66.67},
-				{Tag: "browser", Value: "Firefox", Count: 20, PercentsOfTotal: 33.33},
-				{Tag: "os", Value: "Windows", Count: 90, PercentsOfTotal: 60},
-				{Tag: "os", Value: "macOS", Count: 60, PercentsOfTotal: 40},
-			}, nil
+		SubscriptionStore: &mock.SubscriptionStore{
+			CreateSubscriptionFn: func(_ context.Context, _ *warnly.Subscription) error { return nil },
 		},
-		GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: projectID}, nil
+			},
+		},
+		MessageStore: messageStore,
+		MentionStore: mentionStore,
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		UnitOfWork: uw.Start,
+		Now:        func() time.Time { return customTime },
+	})
+
+	req := &warnly.CreateMessageRequest{
+		User: user,
+		// mallory isn't a teammate, the mention is ignored.
+		Content:   "<p>Mentioning @jane, @mallory and @Jane again, cc bob@example.com</p>",
+		ProjectID: projectID,
+		IssueID:   issueID,
+	}
+
+	result, err := svc.CreateMessage(ctx, req)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, projectID, result.Info.ProjectID)
+	assert.Equal(t, issueID, result.Info.IssueID)
+	assert.Len(t, result.Teammates, 3)
+	assert.Len(t, result.Messages, 1)
+	assert.Equal(t, []warnly.Mention{{MessageID: 42, MentionedUserID: 2, CreatedAt: customTime}}, mentions)
+}
+
+func TestListTagValuesSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{
+				{ID: 1, TeamID: teamID, Name: "Test Project"},
+			}, nil
+		},
+	}
+
+	analyticsStore := &mock.AnalyticsStore{
+		ListTagValuesFn: func(_ context.Context, _ *warnly.ListTagValuesCriteria) ([]warnly.TagValueCount, error) {
+			return []warnly.TagValueCount{
+				{Value: "Chrome", Count: 150},
+				{Value: "Firefox", Count: 80},
+				{Value: "Safari", Count: 45},
+			}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
+
+	req := &warnly.ListTagValuesRequest{
+		User:   user,
+		Tag:    "browser",
+		Period: "24h",
+		Limit:  10,
+	}
+
+	result, err := svc.ListTagValues(ctx, req)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Len(t, result, 3)
+	assert.Equal(t, "Chrome", result[0].Value)
+	assert.Equal(t, uint64(150), result[0].Count)
+	assert.Equal(t, "Firefox", result[1].Value)
+	assert.Equal(t, uint64(80), result[1].Count)
+	assert.Equal(t, "Safari", result[2].Value)
+	assert.Equal(t, uint64(45), result[2].Count)
+}
+
+func TestListTagValuesNoResults(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{
+				{ID: 1, TeamID: teamID, Name: "Test Project"},
+			}, nil
+		},
+	}
+
+	analyticsStore := &mock.AnalyticsStore{
+		ListTagValuesFn: func(_ context.Context, _ *warnly.ListTagValuesCriteria) ([]warnly.TagValueCount, error) {
+			return []warnly.TagValueCount{}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:   projectStore,
+		TeamStore:      teamStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
+
+	req := &warnly.ListTagValuesRequest{
+		User:   user,
+		Tag:    "browser",
+		Period: "24h",
+		Limit:  10,
+	}
+
+	result, err := svc.ListTagValues(ctx, req)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
+
+func TestSuggestTagValues(t *testing.T) {
+	t.Parallel()
+
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		projectID int
+		prefix    string
+		wantErr   error
+	}{
+		{
+			name:      "prefix is passed to the store",
+			projectID: 5,
+			prefix:    " chr ",
+		},
+		{
+			name:      "project of another team",
+			projectID: 6,
+			wantErr:   warnly.ErrProjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			analyticsStore := &mock.AnalyticsStore{
+				SuggestTagValuesFn: func(_ context.Context, c *warnly.SuggestTagValuesCriteria) ([]warnly.TagValueCount, error) {
+					assert.Equal(t, &warnly.SuggestTagValuesCriteria{
+						From:      customTime.Add(-7 * 24 * time.Hour),
+						To:        customTime,
+						Tag:       "browser",
+						Prefix:    "chr",
+						ProjectID: 5,
+						Limit:     warnly.MaxTagValueSuggestions,
+					}, c)
+					return []warnly.TagValueCount{{Value: "Chrome", Count: 150}}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+						return &warnly.Project{ID: projectID, TeamID: projectID * 2}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+					},
+				},
+				AnalyticsStore: analyticsStore,
+				Now:            func() time.Time { return customTime },
+			})
+
+			values, err := svc.SuggestTagValues(t.Context(), &warnly.SuggestTagValuesRequest{
+				User:      &warnly.User{ID: 1},
+				Tag:       "browser",
+				Prefix:    tt.prefix,
+				ProjectID: tt.projectID,
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []warnly.TagValueCount{{Value: "Chrome", Count: 150}}, values)
+		})
+	}
+}
+
+func TestCompareWindows(t *testing.T) {
+	t.Parallel()
+
+	customTime := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	baseline := warnly.TimeWindow{From: customTime.Add(-14 * 24 * time.Hour), To: customTime.Add(-7 * 24 * time.Hour)}
+	current := warnly.TimeWindow{From: customTime.Add(-7 * 24 * time.Hour), To: customTime}
+
+	analyticsStore := &mock.AnalyticsStore{
+		CompareWindowsFn: func(_ context.Context, c *warnly.CompareWindowsCriteria) ([]warnly.IssueWindowCounts, error) {
+			assert.Equal(t, &warnly.CompareWindowsCriteria{
+				Baseline:   baseline,
+				Current:    current,
+				ProjectIDs: []int{5},
+				Limit:      warnly.MaxComparedIssues,
+			}, c)
+			return []warnly.IssueWindowCounts{
+				{GroupID: 2, Baseline: 0, Current: 7},
+				{GroupID: 1, Baseline: 10, Current: 4},
+			}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		ListIssuesFn: func(_ context.Context, c *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			assert.Equal(t, &warnly.ListIssuesCriteria{
+				ProjectIDs: []int{5},
+				GroupIDs:   []int64{2, 1},
+				From:       baseline.From,
+				To:         customTime,
+			}, c)
+			return []warnly.Issue{
+				{ID: 1, ProjectID: 5, ErrorType: "TimeoutError", Message: "upstream timed out"},
+				{ID: 2, ProjectID: 5, ErrorType: "KeyError", Message: "missing user_id"},
+			}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+				return &warnly.Project{ID: projectID, TeamID: projectID * 2}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+			},
+		},
+		IssueStore:     issueStore,
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
+
+	res, err := svc.CompareWindows(t.Context(), &warnly.CompareWindowsRequest{
+		User:      &warnly.User{ID: 1},
+		Baseline:  baseline,
+		Current:   current,
+		ProjectID: 5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &warnly.WindowComparison{
+		Baseline:  baseline,
+		Current:   current,
+		ProjectID: 5,
+		Issues: []warnly.IssueComparison{
+			{IssueID: 2, ErrorType: "KeyError", Message: "missing user_id", Current: 7, Delta: 7, New: true},
+			{IssueID: 1, ErrorType: "TimeoutError", Message: "upstream timed out", Baseline: 10, Current: 4, Delta: -6},
+		},
+	}, res)
+
+	_, err = svc.CompareWindows(t.Context(), &warnly.CompareWindowsRequest{
+		User:      &warnly.User{ID: 1},
+		Baseline:  warnly.TimeWindow{From: baseline.To, To: baseline.From},
+		Current:   current,
+		ProjectID: 5,
+	})
+	require.ErrorIs(t, err, warnly.ErrInvalidTimeWindow)
+
+	_, err = svc.CompareWindows(t.Context(), &warnly.CompareWindowsRequest{
+		User:      &warnly.User{ID: 1},
+		Baseline:  baseline,
+		Current:   current,
+		ProjectID: 6,
+	})
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound)
+}
+func TestSearchProjectSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+	projectID := 5
+	projectName := "Test Project"
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, userID int) ([]warnly.Team, error) {
+			assert.Equal(t, 1, userID)
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, teamIDs []int, name string) ([]warnly.Project, error) {
+			assert.Len(t, teamIDs, 1)
+			assert.Equal(t, teamID, teamIDs[0])
+			assert.Equal(t, projectName, name)
+			return []warnly.Project{
+				{ID: projectID, TeamID: teamID, Name: projectName},
+			}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
+
+	result, err := svc.SearchProject(ctx, projectName, user)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, projectID, result.ID)
+	assert.Equal(t, teamID, result.TeamID)
+	assert.Equal(t, projectName, result.Name)
+}
+
+func TestSearchProjectMultipleProjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+	projectID := 5
+	projectName := "Backend API"
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{
+				{ID: projectID, TeamID: teamID, Name: "Backend API"},
+				{ID: 6, TeamID: teamID, Name: "Frontend Web"},
+				{ID: 7, TeamID: teamID, Name: "Mobile App"},
+			}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
+
+	result, err := svc.SearchProject(ctx, projectName, user)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, projectID, result.ID)
+	assert.Equal(t, projectName, result.Name)
+}
+
+func TestSearchProjectNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	teamID := 10
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		ListProjectsFn: func(_ context.Context, _ []int, _ string) ([]warnly.Project, error) {
+			return []warnly.Project{}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
+
+	result, err := svc.SearchProject(ctx, "Nonexistent Project", user)
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, warnly.ErrProjectNotFound, err)
+}
+
+func TestGetIssueSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	projectID := 5
+	issueID := 100
+	teamID := 10
+	customTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issueFirstSeen := customTime.Add(-30 * 24 * time.Hour)
+	lastSeen := customTime.Add(-1 * time.Hour)
+	// two events within the last hour, the others are older.
+	eventTimes := []time.Time{
+		customTime.Add(-5 * time.Minute),
+		customTime.Add(-59 * time.Minute),
+		customTime.Add(-61 * time.Minute),
+		customTime.Add(-3 * time.Hour),
+		customTime.Add(-2 * 24 * time.Hour),
+	}
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: teamID, Name: "Team A"},
+			}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{
+				{ID: 1, Name: "John Doe", Email: "john@example.com"},
+				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+			}, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{
+				ID:       projectID,
+				TeamID:   teamID,
+				Name:     "Test Project",
+				Platform: warnly.PlatformGolang,
+			}, nil
+		},
+	}
+
+	issueStore := &mock.IssueStore{
+		GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+			return &warnly.Issue{
+				ID:        int64(issueID),
+				ProjectID: projectID,
+				ErrorType: "RuntimeError",
+				Message:   "Division by zero",
+				View:      "main.go:42",
+				FirstSeen: issueFirstSeen,
+				Priority:  warnly.PriorityHigh,
+			}, nil
+		},
+	}
+
+	analyticsStore := &mock.AnalyticsStore{
+		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			return []warnly.IssueMetrics{
+				{
+					GID:       uint64(issueID),
+					FirstSeen: issueFirstSeen,
+					LastSeen:  lastSeen,
+					TimesSeen: 150,
+					UserCount: 25,
+				},
+			}, nil
+		},
+		CountEventsFn: func(_ context.Context, c *warnly.EventCriteria) (uint64, error) {
+			var n uint64
+			for _, at := range eventTimes {
+				if !at.Before(c.From) && at.Before(c.To) {
+					n++
+				}
+			}
+			return n, nil
+		},
+		CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
+			return []warnly.EventPerDay{
+				{Time: customTime, GID: uint64(issueID), Count: 25},
+				{Time: customTime.Add(-1 * 24 * time.Hour), GID: uint64(issueID), Count: 50},
+			}, nil
+		},
+		CalculateEventGapsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error) {
+			return warnly.NewEventGapHistogram(), nil
+		},
+		CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+			return []warnly.TagCount{
+				{Tag: "browser", Count: 60},
+				{Tag: "os", Count: 150},
+			}, nil
+		},
+		CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
+			return []warnly.FieldValueNum{
+				{Tag: "browser", Value: "Chrome", Count: 40, PercentsOfTotal: 66.67},
+				{Tag: "browser", Value: "Firefox", Count: 20, PercentsOfTotal: 33.33},
+				{Tag: "os", Value: "Windows", Count: 90, PercentsOfTotal: 60},
+				{Tag: "os", Value: "macOS", Count: 60, PercentsOfTotal: 40},
+			}, nil
+		},
+		GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
 			return &warnly.IssueEvent{
 				EventID:   "event-123",
-				UserID:    "user-456",
-				UserEmail: "test@example.com",
-				UserName:  "Test User",
-				Message:   "Division by zero at line 42",
-				TagsKey:   []string{"browser", "os"},
-				TagsValue: []string{"Chrome", "Windows"},
+				UserID:    "user-456",
+				UserEmail: "test@example.com",
+				UserName:  "Test User",
+				Message:   "Division by zero at line 42",
+				TagsKey:   []string{"browser", "os"},
+				TagsValue: []string{"Chrome", "Windows"},
+			}, nil
+		},
+	}
+
+	messageStore := &mock.MessageStore{
+		CountMessagesFn: func(_ context.Context, _ int64) (int, error) {
+			return 3, nil
+		},
+	}
+
+	assignmentStore := &mock.AssingmentStore{
+		ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+			return []*warnly.AssignedUser{
+				{
+					IssueID:          int64(issueID),
+					AssignedToUserID: sql.NullInt64{Int64: 1, Valid: true},
+				},
+			}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		IssueStore:      issueStore,
+		MessageStore:    messageStore,
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		LabelStore: &mock.IssueLabelStore{
+			ListLabelsFn: func(_ context.Context, _ int64) ([]warnly.IssueLabel, error) {
+				return []warnly.IssueLabel{{Name: "needs-repro", IssueID: 1}}, nil
+			},
+		},
+		SeenStore:      newSeenStore(),
+		AnalyticsStore: analyticsStore,
+		Now:            func() time.Time { return customTime },
+	})
+
+	req := &warnly.GetIssueRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+		Period:    "24h",
+		EventID:   "event-123",
+	}
+
+	result, err := svc.GetIssue(ctx, req)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, int64(issueID), result.IssueID)
+	assert.Equal(t, projectID, result.ProjectID)
+	assert.Equal(t, "Test Project", result.ProjectName)
+	assert.Equal(t, "RuntimeError", result.ErrorType)
+	assert.Equal(t, "Division by zero", result.ErrorValue)
+	assert.Equal(t, "main.go:42", result.View)
+	assert.Equal(t, warnly.PriorityHigh, result.Priority)
+	assert.Equal(t, issueFirstSeen, result.FirstSeen)
+	assert.Equal(t, lastSeen, result.LastSeen)
+	assert.Equal(t, uint64(150), result.TimesSeen)
+	assert.Equal(t, uint64(25), result.UserCount)
+	assert.Equal(t, uint64(2), result.Total1Hour)
+	assert.Equal(t, uint64(25), result.Total24Hours)
+	assert.Equal(t, uint64(75), result.Total30Days)
+	assert.Equal(t, 3, result.MessagesCount)
+	assert.Equal(t, warnly.PlatformGolang, result.Platform)
+	assert.Len(t, result.Teammates, 2)
+	require.Len(t, result.SeenBy, 1)
+	assert.Equal(t, int64(1), result.SeenBy[0].ID)
+	assert.NotNil(t, result.Assignments)
+	assert.NotNil(t, result.LastEvent)
+	assert.Equal(t, "event-123", result.LastEvent.EventID)
+	assert.Equal(t, []warnly.IssueLabel{{Name: "needs-repro", IssueID: 1}}, result.Labels)
+	assert.False(t, result.IsNew)
+	assert.False(t, result.NewInPeriod)
+}
+
+func TestGetIssueNewInPeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	const (
+		projectID = 5
+		issueID   = 100
+	)
+
+	newService := func(firstSeen time.Time) *project.ProjectService {
+		return newTestService(testService{
+			ProjectStore: &mock.ProjectStore{
+				GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+					return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+				},
+			},
+			AssingmentStore: &mock.AssingmentStore{
+				ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+					return nil, nil
+				},
+			},
+			TeamStore: &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+				},
+				ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+					return []warnly.Teammate{}, nil
+				},
+			},
+			IssueStore: &mock.IssueStore{
+				GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+					return &warnly.Issue{ID: issueID, ProjectID: projectID, ErrorType: "RuntimeError", FirstSeen: firstSeen}, nil
+				},
+			},
+			MessageStore: &mock.MessageStore{
+				CountMessagesFn: func(_ context.Context, _ int64) (int, error) {
+					return 0, nil
+				},
+			},
+			SubscriptionStore: &mock.SubscriptionStore{
+				ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+			},
+			LabelStore: &mock.IssueLabelStore{ListLabelsFn: func(_ context.Context, _ int64) ([]warnly.IssueLabel, error) {
+				return nil, nil
+			}},
+			SeenStore: newSeenStore(),
+			AnalyticsStore: &mock.AnalyticsStore{
+				ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return []warnly.IssueMetrics{{GID: issueID, FirstSeen: now.Add(-time.Hour), LastSeen: now, TimesSeen: 1}}, nil
+				},
+				CountEventsFn: func(_ context.Context, _ *warnly.EventCriteria) (uint64, error) {
+					return 0, nil
+				},
+				CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
+					return nil, nil
+				},
+				CalculateEventGapsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error) {
+					return warnly.NewEventGapHistogram(), nil
+				},
+				CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+					return nil, nil
+				},
+				CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
+					return nil, nil
+				},
+				GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
+					return &warnly.IssueEvent{EventID: "event-123"}, nil
+				},
+			},
+			Now: func() time.Time { return now },
+		})
+	}
+
+	tests := []struct {
+		name            string
+		firstSeen       time.Time
+		wantNew         bool
+		wantNewInPeriod bool
+	}{
+		{name: "first seen within the period", firstSeen: now.Add(-time.Hour), wantNew: true, wantNewInPeriod: true},
+		{name: "first seen before the period", firstSeen: now.Add(-72 * time.Hour), wantNew: true, wantNewInPeriod: false},
+		{name: "first seen before the new issue window", firstSeen: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := newService(tt.firstSeen).GetIssue(t.Context(), &warnly.GetIssueRequest{
+				User:      &warnly.User{ID: 1},
+				ProjectID: projectID,
+				IssueID:   issueID,
+				Period:    "24h",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNew, result.IsNew)
+			assert.Equal(t, tt.wantNewInPeriod, result.NewInPeriod)
+		})
+	}
+}
+
+func TestAssignIssueSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	targetUserID := 2
+	projectID := 5
+	issueID := 100
+	customTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: 10, Name: "Team A", Role: warnly.RoleMember},
+			}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{
+				{ID: 1, Name: "John Doe", Email: "john@example.com"},
+				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+			}, nil
+		},
+	}
+
+	assignmentStore := &mock.AssingmentStore{
+		CreateAssingmentFn: func(_ context.Context, assignment *warnly.Assignment) error {
+			assert.Equal(t, int64(issueID), assignment.IssueID)
+			assert.Equal(t, int64(targetUserID), assignment.AssignedToUserID)
+			assert.Equal(t, int64(1), assignment.AssignedByUserID)
+			assert.Equal(t, customTime, assignment.AssignedAt)
+			return nil
+		},
+	}
+
+	var activity *warnly.Activity
+	activityStore := &mock.ActivityStore{
+		CreateActivityFn: func(_ context.Context, a *warnly.Activity) error {
+			activity = a
+			return nil
+		},
+	}
+	var subscription *warnly.Subscription
+	subscriptionStore := &mock.SubscriptionStore{
+		CreateSubscriptionFn: func(_ context.Context, s *warnly.Subscription) error {
+			subscription = s
+			return nil
+		},
+	}
+	uw := &mock.UnitOfWork{
+		AssingmentStore:   assignmentStore,
+		ActivityStore:     activityStore,
+		SubscriptionStore: subscriptionStore,
+	}
+
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: projectID}, nil
+			},
+		},
+		ActivityStore:     activityStore,
+		SubscriptionStore: subscriptionStore,
+		UnitOfWork:        uw.Start,
+		Now:               func() time.Time { return customTime },
+	})
+
+	req := &warnly.AssignIssueRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+		UserID:    targetUserID,
+	}
+
+	err := svc.AssignIssue(ctx, req)
+
+	require.NoError(t, err)
+	require.NotNil(t, activity, "assigning must record an activity entry")
+	assert.Equal(t, warnly.ActivityAssigned, activity.Type)
+	assert.Equal(t, int64(issueID), activity.IssueID)
+	assert.Equal(t, int64(1), activity.ActorID)
+	assert.Equal(t, "Jane Smith", activity.Detail)
+	assert.Equal(t, customTime, activity.CreatedAt)
+	require.NotNil(t, subscription, "the assignee is subscribed to the issue")
+	assert.Equal(t, warnly.Subscription{CreatedAt: customTime, IssueID: int64(issueID), UserID: int64(targetUserID)}, *subscription)
+}
+
+func TestAssignIssueToTeam(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	projectID := 5
+	issueID := 100
+	customTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	firstSeen := customTime.Add(-30 * 24 * time.Hour)
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: 10, Name: "Backend", Role: warnly.RoleMember},
+				{ID: 20, Name: "On-call", Role: warnly.RoleViewer},
+			}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{
+				{ID: 1, Name: "John Doe", Email: "john@example.com"},
+				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+			}, nil
+		},
+	}
+
+	var assignments []*warnly.AssignedUser
+	assignmentStore := &mock.AssingmentStore{
+		CreateAssingmentFn: func(_ context.Context, assignment *warnly.Assignment) error {
+			assert.Equal(t, warnly.AssigneeTeam, assignment.AssigneeType)
+			assert.Equal(t, int64(20), assignment.AssignedToTeamID)
+			assert.Zero(t, assignment.AssignedToUserID)
+			assert.Equal(t, int64(1), assignment.AssignedByUserID)
+			assignments = append(assignments, &warnly.AssignedUser{
+				IssueID:          assignment.IssueID,
+				AssignedToTeamID: sql.NullInt64{Int64: assignment.AssignedToTeamID, Valid: true},
+			})
+			return nil
+		},
+		ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+			return assignments, nil
+		},
+	}
+
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project", Platform: warnly.PlatformGolang}, nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: int64(issueID), ProjectID: projectID, FirstSeen: firstSeen}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			return []warnly.IssueMetrics{{GID: uint64(issueID), FirstSeen: firstSeen, LastSeen: customTime, TimesSeen: 3}}, nil
+		},
+		CountEventsFn: func(_ context.Context, _ *warnly.EventCriteria) (uint64, error) {
+			return 0, nil
+		},
+		CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
+			return nil, nil
+		},
+		CalculateEventGapsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error) {
+			return warnly.NewEventGapHistogram(), nil
+		},
+		CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+			return nil, nil
+		},
+		CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
+			return nil, nil
+		},
+		GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
+			return &warnly.IssueEvent{EventID: "event-123"}, nil
+		},
+	}
+	messageStore := &mock.MessageStore{
+		CountMessagesFn: func(_ context.Context, _ int64) (int, error) { return 0, nil },
+	}
+
+	var activity *warnly.Activity
+	uw := &mock.UnitOfWork{
+		AssingmentStore: assignmentStore,
+		ActivityStore: &mock.ActivityStore{
+			CreateActivityFn: func(_ context.Context, a *warnly.Activity) error {
+				activity = a
+				return nil
+			},
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		IssueStore:      issueStore,
+		MessageStore:    messageStore,
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		LabelStore: &mock.IssueLabelStore{ListLabelsFn: func(_ context.Context, _ int64) ([]warnly.IssueLabel, error) {
+			return nil, nil
+		}},
+		SeenStore:      newSeenStore(),
+		AnalyticsStore: analyticsStore,
+		UnitOfWork:     uw.Start,
+		Now:            func() time.Time { return customTime },
+	})
+
+	err := svc.AssignIssue(ctx, &warnly.AssignIssueRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+		TeamID:    20,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, activity)
+	assert.Equal(t, warnly.ActivityAssigned, activity.Type)
+	assert.Equal(t, "team On-call", activity.Detail)
+
+	result, err := svc.GetIssue(ctx, &warnly.GetIssueRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   issueID,
+		Period:    "24h",
+		EventID:   "event-123",
+	})
+	require.NoError(t, err)
+
+	team, ok := result.Assignments.AssignedTeam(int64(issueID))
+	require.True(t, ok)
+	assert.Equal(t, 20, team.ID)
+	assert.Equal(t, "On-call", team.Name)
+	_, ok = result.Assignments.AssignedUser(int64(issueID))
+	assert.False(t, ok)
+	assert.Len(t, result.Teams, 2)
+}
+
+func TestGetIssueSuggestsCodeOwner(t *testing.T) {
+	t.Parallel()
+
+	projectID := 5
+	issueID := 100
+	customTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	firstSeen := customTime.Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		assignments []*warnly.AssignedUser
+		want        int64
+	}{
+		{
+			name: "unassigned issue",
+			want: 2,
+		},
+		{
+			name: "assigned issue",
+			assignments: []*warnly.AssignedUser{
+				{IssueID: int64(issueID), AssignedToUserID: sql.NullInt64{Int64: 1, Valid: true}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+						return &warnly.Project{
+							ID:       projectID,
+							TeamID:   10,
+							Name:     "Test Project",
+							Platform: warnly.PlatformGolang,
+							CodeOwners: []warnly.CodeOwner{
+								{Pattern: "*.go", UserID: 1},
+								{Pattern: "internal/billing/", UserID: 2},
+							},
+						}, nil
+					},
+				},
+				AssingmentStore: &mock.AssingmentStore{
+					ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+						return tt.assignments, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Backend"}}, nil
+					},
+					ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+						return []warnly.Teammate{
+							{ID: 1, Name: "John Doe", Username: "john"},
+							{ID: 2, Name: "Jane Smith", Username: "jane"},
+						}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: int64(issueID), ProjectID: projectID, FirstSeen: firstSeen}, nil
+					},
+				},
+				MessageStore: &mock.MessageStore{
+					CountMessagesFn: func(_ context.Context, _ int64) (int, error) { return 0, nil },
+				},
+				SubscriptionStore: &mock.SubscriptionStore{
+					ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+				},
+				LabelStore: &mock.IssueLabelStore{ListLabelsFn: func(_ context.Context, _ int64) ([]warnly.IssueLabel, error) {
+					return nil, nil
+				}},
+				SeenStore: newSeenStore(),
+				AnalyticsStore: &mock.AnalyticsStore{
+					ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+						return []warnly.IssueMetrics{{GID: uint64(issueID), FirstSeen: firstSeen, LastSeen: customTime, TimesSeen: 3}}, nil
+					},
+					CountEventsFn: func(_ context.Context, _ *warnly.EventCriteria) (uint64, error) {
+						return 0, nil
+					},
+					CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
+						return nil, nil
+					},
+					CalculateEventGapsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error) {
+						return warnly.NewEventGapHistogram(), nil
+					},
+					CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+						return nil, nil
+					},
+					CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
+						return nil, nil
+					},
+					GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
+						// Frames are stored outermost first, the top frame is the last one.
+						return &warnly.IssueEvent{
+							EventID:                 "event-123",
+							ExceptionFramesAbsPath:  []string{"/app/cmd/shop/main.go", "/app/internal/billing/invoice.go", "/usr/local/go/src/runtime/panic.go"},
+							ExceptionFramesFunction: []string{"main", "Charge", "panic"},
+							ExceptionFramesLineno:   []int{10, 42, 7},
+							ExceptionFramesInApp:    []int{1, 1, 0},
+						}, nil
+					},
+				},
+				Now: func() time.Time { return customTime },
+			})
+
+			result, err := svc.GetIssue(t.Context(), &warnly.GetIssueRequest{
+				User:      &warnly.User{ID: 1},
+				ProjectID: projectID,
+				IssueID:   issueID,
+				Period:    "24h",
+				EventID:   "event-123",
+			})
+			require.NoError(t, err)
+
+			if tt.want == 0 {
+				assert.Nil(t, result.SuggestedAssignee)
+				return
+			}
+			require.NotNil(t, result.SuggestedAssignee)
+			assert.Equal(t, tt.want, result.SuggestedAssignee.ID)
+		})
+	}
+}
+
+func TestSetCodeOwnersRequiresTeammates(t *testing.T) {
+	t.Parallel()
+
+	var stored []warnly.CodeOwner
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+				return &warnly.Project{ID: 5, TeamID: 10}, nil
+			},
+			UpdateCodeOwnersFn: func(_ context.Context, _ int, owners []warnly.CodeOwner) error {
+				stored = owners
+				return nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleAdmin}}, nil
+			},
+			ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+				return []warnly.Teammate{{ID: 1}, {ID: 2}}, nil
+			},
+		},
+	})
+
+	err := svc.SetCodeOwners(t.Context(), &warnly.SetCodeOwnersRequest{
+		User:      &warnly.User{ID: 1},
+		ProjectID: 5,
+		Owners:    []warnly.CodeOwner{{Pattern: "internal/billing/", UserID: 3}},
+	})
+	require.ErrorIs(t, err, warnly.ErrInvalidCodeOwner)
+	assert.Nil(t, stored)
+
+	owners := []warnly.CodeOwner{{Pattern: "internal/billing/", UserID: 2}}
+	err = svc.SetCodeOwners(t.Context(), &warnly.SetCodeOwnersRequest{
+		User:      &warnly.User{ID: 1},
+		ProjectID: 5,
+		Owners:    owners,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, owners, stored)
+}
+
+func TestGetRawEvent(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{"event_id":"0018ce1ba9d34f688814c938b74d8e14","level":"error"}`)
+
+	tests := []struct {
+		expectedError  error
+		name           string
+		expectedRaw    []byte
+		userTeamID     int
+		issueProjectID int
+	}{
+		{
+			name:           "HappyPath",
+			userTeamID:     10,
+			issueProjectID: 5,
+			expectedRaw:    raw,
+		},
+		{
+			name:           "ProjectOfAnotherTeam",
+			userTeamID:     11,
+			issueProjectID: 5,
+			expectedError:  warnly.ErrProjectNotFound,
+		},
+		{
+			name:           "IssueOfAnotherProject",
+			userTeamID:     10,
+			issueProjectID: 6,
+			expectedError:  warnly.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var criteria *warnly.EventDefCriteria
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+						return &warnly.Project{ID: 5, TeamID: 10}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: tt.userTeamID}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: id, ProjectID: tt.issueProjectID}, nil
+					},
+				},
+				AnalyticsStore: &mock.AnalyticsStore{
+					GetRawEventFn: func(_ context.Context, c *warnly.EventDefCriteria) ([]byte, error) {
+						criteria = c
+						return raw, nil
+					},
+				},
+			})
+
+			got, err := svc.GetRawEvent(t.Context(), &warnly.GetRawEventRequest{
+				User:      &warnly.User{ID: 1},
+				EventID:   "0018ce1ba9d34f688814c938b74d8e14",
+				ProjectID: 5,
+				IssueID:   100,
+			})
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, criteria)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRaw, got)
+			assert.Equal(t, &warnly.EventDefCriteria{
+				EventID:   "0018ce1ba9d34f688814c938b74d8e14",
+				GroupID:   100,
+				ProjectID: 5,
+			}, criteria)
+		})
+	}
+}
+
+func TestAssignIssueToTeamRejectsInvalidAssignee(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		req  *warnly.AssignIssueRequest
+		err  error
+	}{
+		{
+			name: "team the user is not a member of",
+			req:  &warnly.AssignIssueRequest{TeamID: 30},
+		},
+		{
+			name: "both user and team",
+			req:  &warnly.AssignIssueRequest{TeamID: 10, UserID: 2},
+			err:  warnly.ErrInvalidAssignee,
+		},
+		{
+			name: "neither user nor team",
+			req:  &warnly.AssignIssueRequest{},
+			err:  warnly.ErrInvalidAssignee,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			teamStore := &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleMember}}, nil
+				},
+				ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+					return []warnly.Teammate{{ID: 1, Name: "John Doe"}, {ID: 2, Name: "Jane Smith"}}, nil
+				},
+			}
+			assignmentStore := &mock.AssingmentStore{
+				CreateAssingmentFn: func(_ context.Context, _ *warnly.Assignment) error {
+					t.Error("invalid assignment must not be stored")
+					return nil
+				},
+			}
+
+			projectStore := &mock.ProjectStore{
+				GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+					return &warnly.Project{ID: 5, TeamID: 10, Name: "Test Project"}, nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore:    projectStore,
+				AssingmentStore: assignmentStore,
+				TeamStore:       teamStore,
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: id, ProjectID: 5}, nil
+					},
+				},
+			})
+
+			tt.req.User = &warnly.User{ID: 1}
+			tt.req.ProjectID = 5
+			tt.req.IssueID = 100
+
+			err := svc.AssignIssue(t.Context(), tt.req)
+			require.Error(t, err)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAssignIssueViewerPermissionDenied(t *testing.T) {
+	t.Parallel()
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleViewer}}, nil
+		},
+		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+			return []warnly.Teammate{{ID: 1, Name: "John Doe"}, {ID: 2, Name: "Jane Smith"}}, nil
+		},
+	}
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{ID: 5, TeamID: 10, Name: "Test Project"}, nil
+		},
+	}
+	assignmentStore := &mock.AssingmentStore{
+		CreateAssingmentFn: func(_ context.Context, _ *warnly.Assignment) error {
+			t.Error("a viewer must not assign issues")
+			return nil
+		},
+	}
+
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		UnitOfWork:      (&mock.UnitOfWork{AssingmentStore: assignmentStore}).Start,
+	})
+
+	err := svc.AssignIssue(t.Context(), &warnly.AssignIssueRequest{
+		User:      &warnly.User{ID: 1},
+		ProjectID: 5,
+		IssueID:   100,
+		UserID:    2,
+	})
+	require.ErrorIs(t, err, warnly.ErrPermissionDenied)
+}
+
+func TestIssueChangesRequireMember(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 1}
+	tests := []struct {
+		name string
+		call func(svc *project.ProjectService) error
+	}{
+		{
+			name: "resolve",
+			call: func(svc *project.ProjectService) error {
+				return svc.ResolveIssue(t.Context(), &warnly.ResolveIssueRequest{User: user, ProjectID: 5, IssueID: 100})
+			},
+		},
+		{
+			name: "ignore",
+			call: func(svc *project.ProjectService) error {
+				return svc.IgnoreIssue(t.Context(), &warnly.IgnoreIssueRequest{
+					User:      user,
+					ProjectID: 5,
+					IssueID:   100,
+					Condition: warnly.IgnoreConditionCount,
+					Threshold: 10,
+				})
+			},
+		},
+		{
+			name: "unassign",
+			call: func(svc *project.ProjectService) error {
+				return svc.DeleteAssignment(t.Context(), &warnly.UnassignIssueRequest{User: user, ProjectID: 5, IssueID: 100})
+			},
+		},
+		{
+			name: "add label",
+			call: func(svc *project.ProjectService) error {
+				return svc.AddLabel(t.Context(), &warnly.IssueLabelRequest{User: user, Label: "regression", ProjectID: 5, IssueID: 100})
+			},
+		},
+		{
+			name: "remove label",
+			call: func(svc *project.ProjectService) error {
+				return svc.RemoveLabel(t.Context(), &warnly.IssueLabelRequest{User: user, Label: "regression", ProjectID: 5, IssueID: 100})
+			},
+		},
+		{
+			name: "add noise filter",
+			call: func(svc *project.ProjectService) error {
+				return svc.AddNoiseFilter(t.Context(), &warnly.NoiseFilterRequest{
+					User: user, Filter: "env=qa", ProjectID: 5, IssueID: 100,
+				})
+			},
+		},
+		{
+			name: "remove noise filter",
+			call: func(svc *project.ProjectService) error {
+				return svc.RemoveNoiseFilter(t.Context(), &warnly.NoiseFilterRequest{
+					User: user, Filter: "env=qa", ProjectID: 5, IssueID: 100,
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the issue is left untouched, the mocks would panic otherwise.
+			svc := newTestService(testService{
+				ProjectStore: &mock.ProjectStore{
+					GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+						return &warnly.Project{ID: id, TeamID: 10}, nil
+					},
+				},
+				TeamStore: &mock.TeamStore{
+					ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+						return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleViewer}}, nil
+					},
+				},
+				IssueStore: &mock.IssueStore{
+					GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+						return &warnly.Issue{ID: id, ProjectID: 5}, nil
+					},
+				},
+			})
+
+			require.ErrorIs(t, tt.call(svc), warnly.ErrPermissionDenied)
+		})
+	}
+}
+
+func TestProjectSettingsRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 1}
+	calls := map[string]func(svc *project.ProjectService) error{
+		"sample rate": func(svc *project.ProjectService) error {
+			return svc.SetSampleRate(t.Context(), &warnly.SetSampleRateRequest{User: user, SampleRate: 0.5, ProjectID: 5})
+		},
+		"dedup window": func(svc *project.ProjectService) error {
+			return svc.SetDedupWindow(t.Context(), &warnly.SetDedupWindowRequest{User: user, Window: time.Minute, ProjectID: 5})
+		},
+		"ingest filters": func(svc *project.ProjectService) error {
+			return svc.SetIngestFilters(t.Context(), &warnly.SetIngestFiltersRequest{User: user, ProjectID: 5})
+		},
+		"options": func(svc *project.ProjectService) error {
+			return svc.UpdateProjectOptions(t.Context(), &warnly.UpdateProjectOptionsRequest{
+				User:          user,
+				ProjectID:     5,
+				Platform:      warnly.PlatformGolang,
+				RetentionDays: 30,
+			})
+		},
+	}
+
+	for _, role := range []warnly.Role{warnly.RoleViewer, warnly.RoleMember} {
+		for name, call := range calls {
+			t.Run(string(role)+" "+name, func(t *testing.T) {
+				t.Parallel()
+
+				// the settings are left untouched, the mocks would panic otherwise.
+				svc := newTestService(testService{
+					ProjectStore: &mock.ProjectStore{
+						GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+							return &warnly.Project{ID: id, TeamID: 10}, nil
+						},
+					},
+					TeamStore: &mock.TeamStore{
+						ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+							return []warnly.Team{{ID: 10, Name: "Backend", Role: role}}, nil
+						},
+					},
+				})
+
+				require.ErrorIs(t, call(svc), warnly.ErrPermissionDenied)
+			})
+		}
+	}
+}
+
+func TestIssueOfAnotherProjectNotFound(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 1}
+	// the issue is neither assigned nor discussed, the mocks would panic otherwise.
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+				return &warnly.Project{ID: id, TeamID: 10}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleMember}}, nil
+			},
+			ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+				return []warnly.Teammate{{ID: 1, Name: "John Doe"}, {ID: 2, Name: "Jane Smith"}}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: 6}, nil
+			},
+		},
+	})
+
+	err := svc.AssignIssue(t.Context(), &warnly.AssignIssueRequest{User: user, ProjectID: 5, IssueID: 100, UserID: 2})
+	require.ErrorIs(t, err, warnly.ErrNotFound)
+
+	_, err = svc.CreateMessage(t.Context(), &warnly.CreateMessageRequest{
+		User:      user,
+		Content:   "<p>hello</p>",
+		ProjectID: 5,
+		IssueID:   100,
+	})
+	require.ErrorIs(t, err, warnly.ErrNotFound)
+}
+
+func TestDeleteProjectRequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 1}
+	for _, role := range []warnly.Role{warnly.RoleViewer, warnly.RoleMember} {
+		t.Run(string(role), func(t *testing.T) {
+			t.Parallel()
+
+			teamStore := &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Backend", Role: role}}, nil
+				},
+			}
+			projectStore := &mock.ProjectStore{
+				GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+					return &warnly.Project{ID: 5, TeamID: 10, Name: "Test Project"}, nil
+				},
+				SoftDeleteFn: func(_ context.Context, _ int, _ time.Time) error {
+					t.Error("only an admin may delete the project")
+					return nil
+				},
+			}
+
+			svc := newTestService(testService{
+				ProjectStore: projectStore,
+				TeamStore:    teamStore,
+			})
+
+			require.ErrorIs(t, svc.DeleteProject(t.Context(), 5, user), warnly.ErrPermissionDenied)
+		})
+	}
+}
+
+func TestTransferAndRestoreProjectRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	user := &warnly.User{ID: 1}
+	deletedAt := time.Now().UTC()
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: 10, Name: "Backend", Role: warnly.RoleMember},
+				{ID: 20, Name: "Frontend", Role: warnly.RoleAdmin},
 			}, nil
 		},
 	}
+	// the project is neither moved nor restored, the mocks would panic otherwise.
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{ID: 5, TeamID: 10, Name: "Test Project"}, nil
+		},
+		GetDeletedProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+			return &warnly.Project{ID: 5, TeamID: 10, Name: "Test Project", DeletedAt: &deletedAt}, nil
+		},
+	}
 
-	messageStore := &mock.MessageStore{
-		CountMessagesFn: func(_ context.Context, _ int64) (int, error) {
-			return 3, nil
+	svc := newTestService(testService{
+		ProjectStore: projectStore,
+		TeamStore:    teamStore,
+	})
+
+	err := svc.TransferProject(t.Context(), &warnly.TransferProjectRequest{User: user, ProjectID: 5, TeamID: 20})
+	require.ErrorIs(t, err, warnly.ErrPermissionDenied)
+	require.ErrorIs(t, svc.RestoreProject(t.Context(), 5, user), warnly.ErrPermissionDenied)
+}
+
+func TestDeleteAssignmentSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	issueID := 100
+
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{
+				{ID: 10, Name: "Team A", Role: warnly.RoleMember},
+			}, nil
 		},
 	}
 
 	assignmentStore := &mock.AssingmentStore{
-		ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
-			return []*warnly.AssignedUser{
-				{
-					IssueID:          int64(issueID),
-					AssignedToUserID: sql.NullInt64{Int64: 1, Valid: true},
-				},
-			}, nil
+		DeleteAssignmentFn: func(_ context.Context, id int64) error {
+			assert.Equal(t, int64(issueID), id)
+			return nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		projectStore,
-		assignmentStore,
-		teamStore,
-		issueStore,
-		messageStore,
-		&mock.MentionStore{},
-		analyticsStore,
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+				return &warnly.Project{ID: projectID, TeamID: 10}, nil
+			},
+		},
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: 5}, nil
+			},
+		},
+	})
 
-	req := &warnly.GetIssueRequest{
+	req := &warnly.UnassignIssueRequest{
 		User:      user,
-		ProjectID: projectID,
+		ProjectID: 5,
 		IssueID:   issueID,
-		Period:    "24h",
-		EventID:   "event-123",
 	}
 
-	result, err := svc.GetIssue(ctx, req)
+	err := svc.DeleteAssignment(ctx, req)
 
-	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, int64(issueID), result.IssueID)
-	assert.Equal(t, projectID, result.ProjectID)
-	assert.Equal(t, "Test Project", result.ProjectName)
-	assert.Equal(t, "RuntimeError", result.ErrorType)
-	assert.Equal(t, "Division by zero", result.ErrorValue)
-	assert.Equal(t, "main.go:42", result.View)
-	assert.Equal(t, warnly.PriorityHigh, result.Priority)
-	assert.Equal(t, issueFirstSeen, result.FirstSeen)
-	assert.Equal(t, lastSeen, result.LastSeen)
-	assert.Equal(t, uint64(150), result.TimesSeen)
-	assert.Equal(t, uint64(25), result.UserCount)
-	assert.Equal(t, uint64(25), result.Total24Hours)
-	assert.Equal(t, uint64(75), result.Total30Days)
-	assert.Equal(t, 3, result.MessagesCount)
-	assert.Equal(t, warnly.PlatformGolang, result.Platform)
-	assert.Len(t, result.Teammates, 2)
-	assert.NotNil(t, result.Assignments)
-	assert.NotNil(t, result.LastEvent)
-	assert.Equal(t, "event-123", result.LastEvent.EventID)
-	assert.False(t, result.IsNew)
+	assert.NoError(t, err)
 }
 
-func TestAssignIssueSuccess(t *testing.T) {
+func TestResolveIssueWithNoteAndNotify(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
-	user := &warnly.User{ID: 1}
-	targetUserID := 2
+	user := &warnly.User{ID: 1, Username: "john"}
 	projectID := 5
 	issueID := 100
 	customTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+			return &warnly.Project{ID: id, Name: "api", TeamID: 10}, nil
+		},
+	}
 	teamStore := &mock.TeamStore{
 		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: 10, Name: "Team A"},
-			}, nil
+			return []warnly.Team{{ID: 10, Name: "Team A", Role: warnly.RoleMember}}, nil
 		},
 		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
 			return []warnly.Teammate{
-				{ID: 1, Name: "John Doe", Email: "john@example.com"},
-				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
+				{ID: 1, Name: "John", Username: "john"},
+				{ID: 2, Name: "Jane", Username: "jane", Email: "jane@example.com"},
+				{ID: 3, Name: "Bob", Username: "bob", Email: "bob@example.com"},
 			}, nil
 		},
 	}
-
 	assignmentStore := &mock.AssingmentStore{
-		CreateAssingmentFn: func(_ context.Context, assignment *warnly.Assignment) error {
-			assert.Equal(t, int64(issueID), assignment.IssueID)
-			assert.Equal(t, int64(targetUserID), assignment.AssignedToUserID)
-			assert.Equal(t, int64(1), assignment.AssignedByUserID)
-			assert.Equal(t, customTime, assignment.AssignedAt)
-			return nil
+		ListAssingmentsFn: func(_ context.Context, issueIDs []int64) ([]*warnly.AssignedUser, error) {
+			assert.Equal(t, []int64{int64(issueID)}, issueIDs)
+			return []*warnly.AssignedUser{
+				{IssueID: int64(issueID), AssignedToUserID: sql.NullInt64{Int64: 2, Valid: true}},
+			}, nil
 		},
 	}
 
-	svc := project.NewProjectService(
-		&mock.ProjectStore{},
-		assignmentStore,
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		func() time.Time { return customTime },
-		slog.Default(),
-	)
+	var steps []string
+	issueStore := &mock.IssueStore{
+		GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+			return &warnly.Issue{ID: id, ProjectID: projectID, Message: "nil pointer dereference"}, nil
+		},
+		UpdateStatusFn: func(_ context.Context, upd *warnly.UpdateIssueStatus) error {
+			steps = append(steps, "status")
+			assert.Equal(t, int64(issueID), upd.IssueID)
+			assert.Equal(t, warnly.IssueStatusResolved, upd.Status)
+			require.NotNil(t, upd.ResolvedAt)
+			assert.Equal(t, customTime, *upd.ResolvedAt)
+			return nil
+		},
+	}
+	messageStore := &mock.MessageStore{
+		CreateMessageFn: func(_ context.Context, message *warnly.Message) error {
+			steps = append(steps, "message")
+			assert.Equal(t, int64(issueID), message.IssueID)
+			assert.Equal(t, 1, message.UserID)
+			assert.Equal(t, "fixed in 1.2.3", message.Content)
+			return nil
+		},
+		ListCommentersFn: func(_ context.Context, id int64) ([]int64, error) {
+			assert.Equal(t, int64(issueID), id)
+			return []int64{1, 3}, nil
+		},
+	}
+	notifier := &mock.IssueNotifier{
+		NotifyIssueResolvedFn: func(_ context.Context, n *warnly.IssueResolvedNotification) error {
+			steps = append(steps, "notify")
+			assert.Equal(t, int64(issueID), n.IssueID)
+			assert.Equal(t, 10, n.TeamID)
+			assert.Equal(t, "api", n.ProjectName)
+			assert.Equal(t, "fixed in 1.2.3", n.Note)
+			assert.Equal(t, user, n.ResolvedBy)
+			require.Len(t, n.Recipients, 2)
+			assert.Equal(t, int64(2), n.Recipients[0].ID)
+			assert.Equal(t, int64(3), n.Recipients[1].ID)
+			return nil
+		},
+	}
+	var activity *warnly.Activity
+	activityStore := &mock.ActivityStore{
+		CreateActivityFn: func(_ context.Context, a *warnly.Activity) error {
+			activity = a
+			return nil
+		},
+	}
+	uw := &mock.UnitOfWork{IssueStore: issueStore, MessageStore: messageStore, ActivityStore: activityStore}
+
+	svc := newTestService(testService{
+		ProjectStore:    projectStore,
+		AssingmentStore: assignmentStore,
+		TeamStore:       teamStore,
+		IssueStore:      issueStore,
+		MessageStore:    messageStore,
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		Notifier:   notifier,
+		UnitOfWork: uw.Start,
+		Policy:     bluemonday.StrictPolicy(),
+		Now:        func() time.Time { return customTime },
+	})
 
-	req := &warnly.AssignIssueRequest{
+	err := svc.ResolveIssue(ctx, &warnly.ResolveIssueRequest{
 		User:      user,
 		ProjectID: projectID,
 		IssueID:   issueID,
-		UserID:    targetUserID,
-	}
+		Note:      "fixed in 1.2.3",
+		Notify:    true,
+	})
 
-	err := svc.AssignIssue(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"status", "message", "notify"}, steps)
+	require.NotNil(t, activity)
+	assert.Equal(t, warnly.ActivityStatusChanged, activity.Type)
+	assert.Equal(t, "resolved", activity.Detail)
+}
 
-	assert.NoError(t, err)
+func TestResolveIssueWithoutNoteAndNotify(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	user := &warnly.User{ID: 1}
+	projectID := 5
+
+	statusUpdated := false
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+				return &warnly.Project{ID: id, TeamID: 10}, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Role: warnly.RoleMember}}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: projectID, Hash: "hash"}, nil
+			},
+		},
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		UnitOfWork: (&mock.UnitOfWork{
+			IssueStore: &mock.IssueStore{
+				UpdateStatusFn: func(_ context.Context, _ *warnly.UpdateIssueStatus) error {
+					statusUpdated = true
+					return nil
+				},
+			},
+			ActivityStore: &mock.ActivityStore{
+				CreateActivityFn: func(_ context.Context, _ *warnly.Activity) error { return nil },
+			},
+		}).Start,
+		Policy: bluemonday.StrictPolicy(),
+	})
+	forgotten := ""
+	svc.SetIngestCache(&mock.IngestCache{
+		ForgetIssueFn: func(projectID int, hash string) { forgotten = fmt.Sprintf("%d:%s", projectID, hash) },
+	})
+
+	err := svc.ResolveIssue(ctx, &warnly.ResolveIssueRequest{User: user, ProjectID: projectID, IssueID: 100})
+
+	require.NoError(t, err)
+	assert.True(t, statusUpdated)
+	assert.Equal(t, "5:hash", forgotten)
 }
 
-func TestDeleteAssignmentSuccess(t *testing.T) {
+func TestIgnoreIssue(t *testing.T) {
 	t.Parallel()
 
 	ctx := t.Context()
 	user := &warnly.User{ID: 1}
-	issueID := 100
+	projectID := 5
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	teamStore := &mock.TeamStore{
-		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
-			return []warnly.Team{
-				{ID: 10, Name: "Team A"},
-			}, nil
+	var (
+		upd      *warnly.UpdateIssueStatus
+		activity *warnly.Activity
+		ignored  *warnly.IssueIgnoredNotification
+		forgot   []string
+	)
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, id int) (*warnly.Project, error) {
+				return &warnly.Project{ID: id, TeamID: 10}, nil
+			},
 		},
-		ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
-			return []warnly.Teammate{
-				{ID: 1, Name: "John Doe", Email: "john@example.com"},
-				{ID: 2, Name: "Jane Smith", Email: "jane@example.com"},
-			}, nil
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Role: warnly.RoleMember}}, nil
+			},
+			ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+				return []warnly.Teammate{{ID: 1, Username: "actor"}, {ID: 2, Username: "watcher"}, {ID: 3}}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, id int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: id, ProjectID: projectID, Hash: "h", Message: "boom"}, nil
+			},
+		},
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(_ context.Context, _ int64) ([]int64, error) {
+				return []int64{1, 2}, nil
+			},
+		},
+		Notifier: &mock.IssueNotifier{
+			NotifyIssueIgnoredFn: func(_ context.Context, n *warnly.IssueIgnoredNotification) error {
+				ignored = n
+				return nil
+			},
+		},
+		UnitOfWork: (&mock.UnitOfWork{
+			IssueStore: &mock.IssueStore{
+				UpdateStatusFn: func(_ context.Context, u *warnly.UpdateIssueStatus) error {
+					upd = u
+					return nil
+				},
+			},
+			ActivityStore: &mock.ActivityStore{
+				CreateActivityFn: func(_ context.Context, a *warnly.Activity) error {
+					activity = a
+					return nil
+				},
+			},
+		}).Start,
+		Policy: bluemonday.StrictPolicy(),
+		Now:    func() time.Time { return now },
+	})
+	svc.SetIngestCache(&mock.IngestCache{
+		ForgetIssueFn: func(projectID int, hash string) {
+			forgot = append(forgot, fmt.Sprintf("%d:%s", projectID, hash))
+		},
+	})
+
+	err := svc.IgnoreIssue(ctx, &warnly.IgnoreIssueRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   100,
+		Condition: warnly.IgnoreConditionCount,
+		Threshold: 10,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &warnly.UpdateIssueStatus{
+		IssueID: 100,
+		Status:  warnly.IssueStatusIgnored,
+		Ignore:  &warnly.IgnoreRule{IgnoredAt: now, Condition: warnly.IgnoreConditionCount, Threshold: 10},
+	}, upd)
+	require.NotNil(t, activity)
+	assert.Equal(t, string(warnly.IssueStatusIgnored), activity.Detail)
+	assert.Equal(t, []string{"5:h"}, forgot, "the next event counts against the ignore condition")
+	require.NotNil(t, ignored, "the subscribers are notified")
+	assert.Equal(t, "boom", ignored.Message)
+	assert.Equal(t, upd.Ignore, ignored.Rule)
+	assert.Equal(t, []warnly.Teammate{{ID: 2, Username: "watcher"}}, ignored.Recipients, "the user who ignored it is left out")
+
+	err = svc.IgnoreIssue(ctx, &warnly.IgnoreIssueRequest{
+		User:      user,
+		ProjectID: projectID,
+		IssueID:   100,
+		Condition: warnly.IgnoreConditionTime,
+	})
+	require.ErrorIs(t, err, warnly.ErrInvalidIgnoreCondition)
+}
+
+func TestSetSourceURLTemplate(t *testing.T) {
+	t.Parallel()
+
+	var stored []string
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+				return &warnly.Project{ID: 5, TeamID: 10}, nil
+			},
+			UpdateSourceURLTemplateFn: func(_ context.Context, projectID int, template string) error {
+				require.Equal(t, 5, projectID)
+				stored = append(stored, template)
+				return nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleAdmin}}, nil
+			},
+		},
+	})
+	user := &warnly.User{ID: 1}
+
+	err := svc.SetSourceURLTemplate(t.Context(), &warnly.SetSourceURLTemplateRequest{
+		User:      user,
+		ProjectID: 5,
+		Template:  "https://github.com/org/repo/blob/main/",
+	})
+	require.ErrorIs(t, err, warnly.ErrInvalidSourceURLTemplate)
+	require.Empty(t, stored)
+
+	err = svc.SetSourceURLTemplate(t.Context(), &warnly.SetSourceURLTemplateRequest{
+		User:      user,
+		ProjectID: 5,
+		Template:  " https://github.com/org/repo/blob/{ref}/{path}#L{line} ",
+	})
+	require.NoError(t, err)
+
+	err = svc.SetSourceURLTemplate(t.Context(), &warnly.SetSourceURLTemplateRequest{
+		User:      user,
+		ProjectID: 5,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"https://github.com/org/repo/blob/{ref}/{path}#L{line}", ""}, stored)
+}
+
+func TestProjectOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := &warnly.ProjectOptions{
+		ID:            5,
+		Name:          "api",
+		TeamID:        10,
+		Platform:      warnly.PlatformGolang,
+		RetentionDays: warnly.DefaultRetentionDays,
+	}
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+				if projectID == 7 {
+					return &warnly.Project{ID: projectID, TeamID: 11, Key: "b4f1e2a"}, nil
+				}
+				return &warnly.Project{ID: projectID, TeamID: 10, Key: "a9d0c3e"}, nil
+			},
+			GetOptionsFn: func(_ context.Context, projectID int, projectKey string) (*warnly.ProjectOptions, error) {
+				require.Equal(t, 5, projectID)
+				require.Equal(t, "a9d0c3e", projectKey)
+				stored := *opts
+				return &stored, nil
+			},
+			UpdateOptionsFn: func(_ context.Context, projectID int, platform warnly.Platform, retentionDays uint8) error {
+				require.Equal(t, 5, projectID)
+				opts.Platform = platform
+				opts.RetentionDays = retentionDays
+				return nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Backend", Role: warnly.RoleAdmin}}, nil
+			},
 		},
+	})
+	user := &warnly.User{ID: 1}
+
+	got, err := svc.GetProjectOptions(t.Context(), 5, user)
+	require.NoError(t, err)
+	assert.Equal(t, "api", got.Name)
+	assert.Equal(t, warnly.PlatformGolang, got.Platform)
+	assert.Equal(t, uint8(warnly.DefaultRetentionDays), got.RetentionDays)
+
+	_, err = svc.GetProjectOptions(t.Context(), 7, user)
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound, "the project belongs to another team")
+
+	for _, days := range []int{0, warnly.MaxRetentionDays + 1} {
+		err = svc.UpdateProjectOptions(t.Context(), &warnly.UpdateProjectOptionsRequest{
+			User:          user,
+			ProjectID:     5,
+			Platform:      warnly.PlatformGolang,
+			RetentionDays: days,
+		})
+		require.ErrorIs(t, err, warnly.ErrInvalidRetention)
 	}
+	err = svc.UpdateProjectOptions(t.Context(), &warnly.UpdateProjectOptionsRequest{
+		User:          user,
+		ProjectID:     5,
+		RetentionDays: 30,
+	})
+	require.ErrorIs(t, err, warnly.ErrInvalidPlatform)
+	err = svc.UpdateProjectOptions(t.Context(), &warnly.UpdateProjectOptionsRequest{
+		User:          user,
+		ProjectID:     7,
+		Platform:      warnly.PlatformGolang,
+		RetentionDays: 30,
+	})
+	require.ErrorIs(t, err, warnly.ErrProjectNotFound)
+
+	err = svc.UpdateProjectOptions(t.Context(), &warnly.UpdateProjectOptionsRequest{
+		User:          user,
+		ProjectID:     5,
+		Platform:      warnly.PlatformRust,
+		RetentionDays: 30,
+	})
+	require.NoError(t, err)
 
-	assignmentStore := &mock.AssingmentStore{
-		DeleteAssignmentFn: func(_ context.Context, id int64) error {
-			assert.Equal(t, int64(issueID), id)
+	got, err = svc.GetProjectOptions(t.Context(), 5, user)
+	require.NoError(t, err)
+	assert.Equal(t, warnly.PlatformRust, got.Platform)
+	assert.Equal(t, uint8(30), got.RetentionDays)
+}
+
+// newSeenStore returns a seen store that keeps a single view per user and issue in memory.
+func newSeenStore() *mock.SeenStore {
+	var mu sync.Mutex
+	views := make(map[[2]int64]warnly.IssueView)
+	return &mock.SeenStore{
+		MarkSeenFn: func(_ context.Context, view *warnly.IssueView) error {
+			mu.Lock()
+			defer mu.Unlock()
+			views[[2]int64{view.IssueID, view.UserID}] = *view
 			return nil
 		},
+		ListSeenByFn: func(_ context.Context, issueID int64) ([]warnly.IssueView, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var res []warnly.IssueView
+			for key, view := range views {
+				if key[0] == issueID {
+					res = append(res, view)
+				}
+			}
+			slices.SortFunc(res, func(a, b warnly.IssueView) int { return b.SeenAt.Compare(a.SeenAt) })
+			return res, nil
+		},
 	}
+}
 
-	svc := project.NewProjectService(
-		&mock.ProjectStore{},
-		assignmentStore,
-		teamStore,
-		&mock.IssueStore{},
-		&mock.MessageStore{},
-		&mock.MentionStore{},
-		&mock.AnalyticsStore{},
-		mock.StartUnitOfWork,
-		bluemonday.NewPolicy(),
-		"localhost:8080",
-		"http",
-		"localhost:8080",
-		"http",
-		time.Now,
-		slog.Default(),
+func TestGetIssueMarksSeen(t *testing.T) {
+	t.Parallel()
+
+	const (
+		projectID = 5
+		issueID   = 100
 	)
 
-	req := &warnly.UnassignIssueRequest{
-		User:      user,
-		ProjectID: 5,
-		IssueID:   issueID,
+	newService := func(seenStore warnly.SeenStore, now *time.Time) *project.ProjectService {
+		return newTestService(testService{
+			ProjectStore: &mock.ProjectStore{
+				GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+					return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+				},
+			},
+			AssingmentStore: &mock.AssingmentStore{
+				ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+					return nil, nil
+				},
+			},
+			TeamStore: &mock.TeamStore{
+				ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+					return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+				},
+				ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+					return []warnly.Teammate{
+						{ID: 1, Name: "John", Surname: "Doe"},
+						{ID: 2, Name: "Jane", Surname: "Smith"},
+					}, nil
+				},
+			},
+			IssueStore: &mock.IssueStore{
+				GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+					return &warnly.Issue{ID: issueID, ProjectID: projectID, FirstSeen: now.Add(-time.Hour)}, nil
+				},
+			},
+			MessageStore: &mock.MessageStore{
+				CountMessagesFn: func(_ context.Context, _ int64) (int, error) {
+					return 0, nil
+				},
+			},
+			SubscriptionStore: &mock.SubscriptionStore{
+				ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+			},
+			LabelStore: &mock.IssueLabelStore{ListLabelsFn: func(_ context.Context, _ int64) ([]warnly.IssueLabel, error) {
+				return nil, nil
+			}},
+			SeenStore: seenStore,
+			AnalyticsStore: &mock.AnalyticsStore{
+				ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					return []warnly.IssueMetrics{{GID: issueID, FirstSeen: now.Add(-time.Hour), LastSeen: *now, TimesSeen: 1}}, nil
+				},
+				CountEventsFn: func(_ context.Context, _ *warnly.EventCriteria) (uint64, error) {
+					return 0, nil
+				},
+				CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
+					return nil, nil
+				},
+				CalculateEventGapsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error) {
+					return warnly.NewEventGapHistogram(), nil
+				},
+				CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+					return nil, nil
+				},
+				CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
+					return nil, nil
+				},
+				GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
+					return &warnly.IssueEvent{EventID: "event-123"}, nil
+				},
+			},
+			Now: func() time.Time { return *now },
+		})
 	}
 
-	err := svc.DeleteAssignment(ctx, req)
+	getIssue := func(t *testing.T, svc *project.ProjectService, userID int64) *warnly.IssueDetails {
+		t.Helper()
+		result, err := svc.GetIssue(t.Context(), &warnly.GetIssueRequest{
+			User:      &warnly.User{ID: userID},
+			ProjectID: projectID,
+			IssueID:   issueID,
+			Period:    "24h",
+		})
+		require.NoError(t, err)
+		return result
+	}
 
-	assert.NoError(t, err)
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	seenStore := newSeenStore()
+	svc := newService(seenStore, &now)
+
+	result := getIssue(t, svc, 1)
+	require.Len(t, result.SeenBy, 1)
+	assert.Equal(t, "JD", result.SeenBy[0].AvatarInitials())
+
+	now = now.Add(time.Hour)
+	getIssue(t, svc, 2)
+	now = now.Add(time.Hour)
+	result = getIssue(t, svc, 1)
+
+	views, err := seenStore.ListSeenBy(t.Context(), issueID)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.Equal(t, int64(1), views[0].UserID)
+	assert.Equal(t, now, views[0].SeenAt)
+	assert.Equal(t, int64(2), views[1].UserID)
+	assert.Equal(t, now.Add(-time.Hour), views[1].SeenAt)
+
+	require.Len(t, result.SeenBy, 2)
+	assert.Equal(t, int64(1), result.SeenBy[0].ID)
+	assert.Equal(t, int64(2), result.SeenBy[1].ID)
+}
+
+func TestGetIssueListsTraceEvents(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	const (
+		projectID = 5
+		issueID   = 100
+		traceID   = "4bf92f3577b34da6a3ce929d0e0e4736"
+	)
+
+	var gotProjectIDs []int
+	svc := newTestService(testService{
+		ProjectStore: &mock.ProjectStore{
+			GetProjectFn: func(_ context.Context, _ int) (*warnly.Project, error) {
+				return &warnly.Project{ID: projectID, TeamID: 10, Name: "Test Project"}, nil
+			},
+			ListProjectsFn: func(_ context.Context, teamIDs []int, _ string) ([]warnly.Project, error) {
+				assert.Equal(t, []int{10}, teamIDs)
+				return []warnly.Project{{ID: projectID, TeamID: 10}, {ID: 6, TeamID: 10}}, nil
+			},
+		},
+		AssingmentStore: &mock.AssingmentStore{
+			ListAssingmentsFn: func(_ context.Context, _ []int64) ([]*warnly.AssignedUser, error) {
+				return nil, nil
+			},
+		},
+		TeamStore: &mock.TeamStore{
+			ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+				return []warnly.Team{{ID: 10, Name: "Team A"}}, nil
+			},
+			ListTeammatesFn: func(_ context.Context, _ []int) ([]warnly.Teammate, error) {
+				return []warnly.Teammate{}, nil
+			},
+		},
+		IssueStore: &mock.IssueStore{
+			GetIssueByIDFn: func(_ context.Context, _ int64) (*warnly.Issue, error) {
+				return &warnly.Issue{ID: issueID, ProjectID: projectID, FirstSeen: now.Add(-time.Hour)}, nil
+			},
+		},
+		MessageStore: &mock.MessageStore{
+			CountMessagesFn: func(_ context.Context, _ int64) (int, error) {
+				return 0, nil
+			},
+		},
+		SubscriptionStore: &mock.SubscriptionStore{
+			ListSubscribersFn: func(context.Context, int64) ([]int64, error) { return nil, nil },
+		},
+		LabelStore: &mock.IssueLabelStore{ListLabelsFn: func(_ context.Context, _ int64) ([]warnly.IssueLabel, error) {
+			return nil, nil
+		}},
+		SeenStore: newSeenStore(),
+		AnalyticsStore: &mock.AnalyticsStore{
+			ListIssueMetricsFn: func(_ context.Context, _ *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+				return []warnly.IssueMetrics{{GID: issueID, FirstSeen: now.Add(-time.Hour), LastSeen: now, TimesSeen: 1}}, nil
+			},
+			CountEventsFn: func(_ context.Context, _ *warnly.EventCriteria) (uint64, error) {
+				return 0, nil
+			},
+			CalculateEventsPerDayFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventPerDay, error) {
+				return nil, nil
+			},
+			CalculateEventGapsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.EventGapBucket, error) {
+				return warnly.NewEventGapHistogram(), nil
+			},
+			CalculateFieldsFn: func(_ context.Context, _ warnly.FieldsCriteria) ([]warnly.TagCount, error) {
+				return nil, nil
+			},
+			CountFieldsFn: func(_ context.Context, _ *warnly.EventDefCriteria) ([]warnly.FieldValueNum, error) {
+				return nil, nil
+			},
+			GetIssueEventFn: func(_ context.Context, _ *warnly.EventDefCriteria) (*warnly.IssueEvent, error) {
+				return &warnly.IssueEvent{EventID: "event-123", TraceID: traceID}, nil
+			},
+			ListEventsByTraceFn: func(_ context.Context, id string, projectIDs []int) ([]warnly.TraceEvent, error) {
+				assert.Equal(t, traceID, id)
+				gotProjectIDs = projectIDs
+				return []warnly.TraceEvent{
+					{EventID: "event-456", GroupID: 200, ProjectID: 6, Title: "connection refused"},
+					{EventID: "event-123", GroupID: issueID, ProjectID: projectID},
+				}, nil
+			},
+		},
+		Now: func() time.Time { return now },
+	})
+
+	result, err := svc.GetIssue(t.Context(), &warnly.GetIssueRequest{
+		User:      &warnly.User{ID: 1},
+		ProjectID: projectID,
+		IssueID:   issueID,
+		Period:    "24h",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{projectID, 6}, gotProjectIDs)
+	assert.Equal(t, traceID, result.TraceID())
+	require.Len(t, result.TraceEvents, 1, "the displayed event is not listed")
+	assert.Equal(t, int64(200), result.TraceEvents[0].GroupID)
+	assert.Equal(t, 6, result.TraceEvents[0].ProjectID)
 }
@@ -0,0 +1,42 @@
+package project
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Names of the sanitizer policies applied to issue discussion messages and resolution notes.
+const (
+	// SanitizerPolicyUGC allows the HTML of user generated content, including images and tables.
+	SanitizerPolicyUGC = "ugc"
+	// SanitizerPolicyBasic allows text formatting, lists, quotes, code blocks and links, but no images.
+	SanitizerPolicyBasic = "basic"
+	// SanitizerPolicyStrict strips all HTML and keeps the text only.
+	SanitizerPolicyStrict = "strict"
+)
+
+var codeLanguageClass = regexp.MustCompile(`^language-[a-zA-Z0-9_+-]+$`)
+
+// NewSanitizerPolicy builds the sanitizer policy with the name, SanitizerPolicyUGC when the name is empty.
+func NewSanitizerPolicy(name string) (*bluemonday.Policy, error) {
+	switch name {
+	case "", SanitizerPolicyUGC:
+		return bluemonday.UGCPolicy(), nil
+	case SanitizerPolicyBasic:
+		p := bluemonday.NewPolicy()
+		p.AllowElements("p", "br", "b", "strong", "i", "em", "u", "s", "del",
+			"blockquote", "ul", "ol", "li", "pre", "code")
+		p.AllowAttrs("class").Matching(codeLanguageClass).OnElements("code")
+		p.AllowStandardURLs()
+		p.AllowAttrs("href").OnElements("a")
+		p.RequireNoFollowOnLinks(true)
+		p.AddTargetBlankToFullyQualifiedLinks(true)
+		return p, nil
+	case SanitizerPolicyStrict:
+		return bluemonday.StrictPolicy(), nil
+	default:
+		return nil, fmt.Errorf("unknown sanitizer policy %q", name)
+	}
+}
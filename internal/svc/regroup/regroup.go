@@ -0,0 +1,433 @@
+// Package regroup provides the implementation of the warnly.RegroupService interface.
+package regroup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// DefaultBatchSize is the number of events regrouped at once when the batch size isn't set.
+const DefaultBatchSize = 1000
+
+// maxPendingMoves is the number of moved events collected over batches before they are written
+// with a single mutation, so that a run causes few mutations.
+const maxPendingMoves = 10000
+
+// RegroupService regroups stored events of a project with its current grouping options,
+// moving events to the issues they would be grouped into if they were ingested now.
+// Each project has at most one running job, progress is kept in memory of the instance running it.
+type RegroupService struct {
+	projectStore   warnly.ProjectStore
+	teamStore      warnly.TeamStore
+	issueStore     warnly.IssueStore
+	analyticsStore warnly.AnalyticsStore
+	jobs           map[int]*job
+	now            func() time.Time
+	logger         *slog.Logger
+	wg             sync.WaitGroup
+	batchSize      int
+	mu             sync.Mutex
+}
+
+// job is a regroup job of a project.
+type job struct {
+	cancel   context.CancelFunc
+	progress warnly.RegroupProgress
+}
+
+// regroupedIssue is an issue events are grouped into by the job.
+type regroupedIssue struct {
+	lastSeen  time.Time
+	message   string
+	errorType string
+	view      string
+	id        int64
+}
+
+// NewRegroupService is a constructor of RegroupService.
+// DefaultBatchSize is used when batchSize is not positive.
+func NewRegroupService(
+	projectStore warnly.ProjectStore,
+	teamStore warnly.TeamStore,
+	issueStore warnly.IssueStore,
+	analyticsStore warnly.AnalyticsStore,
+	batchSize int,
+	now func() time.Time,
+	logger *slog.Logger,
+) *RegroupService {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &RegroupService{
+		projectStore:   projectStore,
+		teamStore:      teamStore,
+		issueStore:     issueStore,
+		analyticsStore: analyticsStore,
+		jobs:           make(map[int]*job),
+		batchSize:      batchSize,
+		now:            now,
+		logger:         logger,
+	}
+}
+
+// StartRegroup starts regrouping the events of a project in the background.
+// Returns warnly.ErrRegroupRunning if the project's events are already being regrouped.
+func (s *RegroupService) StartRegroup(ctx context.Context, req *warnly.RegroupRequest) (*warnly.RegroupProgress, error) {
+	project, err := s.getProject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := s.projectStore.GetOptions(ctx, project.ID, project.Key)
+	if err != nil {
+		return nil, fmt.Errorf("regroup service start: get project options: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if j, ok := s.jobs[project.ID]; ok && j.progress.Status == warnly.RegroupRunning {
+		return nil, warnly.ErrRegroupRunning
+	}
+
+	// The job outlives the request that started it.
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	j := &job{
+		cancel: cancel,
+		progress: warnly.RegroupProgress{
+			StartedAt: s.now().UTC(),
+			Status:    warnly.RegroupRunning,
+			ProjectID: project.ID,
+		},
+	}
+	s.jobs[project.ID] = j
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(jobCtx, j, opts)
+	}()
+
+	progress := j.progress
+	return &progress, nil
+}
+
+// GetRegroup returns the progress of the last regroup job of a project.
+// Returns warnly.ErrNotFound if the project's events were not regrouped since the start.
+func (s *RegroupService) GetRegroup(ctx context.Context, req *warnly.RegroupRequest) (*warnly.RegroupProgress, error) {
+	project, err := s.getProject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[project.ID]
+	if !ok {
+		return nil, fmt.Errorf("regroup service get: project %d: %w", project.ID, warnly.ErrNotFound)
+	}
+
+	progress := j.progress
+	return &progress, nil
+}
+
+// CancelRegroup cancels the running regroup job of a project, batches already processed stay regrouped.
+// Returns warnly.ErrNotFound if no job of the project is running.
+func (s *RegroupService) CancelRegroup(ctx context.Context, req *warnly.RegroupRequest) error {
+	project, err := s.getProject(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[project.ID]
+	if !ok || j.progress.Status != warnly.RegroupRunning {
+		return fmt.Errorf("regroup service cancel: project %d: %w", project.ID, warnly.ErrNotFound)
+	}
+	j.cancel()
+
+	return nil
+}
+
+// Stop cancels running jobs and waits for them to finish.
+func (s *RegroupService) Stop() {
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		j.cancel()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// getProject returns the project if it belongs to one of the user teams the user is an admin of,
+// regrouping moves the events of the whole project.
+// Returns warnly.ErrPermissionDenied if the user isn't an admin of the project's team.
+func (s *RegroupService) getProject(ctx context.Context, req *warnly.RegroupRequest) (*warnly.Project, error) {
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectStore.GetProject(ctx, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range teams {
+		if teams[i].ID != project.TeamID {
+			continue
+		}
+		if !teams[i].Role.Allows(warnly.RoleAdmin) {
+			return nil, warnly.ErrPermissionDenied
+		}
+		return project, nil
+	}
+
+	return nil, warnly.ErrProjectNotFound
+}
+
+// run regroups the events of the job's project and records how the job ended.
+func (s *RegroupService) run(ctx context.Context, j *job, opts *warnly.ProjectOptions) {
+	defer j.cancel()
+
+	err := s.regroup(ctx, j, opts)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j.progress.FinishedAt = s.now().UTC()
+	switch {
+	case err == nil:
+		j.progress.Status = warnly.RegroupDone
+	case ctx.Err() != nil:
+		j.progress.Status = warnly.RegroupCanceled
+	default:
+		j.progress.Status = warnly.RegroupFailed
+		j.progress.Error = err.Error()
+		s.logger.Error("regroup project events",
+			slog.Int("project_id", j.progress.ProjectID),
+			slog.Any("error", err))
+	}
+}
+
+// regroup reads the project's events in batches, moves the events whose group changed
+// and updates the last seen time of the issues events were grouped into.
+// Moves are collected over batches and written when enough of them are pending, on cancellation as well,
+// since reading the next batch doesn't depend on the group of the events.
+// Once all events are regrouped, the issues all of their events were moved from are deleted.
+func (s *RegroupService) regroup(ctx context.Context, j *job, opts *warnly.ProjectOptions) (err error) {
+	issues := make(map[string]*regroupedIssue)
+	// left are the issues events were moved from, kept are the issues that still have events.
+	left, kept := make(map[uint64]struct{}), make(map[uint64]struct{})
+	criteria := &warnly.RawEventsCriteria{ProjectID: opts.ID, Limit: s.batchSize}
+	moves := &pendingMoves{byGroup: make(map[uint64][]string)}
+	defer func() {
+		if flushErr := s.flushMoves(context.WithoutCancel(ctx), j, opts.ID, moves); flushErr != nil {
+			err = errors.Join(err, flushErr)
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		events, err := s.analyticsStore.ListRawEvents(ctx, criteria)
+		if err != nil {
+			return err
+		}
+
+		skipped, newIssues := 0, 0
+		for i := range events {
+			issue, created, err := s.groupEvent(ctx, issues, opts, &events[i])
+			if err != nil {
+				return err
+			}
+			if issue == nil {
+				skipped++
+				kept[events[i].GroupID] = struct{}{}
+				continue
+			}
+			if created {
+				newIssues++
+			}
+			gid := uint64(issue.id)
+			kept[gid] = struct{}{}
+			if gid != events[i].GroupID {
+				moves.add(gid, events[i].EventID)
+				left[events[i].GroupID] = struct{}{}
+			}
+		}
+
+		s.mu.Lock()
+		j.progress.Processed += len(events)
+		j.progress.Skipped += skipped
+		j.progress.NewIssues += newIssues
+		s.mu.Unlock()
+
+		if moves.count >= maxPendingMoves {
+			if err := s.flushMoves(ctx, j, opts.ID, moves); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < criteria.Limit {
+			break
+		}
+		last := events[len(events)-1]
+		criteria.AfterCreatedAt, criteria.AfterEventID = last.CreatedAt, last.EventID
+	}
+
+	for _, issue := range issues {
+		if err := s.issueStore.UpdateLastSeen(ctx, &warnly.UpdateLastSeen{
+			LastSeen:  issue.lastSeen,
+			Message:   issue.message,
+			ErrorType: issue.errorType,
+			View:      issue.view,
+			IssueID:   issue.id,
+		}); err != nil {
+			return fmt.Errorf("regroup service: update last seen of issue %d: %w", issue.id, err)
+		}
+	}
+
+	// The events are moved before the issues they were moved from are deleted.
+	if err := s.flushMoves(ctx, j, opts.ID, moves); err != nil {
+		return err
+	}
+
+	return s.deleteEmptyIssues(ctx, j, left, kept)
+}
+
+// deleteEmptyIssues deletes the issues events were moved from that have no events left.
+func (s *RegroupService) deleteEmptyIssues(ctx context.Context, j *job, left, kept map[uint64]struct{}) error {
+	empty := make([]int64, 0, len(left))
+	for gid := range left {
+		if _, ok := kept[gid]; !ok {
+			empty = append(empty, int64(gid))
+		}
+	}
+	if len(empty) == 0 {
+		return nil
+	}
+	slices.Sort(empty)
+
+	if err := s.issueStore.DeleteIssues(ctx, empty); err != nil {
+		return fmt.Errorf("regroup service: delete empty issues: %w", err)
+	}
+
+	s.mu.Lock()
+	j.progress.DeletedIssues += len(empty)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// pendingMoves are the events moved to other groups that aren't written yet.
+type pendingMoves struct {
+	byGroup map[uint64][]string
+	count   int
+}
+
+func (m *pendingMoves) add(gid uint64, eventID string) {
+	m.byGroup[gid] = append(m.byGroup[gid], eventID)
+	m.count++
+}
+
+// flushMoves writes the pending moves with a single mutation and counts them as regrouped.
+func (s *RegroupService) flushMoves(ctx context.Context, j *job, projectID int, moves *pendingMoves) error {
+	if moves.count == 0 {
+		return nil
+	}
+
+	if err := s.analyticsStore.RegroupEvents(ctx, &warnly.RegroupEventsCriteria{
+		Moves:     moves.byGroup,
+		ProjectID: projectID,
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	j.progress.Regrouped += moves.count
+	s.mu.Unlock()
+
+	moves.byGroup = make(map[uint64][]string)
+	moves.count = 0
+
+	return nil
+}
+
+// groupEvent returns the issue the event is grouped into with the project's grouping options,
+// the issue is created if it doesn't exist. Events without a payload are skipped, then the issue is nil.
+func (s *RegroupService) groupEvent(
+	ctx context.Context,
+	issues map[string]*regroupedIssue,
+	opts *warnly.ProjectOptions,
+	ev *warnly.RawEvent,
+) (*regroupedIssue, bool, error) {
+	if len(ev.Raw) == 0 {
+		return nil, false, nil
+	}
+
+	event := warnly.EventBody{}
+	if err := json.Unmarshal(ev.Raw, &event); err != nil {
+		return nil, false, nil //nolint:nilerr // payloads that can't be parsed are skipped
+	}
+
+	hash, err := warnly.GetGroupingHash(&event, opts.Grouping, opts.GroupingRules)
+	if err != nil {
+		return nil, false, err
+	}
+	warnly.ApplyInApp(&event, opts.Platform, opts.GroupingRules)
+
+	exceptionType := warnly.GetExceptionType(event.Exception, event.Message)
+	exceptionValue := warnly.GetExceptionValue(event.Exception, warnly.DefaultMessage)
+	view := warnly.GetBreaker(event.Exception, opts.GroupingRules)
+
+	created := false
+	issue, ok := issues[hash]
+	if !ok {
+		stored, err := s.issueStore.GetIssue(ctx, warnly.GetIssueCriteria{ProjectID: opts.ID, Hash: hash})
+		if err != nil {
+			if !errors.Is(err, warnly.ErrNotFound) {
+				return nil, false, fmt.Errorf("regroup service: get issue: %w", err)
+			}
+			stored = &warnly.Issue{
+				UUID:      warnly.NewUUID(),
+				FirstSeen: ev.CreatedAt,
+				LastSeen:  ev.CreatedAt,
+				Hash:      hash,
+				Message:   exceptionValue,
+				ErrorType: exceptionType,
+				View:      view,
+				ProjectID: opts.ID,
+				Priority:  warnly.DefaultIssuePriority,
+			}
+			if err := s.issueStore.StoreIssue(ctx, stored); err != nil {
+				return nil, false, fmt.Errorf("regroup service: store issue: %w", err)
+			}
+			created = true
+		}
+		issue = &regroupedIssue{id: stored.ID}
+		issues[hash] = issue
+	}
+
+	// Events are read the oldest first, so the issue ends up with the latest event details.
+	issue.lastSeen = ev.CreatedAt
+	issue.message = exceptionValue
+	issue.errorType = exceptionType
+	issue.view = view
+
+	return issue, created, nil
+}
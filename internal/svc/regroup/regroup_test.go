@@ -0,0 +1,236 @@
+package regroup_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/svc/regroup"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+const (
+	testProjectID = 1
+	testTeamID    = 2
+)
+
+func newStores(options *warnly.ProjectOptions) (*mock.ProjectStore, *mock.TeamStore) {
+	projectStore := &mock.ProjectStore{
+		GetProjectFn: func(_ context.Context, projectID int) (*warnly.Project, error) {
+			return &warnly.Project{ID: projectID, TeamID: testTeamID, Key: "d7a8fbb3"}, nil
+		},
+		GetOptionsFn: func(_ context.Context, _ int, _ string) (*warnly.ProjectOptions, error) {
+			return options, nil
+		},
+	}
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: testTeamID, Role: warnly.RoleAdmin}}, nil
+		},
+	}
+	return projectStore, teamStore
+}
+
+func rawEvent(t *testing.T, createdAt time.Time, eventID string, groupID uint64, exceptionType, value string) warnly.RawEvent {
+	t.Helper()
+
+	raw, err := json.Marshal(map[string]any{
+		"event_id": eventID,
+		"platform": "python",
+		"exception": map[string]any{"values": []map[string]any{{
+			"type":       exceptionType,
+			"value":      value,
+			"stacktrace": map[string]any{"frames": []map[string]any{{"module": "app.db", "function": "execute", "lineno": 42, "in_app": true}}},
+		}}},
+	})
+	require.NoError(t, err)
+
+	return warnly.RawEvent{CreatedAt: createdAt, EventID: eventID, Raw: raw, GroupID: groupID}
+}
+
+func waitFinished(t *testing.T, svc *regroup.RegroupService, req *warnly.RegroupRequest) *warnly.RegroupProgress {
+	t.Helper()
+
+	var progress *warnly.RegroupProgress
+	require.Eventually(t, func() bool {
+		var err error
+		progress, err = svc.GetRegroup(t.Context(), req)
+		require.NoError(t, err)
+		return progress.Status != warnly.RegroupRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return progress
+}
+
+func TestRegroupAppliesNewGroupingRules(t *testing.T) {
+	t.Parallel()
+
+	rules := &warnly.GroupingRules{IgnoreMessageTypes: []string{"OperationalError"}}
+	projectStore, teamStore := newStores(&warnly.ProjectOptions{ID: testProjectID, GroupingRules: rules})
+
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	events := []warnly.RawEvent{
+		rawEvent(t, start, "0b7e1c2d3f4a5b6c7d8e9f0a1b2c3d4e", 1, "OperationalError", "statement timeout on orders"),
+		rawEvent(t, start.Add(time.Minute), "1c8f2d3e4a5b6c7d8e9f0a1b2c3d4e5f", 3, "ValueError", "invalid literal for int()"),
+		rawEvent(t, start.Add(2*time.Minute), "2d9a3e4f5b6c7d8e9f0a1b2c3d4e5f6a", 2, "OperationalError", "lock timeout on invoices"),
+		rawEvent(t, start.Add(3*time.Minute), "3e0b4f5a6c7d8e9f0a1b2c3d4e5f6a7b", 1, "OperationalError", "statement timeout on orders"),
+		// events stored before payloads were kept can't be regrouped.
+		{CreatedAt: start.Add(4 * time.Minute), EventID: "4f1c5a6b7d8e9f0a1b2c3d4e5f6a7b8c", GroupID: 1},
+	}
+
+	var valueErrorBody warnly.EventBody
+	require.NoError(t, json.Unmarshal(events[1].Raw, &valueErrorBody))
+	valueErrorHash, err := warnly.GetGroupingHash(&valueErrorBody, warnly.GroupingByMessage, rules)
+	require.NoError(t, err)
+
+	var stored []warnly.Issue
+	var deleted []int64
+	lastSeen := make(map[int64]time.Time)
+	issueStore := &mock.IssueStore{
+		GetIssueFn: func(_ context.Context, criteria warnly.GetIssueCriteria) (*warnly.Issue, error) {
+			if criteria.Hash == valueErrorHash {
+				return &warnly.Issue{ID: 3, Hash: valueErrorHash, ProjectID: testProjectID}, nil
+			}
+			return nil, warnly.ErrNotFound
+		},
+		StoreIssueFn: func(_ context.Context, issue *warnly.Issue) error {
+			issue.ID = 10
+			stored = append(stored, *issue)
+			return nil
+		},
+		UpdateLastSeenFn: func(_ context.Context, upd *warnly.UpdateLastSeen) error {
+			lastSeen[upd.IssueID] = upd.LastSeen
+			return nil
+		},
+		DeleteIssuesFn: func(_ context.Context, issueIDs []int64) error {
+			deleted = append(deleted, issueIDs...)
+			return nil
+		},
+	}
+
+	var batches, mutations int
+	moved := make(map[string]uint64)
+	analyticsStore := &mock.AnalyticsStore{
+		ListRawEventsFn: func(_ context.Context, criteria *warnly.RawEventsCriteria) ([]warnly.RawEvent, error) {
+			assert.Equal(t, testProjectID, criteria.ProjectID)
+			batches++
+			from := 0
+			for i := range events {
+				if criteria.AfterEventID == events[i].EventID {
+					from = i + 1
+				}
+			}
+			return events[from:min(from+criteria.Limit, len(events))], nil
+		},
+		RegroupEventsFn: func(_ context.Context, criteria *warnly.RegroupEventsCriteria) error {
+			mutations++
+			for gid, eventIDs := range criteria.Moves {
+				for _, eventID := range eventIDs {
+					moved[eventID] = gid
+				}
+			}
+			return nil
+		},
+	}
+
+	now := func() time.Time { return start.Add(time.Hour) }
+	svc := regroup.NewRegroupService(projectStore, teamStore, issueStore, analyticsStore, 2, now, slog.Default())
+	t.Cleanup(svc.Stop)
+
+	req := &warnly.RegroupRequest{User: &warnly.User{ID: 1}, ProjectID: testProjectID}
+	progress, err := svc.StartRegroup(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, warnly.RegroupRunning, progress.Status)
+
+	progress = waitFinished(t, svc, req)
+	assert.Equal(t, warnly.RegroupDone, progress.Status)
+	assert.Equal(t, 5, progress.Processed)
+	assert.Equal(t, 3, progress.Regrouped)
+	assert.Equal(t, 1, progress.Skipped)
+	assert.Equal(t, 1, progress.NewIssues)
+	assert.Equal(t, 1, progress.DeletedIssues)
+	assert.Equal(t, 3, batches)
+	assert.Equal(t, 1, mutations, "moves of all batches are written at once")
+
+	// OperationalError events with different messages are rebuilt into a single new issue.
+	require.Len(t, stored, 1)
+	assert.Equal(t, "OperationalError", stored[0].ErrorType)
+	assert.Equal(t, start, stored[0].FirstSeen)
+	assert.Equal(t, map[string]uint64{
+		"0b7e1c2d3f4a5b6c7d8e9f0a1b2c3d4e": 10,
+		"2d9a3e4f5b6c7d8e9f0a1b2c3d4e5f6a": 10,
+		"3e0b4f5a6c7d8e9f0a1b2c3d4e5f6a7b": 10,
+	}, moved)
+	assert.Equal(t, map[int64]time.Time{
+		3:  start.Add(time.Minute),
+		10: start.Add(3 * time.Minute),
+	}, lastSeen)
+	// the skipped event stays in issue 1, issue 2 has no events left.
+	assert.Equal(t, []int64{2}, deleted)
+}
+
+func TestRegroupCancel(t *testing.T) {
+	t.Parallel()
+
+	projectStore, teamStore := newStores(&warnly.ProjectOptions{ID: testProjectID})
+	listing := make(chan struct{})
+	analyticsStore := &mock.AnalyticsStore{
+		ListRawEventsFn: func(ctx context.Context, _ *warnly.RawEventsCriteria) ([]warnly.RawEvent, error) {
+			close(listing)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	svc := regroup.NewRegroupService(projectStore, teamStore, &mock.IssueStore{}, analyticsStore, 0, now, slog.Default())
+	t.Cleanup(svc.Stop)
+
+	req := &warnly.RegroupRequest{User: &warnly.User{ID: 1}, ProjectID: testProjectID}
+	_, err := svc.GetRegroup(t.Context(), req)
+	require.ErrorIs(t, err, warnly.ErrNotFound)
+
+	_, err = svc.StartRegroup(t.Context(), req)
+	require.NoError(t, err)
+	<-listing
+
+	_, err = svc.StartRegroup(t.Context(), req)
+	require.ErrorIs(t, err, warnly.ErrRegroupRunning)
+
+	require.NoError(t, svc.CancelRegroup(t.Context(), req))
+
+	progress := waitFinished(t, svc, req)
+	assert.Equal(t, warnly.RegroupCanceled, progress.Status)
+	assert.Equal(t, now(), progress.FinishedAt)
+	require.ErrorIs(t, svc.CancelRegroup(t.Context(), req), warnly.ErrNotFound)
+}
+
+func TestRegroupRequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	projectStore, _ := newStores(&warnly.ProjectOptions{ID: testProjectID})
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: testTeamID, Role: warnly.RoleMember}}, nil
+		},
+	}
+
+	// the events aren't read, the mocks would panic otherwise.
+	now := func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	svc := regroup.NewRegroupService(projectStore, teamStore, &mock.IssueStore{}, &mock.AnalyticsStore{}, 0, now, slog.Default())
+	t.Cleanup(svc.Stop)
+
+	req := &warnly.RegroupRequest{User: &warnly.User{ID: 1}, ProjectID: testProjectID}
+	_, err := svc.StartRegroup(t.Context(), req)
+	require.ErrorIs(t, err, warnly.ErrPermissionDenied)
+
+	_, err = svc.GetRegroup(t.Context(), req)
+	require.ErrorIs(t, err, warnly.ErrPermissionDenied)
+
+	require.ErrorIs(t, svc.CancelRegroup(t.Context(), req), warnly.ErrPermissionDenied)
+}
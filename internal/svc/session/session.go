@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,13 +15,27 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// InvitationOptions configures invitations of teammates.
+type InvitationOptions struct {
+	// Sender delivers invitations, links are only returned to the inviting admin when nil.
+	Sender warnly.InvitationSender
+	// BaseURL is the scheme and host the invitation links point to.
+	BaseURL string
+	// Key signs invitation tokens.
+	Key []byte
+	// TTL is how long an invitation can be accepted, DefaultInvitationTTL when zero.
+	TTL time.Duration
+}
+
 // SessionService provides user session operations.
 type SessionService struct {
-	sessionStore warnly.SessionStore
-	userStore    warnly.UserStore
-	teamStore    warnly.TeamStore
-	uow          uow.StartUnitOfWork
-	now          func() time.Time
+	sessionStore    warnly.SessionStore
+	userStore       warnly.UserStore
+	teamStore       warnly.TeamStore
+	invitationStore warnly.InvitationStore
+	uow             uow.StartUnitOfWork
+	now             func() time.Time
+	invitations     InvitationOptions
 }
 
 // NewSessionService is a constructor of SessionService.
@@ -28,15 +43,22 @@ func NewSessionService(
 	sessionStore warnly.SessionStore,
 	userStore warnly.UserStore,
 	teamStore warnly.TeamStore,
+	invitationStore warnly.InvitationStore,
 	uw uow.StartUnitOfWork,
+	invitations InvitationOptions,
 	now func() time.Time,
 ) *SessionService {
+	if invitations.TTL <= 0 {
+		invitations.TTL = warnly.DefaultInvitationTTL
+	}
 	return &SessionService{
-		sessionStore: sessionStore,
-		userStore:    userStore,
-		teamStore:    teamStore,
-		uow:          uw,
-		now:          now,
+		sessionStore:    sessionStore,
+		userStore:       userStore,
+		teamStore:       teamStore,
+		invitationStore: invitationStore,
+		uow:             uw,
+		invitations:     invitations,
+		now:             now,
 	}
 }
 
@@ -131,7 +153,7 @@ func (s *SessionService) GetOrCreateUser(ctx context.Context, req *warnly.GetOrC
 		if err != nil {
 			return err
 		}
-		return uw.Teams().AddUserToTeam(ctx, s.now().UTC(), userID, warnly.DefaultTeamID)
+		return uw.Teams().AddUserToTeam(ctx, s.now().UTC(), userID, warnly.DefaultTeamID, warnly.RoleMember)
 	}, s.userStore, s.teamStore)
 	if err != nil {
 		return nil, err
@@ -144,3 +166,165 @@ func (s *SessionService) GetOrCreateUser(ctx context.Context, req *warnly.GetOrC
 
 	return &warnly.Session{User: user}, nil
 }
+
+// Invite invites a person to the team by email. Only team admins can invite,
+// the invitation is emailed when a sender is configured.
+func (s *SessionService) Invite(ctx context.Context, req *warnly.InviteRequest) (*warnly.InviteResult, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if err := warnly.ValidateEmail(email); err != nil {
+		return nil, err
+	}
+
+	role := req.Role
+	if role == "" {
+		role = warnly.RoleMember
+	}
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: %q", warnly.ErrInvalidRole, role)
+	}
+
+	teams, err := s.teamStore.ListTeams(ctx, int(req.User.ID))
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(teams, func(t warnly.Team) bool { return t.ID == req.TeamID })
+	if idx == -1 {
+		return nil, warnly.ErrTeamNotFound
+	}
+	team := teams[idx]
+	if !team.Role.Allows(warnly.RoleAdmin) {
+		return nil, warnly.ErrPermissionDenied
+	}
+
+	if err := s.ensureNoUser(ctx, email); err != nil {
+		return nil, err
+	}
+
+	// datetime columns keep whole seconds, the signature must survive the round trip.
+	now := s.now().UTC().Truncate(time.Second)
+	inv := &warnly.Invitation{
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.invitations.TTL),
+		Email:     email,
+		Role:      role,
+		InvitedBy: req.User.ID,
+		TeamID:    team.ID,
+	}
+	inv.ID, err = s.invitationStore.CreateInvitation(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &warnly.InviteResult{
+		Invitation: inv,
+		Link:       s.invitations.BaseURL + "/invitations/" + warnly.SignInvitation(s.invitations.Key, inv),
+	}
+
+	if s.invitations.Sender != nil {
+		err := s.invitations.Sender.SendInvitation(ctx, &warnly.InvitationMail{
+			ExpiresAt: inv.ExpiresAt,
+			Email:     inv.Email,
+			TeamName:  team.Name,
+			Inviter:   req.User.Email,
+			Link:      result.Link,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("send invitation: %w", err)
+		}
+		result.Sent = true
+	}
+
+	return result, nil
+}
+
+// GetInvitation returns the invitation the token was issued for if it can still be accepted.
+func (s *SessionService) GetInvitation(ctx context.Context, token string) (*warnly.Invitation, error) {
+	id, sig, err := warnly.ParseInvitationToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := s.invitationStore.GetInvitation(ctx, id)
+	if err != nil {
+		if errors.Is(err, warnly.ErrNotFound) {
+			return nil, warnly.ErrInvalidInvitation
+		}
+		return nil, err
+	}
+
+	if !warnly.VerifyInvitation(s.invitations.Key, inv, sig) {
+		return nil, warnly.ErrInvalidInvitation
+	}
+	if inv.AcceptedAt != nil {
+		return nil, warnly.ErrInvitationUsed
+	}
+	if inv.Expired(s.now().UTC()) {
+		return nil, warnly.ErrInvitationExpired
+	}
+
+	return inv, nil
+}
+
+// AcceptInvitation creates the invited user with the chosen password
+// and adds them to the team with the role of the invitation.
+func (s *SessionService) AcceptInvitation(ctx context.Context, req *warnly.AcceptInvitationRequest) (*warnly.Session, error) {
+	inv, err := s.GetInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Password) < warnly.MinPasswordLen {
+		return nil, warnly.ErrPasswordTooShort
+	}
+
+	if err := s.ensureNoUser(ctx, inv.Email); err != nil {
+		return nil, err
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		username, err = warnly.UsernameFromEmail(inv.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("generate password: %w", err)
+	}
+
+	var user *warnly.User
+	now := s.now().UTC()
+	err = s.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+		// marking the invitation accepted first rejects a token used concurrently.
+		if err := uw.Invitations().AcceptInvitation(ctx, inv.ID, now); err != nil {
+			return err
+		}
+		if err := uw.Users().CreateUser(ctx, inv.Email, username, hashedPassword); err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+		user, err = uw.Users().GetUser(ctx, inv.Email)
+		if err != nil {
+			return fmt.Errorf("get created user: %w", err)
+		}
+		return uw.Teams().AddUserToTeam(ctx, now, user.ID, inv.TeamID, inv.Role)
+	}, s.invitationStore, s.userStore, s.teamStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return &warnly.Session{User: user}, nil
+}
+
+// ensureNoUser returns ErrUserExists if there is a user with the email.
+func (s *SessionService) ensureNoUser(ctx context.Context, email string) error {
+	user, err := s.userStore.GetUser(ctx, email)
+	if err != nil && !errors.Is(err, warnly.ErrNotFound) {
+		return err
+	}
+	if user != nil {
+		return warnly.ErrUserExists
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package session_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/svc/session"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// invitationFixture is an in-memory invitation, user and team membership storage.
+type invitationFixture struct {
+	invitations map[int64]*warnly.Invitation
+	users       map[string]*warnly.User
+	members     map[int]warnly.Role
+	mails       []*warnly.InvitationMail
+}
+
+func newInvitationFixture() *invitationFixture {
+	return &invitationFixture{
+		invitations: map[int64]*warnly.Invitation{},
+		users:       map[string]*warnly.User{},
+		members:     map[int]warnly.Role{},
+	}
+}
+
+func (f *invitationFixture) service(now func() time.Time) *session.SessionService {
+	invitationStore := &mock.InvitationStore{
+		CreateInvitationFn: func(_ context.Context, inv *warnly.Invitation) (int64, error) {
+			id := int64(len(f.invitations) + 1)
+			stored := *inv
+			stored.ID = id
+			f.invitations[id] = &stored
+			return id, nil
+		},
+		GetInvitationFn: func(_ context.Context, id int64) (*warnly.Invitation, error) {
+			inv, ok := f.invitations[id]
+			if !ok {
+				return nil, warnly.ErrNotFound
+			}
+			stored := *inv
+			return &stored, nil
+		},
+		AcceptInvitationFn: func(_ context.Context, id int64, at time.Time) error {
+			if f.invitations[id].AcceptedAt != nil {
+				return warnly.ErrInvitationUsed
+			}
+			f.invitations[id].AcceptedAt = &at
+			return nil
+		},
+	}
+	userStore := &mock.UserStore{
+		GetUserFn: func(_ context.Context, email string) (*warnly.User, error) {
+			if u, ok := f.users[email]; ok {
+				return u, nil
+			}
+			return nil, warnly.ErrNotFound
+		},
+		CreateUserFn: func(_ context.Context, email, username string, _ []byte) error {
+			f.users[email] = &warnly.User{ID: int64(len(f.users) + 10), Email: email, Username: username}
+			return nil
+		},
+	}
+	teamStore := &mock.TeamStore{
+		ListTeamsFn: func(_ context.Context, _ int) ([]warnly.Team, error) {
+			return []warnly.Team{{ID: 1, Name: "Backend", Role: warnly.RoleAdmin}}, nil
+		},
+		AddUserToTeamFn: func(_ context.Context, _ time.Time, userID int64, teamID int, role warnly.Role) error {
+			if teamID == 1 {
+				f.members[int(userID)] = role
+			}
+			return nil
+		},
+	}
+	sender := &mock.InvitationSender{
+		SendInvitationFn: func(_ context.Context, mail *warnly.InvitationMail) error {
+			f.mails = append(f.mails, mail)
+			return nil
+		},
+	}
+	uw := &mock.UnitOfWork{InvitationStore: invitationStore, UserStore: userStore, TeamStore: teamStore}
+
+	return session.NewSessionService(
+		nil,
+		userStore,
+		teamStore,
+		invitationStore,
+		uw.Start,
+		session.InvitationOptions{
+			Sender:  sender,
+			BaseURL: "https://warnly.example.com",
+			Key:     []byte("invitation-key"),
+			TTL:     24 * time.Hour,
+		},
+		now,
+	)
+}
+
+// token returns the token of the invitation link.
+func token(t *testing.T, link string) string {
+	t.Helper()
+	token, ok := strings.CutPrefix(link, "https://warnly.example.com/invitations/")
+	require.True(t, ok, link)
+	return token
+}
+
+func TestInviteAndAccept(t *testing.T) {
+	t.Parallel()
+
+	invitedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := invitedAt
+	f := newInvitationFixture()
+	svc := f.service(func() time.Time { return now })
+
+	result, err := svc.Invite(t.Context(), &warnly.InviteRequest{
+		User:   &warnly.User{ID: 1, Email: "admin@example.com"},
+		Email:  " Jane@Example.com ",
+		Role:   warnly.RoleViewer,
+		TeamID: 1,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Sent)
+	assert.Equal(t, "jane@example.com", result.Invitation.Email)
+	assert.Equal(t, invitedAt.Add(24*time.Hour), result.Invitation.ExpiresAt)
+	require.Len(t, f.mails, 1)
+	assert.Equal(t, "jane@example.com", f.mails[0].Email)
+	assert.Equal(t, "Backend", f.mails[0].TeamName)
+	assert.Equal(t, result.Link, f.mails[0].Link)
+
+	now = invitedAt.Add(time.Hour)
+	sess, err := svc.AcceptInvitation(t.Context(), &warnly.AcceptInvitationRequest{
+		Token:    token(t, result.Link),
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", sess.User.Email)
+	assert.Equal(t, "jane", sess.User.Username)
+	assert.Equal(t, warnly.RoleViewer, f.members[int(sess.User.ID)], "the invited user joins the team with the invited role")
+	require.NotNil(t, f.invitations[result.Invitation.ID].AcceptedAt)
+
+	_, err = svc.AcceptInvitation(t.Context(), &warnly.AcceptInvitationRequest{
+		Token:    token(t, result.Link),
+		Password: "correct horse",
+	})
+	require.ErrorIs(t, err, warnly.ErrInvitationUsed)
+}
+
+func TestAcceptInvitationRejected(t *testing.T) {
+	t.Parallel()
+
+	invitedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	invite := func(t *testing.T, svc *session.SessionService) string {
+		t.Helper()
+		result, err := svc.Invite(t.Context(), &warnly.InviteRequest{
+			User:   &warnly.User{ID: 1},
+			Email:  "jane@example.com",
+			TeamID: 1,
+		})
+		require.NoError(t, err)
+		return token(t, result.Link)
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		now := invitedAt
+		svc := newInvitationFixture().service(func() time.Time { return now })
+		tok := invite(t, svc)
+
+		now = invitedAt.Add(24 * time.Hour)
+		_, err := svc.AcceptInvitation(t.Context(), &warnly.AcceptInvitationRequest{Token: tok, Password: "correct horse"})
+		require.ErrorIs(t, err, warnly.ErrInvitationExpired)
+	})
+
+	t.Run("forged signature", func(t *testing.T) {
+		t.Parallel()
+
+		f := newInvitationFixture()
+		svc := f.service(func() time.Time { return invitedAt })
+		tok := invite(t, svc)
+
+		id, _, _ := strings.Cut(tok, ".")
+		_, err := svc.AcceptInvitation(t.Context(), &warnly.AcceptInvitationRequest{
+			Token:    id + "." + strings.Repeat("00", 32),
+			Password: "correct horse",
+		})
+		require.ErrorIs(t, err, warnly.ErrInvalidInvitation)
+		assert.Empty(t, f.members)
+	})
+
+	t.Run("short password", func(t *testing.T) {
+		t.Parallel()
+
+		f := newInvitationFixture()
+		svc := f.service(func() time.Time { return invitedAt })
+		tok := invite(t, svc)
+
+		_, err := svc.AcceptInvitation(t.Context(), &warnly.AcceptInvitationRequest{Token: tok, Password: "short"})
+		require.ErrorIs(t, err, warnly.ErrPasswordTooShort)
+		assert.Nil(t, f.invitations[1].AcceptedAt, "the invitation stays valid")
+	})
+}
+
+func TestInviteRejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	f := newInvitationFixture()
+	svc := f.service(time.Now)
+
+	_, err := svc.Invite(t.Context(), &warnly.InviteRequest{
+		User:   &warnly.User{ID: 1},
+		Email:  "jane@example.com",
+		TeamID: 2,
+	})
+	require.ErrorIs(t, err, warnly.ErrTeamNotFound)
+
+	_, err = svc.Invite(t.Context(), &warnly.InviteRequest{
+		User:   &warnly.User{ID: 1},
+		Email:  "jane@example.com",
+		Role:   "owner",
+		TeamID: 1,
+	})
+	require.ErrorIs(t, err, warnly.ErrInvalidRole)
+	assert.Empty(t, f.invitations)
+}
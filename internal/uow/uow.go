@@ -32,6 +32,11 @@ type UnitOfWork interface {
 	Assignments() warnly.AssingmentStore
 	Users() warnly.UserStore
 	Teams() warnly.TeamStore
+	Issues() warnly.IssueStore
+	Activities() warnly.ActivityStore
+	Invitations() warnly.InvitationStore
+	Projects() warnly.ProjectStore
+	Subscriptions() warnly.SubscriptionStore
 }
 
 // StartUnitOfWork is a function that starts a UnitOfWork (e.g. database transaction).
@@ -0,0 +1,78 @@
+package warnly
+
+import (
+	"context"
+	"time"
+)
+
+// ActivityType is the kind of change recorded in the timeline of an issue.
+type ActivityType string
+
+const (
+	// ActivityAssigned is recorded when an issue is assigned to a user or a team.
+	ActivityAssigned ActivityType = "assigned"
+	// ActivityUnassigned is recorded when the assignee of an issue is removed.
+	ActivityUnassigned ActivityType = "unassigned"
+	// ActivityStatusChanged is recorded when an issue is resolved, ignored or reopened.
+	ActivityStatusChanged ActivityType = "status_changed"
+	// ActivityMessageAdded is recorded when a message is posted to the issue discussion.
+	ActivityMessageAdded ActivityType = "message_added"
+)
+
+// Activity is an entry of the issue timeline.
+type Activity struct {
+	CreatedAt time.Time    `json:"created_at"`
+	Type      ActivityType `json:"type"`
+	// Detail describes the change: the assignee name for assignments, the new status for status changes.
+	Detail  string `json:"detail"`
+	ID      int64  `json:"id"`
+	IssueID int64  `json:"issue_id"`
+	// ActorID is the user who made the change, zero for automatic changes.
+	ActorID int64 `json:"actor_id"`
+}
+
+// IssueActivity is an entry of the issue timeline along with the name of the actor.
+type IssueActivity struct {
+	Activity
+
+	ActorName string `json:"actor_name"`
+}
+
+// Description returns a human readable description of the change, without the actor.
+func (a *IssueActivity) Description() string {
+	switch a.Type {
+	case ActivityAssigned:
+		return "assigned the issue to " + a.Detail
+	case ActivityUnassigned:
+		return "removed the assignee"
+	case ActivityStatusChanged:
+		return "marked the issue as " + a.Detail
+	case ActivityMessageAdded:
+		return "commented"
+	default:
+		return string(a.Type)
+	}
+}
+
+// Actor returns the name of the user who made the change.
+func (a *IssueActivity) Actor() string {
+	if a.ActorID == 0 || a.ActorName == "" {
+		return "Warnly"
+	}
+	return a.ActorName
+}
+
+// ActivityStore encapsulates the methods to interact with database for the issue timeline.
+type ActivityStore interface {
+	// CreateActivity records a new entry in the issue timeline.
+	CreateActivity(ctx context.Context, activity *Activity) error
+	// ListIssueActivity lists the timeline of the issue in chronological order.
+	ListIssueActivity(ctx context.Context, issueID int64) ([]IssueActivity, error)
+}
+
+// GetIssueActivityRequest is a request to get the timeline of an issue.
+type GetIssueActivityRequest struct {
+	User      *User
+	ProjectID int
+	IssueID   int
+}
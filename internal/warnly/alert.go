@@ -2,6 +2,8 @@ package warnly
 
 import (
 	"context"
+	"errors"
+	"math"
 	"time"
 )
 
@@ -17,6 +19,8 @@ type AlertService interface {
 	DeleteAlert(ctx context.Context, alertID int, user *User) error
 	// GetAlert returns an alert by ID.
 	GetAlert(ctx context.Context, alertID int, user *User) (*Alert, error)
+	// AcknowledgeAlert stops the escalation of the triggered alert the token was issued for.
+	AcknowledgeAlert(ctx context.Context, ackToken string) error
 }
 
 // AlertStore encapsulates the alert storage.
@@ -33,6 +37,8 @@ type AlertStore interface {
 	GetAlert(ctx context.Context, alertID int) (*Alert, error)
 	// ListAlertsByProject returns alerts for a project.
 	ListAlertsByProject(ctx context.Context, projectID int) ([]Alert, error)
+	// AcknowledgeAlert acknowledges the triggered alert the token was issued for.
+	AcknowledgeAlert(ctx context.Context, ackToken string, at time.Time) error
 }
 
 type AlertStatus string
@@ -53,8 +59,78 @@ const (
 	AlertConditionOccurrences AlertCondition = 1
 	// AlertConditionUsers - when threshold number of users is affected.
 	AlertConditionUsers AlertCondition = 2
+	// AlertConditionNewIssue - when an issue is seen for the first time.
+	AlertConditionNewIssue AlertCondition = 3
+	// AlertConditionReoccurred - when a resolved issue is seen again.
+	AlertConditionReoccurred AlertCondition = 4
+	// AlertConditionSpike - when the number of events in the timeframe exceeds
+	// the rolling baseline of the preceding timeframes by the spike multiplier.
+	AlertConditionSpike AlertCondition = 5
 )
 
+const (
+	// DefaultBaselineWindows is the number of preceding timeframes the spike baseline is averaged over.
+	DefaultBaselineWindows = 6
+	// DefaultSpikeMultiplier is how many times the baseline the current timeframe must exceed to be a spike.
+	DefaultSpikeMultiplier = 3.0
+	// MaxBaselineWindows limits how far back the spike baseline looks.
+	MaxBaselineWindows = 168
+	// MaxEscalationChain limits the number of channels an alert escalates to.
+	MaxEscalationChain = 5
+	// MinEscalationWindow is the shortest time an alert waits for an acknowledgement before escalating.
+	MinEscalationWindow = time.Minute
+	// MaxEscalationWindow is the longest time an alert waits for an acknowledgement before escalating.
+	MaxEscalationWindow = 24 * time.Hour
+)
+
+var (
+	// ErrInvalidAlertCondition is returned when an alert rule has an unknown condition.
+	ErrInvalidAlertCondition = errors.New("invalid alert condition")
+	// ErrInvalidSpikeSettings is returned when a spike alert has an invalid baseline or multiplier.
+	ErrInvalidSpikeSettings = errors.New("spike alert needs 1-168 baseline windows and a multiplier above 1")
+	// ErrInvalidEscalation is returned when an alert has an invalid escalation chain or window.
+	ErrInvalidEscalation = errors.New("escalation needs a high priority alert, 1-5 distinct channels and a window between 1m and 24h")
+)
+
+// IsValid reports whether the condition is one of the known conditions.
+func (c AlertCondition) IsValid() bool {
+	return c >= AlertConditionOccurrences && c <= AlertConditionSpike
+}
+
+// ValidateSpikeSettings checks the baseline window count and the multiplier of a spike alert.
+func ValidateSpikeSettings(baselineWindows int, multiplier float64) error {
+	if baselineWindows < 1 || baselineWindows > MaxBaselineWindows || math.IsNaN(multiplier) || multiplier <= 1 {
+		return ErrInvalidSpikeSettings
+	}
+	return nil
+}
+
+// ValidateEscalation checks the escalation chain and window of an alert.
+// An alert without both a chain and a window doesn't escalate and is valid.
+func ValidateEscalation(highPriority bool, chain []int, window time.Duration) error {
+	if len(chain) == 0 && window == 0 {
+		return nil
+	}
+	if !highPriority || len(chain) == 0 || len(chain) > MaxEscalationChain ||
+		window < MinEscalationWindow || window > MaxEscalationWindow || window%time.Second != 0 {
+		return ErrInvalidEscalation
+	}
+	seen := make(map[int]struct{}, len(chain))
+	for _, channelID := range chain {
+		if _, ok := seen[channelID]; ok || channelID <= 0 {
+			return ErrInvalidEscalation
+		}
+		seen[channelID] = struct{}{}
+	}
+	return nil
+}
+
+// HasThreshold reports whether the condition compares a metric against the alert threshold.
+// New issue, reoccurred issue and spike conditions ignore the threshold.
+func (c AlertCondition) HasThreshold() bool {
+	return c == AlertConditionOccurrences || c == AlertConditionUsers
+}
+
 type AlertTimeframe int
 
 const (
@@ -88,9 +164,74 @@ type Alert struct {
 	ProjectID          int
 	TeamID             int
 	Threshold          int
-	Condition          AlertCondition // 1 = occurrences, 2 = users affected
+	Condition          AlertCondition // 1 = occurrences, 2 = users affected, 3 = new issue, 4 = reoccurred, 5 = spike
 	Timeframe          AlertTimeframe // 1=1min, 2=5min, 3=15min, 4=1h, 5=1d, 6=1w, 7=30d
-	HighPriority       bool
+	// BaselineWindows is the number of preceding timeframes averaged into the spike baseline.
+	BaselineWindows int
+	// SpikeMultiplier is how many times the baseline the current timeframe must exceed to trigger a spike alert.
+	SpikeMultiplier float64
+	// AcknowledgedAt is when the current trigger of the alert was acknowledged.
+	AcknowledgedAt *time.Time
+	// EscalatedAt is when the last channel of the escalation chain was notified.
+	EscalatedAt *time.Time
+	// AckToken acknowledges the current trigger of the alert, a new token is issued every time the alert triggers.
+	AckToken string
+	// EscalationChain lists the notification channels notified one after another
+	// while a triggered high priority alert stays unacknowledged.
+	EscalationChain []int
+	// EscalationWindow is how long the alert waits for an acknowledgement before notifying the next channel in the chain.
+	EscalationWindow time.Duration
+	// EscalationLevel is the number of channels in the chain notified since the alert triggered.
+	EscalationLevel int
+	HighPriority    bool
+}
+
+// Escalates reports whether the alert notifies its escalation chain while it stays unacknowledged.
+func (a *Alert) Escalates() bool {
+	return a.HighPriority && a.EscalationWindow > 0 && len(a.EscalationChain) > 0
+}
+
+// NextEscalation returns the channel of the chain to notify next and whether
+// the triggered alert has been waiting for an acknowledgement long enough at now.
+func (a *Alert) NextEscalation(now time.Time) (int, bool) {
+	if !a.Escalates() || a.Status != AlertStatusTriggered || a.AcknowledgedAt != nil ||
+		a.LastTriggeredAt == nil || a.EscalationLevel >= len(a.EscalationChain) {
+		return 0, false
+	}
+
+	since := *a.LastTriggeredAt
+	if a.EscalatedAt != nil {
+		since = *a.EscalatedAt
+	}
+	if now.Before(since.Add(a.EscalationWindow)) {
+		return 0, false
+	}
+
+	return a.EscalationChain[a.EscalationLevel], true
+}
+
+// NotificationCooldown is how long the alert stays quiet after a trigger notification,
+// a rule flapping within its own timeframe notifies its channels once.
+func (a *Alert) NotificationCooldown() time.Duration {
+	return a.GetTimeframeDuration()
+}
+
+// NotificationSuppressed reports whether the notification of the alert is held back by the cooldown at now.
+// A trigger is suppressed within the cooldown of the last trigger notification, a resolution
+// is suppressed when the trigger it resolves was, so channels never hear about a resolution alone.
+// Escalations are never suppressed.
+func (a *Alert) NotificationSuppressed(notificationType AlertNotificationType, now time.Time) bool {
+	if a.NotificationSentAt == nil {
+		return false
+	}
+	switch notificationType {
+	case AlertNotificationTriggered:
+		return now.Before(a.NotificationSentAt.Add(a.NotificationCooldown()))
+	case AlertNotificationResolved:
+		return a.LastTriggeredAt != nil && a.NotificationSentAt.Before(*a.LastTriggeredAt)
+	default:
+		return false
+	}
 }
 
 // GetTimeframeDuration returns the duration for the timeframe.
@@ -135,23 +276,38 @@ type ListAlertsResult struct {
 
 // CreateAlertRequest is a request to create a new alert.
 type CreateAlertRequest struct {
-	User         *User
-	RuleName     string
-	ProjectID    int
-	Threshold    int
-	Condition    AlertCondition
-	Timeframe    AlertTimeframe
-	HighPriority bool
+	User            *User
+	RuleName        string
+	ProjectID       int
+	Threshold       int
+	Condition       AlertCondition
+	Timeframe       AlertTimeframe
+	BaselineWindows int
+	SpikeMultiplier float64
+	// EscalationChain and EscalationWindow configure escalation of a high priority alert,
+	// both are empty when the alert doesn't escalate.
+	EscalationChain  []int
+	EscalationWindow time.Duration
+	HighPriority     bool
 }
 
 // UpdateAlertRequest is a request to update an alert.
 type UpdateAlertRequest struct {
-	User         *User
-	RuleName     string
-	Status       AlertStatus
-	AlertID      int
-	Threshold    int
-	Condition    AlertCondition
-	Timeframe    AlertTimeframe
-	HighPriority bool
+	User            *User
+	RuleName        string
+	Status          AlertStatus
+	AlertID         int
+	Threshold       int
+	Condition       AlertCondition
+	Timeframe       AlertTimeframe
+	BaselineWindows int
+	SpikeMultiplier float64
+	// EscalationChain and EscalationWindow configure escalation of a high priority alert,
+	// both are empty when the alert doesn't escalate.
+	EscalationChain  []int
+	EscalationWindow time.Duration
+	// KeepEscalation is set when the request doesn't configure escalation,
+	// the alert keeps its escalation for as long as it stays high priority.
+	KeepEscalation bool
+	HighPriority   bool
 }
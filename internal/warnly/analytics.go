@@ -2,6 +2,7 @@ package warnly
 
 import (
 	"context"
+	"strconv"
 	"time"
 )
 
@@ -12,6 +13,16 @@ import (
 type AnalyticsStore interface {
 	// CalculateEvents calculates the number of events per day split by hour.
 	CalculateEvents(ctx context.Context, criteria *ListIssueMetricsCriteria) ([]EventsPerHour, error)
+	// CalculateIssueEvents calculates the number of events of the issues per hour, most recent hours first.
+	CalculateIssueEvents(ctx context.Context, criteria *ListIssueMetricsCriteria) ([]IssueEventsPerHour, error)
+	// EventSeriesForIssues calculates the number of events of each of the issues of the projects per hour
+	// within the time range in a single query, ordered by issue and hour.
+	EventSeriesForIssues(ctx context.Context, gids []int64, from, to time.Time, projectIDs []int) ([]IssueEventsPerHour, error)
+	// CountUniqueUsers counts the distinct users the events of each project came from within the period,
+	// projects without events of identified users are left out.
+	CountUniqueUsers(ctx context.Context, criteria *ListIssueMetricsCriteria) ([]ProjectUsers, error)
+	// CalculateEventsByEnv calculates the number of events per hour split by environment.
+	CalculateEventsByEnv(ctx context.Context, criteria *ListIssueMetricsCriteria) ([]EventsPerEnvHour, error)
 	// ListIssueMetrics lists issue metrics for the given project IDs and issue IDs within the specified time range.
 	// It displays how many times each issue was seen, when it was first and last seen
 	// and the number of unique users affected.
@@ -26,10 +37,16 @@ type AnalyticsStore interface {
 	CalculateFields(ctx context.Context, criteria FieldsCriteria) ([]TagCount, error)
 	// GetIssueEvent retrieves a single event associated with a specific issue and project within a given time range.
 	GetIssueEvent(ctx context.Context, criteria *EventDefCriteria) (*IssueEvent, error)
+	// GetRawEvent retrieves the original JSON payload of the event.
+	// Returns ErrNotFound if the event doesn't exist or was stored without its payload.
+	GetRawEvent(ctx context.Context, criteria *EventDefCriteria) ([]byte, error)
 	// CountEvents counts the number of events based on the given criteria.
 	CountEvents(ctx context.Context, criteria *EventCriteria) (uint64, error)
 	// ListEvents lists error events based on the given criteria.
 	ListEvents(ctx context.Context, criteria *EventCriteria) ([]EventEntry, error)
+	// StreamEvents calls fn for every error event matching the given criteria
+	// instead of collecting them, so large results can be written incrementally.
+	StreamEvents(ctx context.Context, criteria *EventCriteria, fn func(event *EventEntry) error) error
 	// ListSlowQueries lists olap slow SQL queries from the system.
 	ListSlowQueries(ctx context.Context) ([]SQLQuery, error)
 	// ListSchemas lists olap database schemas from largest to smallest.
@@ -38,6 +55,9 @@ type AnalyticsStore interface {
 	ListErrors(ctx context.Context, criteria ListErrorsCriteria) ([]AnalyticsStoreErr, error)
 	// StoreEvent stores an event in the analytics database.
 	StoreEvent(ctx context.Context, event *EventClickhouse) error
+	// StoreEventSync stores an event in the analytics database and returns
+	// only after the event is persisted.
+	StoreEventSync(ctx context.Context, event *EventClickhouse) error
 	// ListFieldFilters lists field filters for a given project.
 	ListFieldFilters(ctx context.Context, criteria *FieldFilterCriteria) ([]Filter, error)
 	// ListPopularTags lists popular tag keys across all events.
@@ -46,8 +66,179 @@ type AnalyticsStore interface {
 	ListTagValues(ctx context.Context, criteria *ListTagValuesCriteria) ([]TagValueCount, error)
 	// GetFilteredGroupIDs returns group IDs that match the query filters.
 	GetFilteredGroupIDs(ctx context.Context, tokens []QueryToken, from, to time.Time, projectIDs []int) ([]int64, error)
+	// SearchIssues returns group IDs whose titles or messages match the query, best matches first.
+	SearchIssues(ctx context.Context, query string, criteria *SearchIssuesCriteria) ([]IssueMatch, error)
+	// ListUnhandledGroupIDs returns group IDs that have at least one unhandled event.
+	ListUnhandledGroupIDs(ctx context.Context, from, to time.Time, projectIDs []int) ([]int64, error)
 	// GetEventPagination returns the pagination for an event.
 	GetEventPagination(ctx context.Context, c *EventPaginationCriteria) (*EventPagination, error)
+	// RollingBaseline counts the project events in the current window
+	// and averages the counts of the preceding windows.
+	RollingBaseline(ctx context.Context, criteria *RollingBaselineCriteria) (*RollingBaseline, error)
+	// CalculateEventGaps builds the histogram of time gaps between consecutive events of an issue
+	// within a specified time range, with a bucket for each of EventGapBounds and one for longer gaps.
+	CalculateEventGaps(ctx context.Context, criteria *EventDefCriteria) ([]EventGapBucket, error)
+	// ReleaseAdoption aggregates users and errors of the most recent releases of a project
+	// within a specified time range, the most recently first seen release first.
+	ReleaseAdoption(ctx context.Context, criteria *ReleaseAdoptionCriteria) ([]ReleaseAdoption, error)
+	// CompareWindows counts the events of each issue in the baseline and the current windows,
+	// issues without events in either window are left out.
+	CompareWindows(ctx context.Context, criteria *CompareWindowsCriteria) ([]IssueWindowCounts, error)
+	// SuggestTagValues lists the most popular values of a project tag starting with a prefix,
+	// ignoring case, within a specified time range.
+	SuggestTagValues(ctx context.Context, criteria *SuggestTagValuesCriteria) ([]TagValueCount, error)
+	// ListRawEvents lists a batch of stored events of a project with their payloads, the oldest first.
+	ListRawEvents(ctx context.Context, criteria *RawEventsCriteria) ([]RawEvent, error)
+	// RegroupEvents reassigns events of a project to other groups.
+	RegroupEvents(ctx context.Context, criteria *RegroupEventsCriteria) error
+	// DeleteProjectEvents deletes all events of a project.
+	DeleteProjectEvents(ctx context.Context, projectID int) error
+	// HasEvent reports whether an event with the ID was stored for the project since the time.
+	HasEvent(ctx context.Context, projectID int, eventID string, since time.Time) (bool, error)
+	// ListEventsByTrace lists up to MaxTraceEvents events of the projects that happened in the trace,
+	// the most recent first.
+	ListEventsByTrace(ctx context.Context, traceID string, projectIDs []int) ([]TraceEvent, error)
+}
+
+// MaxTraceEvents is the maximum number of events of a trace listed along with an issue.
+const MaxTraceEvents = 50
+
+// TraceEvent is an event that happened in a distributed trace.
+type TraceEvent struct {
+	CreatedAt time.Time
+	EventID   string
+	Title     string
+	Message   string
+	GroupID   int64
+	ProjectID int
+}
+
+// SuggestTagValuesCriteria represents the criteria for suggesting values of a project tag.
+type SuggestTagValuesCriteria struct {
+	From time.Time
+	To   time.Time
+	Tag  string
+	// Prefix is the beginning of the values, all values match an empty prefix.
+	Prefix    string
+	ProjectID int
+	// Limit is the maximum number of values.
+	Limit int
+}
+
+// ReleaseAdoptionCriteria represents the criteria for aggregating events by release.
+type ReleaseAdoptionCriteria struct {
+	From      time.Time
+	To        time.Time
+	ProjectID int
+	// Limit is the maximum number of releases.
+	Limit int
+}
+
+// ReleaseAdoption approximates how a release rolled out from the events reported by it.
+// Only users that sent an error are seen, so the numbers are a lower bound of the adoption.
+type ReleaseAdoption struct {
+	FirstSeen time.Time    `json:"first_seen"`
+	Release   string       `json:"release"`
+	Days      []ReleaseDay `json:"days"`
+	// Users is the number of distinct users that sent events from the release.
+	Users uint64 `json:"users"`
+	// NewUsers is the number of users whose first event in the time range was sent from the release.
+	NewUsers uint64 `json:"new_users"`
+	Errors   uint64 `json:"errors"`
+}
+
+// ReleaseDay holds the users and errors of a release on a single day.
+type ReleaseDay struct {
+	Day    time.Time `json:"day"`
+	Users  uint64    `json:"users"`
+	Errors uint64    `json:"errors"`
+}
+
+// EventGapBounds are the exclusive upper bounds of the event gap histogram buckets.
+// Gaps not shorter than the last bound fall into an extra, unbounded bucket.
+var EventGapBounds = []time.Duration{
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+// EventGapBucket is a bucket of the histogram of time gaps between consecutive events of an issue.
+// Bursty issues have most gaps in the first buckets, steady ones spread over the later buckets.
+type EventGapBucket struct {
+	// Max is the exclusive upper bound of the bucket gaps, zero for the unbounded last bucket.
+	Max   time.Duration
+	Count uint64
+}
+
+// NewEventGapHistogram returns empty buckets for EventGapBounds followed by the unbounded bucket.
+func NewEventGapHistogram() []EventGapBucket {
+	buckets := make([]EventGapBucket, len(EventGapBounds)+1)
+	for i, bound := range EventGapBounds {
+		buckets[i].Max = bound
+	}
+	return buckets
+}
+
+// Label returns a short description of the bucket range, e.g. "<1m" or ">=1d".
+func (b EventGapBucket) Label() string {
+	if b.Max == 0 {
+		return ">=" + formatGapBound(EventGapBounds[len(EventGapBounds)-1])
+	}
+	return "<" + formatGapBound(b.Max)
+}
+
+// formatGapBound formats a bucket bound with the largest whole unit, e.g. 10m or 1d.
+func formatGapBound(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return strconv.FormatInt(int64(d/(24*time.Hour)), 10) + "d"
+	case d >= time.Hour && d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d >= time.Minute && d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	default:
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+}
+
+// RollingBaselineCriteria defines the current window and the number of preceding windows
+// that make up the baseline it is compared against.
+type RollingBaselineCriteria struct {
+	// To is the end of the current window.
+	To time.Time
+	// Window is the length of the current window and of every baseline window.
+	Window    time.Duration
+	ProjectID int
+	// Windows is the number of preceding windows the baseline is averaged over.
+	Windows int
+}
+
+// CurrentFrom returns the start of the current window.
+func (c *RollingBaselineCriteria) CurrentFrom() time.Time {
+	return c.To.Add(-c.Window)
+}
+
+// BaselineFrom returns the start of the earliest baseline window.
+func (c *RollingBaselineCriteria) BaselineFrom() time.Time {
+	return c.CurrentFrom().Add(-time.Duration(c.Windows) * c.Window)
+}
+
+// RollingBaseline is the number of events in the current window
+// and the average number of events per window before it.
+type RollingBaseline struct {
+	Current  uint64
+	Baseline float64
+}
+
+// IsSpike reports whether the current window exceeds the baseline by the multiplier.
+// A baseline below one event per window counts as one, so that the first few events
+// of a quiet project don't make a spike on their own.
+func (b *RollingBaseline) IsSpike(multiplier float64) bool {
+	return float64(b.Current) > max(b.Baseline, 1)*multiplier
 }
 
 type EventPaginationCriteria struct {
@@ -87,6 +278,30 @@ type ListIssueMetricsCriteria struct {
 	To         time.Time
 	ProjectIDs []int
 	GroupIDs   []int64
+	// Levels limits the metrics to events of the given level names, all levels when empty.
+	Levels []string
+	// After lists the metrics of the issues that follow the cursor in the issue list order,
+	// from the start of the list when nil.
+	After *IssueCursor
+	// Limit caps the number of listed metrics ordered the way issues are listed,
+	// zero lists the metrics of all issues unordered.
+	Limit int
+}
+
+// SearchIssuesCriteria represents the criteria for the full-text search of issues.
+type SearchIssuesCriteria struct {
+	From       time.Time
+	To         time.Time
+	ProjectIDs []int
+	// Phrase matches the query as a substring instead of by tokens.
+	Phrase bool
+	Limit  int
+}
+
+// IssueMatch is an issue found by the full-text search with its match score.
+type IssueMatch struct {
+	GID   int64
+	Score float64
 }
 
 // ListErrorsCriteria represents the criteria for listing errors
@@ -102,6 +317,7 @@ type EventEntry struct {
 	Title        string
 	Message      string
 	Release      string
+	Dist         string
 	Env          string
 	UserEmail    string
 	UserUsername string
@@ -129,6 +345,15 @@ func (e *EventEntry) DisplayUser() string {
 	return "(no value)"
 }
 
+// DisplayRelease returns the release of the event followed by its distribution, if any,
+// so builds of a mobile release are told apart.
+func (e *EventEntry) DisplayRelease() string {
+	if e.Release == "" || e.Dist == "" {
+		return e.Release
+	}
+	return e.Release + " (" + e.Dist + ")"
+}
+
 // FieldsCriteria represents the criteria for querying fields.
 type FieldsCriteria struct {
 	From      time.Time
@@ -139,10 +364,16 @@ type FieldsCriteria struct {
 
 // EventCriteria represents the criteria for querying events.
 type EventCriteria struct {
-	From      time.Time
-	To        time.Time
-	Tags      map[string]QueryValue
-	Message   string
+	From    time.Time
+	To      time.Time
+	Tags    map[string]QueryValue
+	Message string
+	// UserEmail limits the events to the ones of the user with the email, all users when empty.
+	UserEmail string
+	// UserID limits the events to the ones of the user with the ID, all users when empty.
+	UserID string
+	// Levels limits the events to the given level names, all levels when empty.
+	Levels    []string
 	ProjectID int
 	GroupID   int
 	Limit     int
@@ -153,6 +384,8 @@ type EventCriteria struct {
 type QueryValue struct {
 	Value string
 	IsNot bool
+	// IsContext is true when the value is matched against event contexts instead of tags.
+	IsContext bool
 }
 
 // EventsPerHour represents the number of events per hour.
@@ -162,6 +395,27 @@ type EventsPerHour struct {
 	Count     int
 }
 
+// IssueEventsPerHour is the number of events of an issue within an hour.
+type IssueEventsPerHour struct {
+	TS      time.Time
+	GroupID int64
+	Count   int
+}
+
+// ProjectUsers is the number of distinct users the events of a project came from.
+type ProjectUsers struct {
+	ProjectID int
+	Count     uint64
+}
+
+// EventsPerEnvHour is the number of events of a project environment within an hour.
+type EventsPerEnvHour struct {
+	TS        time.Time
+	Env       string
+	ProjectID int
+	Count     int
+}
+
 // EventPerDay represents the number of events per day.
 type EventPerDay struct {
 	Time  time.Time
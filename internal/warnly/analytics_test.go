@@ -1,7 +1,9 @@
 package warnly_test
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/vk-rv/warnly/internal/warnly"
 )
@@ -64,3 +66,41 @@ func TestEventEntry_DisplayUser(t *testing.T) {
 		})
 	}
 }
+
+func TestRollingBaselineCriteriaWindows(t *testing.T) {
+	t.Parallel()
+
+	to := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &warnly.RollingBaselineCriteria{To: to, Window: 15 * time.Minute, Windows: 4}
+
+	if got, want := c.CurrentFrom(), to.Add(-15*time.Minute); !got.Equal(want) {
+		t.Errorf("CurrentFrom() = %v, want %v", got, want)
+	}
+	if got, want := c.BaselineFrom(), to.Add(-75*time.Minute); !got.Equal(want) {
+		t.Errorf("BaselineFrom() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateSpikeSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		windows    int
+		multiplier float64
+		wantErr    bool
+	}{
+		{windows: 6, multiplier: 3},
+		{windows: 1, multiplier: 1.5},
+		{windows: 0, multiplier: 3, wantErr: true},
+		{windows: warnly.MaxBaselineWindows + 1, multiplier: 3, wantErr: true},
+		{windows: 6, multiplier: 1, wantErr: true},
+		{windows: 6, multiplier: math.NaN(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := warnly.ValidateSpikeSettings(tt.windows, tt.multiplier)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSpikeSettings(%d, %v) error = %v, wantErr %v", tt.windows, tt.multiplier, err, tt.wantErr)
+		}
+	}
+}
@@ -1,28 +1,56 @@
 package warnly
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"errors"
+	"slices"
 	"time"
 )
 
-// Assignment represents the assignment of an issue to a user.
+// AssigneeType tells whether an issue is assigned to a user or to a team.
+type AssigneeType string
+
+const (
+	// AssigneeUser is the type of an assignment to a single user.
+	AssigneeUser AssigneeType = "user"
+	// AssigneeTeam is the type of an assignment to a team, e.g. to be picked up by its rotation.
+	AssigneeTeam AssigneeType = "team"
+)
+
+// ErrInvalidAssignee is returned when an assignment targets both or neither a user and a team.
+var ErrInvalidAssignee = errors.New("issue must be assigned to either a user or a team")
+
+// Assignment represents the assignment of an issue to a user or a team.
+// Only the ID matching AssigneeType is set.
 type Assignment struct {
-	AssignedAt       time.Time `json:"assigned_at"`
-	IssueID          int64     `json:"issue_id"`
-	AssignedToUserID int64     `json:"assigned_to_user_id"`
-	AssignedToTeamID int64     `json:"assigned_to_team_id"`
-	AssignedByUserID int64     `json:"assigned_by_user_id"`
+	AssignedAt       time.Time    `json:"assigned_at"`
+	AssigneeType     AssigneeType `json:"assignee_type"`
+	IssueID          int64        `json:"issue_id"`
+	AssignedToUserID int64        `json:"assigned_to_user_id"`
+	AssignedToTeamID int64        `json:"assigned_to_team_id"`
+	AssignedByUserID int64        `json:"assigned_by_user_id"`
 }
 
-// AssignedUser represents the result of assigned user to an issue.
+// AssignedUser represents the result of assigned user or team to an issue.
 type AssignedUser struct {
 	IssueID          int64
 	AssignedToUserID sql.NullInt64
+	AssignedToTeamID sql.NullInt64
+}
+
+// AssigneeType returns whether the issue is assigned to a user or to a team.
+func (a *AssignedUser) AssigneeType() AssigneeType {
+	if a.AssignedToTeamID.Valid {
+		return AssigneeTeam
+	}
+	return AssigneeUser
 }
 
 type Assignments struct {
 	IssueToAssigned map[int64]*Teammate
+	IssueToTeam     map[int64]*Team
 }
 
 // AssignedUser returns the assigned user for the given issue.
@@ -34,6 +62,15 @@ func (a *Assignments) AssignedUser(issueID int64) (*Teammate, bool) {
 	return assigned, ok
 }
 
+// AssignedTeam returns the team the given issue is assigned to.
+func (a *Assignments) AssignedTeam(issueID int64) (*Team, bool) {
+	if a.IssueToTeam == nil {
+		return nil, false
+	}
+	team, ok := a.IssueToTeam[issueID]
+	return team, ok
+}
+
 // AssingmentStore is the interface for the assignment storage.
 type AssingmentStore interface {
 	// CreateAssingment creates a new issue assignment in the database.
@@ -42,16 +79,76 @@ type AssingmentStore interface {
 	DeleteAssignment(ctx context.Context, issueID int64) error
 	// ListAssingments lists all assignments for a given issue.
 	ListAssingments(ctx context.Context, issueIDs []int64) ([]*AssignedUser, error)
+	// ListProjectAssingments lists the assignments of all issues of a project.
+	ListProjectAssingments(ctx context.Context, projectID int) ([]*AssignedUser, error)
 	// ListAssignedFilters gets filters for assigned issues.
 	ListAssignedFilters(ctx context.Context, criteria *GetAssignedFiltersCriteria) ([]Filter, error)
+	// GetLastRotationAssignee returns the member who was assigned last by the team rotation,
+	// zero if the rotation hasn't started yet.
+	GetLastRotationAssignee(ctx context.Context, teamID int) (int64, error)
+	// SaveLastRotationAssignee stores the member who was assigned last by the team rotation.
+	SaveLastRotationAssignee(ctx context.Context, teamID int, userID int64) error
+}
+
+// AssignmentStrategy picks an assignee for a newly created issue.
+type AssignmentStrategy interface {
+	// PickAssignee returns the user to assign the issue to.
+	// ok is false when the strategy has no opinion about the issue.
+	PickAssignee(ctx context.Context, issue *Issue, teamID int) (userID int64, ok bool, err error)
 }
 
-// AssignIssueRequest represents the request to assign an issue to a user.
+// IssueAutoAssigner assigns newly created issues automatically.
+type IssueAutoAssigner interface {
+	// AssignNewIssue assigns the issue created in a project of the team.
+	AssignNewIssue(ctx context.Context, issue *Issue, teamID int) error
+}
+
+// NextInRotation returns the active member following lastUserID in the rotation.
+// Members rotate in the order of their IDs, so the rotation is stable when members
+// join or leave. ok is false when the team has no active members.
+func NextInRotation(members []Teammate, lastUserID int64) (Teammate, bool) {
+	active := make([]Teammate, 0, len(members))
+	for i := range members {
+		if members[i].Active {
+			active = append(active, members[i])
+		}
+	}
+	if len(active) == 0 {
+		return Teammate{}, false
+	}
+
+	slices.SortFunc(active, func(a, b Teammate) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	for i := range active {
+		if active[i].ID > lastUserID {
+			return active[i], true
+		}
+	}
+
+	return active[0], true
+}
+
+// AssignIssueRequest represents the request to assign an issue to a user or, when TeamID is set, to a team.
 type AssignIssueRequest struct {
 	User      *User `json:"user"`
 	IssueID   int   `json:"issue_id"`
 	ProjectID int   `json:"project_id"`
 	UserID    int   `json:"user_id"`
+	TeamID    int   `json:"team_id"`
+}
+
+// AssigneeType returns whether the request assigns the issue to a user or to a team.
+func (r *AssignIssueRequest) AssigneeType() (AssigneeType, error) {
+	switch {
+	case r.TeamID != 0 && r.UserID == 0:
+		return AssigneeTeam, nil
+	case r.UserID != 0 && r.TeamID == 0:
+		return AssigneeUser, nil
+	default:
+		return "", ErrInvalidAssignee
+	}
 }
 
 // UnassignIssueRequest represents the request to unassign an issue from a user.
@@ -94,3 +94,42 @@ func TestAssignments_AssignedUser(t *testing.T) {
 		})
 	}
 }
+
+func TestNextInRotation(t *testing.T) {
+	t.Parallel()
+
+	members := []warnly.Teammate{
+		{ID: 7, Username: "carol", Active: true},
+		{ID: 3, Username: "alice", Active: true},
+		{ID: 5, Username: "bob", Active: false},
+	}
+
+	tests := []struct {
+		name       string
+		members    []warnly.Teammate
+		lastUserID int64
+		wantID     int64
+		wantOK     bool
+	}{
+		{name: "rotation not started", members: members, lastUserID: 0, wantID: 3, wantOK: true},
+		{name: "inactive member skipped", members: members, lastUserID: 3, wantID: 7, wantOK: true},
+		{name: "wraps around", members: members, lastUserID: 7, wantID: 3, wantOK: true},
+		{name: "last assignee left the team", members: members, lastUserID: 4, wantID: 7, wantOK: true},
+		{name: "no active members", members: []warnly.Teammate{{ID: 5}}, lastUserID: 0, wantOK: false},
+		{name: "empty team", members: nil, lastUserID: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := warnly.NextInRotation(tt.members, tt.lastUserID)
+			if ok != tt.wantOK {
+				t.Fatalf("NextInRotation() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.ID != tt.wantID {
+				t.Errorf("NextInRotation() = %d, want %d", got.ID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package warnly
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MaxAttachmentSize is the maximum size in bytes of a single event attachment.
+const MaxAttachmentSize = 512 * 1024
+
+// DefaultAttachmentContentType is used when the SDK didn't send the attachment content type.
+const DefaultAttachmentContentType = "application/octet-stream"
+
+// ErrInvalidAttachment is returned when an attachment can't be stored, e.g. because of its name.
+var ErrInvalidAttachment = errors.New("invalid attachment")
+
+// Attachment is a file sent by an SDK along with an event, e.g. a screenshot or a log file.
+type Attachment struct {
+	CreatedAt   time.Time `json:"created_at"`
+	EventID     string    `json:"event_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Data        []byte    `json:"-"`
+	Size        int       `json:"size"`
+	ProjectID   int       `json:"project_id"`
+}
+
+// AttachmentStore persists event attachments keyed by project and event ID.
+type AttachmentStore interface {
+	// StoreAttachment stores the attachment, replacing an attachment of the event with the same filename.
+	StoreAttachment(ctx context.Context, attachment *Attachment) error
+	// ListAttachments lists attachments of the event without their data.
+	ListAttachments(ctx context.Context, projectID int, eventID string) ([]Attachment, error)
+	// GetAttachment returns the attachment with its data. Returns ErrNotFound if there is no such attachment.
+	GetAttachment(ctx context.Context, projectID int, eventID, filename string) (*Attachment, error)
+}
+
+// ObjectStore keeps binary objects by key, e.g. on the local filesystem or in an S3 bucket.
+// Keys are slash separated paths such as "1/<event id>/files/screenshot.png".
+type ObjectStore interface {
+	// PutObject stores the object, replacing an object with the same key.
+	PutObject(ctx context.Context, key string, data []byte, contentType string) error
+	// GetObject returns the object data. Returns ErrNotFound if there is no such object.
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	// DeleteObject deletes the object, deleting a missing object is not an error.
+	DeleteObject(ctx context.Context, key string) error
+	// ListObjects returns keys of the objects under the prefix, which ends with a slash.
+	ListObjects(ctx context.Context, prefix string) ([]string, error)
+}
+
+// AttachmentService provides access to event attachments.
+type AttachmentService interface {
+	// GetAttachment returns the attachment of an event of a project the user has access to.
+	GetAttachment(ctx context.Context, req *GetAttachmentRequest) (*Attachment, error)
+}
+
+// GetAttachmentRequest is a request to download an event attachment.
+type GetAttachmentRequest struct {
+	User      *User
+	EventID   string
+	Filename  string
+	ProjectID int
+}
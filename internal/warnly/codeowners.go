@@ -0,0 +1,108 @@
+package warnly
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// MaxCodeOwners is the maximum number of code owner rules a project can define.
+const MaxCodeOwners = 50
+
+// ErrInvalidCodeOwner is returned when a code owner rule can't be matched against file paths.
+var ErrInvalidCodeOwner = errors.New("invalid code owner")
+
+// CodeOwner maps file paths matching Pattern to the teammate responsible for them, like a CODEOWNERS line.
+// The pattern is matched against any trailing part of a frame file path: "internal/billing/" matches
+// every file under a billing directory, "*.sql" any SQL file and "**" any number of directories.
+type CodeOwner struct {
+	Pattern string `json:"pattern"`
+	UserID  int64  `json:"user_id"`
+}
+
+// Validate checks that the rule has a well-formed pattern and an owner.
+func (o *CodeOwner) Validate() error {
+	if strings.Trim(o.Pattern, "/") == "" {
+		return fmt.Errorf("%w: pattern is required", ErrInvalidCodeOwner)
+	}
+	if _, err := path.Match(o.Pattern, ""); err != nil {
+		return fmt.Errorf("%w: pattern %q: %w", ErrInvalidCodeOwner, o.Pattern, err)
+	}
+	if o.UserID <= 0 {
+		return fmt.Errorf("%w: owner is required", ErrInvalidCodeOwner)
+	}
+	return nil
+}
+
+// Match reports whether the file path is owned according to the rule.
+func (o *CodeOwner) Match(filepath string) bool {
+	pattern := strings.Split(strings.Trim(o.Pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(filepath, "/"), "/")
+	for i := range segments {
+		if matchSegments(pattern, segments[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether the pattern segments match the leading path segments.
+// A pattern matching a directory owns everything beneath it.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if pattern[0] == "**" {
+		for i := range len(segments) + 1 {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// ValidateCodeOwners checks the code owner rules of a project.
+func ValidateCodeOwners(owners []CodeOwner) error {
+	if len(owners) > MaxCodeOwners {
+		return fmt.Errorf("%w: at most %d rules are allowed", ErrInvalidCodeOwner, MaxCodeOwners)
+	}
+	for i := range owners {
+		if err := owners[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SuggestOwner returns the owner of the top in-app frame of the stack.
+// As in CODEOWNERS, the last matching rule takes precedence.
+func SuggestOwner(owners []CodeOwner, stack []StackDetail) (int64, bool) {
+	for i := range stack {
+		if !stack[i].InApp {
+			continue
+		}
+		for j := len(owners) - 1; j >= 0; j-- {
+			if owners[j].Match(stack[i].Filepath) {
+				return owners[j].UserID, true
+			}
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+// SetCodeOwnersRequest is a request to replace the code owner rules of a project.
+type SetCodeOwnersRequest struct {
+	User      *User
+	Owners    []CodeOwner
+	ProjectID int
+}
@@ -0,0 +1,114 @@
+package warnly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestCodeOwnerMatch(t *testing.T) {
+	t.Parallel()
+
+	const file = "/home/app/src/github.com/acme/shop/internal/billing/invoice.go"
+
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"internal/billing/", true},
+		{"/internal/billing", true},
+		{"billing/invoice.go", true},
+		{"*.go", true},
+		{"internal/**/invoice.go", true},
+		{"shop/**", true},
+		{"internal/shipping/", false},
+		{"*.sql", false},
+		{"billing/*.sql", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+			owner := warnly.CodeOwner{Pattern: tt.pattern, UserID: 1}
+			require.Equal(t, tt.want, owner.Match(file))
+		})
+	}
+}
+
+func TestSuggestOwner(t *testing.T) {
+	t.Parallel()
+
+	owners := []warnly.CodeOwner{
+		{Pattern: "*.go", UserID: 1},
+		{Pattern: "internal/billing/", UserID: 2},
+		{Pattern: "internal/shipping/", UserID: 3},
+	}
+
+	tests := []struct {
+		name   string
+		stack  []warnly.StackDetail
+		want   int64
+		wantOK bool
+	}{
+		{
+			name: "top in-app frame decides",
+			stack: []warnly.StackDetail{
+				{Filepath: "/usr/local/go/src/runtime/panic.go", InApp: false},
+				{Filepath: "/app/internal/billing/invoice.go", InApp: true},
+				{Filepath: "/app/internal/shipping/label.go", InApp: true},
+			},
+			want:   2,
+			wantOK: true,
+		},
+		{
+			name: "last matching rule wins",
+			stack: []warnly.StackDetail{
+				{Filepath: "/app/internal/shipping/label.go", InApp: true},
+			},
+			want:   3,
+			wantOK: true,
+		},
+		{
+			name: "falls back to a broader rule",
+			stack: []warnly.StackDetail{
+				{Filepath: "/app/cmd/shop/main.go", InApp: true},
+			},
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name: "no in-app frames",
+			stack: []warnly.StackDetail{
+				{Filepath: "/usr/local/go/src/runtime/panic.go", InApp: false},
+			},
+			wantOK: false,
+		},
+		{
+			name: "top in-app frame not owned",
+			stack: []warnly.StackDetail{
+				{Filepath: "/app/web/index.js", InApp: true},
+				{Filepath: "/app/internal/billing/invoice.go", InApp: true},
+			},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := warnly.SuggestOwner(owners, tt.stack)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateCodeOwners(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, warnly.ValidateCodeOwners([]warnly.CodeOwner{{Pattern: "internal/billing/", UserID: 2}}))
+	require.ErrorIs(t, warnly.ValidateCodeOwners([]warnly.CodeOwner{{Pattern: "/", UserID: 2}}), warnly.ErrInvalidCodeOwner)
+	require.ErrorIs(t, warnly.ValidateCodeOwners([]warnly.CodeOwner{{Pattern: "[a", UserID: 2}}), warnly.ErrInvalidCodeOwner)
+	require.ErrorIs(t, warnly.ValidateCodeOwners([]warnly.CodeOwner{{Pattern: "*.go"}}), warnly.ErrInvalidCodeOwner)
+}
@@ -0,0 +1,71 @@
+package warnly
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidTimeWindow is returned when a compared time window is empty or ends before it starts.
+var ErrInvalidTimeWindow = errors.New("time window must end after it starts")
+
+// MaxComparedIssues is the maximum number of issues in a comparison of two time windows.
+const MaxComparedIssues = 100
+
+// TimeWindow is a time range including From and excluding To.
+type TimeWindow struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Validate checks that the window isn't empty.
+func (w TimeWindow) Validate() error {
+	if w.From.IsZero() || w.To.IsZero() || !w.From.Before(w.To) {
+		return ErrInvalidTimeWindow
+	}
+	return nil
+}
+
+// CompareWindowsCriteria represents the criteria for counting issue events in two time windows.
+type CompareWindowsCriteria struct {
+	Baseline   TimeWindow
+	Current    TimeWindow
+	ProjectIDs []int
+	// Limit is the maximum number of issues.
+	Limit int
+}
+
+// IssueWindowCounts is the number of events of an issue in the baseline and the current windows.
+type IssueWindowCounts struct {
+	GroupID  int64
+	Baseline uint64
+	Current  uint64
+}
+
+// CompareWindowsRequest is a request to compare issue activity of a project between two time windows.
+type CompareWindowsRequest struct {
+	User      *User
+	Baseline  TimeWindow
+	Current   TimeWindow
+	ProjectID int
+}
+
+// WindowComparison is the issue activity of a project in two time windows.
+type WindowComparison struct {
+	Baseline  TimeWindow        `json:"baseline"`
+	Current   TimeWindow        `json:"current"`
+	Issues    []IssueComparison `json:"issues"`
+	ProjectID int               `json:"project_id"`
+}
+
+// IssueComparison is the number of events of an issue in two time windows.
+type IssueComparison struct {
+	Message   string `json:"message"`
+	ErrorType string `json:"error_type"`
+	IssueID   int64  `json:"issue_id"`
+	Baseline  uint64 `json:"baseline"`
+	Current   uint64 `json:"current"`
+	// Delta is the change of the number of events from the baseline to the current window.
+	Delta int64 `json:"delta"`
+	// New is set when the issue has no events in the baseline window.
+	New bool `json:"new"`
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 )
@@ -65,6 +66,33 @@ var ignoredModules = map[string]struct{}{
 	"github.com/getsentry/sentry-go": {},
 }
 
+// Envelope item types that can be ingested.
+const (
+	// EnvelopeItemEvent is the envelope item type carrying an error event.
+	EnvelopeItemEvent = "event"
+	// EnvelopeItemAttachment is the envelope item type carrying a file sent along with the event.
+	EnvelopeItemAttachment = "attachment"
+	// EnvelopeItemTransaction is the envelope item type carrying a performance transaction.
+	EnvelopeItemTransaction = "transaction"
+	// EnvelopeItemClientReport is the envelope item type carrying the events an SDK dropped client-side.
+	EnvelopeItemClientReport = "client_report"
+)
+
+// EnvelopeHeader is the first line of an envelope.
+type EnvelopeHeader struct {
+	EventID string `json:"event_id"`
+}
+
+// EnvelopeItemHeader is the header line preceding every envelope item payload.
+// Fields tagged envelope:"required" are listed as required by EnvelopeSchema.
+type EnvelopeItemHeader struct {
+	Type        string `envelope:"required" json:"type"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	// Length is the size of the payload in bytes, attachments are read by it since they may contain newlines.
+	Length int `json:"length"`
+}
+
 // Event represents the main event structure.
 type (
 	Event struct {
@@ -91,6 +119,13 @@ type Contexts struct {
 	OS      OSContext      `json:"os"`
 	Device  DeviceContext  `json:"device"`
 	Runtime RuntimeContext `json:"runtime"`
+	Trace   TraceContext   `json:"trace"`
+}
+
+// TraceContext represents the distributed trace the event happened in.
+type TraceContext struct {
+	TraceID string `json:"trace_id"`
+	SpanID  string `json:"span_id"`
 }
 
 // DeviceContext represents device-specific information.
@@ -155,11 +190,31 @@ type StackTrace struct {
 
 // Exception represents an individual exception.
 type Exception struct {
+	Mechanism  *Mechanism `json:"mechanism"`
 	Type       string     `json:"type"`
 	Value      string     `json:"value"`
 	StackTrace StackTrace `json:"stacktrace"`
 }
 
+// Mechanism describes how an exception was captured by the SDK.
+type Mechanism struct {
+	// Handled is false when the exception was not caught by user code (e.g. a crash).
+	// SDKs may omit it, in which case the exception is considered handled.
+	Handled *bool  `json:"handled"`
+	Type    string `json:"type"`
+}
+
+// IsUnhandled reports whether any of the exceptions was explicitly marked as unhandled.
+func IsUnhandled(exceptions []Exception) bool {
+	for i := range exceptions {
+		m := exceptions[i].Mechanism
+		if m != nil && m.Handled != nil && !*m.Handled {
+			return true
+		}
+	}
+	return false
+}
+
 // ExceptionList handles both Sentry exception formats:
 // - flat array: [{"type": "Error", ...}]
 // - object with values: {"values": [{"type": "Error", ...}]}.
@@ -238,6 +293,9 @@ type EventBody struct {
 	Message     string            `json:"message"`
 	Platform    string            `json:"platform"`
 	Release     string            `json:"release"`
+	Dist        string            `json:"dist"`
+	Logger      string            `json:"logger"`
+	Transaction string            `json:"transaction"`
 	ServerName  string            `json:"server_name"`
 	Level       string            `json:"level"`
 	EventID     string            `json:"event_id"`
@@ -311,8 +369,13 @@ type EventClickhouse struct {
 	Message                 string     `ch:"message" json:"message"`
 	IPv6                    string     `ch:"ipv6" json:"ipv6"`
 	Release                 string     `ch:"release" json:"release"`
+	Dist                    string     `ch:"dist" json:"dist"`
+	Logger                  string     `ch:"logger" json:"logger"`
+	Transaction             string     `ch:"transaction" json:"transaction"`
+	TraceID                 string     `ch:"trace_id" json:"trace_id"`
 	Title                   string     `ch:"title" json:"title"`
 	IPv4                    string     `ch:"ipv4" json:"ipv4"`
+	Raw                     string     `ch:"raw" json:"raw"`
 	ExceptionFramesInApp    Uint8Array `ch:"exception_frames.in_app" json:"exception_frames.in_app"`
 	ContextsKey             []string   `ch:"contexts.key" json:"contexts.key"`
 	ExceptionFramesColNo    []uint32   `ch:"exception_frames.colno" json:"exception_frames.colno"`
@@ -333,6 +396,7 @@ type EventClickhouse struct {
 	Platform                uint8      `ch:"platform" json:"platform"`
 	RetentionDays           uint8      `ch:"retention_days" json:"retention_days"`
 	Deleted                 uint8      `ch:"deleted" json:"deleted"`
+	Unhandled               uint8      `ch:"unhandled" json:"unhandled"`
 }
 
 // GetExceptionStackTypes returns a list of exception stack types.
@@ -379,7 +443,10 @@ func GetExceptionFramesAbsPath(exceptions []Exception) []string {
 	return absPath
 }
 
-func GetBreaker(exceptions []Exception) string {
+// GetBreaker returns the view of an issue: the module and function of the innermost frame
+// of the last exception that is neither ignored nor excluded by the grouping rules.
+// When some frames of the exception are in-app, frames that are not are skipped too.
+func GetBreaker(exceptions []Exception, rules *GroupingRules) string {
 	if len(exceptions) == 0 {
 		return ""
 	}
@@ -389,12 +456,16 @@ func GetBreaker(exceptions []Exception) string {
 	}
 
 	frames := e.StackTrace.Frames
+	hasInApp := slices.ContainsFunc(frames, func(f Frame) bool { return f.InApp })
 
 	for i := len(frames) - 1; i >= 0; i-- {
 		frame := frames[i]
 		if _, found := ignoredModules[frame.GetModule()]; found {
 			continue
 		}
+		if !rules.IsInApp(&frame) || (hasInApp && !frame.InApp) {
+			continue
+		}
 		return frame.GetModule() + " in " + frame.Function
 	}
 
@@ -489,15 +560,20 @@ func GetExceptionFramesLineNo(exceptions []Exception) []uint32 {
 	return lineNo
 }
 
-func GetExceptionFramesInApp(exceptions []Exception) Uint8Array {
+// GetExceptionFramesInApp returns 1 for frames that are in-app and 0 for frames excluded by the grouping rules.
+func GetExceptionFramesInApp(exceptions []Exception, rules *GroupingRules) Uint8Array {
 	if len(exceptions) == 0 {
 		return Uint8Array{}
 	}
 
 	inApp := make(Uint8Array, 0, len(exceptions))
 	for i := range exceptions {
-		for range exceptions[i].StackTrace.Frames {
-			inApp = append(inApp, 1) // for now, all frames are in app
+		for j := range exceptions[i].StackTrace.Frames {
+			if rules.IsInApp(&exceptions[i].StackTrace.Frames[j]) {
+				inApp = append(inApp, 1)
+			} else {
+				inApp = append(inApp, 0)
+			}
 		}
 	}
 
@@ -573,3 +649,54 @@ func GetLevel(level string) Level {
 
 	return levelMapping["unknown"]
 }
+
+// DefaultIssueLevels are the levels issue listings show unless other levels are requested.
+var DefaultIssueLevels = []string{"fatal", "error"}
+
+// LevelView is a set of levels issue listings can be switched to.
+type LevelView struct {
+	Name string
+	// Levels are the level names of the view formatted as the value of the "level" query parameter.
+	Levels string
+}
+
+// IssueLevelViews are the level sets offered by issue listings, the first one is DefaultIssueLevels.
+var IssueLevelViews = []LevelView{
+	{Name: "Errors", Levels: "fatal,error"},
+	{Name: "Errors and warnings", Levels: "fatal,error,warning"},
+	{Name: "All levels", Levels: "fatal,error,warning,info,debug,trace,unknown"},
+}
+
+// LevelName returns the name of the level, "unknown" for levels without a name.
+func LevelName(level Level) string {
+	for name, l := range levelMapping {
+		if l == level {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// ParseLevels parses a comma separated list of level names, e.g. "error,warning".
+// Unknown and repeated names are dropped, the result is ordered by severity.
+// It returns nil when no known level is listed.
+func ParseLevels(s string) []string {
+	var levels []Level
+	for name := range strings.SplitSeq(s, ",") {
+		level, ok := levelMapping[strings.ToLower(strings.TrimSpace(name))]
+		if !ok || slices.Contains(levels, level) {
+			continue
+		}
+		levels = append(levels, level)
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+
+	slices.Sort(levels)
+	names := make([]string, len(levels))
+	for i, level := range levels {
+		names[i] = LevelName(level)
+	}
+	return names
+}
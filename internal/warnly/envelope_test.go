@@ -1,6 +1,8 @@
 package warnly_test
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
 
 	"github.com/vk-rv/warnly/internal/warnly"
@@ -173,6 +175,7 @@ func TestGetBreaker(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
+		rules      *warnly.GroupingRules
 		name       string
 		want       string
 		exceptions []warnly.Exception
@@ -230,12 +233,27 @@ func TestGetBreaker(t *testing.T) {
 			},
 			want: "github.com/buger/jsonparser in parse",
 		},
+		{
+			name: "vendor frame excluded by grouping rules",
+			exceptions: []warnly.Exception{
+				{
+					StackTrace: warnly.StackTrace{
+						Frames: []warnly.Frame{
+							{Module: "mymodule", Function: "myfunc", AbsPath: "/app/handler.go"},
+							{Module: "github.com/lib/pq", Function: "exec", AbsPath: "/app/vendor/github.com/lib/pq/conn.go"},
+						},
+					},
+				},
+			},
+			rules: &warnly.GroupingRules{NotInAppPrefixes: []string{"/app/vendor/"}},
+			want:  "mymodule in myfunc",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := warnly.GetBreaker(tt.exceptions)
+			got := warnly.GetBreaker(tt.exceptions, tt.rules)
 			if got != tt.want {
 				t.Errorf("GetBreaker() = %v, want %v", got, tt.want)
 			}
@@ -597,3 +615,135 @@ func TestGetLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "single", input: "warning", want: []string{"warning"}},
+		{name: "ordered by severity", input: "warning,error,fatal", want: []string{"fatal", "error", "warning"}},
+		{name: "case and spaces", input: " Error , WARNING", want: []string{"error", "warning"}},
+		{name: "duplicates", input: "error,error", want: []string{"error"}},
+		{name: "unknown names dropped", input: "error,critical", want: []string{"error"}},
+		{name: "only unknown names", input: "critical", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := warnly.ParseLevels(tt.input)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseLevels(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"fatal", "error", "warning", "info", "debug", "trace", "unknown"} {
+		if got := warnly.LevelName(warnly.GetLevel(name)); got != name {
+			t.Errorf("LevelName(GetLevel(%q)) = %q", name, got)
+		}
+	}
+	if got := warnly.LevelName(0); got != "unknown" {
+		t.Errorf("LevelName(0) = %q, want unknown", got)
+	}
+}
+
+func TestIsUnhandled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		payload string
+		want    bool
+	}{
+		{
+			name:    "no mechanism",
+			payload: `[{"type":"TypeError","value":"x"}]`,
+			want:    false,
+		},
+		{
+			name:    "mechanism without handled flag",
+			payload: `[{"type":"TypeError","mechanism":{"type":"generic"}}]`,
+			want:    false,
+		},
+		{
+			name:    "handled",
+			payload: `{"values":[{"type":"TypeError","mechanism":{"type":"generic","handled":true}}]}`,
+			want:    false,
+		},
+		{
+			name:    "unhandled in chain",
+			payload: `{"values":[{"type":"Wrapped"},{"type":"panic","mechanism":{"type":"panic","handled":false}}]}`,
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var exceptions warnly.ExceptionList
+			if err := json.Unmarshal([]byte(tt.payload), &exceptions); err != nil {
+				t.Fatalf("unmarshal exceptions: %v", err)
+			}
+			if got := warnly.IsUnhandled(exceptions); got != tt.want {
+				t.Errorf("IsUnhandled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvelopeSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := warnly.EnvelopeSchema()
+
+	if !slices.Equal(schema.ItemHeader.Required, []string{"type"}) {
+		t.Fatalf("item header required = %v, want [type]", schema.ItemHeader.Required)
+	}
+	for _, field := range []string{"type", "length", "filename", "content_type"} {
+		if _, ok := schema.ItemHeader.Properties[field]; !ok {
+			t.Errorf("item header is missing %q", field)
+		}
+	}
+	if _, ok := schema.Header.Properties["event_id"]; !ok {
+		t.Error("envelope header is missing event_id")
+	}
+
+	for _, itemType := range []string{
+		warnly.EnvelopeItemEvent,
+		warnly.EnvelopeItemAttachment,
+		warnly.EnvelopeItemTransaction,
+		warnly.EnvelopeItemClientReport,
+	} {
+		if _, ok := schema.Items[itemType]; !ok {
+			t.Errorf("item type %q is missing", itemType)
+		}
+	}
+
+	event := schema.Items[warnly.EnvelopeItemEvent]
+	if event.Type != "object" {
+		t.Fatalf("event type = %q, want object", event.Type)
+	}
+	for _, field := range []string{"event_id", "timestamp", "message", "level", "release", "exception", "user", "tags"} {
+		if _, ok := event.Properties[field]; !ok {
+			t.Errorf("event is missing %q", field)
+		}
+	}
+	if got := len(event.Properties["exception"].OneOf); got != 2 {
+		t.Errorf("exception accepts %d shapes, want 2", got)
+	}
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("marshal schema: %v", err)
+	}
+}
@@ -2,6 +2,10 @@ package warnly
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 )
 
 // DefaultMessage is used when we can't get the error message from stacktrace.
@@ -11,17 +15,90 @@ const DefaultMessage = "(No error message)"
 type EventService interface {
 	// IngestEvent ingests and stores a new event in both OLTP and OLAP databases.
 	IngestEvent(ctx context.Context, req IngestRequest) (IngestEventResult, error)
+	// AuthenticateIngest checks the project key and the payload signature of an envelope without an event,
+	// e.g. of transactions only, which is acknowledged without being stored.
+	AuthenticateIngest(ctx context.Context, req IngestRequest) error
+	// RecordClientReports adds up the events SDKs of a project dropped before sending them.
+	RecordClientReports(ctx context.Context, req *ClientReportsRequest) error
+}
+
+// ClientReport is sent by SDKs with the events they dropped client-side, e.g. because of sampling.
+type ClientReport struct {
+	DiscardedEvents []DiscardedEvents `json:"discarded_events"`
+}
+
+// DiscardedEvents is the number of events of a category dropped for a reason,
+// e.g. 3 errors dropped because of sample_rate.
+type DiscardedEvents struct {
+	Reason   string `json:"reason"`
+	Category string `json:"category"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// MaxDiscardFieldLength is the maximum length of a discard reason or category, longer ones are ignored.
+const MaxDiscardFieldLength = 64
+
+// ClientReportsRequest is a request to record client reports of a project.
+type ClientReportsRequest struct {
+	Reports    []ClientReport
+	ProjectKey string
+	ProjectID  int
 }
 
 type IngestEventResult struct {
 	// EventID is the ID of the ingested event.
 	EventID string
+	// Dropped reports whether the event was discarded by project sampling.
+	Dropped bool
+	// Deduplicated reports whether the event was not stored as a duplicate of a recent event.
+	Deduplicated bool
+	// Filtered reports whether the event was dropped by the ingest filters of the project
+	// or by the noise filters of its issue.
+	Filtered bool
+	// Queued reports whether the event was produced to the queue and is stored asynchronously.
+	Queued bool
 }
 
 // IngestRequest is a request to ingest a new event.
 type IngestRequest struct {
 	Event      *EventBody
+	RawEvent   []byte // event payload as it was sent by the SDK
 	IP         string
 	ProjectKey string
 	ProjectID  int
+	// Attachments are files sent along with the event, their event and project are set on ingestion.
+	Attachments []Attachment
+	// Payload is the request body the signature is computed over.
+	Payload []byte
+	// Signature is the hex HMAC-SHA256 of the payload sent in the IngestSignatureHeader.
+	Signature string
+}
+
+// IngestSignatureHeader is the header carrying the signature of an ingested payload
+// for projects that require signed ingestion.
+// It differs from the signature header of outgoing webhooks, which is signed over a timestamp as well.
+const IngestSignatureHeader = "X-Warnly-Ingest-Signature"
+
+// ErrInvalidSignature is returned when a project requires signed ingestion
+// and the payload signature is missing or doesn't match.
+var ErrInvalidSignature = errors.New("invalid ingest signature")
+
+// ErrStoreEvent is returned when an ingested event could not be persisted.
+var ErrStoreEvent = errors.New("event not stored")
+
+// VerifyIngestSignature checks that the signature is the hex HMAC-SHA256 of the payload with the secret.
+func VerifyIngestSignature(secret string, payload []byte, signature string) error {
+	if signature == "" {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(payload)
+	if !hmac.Equal(got, h.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
 }
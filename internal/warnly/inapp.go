@@ -0,0 +1,56 @@
+package warnly
+
+import "strings"
+
+// goModuleCacheDir is part of the path of every source file in the Go module cache,
+// which is $GOPATH/pkg/mod unless GOMODCACHE says otherwise.
+const goModuleCacheDir = "/pkg/mod/"
+
+// goStdRoots are the top-level packages of the Go standard library, the directories of GOROOT/src.
+var goStdRoots = map[string]struct{}{
+	"archive": {}, "bufio": {}, "bytes": {}, "cmp": {}, "compress": {}, "container": {},
+	"context": {}, "crypto": {}, "database": {}, "debug": {}, "embed": {}, "encoding": {},
+	"errors": {}, "expvar": {}, "flag": {}, "fmt": {}, "go": {}, "hash": {}, "html": {},
+	"image": {}, "index": {}, "internal": {}, "io": {}, "iter": {}, "log": {}, "maps": {},
+	"math": {}, "mime": {}, "net": {}, "os": {}, "path": {}, "plugin": {}, "reflect": {},
+	"regexp": {}, "runtime": {}, "slices": {}, "sort": {}, "strconv": {}, "strings": {},
+	"structs": {}, "sync": {}, "syscall": {}, "testing": {}, "text": {}, "time": {},
+	"unicode": {}, "unique": {}, "unsafe": {}, "vendor": {}, "weak": {},
+}
+
+// ApplyInApp overrides the in_app flag the SDK set on the frames of the event with the
+// path heuristics of the project's platform. For Go, frames from the module cache and
+// the standard library are not in-app even when the SDK marks them so.
+// Frames matching the in-app prefixes of the grouping rules keep the SDK's flag.
+func ApplyInApp(event *EventBody, platform Platform, rules *GroupingRules) {
+	if platform != PlatformGolang {
+		return
+	}
+	for i := range event.Exception {
+		applyGoInApp(event.Exception[i].StackTrace.Frames, rules)
+	}
+	for i := range event.Threads {
+		applyGoInApp(event.Threads[i].StackTrace.Frames, rules)
+	}
+}
+
+func applyGoInApp(frames []Frame, rules *GroupingRules) {
+	for i := range frames {
+		if !frames[i].InApp || rules.KeepsInApp(&frames[i]) {
+			continue
+		}
+		if isGoDependency(&frames[i]) {
+			frames[i].InApp = false
+		}
+	}
+}
+
+// isGoDependency reports whether the frame is in the module cache or in the standard library.
+func isGoDependency(frame *Frame) bool {
+	if strings.Contains(frame.AbsPath, goModuleCacheDir) {
+		return true
+	}
+	root, _, _ := strings.Cut(frame.GetModule(), "/")
+	_, std := goStdRoots[root]
+	return std
+}
@@ -0,0 +1,106 @@
+package warnly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestApplyInApp(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func() *warnly.EventBody {
+		return &warnly.EventBody{
+			Exception: warnly.ExceptionList{{
+				StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+					{Module: "main", Function: "main", AbsPath: "/app/main.go", InApp: true},
+					{Module: "shop/checkout", Function: "Pay", AbsPath: "/app/checkout/pay.go", InApp: true},
+					{Module: "net/http", Function: "(*Client).Do", AbsPath: "/usr/local/go/src/net/http/client.go", InApp: true},
+					{Module: "github.com/acme/payments", Function: "Charge", AbsPath: "/go/pkg/mod/github.com/acme/payments@v1.2.0/charge.go", InApp: true},
+				}},
+			}},
+			Threads: warnly.ThreadList{{
+				StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+					{Module: "runtime", Function: "goexit", AbsPath: "/usr/local/go/src/runtime/asm_amd64.s", InApp: true},
+				}},
+			}},
+		}
+	}
+	inApp := func(frames []warnly.Frame) []bool {
+		res := make([]bool, len(frames))
+		for i := range frames {
+			res[i] = frames[i].InApp
+		}
+		return res
+	}
+
+	tests := []struct {
+		rules       *warnly.GroupingRules
+		name        string
+		platform    warnly.Platform
+		wantFrames  []bool
+		wantThreads []bool
+		wantView    string
+	}{
+		{
+			name:        "go module cache and stdlib frames",
+			platform:    warnly.PlatformGolang,
+			wantFrames:  []bool{true, true, false, false},
+			wantThreads: []bool{false},
+			wantView:    "shop/checkout in Pay",
+		},
+		{
+			name:        "in-app prefix keeps the sdk flag",
+			platform:    warnly.PlatformGolang,
+			rules:       &warnly.GroupingRules{InAppPrefixes: []string{"github.com/acme/"}},
+			wantFrames:  []bool{true, true, false, true},
+			wantThreads: []bool{false},
+			wantView:    "github.com/acme/payments in Charge",
+		},
+		{
+			name:        "not a go project",
+			platform:    warnly.PlatformRust,
+			wantFrames:  []bool{true, true, true, true},
+			wantThreads: []bool{true},
+			wantView:    "github.com/acme/payments in Charge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event := newEvent()
+			warnly.ApplyInApp(event, tt.platform, tt.rules)
+
+			assert.Equal(t, tt.wantFrames, inApp(event.Exception[0].StackTrace.Frames))
+			assert.Equal(t, tt.wantThreads, inApp(event.Threads[0].StackTrace.Frames))
+			assert.Equal(t, tt.wantView, warnly.GetBreaker(event.Exception, tt.rules))
+		})
+	}
+}
+
+func TestApplyInAppKeepsFramesTheSDKMarkedOutOfApp(t *testing.T) {
+	t.Parallel()
+
+	event := &warnly.EventBody{Exception: warnly.ExceptionList{{
+		StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+			{Module: "shop/checkout", Function: "Pay", AbsPath: "/app/checkout/pay.go"},
+		}},
+	}}}
+	warnly.ApplyInApp(event, warnly.PlatformGolang, &warnly.GroupingRules{InAppPrefixes: []string{"shop/"}})
+
+	assert.False(t, event.Exception[0].StackTrace.Frames[0].InApp)
+}
+
+func TestValidateInAppPrefixes(t *testing.T) {
+	t.Parallel()
+
+	rules := &warnly.GroupingRules{InAppPrefixes: []string{"github.com/acme/"}}
+	assert.NoError(t, rules.Validate())
+	assert.False(t, rules.IsZero())
+
+	invalid := &warnly.GroupingRules{InAppPrefixes: []string{" "}}
+	assert.ErrorIs(t, invalid.Validate(), warnly.ErrInvalidGroupingRules)
+}
@@ -0,0 +1,84 @@
+package warnly
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// MaxIngestFilterPatterns is the maximum number of patterns each list of ingest filters can define.
+const MaxIngestFilterPatterns = 50
+
+// ErrInvalidIngestFilters is returned when ingest filters can't be matched against events.
+var ErrInvalidIngestFilters = errors.New("invalid ingest filters")
+
+// IngestFilters drop events of a project at ingest by their environment and release.
+// Patterns are globs, e.g. local, *-rc* or 1.2.*, see path.Match for the syntax.
+// An event is dropped when it matches a deny pattern, or when an allow list is set
+// and it matches none of its patterns.
+type IngestFilters struct {
+	AllowEnvironments []string `json:"allow_environments,omitempty"`
+	DenyEnvironments  []string `json:"deny_environments,omitempty"`
+	AllowReleases     []string `json:"allow_releases,omitempty"`
+	DenyReleases      []string `json:"deny_releases,omitempty"`
+}
+
+// IsZero reports whether no pattern is set, so every event is kept.
+func (f *IngestFilters) IsZero() bool {
+	return f == nil || (len(f.AllowEnvironments) == 0 && len(f.DenyEnvironments) == 0 &&
+		len(f.AllowReleases) == 0 && len(f.DenyReleases) == 0)
+}
+
+// Validate checks that every pattern is a valid glob and the number of patterns is limited.
+func (f *IngestFilters) Validate() error {
+	lists := []struct {
+		name     string
+		patterns []string
+	}{
+		{name: "allowed environments", patterns: f.AllowEnvironments},
+		{name: "denied environments", patterns: f.DenyEnvironments},
+		{name: "allowed releases", patterns: f.AllowReleases},
+		{name: "denied releases", patterns: f.DenyReleases},
+	}
+	for _, list := range lists {
+		if len(list.patterns) > MaxIngestFilterPatterns {
+			return fmt.Errorf("%w: at most %d %s are allowed", ErrInvalidIngestFilters, MaxIngestFilterPatterns, list.name)
+		}
+		for _, pattern := range list.patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%w: empty pattern in %s", ErrInvalidIngestFilters, list.name)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%w: pattern %q in %s: %w", ErrInvalidIngestFilters, pattern, list.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Drops reports whether an event of the environment and release is dropped at ingest.
+func (f *IngestFilters) Drops(env, release string) bool {
+	if f == nil {
+		return false
+	}
+	return !keeps(env, f.AllowEnvironments, f.DenyEnvironments) || !keeps(release, f.AllowReleases, f.DenyReleases)
+}
+
+// keeps reports whether the value matches none of the deny patterns
+// and one of the allow patterns, when there are any.
+func keeps(value string, allow, deny []string) bool {
+	if matchesAny(value, deny) {
+		return false
+	}
+	return len(allow) == 0 || matchesAny(value, allow)
+}
+
+func matchesAny(value string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
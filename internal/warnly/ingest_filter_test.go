@@ -0,0 +1,97 @@
+package warnly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestIngestFiltersDrops(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filters *warnly.IngestFilters
+		env     string
+		release string
+		want    bool
+	}{
+		{name: "no filters", env: "local", release: "1.0.0"},
+		{
+			name:    "denied environment",
+			filters: &warnly.IngestFilters{DenyEnvironments: []string{"local"}},
+			env:     "local",
+			want:    true,
+		},
+		{
+			name:    "environment not denied",
+			filters: &warnly.IngestFilters{DenyEnvironments: []string{"local"}},
+			env:     "production",
+		},
+		{
+			name:    "environment not allowed",
+			filters: &warnly.IngestFilters{AllowEnvironments: []string{"production", "staging-*"}},
+			env:     "qa",
+			want:    true,
+		},
+		{
+			name:    "allowed environment glob",
+			filters: &warnly.IngestFilters{AllowEnvironments: []string{"production", "staging-*"}},
+			env:     "staging-eu",
+		},
+		{
+			name: "deny wins over allow",
+			filters: &warnly.IngestFilters{
+				AllowEnvironments: []string{"*"},
+				DenyEnvironments:  []string{"local"},
+			},
+			env:  "local",
+			want: true,
+		},
+		{
+			name:    "pre-release denied",
+			filters: &warnly.IngestFilters{DenyReleases: []string{"*-alpha*", "*-rc*"}},
+			env:     "production",
+			release: "app@2.0.0-rc.1",
+			want:    true,
+		},
+		{
+			name:    "release not allowed",
+			filters: &warnly.IngestFilters{AllowReleases: []string{"app@2.*"}},
+			release: "app@1.9.0",
+			want:    true,
+		},
+		{
+			name:    "allowed release",
+			filters: &warnly.IngestFilters{AllowReleases: []string{"app@2.*"}},
+			release: "app@2.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.filters.Drops(tt.env, tt.release))
+		})
+	}
+}
+
+func TestIngestFiltersValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&warnly.IngestFilters{DenyEnvironments: []string{"local"}, AllowReleases: []string{"1.*"}}).Validate())
+
+	err := (&warnly.IngestFilters{DenyReleases: []string{"[1-"}}).Validate()
+	require.ErrorIs(t, err, warnly.ErrInvalidIngestFilters)
+
+	err = (&warnly.IngestFilters{AllowEnvironments: []string{" "}}).Validate()
+	require.ErrorIs(t, err, warnly.ErrInvalidIngestFilters)
+
+	err = (&warnly.IngestFilters{DenyEnvironments: make([]string, warnly.MaxIngestFilterPatterns+1)}).Validate()
+	require.ErrorIs(t, err, warnly.ErrInvalidIngestFilters)
+
+	assert.True(t, (*warnly.IngestFilters)(nil).IsZero())
+	assert.True(t, (&warnly.IngestFilters{}).IsZero())
+}
@@ -0,0 +1,152 @@
+package warnly
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultInvitationTTL is how long an invitation can be accepted when nothing else is configured.
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+// MinPasswordLen is the shortest password a user signing up with an invitation can choose.
+const MinPasswordLen = 8
+
+var (
+	// ErrInvalidInvitation is returned when an invitation token is malformed or its signature doesn't match.
+	ErrInvalidInvitation = errors.New("invalid invitation")
+	// ErrInvitationExpired is returned when an invitation is accepted after it expired.
+	ErrInvitationExpired = errors.New("invitation expired")
+	// ErrInvitationUsed is returned when an invitation has already been accepted.
+	ErrInvitationUsed = errors.New("invitation already used")
+	// ErrInvalidEmail is returned when the invited email isn't a valid address.
+	ErrInvalidEmail = errors.New("invalid email")
+	// ErrUserExists is returned when an invitation is sent to or accepted for an email of an existing user.
+	ErrUserExists = errors.New("user already exists")
+	// ErrPasswordTooShort is returned when the chosen password is shorter than MinPasswordLen.
+	ErrPasswordTooShort = errors.New("password too short")
+)
+
+// Invitation is an invitation of a person to join a team, accepted with a signed token.
+type Invitation struct {
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	Email      string
+	Role       Role
+	ID         int64
+	InvitedBy  int64
+	TeamID     int
+}
+
+// Expired reports whether the invitation can no longer be accepted at the given time.
+func (i *Invitation) Expired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// InviteRequest is a request to invite a person to the team by email.
+type InviteRequest struct {
+	User   *User
+	Email  string
+	Role   Role
+	TeamID int
+}
+
+// InviteResult is the created invitation along with the link accepting it.
+type InviteResult struct {
+	Invitation *Invitation
+	Link       string
+	// Sent is false when no mailer is configured and the link has to be shared manually.
+	Sent bool
+}
+
+// AcceptInvitationRequest is a request to sign up with an invitation token.
+type AcceptInvitationRequest struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// InvitationMail is the message delivered to the invited person.
+type InvitationMail struct {
+	ExpiresAt time.Time
+	Email     string
+	TeamName  string
+	Inviter   string
+	Link      string
+}
+
+// InvitationStore encapsulates the invitation storage.
+type InvitationStore interface {
+	// CreateInvitation stores the invitation and returns its identifier.
+	CreateInvitation(ctx context.Context, invitation *Invitation) (int64, error)
+	// GetInvitation returns the invitation by identifier, ErrNotFound if there is none.
+	GetInvitation(ctx context.Context, id int64) (*Invitation, error)
+	// AcceptInvitation marks the invitation accepted, ErrInvitationUsed if it was accepted before.
+	AcceptInvitation(ctx context.Context, id int64, at time.Time) error
+}
+
+// InvitationSender delivers invitations to the invited people.
+type InvitationSender interface {
+	// SendInvitation delivers the invitation link to the invited email.
+	SendInvitation(ctx context.Context, mail *InvitationMail) error
+}
+
+// ValidateEmail checks that the value is a bare email address.
+func ValidateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// SignInvitation returns the token accepting the invitation, formatted as "<id>.<hex signature>".
+// The HMAC-SHA256 signature covers the email, the team, the role and the expiration,
+// so the token is only valid for the invitation it was issued for.
+func SignInvitation(key []byte, inv *Invitation) string {
+	return strconv.FormatInt(inv.ID, 10) + "." + hex.EncodeToString(invitationMAC(key, inv))
+}
+
+// ParseInvitationToken returns the invitation identifier and the signature carried by the token.
+func ParseInvitationToken(token string) (int64, []byte, error) {
+	rawID, rawSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, nil, ErrInvalidInvitation
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, nil, ErrInvalidInvitation
+	}
+	sig, err := hex.DecodeString(rawSig)
+	if err != nil {
+		return 0, nil, ErrInvalidInvitation
+	}
+	return id, sig, nil
+}
+
+// VerifyInvitation reports whether the signature was issued for the invitation with the key.
+func VerifyInvitation(key []byte, inv *Invitation, sig []byte) bool {
+	return hmac.Equal(sig, invitationMAC(key, inv))
+}
+
+// invitationMAC computes the signature of the invitation fields the token is tied to.
+func invitationMAC(key []byte, inv *Invitation) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(strconv.FormatInt(inv.ID, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ToLower(inv.Email)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(inv.TeamID)))
+	h.Write([]byte{0})
+	h.Write([]byte(inv.Role))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(inv.ExpiresAt.Unix(), 10)))
+	return h.Sum(nil)
+}
@@ -0,0 +1,60 @@
+package warnly_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestSignInvitation(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("invitation-key")
+	inv := &warnly.Invitation{
+		ExpiresAt: time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC),
+		Email:     "jane@example.com",
+		Role:      warnly.RoleMember,
+		ID:        42,
+		TeamID:    1,
+	}
+
+	id, sig, err := warnly.ParseInvitationToken(warnly.SignInvitation(key, inv))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.True(t, warnly.VerifyInvitation(key, inv, sig))
+
+	assert.False(t, warnly.VerifyInvitation([]byte("other-key"), inv, sig), "another key")
+
+	tampered := []func(inv *warnly.Invitation){
+		func(inv *warnly.Invitation) { inv.Email = "mallory@example.com" },
+		func(inv *warnly.Invitation) { inv.TeamID = 2 },
+		func(inv *warnly.Invitation) { inv.Role = warnly.RoleAdmin },
+		func(inv *warnly.Invitation) { inv.ExpiresAt = inv.ExpiresAt.Add(time.Hour) },
+	}
+	for i, tamper := range tampered {
+		changed := *inv
+		tamper(&changed)
+		assert.False(t, warnly.VerifyInvitation(key, &changed, sig), "tampered field %d", i)
+	}
+}
+
+func TestParseInvitationTokenInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, token := range []string{"", "42", "abc.00", "0.00", "-1.00", "42.xyz"} {
+		_, _, err := warnly.ParseInvitationToken(token)
+		require.ErrorIs(t, err, warnly.ErrInvalidInvitation, token)
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, warnly.ValidateEmail("jane@example.com"))
+	for _, email := range []string{"", "jane", "Jane <jane@example.com>", "jane@example.com\r\nBcc: x@example.com"} {
+		require.ErrorIs(t, warnly.ValidateEmail(email), warnly.ErrInvalidEmail, email)
+	}
+}
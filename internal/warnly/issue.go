@@ -1,9 +1,18 @@
 package warnly
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"golang.org/x/mod/semver"
 )
 
 // ErrDuplicate is returned when an entity already exists in a database.
@@ -11,17 +20,145 @@ var ErrDuplicate = errors.New("entity already exists in a database")
 
 // Issue represents a collection of error events mapped by their hash.
 type Issue struct {
-	FirstSeen   time.Time     `json:"first_seen"`
-	LastSeen    time.Time     `json:"last_seen"`
-	Hash        string        `json:"hash"`
-	Message     string        `json:"message"`
-	View        string        `json:"view"`
-	ErrorType   string        `json:"error_type"`
-	UUID        UUID          `json:"uuid"`
-	ID          int64         `json:"id"`
-	NumComments int           `json:"num_comments"`
-	ProjectID   int           `json:"project_id"`
-	Priority    IssuePriority `json:"priority"`
+	FirstSeen  time.Time  `json:"first_seen"`
+	LastSeen   time.Time  `json:"last_seen"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	Hash       string     `json:"hash"`
+	// ResolvedInRelease is the release the issue was fixed in, empty when unknown.
+	ResolvedInRelease string        `json:"resolved_in_release"`
+	Message           string        `json:"message"`
+	View              string        `json:"view"`
+	ErrorType         string        `json:"error_type"`
+	Status            IssueStatus   `json:"status"`
+	UUID              UUID          `json:"uuid"`
+	ID                int64         `json:"id"`
+	NumComments       int           `json:"num_comments"`
+	ProjectID         int           `json:"project_id"`
+	Priority          IssuePriority `json:"priority"`
+	// Ignore is the condition an ignored issue reappears on, nil unless the issue is ignored.
+	Ignore *IgnoreRule `json:"ignore,omitempty"`
+	// NoiseFilters drop the events of the issue by their tags at ingest.
+	NoiseFilters NoiseFilters `json:"noise_filters,omitempty"`
+}
+
+// IssueStatus represents the lifecycle state of an issue.
+type IssueStatus string
+
+const (
+	// IssueStatusUnresolved is the status of an issue that still needs attention.
+	IssueStatusUnresolved IssueStatus = "unresolved"
+	// IssueStatusResolved is the status of an issue marked as fixed.
+	IssueStatusResolved IssueStatus = "resolved"
+	// IssueStatusIgnored is the status of an issue hidden until its ignore condition is met.
+	IssueStatusIgnored IssueStatus = "ignored"
+)
+
+// IsResolved reports whether the issue is marked as resolved.
+func (i *Issue) IsResolved() bool {
+	return i.Status == IssueStatusResolved
+}
+
+// IsIgnored reports whether the issue is ignored until its ignore condition is met.
+func (i *Issue) IsIgnored() bool {
+	return i.Status == IssueStatusIgnored && i.Ignore != nil
+}
+
+// IgnoreCondition is what an ignored issue waits for before it reappears.
+type IgnoreCondition string
+
+const (
+	// IgnoreConditionCount reopens the issue once it occurs the threshold number of times more.
+	IgnoreConditionCount IgnoreCondition = "count"
+	// IgnoreConditionUsers reopens the issue once it affects the threshold number of users more.
+	IgnoreConditionUsers IgnoreCondition = "users"
+	// IgnoreConditionTime reopens the issue once the time it is ignored for has passed.
+	IgnoreConditionTime IgnoreCondition = "time"
+)
+
+const (
+	// MaxIgnoreThreshold limits the number of occurrences or users an issue can be ignored for.
+	MaxIgnoreThreshold = 1_000_000
+	// MaxIgnoreDuration limits the time an issue can be ignored for.
+	MaxIgnoreDuration = 90 * 24 * time.Hour
+)
+
+// ErrInvalidIgnoreCondition is returned when an issue is ignored with an unknown condition,
+// a threshold out of range or without a duration.
+var ErrInvalidIgnoreCondition = errors.New("ignore needs a count or users condition with a threshold " +
+	"of 1-1000000 or a time condition with a duration of up to 90 days")
+
+// IgnoreRule is the condition an ignored issue reappears on.
+type IgnoreRule struct {
+	// IgnoredAt is when the issue was ignored, occurrences and users are counted from it.
+	IgnoredAt time.Time `json:"ignored_at"`
+	// Until is when an issue ignored for a time reappears, nil for other conditions.
+	Until     *time.Time      `json:"until,omitempty"`
+	Condition IgnoreCondition `json:"condition"`
+	// Threshold is the number of occurrences or affected users the issue reappears after.
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// NewIgnoreRule returns the rule of an issue ignored at now, checking the condition.
+// threshold is used by the count and users conditions, duration by the time condition.
+func NewIgnoreRule(condition IgnoreCondition, threshold int, duration time.Duration, now time.Time) (*IgnoreRule, error) {
+	rule := &IgnoreRule{IgnoredAt: now, Condition: condition}
+	switch condition {
+	case IgnoreConditionCount, IgnoreConditionUsers:
+		if threshold < 1 || threshold > MaxIgnoreThreshold {
+			return nil, ErrInvalidIgnoreCondition
+		}
+		rule.Threshold = threshold
+	case IgnoreConditionTime:
+		if duration <= 0 || duration > MaxIgnoreDuration {
+			return nil, ErrInvalidIgnoreCondition
+		}
+		until := now.Add(duration)
+		rule.Until = &until
+	default:
+		return nil, ErrInvalidIgnoreCondition
+	}
+	return rule, nil
+}
+
+// Met reports whether the ignored issue should reappear at now. seen is the number of
+// occurrences or affected users since the issue was ignored, depending on the condition.
+func (r *IgnoreRule) Met(seen int, now time.Time) bool {
+	switch r.Condition {
+	case IgnoreConditionCount, IgnoreConditionUsers:
+		return seen >= r.Threshold
+	case IgnoreConditionTime:
+		return r.Until != nil && !now.Before(*r.Until)
+	default:
+		return false
+	}
+}
+
+// IsRegression reports whether an event of the given release is a recurrence of an issue
+// resolved in resolvedIn. Releases are ordered as semantic versions, with or without the v prefix,
+// and by a plain string comparison when either of them isn't one, e.g. date based release names.
+// Events of the release the issue was fixed in, of older releases and without a release
+// are not regressions.
+func IsRegression(resolvedIn, release string) bool {
+	if resolvedIn == "" || release == "" {
+		return false
+	}
+	resolvedVersion, version := semanticVersion(resolvedIn), semanticVersion(release)
+	if resolvedVersion != "" && version != "" {
+		return semver.Compare(version, resolvedVersion) > 0
+	}
+	return release > resolvedIn
+}
+
+// semanticVersion returns the release as a semantic version with the v prefix,
+// empty when the release isn't a semantic version.
+func semanticVersion(release string) string {
+	if !strings.HasPrefix(release, "v") {
+		release = "v" + release
+	}
+	if !semver.IsValid(release) {
+		return ""
+	}
+	return release
 }
 
 // IssueMetrics represents the metrics of an issue.
@@ -31,6 +168,8 @@ type IssueMetrics struct {
 	GID       uint64    `json:"gid"`
 	TimesSeen uint64    `json:"times_seen"`
 	UserCount uint64    `json:"user_count"`
+	// Level is the most frequent level of the issue events.
+	Level Level `json:"level"`
 }
 
 func GetMetrics(metrics []IssueMetrics, id int64) (IssueMetrics, bool) {
@@ -42,10 +181,69 @@ func GetMetrics(metrics []IssueMetrics, id int64) (IssueMetrics, bool) {
 	return IssueMetrics{}, false
 }
 
+// Hotness scores how hot an issue is at now from its hourly event counts. Every event adds to
+// the score an amount halving with every halfLife of its age, so the score follows the recent
+// event velocity: an issue spiking now outranks an issue with more events that are hours old.
+// Events of an hour are aged from the middle of the hour, lastSeen caps the age of the
+// current hour so that its events aren't counted as younger than the last of them.
+func Hotness(hours []IssueEventsPerHour, lastSeen time.Time, halfLife time.Duration, now time.Time) float64 {
+	score := 0.0
+	for _, h := range hours {
+		at := h.TS.Add(30 * time.Minute)
+		if at.After(lastSeen) && !h.TS.After(lastSeen) {
+			at = lastSeen
+		}
+		age := max(now.Sub(at), 0)
+		score += float64(h.Count) * math.Exp2(-float64(age)/float64(halfLife))
+	}
+	return score
+}
+
+// SparklineHours is the number of hourly buckets of the event series of an issue in the issue list.
+const SparklineHours = 24
+
+// NewSparkline spreads the hourly event counts of an issue over SparklineHours buckets, oldest first,
+// the last bucket is the hour of to. Hours without events and hours out of the buckets are left out.
+func NewSparkline(hours []IssueEventsPerHour, to time.Time) []int {
+	series := make([]int, SparklineHours)
+	last := to.UTC().Truncate(time.Hour)
+	for _, h := range hours {
+		i := SparklineHours - 1 - int(last.Sub(h.TS.UTC().Truncate(time.Hour))/time.Hour)
+		if i < 0 || i >= SparklineHours {
+			continue
+		}
+		series[i] += h.Count
+	}
+	return series
+}
+
 type IssueInfo struct {
 	UUID string `json:"uuid"`
 	Hash string `json:"hash"`
-	ID   int64  `json:"id"`
+	// ResolvedInRelease is set while the issue is resolved in a known release.
+	ResolvedInRelease string `json:"resolved_in_release,omitempty"`
+	ID                int64  `json:"id"`
+	// Ignore is set while the issue is ignored.
+	Ignore *IgnoreRule `json:"ignore,omitempty"`
+	// NoiseFilters drop the events of the issue by their tags.
+	NoiseFilters NoiseFilters `json:"noise_filters,omitempty"`
+}
+
+// IgnoredIssueReopener reopens the ignored issues whose ignore condition is met without their next event.
+type IgnoredIssueReopener interface {
+	// ReopenIgnoredIssues reopens the ignored issues whose ignore time has passed or that affected
+	// the threshold number of users, it returns the number of reopened issues.
+	ReopenIgnoredIssues(ctx context.Context) (int, error)
+}
+
+// IngestCache is the cache of issue infos and project options kept by the ingestion.
+type IngestCache interface {
+	// ForgetIssue drops the cached info of the issue, so that a change of its status
+	// applies to the next event of the issue rather than once the cached info expires.
+	ForgetIssue(projectID int, hash string)
+	// ForgetProjectKey drops the project options cached for the key, so that a revoked key
+	// is rejected once the revocation takes effect rather than once the cached options expire.
+	ForgetProjectKey(projectID int, key string)
 }
 
 type IssuePriority int
@@ -62,6 +260,65 @@ var AllowedPriorities = [...]IssuePriority{
 	PriorityHigh,
 }
 
+// DefaultIssuePriority is the priority of a new issue that no priority rule of its project matches.
+const DefaultIssuePriority = PriorityHigh
+
+// MaxPriorityRules is the maximum number of priority rules a project can define.
+const MaxPriorityRules = 20
+
+// ErrInvalidPriorityRule is returned when a priority rule can't be applied to events.
+var ErrInvalidPriorityRule = errors.New("invalid priority rule")
+
+// PriorityRule sets the priority of a new issue to Priority when its first event carries
+// the tag TagKey with the value TagValue, e.g. env:staging, and raises the priority
+// of an existing issue when a later event carries the tag.
+type PriorityRule struct {
+	TagKey   string        `json:"tag_key"`
+	TagValue string        `json:"tag_value"`
+	Priority IssuePriority `json:"priority"`
+}
+
+// Validate checks that the rule matches a tag and raises to a known priority.
+func (r *PriorityRule) Validate() error {
+	if r.TagKey == "" || r.TagValue == "" {
+		return fmt.Errorf("%w: tag key and value are required", ErrInvalidPriorityRule)
+	}
+	if !slices.Contains(AllowedPriorities[:], r.Priority) {
+		return fmt.Errorf("%w: unknown priority %d", ErrInvalidPriorityRule, r.Priority)
+	}
+	return nil
+}
+
+// ValidatePriorityRules checks the priority rules of a project.
+func ValidatePriorityRules(rules []PriorityRule) error {
+	if len(rules) > MaxPriorityRules {
+		return fmt.Errorf("%w: at most %d rules are allowed", ErrInvalidPriorityRule, MaxPriorityRules)
+	}
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BoostPriority returns the highest of the priority and the priorities of the rules
+// matched by the event tags, given as parallel key and value slices.
+func BoostPriority(priority IssuePriority, rules []PriorityRule, tagKeys, tagValues []string) IssuePriority {
+	for i := range rules {
+		if rules[i].Priority <= priority {
+			continue
+		}
+		for j := range tagKeys {
+			if tagKeys[j] == rules[i].TagKey && tagValues[j] == rules[i].TagValue {
+				priority = rules[i].Priority
+				break
+			}
+		}
+	}
+	return priority
+}
+
 func (p IssuePriority) String() string {
 	switch p {
 	case PriorityLow:
@@ -75,6 +332,42 @@ func (p IssuePriority) String() string {
 	}
 }
 
+// PriorityThresholds decide when the recent metrics of an issue promote it to high priority.
+type PriorityThresholds struct {
+	// Window is the period the metrics of an issue are counted over.
+	Window time.Duration
+	// Users promotes an issue that affected at least this many users within the window, zero disables it.
+	Users uint64
+	// MinEvents is the number of events within the window an issue needs for its acceleration to count.
+	MinEvents uint64
+	// Acceleration promotes an issue whose events within the window are at least this many times
+	// the events of the preceding window, zero disables it.
+	Acceleration float64
+}
+
+// Enabled reports whether any threshold promotes issues.
+func (t PriorityThresholds) Enabled() bool {
+	return t.Window > 0 && (t.Users > 0 || t.Acceleration > 0)
+}
+
+// Promotes reports whether the metrics of an issue within the window and within the preceding window
+// cross a threshold. Previous is nil when the issue had no events in the preceding window.
+func (t PriorityThresholds) Promotes(current, previous *IssueMetrics) bool {
+	if current == nil {
+		return false
+	}
+	if t.Users > 0 && current.UserCount >= t.Users {
+		return true
+	}
+	if t.Acceleration <= 0 || current.TimesSeen < max(t.MinEvents, 1) {
+		return false
+	}
+	if previous == nil || previous.TimesSeen == 0 {
+		return true
+	}
+	return float64(current.TimesSeen) >= t.Acceleration*float64(previous.TimesSeen)
+}
+
 type IssueStore interface {
 	// GetIssue returns an issue by hash.
 	GetIssue(ctx context.Context, criteria GetIssueCriteria) (*Issue, error)
@@ -84,8 +377,68 @@ type IssueStore interface {
 	StoreIssue(ctx context.Context, issue *Issue) error
 	// ListIssues returns a list of issues.
 	ListIssues(ctx context.Context, criteria *ListIssuesCriteria) ([]Issue, error)
+	// CountIssues returns the number of issues seen in a period without listing them.
+	CountIssues(ctx context.Context, criteria *CountIssuesCriteria) (IssueCounts, error)
 	// UpdateLastSeen updates the last seen time of an issue.
 	UpdateLastSeen(ctx context.Context, upd *UpdateLastSeen) error
+	// UpdateStatus changes the status of an issue.
+	UpdateStatus(ctx context.Context, upd *UpdateIssueStatus) error
+	// ReopenIssue marks a resolved issue as unresolved again,
+	// reporting false when the issue was not resolved.
+	ReopenIssue(ctx context.Context, issueID int64) (bool, error)
+	// ReopenIgnored marks an ignored issue as unresolved again,
+	// reporting false when the issue was not ignored.
+	ReopenIgnored(ctx context.Context, issueID int64) (bool, error)
+	// CountIgnoredEvent counts an occurrence of an ignored issue and returns the number
+	// of occurrences since it was ignored, zero when the issue is not ignored.
+	CountIgnoredEvent(ctx context.Context, issueID int64) (int, error)
+	// ListIgnoredIssues returns the ignored issues of all projects ignored with one of the conditions.
+	ListIgnoredIssues(ctx context.Context, conditions []IgnoreCondition) ([]Issue, error)
+	// RaisePriority sets the priority of an issue unless it is already higher.
+	RaisePriority(ctx context.Context, issueID int64, priority IssuePriority) error
+	// UpdatePriority sets the priority of an issue.
+	UpdatePriority(ctx context.Context, issueID int64, priority IssuePriority) error
+	// UpdateNoiseFilters replaces the noise filters of an issue.
+	UpdateNoiseFilters(ctx context.Context, issueID int64, filters NoiseFilters) error
+	// DeleteIssues deletes issues along with the rows that belong to them.
+	DeleteIssues(ctx context.Context, issueIDs []int64) error
+}
+
+// UpdateIssueStatus is used to change the status of an issue.
+// ResolvedAt and ResolvedInRelease are expected to be empty unless the issue is being resolved,
+// Ignore is expected to be nil unless the issue is being ignored.
+type UpdateIssueStatus struct {
+	ResolvedAt        *time.Time
+	Ignore            *IgnoreRule
+	Status            IssueStatus
+	ResolvedInRelease string
+	IssueID           int64
+}
+
+// ResolveIssueRequest is a request to resolve an issue, optionally leaving
+// a resolution note in the discussion and notifying the people following the issue.
+type ResolveIssueRequest struct {
+	User *User
+	Note string
+	// Release is the release that fixed the issue, the issue is reopened
+	// when it recurs in a newer one. Empty when unknown.
+	Release   string
+	IssueID   int
+	ProjectID int
+	// Notify notifies the assignee of the issue and the users who wrote in its discussion
+	// along with its subscribers, who are notified either way.
+	Notify bool
+}
+
+// IgnoreIssueRequest is a request to ignore an issue until it occurs Threshold more times,
+// affects Threshold more users or Duration passes, depending on the condition.
+type IgnoreIssueRequest struct {
+	User      *User
+	Condition IgnoreCondition
+	Threshold int
+	Duration  time.Duration
+	IssueID   int
+	ProjectID int
 }
 
 type UpdateLastSeen struct {
@@ -110,6 +463,88 @@ type ListIssuesCriteria struct {
 	To         time.Time
 	ProjectIDs []int
 	GroupIDs   []int64
+	// ExcludeIgnored leaves out the issues ignored until their ignore condition is met.
+	ExcludeIgnored bool
+}
+
+// CountIssuesCriteria represents the criteria for counting the issues seen in a period.
+type CountIssuesCriteria struct {
+	From time.Time
+	To   time.Time
+	// NewSince is the time after which the issues first seen are counted as new.
+	NewSince   time.Time
+	ProjectIDs []int
+	// ExcludeIgnored leaves out the issues ignored until their ignore condition is met.
+	ExcludeIgnored bool
+}
+
+// IssueCounts is the number of issues seen in a period and of the new ones among them.
+type IssueCounts struct {
+	All int
+	New int
+}
+
+// ErrInvalidIssueCursor is returned when an issue list cursor can't be decoded.
+var ErrInvalidIssueCursor = errors.New("invalid issue cursor")
+
+// IssueCursor is a position in an issue list ordered by times seen, last seen and ID, all descending,
+// the way the issues of a project are listed. A page listed after the cursor starts with the issue
+// that follows it, so issues aren't skipped or repeated however deep the list is paged.
+type IssueCursor struct {
+	LastSeen  time.Time
+	TimesSeen uint64
+	ID        int64
+}
+
+// CursorAt returns the cursor positioned at the issue entry.
+func CursorAt(entry *IssueEntry) *IssueCursor {
+	return &IssueCursor{LastSeen: entry.LastSeen.Truncate(time.Second), TimesSeen: entry.TimesSeen, ID: entry.ID}
+}
+
+// String encodes the cursor into an opaque token safe to put into URLs.
+func (c *IssueCursor) String() string {
+	raw := strconv.FormatUint(c.TimesSeen, 10) + "." + strconv.FormatInt(c.LastSeen.Unix(), 10) + "." +
+		strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseIssueCursor decodes a cursor encoded by IssueCursor.String.
+func ParseIssueCursor(token string) (*IssueCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIssueCursor, err)
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIssueCursor
+	}
+	timesSeen, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: times seen: %w", ErrInvalidIssueCursor, err)
+	}
+	lastSeen, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: last seen: %w", ErrInvalidIssueCursor, err)
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: id: %w", ErrInvalidIssueCursor, err)
+	}
+	if id <= 0 {
+		return nil, fmt.Errorf("%w: id %d", ErrInvalidIssueCursor, id)
+	}
+	return &IssueCursor{LastSeen: time.Unix(lastSeen, 0).UTC(), TimesSeen: timesSeen, ID: id}, nil
+}
+
+// CompareIssueEntries orders issue entries the way they are listed: by times seen, last seen and ID, all descending.
+func CompareIssueEntries(a, b IssueEntry) int {
+	if c := cmp.Compare(b.TimesSeen, a.TimesSeen); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.LastSeen.Unix(), a.LastSeen.Unix()); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.ID, a.ID)
 }
 
 // IsAllowedIssueType checks whether provided issueType argument is included into predefined
@@ -1,6 +1,7 @@
 package warnly_test
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -99,3 +100,197 @@ func TestIssuePriority_String(t *testing.T) {
 		})
 	}
 }
+
+func TestBoostPriority(t *testing.T) {
+	t.Parallel()
+
+	rules := []warnly.PriorityRule{
+		{TagKey: "env", TagValue: "production", Priority: warnly.PriorityMedium},
+		{TagKey: "critical", TagValue: "true", Priority: warnly.PriorityHigh},
+	}
+
+	tests := []struct {
+		name     string
+		keys     []string
+		values   []string
+		priority warnly.IssuePriority
+		expected warnly.IssuePriority
+	}{
+		{"no tags", nil, nil, warnly.PriorityLow, warnly.PriorityLow},
+		{"matching tag", []string{"env"}, []string{"production"}, warnly.PriorityLow, warnly.PriorityMedium},
+		{"key with other value", []string{"env"}, []string{"staging"}, warnly.PriorityLow, warnly.PriorityLow},
+		{"highest matching rule", []string{"env", "critical"}, []string{"production", "true"}, warnly.PriorityLow, warnly.PriorityHigh},
+		{"never lowers", []string{"env"}, []string{"production"}, warnly.PriorityHigh, warnly.PriorityHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, warnly.BoostPriority(tt.priority, rules, tt.keys, tt.values))
+		})
+	}
+}
+
+func TestValidatePriorityRules(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, warnly.ValidatePriorityRules([]warnly.PriorityRule{
+		{TagKey: "critical", TagValue: "true", Priority: warnly.PriorityHigh},
+	}))
+	require.ErrorIs(t, warnly.ValidatePriorityRules([]warnly.PriorityRule{
+		{TagKey: "critical", Priority: warnly.PriorityHigh},
+	}), warnly.ErrInvalidPriorityRule)
+	require.ErrorIs(t, warnly.ValidatePriorityRules([]warnly.PriorityRule{
+		{TagKey: "critical", TagValue: "true", Priority: warnly.IssuePriority(9)},
+	}), warnly.ErrInvalidPriorityRule)
+}
+
+func TestIsRegression(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, warnly.IsRegression("1.2.0", "1.3.0"))
+	require.True(t, warnly.IsRegression("2025-01-01", "2025-02-01"))
+	require.False(t, warnly.IsRegression("1.2.0", "1.2.0"))
+	require.False(t, warnly.IsRegression("1.2.0", "1.1.9"))
+	require.False(t, warnly.IsRegression("1.2.0", ""))
+	require.False(t, warnly.IsRegression("", "1.3.0"))
+	require.True(t, warnly.IsRegression("1.9.0", "1.10.0"))
+	require.True(t, warnly.IsRegression("v1.2.0", "1.2.1"))
+	require.True(t, warnly.IsRegression("1.2.0-rc.1", "1.2.0"))
+	require.False(t, warnly.IsRegression("1.10.0", "1.9.0"))
+	require.False(t, warnly.IsRegression("1.2.0", "1.2.0-rc.1"))
+}
+
+func TestIgnoreRule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	count, err := warnly.NewIgnoreRule(warnly.IgnoreConditionCount, 10, 0, now)
+	require.NoError(t, err)
+	require.False(t, count.Met(9, now))
+	require.True(t, count.Met(10, now))
+
+	users, err := warnly.NewIgnoreRule(warnly.IgnoreConditionUsers, 3, 0, now)
+	require.NoError(t, err)
+	require.False(t, users.Met(2, now))
+	require.True(t, users.Met(3, now))
+
+	timed, err := warnly.NewIgnoreRule(warnly.IgnoreConditionTime, 0, time.Hour, now)
+	require.NoError(t, err)
+	require.False(t, timed.Met(100, now.Add(59*time.Minute)))
+	require.True(t, timed.Met(0, now.Add(time.Hour)))
+
+	for _, tc := range []struct {
+		condition warnly.IgnoreCondition
+		threshold int
+		duration  time.Duration
+	}{
+		{condition: warnly.IgnoreConditionCount, threshold: 0},
+		{condition: warnly.IgnoreConditionUsers, threshold: warnly.MaxIgnoreThreshold + 1},
+		{condition: warnly.IgnoreConditionTime, duration: 0},
+		{condition: warnly.IgnoreConditionTime, duration: warnly.MaxIgnoreDuration + time.Hour},
+		{condition: "forever", threshold: 1, duration: time.Hour},
+	} {
+		_, err := warnly.NewIgnoreRule(tc.condition, tc.threshold, tc.duration, now)
+		require.ErrorIs(t, err, warnly.ErrInvalidIgnoreCondition)
+	}
+}
+
+func TestHotness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+	halfLife := 6 * time.Hour
+
+	require.Zero(t, warnly.Hotness(nil, time.Time{}, halfLife, now))
+
+	// events in the middle of an hour six hours ago count half.
+	old := []warnly.IssueEventsPerHour{{TS: time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC), Count: 100}}
+	require.InDelta(t, 50, warnly.Hotness(old, now.Add(-6*time.Hour), halfLife, now), 0.001)
+
+	// events of the current hour are aged from the last of them.
+	current := []warnly.IssueEventsPerHour{{TS: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Count: 10}}
+	require.InDelta(t, 10, warnly.Hotness(current, now, halfLife, now), 0.001)
+
+	// a recent spike outranks more events that are a day old.
+	spike := []warnly.IssueEventsPerHour{{TS: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC), Count: 40}}
+	flood := []warnly.IssueEventsPerHour{{TS: time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), Count: 500}}
+	require.Greater(t,
+		warnly.Hotness(spike, now.Add(-time.Hour), halfLife, now),
+		warnly.Hotness(flood, now.Add(-24*time.Hour), halfLife, now))
+}
+
+func TestNewSparkline(t *testing.T) {
+	t.Parallel()
+
+	to := time.Date(2025, 1, 2, 12, 40, 0, 0, time.UTC)
+
+	empty := warnly.NewSparkline(nil, to)
+	require.Len(t, empty, warnly.SparklineHours)
+	for _, n := range empty {
+		require.Zero(t, n)
+	}
+
+	series := warnly.NewSparkline([]warnly.IssueEventsPerHour{
+		{TS: time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), Count: 3},
+		{TS: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), Count: 5},
+		{TS: time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC), Count: 7},
+		// out of the buckets.
+		{TS: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Count: 100},
+		{TS: time.Date(2025, 1, 2, 13, 0, 0, 0, time.UTC), Count: 100},
+	}, to)
+	require.Len(t, series, warnly.SparklineHours)
+	require.Equal(t, 3, series[23], "the hour of to is the last bucket")
+	require.Equal(t, 5, series[21])
+	require.Equal(t, 7, series[0], "the oldest hour is the first bucket")
+	sum := 0
+	for _, n := range series {
+		sum += n
+	}
+	require.Equal(t, 15, sum)
+}
+
+func TestIssueCursor(t *testing.T) {
+	t.Parallel()
+
+	entry := &warnly.IssueEntry{
+		LastSeen:  time.Date(2025, 3, 4, 10, 20, 30, 500, time.UTC),
+		TimesSeen: 42,
+		ID:        7,
+	}
+	token := warnly.CursorAt(entry).String()
+	require.NotContains(t, token, "=")
+
+	cursor, err := warnly.ParseIssueCursor(token)
+	require.NoError(t, err)
+	require.Equal(t, &warnly.IssueCursor{
+		LastSeen:  time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC),
+		TimesSeen: 42,
+		ID:        7,
+	}, cursor)
+
+	for _, token := range []string{"", "%%%", "MS4y", "YS4xLjc", "NDIuMTc0MTA4MzYzMC4w"} {
+		_, err := warnly.ParseIssueCursor(token)
+		require.ErrorIs(t, err, warnly.ErrInvalidIssueCursor, token)
+	}
+}
+
+func TestCompareIssueEntries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	entries := []warnly.IssueEntry{
+		{ID: 1, TimesSeen: 5, LastSeen: now},
+		{ID: 2, TimesSeen: 9, LastSeen: now.Add(-time.Hour)},
+		{ID: 3, TimesSeen: 5, LastSeen: now},
+		{ID: 4, TimesSeen: 5, LastSeen: now.Add(time.Minute)},
+	}
+	slices.SortFunc(entries, warnly.CompareIssueEntries)
+
+	ids := make([]int64, len(entries))
+	for i := range entries {
+		ids[i] = entries[i].ID
+	}
+	require.Equal(t, []int64{2, 4, 3, 1}, ids)
+}
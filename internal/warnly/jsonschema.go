@@ -0,0 +1,133 @@
+package warnly
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchema is a JSON Schema of a JSON value.
+type JSONSchema struct {
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+// EnvelopeSchemaDocument describes the envelopes the ingest endpoint accepts: the envelope header line,
+// the header line preceding every item and the payload of every recognized item type.
+// Items of other types are skipped.
+type EnvelopeSchemaDocument struct {
+	Header     *JSONSchema            `json:"header"`
+	ItemHeader *JSONSchema            `json:"item_header"`
+	Items      map[string]*JSONSchema `json:"items"`
+}
+
+// EnvelopeSchema returns the schema of the accepted envelopes. It is generated from the structs
+// the envelope is parsed into, so fields warnly doesn't read are not listed.
+func EnvelopeSchema() *EnvelopeSchemaDocument {
+	return &EnvelopeSchemaDocument{
+		Header:     schemaOf(reflect.TypeFor[EnvelopeHeader]()),
+		ItemHeader: schemaOf(reflect.TypeFor[EnvelopeItemHeader]()),
+		Items: map[string]*JSONSchema{
+			EnvelopeItemEvent: schemaOf(reflect.TypeFor[EventBody]()),
+			EnvelopeItemAttachment: {
+				Type:        "string",
+				Description: "raw file contents of the length declared in the item header",
+			},
+			EnvelopeItemTransaction: {
+				Type:        "object",
+				Description: "accepted so that SDKs don't retry it, transactions are not stored",
+			},
+			EnvelopeItemClientReport: schemaOf(reflect.TypeFor[ClientReport]()),
+		},
+	}
+}
+
+// schemaDescriber is implemented by types decoded from more than one JSON shape.
+type schemaDescriber interface {
+	JSONSchema() *JSONSchema
+}
+
+var (
+	schemaDescriberType = reflect.TypeFor[schemaDescriber]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// schemaOf returns the schema of the JSON a value of type t is decoded from,
+// struct fields are named by their json tags.
+func schemaOf(t reflect.Type) *JSONSchema {
+	if t.Implements(schemaDescriberType) {
+		d, _ := reflect.Zero(t).Interface().(schemaDescriber)
+		return d.JSONSchema()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOf(t.Elem())
+	case reflect.Struct:
+		if t == timeType {
+			return &JSONSchema{Type: "string", Format: "date-time"}
+		}
+		s := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema, t.NumField())}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			s.Properties[name] = schemaOf(f.Type)
+			if f.Tag.Get("envelope") == "required" {
+				s.Required = append(s.Required, name)
+			}
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: schemaOf(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: schemaOf(t.Elem())}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	default:
+		// Interfaces accept any JSON value.
+		return &JSONSchema{}
+	}
+}
+
+// JSONSchema describes the RFC 3339 string or the unix timestamp in seconds a timestamp is decoded from.
+func (SentryTimestamp) JSONSchema() *JSONSchema {
+	return &JSONSchema{OneOf: []*JSONSchema{
+		{Type: "string", Format: "date-time"},
+		{Type: "number", Description: "unix timestamp in seconds"},
+	}}
+}
+
+// JSONSchema describes the flat array or the object with values exceptions are decoded from.
+func (ExceptionList) JSONSchema() *JSONSchema {
+	return listSchema(reflect.TypeFor[[]Exception]())
+}
+
+// JSONSchema describes the flat array or the object with values threads are decoded from.
+func (ThreadList) JSONSchema() *JSONSchema {
+	return listSchema(reflect.TypeFor[[]Thread]())
+}
+
+// listSchema returns the schema of a list sent either as a flat array or as an object with values.
+func listSchema(t reflect.Type) *JSONSchema {
+	values := schemaOf(t)
+	return &JSONSchema{OneOf: []*JSONSchema{
+		values,
+		{Type: "object", Properties: map[string]*JSONSchema{"values": values}},
+	}}
+}
@@ -0,0 +1,62 @@
+package warnly
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxLabelLength is the longest label that can be attached to an issue.
+const MaxLabelLength = 32
+
+// LabelFilterKey is the query filter key of the issue label, e.g. label:regression.
+const LabelFilterKey = "label"
+
+// ErrInvalidLabel is returned when a label is empty, too long or has characters other than
+// lowercase letters, digits, dashes, dots and underscores.
+var ErrInvalidLabel = fmt.Errorf("invalid label: use up to %d lowercase letters, digits, dashes, dots and underscores",
+	MaxLabelLength)
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// IssueLabel is a lightweight label a user attached to an issue, e.g. "regression" or "needs-repro".
+// Labels are scoped to the team that owns the issue's project, so teams label their issues independently
+// and the labels move along with a project transferred to another team.
+type IssueLabel struct {
+	CreatedAt time.Time
+	Name      string
+	IssueID   int64
+	UserID    int64
+}
+
+// NormalizeLabel lowercases and trims the label and checks that it can be attached to an issue.
+func NormalizeLabel(label string) (string, error) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if label == "" || len(label) > MaxLabelLength || !labelPattern.MatchString(label) {
+		return "", ErrInvalidLabel
+	}
+	return label, nil
+}
+
+// IssueLabelStore encapsulates the methods to interact with database for issue labels.
+type IssueLabelStore interface {
+	// CreateLabel attaches the label to the issue, attaching it again keeps the existing label.
+	CreateLabel(ctx context.Context, label *IssueLabel) error
+	// DeleteLabel removes the label from the issue.
+	DeleteLabel(ctx context.Context, issueID int64, name string) error
+	// ListLabels returns the labels of the issue ordered by name.
+	ListLabels(ctx context.Context, issueID int64) ([]IssueLabel, error)
+	// ListLabeledIssueIDs returns identifiers of the issues of the teams' projects that have the label.
+	ListLabeledIssueIDs(ctx context.Context, teamIDs []int, name string) ([]int64, error)
+}
+
+// IssueLabelRequest is a request to attach a label to an issue, to remove it or to list the labels of the issue.
+type IssueLabelRequest struct {
+	User *User
+	// Label is the name of the label, unused when listing labels.
+	Label     string
+	ProjectID int
+	IssueID   int
+}
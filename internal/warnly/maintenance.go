@@ -0,0 +1,12 @@
+package warnly
+
+import "errors"
+
+// ErrReadOnly is returned by mutating actions while warnly is in read-only maintenance mode,
+// events are still ingested and the data can be read.
+var ErrReadOnly = errors.New("warnly is in read-only maintenance mode, changes are disabled")
+
+// MaintenanceMode is the read-only maintenance mode state reported and changed by admins.
+type MaintenanceMode struct {
+	ReadOnly bool `json:"read_only"`
+}
@@ -2,6 +2,9 @@ package warnly
 
 import (
 	"context"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -30,12 +33,38 @@ type MessageStore interface {
 	CreateMessage(ctx context.Context, message *Message) error
 	// ListIssueMessages lists all messages in the issue discussion.
 	ListIssueMessages(ctx context.Context, issueID int64) ([]IssueMessage, error)
+	// ListCommenters returns identifiers of the users who wrote in the issue discussion.
+	ListCommenters(ctx context.Context, issueID int64) ([]int64, error)
 	// CountMessages counts all messages in the issue discussion.
 	CountMessages(ctx context.Context, issueID int64) (int, error)
 	// DeleteMessage deletes a message in the issue discussion.
 	DeleteMessage(ctx context.Context, messageID, userID int) error
 	// CountMessagesByIDs counts all messages in the issue discussion by IDs.
 	CountMessagesByIDs(ctx context.Context, issueIDs []int64) ([]MessageCount, error)
+	// ListExpiredMessages returns identifiers of messages eligible for removal by the retention policy.
+	ListExpiredMessages(ctx context.Context, criteria *ExpiredMessagesCriteria) ([]int, error)
+	// DeleteMessages deletes messages by identifiers together with their views.
+	DeleteMessages(ctx context.Context, messageIDs []int) error
+}
+
+// MessageRetention is the policy for removing old issue discussions.
+type MessageRetention struct {
+	// MaxAge is the age after which a message is removed, zero keeps messages forever.
+	MaxAge time.Duration
+	// KeepOpenIssues keeps messages of unresolved issues regardless of their age.
+	KeepOpenIssues bool
+}
+
+// Enabled reports whether messages expire at all.
+func (r MessageRetention) Enabled() bool {
+	return r.MaxAge > 0
+}
+
+// ExpiredMessagesCriteria defines which messages are expired according to the retention policy.
+type ExpiredMessagesCriteria struct {
+	Before         time.Time
+	Limit          int
+	KeepOpenIssues bool
 }
 
 // MessageCount represents a count of messages in the issue discussion.
@@ -52,12 +81,37 @@ type Mention struct {
 	MentionedUserID int       `json:"mentioned_user_id"`
 }
 
+// mentionPattern matches "@username" that doesn't follow a word character, so e-mail addresses aren't mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.+-]+)`)
+
+// ParseMentions returns identifiers of the teammates mentioned with "@username" in the content,
+// in order of the first mention. Mentions that don't match the username of a teammate are ignored.
+func ParseMentions(content string, teammates []Teammate) []int64 {
+	var mentioned []int64
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		// a mention may end a sentence.
+		username := strings.TrimRight(match[1], ".")
+		for i := range teammates {
+			if teammates[i].Username == "" || !strings.EqualFold(teammates[i].Username, username) {
+				continue
+			}
+			if !slices.Contains(mentioned, teammates[i].ID) {
+				mentioned = append(mentioned, teammates[i].ID)
+			}
+			break
+		}
+	}
+	return mentioned
+}
+
 // MentionStore encapsulates the methods to interact with database for Mention entity.
 type MentionStore interface {
 	// CreateMentions creates new mentions in issue discussion (when user was tagged with "@").
 	CreateMentions(ctx context.Context, mentions []Mention) error
 	// DeleteMentions deletes mentions in issue discussion.
 	DeleteMentions(ctx context.Context, messageID int) error
+	// DeleteMessagesMentions deletes mentions of all given messages.
+	DeleteMessagesMentions(ctx context.Context, messageIDs []int) error
 }
 
 // MessageView represents a view of a message by a user.
@@ -1 +1,39 @@
 package warnly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+
+	teammates := []warnly.Teammate{
+		{ID: 1, Username: "john"},
+		{ID: 2, Username: "jane.doe"},
+		{ID: 3, Username: ""},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []int64
+	}{
+		{name: "teammate", content: "@john please take a look", want: []int64{1}},
+		{name: "end of sentence", content: "assigned to @jane.doe.", want: []int64{2}},
+		{name: "order of first mention", content: "@jane.doe @john @JOHN", want: []int64{2, 1}},
+		{name: "not a teammate", content: "@mallory fixed it", want: nil},
+		{name: "e-mail address", content: "write to john@example.com", want: nil},
+		{name: "bare at sign", content: "@ nobody", want: nil},
+		{name: "inside markup", content: "<p>@john</p>", want: []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, warnly.ParseMentions(tt.content, teammates))
+		})
+	}
+}
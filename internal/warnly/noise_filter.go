@@ -0,0 +1,100 @@
+package warnly
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxNoiseFilters is the maximum number of noise filters an issue can have.
+const MaxNoiseFilters = 20
+
+// ErrInvalidNoiseFilter is returned when a noise filter isn't a list of tag key=value pairs.
+var ErrInvalidNoiseFilter = errors.New("invalid noise filter: use tag key=value pairs separated by commas")
+
+// ErrTooManyNoiseFilters is returned when an issue already has MaxNoiseFilters noise filters.
+var ErrTooManyNoiseFilters = fmt.Errorf("an issue can have at most %d noise filters", MaxNoiseFilters)
+
+// NoiseTag is a tag key and value an event must have to match a noise filter.
+type NoiseTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NoiseFilter drops the events of an issue that have all of its tags at ingest,
+// e.g. browser=HeadlessChrome, env=production.
+// Dropped events are not stored, so they don't count to the issue metrics.
+type NoiseFilter struct {
+	Tags []NoiseTag `json:"tags"`
+}
+
+// ParseNoiseFilter parses a noise filter of tag key=value pairs separated by commas,
+// tags are sorted by key so that equal filters have the same string form.
+func ParseNoiseFilter(s string) (NoiseFilter, error) {
+	var f NoiseFilter
+	for pair := range strings.SplitSeq(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return NoiseFilter{}, ErrInvalidNoiseFilter
+		}
+		if slices.ContainsFunc(f.Tags, func(t NoiseTag) bool { return t.Key == key }) {
+			return NoiseFilter{}, fmt.Errorf("%w: tag %q is repeated", ErrInvalidNoiseFilter, key)
+		}
+		f.Tags = append(f.Tags, NoiseTag{Key: key, Value: value})
+	}
+	slices.SortFunc(f.Tags, func(a, b NoiseTag) int { return strings.Compare(a.Key, b.Key) })
+	return f, nil
+}
+
+// String returns the tags of the filter as key=value pairs separated by commas.
+func (f NoiseFilter) String() string {
+	pairs := make([]string, len(f.Tags))
+	for i, t := range f.Tags {
+		pairs[i] = t.Key + "=" + t.Value
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// Matches reports whether the event tags have every tag of the filter.
+func (f NoiseFilter) Matches(tagKeys, tagValues []string) bool {
+	if len(f.Tags) == 0 {
+		return false
+	}
+	for _, t := range f.Tags {
+		found := false
+		for i := range tagKeys {
+			if tagKeys[i] == t.Key && tagValues[i] == t.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// NoiseFilters are the noise filters of an issue.
+type NoiseFilters []NoiseFilter
+
+// Drops reports whether any of the filters matches the event tags.
+func (fs NoiseFilters) Drops(tagKeys, tagValues []string) bool {
+	return slices.ContainsFunc(fs, func(f NoiseFilter) bool { return f.Matches(tagKeys, tagValues) })
+}
+
+// Index returns the index of the filter equal to f, or -1 if there is none.
+func (fs NoiseFilters) Index(f NoiseFilter) int {
+	return slices.IndexFunc(fs, func(other NoiseFilter) bool { return slices.Equal(other.Tags, f.Tags) })
+}
+
+// NoiseFilterRequest is a request to add a noise filter to an issue or to remove it.
+type NoiseFilterRequest struct {
+	User *User
+	// Filter is the tag key=value pairs separated by commas, e.g. "browser=HeadlessChrome, env=production".
+	Filter    string
+	ProjectID int
+	IssueID   int
+}
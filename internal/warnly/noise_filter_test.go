@@ -0,0 +1,70 @@
+package warnly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestParseNoiseFilter(t *testing.T) {
+	t.Parallel()
+
+	f, err := warnly.ParseNoiseFilter(" user_agent = HeadlessChrome ,env=production")
+	require.NoError(t, err)
+	assert.Equal(t, []warnly.NoiseTag{
+		{Key: "env", Value: "production"},
+		{Key: "user_agent", Value: "HeadlessChrome"},
+	}, f.Tags)
+	assert.Equal(t, "env=production, user_agent=HeadlessChrome", f.String())
+
+	for _, s := range []string{"", "env", "env=", "=production", "env=production,", "env=a, env=b"} {
+		_, err := warnly.ParseNoiseFilter(s)
+		require.ErrorIs(t, err, warnly.ErrInvalidNoiseFilter, s)
+	}
+}
+
+func TestNoiseFiltersDrops(t *testing.T) {
+	t.Parallel()
+
+	bot, err := warnly.ParseNoiseFilter("env=production, user_agent=HeadlessChrome")
+	require.NoError(t, err)
+	filters := warnly.NoiseFilters{bot}
+
+	tests := []struct {
+		name   string
+		keys   []string
+		values []string
+		want   bool
+	}{
+		{
+			name:   "all tags match",
+			keys:   []string{"env", "level", "user_agent"},
+			values: []string{"production", "error", "HeadlessChrome"},
+			want:   true,
+		},
+		{
+			name:   "a tag has another value",
+			keys:   []string{"env", "user_agent"},
+			values: []string{"staging", "HeadlessChrome"},
+		},
+		{
+			name:   "a tag is missing",
+			keys:   []string{"env"},
+			values: []string{"production"},
+		},
+		{name: "no tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, filters.Drops(tt.keys, tt.values))
+		})
+	}
+
+	assert.Equal(t, 0, filters.Index(bot))
+	assert.False(t, warnly.NoiseFilters(nil).Drops([]string{"env"}, []string{"production"}))
+}
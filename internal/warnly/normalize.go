@@ -3,8 +3,11 @@ package warnly
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -74,8 +77,275 @@ var (
 
 	// Version patterns.
 	versionPattern = regexp.MustCompile(`\bv?\d+\.\d+(?:\.\d+)?(?:-[a-zA-Z0-9]+)?\b`)
+
+	// Patterns of the message rules.
+	// Single quotes are only matched at the start of a word so that apostrophes are kept.
+	doubleQuotedPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|` + "`[^`]*`")
+	singleQuotedPattern = regexp.MustCompile(`(^|[^\w'])'(?:[^'\\]|\\.)*'`)
+	clockPattern        = regexp.MustCompile(`\b\d{1,2}:\d{2}(?::\d{2})?(?:\.\d+)?\b`)
+	digitsPattern       = regexp.MustCompile(`\d+`)
+)
+
+// GroupingStrategy defines how events without an exception stack trace are grouped into issues.
+type GroupingStrategy string
+
+const (
+	// GroupingByMessage groups message-only events by their normalized message.
+	GroupingByMessage GroupingStrategy = "message"
+	// GroupingByTopInAppFrame groups message-only events by the top in-app frame
+	// of the thread stack trace, so that variable messages logged from the same
+	// place end up in the same issue.
+	GroupingByTopInAppFrame GroupingStrategy = "top_in_app_frame"
+)
+
+// ErrInvalidGrouping is returned when a grouping strategy is unknown.
+var ErrInvalidGrouping = errors.New("invalid grouping strategy")
+
+// ValidateGrouping checks that the grouping strategy is known.
+func ValidateGrouping(grouping GroupingStrategy) error {
+	switch grouping {
+	case GroupingByMessage, GroupingByTopInAppFrame:
+		return nil
+	default:
+		return ErrInvalidGrouping
+	}
+}
+
+// MessageRule strips a kind of variable literal from the messages of message-only events
+// before they are grouped, so that messages logged from the same template end up in the same issue.
+type MessageRule string
+
+const (
+	// MessageRuleQuoted strips quoted literals, e.g. "user 'alice' not found".
+	MessageRuleQuoted MessageRule = "quoted"
+	// MessageRuleTimestamps strips dates and times, including the hours and minutes of a clock.
+	MessageRuleTimestamps MessageRule = "timestamps"
+	// MessageRuleUUIDs strips UUIDs.
+	MessageRuleUUIDs MessageRule = "uuids"
+	// MessageRuleNumbers strips every number, regardless of its length.
+	MessageRuleNumbers MessageRule = "numbers"
 )
 
+// messageRuleOrder is the order the message rules are applied in: literals that contain
+// digits are stripped before numbers so that they are replaced as a whole.
+var messageRuleOrder = []MessageRule{MessageRuleQuoted, MessageRuleTimestamps, MessageRuleUUIDs, MessageRuleNumbers}
+
+const (
+	// MaxNotInAppPrefixes is the maximum number of path prefixes of either kind grouping rules can define.
+	MaxNotInAppPrefixes = 50
+	// MaxIgnoreMessageTypes is the maximum number of exception types grouping rules can define.
+	MaxIgnoreMessageTypes = 50
+)
+
+// ErrInvalidGroupingRules is returned when grouping rules can't be applied to frames.
+var ErrInvalidGroupingRules = errors.New("invalid grouping rules")
+
+// GroupingRules configure how stack trace frames of a project's events are treated.
+type GroupingRules struct {
+	// NotInAppPrefixes are path or module prefixes of framework and vendored code,
+	// e.g. /app/vendor/, frames matching one of them are not in-app.
+	NotInAppPrefixes []string `json:"not_in_app_prefixes"`
+	// InAppPrefixes are path or module prefixes of application code the platform heuristics
+	// would take for dependencies, e.g. own modules fetched into the Go module cache,
+	// frames matching one of them keep the in_app flag the SDK set.
+	InAppPrefixes []string `json:"in_app_prefixes,omitempty"`
+	// IgnoreMessageTypes are exception types with highly variable messages,
+	// e.g. database timeouts, events of these types are grouped by the type alone.
+	IgnoreMessageTypes []string `json:"ignore_message_types,omitempty"`
+	// MessageRules strip variable literals from the messages of message-only events before they are grouped.
+	MessageRules []MessageRule `json:"message_rules,omitempty"`
+}
+
+// IsZero reports whether no rule is set.
+func (r *GroupingRules) IsZero() bool {
+	return r == nil || (len(r.NotInAppPrefixes) == 0 && len(r.InAppPrefixes) == 0 &&
+		len(r.IgnoreMessageTypes) == 0 && len(r.MessageRules) == 0)
+}
+
+// Validate checks that every prefix and exception type is set and their number is limited.
+func (r *GroupingRules) Validate() error {
+	if len(r.NotInAppPrefixes) > MaxNotInAppPrefixes {
+		return fmt.Errorf("%w: at most %d prefixes are allowed", ErrInvalidGroupingRules, MaxNotInAppPrefixes)
+	}
+	for _, prefix := range r.NotInAppPrefixes {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("%w: empty prefix", ErrInvalidGroupingRules)
+		}
+	}
+	if len(r.InAppPrefixes) > MaxNotInAppPrefixes {
+		return fmt.Errorf("%w: at most %d in-app prefixes are allowed", ErrInvalidGroupingRules, MaxNotInAppPrefixes)
+	}
+	for _, prefix := range r.InAppPrefixes {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("%w: empty in-app prefix", ErrInvalidGroupingRules)
+		}
+	}
+	if len(r.IgnoreMessageTypes) > MaxIgnoreMessageTypes {
+		return fmt.Errorf("%w: at most %d exception types are allowed", ErrInvalidGroupingRules, MaxIgnoreMessageTypes)
+	}
+	for _, exceptionType := range r.IgnoreMessageTypes {
+		if strings.TrimSpace(exceptionType) == "" {
+			return fmt.Errorf("%w: empty exception type", ErrInvalidGroupingRules)
+		}
+	}
+	for _, rule := range r.MessageRules {
+		if !slices.Contains(messageRuleOrder, rule) {
+			return fmt.Errorf("%w: unknown message rule %q", ErrInvalidGroupingRules, rule)
+		}
+	}
+	return nil
+}
+
+// MessageTemplate strips the literals selected by the message rules from the message.
+// The message is returned as is when no message rule is set.
+func (r *GroupingRules) MessageTemplate(message string) string {
+	if r == nil || len(r.MessageRules) == 0 {
+		return message
+	}
+	for _, rule := range messageRuleOrder {
+		if !slices.Contains(r.MessageRules, rule) {
+			continue
+		}
+		switch rule {
+		case MessageRuleQuoted:
+			message = doubleQuotedPattern.ReplaceAllString(message, "<quoted>")
+			message = singleQuotedPattern.ReplaceAllString(message, "${1}<quoted>")
+		case MessageRuleTimestamps:
+			for i := range timestampPatterns {
+				message = timestampPatterns[i].ReplaceAllString(message, "<timestamp>")
+			}
+			message = clockPattern.ReplaceAllString(message, "<timestamp>")
+		case MessageRuleUUIDs:
+			message = uuidPattern.ReplaceAllString(message, "<uuid>")
+		case MessageRuleNumbers:
+			message = digitsPattern.ReplaceAllString(message, "<number>")
+		}
+	}
+	return message
+}
+
+// IgnoresMessage reports whether events of the exception type are grouped by the type alone.
+func (r *GroupingRules) IgnoresMessage(exceptionType string) bool {
+	if r == nil || exceptionType == "" {
+		return false
+	}
+	return slices.Contains(r.IgnoreMessageTypes, exceptionType)
+}
+
+// IsInApp reports whether the frame doesn't match any of the not in-app prefixes.
+// Both the absolute path and the module of the frame are matched.
+func (r *GroupingRules) IsInApp(frame *Frame) bool {
+	if r == nil {
+		return true
+	}
+	module := frame.GetModule()
+	for _, prefix := range r.NotInAppPrefixes {
+		if strings.HasPrefix(frame.AbsPath, prefix) || strings.HasPrefix(module, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// KeepsInApp reports whether the frame matches one of the in-app prefixes,
+// so the platform heuristics don't override the SDK's in_app flag of it.
+func (r *GroupingRules) KeepsInApp(frame *Frame) bool {
+	if r == nil {
+		return false
+	}
+	module := frame.GetModule()
+	for _, prefix := range r.InAppPrefixes {
+		if strings.HasPrefix(frame.AbsPath, prefix) || strings.HasPrefix(module, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGroupingHash returns the hash events are grouped into issues by.
+// Exceptions of a type the rules ignore the message of are grouped by the type alone.
+// Other events with an exception stack trace are always grouped by it, the grouping strategy
+// and the message rules only apply to message-only events.
+func GetGroupingHash(event *EventBody, grouping GroupingStrategy, rules *GroupingRules) (string, error) {
+	if len(event.Exception) > 0 {
+		if exceptionType := event.Exception[len(event.Exception)-1].Type; rules.IgnoresMessage(exceptionType) {
+			return GetHashByExceptionType(event, exceptionType)
+		}
+	}
+	if !hasExceptionFrames(event.Exception) {
+		if grouping == GroupingByTopInAppFrame {
+			if frame, ok := topInAppFrame(event.GetThreadFrames()); ok {
+				return GetHashByFrame(event, &frame)
+			}
+		}
+		if rules != nil && len(rules.MessageRules) > 0 {
+			templated := *event
+			templated.Message = rules.MessageTemplate(event.Message)
+			event = &templated
+		}
+	}
+
+	return GetNormalizedHash(event)
+}
+
+func hasExceptionFrames(exceptions []Exception) bool {
+	return len(exceptions) > 0 && len(exceptions[0].StackTrace.Frames) > 0
+}
+
+// topInAppFrame returns the innermost in-app frame, frames are ordered from the outermost call.
+func topInAppFrame(frames []Frame) (Frame, bool) {
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i].InApp {
+			return frames[i], true
+		}
+	}
+	return Frame{}, false
+}
+
+// GetHashByFrame returns the hash of the code location the event was sent from.
+// The source line is preferred over the line number so that unrelated edits
+// above the call don't split the issue.
+func GetHashByFrame(event *EventBody, frame *Frame) (string, error) {
+	h := md5.New() //nolint:gosec // Non-crypto use
+
+	if _, err := h.Write([]byte(frame.GetModule())); err != nil {
+		return "", fmt.Errorf("md5: write module: %w", err)
+	}
+	if _, err := h.Write([]byte(frame.Function)); err != nil {
+		return "", fmt.Errorf("md5: write function: %w", err)
+	}
+	location := strings.TrimSpace(frame.ContextLine)
+	if location == "" {
+		location = strconv.FormatUint(uint64(frame.LineNo), 10)
+	}
+	if _, err := h.Write([]byte(location)); err != nil {
+		return "", fmt.Errorf("md5: write location: %w", err)
+	}
+	if _, err := h.Write([]byte(event.Level)); err != nil {
+		return "", fmt.Errorf("md5: write level: %w", err)
+	}
+	if _, err := h.Write([]byte(event.Platform)); err != nil {
+		return "", fmt.Errorf("md5: write platform: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GetHashByExceptionType returns the hash of the exception type, so events of the type
+// are grouped together regardless of their messages and stack traces.
+func GetHashByExceptionType(event *EventBody, exceptionType string) (string, error) {
+	h := md5.New() //nolint:gosec // Non-crypto use
+
+	if _, err := h.Write([]byte(exceptionType)); err != nil {
+		return "", fmt.Errorf("md5: write type: %w", err)
+	}
+	if _, err := h.Write([]byte(event.Platform)); err != nil {
+		return "", fmt.Errorf("md5: write platform: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func GetNormalizedHash(event *EventBody) (string, error) {
 	normalizedEvent := *event
 	normalizedEvent.Exception = NormalizeStackTrace(event.Exception)
@@ -1 +1,319 @@
 package warnly_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// loggedEvent returns a message-only event as sent by zapsentry, with the call site in the thread stack trace.
+func loggedEvent(message string, lineNo uint32, contextLine string) *warnly.EventBody {
+	return &warnly.EventBody{
+		Message:  message,
+		Level:    "error",
+		Platform: "go",
+		Threads: warnly.ThreadList{
+			{
+				Current: true,
+				StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+					{Module: "main", Function: "main", LineNo: 95, InApp: true},
+					{Module: "main", Function: "run", LineNo: lineNo, ContextLine: contextLine, InApp: true},
+					{Module: "go.uber.org/zap", Function: "(*Logger).Error", LineNo: 247, InApp: false},
+				}},
+			},
+		},
+	}
+}
+
+func TestGetGroupingHashByTopInAppFrame(t *testing.T) {
+	t.Parallel()
+
+	const callSite = "\t\tmyLogger.Error(fmt.Sprintf(\"order %s failed: %s\", id, reason))"
+
+	first := loggedEvent("order A-17 failed: card declined", 149, callSite)
+	second := loggedEvent("order Z-9 failed: warehouse unreachable", 152, callSite)
+
+	firstHash, err := warnly.GetGroupingHash(first, warnly.GroupingByTopInAppFrame, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	secondHash, err := warnly.GetGroupingHash(second, warnly.GroupingByTopInAppFrame, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	if firstHash != secondHash {
+		t.Errorf("events logged from the same frame got different hashes: %s != %s", firstHash, secondHash)
+	}
+
+	firstByMessage, err := warnly.GetGroupingHash(first, warnly.GroupingByMessage, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	secondByMessage, err := warnly.GetGroupingHash(second, warnly.GroupingByMessage, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	if firstByMessage == secondByMessage {
+		t.Error("events with different messages got the same hash when grouped by message")
+	}
+
+	other, err := warnly.GetGroupingHash(loggedEvent("order A-17 failed: card declined", 160, "\t\tlog.Error(msg)"),
+		warnly.GroupingByTopInAppFrame, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	if other == firstHash {
+		t.Error("events logged from different call sites got the same hash")
+	}
+}
+
+func TestGetGroupingHashFallsBack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		event *warnly.EventBody
+	}{
+		{
+			name:  "no threads",
+			event: &warnly.EventBody{Message: "connection refused", Level: "error", Platform: "go"},
+		},
+		{
+			name: "no in-app frames",
+			event: &warnly.EventBody{
+				Message:  "connection refused",
+				Level:    "error",
+				Platform: "go",
+				Threads: warnly.ThreadList{{StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+					{Module: "net/http", Function: "(*Client).Do", LineNo: 590},
+				}}}},
+			},
+		},
+		{
+			name: "exception stack trace",
+			event: &warnly.EventBody{
+				Message:  "connection refused",
+				Level:    "error",
+				Platform: "go",
+				Exception: warnly.ExceptionList{{
+					Type:       "*net.OpError",
+					Value:      "connection refused",
+					StackTrace: warnly.StackTrace{Frames: []warnly.Frame{{Module: "main", Function: "dial", InApp: true}}},
+				}},
+				Threads: loggedEvent("connection refused", 149, "dial()").Threads,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := warnly.GetGroupingHash(tt.event, warnly.GroupingByTopInAppFrame, nil)
+			if err != nil {
+				t.Fatalf("GetGroupingHash() error = %v", err)
+			}
+			want, err := warnly.GetNormalizedHash(tt.event)
+			if err != nil {
+				t.Fatalf("GetNormalizedHash() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("GetGroupingHash() = %s, want normalized hash %s", got, want)
+			}
+		})
+	}
+}
+
+// exceptionEvent returns an event with a single exception raised from the same frame.
+func exceptionEvent(exceptionType, value string) *warnly.EventBody {
+	return &warnly.EventBody{
+		Level:    "error",
+		Platform: "python",
+		Exception: []warnly.Exception{{
+			Type:  exceptionType,
+			Value: value,
+			StackTrace: warnly.StackTrace{Frames: []warnly.Frame{
+				{Module: "app.db", Function: "execute", LineNo: 42, InApp: true},
+			}},
+		}},
+	}
+}
+
+func TestGetGroupingHashIgnoresMessageOfListedTypes(t *testing.T) {
+	t.Parallel()
+
+	rules := &warnly.GroupingRules{IgnoreMessageTypes: []string{"OperationalError"}}
+
+	tests := []struct {
+		name      string
+		first     *warnly.EventBody
+		second    *warnly.EventBody
+		wantEqual bool
+	}{
+		{
+			name:      "listed type groups by type",
+			first:     exceptionEvent("OperationalError", "canceling statement due to statement timeout on orders"),
+			second:    exceptionEvent("OperationalError", "could not obtain lock on relation invoices"),
+			wantEqual: true,
+		},
+		{
+			name:   "other type keeps message grouping",
+			first:  exceptionEvent("ValueError", "invalid literal for int()"),
+			second: exceptionEvent("ValueError", "too many values to unpack"),
+		},
+		{
+			name:   "listed types don't group with each other",
+			first:  exceptionEvent("OperationalError", "statement timeout"),
+			second: exceptionEvent("TimeoutError", "statement timeout"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			first, err := warnly.GetGroupingHash(tt.first, warnly.GroupingByMessage, rules)
+			if err != nil {
+				t.Fatalf("GetGroupingHash() error = %v", err)
+			}
+			second, err := warnly.GetGroupingHash(tt.second, warnly.GroupingByMessage, rules)
+			if err != nil {
+				t.Fatalf("GetGroupingHash() error = %v", err)
+			}
+			if (first == second) != tt.wantEqual {
+				t.Errorf("GetGroupingHash() equal = %t, want %t", first == second, tt.wantEqual)
+			}
+		})
+	}
+
+	// without the rule events of the listed type are grouped by message.
+	first, err := warnly.GetGroupingHash(exceptionEvent("OperationalError", "statement timeout"), warnly.GroupingByMessage, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	second, err := warnly.GetGroupingHash(exceptionEvent("OperationalError", "lock timeout"), warnly.GroupingByMessage, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	if first == second {
+		t.Error("events with different messages got the same hash without grouping rules")
+	}
+}
+
+func TestValidateGrouping(t *testing.T) {
+	t.Parallel()
+
+	for _, grouping := range []warnly.GroupingStrategy{warnly.GroupingByMessage, warnly.GroupingByTopInAppFrame} {
+		if err := warnly.ValidateGrouping(grouping); err != nil {
+			t.Errorf("ValidateGrouping(%q) error = %v", grouping, err)
+		}
+	}
+	if err := warnly.ValidateGrouping("stack"); err == nil {
+		t.Error("ValidateGrouping(\"stack\") expected error")
+	}
+}
+
+func TestGetGroupingHashMessageRules(t *testing.T) {
+	t.Parallel()
+
+	rules := &warnly.GroupingRules{MessageRules: []warnly.MessageRule{
+		warnly.MessageRuleQuoted,
+		warnly.MessageRuleTimestamps,
+		warnly.MessageRuleUUIDs,
+		warnly.MessageRuleNumbers,
+	}}
+
+	tests := []struct {
+		name      string
+		first     string
+		second    string
+		wantEqual bool
+	}{
+		{
+			name:      "clock times",
+			first:     "error at 10:00",
+			second:    "error at 10:01",
+			wantEqual: true,
+		},
+		{
+			name:      "short numbers",
+			first:     "retry 3 of 5 failed",
+			second:    "retry 4 of 5 failed",
+			wantEqual: true,
+		},
+		{
+			name:      "quoted literals",
+			first:     `user 'alice' can't open "report-7.pdf"`,
+			second:    `user 'bob' can't open "summary.pdf"`,
+			wantEqual: true,
+		},
+		{
+			name:      "uuids",
+			first:     "job 8c1e2f4a-0b7d-4e6c-9a3b-2e1f0d4c5b60 timed out",
+			second:    "job 1f0d4c5b-2e1f-4a3b-8c1e-0b7d9a3b2e1f timed out",
+			wantEqual: true,
+		},
+		{
+			name:   "different templates",
+			first:  "error at 10:00",
+			second: "pool exhausted at 10:00",
+		},
+		{
+			name:   "apostrophes are not quotes",
+			first:  "can't connect to 'db'",
+			second: "won't connect to 'db'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			first, err := warnly.GetGroupingHash(loggedEvent(tt.first, 149, ""), warnly.GroupingByMessage, rules)
+			if err != nil {
+				t.Fatalf("GetGroupingHash() error = %v", err)
+			}
+			second, err := warnly.GetGroupingHash(loggedEvent(tt.second, 149, ""), warnly.GroupingByMessage, rules)
+			if err != nil {
+				t.Fatalf("GetGroupingHash() error = %v", err)
+			}
+			if (first == second) != tt.wantEqual {
+				t.Errorf("GetGroupingHash() equal = %t, want %t", first == second, tt.wantEqual)
+			}
+		})
+	}
+
+	// without the rules the clock time keeps the messages apart.
+	first, err := warnly.GetGroupingHash(loggedEvent("error at 10:00", 149, ""), warnly.GroupingByMessage, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	second, err := warnly.GetGroupingHash(loggedEvent("error at 10:01", 149, ""), warnly.GroupingByMessage, nil)
+	if err != nil {
+		t.Fatalf("GetGroupingHash() error = %v", err)
+	}
+	if first == second {
+		t.Error("timestamped messages got the same hash without message rules")
+	}
+}
+
+func TestMessageTemplate(t *testing.T) {
+	t.Parallel()
+
+	rules := &warnly.GroupingRules{MessageRules: []warnly.MessageRule{warnly.MessageRuleNumbers, warnly.MessageRuleQuoted}}
+
+	got := rules.MessageTemplate(`order "A-17" failed after 3 attempts, can't retry`)
+	want := `order <quoted> failed after <number> attempts, can't retry`
+	if got != want {
+		t.Errorf("MessageTemplate() = %q, want %q", got, want)
+	}
+
+	if err := rules.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	invalid := &warnly.GroupingRules{MessageRules: []warnly.MessageRule{"emails"}}
+	if err := invalid.Validate(); !errors.Is(err, warnly.ErrInvalidGroupingRules) {
+		t.Errorf("Validate() error = %v, want %v", err, warnly.ErrInvalidGroupingRules)
+	}
+}
@@ -43,6 +43,8 @@ const (
 	AlertNotificationTriggered AlertNotificationType = "triggered"
 	// AlertNotificationResolved represents a resolved alert notification.
 	AlertNotificationResolved AlertNotificationType = "resolved"
+	// AlertNotificationEscalated represents a notification of the next channel in the escalation chain.
+	AlertNotificationEscalated AlertNotificationType = "escalated"
 )
 
 // AlertNotificationStatus represents the status of alert notification.
@@ -69,6 +71,18 @@ type AlertNotification struct {
 	ChannelID        int
 }
 
+// WebhookDeadLetter is a webhook delivery that failed after all attempts,
+// kept for later inspection.
+type WebhookDeadLetter struct {
+	CreatedAt time.Time
+	URL       string
+	Payload   string
+	LastError string
+	ID        int64
+	ChannelID int
+	Attempts  int
+}
+
 // AlertLock represents a distributed lock for alert processing.
 type AlertLock struct {
 	LockedAt   time.Time
@@ -106,6 +120,11 @@ type NotificationStore interface {
 	// ListPendingNotifications returns all pending notifications.
 	ListPendingNotifications(ctx context.Context, limit int) ([]AlertNotification, error)
 
+	// CreateWebhookDeadLetter stores a webhook delivery that failed after all attempts.
+	CreateWebhookDeadLetter(ctx context.Context, deadLetter *WebhookDeadLetter) error
+	// ListWebhookDeadLetters returns the most recent failed deliveries of a channel.
+	ListWebhookDeadLetters(ctx context.Context, channelID, limit int) ([]WebhookDeadLetter, error)
+
 	// AcquireAlertLock attempts to acquire a lock for processing an alert.
 	AcquireAlertLock(ctx context.Context, lock *AlertLock) (bool, error)
 	// ReleaseAlertLock releases a lock for an alert.
@@ -114,12 +133,121 @@ type NotificationStore interface {
 	CleanupExpiredLocks(ctx context.Context, now time.Time) error
 }
 
+// IssueNotifier dispatches notifications about changes of an issue to the users following it.
+type IssueNotifier interface {
+	// NotifyIssueResolved notifies recipients that an issue has been resolved.
+	NotifyIssueResolved(ctx context.Context, n *IssueResolvedNotification) error
+	// NotifyIssueRegressed notifies the project team that a resolved issue recurred in a newer release.
+	NotifyIssueRegressed(ctx context.Context, n *IssueRegressedNotification) error
+	// NotifyIssueReappeared notifies the project team that an ignored issue met its ignore condition and was reopened.
+	NotifyIssueReappeared(ctx context.Context, n *IssueReappearedNotification) error
+	// NotifyIssueIgnored notifies recipients that an issue has been ignored.
+	NotifyIssueIgnored(ctx context.Context, n *IssueIgnoredNotification) error
+	// NotifyIssueCommented notifies the subscribers of an issue about a new message in its discussion.
+	NotifyIssueCommented(ctx context.Context, n *IssueCommentedNotification) error
+}
+
+// AlertDispatcher delivers alert state changes to notification channels.
+type AlertDispatcher interface {
+	// Dispatch sends the triggered, escalated or resolved alert notification to every enabled channel.
+	Dispatch(ctx context.Context, alert *Alert, channels []NotificationChannel, notificationType AlertNotificationType) error
+}
+
+// IssueResolvedNotification describes a resolved issue and who should hear about it.
+type IssueResolvedNotification struct {
+	ResolvedAt  time.Time
+	ResolvedBy  *User
+	ProjectName string
+	Message     string
+	Note        string
+	Recipients  []Teammate
+	IssueID     int64
+	ProjectID   int
+	TeamID      int
+}
+
+// IssueRegressedNotification describes a resolved issue that was reopened
+// after recurring in a release newer than the one that fixed it.
+type IssueRegressedNotification struct {
+	RegressedAt       time.Time
+	ProjectName       string
+	Message           string
+	ResolvedInRelease string
+	Release           string
+	Recipients        []Teammate
+	IssueID           int64
+	ProjectID         int
+	TeamID            int
+}
+
+// IssueReappearedNotification describes an ignored issue that was reopened
+// once the condition it was ignored until was met.
+type IssueReappearedNotification struct {
+	ReappearedAt time.Time
+	ProjectName  string
+	Message      string
+	Condition    IgnoreCondition
+	Recipients   []Teammate
+	IssueID      int64
+	ProjectID    int
+	TeamID       int
+}
+
+// IssueIgnoredNotification describes an ignored issue and who should hear about it.
+type IssueIgnoredNotification struct {
+	IgnoredBy   *User
+	Rule        *IgnoreRule
+	ProjectName string
+	Message     string
+	Recipients  []Teammate
+	IssueID     int64
+	ProjectID   int
+	TeamID      int
+}
+
+// IssueCommentedNotification describes a new message in the discussion of an issue
+// and the subscribers who should hear about it.
+type IssueCommentedNotification struct {
+	CreatedAt   time.Time
+	Author      *User
+	ProjectName string
+	Message     string
+	Content     string
+	Recipients  []Teammate
+	IssueID     int64
+	ProjectID   int
+	TeamID      int
+}
+
 // NotificationService encapsulates service domain logic.
 type NotificationService interface {
 	// SaveWebhookConfig saves or updates webhook configuration for a team.
 	SaveWebhookConfig(ctx context.Context, req *SaveWebhookConfigRequest) error
 	// GetWebhookConfigWithSecretByTeamID returns the webhook configuration with decrypted secret for a team.
 	GetWebhookConfigWithSecretByTeamID(ctx context.Context, teamID int) (*WebhookConfigWithSecret, error)
+	// NotifyIssueResolved notifies recipients that an issue has been resolved.
+	NotifyIssueResolved(ctx context.Context, n *IssueResolvedNotification) error
+	// NotifyIssueRegressed notifies the project team that a resolved issue recurred in a newer release.
+	NotifyIssueRegressed(ctx context.Context, n *IssueRegressedNotification) error
+	// NotifyIssueReappeared notifies the project team that an ignored issue met its ignore condition and was reopened.
+	NotifyIssueReappeared(ctx context.Context, n *IssueReappearedNotification) error
+	// NotifyIssueIgnored notifies recipients that an issue has been ignored.
+	NotifyIssueIgnored(ctx context.Context, n *IssueIgnoredNotification) error
+	// NotifyIssueCommented notifies the subscribers of an issue about a new message in its discussion.
+	NotifyIssueCommented(ctx context.Context, n *IssueCommentedNotification) error
+	// NotifyAlert sends the triggered, escalated or resolved alert notification to the webhook.
+	NotifyAlert(ctx context.Context, alert *Alert, config *WebhookConfig, notificationType AlertNotificationType) error
+	// Dispatch sends the alert notification to every enabled channel, applying the cooldown of the alert.
+	Dispatch(ctx context.Context, alert *Alert, channels []NotificationChannel, notificationType AlertNotificationType) error
+	// ListFailedDeliveries returns webhook deliveries of a team that failed after all attempts.
+	ListFailedDeliveries(ctx context.Context, req *ListFailedDeliveriesRequest) ([]WebhookDeadLetter, error)
+}
+
+// ListFailedDeliveriesRequest is a request to list failed webhook deliveries of a team.
+type ListFailedDeliveriesRequest struct {
+	User   *User
+	TeamID int
+	Limit  int
 }
 
 // WebhookConfigWithSecret holds webhook config with decrypted secret.
@@ -1,7 +1,11 @@
 package warnly
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -11,8 +15,15 @@ import (
 	"time"
 )
 
+// PageSize is the default number of issues on a page of the project details.
 const PageSize = 5
 
+// DefaultIssuesPeriod is the default time range of the issues list.
+const DefaultIssuesPeriod = "14d"
+
+// MaxListIssues is the default maximum number of issues returned by a single ListIssues call.
+const MaxListIssues = 100
+
 const (
 	// PlatformGolang represents the Go platform.
 	PlatformGolang Platform = iota + 1
@@ -20,9 +31,28 @@ const (
 	PlatformRust
 )
 
+// Platforms lists the supported platforms of projects.
+var Platforms = []Platform{PlatformGolang, PlatformRust}
+
 // ErrProjectNotFound is an error that is returned when the project is not found.
 var ErrProjectNotFound = errors.New("project not found")
 
+// ErrProjectNotReady is returned when events are sent with the key of a project created moments ago
+// that isn't visible yet, the SDK should send them again shortly.
+var ErrProjectNotReady = errors.New("project was created recently and is not ready yet")
+
+// DefaultProjectDeletionGracePeriod is how long a deleted project can be restored before it is purged.
+const DefaultProjectDeletionGracePeriod = 7 * 24 * time.Hour
+
+// DefaultNewIssueWindow is how long an issue is considered new after it was first seen.
+const DefaultNewIssueWindow = 7 * 24 * time.Hour
+
+// DefaultHotnessHalfLife is how long it takes for an event to count half as much to the hotness of an issue.
+const DefaultHotnessHalfLife = 6 * time.Hour
+
+// ErrTeamNotFound is returned when the team is not found among the teams of the user.
+var ErrTeamNotFound = errors.New("team not found")
+
 // Project is a representation of a project in the system.
 type Project struct {
 	CreatedAt       time.Time
@@ -39,25 +69,105 @@ type Project struct {
 	AllLength       int
 	NewLength       int
 	Platform        Platform
+	// CodeOwners suggest assignees of the project issues by the file paths of their stack frames.
+	CodeOwners []CodeOwner
+	// DeletedAt is when the project was deleted, nil unless the project awaits purging.
+	DeletedAt *time.Time
+	// SourceURLTemplate links stack frames to the repository, empty when not configured.
+	SourceURLTemplate string
+	// UniqueUsers is the number of distinct users the events of the project came from within the period.
+	UniqueUsers uint64
+}
+
+// ErrProjectKeyNotFound is returned when the project has no active key with the identifier.
+var ErrProjectKeyNotFound = errors.New("project key not found")
+
+// ErrPrimaryProjectKey is returned when revoking the key of the project DSN, it has to be rotated instead.
+var ErrPrimaryProjectKey = errors.New("the key of the project DSN can't be revoked, rotate it instead")
+
+// ProjectKey is an ingest key of a project. Events are accepted with any active key of the project,
+// so that a leaked key can be replaced without losing the events of SDKs that still use it.
+type ProjectKey struct {
+	CreatedAt time.Time
+	// RevokedAt is when the key stops being accepted, nil while the key is active.
+	RevokedAt *time.Time
+	Key       string
+	// DSN is the client key URL of the key.
+	DSN       string
+	ID        int64
+	ProjectID int
+	// Primary reports whether the key is the one of the project DSN.
+	Primary bool
+}
+
+// Active reports whether events are accepted with the key at the time.
+func (k *ProjectKey) Active(at time.Time) bool {
+	return k.RevokedAt == nil || at.Before(*k.RevokedAt)
+}
+
+// ProjectKeysRequest is a request to list or add the ingest keys of a project.
+type ProjectKeysRequest struct {
+	User      *User
+	ProjectID int
+}
+
+// RotateProjectKeyRequest is a request to replace the key of the project DSN with a new one.
+// The previous key is accepted for the grace period, so that SDKs can be reconfigured.
+type RotateProjectKeyRequest struct {
+	User        *User
+	GracePeriod time.Duration
+	ProjectID   int
+}
+
+// RevokeProjectKeyRequest is a request to stop accepting an additional key of a project
+// once the grace period passes, zero grace period revokes the key immediately.
+type RevokeProjectKeyRequest struct {
+	User        *User
+	GracePeriod time.Duration
+	KeyID       int64
+	ProjectID   int
 }
 
 // IssueEntry is how we represent an issue in the system.
 type IssueEntry struct {
-	LastSeen      time.Time
-	FirstSeen     time.Time
-	Type          string
-	View          string
-	Message       string
+	LastSeen  time.Time
+	FirstSeen time.Time
+	Type      string
+	View      string
+	Message   string
+	// Level is the name of the most frequent level of the issue events.
+	Level string
+	// IsNew is true when the issue was first seen within the new issue window.
+	IsNew bool
+	// NewInPeriod is true when the issue was first seen within the queried period,
+	// rather than only having events in it.
+	NewInPeriod   bool
 	ID            int64
 	TimesSeen     uint64
 	UserCount     uint64
 	ProjectID     int
 	MessagesCount int
+	// Hotness is the score the issues are ordered by under the hot sort, zero otherwise.
+	Hotness float64
+	// Sparkline is the number of events of the issue in each of the last SparklineHours hours
+	// of the listed period, oldest first.
+	Sparkline []int
 }
 
+// justNowThreshold is the age below which the wide form of TimeAgo reads "just now"
+// instead of a few seconds. Times in the future because of clock skew read the same.
+const justNowThreshold = 5 * time.Second
+
+// JustNow is what the wide form of TimeAgo returns for times less than a few seconds old.
+const JustNow = "just now"
+
 // TimeAgo returns a human-readable string representing the time since the issue was last seen.
+// The wide form uses singular units for a value of one and reads "just now" under five seconds.
 func TimeAgo(now func() time.Time, t time.Time, narrow bool) string {
 	duration := now().UTC().Sub(t.UTC())
+	if !narrow && duration < justNowThreshold {
+		return JustNow
+	}
 	seconds := int(duration.Seconds())
 	minutes := int(duration.Minutes())
 	hours := int(duration.Hours())
@@ -81,6 +191,40 @@ func TimeAgo(now func() time.Time, t time.Time, narrow bool) string {
 	}
 }
 
+// Ago returns the wide form of TimeAgo followed by "ago", e.g. "1 minute ago" or "just now".
+func Ago(now func() time.Time, t time.Time) string {
+	s := TimeAgo(now, t, false)
+	if s == JustNow {
+		return s
+	}
+	return s + " ago"
+}
+
+// TimestampLayout is the layout of absolute timestamps rendered in the UI.
+const TimestampLayout = "Jan 2, 2006 15:04:05 MST"
+
+// FormatTimestamp returns t as an absolute timestamp in the given location.
+// A nil location falls back to UTC.
+func FormatTimestamp(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(TimestampLayout)
+}
+
+// LoadLocation resolves an IANA timezone name (e.g. "Europe/Berlin").
+// Empty or unknown names resolve to UTC.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 func formatTime(value int, narrowUnit, fullUnit string, narrow bool) string {
 	if narrow {
 		return fmt.Sprintf("%d%s", value, narrowUnit)
@@ -106,19 +250,279 @@ type ProjectStore interface {
 	CreateProject(ctx context.Context, project *Project) error
 	// ListProjects returns a list of projects for the given teams.
 	ListProjects(ctx context.Context, teamIDs []int, name string) ([]Project, error)
-	// DeleteProject deletes a project by ID.
+	// ListProjectIDs returns identifiers of all projects that aren't deleted.
+	ListProjectIDs(ctx context.Context) ([]int, error)
+	// DeleteProject purges a project by ID along with its issues, discussions and alerts.
 	DeleteProject(ctx context.Context, projectID int) error
-	// GetProject returns a project by identifier.
+	// SoftDelete marks the project deleted, deleted projects are hidden until they are restored or purged.
+	SoftDelete(ctx context.Context, projectID int, at time.Time) error
+	// Restore brings back the deleted project.
+	Restore(ctx context.Context, projectID int) error
+	// GetDeletedProject returns the deleted project by identifier, ErrProjectNotFound if it isn't deleted.
+	GetDeletedProject(ctx context.Context, projectID int) (*Project, error)
+	// ListDeletedProjectIDs returns identifiers of projects deleted before the time.
+	ListDeletedProjectIDs(ctx context.Context, before time.Time) ([]int, error)
+	// GetProject returns a project by identifier, deleted projects are not found.
 	GetProject(ctx context.Context, projectID int) (*Project, error)
-	// GetOptions returns the project options.
+	// GetOptions returns the project options, ErrProjectNotFound if the project has no such key.
+	// The key is returned even when it is revoked, ProjectOptions.KeyRevokedAt tells when.
 	GetOptions(ctx context.Context, projectID int, projectKey string) (*ProjectOptions, error)
+	// AddKey adds an ingest key to the project.
+	AddKey(ctx context.Context, key *ProjectKey) error
+	// ListKeys returns the keys of the project, revoked ones included, oldest first.
+	ListKeys(ctx context.Context, projectID int) ([]ProjectKey, error)
+	// RevokeKey stops accepting the key at the time.
+	// Returns ErrProjectKeyNotFound if the project has no such key or it is already revoked.
+	RevokeKey(ctx context.Context, projectID int, keyID int64, at time.Time) error
+	// UpdatePrimaryKey makes the key the one of the project DSN.
+	UpdatePrimaryKey(ctx context.Context, projectID int, key string) error
+	// UpdateSampleRate updates the share of events kept for the project.
+	UpdateSampleRate(ctx context.Context, projectID int, sampleRate float64) error
+	// UpdateDedupWindow updates the window duplicate events of the project are not stored within.
+	UpdateDedupWindow(ctx context.Context, projectID int, window time.Duration) error
+	// UpdateOptions updates the platform of the project and the number of days its new events are kept.
+	UpdateOptions(ctx context.Context, projectID int, platform Platform, retentionDays uint8) error
+	// UpdateSourceURLTemplate updates the template of links from the project stack frames to the repository.
+	UpdateSourceURLTemplate(ctx context.Context, projectID int, template string) error
+	// UpdateGrouping updates the grouping strategy of the project.
+	UpdateGrouping(ctx context.Context, projectID int, grouping GroupingStrategy) error
+	// UpdatePriorityRules replaces the priority rules of the project.
+	UpdatePriorityRules(ctx context.Context, projectID int, rules []PriorityRule) error
+	// UpdateGroupingRules replaces the grouping rules of the project.
+	UpdateGroupingRules(ctx context.Context, projectID int, rules *GroupingRules) error
+	// UpdateIngestFilters replaces the ingest filters of the project.
+	UpdateIngestFilters(ctx context.Context, projectID int, filters *IngestFilters) error
+	// UpdateIngestSecret sets the secret ingested payloads are signed with, empty secret disables signing.
+	UpdateIngestSecret(ctx context.Context, projectID int, secret string) error
+	// AddDiscardedEvents adds the events dropped by SDKs of the project to the counts of the day.
+	AddDiscardedEvents(ctx context.Context, projectID int, day time.Time, discarded []DiscardedEvents) error
+	// ListDiscardedEvents sums the events dropped by SDKs of the project by reason and category since the day.
+	ListDiscardedEvents(ctx context.Context, projectID int, since time.Time) ([]DiscardedEvents, error)
+	// UpdateCodeOwners replaces the code owner rules of the project.
+	UpdateCodeOwners(ctx context.Context, projectID int, owners []CodeOwner) error
+	// UpdateTeam moves the project with its alerts to another team.
+	// Returns ErrDuplicate if the team already has a project with the same name.
+	UpdateTeam(ctx context.Context, projectID, teamID int) error
+	// GetDefaultIssuesQuery returns the query the user applies to the issues list of the project.
+	// Returns ErrNotFound if the user has no default query.
+	GetDefaultIssuesQuery(ctx context.Context, userID, projectID int) (string, error)
+	// SaveDefaultIssuesQuery stores the default query, an empty query removes it.
+	SaveDefaultIssuesQuery(ctx context.Context, query *DefaultIssuesQuery) error
+}
+
+// MaxDefaultIssuesQueryLength is the maximum length of a default issues query.
+const MaxDefaultIssuesQueryLength = 255
+
+// ErrDefaultIssuesQueryTooLong is returned when a default issues query exceeds MaxDefaultIssuesQueryLength.
+var ErrDefaultIssuesQueryTooLong = errors.New("default query is too long")
+
+// DefaultIssuesQuery is the query applied to the issues list when the user opens it without a query.
+// ProjectID is 0 for the list of issues of all projects.
+type DefaultIssuesQuery struct {
+	UpdatedAt time.Time
+	Query     string
+	UserID    int
+	ProjectID int
+}
+
+// SaveDefaultIssuesQueryRequest is a request to change the default issues query of the user.
+// ProjectName is empty for the list of issues of all projects.
+type SaveDefaultIssuesQueryRequest struct {
+	User        *User
+	ProjectName string
+	Query       string
 }
 
 type ProjectOptions struct {
-	Name          string
-	ID            int
-	Platform      Platform
+	Name     string
+	ID       int
+	TeamID   int
+	Platform Platform
+	// Grouping selects how events without an exception stack trace are grouped into issues.
+	Grouping GroupingStrategy
+	// PriorityRules raise the priority of issues whose events carry certain tags.
+	PriorityRules []PriorityRule
+	// GroupingRules exclude framework frames from the issue view, select exception types
+	// grouped regardless of their messages and strip variable literals from messages before grouping,
+	// nil when the project has none.
+	GroupingRules *GroupingRules
+	// IngestSecret signs ingested payloads, empty when the project doesn't require signed ingestion.
+	IngestSecret string
+	// KeyRevokedAt is when the key the options were requested with stops being accepted,
+	// nil while the key is active.
+	KeyRevokedAt *time.Time
+	// SampleRate is the share of events that are stored, from 0 (none) to 1 (all).
+	SampleRate    float64
 	RetentionDays uint8
+	// DedupWindow is the time duplicate events are not stored within, zero disables deduplication.
+	DedupWindow time.Duration
+	// IngestFilters drop events by their environment and release, nil when the project has none.
+	IngestFilters *IngestFilters
+}
+
+// MaxDedupWindow is the longest window duplicate events can be deduplicated within.
+const MaxDedupWindow = time.Hour
+
+// ErrInvalidDedupWindow is returned when a dedup window is negative or too long.
+var ErrInvalidDedupWindow = errors.New("dedup window must be between 0 and 1h")
+
+// ValidateDedupWindow checks that the dedup window is within the [0, MaxDedupWindow] range
+// and has a whole number of seconds.
+func ValidateDedupWindow(window time.Duration) error {
+	if window < 0 || window > MaxDedupWindow || window%time.Second != 0 {
+		return ErrInvalidDedupWindow
+	}
+	return nil
+}
+
+// ErrInvalidSampleRate is returned when a sample rate is outside of the [0, 1] range.
+var ErrInvalidSampleRate = errors.New("sample rate must be between 0 and 1")
+
+// ValidateSampleRate checks that the sample rate is within the [0, 1] range.
+func ValidateSampleRate(rate float64) error {
+	if math.IsNaN(rate) || rate < 0 || rate > 1 {
+		return ErrInvalidSampleRate
+	}
+	return nil
+}
+
+// DefaultRetentionDays is the number of days project events are kept unless configured otherwise.
+const DefaultRetentionDays = 90
+
+// MaxRetentionDays is the longest number of days project events can be kept.
+const MaxRetentionDays = 180
+
+// ErrInvalidRetention is returned when a retention is outside of the [1, MaxRetentionDays] range.
+var ErrInvalidRetention = errors.New("retention must be between 1 and 180 days")
+
+// ValidateRetentionDays checks that the retention is within the [1, MaxRetentionDays] range.
+func ValidateRetentionDays(days int) error {
+	if days < 1 || days > MaxRetentionDays {
+		return ErrInvalidRetention
+	}
+	return nil
+}
+
+// ErrInvalidPlatform is returned when a platform isn't supported.
+var ErrInvalidPlatform = errors.New("unsupported platform")
+
+// KeepSampled reports whether the event with the given ID should be stored
+// under the sample rate. The decision is derived from a hash of the event ID,
+// so the same event is always either kept or dropped.
+func KeepSampled(eventID string, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(eventID))
+	return float64(binary.BigEndian.Uint64(sum[:8]))/float64(math.MaxUint64) < rate
+}
+
+// SetSampleRateRequest is a request to change the sample rate of a project.
+type SetSampleRateRequest struct {
+	User       *User
+	SampleRate float64
+	ProjectID  int
+}
+
+// SetDedupWindowRequest is a request to change the dedup window of a project.
+type SetDedupWindowRequest struct {
+	User      *User
+	Window    time.Duration
+	ProjectID int
+}
+
+// UpdateProjectOptionsRequest is a request to change the platform and the event retention of a project.
+type UpdateProjectOptionsRequest struct {
+	User          *User
+	ProjectID     int
+	Platform      Platform
+	RetentionDays int
+}
+
+// SetGroupingRequest is a request to change the grouping strategy of a project.
+type SetGroupingRequest struct {
+	User      *User
+	Grouping  GroupingStrategy
+	ProjectID int
+}
+
+// SetPriorityRulesRequest is a request to replace the priority rules of a project.
+type SetPriorityRulesRequest struct {
+	User      *User
+	Rules     []PriorityRule
+	ProjectID int
+}
+
+// SetGroupingRulesRequest is a request to replace the grouping rules of a project.
+type SetGroupingRulesRequest struct {
+	User      *User
+	Rules     *GroupingRules
+	ProjectID int
+}
+
+// SetIngestFiltersRequest is a request to replace the ingest filters of a project.
+type SetIngestFiltersRequest struct {
+	User *User
+	// Filters are the new filters, nil keeps every event.
+	Filters   *IngestFilters
+	ProjectID int
+}
+
+// SetIngestSigningRequest is a request to require signed ingestion for a project.
+// Enabling signing for a project that already requires it rotates the secret.
+type SetIngestSigningRequest struct {
+	User      *User
+	ProjectID int
+	Enabled   bool
+}
+
+// MaxManifestProjects is the maximum number of projects a single manifest can import.
+const MaxManifestProjects = 100
+
+// ErrInvalidManifest is returned when a project import manifest can't be imported.
+var ErrInvalidManifest = errors.New("invalid project manifest")
+
+// ProjectManifestEntry describes a project to be created by an import.
+type ProjectManifestEntry struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	TeamID   int    `json:"team_id"`
+}
+
+// Validate checks that the entry describes a project that can be created.
+func (e *ProjectManifestEntry) Validate() error {
+	if e.Name == "" || len(e.Name) >= 32 {
+		return errors.New("name must be between 1 and 31 characters")
+	}
+	if PlatformByName(e.Platform) == 0 {
+		return fmt.Errorf("unknown platform %q", e.Platform)
+	}
+	if e.TeamID <= 0 {
+		return errors.New("team is required")
+	}
+	return nil
+}
+
+// ImportProjectsRequest is a request to create projects in bulk from a manifest.
+type ImportProjectsRequest struct {
+	User     *User
+	Projects []ProjectManifestEntry
+}
+
+// ImportedProject is a project of the manifest along with its DSN.
+// Created is false when the project already existed.
+type ImportedProject struct {
+	ProjectInfo
+	TeamID  int
+	Created bool
+}
+
+// ImportProjectsResult is the result of a project import, in manifest order.
+type ImportProjectsResult struct {
+	Projects []ImportedProject
 }
 
 // ProjectService encapsulates service domain logic.
@@ -133,14 +537,32 @@ type ProjectService interface {
 	ListTeams(ctx context.Context, user *User) ([]Team, error)
 	// GetProject returns a project by identifier.
 	GetProject(ctx context.Context, projectID int, user *User) (*Project, error)
-	// DeleteProject deletes a project by ID.
+	// DeleteProject deletes a project by ID, it can be restored within the deletion grace period.
 	DeleteProject(ctx context.Context, projectID int, user *User) error
+	// RestoreProject brings back a project deleted within the deletion grace period.
+	RestoreProject(ctx context.Context, projectID int, user *User) error
 	// GetProjectDetails returns the project details.
 	GetProjectDetails(ctx context.Context, req *ProjectDetailsRequest, user *User) (*ProjectDetails, error)
 	// GetIssue returns the issue by ID.
 	GetIssue(ctx context.Context, req *GetIssueRequest) (*IssueDetails, error)
+	// GetRawEvent returns the event payload as it was sent by the SDK.
+	GetRawEvent(ctx context.Context, req *GetRawEventRequest) ([]byte, error)
+
+	// ReleaseAdoption returns users and errors of the most recent releases of a project.
+	ReleaseAdoption(ctx context.Context, req *ReleaseAdoptionRequest) ([]ReleaseAdoption, error)
+
+	// CompareWindows returns the number of events of each issue of a project in two time windows.
+	CompareWindows(ctx context.Context, req *CompareWindowsRequest) (*WindowComparison, error)
+
+	// ListDiscardedEvents returns the events SDKs of a project dropped client-side by reason and category.
+	ListDiscardedEvents(ctx context.Context, req *ListDiscardedEventsRequest) ([]DiscardedEvents, error)
+
+	// SuggestTagValues returns the most popular values of a project tag starting with a prefix.
+	SuggestTagValues(ctx context.Context, req *SuggestTagValuesRequest) ([]TagValueCount, error)
 
 	GetDiscussion(ctx context.Context, req *GetDiscussionsRequest) (*Discussion, error)
+	// GetIssueActivity returns the timeline of the issue: assignments, status changes and messages.
+	GetIssueActivity(ctx context.Context, req *GetIssueActivityRequest) ([]IssueActivity, error)
 
 	// ListFields returns a list of fields related to an issue.
 	// e.g. how many times a field like browser or os was seen in events.
@@ -151,6 +573,8 @@ type ProjectService interface {
 
 	// ListIssues returns a list of issues for specified projects.
 	ListIssues(ctx context.Context, req *ListIssuesRequest) (*ListIssuesResult, error)
+	// SaveDefaultIssuesQuery sets the query applied to the issues list when the user opens it without a query.
+	SaveDefaultIssuesQuery(ctx context.Context, req *SaveDefaultIssuesQueryRequest) error
 
 	// ListTeammates returns a list of teammates for the specified project.
 	ListTeammates(ctx context.Context, req *ListTeammatesRequest) ([]Teammate, error)
@@ -167,12 +591,83 @@ type ProjectService interface {
 	// DeleteAssignment unassigns an issue from a user.
 	DeleteAssignment(ctx context.Context, req *UnassignIssueRequest) error
 
+	// ResolveIssue marks an issue as resolved, optionally leaving a note and notifying followers.
+	ResolveIssue(ctx context.Context, req *ResolveIssueRequest) error
+	// IgnoreIssue hides an issue until it occurs or affects users a number of times more or a time passes.
+	IgnoreIssue(ctx context.Context, req *IgnoreIssueRequest) error
+	// Subscribe subscribes the user to new messages and status changes of an issue.
+	Subscribe(ctx context.Context, req *IssueSubscriptionRequest) error
+	// Unsubscribe unsubscribes the user from an issue.
+	Unsubscribe(ctx context.Context, req *IssueSubscriptionRequest) error
+	// AddLabel attaches a label to an issue, labels are shared by the team of the issue's project.
+	AddLabel(ctx context.Context, req *IssueLabelRequest) error
+	// RemoveLabel removes a label from an issue.
+	RemoveLabel(ctx context.Context, req *IssueLabelRequest) error
+	// ListLabels returns the labels of an issue ordered by name.
+	ListLabels(ctx context.Context, req *IssueLabelRequest) ([]IssueLabel, error)
+	// AddNoiseFilter drops the events of an issue that have the tags of the filter at ingest,
+	// so they don't count to the issue metrics.
+	AddNoiseFilter(ctx context.Context, req *NoiseFilterRequest) error
+	// RemoveNoiseFilter stops dropping the events of an issue by the filter.
+	RemoveNoiseFilter(ctx context.Context, req *NoiseFilterRequest) error
+
+	// SetSampleRate changes the share of incoming events stored for a project.
+	SetSampleRate(ctx context.Context, req *SetSampleRateRequest) error
+	// SetDedupWindow changes the window duplicate events of a project are not stored within.
+	SetDedupWindow(ctx context.Context, req *SetDedupWindowRequest) error
+	// GetProjectOptions returns the options of a project, e.g. its platform and event retention.
+	GetProjectOptions(ctx context.Context, projectID int, user *User) (*ProjectOptions, error)
+	// UpdateProjectOptions changes the platform and the event retention of a project.
+	UpdateProjectOptions(ctx context.Context, req *UpdateProjectOptionsRequest) error
+	// SetSourceURLTemplate changes the template of links from a project's stack frames to the repository.
+	SetSourceURLTemplate(ctx context.Context, req *SetSourceURLTemplateRequest) error
+
+	// SetGrouping changes how message-only events of a project are grouped into issues.
+	SetGrouping(ctx context.Context, req *SetGroupingRequest) error
+
+	// SetPriorityRules replaces the rules that set the priority of a project's issues by event tags.
+	SetPriorityRules(ctx context.Context, req *SetPriorityRulesRequest) error
+
+	// SetGroupingRules replaces the rules that exclude framework frames of a project's events from the issue view,
+	// group exceptions of the listed types regardless of their messages and normalize messages before grouping.
+	SetGroupingRules(ctx context.Context, req *SetGroupingRulesRequest) error
+
+	// SetIngestFilters replaces the environment and release patterns a project's events are dropped by at ingest.
+	SetIngestFilters(ctx context.Context, req *SetIngestFiltersRequest) error
+
+	// SetIngestSigning requires or stops requiring signed ingestion for a project.
+	// Returns the new signing secret, empty when signing is disabled.
+	SetIngestSigning(ctx context.Context, req *SetIngestSigningRequest) (string, error)
+	// ListKeys returns the ingest keys of a project.
+	ListKeys(ctx context.Context, req *ProjectKeysRequest) ([]ProjectKey, error)
+	// SampleIngestCommand returns a curl command sending a test event to the project.
+	SampleIngestCommand(ctx context.Context, projectID int, user *User) (string, error)
+	// AddKey adds an ingest key to a project, events are accepted with any active key.
+	AddKey(ctx context.Context, req *ProjectKeysRequest) (*ProjectKey, error)
+	// RotateKey replaces the key of the project DSN, the previous key is accepted for the grace period.
+	RotateKey(ctx context.Context, req *RotateProjectKeyRequest) (*ProjectKey, error)
+	// RevokeKey stops accepting an additional key of a project after the grace period.
+	RevokeKey(ctx context.Context, req *RevokeProjectKeyRequest) error
+	// SetCodeOwners replaces the rules that suggest assignees of a project's issues by stack frame paths.
+	SetCodeOwners(ctx context.Context, req *SetCodeOwnersRequest) error
+	// TransferProject moves a project to another team of the user.
+	TransferProject(ctx context.Context, req *TransferProjectRequest) error
+
+	// ImportProjects creates the projects of a manifest, skipping the ones that already exist.
+	ImportProjects(ctx context.Context, req *ImportProjectsRequest) (*ImportProjectsResult, error)
+
 	// SearchProject searches for projects by name. Returns ErrProjectNotFound if no project is found.
 	SearchProject(ctx context.Context, name string, user *User) (*Project, error)
 	// ListPopularTags lists popular tag keys for search suggestions.
 	ListPopularTags(ctx context.Context, req *ListPopularTagsRequest) ([]TagCount, error)
 	// ListTagValues lists popular values for a given tag.
 	ListTagValues(ctx context.Context, req *ListTagValuesRequest) ([]TagValueCount, error)
+
+	// ReadOnly reports whether mutating actions are rejected with ErrReadOnly.
+	ReadOnly() bool
+	// SetReadOnly turns the read-only maintenance mode on or off at runtime,
+	// for the current process only.
+	SetReadOnly(readOnly bool)
 }
 
 type DeleteMessageRequest struct {
@@ -212,6 +707,10 @@ type Teammate struct {
 	Email    string
 	Username string
 	ID       int64
+	// Role is the role of the teammate in the team.
+	Role Role
+	// Active is false for members excluded from automatic assignment.
+	Active bool
 }
 
 // AvatarInitials returns the initials of the teammate in uppercase.
@@ -239,9 +738,43 @@ type ListIssuesRequest struct {
 	ProjectName string
 	ProjectIDs  []int
 	Offset      int
-	Limit       int
+	// Limit is the maximum number of issues to return, capped by the service maximum.
+	Limit int
+	// UnhandledOnly restricts the list to issues with unhandled events (crashes).
+	UnhandledOnly bool
+	// Sort is the order of the issues, by frequency when empty.
+	Sort string
+	// AssignedTo restricts the list to the issues assigned to a user ID, a username or AssignedToMe,
+	// AssignedToNone restricts it to unassigned issues. It overrides the assigned: filter of the query.
+	AssignedTo string
+	// Label restricts the list to the issues with the label. It overrides the label: filter of the query.
+	Label string
+	// UseDefaultQuery applies the default query of the user when the list is opened without a query.
+	UseDefaultQuery bool
+	// IncludeIgnored lists the ignored issues too, they are left out by default.
+	IncludeIgnored bool
 }
 
+const (
+	// AssignedFilterKey is the query filter key of the issue assignee, e.g. assigned:me.
+	AssignedFilterKey = "assigned"
+	// AssignedToMe refers to the user listing the issues.
+	AssignedToMe = "me"
+	// AssignedToNone refers to the issues nobody is assigned to.
+	AssignedToNone = "none"
+	// AssignedToNoneAlias is the value of unassigned issues suggested by the assignee filters.
+	AssignedToNoneAlias = "unassigned"
+)
+
+const (
+	// IssueSortRelevance orders the issues by how well they match the search text of the query.
+	IssueSortRelevance = "relevance"
+	// IssueSortUsers orders the issues by the number of users affected, then by frequency.
+	IssueSortUsers = "users"
+	// IssueSortHot orders the issues by their hotness score, see Hotness.
+	IssueSortHot = "hot"
+)
+
 type ListIssuesResult struct {
 	RequestedProject string
 	Request          *ListIssuesRequest
@@ -250,6 +783,8 @@ type ListIssuesResult struct {
 	Projects         []Project
 	PopularTags      []TagCount
 	TotalIssues      int
+	// HasMore reports whether there are issues after the returned ones, see ListIssuesRequest.Offset.
+	HasMore bool
 }
 
 type GetAssignedFiltersCriteria struct {
@@ -268,10 +803,52 @@ type QueryToken struct {
 	Operator  string `json:"operator"`
 	Value     string `json:"value"`
 	IsRawText bool   `json:"isRawText"`
+	// IsContext is true when the key refers to an event context or extra value rather than a tag.
+	IsContext bool `json:"isContext"`
+	// IsUser is true when the token filters events by the user they came from, e.g. user:jane@example.com.
+	IsUser bool `json:"isUser"`
+}
+
+// UserFilterKey is the query filter key of the user events came from, an email or a user ID.
+const UserFilterKey = "user"
+
+// UserFilter tells whether the value of a user filter is an email or a user ID
+// and returns it as the one or the other.
+func UserFilter(value string) (email, id string) {
+	if strings.Contains(value, "@") {
+		return value, ""
+	}
+	return "", value
+}
+
+// contextKeys are the keys of values stored from event contexts at ingestion.
+var contextKeys = []string{
+	"user.ip",
+	"device.arch",
+	"device.num_cpu",
+	"runtime.name",
+	"runtime.version",
+	"runtime.go_maxprocs",
+	"runtime.go_numcgocalls",
+	"runtime.go_numroutines",
+	"os.name",
+}
+
+// extraNamespace is the key prefix of extra values stored with event contexts.
+const extraNamespace = "extra."
+
+// IsContextKey reports whether the filter key refers to an event context (e.g. os.name)
+// or an extra value (e.g. extra.order_id) rather than a tag. Other dotted keys, e.g. os.custom, are tags.
+func IsContextKey(key string) bool {
+	if strings.HasPrefix(key, extraNamespace) && len(key) > len(extraNamespace) {
+		return true
+	}
+	return slices.Contains(contextKeys, key)
 }
 
 // ParseQuery parses a query string into QueryTokens.
 // Supports quoted values, operators like : and !:, and raw text.
+// Keys of contexts and extra values are marked with IsContext, user filters with IsUser.
 func ParseQuery(query string) []QueryToken {
 	var tokens []QueryToken
 	var current strings.Builder
@@ -326,9 +903,11 @@ func parseToken(token string) QueryToken {
 		}
 
 		return QueryToken{
-			Key:      key,
-			Operator: operator,
-			Value:    value,
+			Key:       key,
+			Operator:  operator,
+			Value:     value,
+			IsContext: IsContextKey(key),
+			IsUser:    key == UserFilterKey,
 		}
 	}
 
@@ -370,11 +949,13 @@ func (l *ListIssuesResult) NoIssues() bool {
 
 // ListEventsRequest is a request structure for listing all events per issue.
 type ListEventsRequest struct {
-	User      *User
-	Period    string
-	Start     string
-	End       string
-	Query     string
+	User   *User
+	Period string
+	Start  string
+	End    string
+	Query  string
+	// Levels limits the events to the given level names, all levels when empty.
+	Levels    []string
 	ProjectID int
 	IssueID   int
 	Offset    int
@@ -388,6 +969,25 @@ type ListEventsResult struct {
 	IssueID     int
 	TotalEvents uint64
 	Offset      int
+	Limit       int
+	HasNext     bool
+	HasPrev     bool
+}
+
+// PageCount returns the number of pages of matching events.
+func (l *ListEventsResult) PageCount() int {
+	if l.Limit <= 0 {
+		return 0
+	}
+	return int((l.TotalEvents + uint64(l.Limit) - 1) / uint64(l.Limit))
+}
+
+// CurrentPage returns the 1-based number of the page starting at the offset.
+func (l *ListEventsResult) CurrentPage() int {
+	if l.Limit <= 0 {
+		return 1
+	}
+	return l.Offset/l.Limit + 1
 }
 
 type ListFieldsRequest struct {
@@ -411,17 +1011,19 @@ type GetDiscussionsRequest struct {
 }
 
 type CreateMessageRequest struct {
-	User           *User
-	Content        string
-	MentionedUsers []int
-	ProjectID      int
-	IssueID        int
+	User *User
+	// Content is the message, teammates mentioned in it with "@username" are recorded as mentions.
+	Content   string
+	ProjectID int
+	IssueID   int
 }
 
 type Discussion struct {
 	Info      DiscussionInfo
 	Teammates []Teammate
 	Messages  []IssueMessage
+	// Activity is the timeline of the issue in chronological order.
+	Activity []IssueActivity
 }
 
 type DiscussionInfo struct {
@@ -438,6 +1040,7 @@ const (
 
 type GetIssueRequest struct {
 	User      *User
+	Location  *time.Location // timezone of the user to render absolute timestamps in
 	Period    string
 	EventID   string
 	Source    GetIssueRequestSource
@@ -445,6 +1048,65 @@ type GetIssueRequest struct {
 	IssueID   int
 }
 
+// GetRawEventRequest is a request to get the original payload of an issue event.
+type GetRawEventRequest struct {
+	User      *User
+	EventID   string
+	ProjectID int
+	IssueID   int
+}
+
+// MaxAdoptionReleases is the number of most recent releases shown in the release adoption.
+const MaxAdoptionReleases = 10
+
+// ReleaseAdoptionRequest is a request to get the adoption of the recent releases of a project.
+type ReleaseAdoptionRequest struct {
+	User *User
+	// Period is the time range ending now, e.g. 14d, the default is DefaultAdoptionPeriod.
+	Period    string
+	ProjectID int
+}
+
+// ListDiscardedEventsRequest is a request to list the events dropped by SDKs of a project.
+type ListDiscardedEventsRequest struct {
+	User *User
+	// Period is the time range ending now, e.g. 7d, the default is DefaultDiscardedEventsPeriod.
+	Period    string
+	ProjectID int
+}
+
+// DefaultDiscardedEventsPeriod is the period of dropped events when no period is requested.
+const DefaultDiscardedEventsPeriod = "30d"
+
+// DefaultAdoptionPeriod is the release adoption period when no period is requested.
+const DefaultAdoptionPeriod = "14d"
+
+// TransferProjectRequest is a request to move a project to another team.
+type TransferProjectRequest struct {
+	User      *User
+	ProjectID int
+	// TeamID is the team the project is moved to.
+	TeamID int
+}
+
+// SuggestTagValuesRequest is a request to autocomplete a value of a project tag.
+type SuggestTagValuesRequest struct {
+	User *User
+	Tag  string
+	// Prefix is what was typed of the value so far.
+	Prefix string
+	// Period is the time range ending now, e.g. 7d, the default is DefaultTagSuggestionsPeriod.
+	Period    string
+	ProjectID int
+}
+
+const (
+	// MaxTagValueSuggestions is the number of values suggested for a tag.
+	MaxTagValueSuggestions = 10
+	// DefaultTagSuggestionsPeriod is the period tag values are suggested from when no period is requested.
+	DefaultTagSuggestionsPeriod = "7d"
+)
+
 type IssueEvent struct {
 	UserID                  string
 	UserEmail               string
@@ -454,6 +1116,10 @@ type IssueEvent struct {
 	CreatedAt               time.Time
 	Env                     string
 	Release                 string
+	Dist                    string
+	Logger                  string
+	Transaction             string
+	TraceID                 string
 	TagsKey                 []string
 	TagsValue               []string
 	ContextsKey             []string
@@ -484,8 +1150,10 @@ type IssueDetails struct {
 	TagCount      []TagCount
 	TagValueNum   []FieldValueNum
 	Teammates     []Teammate
+	Teams         []Team
 	StackDetails  []StackDetail
 	ProjectID     int
+	Total1Hour    uint64
 	Total24Hours  uint64
 	Priority      IssuePriority
 	Total30Days   uint64
@@ -493,14 +1161,83 @@ type IssueDetails struct {
 	MessagesCount int
 	TimesSeen     uint64
 	UserCount     uint64
-	IsNew         bool
-	Platform      Platform
+	// IsNew is true when the issue was first seen within the new issue window.
+	IsNew bool
+	// NewInPeriod is true when the issue was first seen within the requested period.
+	NewInPeriod bool
+	Platform    Platform
+	// VisibleFrames is the number of stack frames shown before the rest are collapsed,
+	// DefaultVisibleFrames when zero.
+	VisibleFrames int
+	// SuggestedAssignee is the code owner of the top in-app frame of an unassigned issue.
+	SuggestedAssignee *Teammate
+	// EventGaps is the histogram of time gaps between consecutive events over the last 30 days.
+	EventGaps []EventGapBucket
+	// SourceURLTemplate links the top in-app frame to the repository, empty when not configured.
+	SourceURLTemplate string
+	// Subscribed is true when the user viewing the issue is subscribed to it.
+	Subscribed bool
+	// Ignore is the condition the issue is ignored until, nil unless the issue is ignored.
+	Ignore *IgnoreRule
+	// Labels are the labels attached to the issue ordered by name.
+	Labels []IssueLabel
+	// SeenBy are the teammates who have viewed the issue, the most recent viewer first.
+	SeenBy []Teammate
+	// TraceEvents are the other events of the trace the displayed event happened in,
+	// often related failures of other services, the most recent first.
+	TraceEvents []TraceEvent
 }
 
 func (id *IssueDetails) GetPlatform() string {
 	return id.Platform.String()
 }
 
+// FirstSeenFormatted returns the first seen time in the timezone of the requesting user.
+func (id *IssueDetails) FirstSeenFormatted() string {
+	return FormatTimestamp(id.FirstSeen, id.location())
+}
+
+// LastSeenFormatted returns the last seen time in the timezone of the requesting user.
+func (id *IssueDetails) LastSeenFormatted() string {
+	return FormatTimestamp(id.LastSeen, id.location())
+}
+
+// TimeFormatted returns the time in the timezone of the requesting user.
+func (id *IssueDetails) TimeFormatted(t time.Time) string {
+	return FormatTimestamp(t, id.location())
+}
+
+// Logger returns the name of the logger that sent the displayed event, empty when not sent.
+func (id *IssueDetails) Logger() string {
+	if id.LastEvent == nil {
+		return ""
+	}
+	return id.LastEvent.Logger
+}
+
+// Transaction returns the transaction the displayed event happened in, empty when not sent.
+func (id *IssueDetails) Transaction() string {
+	if id.LastEvent == nil {
+		return ""
+	}
+	return id.LastEvent.Transaction
+}
+
+// TraceID returns the trace the displayed event happened in, empty when not sent.
+func (id *IssueDetails) TraceID() string {
+	if id.LastEvent == nil {
+		return ""
+	}
+	return id.LastEvent.TraceID
+}
+
+func (id *IssueDetails) location() *time.Location {
+	if id.Request == nil {
+		return nil
+	}
+	return id.Request.Location
+}
+
 func Cut(s string, n int) string {
 	if len(s) > n {
 		return s[:n] + "..."
@@ -541,6 +1278,101 @@ func (id *IssueDetails) Contexts() map[string]string {
 	return res
 }
 
+// ContextField is a single context value within a group, e.g. "arch" of "device".
+type ContextField struct {
+	Key   string
+	Value string
+}
+
+// ContextGroup is a logical section of the event contexts, e.g. device, os or runtime.
+type ContextGroup struct {
+	// Name is the prefix of the flattened context keys, e.g. "device".
+	Name string
+	// Title is the human readable name of the group, e.g. "Device".
+	Title  string
+	Fields []ContextField
+}
+
+// Value returns the value of the field with the key, or an empty string if there is none.
+func (g *ContextGroup) Value(key string) string {
+	for i := range g.Fields {
+		if g.Fields[i].Key == key {
+			return g.Fields[i].Value
+		}
+	}
+	return ""
+}
+
+// contextGroupOther is the group of the context keys stored without a prefix.
+const contextGroupOther = "other"
+
+// contextGroupOrder is the order well-known context groups are displayed in,
+// other groups follow alphabetically and keys without a prefix come last.
+var contextGroupOrder = []string{"device", "os", "runtime", "user", "extra"}
+
+// contextGroupTitles are the display titles of well-known context groups.
+var contextGroupTitles = map[string]string{
+	"device":          "Device",
+	"os":              "OS",
+	"runtime":         "Runtime",
+	"user":            "User",
+	"extra":           "Extra",
+	contextGroupOther: "Other",
+}
+
+// ContextGroups returns the contexts of the last event grouped by the key prefix.
+// Well-known groups come first in a stable order, fields keep the order they were ingested in.
+func (id *IssueDetails) ContextGroups() []ContextGroup {
+	if len(id.LastEvent.ContextsKey) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]*ContextGroup)
+	for i, key := range id.LastEvent.ContextsKey {
+		name, field, ok := strings.Cut(key, ".")
+		if !ok || name == "" {
+			name, field = contextGroupOther, key
+		}
+		group, ok := byName[name]
+		if !ok {
+			title, known := contextGroupTitles[name]
+			if !known {
+				title = strings.ToUpper(name[:1]) + name[1:]
+			}
+			group = &ContextGroup{Name: name, Title: title}
+			byName[name] = group
+		}
+		var value string
+		if i < len(id.LastEvent.ContextsValue) {
+			value = id.LastEvent.ContextsValue[i]
+		}
+		group.Fields = append(group.Fields, ContextField{Key: field, Value: value})
+	}
+
+	res := make([]ContextGroup, 0, len(byName))
+	for _, name := range contextGroupOrder {
+		if group, ok := byName[name]; ok {
+			res = append(res, *group)
+			delete(byName, name)
+		}
+	}
+	rest := make([]string, 0, len(byName))
+	for name := range byName {
+		if name != contextGroupOther {
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+	for _, name := range rest {
+		res = append(res, *byName[name])
+	}
+	if group, ok := byName[contextGroupOther]; ok {
+		res = append(res, *group)
+	}
+
+	return res
+}
+
 func (id *IssueDetails) Tag(tag string) string {
 	idx := -1
 	for i := range id.LastEvent.TagsKey {
@@ -576,6 +1408,40 @@ func (id *IssueDetails) ProgressLen(val string) string {
 	return ""
 }
 
+// HasEventGaps reports whether the issue has at least two events to measure the gaps between.
+func (id *IssueDetails) HasEventGaps() bool {
+	for i := range id.EventGaps {
+		if id.EventGaps[i].Count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// EventGapBarLen returns the width class of the event gap histogram bar relative to the largest bucket.
+func (id *IssueDetails) EventGapBarLen(count uint64) string {
+	var maxCount uint64
+	for i := range id.EventGaps {
+		maxCount = max(maxCount, id.EventGaps[i].Count)
+	}
+	if maxCount == 0 {
+		return ""
+	}
+	percent := count * 100 / maxCount
+	switch {
+	case percent >= 100:
+		return "w-full"
+	case percent >= 75:
+		return "w-3/4"
+	case percent >= 50:
+		return "w-1/2"
+	case percent >= 25:
+		return "w-1/4"
+	default:
+		return "w-1/5"
+	}
+}
+
 func (id *IssueDetails) EventID() string {
 	return id.LastEvent.EventID
 }
@@ -596,16 +1462,29 @@ func (sd *StackDetail) InAppStr() string {
 
 func (id *IssueDetails) HasStackDetails() bool { return len(id.StackDetails) > 0 }
 
+// DefaultVisibleFrames is the number of stack frames shown before the rest are collapsed
+// when neither the platform nor the configuration sets another.
+const DefaultVisibleFrames = 5
+
+func (id *IssueDetails) visibleFrames() int {
+	if id.VisibleFrames <= 0 {
+		return DefaultVisibleFrames
+	}
+	return id.VisibleFrames
+}
+
+// StackVisible returns the stack frames shown expanded.
 func (id *IssueDetails) StackVisible() []StackDetail {
-	if len(id.StackDetails) > 5 {
-		return id.StackDetails[:5]
+	if n := id.visibleFrames(); len(id.StackDetails) > n {
+		return id.StackDetails[:n]
 	}
 	return id.StackDetails
 }
 
+// StackHidden returns the stack frames collapsed behind the visible ones, nil when all are visible.
 func (id *IssueDetails) StackHidden() []StackDetail {
-	if len(id.StackDetails) > 5 {
-		return id.StackDetails[5:]
+	if n := id.visibleFrames(); len(id.StackDetails) > n {
+		return id.StackDetails[n:]
 	}
 	return nil
 }
@@ -660,12 +1539,18 @@ func (t *FieldValueNum) PercentsFormatted() string {
 }
 
 type ProjectDetailsRequest struct {
-	Issues    IssuesType
-	Period    string
-	Start     string
-	End       string
+	Issues IssuesType
+	Period string
+	Start  string
+	End    string
+	// Levels are the level names of the listed issues, DefaultIssueLevels when empty.
+	Levels []string
+	// Cursor lists the page of all issues that follows it instead of the numbered page.
+	Cursor    string
 	ProjectID int
 	Page      int
+	// IncludeIgnored lists the ignored issues too, they are left out by default.
+	IncludeIgnored bool
 }
 
 type IssuesType string
@@ -683,7 +1568,26 @@ type ProjectDetails struct {
 	Period      string
 	Issues      IssuesType
 	Teammates   []Teammate
-	Page        int
+	// EventsByEnv are the hourly events of the period split by environment.
+	EventsByEnv EnvEventsList
+	// Levels are the level names the issues are listed for.
+	Levels []string
+	// Cursor is the cursor the page of all issues follows, empty for numbered pages.
+	Cursor string
+	// NextCursor lists the next page of all issues, empty on the last page.
+	NextCursor string
+	Page       int
+}
+
+// LevelsParam returns the levels formatted as the value of the "level" query parameter.
+func (pd *ProjectDetails) LevelsParam() string {
+	return strings.Join(pd.Levels, ",")
+}
+
+// HasLevelView reports whether the issues are listed for one of IssueLevelViews.
+func (pd *ProjectDetails) HasLevelView() bool {
+	levels := pd.LevelsParam()
+	return slices.ContainsFunc(IssueLevelViews, func(v LevelView) bool { return v.Levels == levels })
 }
 
 func (pd *ProjectDetails) AllLength() string {
@@ -717,7 +1621,7 @@ type CreateProjectRequest struct {
 	Platform     string `schema:"platform,required"    validate:"required,gt=0,lt=32"`
 	ProjectName  string `schema:"projectName,required" validate:"required,gt=0,lt=32"`
 	Threshold    int    `schema:"threshold,required"   validate:"required,gt=0,lt=1000000"`
-	Condition    int    `schema:"condition,required"   validate:"required,gt=0,lt=3"`
+	Condition    int    `schema:"condition,required"   validate:"required,gt=0,lt=5"`
 	Timeframe    int    `schema:"timeframe,required"   validate:"required,gt=0,lt=8"`
 	TeamID       int    `schema:"team,required"        validate:"required,gt=0"`
 	HighPriority bool   `schema:"highPriority"`
@@ -735,8 +1639,10 @@ type ProjectInfo struct {
 type Team struct {
 	CreatedAt time.Time
 	Name      string
-	ID        int
-	OwnerID   int
+	// Role is the role of the user the team was listed for.
+	Role    Role
+	ID      int
+	OwnerID int
 }
 
 // TeamStore encapsulates the team storage.
@@ -747,8 +1653,8 @@ type TeamStore interface {
 	ListTeams(ctx context.Context, userID int) ([]Team, error)
 	// ListTeammates returns a list of teammates for the given team.
 	ListTeammates(ctx context.Context, teamIDs []int) ([]Teammate, error)
-	// AddUserToTeam adds a user to a team.
-	AddUserToTeam(ctx context.Context, createdAt time.Time, userID int64, teamID int) error
+	// AddUserToTeam adds a user to a team with the given role.
+	AddUserToTeam(ctx context.Context, createdAt time.Time, userID int64, teamID int, role Role) error
 }
 
 // Platform represents the platform of the project.
@@ -766,6 +1672,17 @@ func (p Platform) String() string {
 	}
 }
 
+// VisibleFrames returns the number of stack frames shown before the rest are collapsed.
+// Rust backtraces carry more runtime and panic machinery frames above the application code.
+func (p Platform) VisibleFrames() int {
+	switch p {
+	case PlatformRust:
+		return 10
+	default:
+		return DefaultVisibleFrames
+	}
+}
+
 // PlatformByName returns the platform by name.
 func PlatformByName(name string) Platform {
 	switch name {
@@ -800,9 +1717,15 @@ func (e EventsList) DashboardData(now func() time.Time) string {
 	return e.DashboardDataForPeriod(now, "24h")
 }
 
-// DashboardDataForPeriod returns the data for frontend dashboard adapted to the given period.
-// Returns JSON array of [timestamps, counts] like: [[t1,t2,t3...], [c1,c2,c3...]].
-func (e EventsList) DashboardDataForPeriod(now func() time.Time, period string) string {
+// dashboardBuckets are the contiguous time buckets of a dashboard chart.
+type dashboardBuckets struct {
+	start    time.Time
+	interval time.Duration
+	n        int
+}
+
+// newDashboardBuckets splits the period ending now into buckets sized for the period.
+func newDashboardBuckets(now func() time.Time, period string) dashboardBuckets {
 	if period == "" {
 		period = "24h"
 	}
@@ -849,50 +1772,160 @@ func (e EventsList) DashboardDataForPeriod(now func() time.Time, period string)
 		startTime = startTime.Truncate(interval)
 	}
 
-	// Create map of events by timestamp for quick lookup (ClickHouse returns hourly data)
-	hourlyEventMap := make(map[int64]int, len(e))
-	for _, event := range e {
-		hourKey := event.TS.Truncate(time.Hour).Unix()
-		hourlyEventMap[hourKey] += event.Count
+	return dashboardBuckets{start: startTime, interval: interval, n: numPoints}
+}
+
+// timestamps returns the unix timestamps of the bucket starts.
+func (b dashboardBuckets) timestamps() []int64 {
+	timestamps := make([]int64, b.n)
+	for i := range b.n {
+		timestamps[i] = b.start.Add(time.Duration(i) * b.interval).Unix()
+	}
+	return timestamps
+}
+
+// index returns the bucket of the hour, reports false when the hour is out of the buckets.
+// Buckets are contiguous, so the bucket of an hour (ClickHouse returns hourly data)
+// is found by its offset from the start instead of scanning every bucket.
+func (b dashboardBuckets) index(ts time.Time) (int, bool) {
+	offset := ts.Truncate(time.Hour).Sub(b.start)
+	if offset < 0 {
+		return 0, false
 	}
+	i := int(offset / b.interval)
+	return i, i < b.n
+}
+
+// DashboardDataForPeriod returns the data for frontend dashboard adapted to the given period.
+// Returns JSON array of [timestamps, counts] like: [[t1,t2,t3...], [c1,c2,c3...]].
+func (e EventsList) DashboardDataForPeriod(now func() time.Time, period string) string {
+	buckets := newDashboardBuckets(now, period)
 
 	// Build arrays of timestamps and counts, aggregating hourly data into intervals
-	timestamps := make([]int64, numPoints)
-	counts := make([]int, numPoints)
-
-	for i := range numPoints {
-		bucketStart := startTime.Add(time.Duration(i) * interval)
-		bucketEnd := bucketStart.Add(interval)
-		timestamps[i] = bucketStart.Unix()
-
-		// Aggregate all hourly events that fall in this bucket
-		for hourTS, hourCount := range hourlyEventMap {
-			hourTime := time.Unix(hourTS, 0).UTC()
-			if (hourTime.Equal(bucketStart) || hourTime.After(bucketStart)) && hourTime.Before(bucketEnd) {
-				counts[i] += hourCount
-			}
+	timestamps := buckets.timestamps()
+	counts := make([]int, buckets.n)
+
+	for _, event := range e {
+		if i, ok := buckets.index(event.TS); ok {
+			counts[i] += event.Count
 		}
 	}
 
 	// Format as JSON: [[timestamps...], [counts...]]
-	var result strings.Builder
-	result.WriteString("[[")
+	// 10 digits of a unix timestamp and a few digits of a count per bucket, with separators.
+	result := make([]byte, 0, buckets.n*16+8)
+	result = append(result, "[["...)
 	for i, ts := range timestamps {
 		if i > 0 {
-			result.WriteString(",")
+			result = append(result, ',')
 		}
-		result.WriteString(strconv.FormatInt(ts, 10))
+		result = strconv.AppendInt(result, ts, 10)
 	}
-	result.WriteString("],[")
+	result = append(result, "],["...)
 	for i, count := range counts {
 		if i > 0 {
-			result.WriteString(",")
+			result = append(result, ',')
 		}
-		result.WriteString(strconv.Itoa(count))
+		result = strconv.AppendInt(result, int64(count), 10)
 	}
-	result.WriteString("]]")
+	result = append(result, "]]"...)
+
+	return string(result)
+}
 
-	return result.String()
+// EnvEventsList is the hourly number of events of a project split by environment.
+type EnvEventsList []EventsPerEnvHour
+
+// Hourly returns the number of events of every project within an hour summed over the environments,
+// in the order of the list.
+func (e EnvEventsList) Hourly() EventsList {
+	type projectHour struct {
+		ts        time.Time
+		projectID int
+	}
+	index := make(map[projectHour]int)
+	hourly := make(EventsList, 0, len(e))
+	for i := range e {
+		key := projectHour{ts: e[i].TS, projectID: e[i].ProjectID}
+		j, ok := index[key]
+		if !ok {
+			j = len(hourly)
+			index[key] = j
+			hourly = append(hourly, EventsPerHour{TS: e[i].TS, ProjectID: e[i].ProjectID})
+		}
+		hourly[j].Count += e[i].Count
+	}
+	return hourly
+}
+
+// EnvTotal is the number of events of an environment.
+type EnvTotal struct {
+	Env   string
+	Count int
+}
+
+// Label returns the environment name to display, events sent without an environment have none.
+func (t EnvTotal) Label() string {
+	if t.Env == "" {
+		return "none"
+	}
+	return t.Env
+}
+
+// Totals returns the number of events of every environment, the noisiest environment first.
+func (e EnvEventsList) Totals() []EnvTotal {
+	counts := make(map[string]int)
+	for i := range e {
+		counts[e[i].Env] += e[i].Count
+	}
+	totals := make([]EnvTotal, 0, len(counts))
+	for env, count := range counts {
+		totals = append(totals, EnvTotal{Env: env, Count: count})
+	}
+	slices.SortFunc(totals, func(a, b EnvTotal) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return strings.Compare(a.Env, b.Env)
+	})
+	return totals
+}
+
+// envDashboardData is the chart data of the events split by environment.
+type envDashboardData struct {
+	// Envs are the environments of the series, in the order of Totals.
+	Envs []string `json:"envs"`
+	// Data holds the bucket timestamps followed by the counts of every environment.
+	Data [][]int64 `json:"data"`
+}
+
+// DashboardDataForPeriod returns the data for frontend dashboard adapted to the given period,
+// bucketed as EventsList.DashboardDataForPeriod. Returns JSON object like:
+// {"envs":["production","staging"],"data":[[t1,t2...],[p1,p2...],[s1,s2...]]}.
+func (e EnvEventsList) DashboardDataForPeriod(now func() time.Time, period string) string {
+	buckets := newDashboardBuckets(now, period)
+	totals := e.Totals()
+
+	data := envDashboardData{Envs: make([]string, len(totals)), Data: make([][]int64, 0, len(totals)+1)}
+	data.Data = append(data.Data, buckets.timestamps())
+	series := make(map[string][]int64, len(totals))
+	for i, total := range totals {
+		data.Envs[i] = total.Env
+		series[total.Env] = make([]int64, buckets.n)
+		data.Data = append(data.Data, series[total.Env])
+	}
+
+	for _, event := range e {
+		if i, ok := buckets.index(event.TS); ok {
+			series[event.Env][i] += int64(event.Count)
+		}
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
 }
 
 // TotalErrors returns the total number of errors.
@@ -1,10 +1,13 @@
 package warnly_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
@@ -839,6 +842,65 @@ func TestIssueDetailsStackVisible(t *testing.T) {
 	}
 }
 
+func TestIssueDetailsStackCustomVisibleFrames(t *testing.T) {
+	t.Parallel()
+
+	stack := make([]warnly.StackDetail, 8)
+	for i := range stack {
+		stack[i] = warnly.StackDetail{Filepath: fmt.Sprintf("/app/frame%d.go", i), LineNo: i + 1}
+	}
+
+	tests := []struct {
+		name          string
+		visibleFrames int
+		wantVisible   int
+		wantHidden    int
+	}{
+		{name: "limit of 3", visibleFrames: 3, wantVisible: 3, wantHidden: 5},
+		{name: "limit of 10", visibleFrames: 10, wantVisible: 8, wantHidden: 0},
+		{name: "default limit", visibleFrames: 0, wantVisible: 5, wantHidden: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			details := &warnly.IssueDetails{StackDetails: stack, VisibleFrames: tt.visibleFrames}
+
+			visible := details.StackVisible()
+			hidden := details.StackHidden()
+			if len(visible) != tt.wantVisible {
+				t.Fatalf("StackVisible() len = %d, want %d", len(visible), tt.wantVisible)
+			}
+			if len(hidden) != tt.wantHidden {
+				t.Fatalf("StackHidden() len = %d, want %d", len(hidden), tt.wantHidden)
+			}
+			if tt.wantHidden == 0 && hidden != nil {
+				t.Errorf("StackHidden() = %v, want nil", hidden)
+			}
+			for i := range hidden {
+				if hidden[i].Filepath != stack[len(visible)+i].Filepath {
+					t.Errorf("StackHidden()[%d].Filepath = %s, want %s", i, hidden[i].Filepath, stack[len(visible)+i].Filepath)
+				}
+			}
+		})
+	}
+}
+
+func TestPlatformVisibleFrames(t *testing.T) {
+	t.Parallel()
+
+	if got := warnly.PlatformGolang.VisibleFrames(); got != 5 {
+		t.Errorf("PlatformGolang.VisibleFrames() = %d, want 5", got)
+	}
+	if got := warnly.PlatformRust.VisibleFrames(); got != 10 {
+		t.Errorf("PlatformRust.VisibleFrames() = %d, want 10", got)
+	}
+	if got := warnly.Platform(0).VisibleFrames(); got != warnly.DefaultVisibleFrames {
+		t.Errorf("Platform(0).VisibleFrames() = %d, want %d", got, warnly.DefaultVisibleFrames)
+	}
+}
+
 func TestIssueDetailsHasStackDetails(t *testing.T) {
 	t.Parallel()
 
@@ -1318,6 +1380,78 @@ func TestIssueDetailsContexts(t *testing.T) {
 	}
 }
 
+func TestIssueDetailsContextGroups(t *testing.T) {
+	t.Parallel()
+
+	// contexts flattened at ingestion of the sampled sentry-go event payload.
+	details := &warnly.IssueDetails{
+		LastEvent: &warnly.IssueEvent{
+			ContextsKey: []string{
+				"user.ip", "device.arch", "device.num_cpu",
+				"runtime.name", "runtime.version", "runtime.go_maxprocs", "runtime.go_numroutines",
+				"os.name", "extra.context", "extra.key", "extra.tag_key",
+			},
+			ContextsValue: []string{
+				"127.0.0.1", "arm64", "8",
+				"go", "go1.24.1", "8", "7",
+				"darwin", "example", "1", "bar",
+			},
+		},
+	}
+
+	want := []warnly.ContextGroup{
+		{Name: "device", Title: "Device", Fields: []warnly.ContextField{
+			{Key: "arch", Value: "arm64"},
+			{Key: "num_cpu", Value: "8"},
+		}},
+		{Name: "os", Title: "OS", Fields: []warnly.ContextField{
+			{Key: "name", Value: "darwin"},
+		}},
+		{Name: "runtime", Title: "Runtime", Fields: []warnly.ContextField{
+			{Key: "name", Value: "go"},
+			{Key: "version", Value: "go1.24.1"},
+			{Key: "go_maxprocs", Value: "8"},
+			{Key: "go_numroutines", Value: "7"},
+		}},
+		{Name: "user", Title: "User", Fields: []warnly.ContextField{
+			{Key: "ip", Value: "127.0.0.1"},
+		}},
+		{Name: "extra", Title: "Extra", Fields: []warnly.ContextField{
+			{Key: "context", Value: "example"},
+			{Key: "key", Value: "1"},
+			{Key: "tag_key", Value: "bar"},
+		}},
+	}
+
+	groups := details.ContextGroups()
+	require.Equal(t, want, groups)
+	require.Equal(t, "darwin", groups[1].Value("name"))
+	require.Empty(t, groups[1].Value("version"))
+}
+
+func TestIssueDetailsContextGroupsUnknown(t *testing.T) {
+	t.Parallel()
+
+	details := &warnly.IssueDetails{
+		LastEvent: &warnly.IssueEvent{
+			ContextsKey:   []string{"app_version", "trace.span_id", "os.name", "browser.name"},
+			ContextsValue: []string{"2.5.1", "e0a4b0f5e898d023", "linux", "firefox"},
+		},
+	}
+
+	groups := details.ContextGroups()
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	require.Equal(t, []string{"os", "browser", "trace", "other"}, names,
+		"well-known groups come first, then the rest alphabetically and keys without a prefix last")
+	require.Equal(t, "Browser", groups[1].Title)
+	require.Equal(t, []warnly.ContextField{{Key: "app_version", Value: "2.5.1"}}, groups[3].Fields)
+
+	require.Nil(t, (&warnly.IssueDetails{LastEvent: &warnly.IssueEvent{}}).ContextGroups())
+}
+
 func TestIssueDetailsTagKeyValue(t *testing.T) {
 	t.Parallel()
 
@@ -1592,6 +1726,18 @@ func TestParseTimeRange(t *testing.T) {
 	}
 }
 
+func TestUserFilter(t *testing.T) {
+	t.Parallel()
+
+	email, id := warnly.UserFilter("jane@example.com")
+	require.Equal(t, "jane@example.com", email)
+	require.Empty(t, id)
+
+	email, id = warnly.UserFilter("42")
+	require.Empty(t, email)
+	require.Equal(t, "42", id)
+}
+
 func TestParseQuery(t *testing.T) {
 	t.Parallel()
 
@@ -1652,6 +1798,36 @@ func TestParseQuery(t *testing.T) {
 				{Key: "tag", Operator: "is", Value: "value with spaces"},
 			},
 		},
+		{
+			name:  "context",
+			query: "os.name:darwin",
+			expected: []warnly.QueryToken{
+				{Key: "os.name", Operator: "is", Value: "darwin", IsContext: true},
+			},
+		},
+		{
+			name:  "extra and tag",
+			query: `extra.order_id:!42 os:darwin`,
+			expected: []warnly.QueryToken{
+				{Key: "extra.order_id", Operator: "is not", Value: "42", IsContext: true},
+				{Key: "os", Operator: "is", Value: "darwin"},
+			},
+		},
+		{
+			name:  "user",
+			query: `user:jane@example.com user.ip:10.0.0.1`,
+			expected: []warnly.QueryToken{
+				{Key: "user", Operator: "is", Value: "jane@example.com", IsUser: true},
+				{Key: "user.ip", Operator: "is", Value: "10.0.0.1", IsContext: true},
+			},
+		},
+		{
+			name:  "dotted tag",
+			query: `os.custom:linux`,
+			expected: []warnly.QueryToken{
+				{Key: "os.custom", Operator: "is", Value: "linux"},
+			},
+		},
 		{
 			name:  "mixed quotes",
 			query: `"text" key:value`,
@@ -1874,12 +2050,61 @@ func TestTimeAgo(t *testing.T) {
 			wantNarrow: "30sec",
 		},
 		{
-			name:       "1 second ago",
+			name:       "5 seconds ago",
+			t:          now.Add(-5 * time.Second),
+			narrow:     false,
+			wantWide:   "5 seconds",
+			wantNarrow: "5sec",
+		},
+		{
+			name:       "a few seconds ago reads just now",
+			t:          now.Add(-4 * time.Second),
+			narrow:     false,
+			wantWide:   "just now",
+			wantNarrow: "4sec",
+		},
+		{
+			name:       "1 second ago reads just now",
 			t:          now.Add(-time.Second),
 			narrow:     false,
-			wantWide:   "1 second",
+			wantWide:   "just now",
 			wantNarrow: "1sec",
 		},
+		{
+			name:       "just now",
+			t:          now.Add(-500 * time.Millisecond),
+			narrow:     false,
+			wantWide:   "just now",
+			wantNarrow: "0sec",
+		},
+		{
+			name:       "clock skew reads just now",
+			t:          now.Add(3 * time.Second),
+			narrow:     false,
+			wantWide:   "just now",
+			wantNarrow: "-3sec",
+		},
+		{
+			name:       "1 minute ago",
+			t:          now.Add(-time.Minute),
+			narrow:     false,
+			wantWide:   "1 minute",
+			wantNarrow: "1min",
+		},
+		{
+			name:       "1 hour ago",
+			t:          now.Add(-time.Hour),
+			narrow:     false,
+			wantWide:   "1 hour",
+			wantNarrow: "1h",
+		},
+		{
+			name:       "1 day ago",
+			t:          now.Add(-24 * time.Hour),
+			narrow:     false,
+			wantWide:   "1 day",
+			wantNarrow: "1d",
+		},
 		{
 			name:       "5 minutes ago",
 			t:          now.Add(-5 * time.Minute),
@@ -1922,6 +2147,20 @@ func TestTimeAgo(t *testing.T) {
 			wantWide:   "",
 			wantNarrow: "30sec",
 		},
+		{
+			name:       "just now (narrow)",
+			t:          now,
+			narrow:     true,
+			wantWide:   "",
+			wantNarrow: "0sec",
+		},
+		{
+			name:       "1 second ago (narrow)",
+			t:          now.Add(-time.Second),
+			narrow:     true,
+			wantWide:   "",
+			wantNarrow: "1sec",
+		},
 		{
 			name:       "5 minutes ago (narrow)",
 			t:          now.Add(-5 * time.Minute),
@@ -1946,6 +2185,78 @@ func TestTimeAgo(t *testing.T) {
 	}
 }
 
+func TestAgo(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	mockNow := func() time.Time { return now }
+
+	assert.Equal(t, "just now", warnly.Ago(mockNow, now))
+	assert.Equal(t, "just now", warnly.Ago(mockNow, now.Add(-3*time.Second)))
+	assert.Equal(t, "1 minute ago", warnly.Ago(mockNow, now.Add(-time.Minute)))
+	assert.Equal(t, "3 days ago", warnly.Ago(mockNow, now.Add(-72*time.Hour)))
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2025, 1, 15, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		loc  *time.Location
+		name string
+		want string
+	}{
+		{
+			name: "nil location falls back to UTC",
+			loc:  nil,
+			want: "Jan 15, 2025 23:30:00 UTC",
+		},
+		{
+			name: "fixed zone crosses the date boundary",
+			loc:  time.FixedZone("EET", 2*60*60),
+			want: "Jan 16, 2025 01:30:00 EET",
+		},
+		{
+			name: "negative offset",
+			loc:  time.FixedZone("EST", -5*60*60),
+			want: "Jan 15, 2025 18:30:00 EST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, warnly.FormatTimestamp(ts, tt.loc))
+		})
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, time.UTC, warnly.LoadLocation(""))
+	require.Equal(t, time.UTC, warnly.LoadLocation("Not/AZone"))
+
+	loc := warnly.LoadLocation("Asia/Tokyo")
+	require.Equal(t, "Asia/Tokyo", loc.String())
+}
+
+func TestIssueDetailsSeenFormatted(t *testing.T) {
+	t.Parallel()
+
+	details := &warnly.IssueDetails{
+		FirstSeen: time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC),
+		LastSeen:  time.Date(2025, 1, 15, 20, 15, 0, 0, time.UTC),
+	}
+	require.Equal(t, "Jan 10, 2025 08:00:00 UTC", details.FirstSeenFormatted())
+	require.Equal(t, "Jan 15, 2025 20:15:00 UTC", details.LastSeenFormatted())
+
+	details.Request = &warnly.GetIssueRequest{Location: time.FixedZone("JST", 9*60*60)}
+	require.Equal(t, "Jan 10, 2025 17:00:00 JST", details.FirstSeenFormatted())
+	require.Equal(t, "Jan 16, 2025 05:15:00 JST", details.LastSeenFormatted())
+}
+
 func TestListProjectsCriteriaIsEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -2201,6 +2512,89 @@ func TestEventListDashboardDataCallsPeriodWithDefault(t *testing.T) {
 	}
 }
 
+func TestEnvEventsListHourly(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	byEnv := warnly.EnvEventsList{
+		{TS: now.Add(-2 * time.Hour), ProjectID: 1, Env: "staging", Count: 5},
+		{TS: now.Add(-2 * time.Hour), ProjectID: 1, Env: "", Count: 1},
+		{TS: now.Add(-2 * time.Hour), ProjectID: 2, Env: "staging", Count: 4},
+		{TS: now.Add(-time.Hour), ProjectID: 1, Env: "staging", Count: 10},
+		{TS: now.Add(-time.Hour), ProjectID: 1, Env: "production", Count: 2},
+	}
+
+	assert.Equal(t, warnly.EventsList{
+		{TS: now.Add(-2 * time.Hour), ProjectID: 1, Count: 6},
+		{TS: now.Add(-2 * time.Hour), ProjectID: 2, Count: 4},
+		{TS: now.Add(-time.Hour), ProjectID: 1, Count: 12},
+	}, byEnv.Hourly(), "summed per project and hour, oldest hour first")
+	assert.Empty(t, warnly.EnvEventsList(nil).Hourly())
+}
+
+func TestEnvEventsListDashboardData(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	mockNow := func() time.Time { return now }
+
+	byEnv := warnly.EnvEventsList{
+		{TS: now.Add(-time.Hour), Env: "staging", Count: 10},
+		{TS: now.Add(-time.Hour), Env: "production", Count: 2},
+		{TS: now.Add(-2 * time.Hour), Env: "staging", Count: 5},
+		{TS: now.Add(-2 * time.Hour), Env: "", Count: 1},
+	}
+	total := make(warnly.EventsList, 0, len(byEnv))
+	for _, e := range byEnv {
+		total = append(total, warnly.EventsPerHour{TS: e.TS, Count: e.Count})
+	}
+
+	assert.Equal(t, []warnly.EnvTotal{
+		{Env: "staging", Count: 15},
+		{Env: "production", Count: 2},
+		{Env: "", Count: 1},
+	}, byEnv.Totals(), "the noisiest environment first")
+	assert.Equal(t, "none", byEnv.Totals()[2].Label())
+
+	var chart struct {
+		Envs []string  `json:"envs"`
+		Data [][]int64 `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(byEnv.DashboardDataForPeriod(mockNow, "24h")), &chart))
+	assert.Equal(t, []string{"staging", "production", ""}, chart.Envs)
+	require.Len(t, chart.Data, 4)
+
+	var overall [][]int64
+	require.NoError(t, json.Unmarshal([]byte(total.DashboardDataForPeriod(mockNow, "24h")), &overall))
+	assert.Equal(t, overall[0], chart.Data[0], "environments are bucketed as the overall chart")
+	sum := make([]int64, len(chart.Data[0]))
+	for _, series := range chart.Data[1:] {
+		for i, count := range series {
+			sum[i] += count
+		}
+	}
+	assert.Equal(t, overall[1], sum, "the environments add up to the overall chart")
+}
+
+func BenchmarkEventListDashboardDataForPeriod30Days(b *testing.B) {
+	now := time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)
+	mockNow := func() time.Time { return now }
+
+	// hourly counts of a busy project over the whole window.
+	events := make(warnly.EventsList, 0, 30*24)
+	for h := range 30 * 24 {
+		events = append(events, warnly.EventsPerHour{
+			TS:    now.Truncate(time.Hour).Add(-time.Duration(h) * time.Hour),
+			Count: h%17 + 1,
+		})
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = events.DashboardDataForPeriod(mockNow, "30d")
+	}
+}
+
 func TestEventListTotalErrors(t *testing.T) {
 	t.Parallel()
 
@@ -2405,3 +2799,51 @@ func TestEventListTotalErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestKeepSampledIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	const rate = 0.3
+
+	kept := 0
+	for i := range 1000 {
+		eventID := fmt.Sprintf("%032x", i)
+		first := warnly.KeepSampled(eventID, rate)
+		for range 5 {
+			require.Equal(t, first, warnly.KeepSampled(eventID, rate))
+		}
+		if first {
+			kept++
+		}
+	}
+
+	require.InDelta(t, 300, kept, 60)
+}
+
+func TestKeepSampledBounds(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, warnly.KeepSampled("c1a2b3", 1))
+	require.False(t, warnly.KeepSampled("c1a2b3", 0))
+}
+
+func TestValidateSampleRate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, warnly.ValidateSampleRate(0))
+	require.NoError(t, warnly.ValidateSampleRate(0.5))
+	require.NoError(t, warnly.ValidateSampleRate(1))
+	require.ErrorIs(t, warnly.ValidateSampleRate(-0.1), warnly.ErrInvalidSampleRate)
+	require.ErrorIs(t, warnly.ValidateSampleRate(1.1), warnly.ErrInvalidSampleRate)
+}
+
+func TestValidateDedupWindow(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, warnly.ValidateDedupWindow(0))
+	require.NoError(t, warnly.ValidateDedupWindow(5*time.Second))
+	require.NoError(t, warnly.ValidateDedupWindow(warnly.MaxDedupWindow))
+	require.ErrorIs(t, warnly.ValidateDedupWindow(-time.Second), warnly.ErrInvalidDedupWindow)
+	require.ErrorIs(t, warnly.ValidateDedupWindow(warnly.MaxDedupWindow+time.Second), warnly.ErrInvalidDedupWindow)
+	require.ErrorIs(t, warnly.ValidateDedupWindow(1500*time.Millisecond), warnly.ErrInvalidDedupWindow)
+}
@@ -0,0 +1,84 @@
+package warnly
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRegroupRunning is returned when a project's events are already being regrouped.
+var ErrRegroupRunning = errors.New("regroup is already running")
+
+// RegroupStatus is the state of a regroup job.
+type RegroupStatus string
+
+const (
+	// RegroupRunning means events are being regrouped.
+	RegroupRunning RegroupStatus = "running"
+	// RegroupDone means all stored events were regrouped.
+	RegroupDone RegroupStatus = "done"
+	// RegroupCanceled means the job was canceled, batches processed before stay regrouped.
+	RegroupCanceled RegroupStatus = "canceled"
+	// RegroupFailed means the job stopped on an error.
+	RegroupFailed RegroupStatus = "failed"
+)
+
+// RegroupService recomputes grouping of stored events after the grouping options of a project change.
+type RegroupService interface {
+	// StartRegroup starts regrouping the events of a project in the background.
+	StartRegroup(ctx context.Context, req *RegroupRequest) (*RegroupProgress, error)
+	// GetRegroup returns the progress of the last regroup job of a project.
+	GetRegroup(ctx context.Context, req *RegroupRequest) (*RegroupProgress, error)
+	// CancelRegroup cancels the running regroup job of a project.
+	CancelRegroup(ctx context.Context, req *RegroupRequest) error
+}
+
+// RegroupRequest is a request to manage the regroup job of a project.
+type RegroupRequest struct {
+	User      *User
+	ProjectID int
+}
+
+// RegroupProgress reports how far a regroup job got.
+type RegroupProgress struct {
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at,omitzero"`
+	Status     RegroupStatus `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	ProjectID  int           `json:"project_id"`
+	// Processed is the number of events read so far.
+	Processed int `json:"processed"`
+	// Regrouped is the number of events moved to another issue.
+	Regrouped int `json:"regrouped"`
+	// Skipped is the number of events stored without a payload that can't be regrouped.
+	Skipped int `json:"skipped"`
+	// NewIssues is the number of issues created for groups that didn't exist before.
+	NewIssues int `json:"new_issues"`
+	// DeletedIssues is the number of issues deleted because all of their events were moved to other issues.
+	DeletedIssues int `json:"deleted_issues"`
+}
+
+// RawEvent is a stored event with the payload it was sent with.
+type RawEvent struct {
+	CreatedAt time.Time
+	EventID   string
+	Raw       []byte
+	GroupID   uint64
+}
+
+// RawEventsCriteria selects a batch of stored events of a project ordered by creation time.
+// The batch starts after the event identified by AfterCreatedAt and AfterEventID, from the first
+// event when AfterEventID is empty.
+type RawEventsCriteria struct {
+	AfterCreatedAt time.Time
+	AfterEventID   string
+	ProjectID      int
+	Limit          int
+}
+
+// RegroupEventsCriteria moves events of a project to the groups of other issues.
+type RegroupEventsCriteria struct {
+	// Moves are the IDs of the moved events by the group they are moved to.
+	Moves     map[uint64][]string
+	ProjectID int
+}
@@ -0,0 +1,46 @@
+package warnly
+
+import "errors"
+
+// ErrPermissionDenied is returned when the role of the user in the team doesn't allow the action.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrInvalidRole is returned when the role isn't one of the known roles.
+var ErrInvalidRole = errors.New("invalid role")
+
+// Role is the role of a user in a team.
+type Role string
+
+// Roles of team members, from the least to the most privileged.
+const (
+	// RoleViewer can only browse the team projects and issues.
+	RoleViewer Role = "viewer"
+	// RoleMember can triage issues but can't delete projects or manage their secrets.
+	RoleMember Role = "member"
+	// RoleAdmin can do everything within the team.
+	RoleAdmin Role = "admin"
+)
+
+// rank returns the privilege level of the role, 0 for unknown roles.
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleMember:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Valid reports whether the role is one of the known roles.
+func (r Role) Valid() bool {
+	return r.rank() > 0
+}
+
+// Allows reports whether the role is at least as privileged as the required one.
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && r.rank() >= required.rank()
+}
@@ -0,0 +1,21 @@
+package warnly
+
+import (
+	"context"
+	"time"
+)
+
+// IssueView records the last time a user looked at an issue.
+type IssueView struct {
+	SeenAt  time.Time
+	IssueID int64
+	UserID  int64
+}
+
+// SeenStore encapsulates the methods to interact with database for issue views.
+type SeenStore interface {
+	// MarkSeen records that the user looked at the issue, viewing it again updates the time of the view.
+	MarkSeen(ctx context.Context, view *IssueView) error
+	// ListSeenBy returns the views of the issue, the most recent first.
+	ListSeenBy(ctx context.Context, issueID int64) ([]IssueView, error)
+}
@@ -0,0 +1,85 @@
+package warnly
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MaxSourceURLTemplateLen is the longest source URL template a project can configure.
+const MaxSourceURLTemplateLen = 255
+
+// DefaultSourceRef is the ref substituted into source links of events without a release.
+const DefaultSourceRef = "HEAD"
+
+// Placeholders substituted into a source URL template.
+const (
+	SourcePlaceholderRef  = "{ref}"
+	SourcePlaceholderPath = "{path}"
+	SourcePlaceholderLine = "{line}"
+)
+
+// ErrInvalidSourceURLTemplate is returned when a source URL template can't produce links to the repository.
+var ErrInvalidSourceURLTemplate = errors.New("invalid source url template")
+
+// SetSourceURLTemplateRequest is a request to change the template of links from stack frames to the repository.
+// An empty template disables the links.
+type SetSourceURLTemplateRequest struct {
+	User      *User
+	Template  string
+	ProjectID int
+}
+
+// ValidateSourceURLTemplate checks that the template is an http(s) URL with a {path} placeholder.
+// The empty template is valid and disables source links.
+func ValidateSourceURLTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	if len(template) > MaxSourceURLTemplateLen {
+		return fmt.Errorf("%w: at most %d characters are allowed", ErrInvalidSourceURLTemplate, MaxSourceURLTemplateLen)
+	}
+	if !strings.Contains(template, SourcePlaceholderPath) {
+		return fmt.Errorf("%w: %s placeholder is required", ErrInvalidSourceURLTemplate, SourcePlaceholderPath)
+	}
+	u, err := url.Parse(SourceLink(template, DefaultSourceRef, "path", 1))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSourceURLTemplate, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: an http or https url is required", ErrInvalidSourceURLTemplate)
+	}
+	return nil
+}
+
+// SourceLink substitutes the ref, the file path and the line into the template.
+// Leading slashes of the path are trimmed as the template already separates it from the ref.
+func SourceLink(template, ref, path string, line int) string {
+	return strings.NewReplacer(
+		SourcePlaceholderRef, ref,
+		SourcePlaceholderPath, strings.TrimLeft(path, "/"),
+		SourcePlaceholderLine, strconv.Itoa(line),
+	).Replace(template)
+}
+
+// SourceLink returns the link to the top in-app frame in the repository, with the release
+// of the last event as the ref. Empty when the project has no source URL template
+// or the stack has no in-app frames.
+func (id *IssueDetails) SourceLink() string {
+	if id.SourceURLTemplate == "" {
+		return ""
+	}
+	for i := range id.StackDetails {
+		if !id.StackDetails[i].InApp {
+			continue
+		}
+		ref := DefaultSourceRef
+		if id.LastEvent != nil && id.LastEvent.Release != "" {
+			ref = id.LastEvent.Release
+		}
+		return SourceLink(id.SourceURLTemplate, ref, id.StackDetails[i].Filepath, id.StackDetails[i].LineNo)
+	}
+	return ""
+}
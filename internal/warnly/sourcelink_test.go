@@ -0,0 +1,154 @@
+package warnly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+const githubTemplate = "https://github.com/org/repo/blob/{ref}/{path}#L{line}"
+
+func TestSourceLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		ref      string
+		path     string
+		line     int
+		want     string
+	}{
+		{
+			name:     "relative path",
+			template: githubTemplate,
+			ref:      "v1.4.0",
+			path:     "internal/billing/invoice.go",
+			line:     42,
+			want:     "https://github.com/org/repo/blob/v1.4.0/internal/billing/invoice.go#L42",
+		},
+		{
+			name:     "leading slash trimmed",
+			template: githubTemplate,
+			ref:      "v1.4.0",
+			path:     "/internal/billing/invoice.go",
+			line:     7,
+			want:     "https://github.com/org/repo/blob/v1.4.0/internal/billing/invoice.go#L7",
+		},
+		{
+			name:     "several leading slashes trimmed",
+			template: githubTemplate,
+			ref:      "main",
+			path:     "//src/main.rs",
+			line:     1,
+			want:     "https://github.com/org/repo/blob/main/src/main.rs#L1",
+		},
+		{
+			name:     "template without ref and line",
+			template: "https://git.example.com/repo/{path}",
+			ref:      "v2",
+			path:     "/cmd/app/main.go",
+			line:     3,
+			want:     "https://git.example.com/repo/cmd/app/main.go",
+		},
+		{
+			name:     "placeholders repeated",
+			template: "https://example.com/{ref}/{path}?ref={ref}&line={line}",
+			ref:      "abc123",
+			path:     "main.go",
+			line:     10,
+			want:     "https://example.com/abc123/main.go?ref=abc123&line=10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, warnly.SourceLink(tt.template, tt.ref, tt.path, tt.line))
+		})
+	}
+}
+
+func TestIssueDetailsSourceLink(t *testing.T) {
+	t.Parallel()
+
+	stack := []warnly.StackDetail{
+		{Filepath: "/usr/local/go/src/runtime/panic.go", LineNo: 770},
+		{Filepath: "/internal/billing/invoice.go", LineNo: 42, InApp: true},
+		{Filepath: "/cmd/app/main.go", LineNo: 12, InApp: true},
+	}
+
+	tests := []struct {
+		details *warnly.IssueDetails
+		name    string
+		want    string
+	}{
+		{
+			name: "top in-app frame at release",
+			details: &warnly.IssueDetails{
+				SourceURLTemplate: githubTemplate,
+				StackDetails:      stack,
+				LastEvent:         &warnly.IssueEvent{Release: "v1.4.0"},
+			},
+			want: "https://github.com/org/repo/blob/v1.4.0/internal/billing/invoice.go#L42",
+		},
+		{
+			name: "event without release",
+			details: &warnly.IssueDetails{
+				SourceURLTemplate: githubTemplate,
+				StackDetails:      stack,
+				LastEvent:         &warnly.IssueEvent{},
+			},
+			want: "https://github.com/org/repo/blob/HEAD/internal/billing/invoice.go#L42",
+		},
+		{
+			name: "no template",
+			details: &warnly.IssueDetails{
+				StackDetails: stack,
+				LastEvent:    &warnly.IssueEvent{Release: "v1.4.0"},
+			},
+			want: "",
+		},
+		{
+			name: "no in-app frame",
+			details: &warnly.IssueDetails{
+				SourceURLTemplate: githubTemplate,
+				StackDetails:      stack[:1],
+				LastEvent:         &warnly.IssueEvent{Release: "v1.4.0"},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, tt.details.SourceLink())
+		})
+	}
+}
+
+func TestValidateSourceURLTemplate(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{
+		"",
+		githubTemplate,
+		"http://git.internal/repo/{path}",
+	}
+	for _, template := range valid {
+		require.NoError(t, warnly.ValidateSourceURLTemplate(template), template)
+	}
+
+	invalid := []string{
+		"https://github.com/org/repo/blob/main/",
+		"ftp://example.com/{path}",
+		"javascript:alert(1)//{path}",
+		"/relative/{path}",
+		"https://example.com/" + string(make([]byte, warnly.MaxSourceURLTemplateLen)) + "{path}",
+	}
+	for _, template := range invalid {
+		require.ErrorIs(t, warnly.ValidateSourceURLTemplate(template), warnly.ErrInvalidSourceURLTemplate, template)
+	}
+}
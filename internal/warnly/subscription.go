@@ -0,0 +1,31 @@
+package warnly
+
+import (
+	"context"
+	"time"
+)
+
+// Subscription is a user watching an issue. Subscribers hear about new messages
+// and status changes of the issue whether they are mentioned or not.
+type Subscription struct {
+	CreatedAt time.Time `json:"created_at"`
+	IssueID   int64     `json:"issue_id"`
+	UserID    int64     `json:"user_id"`
+}
+
+// SubscriptionStore encapsulates the methods to interact with database for issue subscriptions.
+type SubscriptionStore interface {
+	// CreateSubscription subscribes the user to the issue, subscribing again keeps the existing subscription.
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	// DeleteSubscription unsubscribes the user from the issue.
+	DeleteSubscription(ctx context.Context, issueID, userID int64) error
+	// ListSubscribers returns identifiers of the users subscribed to the issue.
+	ListSubscribers(ctx context.Context, issueID int64) ([]int64, error)
+}
+
+// IssueSubscriptionRequest is a request to subscribe the user to an issue or to unsubscribe from it.
+type IssueSubscriptionRequest struct {
+	User      *User
+	ProjectID int
+	IssueID   int
+}
@@ -90,6 +90,12 @@ type SessionService interface {
 	// GetOrCreateUser creates a new user if it does not exist in the database
 	// or returns the existing user.
 	GetOrCreateUser(ctx context.Context, req *GetOrCreateUserRequest) (*Session, error)
+	// Invite invites a person to the team by email.
+	Invite(ctx context.Context, req *InviteRequest) (*InviteResult, error)
+	// GetInvitation returns the invitation the token was issued for if it can still be accepted.
+	GetInvitation(ctx context.Context, token string) (*Invitation, error)
+	// AcceptInvitation creates the invited user and adds them to the team.
+	AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*Session, error)
 }
 
 // GetOrCreateUserRequest represents a request to get or create a user.
@@ -351,33 +351,46 @@ func ProjectGrid(res *warnly.ListProjectsResult) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "</span> | Last 24 hours</p></a></div><div class=\"flex gap-1 flex-shrink-0\"><a hx-get=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "</span> | Users: ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var19 string
-			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/settings/projects/%d", project.ID))
+			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(warnly.NumFormatted(project.UniqueUsers))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/project.templ`, Line: 116, Col: 66}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/project.templ`, Line: 112, Col: 173}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "\" hx-target=\"#content\" hx-swap=\"outerHTML settle:0\" hx-push-url=\"true\" class=\"p-2 cursor-pointer hover:bg-gray-100 rounded-full\"><svg width=\"16\" height=\"16\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><circle cx=\"12\" cy=\"12\" r=\"3\"></circle> <path d=\"M19.4 15a1.65 1.65 0 0 0 .33 1.82l.06.06a2 2 0 0 1 0 2.83 2 2 0 0 1-2.83 0l-.06-.06a1.65 1.65 0 0 0-1.82-.33 1.65 1.65 0 0 0-1 1.51V21a2 2 0 0 1-2 2 2 2 0 0 1-2-2v-.09A1.65 1.65 0 0 0 9 19.4a1.65 1.65 0 0 0-1.82.33l-.06.06a2 2 0 0 1-2.83 0 2 2 0 0 1 0-2.83l.06-.06a1.65 1.65 0 0 0 .33-1.82 1.65 1.65 0 0 0-1.51-1H3a2 2 0 0 1-2-2 2 2 0 0 1 2-2h.09A1.65 1.65 0 0 0 4.6 9a1.65 1.65 0 0 0-.33-1.82l-.06-.06a2 2 0 0 1 0-2.83 2 2 0 0 1 2.83 0l.06.06a1.65 1.65 0 0 0 1.82.33H9a1.65 1.65 0 0 0 1-1.51V3a2 2 0 0 1 2-2 2 2 0 0 1 2 2v.09a1.65 1.65 0 0 0 1 1.51 1.65 1.65 0 0 0 1.82-.33l.06-.06a2 2 0 0 1 2.83 0 2 2 0 0 1 0 2.83l-.06.06a1.65 1.65 0 0 0-.33 1.82V9a1.65 1.65 0 0 0 1.51 1H21a2 2 0 0 1 2 2 2 2 0 0 1-2 2h-.09a1.65 1.65 0 0 0-1.51 1z\"></path></svg></a></div></div><div class=\"bg-gray-50\"></div><div class=\"warnly-project warnly-preview h-[200px]\" data-chart=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " | Last 24 hours</p></a></div><div class=\"flex gap-1 flex-shrink-0\"><a hx-get=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var20 string
-			templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(project.Events.DashboardData(time.Now))
+			templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/settings/projects/%d", project.ID))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/project.templ`, Line: 125, Col: 108}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/project.templ`, Line: 116, Col: 66}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "\"></div><div class=\"border-t border-gray-300 mt-10 p-4 grid grid-cols-2\"></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "\" hx-target=\"#content\" hx-swap=\"outerHTML settle:0\" hx-push-url=\"true\" class=\"p-2 cursor-pointer hover:bg-gray-100 rounded-full\"><svg width=\"16\" height=\"16\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><circle cx=\"12\" cy=\"12\" r=\"3\"></circle> <path d=\"M19.4 15a1.65 1.65 0 0 0 .33 1.82l.06.06a2 2 0 0 1 0 2.83 2 2 0 0 1-2.83 0l-.06-.06a1.65 1.65 0 0 0-1.82-.33 1.65 1.65 0 0 0-1 1.51V21a2 2 0 0 1-2 2 2 2 0 0 1-2-2v-.09A1.65 1.65 0 0 0 9 19.4a1.65 1.65 0 0 0-1.82.33l-.06.06a2 2 0 0 1-2.83 0 2 2 0 0 1 0-2.83l.06-.06a1.65 1.65 0 0 0 .33-1.82 1.65 1.65 0 0 0-1.51-1H3a2 2 0 0 1-2-2 2 2 0 0 1 2-2h.09A1.65 1.65 0 0 0 4.6 9a1.65 1.65 0 0 0-.33-1.82l-.06-.06a2 2 0 0 1 0-2.83 2 2 0 0 1 2.83 0l.06.06a1.65 1.65 0 0 0 1.82.33H9a1.65 1.65 0 0 0 1-1.51V3a2 2 0 0 1 2-2 2 2 0 0 1 2 2v.09a1.65 1.65 0 0 0 1 1.51 1.65 1.65 0 0 0 1.82-.33l.06-.06a2 2 0 0 1 2.83 0 2 2 0 0 1 0 2.83l-.06.06a1.65 1.65 0 0 0-.33 1.82V9a1.65 1.65 0 0 0 1.51 1H21a2 2 0 0 1 2 2 2 2 0 0 1-2 2h-.09a1.65 1.65 0 0 0-1.51 1z\"></path></svg></a></div></div><div class=\"bg-gray-50\"></div><div class=\"warnly-project warnly-preview h-[200px]\" data-chart=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var21 string
+			templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(project.Events.DashboardData(time.Now))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/project.templ`, Line: 125, Col: 108}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "\"></div><div class=\"border-t border-gray-300 mt-10 p-4 grid grid-cols-2\"></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -386,7 +399,7 @@ func ProjectGrid(res *warnly.ListProjectsResult) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "</div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -410,12 +423,12 @@ func chartInitialization() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var21 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var21 == nil {
-			templ_7745c5c3_Var21 = templ.NopComponent
+		templ_7745c5c3_Var22 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var22 == nil {
+			templ_7745c5c3_Var22 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "<script>\n\tfunction initializeCharts() {\n\t\tvar projects = document.querySelectorAll('.warnly-project');\n\t\tif (projects.length === 0) {\n\t\t\treturn;\n\t\t}\n\t\tprojects.forEach(project => {\n\t\t\tif (project._uPlot) {\n\t\t\t\tproject._uPlot.destroy();\n\t\t\t}\n\t\t\tif (project._resizeObserver) {\n\t\t\t\tproject._resizeObserver.disconnect();\n\t\t\t}\n\n\t\t\tlet chartData = JSON.parse(project.getAttribute('data-chart'));\n\n\t\t\tif (!Array.isArray(chartData) || chartData.length !== 2) {\n\t\t\t\tconsole.error(\"Invalid chart data format:\", chartData);\n\t\t\t\treturn;\n\t\t\t}\n\t\t\t\n\t\t\tconst data = chartData;\n\n\t\t\tconst opts = {\n\t\t\t\twidth: project.offsetWidth,\n\t\t\t\theight: project.offsetHeight,\n\t\t\t\tcursor: {\n\t\t\t\t\tdrag: {\n\t\t\t\t\t\tx: false,\n\t\t\t\t\t\ty: false,\n\t\t\t\t\t},\n\t\t\t\t},\n\t\t\t\tselect: {\n\t\t\t\t\tshow: false,\n\t\t\t\t},\n\t\t\t\tscales: {\n\t\t\t\t\tx: {},\n\t\t\t\t},\n\t\t\t\taxes: [\n\t\t\t\t\t{\n\t\t\t\t\t\tticks: { show: false },\n\t\t\t\t\t\tgrid: { show: false },\n\t\t\t\t\t},\n\t\t\t\t\t{\n\t\t\t\t\t\tgrid: { show: false },\n\t\t\t\t\t\tvalues: (u, vals) => vals.map(v => {\n\t\t\t\t\t\t\tif (v >= 1e6) return (v / 1e6).toFixed(0) + 'M';\n\t\t\t\t\t\t\tif (v >= 1e3) return (v / 1e3).toFixed(0) + 'k';\n\t\t\t\t\t\t\treturn v.toFixed(0);\n\t\t\t\t\t\t}),\n\t\t\t\t\t},\n\t\t\t\t],\n\t\t\t\tseries: [\n\t\t\t\t\t{},\n\t\t\t\t\t{\n\t\t\t\t\t\tlabel: \"Errors\",\n\t\t\t\t\t\tpaths: uPlot.paths.bars({size: [0.8, 100]}),\n\t\t\t\t\t\tstroke: \"black\",\n\t\t\t\t\t\tfill: \"rgba(0,0,0,0.5)\",\n\t\t\t\t\t\twidth: 1,\n\t\t\t\t\t\tpoints: {\n\t\t\t\t\t\t\tshow: false\n\t\t\t\t\t\t},\n\t\t\t\t\t\tvalue: (u, v) => {\n\t\t\t\t\t\t\tif (v == null) return \"-\";\n\t\t\t\t\t\t\tif (v >= 1e6) return (v / 1e6).toFixed(0) + 'M';\n\t\t\t\t\t\t\tif (v >= 1e3) return (v / 1e3).toFixed(0) + 'k';\n\t\t\t\t\t\t\treturn v.toFixed(0);\n\t\t\t\t\t\t},\n\t\t\t\t\t},\n\t\t\t\t],\n\t\t\t};\n\n\t\t\tlet u = new uPlot(opts, data, project);\n\n\t\t\tconst resizeObserver = new ResizeObserver(() => {\n\t\t\t\tu.setSize({\n\t\t\t\t\twidth: project.offsetWidth,\n\t\t\t\t\theight: project.offsetHeight,\n\t\t\t\t});\n\t\t\t});\n\t\t\tresizeObserver.observe(project);\n\n\t\t\tproject._uPlot = u;\n\t\t\tproject._resizeObserver = resizeObserver;\n\t\t});\n\t}\n\ninitializeCharts();\n\n</script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "<script>\n\tfunction initializeCharts() {\n\t\tvar projects = document.querySelectorAll('.warnly-project');\n\t\tif (projects.length === 0) {\n\t\t\treturn;\n\t\t}\n\t\tprojects.forEach(project => {\n\t\t\tif (project._uPlot) {\n\t\t\t\tproject._uPlot.destroy();\n\t\t\t}\n\t\t\tif (project._resizeObserver) {\n\t\t\t\tproject._resizeObserver.disconnect();\n\t\t\t}\n\n\t\t\tlet chartData = JSON.parse(project.getAttribute('data-chart'));\n\n\t\t\tif (!Array.isArray(chartData) || chartData.length !== 2) {\n\t\t\t\tconsole.error(\"Invalid chart data format:\", chartData);\n\t\t\t\treturn;\n\t\t\t}\n\t\t\t\n\t\t\tconst data = chartData;\n\n\t\t\tconst opts = {\n\t\t\t\twidth: project.offsetWidth,\n\t\t\t\theight: project.offsetHeight,\n\t\t\t\tcursor: {\n\t\t\t\t\tdrag: {\n\t\t\t\t\t\tx: false,\n\t\t\t\t\t\ty: false,\n\t\t\t\t\t},\n\t\t\t\t},\n\t\t\t\tselect: {\n\t\t\t\t\tshow: false,\n\t\t\t\t},\n\t\t\t\tscales: {\n\t\t\t\t\tx: {},\n\t\t\t\t},\n\t\t\t\taxes: [\n\t\t\t\t\t{\n\t\t\t\t\t\tticks: { show: false },\n\t\t\t\t\t\tgrid: { show: false },\n\t\t\t\t\t},\n\t\t\t\t\t{\n\t\t\t\t\t\tgrid: { show: false },\n\t\t\t\t\t\tvalues: (u, vals) => vals.map(v => {\n\t\t\t\t\t\t\tif (v >= 1e6) return (v / 1e6).toFixed(0) + 'M';\n\t\t\t\t\t\t\tif (v >= 1e3) return (v / 1e3).toFixed(0) + 'k';\n\t\t\t\t\t\t\treturn v.toFixed(0);\n\t\t\t\t\t\t}),\n\t\t\t\t\t},\n\t\t\t\t],\n\t\t\t\tseries: [\n\t\t\t\t\t{},\n\t\t\t\t\t{\n\t\t\t\t\t\tlabel: \"Errors\",\n\t\t\t\t\t\tpaths: uPlot.paths.bars({size: [0.8, 100]}),\n\t\t\t\t\t\tstroke: \"black\",\n\t\t\t\t\t\tfill: \"rgba(0,0,0,0.5)\",\n\t\t\t\t\t\twidth: 1,\n\t\t\t\t\t\tpoints: {\n\t\t\t\t\t\t\tshow: false\n\t\t\t\t\t\t},\n\t\t\t\t\t\tvalue: (u, v) => {\n\t\t\t\t\t\t\tif (v == null) return \"-\";\n\t\t\t\t\t\t\tif (v >= 1e6) return (v / 1e6).toFixed(0) + 'M';\n\t\t\t\t\t\t\tif (v >= 1e3) return (v / 1e3).toFixed(0) + 'k';\n\t\t\t\t\t\t\treturn v.toFixed(0);\n\t\t\t\t\t\t},\n\t\t\t\t\t},\n\t\t\t\t],\n\t\t\t};\n\n\t\t\tlet u = new uPlot(opts, data, project);\n\n\t\t\tconst resizeObserver = new ResizeObserver(() => {\n\t\t\t\tu.setSize({\n\t\t\t\t\twidth: project.offsetWidth,\n\t\t\t\t\theight: project.offsetHeight,\n\t\t\t\t});\n\t\t\t});\n\t\t\tresizeObserver.observe(project);\n\n\t\t\tproject._uPlot = u;\n\t\t\tproject._resizeObserver = resizeObserver;\n\t\t});\n\t}\n\ninitializeCharts();\n\n</script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -1,15 +1,18 @@
-// Package worker provides background workers for processing alerts.
+// Package worker provides background workers for processing alerts and housekeeping.
 package worker
 
 import (
 	"context"
-	"errors"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"sync"
 	"time"
 
-	"github.com/vk-rv/warnly/internal/notifier"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/vk-rv/warnly/internal/warnly"
 )
 
@@ -21,34 +24,43 @@ type AlertWorker struct {
 	notificationStore warnly.NotificationStore
 	stopCh            chan struct{}
 	logger            *slog.Logger
-	webhookNotifier   *notifier.WebhookNotifier
+	metrics           *alertWorkerMetrics
+	dispatcher        warnly.AlertDispatcher
 	now               func() time.Time
+	startedAt         time.Time
 	instanceID        string
 	interval          time.Duration
 	lockDuration      time.Duration
+	warmUp            time.Duration
 	mu                sync.Mutex
 	running           bool
 }
 
 // NewAlertWorker creates a new alert worker.
+// During warmUp after creation the worker leaves alert states as they are and doesn't notify,
+// so spikes that happened while the instance was down don't cause an alert storm on rollout.
+// A condition that still holds after warmUp triggers the alert with a notification.
 func NewAlertWorker(
 	alertStore warnly.AlertStore,
 	analyticsStore warnly.AnalyticsStore,
 	issueStore warnly.IssueStore,
 	notificationStore warnly.NotificationStore,
-	webhookNotifier *notifier.WebhookNotifier,
+	dispatcher warnly.AlertDispatcher,
 	now func() time.Time,
 	interval time.Duration,
+	warmUp time.Duration,
 	instanceID string,
 	logger *slog.Logger,
 ) *AlertWorker {
 	return &AlertWorker{
 		now:               now,
+		startedAt:         now().UTC(),
+		warmUp:            warmUp,
 		alertStore:        alertStore,
 		analyticsStore:    analyticsStore,
 		issueStore:        issueStore,
 		notificationStore: notificationStore,
-		webhookNotifier:   webhookNotifier,
+		dispatcher:        dispatcher,
 		logger:            logger,
 		interval:          interval,
 		instanceID:        instanceID,
@@ -57,6 +69,38 @@ func NewAlertWorker(
 	}
 }
 
+// alertWorkerMetrics are the Prometheus metrics of the alert evaluations.
+type alertWorkerMetrics struct {
+	evaluations        prometheus.Counter
+	fired              *prometheus.CounterVec
+	errors             prometheus.Counter
+	evaluationDuration prometheus.Histogram
+}
+
+// RegisterMetrics counts the alert evaluations, the fired alerts labeled by project and the worker errors,
+// and records the duration of the evaluations, metrics aren't recorded until it's called.
+func (w *AlertWorker) RegisterMetrics(r prometheus.Registerer) {
+	w.metrics = &alertWorkerMetrics{
+		evaluations: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "warnly_alert_evaluations_total",
+			Help: "Total number of alert rule evaluations.",
+		}),
+		fired: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Name: "warnly_alerts_fired_total",
+			Help: "Total number of alerts that transitioned to the triggered state.",
+		}, []string{"project"}),
+		errors: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "warnly_alert_worker_errors_total",
+			Help: "Total number of errors of the alert worker.",
+		}),
+		evaluationDuration: promauto.With(r).NewHistogram(prometheus.HistogramOpts{
+			Name:    "warnly_alert_evaluation_duration_seconds",
+			Help:    "Duration of alert rule evaluations in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
 // Start begins processing alerts in the background.
 func (w *AlertWorker) Start(ctx context.Context) {
 	w.mu.Lock()
@@ -105,12 +149,14 @@ func (w *AlertWorker) Stop() {
 func (w *AlertWorker) processAlerts(ctx context.Context) {
 	if err := w.notificationStore.CleanupExpiredLocks(ctx, w.now().UTC()); err != nil {
 		w.logger.Error("process alerts: failed to cleanup expired locks", slog.Any("error", err))
+		w.countError()
 		return
 	}
 
 	alerts, _, err := w.alertStore.ListAlerts(ctx, []int{}, "", 0, 1000)
 	if err != nil {
 		w.logger.Error("process alerts: failed to list alerts", slog.Any("error", err))
+		w.countError()
 		return
 	}
 
@@ -132,6 +178,7 @@ func (w *AlertWorker) processAlerts(ctx context.Context) {
 				slog.String("alert_name", alertsToProcess[i].RuleName),
 				slog.Any("error", err),
 			)
+			w.countError()
 		}
 	}
 }
@@ -167,7 +214,21 @@ func (w *AlertWorker) checkAlert(ctx context.Context, alert *warnly.Alert) error
 		}
 	}()
 
+	if err := w.observeEvaluation(func() error { return w.evaluate(ctx, alert, now) }); err != nil {
+		return err
+	}
+
+	return w.escalate(ctx, alert, now)
+}
+
+// evaluate checks the alert condition and triggers or resolves the alert when it changed.
+func (w *AlertWorker) evaluate(ctx context.Context, alert *warnly.Alert, now time.Time) error {
 	timeframe := alert.GetTimeframeDuration()
+
+	if alert.Condition == warnly.AlertConditionSpike {
+		return w.checkSpike(ctx, alert, timeframe, now)
+	}
+
 	from := now.Add(-timeframe)
 
 	issues, err := w.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
@@ -180,10 +241,7 @@ func (w *AlertWorker) checkAlert(ctx context.Context, alert *warnly.Alert) error
 	}
 
 	if len(issues) == 0 {
-		if alert.Status == warnly.AlertStatusTriggered {
-			return w.resolveAlert(ctx, alert, now)
-		}
-		return nil
+		return w.transition(ctx, alert, false, now)
 	}
 
 	issueIDs := make([]int64, len(issues))
@@ -203,44 +261,130 @@ func (w *AlertWorker) checkAlert(ctx context.Context, alert *warnly.Alert) error
 		return err
 	}
 
-	triggered := false
+	return w.transition(ctx, alert, conditionMet(alert, issues, metrics, from), now)
+}
+
+// checkSpike compares the project events in the timeframe against the rolling baseline.
+func (w *AlertWorker) checkSpike(ctx context.Context, alert *warnly.Alert, timeframe time.Duration, now time.Time) error {
+	baseline, err := w.analyticsStore.RollingBaseline(ctx, &warnly.RollingBaselineCriteria{
+		ProjectID: alert.ProjectID,
+		To:        now,
+		Window:    timeframe,
+		Windows:   alert.BaselineWindows,
+	})
+	if err != nil {
+		return fmt.Errorf("check spike: %w", err)
+	}
+
+	return w.transition(ctx, alert, baseline.IsSpike(alert.SpikeMultiplier), now)
+}
+
+// transition triggers or resolves the alert when its condition changed.
+// While the worker warms up the state is kept, so that each state change is notified.
+func (w *AlertWorker) transition(ctx context.Context, alert *warnly.Alert, triggered bool, now time.Time) error {
+	changed := (triggered && alert.Status == warnly.AlertStatusActive) ||
+		(!triggered && alert.Status == warnly.AlertStatusTriggered)
+	if !changed {
+		return nil
+	}
+
+	if w.warmingUp() {
+		w.logger.Info("alert worker is warming up, state change postponed",
+			slog.Int("alert_id", alert.ID),
+			slog.Bool("triggered", triggered),
+		)
+		return nil
+	}
+
+	if triggered {
+		return w.triggerAlert(ctx, alert, now)
+	}
+
+	return w.resolveAlert(ctx, alert, now)
+}
+
+// conditionMet reports whether the issues seen within the alert timeframe satisfy the alert condition.
+func conditionMet(alert *warnly.Alert, issues []warnly.Issue, metrics []warnly.IssueMetrics, from time.Time) bool {
+	switch alert.Condition {
+	case warnly.AlertConditionUsers:
+		return exceedsThreshold(metrics, alert.Threshold, func(m *warnly.IssueMetrics) uint64 { return m.UserCount })
+	case warnly.AlertConditionNewIssue:
+		return hasNewIssue(issues, metrics, from)
+	case warnly.AlertConditionReoccurred:
+		return hasReoccurredIssue(issues, metrics)
+	default:
+		return exceedsThreshold(metrics, alert.Threshold, func(m *warnly.IssueMetrics) uint64 { return m.TimesSeen })
+	}
+}
+
+// exceedsThreshold reports whether any issue has the metric value above the threshold.
+func exceedsThreshold(metrics []warnly.IssueMetrics, threshold int, value func(m *warnly.IssueMetrics) uint64) bool {
 	for i := range metrics {
-		var value uint64
-		switch alert.Condition {
-		case warnly.AlertConditionOccurrences:
-			value = metrics[i].TimesSeen
-		case warnly.AlertConditionUsers:
-			value = metrics[i].UserCount
-		default:
-			value = metrics[i].TimesSeen
+		if value(&metrics[i]) > uint64(threshold) {
+			return true
 		}
+	}
+	return false
+}
 
-		if value > uint64(alert.Threshold) {
-			triggered = true
-			break
+// hasNewIssue reports whether an issue was first seen within the timeframe.
+func hasNewIssue(issues []warnly.Issue, metrics []warnly.IssueMetrics, from time.Time) bool {
+	for i := range issues {
+		if issues[i].FirstSeen.Before(from) {
+			continue
+		}
+		if m, ok := warnly.GetMetrics(metrics, issues[i].ID); ok && m.TimesSeen > 0 {
+			return true
 		}
 	}
+	return false
+}
 
-	if triggered && alert.Status == warnly.AlertStatusActive {
-		return w.triggerAlert(ctx, alert, now)
-	} else if !triggered && alert.Status == warnly.AlertStatusTriggered {
-		return w.resolveAlert(ctx, alert, now)
+// hasReoccurredIssue reports whether a resolved issue received events after it was resolved.
+func hasReoccurredIssue(issues []warnly.Issue, metrics []warnly.IssueMetrics) bool {
+	for i := range issues {
+		if !issues[i].IsResolved() || issues[i].ResolvedAt == nil {
+			continue
+		}
+		if m, ok := warnly.GetMetrics(metrics, issues[i].ID); ok && m.LastSeen.After(*issues[i].ResolvedAt) {
+			return true
+		}
 	}
-
-	return nil
+	return false
 }
 
 // triggerAlert transitions an alert to triggered state and sends notification.
+// An escalating alert gets a new ack token and starts its escalation chain over.
 func (w *AlertWorker) triggerAlert(ctx context.Context, alert *warnly.Alert, now time.Time) error {
 	alert.Status = warnly.AlertStatusTriggered
 	alert.UpdatedAt = now
 	alert.LastTriggeredAt = &now
+	alert.AckToken = ""
+	alert.AcknowledgedAt = nil
+	alert.EscalationLevel = 0
+	alert.EscalatedAt = nil
+
+	if alert.Escalates() {
+		token, err := newAckToken()
+		if err != nil {
+			return fmt.Errorf("trigger alert: %w", err)
+		}
+		alert.AckToken = token
+	}
+
+	// The alert is stored after the dispatch, so the time of the notification
+	// the cooldown is counted from is kept along with the new state.
+	notifyErr := w.sendNotifications(ctx, alert, warnly.AlertNotificationTriggered)
 
 	if err := w.alertStore.UpdateAlert(ctx, alert); err != nil {
 		return fmt.Errorf("update alert status: %w", err)
 	}
 
-	return w.sendNotifications(ctx, alert, warnly.AlertNotificationTriggered)
+	if w.metrics != nil {
+		w.metrics.fired.WithLabelValues(strconv.Itoa(alert.ProjectID)).Inc()
+	}
+
+	return notifyErr
 }
 
 // resolveAlert transitions an alert to resolved state and sends notification.
@@ -257,6 +401,51 @@ func (w *AlertWorker) resolveAlert(ctx context.Context, alert *warnly.Alert, now
 	return w.sendNotifications(ctx, alert, warnly.AlertNotificationResolved)
 }
 
+// escalate notifies the next channel in the escalation chain of a triggered alert
+// nobody acknowledged within the escalation window.
+func (w *AlertWorker) escalate(ctx context.Context, alert *warnly.Alert, now time.Time) error {
+	channelID, due := alert.NextEscalation(now)
+	if !due {
+		return nil
+	}
+
+	if w.warmingUp() {
+		w.logger.Info("alert worker is warming up, escalation postponed",
+			slog.Int("alert_id", alert.ID),
+			slog.Int("channel_id", channelID),
+		)
+		return nil
+	}
+
+	// The chain advances before the channel is notified, so a channel
+	// that can't be reached doesn't hold up the rest of the chain.
+	alert.EscalationLevel++
+	alert.EscalatedAt = &now
+	alert.UpdatedAt = now
+
+	if err := w.alertStore.UpdateAlert(ctx, alert); err != nil {
+		return fmt.Errorf("escalate alert: %w", err)
+	}
+
+	channel, err := w.notificationStore.GetNotificationChannel(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("escalate alert: get notification channel %d: %w", channelID, err)
+	}
+
+	// The chain may outlive a channel, a channel that was moved or disabled is skipped.
+	if channel.TeamID != alert.TeamID || !channel.Enabled {
+		w.logger.Warn("escalation channel is unavailable, skipped",
+			slog.Int("alert_id", alert.ID),
+			slog.Int("channel_id", channelID),
+		)
+		return nil
+	}
+
+	w.dispatch(ctx, alert, []warnly.NotificationChannel{*channel}, warnly.AlertNotificationEscalated)
+
+	return nil
+}
+
 // sendNotifications sends notifications to all enabled channels for the team.
 func (w *AlertWorker) sendNotifications(
 	ctx context.Context,
@@ -268,80 +457,60 @@ func (w *AlertWorker) sendNotifications(
 		return fmt.Errorf("list notification channels: %w", err)
 	}
 
-	for i := range channels {
-		if !channels[i].Enabled {
-			continue
-		}
-
-		notification := &warnly.AlertNotification{
-			CreatedAt:        w.now().UTC(),
-			AlertID:          alert.ID,
-			ChannelID:        channels[i].ID,
-			NotificationType: notificationType,
-			Status:           warnly.AlertNotificationPending,
-		}
-
-		if err := w.notificationStore.CreateAlertNotification(ctx, notification); err != nil {
-			w.logger.Error("failed to create notification record",
-				slog.Int("alert_id", alert.ID),
-				slog.Int("channel_id", channels[i].ID),
-				slog.Any("error", err),
-			)
-			continue
-		}
-
-		if err := w.sendNotification(ctx, alert, &channels[i], notificationType, notification); err != nil {
-			w.logger.Error("failed to send notification",
-				slog.Int("alert_id", alert.ID),
-				slog.Int("channel_id", channels[i].ID),
-				slog.String("channel_type", string(channels[i].ChannelType)),
-				slog.Any("error", err),
-			)
-
-			notification.Status = warnly.AlertNotificationFailed
-			notification.ErrorMessage = err.Error()
-		} else {
-			notification.Status = warnly.AlertNotificationSent
-			now := w.now().UTC()
-			notification.SentAt = &now
-		}
-
-		if err := w.notificationStore.UpdateAlertNotification(ctx, notification); err != nil {
-			w.logger.Error("failed to update notification record",
-				slog.Int64("notification_id", notification.ID),
-				slog.Any("error", err),
-			)
-		}
-	}
+	w.dispatch(ctx, alert, channels, notificationType)
 
 	return nil
 }
 
-// sendNotification sends a notification to a specific channel.
-func (w *AlertWorker) sendNotification(
+// dispatch sends the notification to the channels, a failure of a single channel is logged
+// as it doesn't affect the alert state.
+func (w *AlertWorker) dispatch(
 	ctx context.Context,
 	alert *warnly.Alert,
-	channel *warnly.NotificationChannel,
+	channels []warnly.NotificationChannel,
 	notificationType warnly.AlertNotificationType,
-	_ *warnly.AlertNotification,
-) error {
-	switch channel.ChannelType {
-	case warnly.NotificationChannelWebhook:
-		config, err := w.notificationStore.GetWebhookConfig(ctx, channel.ID)
-		if err != nil {
-			return fmt.Errorf("get webhook config: %w", err)
-		}
+) {
+	if err := w.dispatcher.Dispatch(ctx, alert, channels, notificationType); err != nil {
+		w.logger.Error("failed to send notification",
+			slog.Int("alert_id", alert.ID),
+			slog.String("notification_type", string(notificationType)),
+			slog.Any("error", err),
+		)
+	}
+}
 
-		if config.VerifiedAt == nil {
-			return errors.New("webhook not verified")
-		}
+// observeEvaluation runs the evaluation of an alert, counting it and recording its duration
+// once metrics are registered.
+func (w *AlertWorker) observeEvaluation(evaluate func() error) error {
+	if w.metrics == nil {
+		return evaluate()
+	}
 
-		if notificationType == warnly.AlertNotificationTriggered {
-			return w.webhookNotifier.SendAlertTriggered(ctx, alert, config)
-		}
-		return w.webhookNotifier.SendAlertResolved(ctx, alert, config)
+	start := time.Now()
+	err := evaluate()
+	w.metrics.evaluations.Inc()
+	w.metrics.evaluationDuration.Observe(time.Since(start).Seconds())
 
-	default:
-		return fmt.Errorf("unsupported channel type: %s", channel.ChannelType)
+	return err
+}
+
+// countError counts an error of the worker once metrics are registered.
+func (w *AlertWorker) countError() {
+	if w.metrics != nil {
+		w.metrics.errors.Inc()
+	}
+}
+
+// warmingUp reports whether the worker is still within the warm-up period after startup.
+func (w *AlertWorker) warmingUp() bool {
+	return w.now().UTC().Before(w.startedAt.Add(w.warmUp))
+}
+
+// newAckToken generates a token acknowledging a trigger of an alert.
+func newAckToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate ack token: %w", err)
 	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,552 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+type alertDispatcherFunc func(ctx context.Context, alert *warnly.Alert, channels []warnly.NotificationChannel,
+	notificationType warnly.AlertNotificationType) error
+
+func (f alertDispatcherFunc) Dispatch(
+	ctx context.Context,
+	alert *warnly.Alert,
+	channels []warnly.NotificationChannel,
+	notificationType warnly.AlertNotificationType,
+) error {
+	return f(ctx, alert, channels, notificationType)
+}
+
+// alertRun captures what the worker did with the alert during a single pass.
+type alertRun struct {
+	lastUpdate       *warnly.Alert
+	updated          []warnly.AlertStatus
+	notified         []warnly.AlertNotificationType
+	escalatedTo      []int
+	criteria         *warnly.ListIssueMetricsCriteria
+	baselineCriteria *warnly.RollingBaselineCriteria
+}
+
+// runAlert evaluates the alert once against the issues, the metrics
+// and the rolling baseline the analytics store returns.
+func runAlert(
+	t *testing.T,
+	now time.Time,
+	alert warnly.Alert,
+	issues []warnly.Issue,
+	metrics []warnly.IssueMetrics,
+	baseline *warnly.RollingBaseline,
+) *alertRun {
+	t.Helper()
+
+	run := &alertRun{}
+	w := newTestWorker(t, run, func() time.Time { return now }, 0,
+		func() warnly.Alert { return alert }, issues, metrics, baseline)
+	w.processAlerts(t.Context())
+
+	return run
+}
+
+// newTestWorker creates a worker evaluating the alert returned by alertFn
+// and recording what it did into run.
+func newTestWorker(
+	t *testing.T,
+	run *alertRun,
+	now func() time.Time,
+	warmUp time.Duration,
+	alertFn func() warnly.Alert,
+	issues []warnly.Issue,
+	metrics []warnly.IssueMetrics,
+	baseline *warnly.RollingBaseline,
+) *AlertWorker {
+	t.Helper()
+
+	alertStore := &mock.AlertStore{
+		ListAlertsFn: func(context.Context, []int, string, int, int) ([]warnly.Alert, int, error) {
+			return []warnly.Alert{alertFn()}, 1, nil
+		},
+		UpdateAlertFn: func(_ context.Context, a *warnly.Alert) error {
+			run.updated = append(run.updated, a.Status)
+			updated := *a
+			run.lastUpdate = &updated
+			return nil
+		},
+	}
+	issueStore := &mock.IssueStore{
+		ListIssuesFn: func(context.Context, *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			return issues, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		ListIssueMetricsFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+			run.criteria = c
+			return metrics, nil
+		},
+		RollingBaselineFn: func(_ context.Context, c *warnly.RollingBaselineCriteria) (*warnly.RollingBaseline, error) {
+			run.baselineCriteria = c
+			return baseline, nil
+		},
+	}
+	notificationStore := &mock.NotificationStore{
+		CleanupExpiredLocksFn: func(context.Context, time.Time) error { return nil },
+		AcquireAlertLockFn:    func(context.Context, *warnly.AlertLock) (bool, error) { return true, nil },
+		ReleaseAlertLockFn:    func(context.Context, int, string) error { return nil },
+		ListNotificationChannelsFn: func(context.Context, int) ([]warnly.NotificationChannel, error) {
+			return []warnly.NotificationChannel{
+				{ID: 1, ChannelType: warnly.NotificationChannelWebhook, Enabled: true},
+			}, nil
+		},
+		GetNotificationChannelFn: func(_ context.Context, channelID int) (*warnly.NotificationChannel, error) {
+			return &warnly.NotificationChannel{
+				ID: channelID, TeamID: 3, ChannelType: warnly.NotificationChannelWebhook, Enabled: true,
+			}, nil
+		},
+	}
+	dispatcher := alertDispatcherFunc(func(
+		_ context.Context,
+		_ *warnly.Alert,
+		channels []warnly.NotificationChannel,
+		notificationType warnly.AlertNotificationType,
+	) error {
+		run.notified = append(run.notified, notificationType)
+		if notificationType == warnly.AlertNotificationEscalated {
+			for i := range channels {
+				run.escalatedTo = append(run.escalatedTo, channels[i].ID)
+			}
+		}
+		return nil
+	})
+
+	return NewAlertWorker(alertStore, analyticsStore, issueStore, notificationStore, dispatcher,
+		now, time.Minute, warmUp, "test", slog.Default())
+}
+
+func TestAlertConditions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	resolvedAt := now.Add(-30 * time.Minute)
+	longAgo := now.Add(-72 * time.Hour)
+
+	tests := []struct {
+		name      string
+		condition warnly.AlertCondition
+		threshold int
+		issues    []warnly.Issue
+		metrics   []warnly.IssueMetrics
+		triggered bool
+	}{
+		{
+			name:      "events above threshold",
+			condition: warnly.AlertConditionOccurrences,
+			threshold: 10,
+			issues:    []warnly.Issue{{ID: 1, FirstSeen: longAgo}},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 11, UserCount: 1}},
+			triggered: true,
+		},
+		{
+			name:      "events at threshold",
+			condition: warnly.AlertConditionOccurrences,
+			threshold: 10,
+			issues:    []warnly.Issue{{ID: 1, FirstSeen: longAgo}},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 10, UserCount: 50}},
+			triggered: false,
+		},
+		{
+			name:      "unique users above threshold",
+			condition: warnly.AlertConditionUsers,
+			threshold: 5,
+			issues:    []warnly.Issue{{ID: 1, FirstSeen: longAgo}, {ID: 2, FirstSeen: longAgo}},
+			metrics: []warnly.IssueMetrics{
+				{GID: 1, TimesSeen: 100, UserCount: 2},
+				{GID: 2, TimesSeen: 7, UserCount: 6},
+			},
+			triggered: true,
+		},
+		{
+			name:      "unique users below threshold despite many events",
+			condition: warnly.AlertConditionUsers,
+			threshold: 5,
+			issues:    []warnly.Issue{{ID: 1, FirstSeen: longAgo}},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 1000, UserCount: 5}},
+			triggered: false,
+		},
+		{
+			name:      "new issue first seen in timeframe",
+			condition: warnly.AlertConditionNewIssue,
+			issues: []warnly.Issue{
+				{ID: 1, FirstSeen: longAgo},
+				{ID: 2, FirstSeen: now.Add(-10 * time.Minute)},
+			},
+			metrics: []warnly.IssueMetrics{
+				{GID: 1, TimesSeen: 40},
+				{GID: 2, TimesSeen: 1, FirstSeen: now.Add(-10 * time.Minute)},
+			},
+			triggered: true,
+		},
+		{
+			name:      "only old issues seen",
+			condition: warnly.AlertConditionNewIssue,
+			issues:    []warnly.Issue{{ID: 1, FirstSeen: longAgo}},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 40}},
+			triggered: false,
+		},
+		{
+			name:      "resolved issue seen after resolution",
+			condition: warnly.AlertConditionReoccurred,
+			issues: []warnly.Issue{
+				{ID: 1, FirstSeen: longAgo, Status: warnly.IssueStatusResolved, ResolvedAt: &resolvedAt},
+			},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 3, LastSeen: now.Add(-5 * time.Minute)}},
+			triggered: true,
+		},
+		{
+			name:      "resolved issue without new events",
+			condition: warnly.AlertConditionReoccurred,
+			issues: []warnly.Issue{
+				{ID: 1, FirstSeen: longAgo, Status: warnly.IssueStatusResolved, ResolvedAt: &resolvedAt},
+			},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 3, LastSeen: resolvedAt.Add(-time.Minute)}},
+			triggered: false,
+		},
+		{
+			name:      "unresolved issue keeps occurring",
+			condition: warnly.AlertConditionReoccurred,
+			issues:    []warnly.Issue{{ID: 1, FirstSeen: longAgo, Status: warnly.IssueStatusUnresolved}},
+			metrics:   []warnly.IssueMetrics{{GID: 1, TimesSeen: 3, LastSeen: now.Add(-time.Minute)}},
+			triggered: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alert := warnly.Alert{
+				ID:        1,
+				ProjectID: 7,
+				TeamID:    3,
+				Status:    warnly.AlertStatusActive,
+				Condition: tt.condition,
+				Threshold: tt.threshold,
+				Timeframe: warnly.AlertTimeframe1Hour,
+			}
+
+			run := runAlert(t, now, alert, tt.issues, tt.metrics, nil)
+
+			require.NotNil(t, run.criteria)
+			assert.Equal(t, now.Add(-time.Hour), run.criteria.From)
+			assert.Equal(t, now, run.criteria.To)
+
+			if tt.triggered {
+				assert.Equal(t, []warnly.AlertStatus{warnly.AlertStatusTriggered}, run.updated)
+				assert.Equal(t, []warnly.AlertNotificationType{warnly.AlertNotificationTriggered}, run.notified)
+			} else {
+				assert.Empty(t, run.updated)
+				assert.Empty(t, run.notified)
+			}
+		})
+	}
+}
+
+func TestTriggeredAlertResolvesWhenConditionClears(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	alert := warnly.Alert{
+		ID:        1,
+		ProjectID: 7,
+		TeamID:    3,
+		Status:    warnly.AlertStatusTriggered,
+		Condition: warnly.AlertConditionNewIssue,
+		Timeframe: warnly.AlertTimeframe15Min,
+	}
+	issues := []warnly.Issue{{ID: 1, FirstSeen: now.Add(-time.Hour)}}
+	metrics := []warnly.IssueMetrics{{GID: 1, TimesSeen: 2}}
+
+	run := runAlert(t, now, alert, issues, metrics, nil)
+
+	assert.Equal(t, []warnly.AlertStatus{warnly.AlertStatusActive}, run.updated)
+	assert.Equal(t, []warnly.AlertNotificationType{warnly.AlertNotificationResolved}, run.notified)
+}
+
+func TestSpikeAlert(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		baseline  warnly.RollingBaseline
+		triggered bool
+	}{
+		{
+			name:      "clear spike",
+			baseline:  warnly.RollingBaseline{Current: 480, Baseline: 41.5},
+			triggered: true,
+		},
+		{
+			name:      "steady traffic",
+			baseline:  warnly.RollingBaseline{Current: 52, Baseline: 47.2},
+			triggered: false,
+		},
+		{
+			name:      "busier but below multiplier",
+			baseline:  warnly.RollingBaseline{Current: 120, Baseline: 41.5},
+			triggered: false,
+		},
+		{
+			name:      "first events of a quiet project",
+			baseline:  warnly.RollingBaseline{Current: 2, Baseline: 0},
+			triggered: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alert := warnly.Alert{
+				ID:              1,
+				ProjectID:       7,
+				TeamID:          3,
+				Status:          warnly.AlertStatusActive,
+				Condition:       warnly.AlertConditionSpike,
+				Timeframe:       warnly.AlertTimeframe15Min,
+				BaselineWindows: 8,
+				SpikeMultiplier: 3,
+			}
+
+			run := runAlert(t, now, alert, nil, nil, &tt.baseline)
+
+			require.NotNil(t, run.baselineCriteria)
+			assert.Equal(t, 7, run.baselineCriteria.ProjectID)
+			assert.Equal(t, now, run.baselineCriteria.To)
+			assert.Equal(t, 15*time.Minute, run.baselineCriteria.Window)
+			assert.Equal(t, 8, run.baselineCriteria.Windows)
+			assert.Nil(t, run.criteria)
+
+			if tt.triggered {
+				assert.Equal(t, []warnly.AlertStatus{warnly.AlertStatusTriggered}, run.updated)
+				assert.Equal(t, []warnly.AlertNotificationType{warnly.AlertNotificationTriggered}, run.notified)
+			} else {
+				assert.Empty(t, run.updated)
+				assert.Empty(t, run.notified)
+			}
+		})
+	}
+}
+
+func TestAlertWorkerWarmUp(t *testing.T) {
+	t.Parallel()
+
+	bootedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := bootedAt
+	alert := warnly.Alert{
+		ID:              1,
+		ProjectID:       7,
+		TeamID:          3,
+		Status:          warnly.AlertStatusActive,
+		Condition:       warnly.AlertConditionSpike,
+		Timeframe:       warnly.AlertTimeframe15Min,
+		BaselineWindows: 8,
+		SpikeMultiplier: 3,
+	}
+	baseline := &warnly.RollingBaseline{Current: 480, Baseline: 41.5}
+
+	run := &alertRun{}
+	w := newTestWorker(t, run, func() time.Time { return now }, 5*time.Minute,
+		func() warnly.Alert { return alert }, nil, nil, baseline)
+
+	now = bootedAt.Add(time.Minute)
+	w.processAlerts(t.Context())
+
+	assert.Empty(t, run.updated, "alert states are kept during warm-up")
+	assert.Empty(t, run.notified, "no notifications during warm-up")
+
+	now = bootedAt.Add(5 * time.Minute)
+	w.processAlerts(t.Context())
+
+	assert.Equal(t, []warnly.AlertStatus{warnly.AlertStatusTriggered}, run.updated)
+	assert.Equal(t, []warnly.AlertNotificationType{warnly.AlertNotificationTriggered}, run.notified)
+}
+
+func TestAlertEscalation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	issues := []warnly.Issue{{ID: 1, FirstSeen: now.Add(-50 * time.Minute)}}
+	metrics := []warnly.IssueMetrics{{GID: 1, TimesSeen: 4}}
+
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(a *warnly.Alert)
+		escalatedTo []int
+	}{
+		{
+			name:        "unacknowledged past the window",
+			setup:       func(*warnly.Alert) {},
+			escalatedTo: []int{5},
+		},
+		{
+			name:  "acknowledged",
+			setup: func(a *warnly.Alert) { a.AcknowledgedAt = at(-10 * time.Minute) },
+		},
+		{
+			name:  "still within the window",
+			setup: func(a *warnly.Alert) { a.LastTriggeredAt = at(-14 * time.Minute) },
+		},
+		{
+			name: "next channel after another window",
+			setup: func(a *warnly.Alert) {
+				a.EscalationLevel = 1
+				a.EscalatedAt = at(-15 * time.Minute)
+			},
+			escalatedTo: []int{6},
+		},
+		{
+			name: "chain exhausted",
+			setup: func(a *warnly.Alert) {
+				a.EscalationLevel = 2
+				a.EscalatedAt = at(-time.Hour)
+			},
+		},
+		{
+			name:  "not a high priority alert",
+			setup: func(a *warnly.Alert) { a.HighPriority = false },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			alert := warnly.Alert{
+				ID:               1,
+				ProjectID:        7,
+				TeamID:           3,
+				Status:           warnly.AlertStatusTriggered,
+				Condition:        warnly.AlertConditionNewIssue,
+				Timeframe:        warnly.AlertTimeframe1Hour,
+				LastTriggeredAt:  at(-40 * time.Minute),
+				AckToken:         "9f86d081884c7d65",
+				EscalationChain:  []int{5, 6},
+				EscalationWindow: 15 * time.Minute,
+				HighPriority:     true,
+			}
+			tt.setup(&alert)
+
+			run := runAlert(t, now, alert, issues, metrics, nil)
+
+			assert.Equal(t, tt.escalatedTo, run.escalatedTo)
+			if tt.escalatedTo == nil {
+				assert.Empty(t, run.notified)
+				assert.Empty(t, run.updated)
+				return
+			}
+			require.NotNil(t, run.lastUpdate)
+			assert.Equal(t, alert.EscalationLevel+1, run.lastUpdate.EscalationLevel)
+			assert.Equal(t, now, *run.lastUpdate.EscalatedAt)
+		})
+	}
+}
+
+func TestAlertEscalationStopsAfterAcknowledgement(t *testing.T) {
+	t.Parallel()
+
+	triggeredAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := triggeredAt
+	alert := warnly.Alert{
+		ID:               1,
+		ProjectID:        7,
+		TeamID:           3,
+		Status:           warnly.AlertStatusActive,
+		Condition:        warnly.AlertConditionNewIssue,
+		Timeframe:        warnly.AlertTimeframe1Hour,
+		EscalationChain:  []int{5, 6},
+		EscalationWindow: 15 * time.Minute,
+		HighPriority:     true,
+	}
+	issues := []warnly.Issue{{ID: 1, FirstSeen: triggeredAt.Add(-5 * time.Minute)}}
+	metrics := []warnly.IssueMetrics{{GID: 1, TimesSeen: 4}}
+
+	run := &alertRun{}
+	w := newTestWorker(t, run, func() time.Time { return now }, 0,
+		func() warnly.Alert { return alert }, issues, metrics, nil)
+
+	w.processAlerts(t.Context())
+
+	require.NotNil(t, run.lastUpdate)
+	assert.Equal(t, []warnly.AlertNotificationType{warnly.AlertNotificationTriggered}, run.notified)
+	assert.NotEmpty(t, run.lastUpdate.AckToken, "an escalating alert gets an ack token when it triggers")
+	alert = *run.lastUpdate
+
+	now = triggeredAt.Add(15 * time.Minute)
+	w.processAlerts(t.Context())
+
+	assert.Equal(t, []int{5}, run.escalatedTo)
+	alert = *run.lastUpdate
+
+	acknowledgedAt := triggeredAt.Add(20 * time.Minute)
+	alert.AcknowledgedAt = &acknowledgedAt
+
+	now = triggeredAt.Add(45 * time.Minute)
+	w.processAlerts(t.Context())
+
+	assert.Equal(t, []int{5}, run.escalatedTo, "no escalation after the alert was acknowledged")
+	assert.Equal(t, []warnly.AlertNotificationType{
+		warnly.AlertNotificationTriggered,
+		warnly.AlertNotificationEscalated,
+	}, run.notified)
+}
+
+func TestAlertWorkerMetrics(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	alert := warnly.Alert{
+		ID:        1,
+		ProjectID: 7,
+		TeamID:    3,
+		Status:    warnly.AlertStatusActive,
+		Condition: warnly.AlertConditionOccurrences,
+		Threshold: 10,
+		Timeframe: warnly.AlertTimeframe1Hour,
+	}
+	issues := []warnly.Issue{{ID: 1, FirstSeen: now.Add(-72 * time.Hour)}}
+	metrics := []warnly.IssueMetrics{{GID: 1, TimesSeen: 11}}
+
+	run := &alertRun{}
+	w := newTestWorker(t, run, func() time.Time { return now }, 0,
+		func() warnly.Alert { return alert }, issues, metrics, nil)
+
+	registry := prometheus.NewRegistry()
+	w.RegisterMetrics(registry)
+
+	w.processAlerts(t.Context())
+
+	require.Equal(t, []warnly.AlertStatus{warnly.AlertStatusTriggered}, run.updated)
+	assert.InDelta(t, 1, testutil.ToFloat64(w.metrics.fired.WithLabelValues("7")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(w.metrics.evaluations), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(w.metrics.errors), 0)
+
+	count, err := testutil.GatherAndCount(registry, "warnly_alert_evaluation_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
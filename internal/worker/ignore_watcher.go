@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// IgnoreWatcher periodically reopens the ignored issues whose ignore time has passed
+// or that affected enough users, so that they reappear even if no event of them arrives.
+type IgnoreWatcher struct {
+	reopener warnly.IgnoredIssueReopener
+	stopCh   chan struct{}
+	logger   *slog.Logger
+	interval time.Duration
+	mu       sync.Mutex
+	running  bool
+}
+
+// NewIgnoreWatcher creates a new ignore watcher.
+func NewIgnoreWatcher(
+	reopener warnly.IgnoredIssueReopener,
+	interval time.Duration,
+	logger *slog.Logger,
+) *IgnoreWatcher {
+	return &IgnoreWatcher{
+		reopener: reopener,
+		interval: interval,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start begins reopening ignored issues in the background.
+func (w *IgnoreWatcher) Start(ctx context.Context) {
+	w.mu.Lock()
+	if w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = true
+	w.mu.Unlock()
+
+	w.logger.Info("ignore watcher started", slog.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.reopenIssues(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("ignore watcher stopped due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("ignore watcher stopped")
+			return
+		case <-ticker.C:
+			w.reopenIssues(ctx)
+		}
+	}
+}
+
+// Stop stops the ignore watcher.
+func (w *IgnoreWatcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.running {
+		return
+	}
+
+	close(w.stopCh)
+	w.running = false
+}
+
+// reopenIssues reopens the ignored issues whose condition is met and logs the outcome.
+func (w *IgnoreWatcher) reopenIssues(ctx context.Context) {
+	reopened, err := w.reopener.ReopenIgnoredIssues(ctx)
+	if err != nil {
+		w.logger.Error("reopen ignored issues", slog.Int("reopened", reopened), slog.Any("error", err))
+		return
+	}
+	if reopened > 0 {
+		w.logger.Info("reopened ignored issues", slog.Int("reopened", reopened))
+	}
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vk-rv/warnly/internal/mock"
+)
+
+func TestIgnoreWatcherReopensIgnoredIssues(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	reopener := &mock.IgnoredIssueReopener{
+		ReopenIgnoredIssuesFn: func(context.Context) (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, errors.New("clickhouse is unavailable")
+			}
+			return 2, nil
+		},
+	}
+
+	w := NewIgnoreWatcher(reopener, time.Minute, slog.Default())
+
+	// a failed run is logged and retried on the next tick.
+	w.reopenIssues(t.Context())
+	w.reopenIssues(t.Context())
+	assert.Equal(t, 2, calls)
+}
+
+func TestIgnoreWatcherStopsOnContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	reopener := &mock.IgnoredIssueReopener{
+		ReopenIgnoredIssuesFn: func(context.Context) (int, error) {
+			cancel()
+			return 0, nil
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		NewIgnoreWatcher(reopener, time.Hour, slog.Default()).Start(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ignore watcher didn't stop")
+	}
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/uow"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// defaultReapBatchSize is the number of messages deleted in one transaction.
+const defaultReapBatchSize = 500
+
+// MessageReaper periodically deletes issue discussion messages and their mentions
+// that are older than the retention policy allows.
+type MessageReaper struct {
+	messageStore warnly.MessageStore
+	mentionStore warnly.MentionStore
+	uow          uow.StartUnitOfWork
+	stopCh       chan struct{}
+	logger       *slog.Logger
+	now          func() time.Time
+	retention    warnly.MessageRetention
+	interval     time.Duration
+	batchSize    int
+	mu           sync.Mutex
+	running      bool
+}
+
+// NewMessageReaper creates a new message reaper.
+func NewMessageReaper(
+	messageStore warnly.MessageStore,
+	mentionStore warnly.MentionStore,
+	uow uow.StartUnitOfWork,
+	retention warnly.MessageRetention,
+	now func() time.Time,
+	interval time.Duration,
+	logger *slog.Logger,
+) *MessageReaper {
+	return &MessageReaper{
+		messageStore: messageStore,
+		mentionStore: mentionStore,
+		uow:          uow,
+		retention:    retention,
+		now:          now,
+		interval:     interval,
+		batchSize:    defaultReapBatchSize,
+		logger:       logger,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Start begins reaping expired messages in the background.
+func (r *MessageReaper) Start(ctx context.Context) {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	r.logger.Info("message reaper started",
+		slog.Duration("max_age", r.retention.MaxAge),
+		slog.Bool("keep_open_issues", r.retention.KeepOpenIssues),
+	)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.reapMessages(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("message reaper stopped due to context cancellation")
+			return
+		case <-r.stopCh:
+			r.logger.Info("message reaper stopped")
+			return
+		case <-ticker.C:
+			r.reapMessages(ctx)
+		}
+	}
+}
+
+// Stop stops the message reaper.
+func (r *MessageReaper) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return
+	}
+
+	close(r.stopCh)
+	r.running = false
+}
+
+// reapMessages deletes expired messages and logs the outcome.
+func (r *MessageReaper) reapMessages(ctx context.Context) {
+	deleted, err := r.reap(ctx)
+	if err != nil {
+		r.logger.Error("reap messages", slog.Int("deleted", deleted), slog.Any("error", err))
+		return
+	}
+	if deleted > 0 {
+		r.logger.Info("reaped expired messages", slog.Int("deleted", deleted))
+	}
+}
+
+// reap deletes expired messages in batches and returns how many were deleted.
+func (r *MessageReaper) reap(ctx context.Context) (int, error) {
+	criteria := &warnly.ExpiredMessagesCriteria{
+		Before:         r.now().UTC().Add(-r.retention.MaxAge),
+		KeepOpenIssues: r.retention.KeepOpenIssues,
+		Limit:          r.batchSize,
+	}
+
+	deleted := 0
+	for {
+		ids, err := r.messageStore.ListExpiredMessages(ctx, criteria)
+		if err != nil {
+			return deleted, fmt.Errorf("list expired messages: %w", err)
+		}
+		if len(ids) == 0 {
+			return deleted, nil
+		}
+
+		err = r.uow(ctx, uow.Write, func(ctx context.Context, uw uow.UnitOfWork) error {
+			if err := uw.Mentions().DeleteMessagesMentions(ctx, ids); err != nil {
+				return err
+			}
+			return uw.Messages().DeleteMessages(ctx, ids)
+		}, r.messageStore, r.mentionStore)
+		if err != nil {
+			return deleted, fmt.Errorf("delete expired messages: %w", err)
+		}
+		deleted += len(ids)
+
+		if len(ids) < r.batchSize {
+			return deleted, nil
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// storedMessage is a discussion message along with the status of its issue.
+type storedMessage struct {
+	createdAt time.Time
+	id        int
+	resolved  bool
+}
+
+// messageDB is an in-memory message table the reaper deletes from.
+type messageDB struct {
+	messages []storedMessage
+	mentions map[int]int
+}
+
+func (db *messageDB) ids() []int {
+	ids := make([]int, 0, len(db.messages))
+	for i := range db.messages {
+		ids = append(ids, db.messages[i].id)
+	}
+	return ids
+}
+
+func newTestReaper(t *testing.T, db *messageDB, now time.Time, retention warnly.MessageRetention) *MessageReaper {
+	t.Helper()
+
+	messageStore := &mock.MessageStore{
+		ListExpiredMessagesFn: func(_ context.Context, criteria *warnly.ExpiredMessagesCriteria) ([]int, error) {
+			var ids []int
+			for _, m := range db.messages {
+				if len(ids) == criteria.Limit {
+					break
+				}
+				if !m.createdAt.Before(criteria.Before) || (criteria.KeepOpenIssues && !m.resolved) {
+					continue
+				}
+				ids = append(ids, m.id)
+			}
+			return ids, nil
+		},
+		DeleteMessagesFn: func(_ context.Context, messageIDs []int) error {
+			db.messages = slices.DeleteFunc(db.messages, func(m storedMessage) bool {
+				return slices.Contains(messageIDs, m.id)
+			})
+			return nil
+		},
+	}
+	mentionStore := &mock.MentionStore{
+		DeleteMessagesMentionsFn: func(_ context.Context, messageIDs []int) error {
+			for _, id := range messageIDs {
+				delete(db.mentions, id)
+			}
+			return nil
+		},
+	}
+	unitOfWork := &mock.UnitOfWork{MessageStore: messageStore, MentionStore: mentionStore}
+
+	return NewMessageReaper(messageStore, mentionStore, unitOfWork.Start, retention,
+		func() time.Time { return now }, time.Hour, slog.New(slog.DiscardHandler))
+}
+
+func TestMessageReaper(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-100 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		retention warnly.MessageRetention
+		remaining []int
+	}{
+		{
+			name:      "KeepOpenIssues",
+			retention: warnly.MessageRetention{MaxAge: 90 * 24 * time.Hour, KeepOpenIssues: true},
+			remaining: []int{2, 3, 4},
+		},
+		{
+			name:      "AllIssues",
+			retention: warnly.MessageRetention{MaxAge: 90 * 24 * time.Hour},
+			remaining: []int{3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &messageDB{
+				messages: []storedMessage{
+					{id: 1, createdAt: old, resolved: true},
+					{id: 2, createdAt: old, resolved: false},
+					{id: 3, createdAt: recent, resolved: true},
+					{id: 4, createdAt: recent, resolved: false},
+				},
+				mentions: map[int]int{1: 10, 2: 10, 3: 10},
+			}
+
+			r := newTestReaper(t, db, now, tt.retention)
+			deleted, err := r.reap(t.Context())
+			require.NoError(t, err)
+
+			assert.Equal(t, 4-len(tt.remaining), deleted)
+			assert.Equal(t, tt.remaining, db.ids())
+			for id := range db.mentions {
+				assert.Contains(t, tt.remaining, id, "mentions of deleted message %d remain", id)
+			}
+		})
+	}
+}
+
+func TestMessageReaperBatches(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+
+	db := &messageDB{mentions: map[int]int{}}
+	for id := 1; id <= 5; id++ {
+		db.messages = append(db.messages, storedMessage{id: id, createdAt: old, resolved: id != 5})
+	}
+
+	r := newTestReaper(t, db, now, warnly.MessageRetention{MaxAge: 24 * time.Hour, KeepOpenIssues: true})
+	r.batchSize = 2
+
+	deleted, err := r.reap(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, deleted)
+	assert.Equal(t, []int{5}, db.ids())
+}
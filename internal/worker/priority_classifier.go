@@ -0,0 +1,193 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// PriorityClassifier periodically promotes issues to high priority
+// when their recent metrics cross the configured thresholds.
+// Priorities are only raised, lowering them is left to people.
+type PriorityClassifier struct {
+	projectStore   warnly.ProjectStore
+	issueStore     warnly.IssueStore
+	analyticsStore warnly.AnalyticsStore
+	stopCh         chan struct{}
+	logger         *slog.Logger
+	now            func() time.Time
+	thresholds     warnly.PriorityThresholds
+	interval       time.Duration
+	mu             sync.Mutex
+	running        bool
+}
+
+// NewPriorityClassifier creates a new priority classifier.
+func NewPriorityClassifier(
+	projectStore warnly.ProjectStore,
+	issueStore warnly.IssueStore,
+	analyticsStore warnly.AnalyticsStore,
+	thresholds warnly.PriorityThresholds,
+	now func() time.Time,
+	interval time.Duration,
+	logger *slog.Logger,
+) *PriorityClassifier {
+	return &PriorityClassifier{
+		projectStore:   projectStore,
+		issueStore:     issueStore,
+		analyticsStore: analyticsStore,
+		thresholds:     thresholds,
+		now:            now,
+		interval:       interval,
+		logger:         logger,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// Start begins classifying issues in the background.
+func (c *PriorityClassifier) Start(ctx context.Context) {
+	c.mu.Lock()
+	if c.running {
+		c.mu.Unlock()
+		return
+	}
+	c.running = true
+	c.mu.Unlock()
+
+	c.logger.Info("priority classifier started",
+		slog.Duration("window", c.thresholds.Window),
+		slog.Uint64("users", c.thresholds.Users),
+		slog.Float64("acceleration", c.thresholds.Acceleration),
+	)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.classifyIssues(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("priority classifier stopped due to context cancellation")
+			return
+		case <-c.stopCh:
+			c.logger.Info("priority classifier stopped")
+			return
+		case <-ticker.C:
+			c.classifyIssues(ctx)
+		}
+	}
+}
+
+// Stop stops the priority classifier.
+func (c *PriorityClassifier) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return
+	}
+
+	close(c.stopCh)
+	c.running = false
+}
+
+// classifyIssues classifies the issues of every project and logs the outcome.
+func (c *PriorityClassifier) classifyIssues(ctx context.Context) {
+	projectIDs, err := c.projectStore.ListProjectIDs(ctx)
+	if err != nil {
+		c.logger.Error("classify issues: list projects", slog.Any("error", err))
+		return
+	}
+
+	promoted := 0
+	for _, projectID := range projectIDs {
+		n, err := c.classify(ctx, projectID)
+		promoted += n
+		if err != nil {
+			c.logger.Error("classify issues",
+				slog.Int("project_id", projectID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	if promoted > 0 {
+		c.logger.Info("promoted issues to high priority", slog.Int("promoted", promoted))
+	}
+}
+
+// classify promotes the issues of the project seen within the window whose metrics
+// cross a threshold and returns how many were promoted.
+func (c *PriorityClassifier) classify(ctx context.Context, projectID int) (int, error) {
+	to := c.now().UTC()
+	from := to.Add(-c.thresholds.Window)
+
+	issues, err := c.issueStore.ListIssues(ctx, &warnly.ListIssuesCriteria{
+		ProjectIDs: []int{projectID},
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list issues: %w", err)
+	}
+
+	groupIDs := make([]int64, 0, len(issues))
+	for i := range issues {
+		if issues[i].Priority < warnly.PriorityHigh && !issues[i].IsResolved() {
+			groupIDs = append(groupIDs, issues[i].ID)
+		}
+	}
+	if len(groupIDs) == 0 {
+		return 0, nil
+	}
+
+	current, err := c.analyticsStore.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+		ProjectIDs: []int{projectID},
+		GroupIDs:   groupIDs,
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list issue metrics: %w", err)
+	}
+
+	var previous []warnly.IssueMetrics
+	if c.thresholds.Acceleration > 0 {
+		// the end is inclusive, so the preceding window stops a second before the current one.
+		previous, err = c.analyticsStore.ListIssueMetrics(ctx, &warnly.ListIssueMetricsCriteria{
+			ProjectIDs: []int{projectID},
+			GroupIDs:   groupIDs,
+			From:       from.Add(-c.thresholds.Window),
+			To:         from.Add(-time.Second),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("list previous issue metrics: %w", err)
+		}
+	}
+
+	promoted := 0
+	for _, id := range groupIDs {
+		cur, ok := warnly.GetMetrics(current, id)
+		if !ok {
+			continue
+		}
+		var prev *warnly.IssueMetrics
+		if m, ok := warnly.GetMetrics(previous, id); ok {
+			prev = &m
+		}
+		if !c.thresholds.Promotes(&cur, prev) {
+			continue
+		}
+		if err := c.issueStore.UpdatePriority(ctx, id, warnly.PriorityHigh); err != nil {
+			return promoted, fmt.Errorf("update priority of issue %d: %w", id, err)
+		}
+		promoted++
+	}
+
+	return promoted, nil
+}
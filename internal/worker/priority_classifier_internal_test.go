@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+func TestPriorityClassifier(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	thresholds := warnly.PriorityThresholds{
+		Window:       time.Hour,
+		Users:        50,
+		MinEvents:    20,
+		Acceleration: 4,
+	}
+
+	tests := []struct {
+		name     string
+		issue    warnly.Issue
+		current  []warnly.IssueMetrics
+		previous []warnly.IssueMetrics
+		promoted bool
+	}{
+		{
+			name:     "users affected cross the threshold",
+			issue:    warnly.Issue{ID: 1, Priority: warnly.PriorityMedium},
+			current:  []warnly.IssueMetrics{{GID: 1, TimesSeen: 60, UserCount: 55}},
+			previous: []warnly.IssueMetrics{{GID: 1, TimesSeen: 60, UserCount: 40}},
+			promoted: true,
+		},
+		{
+			name:     "event rate accelerates",
+			issue:    warnly.Issue{ID: 1, Priority: warnly.PriorityMedium},
+			current:  []warnly.IssueMetrics{{GID: 1, TimesSeen: 200, UserCount: 3}},
+			previous: []warnly.IssueMetrics{{GID: 1, TimesSeen: 30, UserCount: 3}},
+			promoted: true,
+		},
+		{
+			name:     "burst of an issue quiet before",
+			issue:    warnly.Issue{ID: 1, Priority: warnly.PriorityLow},
+			current:  []warnly.IssueMetrics{{GID: 1, TimesSeen: 25, UserCount: 2}},
+			promoted: true,
+		},
+		{
+			name:     "steady traffic",
+			issue:    warnly.Issue{ID: 1, Priority: warnly.PriorityMedium},
+			current:  []warnly.IssueMetrics{{GID: 1, TimesSeen: 90, UserCount: 12}},
+			previous: []warnly.IssueMetrics{{GID: 1, TimesSeen: 80, UserCount: 10}},
+		},
+		{
+			name:    "too few events to accelerate",
+			issue:   warnly.Issue{ID: 1, Priority: warnly.PriorityMedium},
+			current: []warnly.IssueMetrics{{GID: 1, TimesSeen: 8, UserCount: 1}},
+		},
+		{
+			name: "resolved issue",
+			issue: warnly.Issue{
+				ID: 1, Priority: warnly.PriorityMedium, Status: warnly.IssueStatusResolved, ResolvedAt: &now,
+			},
+			current: []warnly.IssueMetrics{{GID: 1, TimesSeen: 300, UserCount: 90}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectStore := &mock.ProjectStore{
+				ListProjectIDsFn: func(context.Context) ([]int, error) { return []int{7}, nil },
+			}
+			var updated map[int64]warnly.IssuePriority
+			issueStore := &mock.IssueStore{
+				ListIssuesFn: func(_ context.Context, c *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+					assert.Equal(t, []int{7}, c.ProjectIDs)
+					assert.Equal(t, now.Add(-time.Hour), c.From)
+					return []warnly.Issue{tt.issue}, nil
+				},
+				UpdatePriorityFn: func(_ context.Context, issueID int64, priority warnly.IssuePriority) error {
+					if updated == nil {
+						updated = make(map[int64]warnly.IssuePriority)
+					}
+					updated[issueID] = priority
+					return nil
+				},
+			}
+			analyticsStore := &mock.AnalyticsStore{
+				ListIssueMetricsFn: func(_ context.Context, c *warnly.ListIssueMetricsCriteria) ([]warnly.IssueMetrics, error) {
+					if c.To.Equal(now) {
+						return tt.current, nil
+					}
+					require.Equal(t, now.Add(-2*time.Hour), c.From, "the preceding window")
+					return tt.previous, nil
+				},
+			}
+
+			c := NewPriorityClassifier(projectStore, issueStore, analyticsStore, thresholds,
+				func() time.Time { return now }, time.Minute, slog.Default())
+			c.classifyIssues(t.Context())
+
+			if tt.promoted {
+				assert.Equal(t, map[int64]warnly.IssuePriority{1: warnly.PriorityHigh}, updated)
+			} else {
+				assert.Empty(t, updated)
+			}
+		})
+	}
+}
+
+func TestPriorityClassifierSkipsHighPriorityIssues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	projectStore := &mock.ProjectStore{
+		ListProjectIDsFn: func(context.Context) ([]int, error) { return []int{7}, nil },
+	}
+	issueStore := &mock.IssueStore{
+		ListIssuesFn: func(context.Context, *warnly.ListIssuesCriteria) ([]warnly.Issue, error) {
+			return []warnly.Issue{{ID: 1, Priority: warnly.PriorityHigh}}, nil
+		},
+	}
+
+	// metrics aren't read, the mock would panic otherwise.
+	c := NewPriorityClassifier(projectStore, issueStore, &mock.AnalyticsStore{},
+		warnly.PriorityThresholds{Window: time.Hour, Users: 1}, func() time.Time { return now }, time.Minute, slog.Default())
+	c.classifyIssues(t.Context())
+}
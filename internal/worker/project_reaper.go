@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vk-rv/warnly/internal/warnly"
+)
+
+// ProjectReaper periodically purges projects deleted longer than the grace period ago,
+// their events are deleted first, so a project that failed to be purged is retried.
+type ProjectReaper struct {
+	projectStore   warnly.ProjectStore
+	analyticsStore warnly.AnalyticsStore
+	stopCh         chan struct{}
+	logger         *slog.Logger
+	now            func() time.Time
+	gracePeriod    time.Duration
+	interval       time.Duration
+	mu             sync.Mutex
+	running        bool
+}
+
+// NewProjectReaper creates a new project reaper.
+func NewProjectReaper(
+	projectStore warnly.ProjectStore,
+	analyticsStore warnly.AnalyticsStore,
+	gracePeriod time.Duration,
+	now func() time.Time,
+	interval time.Duration,
+	logger *slog.Logger,
+) *ProjectReaper {
+	return &ProjectReaper{
+		projectStore:   projectStore,
+		analyticsStore: analyticsStore,
+		gracePeriod:    gracePeriod,
+		now:            now,
+		interval:       interval,
+		logger:         logger,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// Start begins purging deleted projects in the background.
+func (r *ProjectReaper) Start(ctx context.Context) {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	r.logger.Info("project reaper started", slog.Duration("grace_period", r.gracePeriod))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.reapProjects(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("project reaper stopped due to context cancellation")
+			return
+		case <-r.stopCh:
+			r.logger.Info("project reaper stopped")
+			return
+		case <-ticker.C:
+			r.reapProjects(ctx)
+		}
+	}
+}
+
+// Stop stops the project reaper.
+func (r *ProjectReaper) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return
+	}
+
+	close(r.stopCh)
+	r.running = false
+}
+
+// reapProjects purges expired projects and logs the outcome.
+func (r *ProjectReaper) reapProjects(ctx context.Context) {
+	purged, err := r.reap(ctx)
+	if err != nil {
+		r.logger.Error("reap projects", slog.Int("purged", purged), slog.Any("error", err))
+		return
+	}
+	if purged > 0 {
+		r.logger.Info("purged deleted projects", slog.Int("purged", purged))
+	}
+}
+
+// reap purges projects deleted before the grace period and returns how many were purged.
+func (r *ProjectReaper) reap(ctx context.Context) (int, error) {
+	ids, err := r.projectStore.ListDeletedProjectIDs(ctx, r.now().UTC().Add(-r.gracePeriod))
+	if err != nil {
+		return 0, fmt.Errorf("list deleted projects: %w", err)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := r.analyticsStore.DeleteProjectEvents(ctx, id); err != nil {
+			return purged, fmt.Errorf("delete events of project %d: %w", id, err)
+		}
+		if err := r.projectStore.DeleteProject(ctx, id); err != nil {
+			return purged, fmt.Errorf("delete project %d: %w", id, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vk-rv/warnly/internal/mock"
+)
+
+func TestProjectReaper(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC)
+	deletedAt := map[int]time.Time{
+		1: now.Add(-8 * 24 * time.Hour),
+		2: now.Add(-7*24*time.Hour - time.Minute),
+		// still within the grace period, the project can be restored.
+		3: now.Add(-6 * 24 * time.Hour),
+	}
+
+	var eventsDeleted, purged []int
+	projectStore := &mock.ProjectStore{
+		ListDeletedProjectIDsFn: func(_ context.Context, before time.Time) ([]int, error) {
+			var ids []int
+			for _, id := range []int{1, 2, 3} {
+				if deletedAt[id].Before(before) {
+					ids = append(ids, id)
+				}
+			}
+			return ids, nil
+		},
+		DeleteProjectFn: func(_ context.Context, projectID int) error {
+			purged = append(purged, projectID)
+			return nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		DeleteProjectEventsFn: func(_ context.Context, projectID int) error {
+			eventsDeleted = append(eventsDeleted, projectID)
+			return nil
+		},
+	}
+
+	r := NewProjectReaper(projectStore, analyticsStore, 7*24*time.Hour,
+		func() time.Time { return now }, time.Minute, slog.Default())
+
+	n, err := r.reap(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []int{1, 2}, eventsDeleted)
+	assert.Equal(t, []int{1, 2}, purged)
+}
+
+func TestProjectReaperKeepsProjectWhenEventsAreNotDeleted(t *testing.T) {
+	t.Parallel()
+
+	projectStore := &mock.ProjectStore{
+		ListDeletedProjectIDsFn: func(context.Context, time.Time) ([]int, error) {
+			return []int{1}, nil
+		},
+	}
+	analyticsStore := &mock.AnalyticsStore{
+		DeleteProjectEventsFn: func(context.Context, int) error {
+			return errors.New("clickhouse is unavailable")
+		},
+	}
+
+	// the project row is kept for the next run, the mock would panic otherwise.
+	r := NewProjectReaper(projectStore, analyticsStore, time.Hour, time.Now, time.Minute, slog.Default())
+
+	n, err := r.reap(t.Context())
+	require.Error(t, err)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,5 @@
+ALTER TABLE event
+    DROP INDEX IF EXISTS `idx_message_tokens`;
+
+ALTER TABLE event
+    DROP INDEX IF EXISTS `idx_title_tokens`;
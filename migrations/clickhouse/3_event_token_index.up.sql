@@ -0,0 +1,7 @@
+-- Token bloom filter indexes used by the full-text search of issues (hasToken over lowercased text).
+-- Parts written before the migration are indexed on merge or with MATERIALIZE INDEX.
+ALTER TABLE event
+    ADD INDEX IF NOT EXISTS `idx_title_tokens` lower(title) TYPE tokenbf_v1(10240, 3, 0) GRANULARITY 4;
+
+ALTER TABLE event
+    ADD INDEX IF NOT EXISTS `idx_message_tokens` lower(message) TYPE tokenbf_v1(32768, 3, 0) GRANULARITY 4;
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `code_owners` json NULL;
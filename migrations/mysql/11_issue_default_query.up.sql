@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `issue_default_query` (
+  `user_id` int NOT NULL,
+  `project_id` int NOT NULL COMMENT '0 for the list of issues of all projects',
+  `query` varchar(255) NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`user_id`, `project_id`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `grouping_rules` json NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `ingest_secret` varchar(64) NULL;
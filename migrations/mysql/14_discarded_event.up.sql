@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `discarded_event` (
+  `project_id` int NOT NULL,
+  `day` DATE NOT NULL,
+  `reason` varchar(64) NOT NULL COMMENT 'why the SDK dropped the events, e.g. sample_rate, ratelimit_backoff',
+  `category` varchar(64) NOT NULL COMMENT 'error, transaction, attachment, etc',
+  `quantity` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  PRIMARY KEY (`project_id`, `day`, `reason`, `category`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `dedup_window_seconds` int unsigned NOT NULL DEFAULT 0 COMMENT 'duplicate events within the window are not stored, 0 disables deduplication';
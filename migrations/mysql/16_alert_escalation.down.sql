@@ -0,0 +1,13 @@
+DELETE FROM `alert_notification` WHERE `notification_type` = 'escalated';
+
+ALTER TABLE `alert_notification`
+  MODIFY COLUMN `notification_type` ENUM('triggered', 'resolved') NOT NULL;
+
+ALTER TABLE `alert`
+  DROP KEY `uq_ack_token`,
+  DROP COLUMN `escalation_chain`,
+  DROP COLUMN `escalation_window_seconds`,
+  DROP COLUMN `ack_token`,
+  DROP COLUMN `acknowledged_at`,
+  DROP COLUMN `escalation_level`,
+  DROP COLUMN `escalated_at`;
@@ -0,0 +1,11 @@
+ALTER TABLE `alert`
+  ADD COLUMN `escalation_chain` json NULL COMMENT 'notification channel IDs notified in order while the alert is unacknowledged',
+  ADD COLUMN `escalation_window_seconds` int unsigned NOT NULL DEFAULT 0,
+  ADD COLUMN `ack_token` varchar(64) DEFAULT NULL,
+  ADD COLUMN `acknowledged_at` datetime DEFAULT NULL,
+  ADD COLUMN `escalation_level` int NOT NULL DEFAULT 0,
+  ADD COLUMN `escalated_at` datetime DEFAULT NULL,
+  ADD UNIQUE KEY `uq_ack_token` (`ack_token`);
+
+ALTER TABLE `alert_notification`
+  MODIFY COLUMN `notification_type` ENUM('triggered', 'resolved', 'escalated') NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE `project`
+  DROP KEY `idx_deleted_at`,
+  DROP COLUMN `deleted_at`;
@@ -0,0 +1,3 @@
+ALTER TABLE `project`
+  ADD COLUMN `deleted_at` datetime DEFAULT NULL COMMENT 'the project is purged once the deletion grace period passes',
+  ADD KEY `idx_deleted_at` (`deleted_at`);
@@ -0,0 +1,2 @@
+ALTER TABLE `issue`
+  DROP COLUMN `resolved_in_release`;
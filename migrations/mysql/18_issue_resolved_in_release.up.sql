@@ -0,0 +1,2 @@
+ALTER TABLE `issue`
+  ADD COLUMN `resolved_in_release` varchar(255) NOT NULL DEFAULT '' COMMENT 'the issue is reopened when it recurs in a newer release';
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `source_url_template` varchar(255) NOT NULL DEFAULT '' COMMENT 'links stack frames to the repository, {ref}, {path} and {line} are substituted';
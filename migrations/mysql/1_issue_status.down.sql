@@ -0,0 +1,3 @@
+ALTER TABLE `issue`
+  DROP COLUMN `resolved_at`,
+  DROP COLUMN `status`;
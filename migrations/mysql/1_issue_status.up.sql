@@ -0,0 +1,3 @@
+ALTER TABLE `issue`
+  ADD COLUMN `status` ENUM('unresolved', 'resolved') NOT NULL DEFAULT 'unresolved',
+  ADD COLUMN `resolved_at` datetime DEFAULT NULL;
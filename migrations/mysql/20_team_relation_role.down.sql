@@ -0,0 +1,2 @@
+ALTER TABLE `team_relation`
+  DROP COLUMN `role`;
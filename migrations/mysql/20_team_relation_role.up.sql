@@ -0,0 +1,5 @@
+ALTER TABLE `team_relation`
+  ADD COLUMN `role` varchar(16) NOT NULL DEFAULT 'member' COMMENT 'admin, member or viewer';
+
+-- existing members could do everything before roles were introduced.
+UPDATE `team_relation` SET `role` = 'admin';
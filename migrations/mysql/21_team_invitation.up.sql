@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `team_invitation` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `created_at` DATETIME NOT NULL,
+  `expires_at` DATETIME NOT NULL,
+  `accepted_at` DATETIME NULL,
+  `email` varchar(255) NOT NULL,
+  `team_id` int NOT NULL,
+  `role` varchar(16) NOT NULL,
+  `invited_by` int NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_team_id` (`team_id`)
+);
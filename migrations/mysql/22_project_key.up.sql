@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `project_key` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `created_at` DATETIME NOT NULL,
+  `revoked_at` DATETIME NULL COMMENT 'the key is accepted until then, NULL while active',
+  `project_id` int NOT NULL,
+  `project_key` varchar(7) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `project_key` (`project_key`),
+  KEY `idx_project_id` (`project_id`)
+);
+
+-- the key of the project DSN stays in the project table and is one of the project keys.
+INSERT INTO `project_key` (`created_at`, `project_id`, `project_key`)
+SELECT `created_at`, `id`, `project_key` FROM `project`;
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `retention_days` tinyint unsigned NOT NULL DEFAULT 90 COMMENT 'number of days new events of the project are kept';
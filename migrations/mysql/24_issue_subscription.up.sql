@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS `issue_subscription` (
+  `issue_id` BIGINT NOT NULL,
+  `user_id` int NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`issue_id`, `user_id`)
+);
@@ -0,0 +1,8 @@
+UPDATE `issue` SET `status` = 'unresolved' WHERE `status` = 'ignored';
+ALTER TABLE `issue`
+  DROP COLUMN `ignore_events`,
+  DROP COLUMN `ignored_until`,
+  DROP COLUMN `ignored_at`,
+  DROP COLUMN `ignore_threshold`,
+  DROP COLUMN `ignore_condition`,
+  MODIFY COLUMN `status` ENUM('unresolved', 'resolved') NOT NULL DEFAULT 'unresolved';
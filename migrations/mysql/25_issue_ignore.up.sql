@@ -0,0 +1,7 @@
+ALTER TABLE `issue`
+  MODIFY COLUMN `status` ENUM('unresolved', 'resolved', 'ignored') NOT NULL DEFAULT 'unresolved',
+  ADD COLUMN `ignore_condition` varchar(16) NOT NULL DEFAULT '' COMMENT 'count, users or time, empty unless the issue is ignored',
+  ADD COLUMN `ignore_threshold` int NOT NULL DEFAULT 0,
+  ADD COLUMN `ignored_at` datetime DEFAULT NULL,
+  ADD COLUMN `ignored_until` datetime DEFAULT NULL,
+  ADD COLUMN `ignore_events` int NOT NULL DEFAULT 0 COMMENT 'occurrences since the issue was ignored';
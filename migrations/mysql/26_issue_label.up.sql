@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `issue_label` (
+  `issue_id` BIGINT NOT NULL,
+  `team_id` int NOT NULL COMMENT 'team of the issue project, labels are scoped to it',
+  `name` varchar(32) NOT NULL,
+  `user_id` int NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`issue_id`, `name`),
+  KEY `idx_il_team_id_name` (`team_id`, `name`)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS `issue_seen` (
+  `issue_id` BIGINT NOT NULL,
+  `user_id` int NOT NULL,
+  `seen_at` DATETIME NOT NULL COMMENT 'last time the user viewed the issue',
+  PRIMARY KEY (`issue_id`, `user_id`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `ingest_filters` json NULL COMMENT 'environment and release patterns events are dropped by at ingest';
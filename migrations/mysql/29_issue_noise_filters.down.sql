@@ -0,0 +1,2 @@
+ALTER TABLE `issue`
+  DROP COLUMN `noise_filters`;
@@ -0,0 +1,2 @@
+ALTER TABLE `issue`
+  ADD COLUMN `noise_filters` json NULL COMMENT 'tag combinations events of the issue are dropped by at ingest';
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `sample_rate` double NOT NULL DEFAULT 1;
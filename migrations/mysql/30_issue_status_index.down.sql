@@ -0,0 +1,2 @@
+ALTER TABLE `issue`
+  DROP KEY `idx_status_ignore_condition`;
@@ -0,0 +1,2 @@
+ALTER TABLE `issue`
+  ADD KEY `idx_status_ignore_condition` (`status`, `ignore_condition`);
@@ -0,0 +1,9 @@
+ALTER TABLE `issue_label`
+  DROP KEY `idx_il_name`,
+  ADD COLUMN `team_id` int NOT NULL DEFAULT 0 COMMENT 'team of the issue project, labels are scoped to it';
+UPDATE `issue_label` AS l
+  INNER JOIN `issue` AS i ON i.id = l.issue_id
+  INNER JOIN `project` AS p ON p.id = i.project_id
+  SET l.team_id = p.team_id;
+ALTER TABLE `issue_label`
+  ADD KEY `idx_il_team_id_name` (`team_id`, `name`);
@@ -0,0 +1,4 @@
+ALTER TABLE `issue_label`
+  DROP KEY `idx_il_team_id_name`,
+  DROP COLUMN `team_id`,
+  ADD KEY `idx_il_name` (`name`);
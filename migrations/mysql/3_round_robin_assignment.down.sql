@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `team_assignment_rotation`;
+
+ALTER TABLE `team_relation`
+  DROP COLUMN `active`;
@@ -0,0 +1,8 @@
+ALTER TABLE `team_relation`
+  ADD COLUMN `active` tinyint(1) NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS `team_assignment_rotation` (
+  `team_id` int NOT NULL PRIMARY KEY,
+  `last_user_id` BIGINT NOT NULL,
+  `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `webhook_dead_letter` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_at` DATETIME NOT NULL,
+  `channel_id` int NOT NULL,
+  `url` varchar(2048) NOT NULL,
+  `payload` TEXT NOT NULL,
+  `attempts` int NOT NULL,
+  `last_error` TEXT NOT NULL,
+  KEY `idx_wdl_channel_id_created_at` (`channel_id`, `created_at`)
+);
@@ -0,0 +1,4 @@
+DELETE FROM `alert` WHERE `cond` IN (3, 4);
+
+ALTER TABLE `alert`
+  MODIFY COLUMN `cond` tinyint NOT NULL COMMENT '1=occurrences, 2=users affected';
@@ -0,0 +1,2 @@
+ALTER TABLE `alert`
+  MODIFY COLUMN `cond` tinyint NOT NULL COMMENT '1=occurrences, 2=users affected, 3=new issue, 4=reoccurred after resolved';
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `grouping_strategy` varchar(32) NOT NULL DEFAULT 'message';
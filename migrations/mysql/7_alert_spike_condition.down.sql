@@ -0,0 +1,6 @@
+DELETE FROM `alert` WHERE `cond` = 5;
+
+ALTER TABLE `alert`
+  DROP COLUMN `spike_multiplier`,
+  DROP COLUMN `baseline_windows`,
+  MODIFY COLUMN `cond` tinyint NOT NULL COMMENT '1=occurrences, 2=users affected, 3=new issue, 4=reoccurred after resolved';
@@ -0,0 +1,4 @@
+ALTER TABLE `alert`
+  MODIFY COLUMN `cond` tinyint NOT NULL COMMENT '1=occurrences, 2=users affected, 3=new issue, 4=reoccurred after resolved, 5=spike',
+  ADD COLUMN `baseline_windows` int NOT NULL DEFAULT 6,
+  ADD COLUMN `spike_multiplier` double NOT NULL DEFAULT 3;
@@ -0,0 +1,2 @@
+ALTER TABLE `project`
+  ADD COLUMN `priority_rules` json NULL;
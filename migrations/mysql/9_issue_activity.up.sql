@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `issue_activity` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `issue_id` BIGINT NOT NULL,
+  `actor_id` BIGINT DEFAULT NULL, -- NULL for automatic changes
+  `activity_type` varchar(32) NOT NULL COMMENT 'assigned, unassigned, status_changed, message_added',
+  `detail` varchar(255) NOT NULL DEFAULT '',
+  `created_at` DATETIME NOT NULL,
+  KEY `idx_ia_issue_id_created_at` (`issue_id`, `created_at`)
+);